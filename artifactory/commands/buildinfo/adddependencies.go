@@ -11,7 +11,9 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/checksumcache"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	coreioutils "github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
@@ -103,6 +105,7 @@ func (badc *BuildAddDependenciesCommand) SetBuildConfiguration(buildConfiguratio
 func collectDependenciesChecksums(dependenciesPaths map[string]string) (map[string]*fileutils.FileDetails, int) {
 	failures := 0
 	dependenciesDetails := make(map[string]*fileutils.FileDetails)
+	getFileDetails, saveChecksumCache := getFileDetailsFunc()
 	for _, dependencyPath := range dependenciesPaths {
 		var details *fileutils.FileDetails
 		var err error
@@ -111,7 +114,7 @@ func collectDependenciesChecksums(dependenciesPaths map[string]string) (map[stri
 			details, err = fspatterns.CreateSymlinkFileDetails()
 		} else {
 			log.Info("Adding dependency:", dependencyPath)
-			details, err = fileutils.GetFileDetails(dependencyPath, true)
+			details, err = getFileDetails(dependencyPath)
 		}
 		if err != nil {
 			log.Error(err)
@@ -120,9 +123,30 @@ func collectDependenciesChecksums(dependenciesPaths map[string]string) (map[stri
 		}
 		dependenciesDetails[dependencyPath] = details
 	}
+	if err := saveChecksumCache(); err != nil {
+		log.Warn("Failed to save the checksum cache:", err.Error())
+	}
 	return dependenciesDetails, failures
 }
 
+// Returns the function used to calculate the dependencies' checksums, and a function that persists the checksum cache.
+// The local checksum cache is used if enabled.
+func getFileDetailsFunc() (getFileDetails func(string) (*fileutils.FileDetails, error), save func() error) {
+	calculateFileDetails := func(path string) (*fileutils.FileDetails, error) {
+		return fileutils.GetFileDetails(coreioutils.ToLongPath(path), true)
+	}
+	noOp := func() error { return nil }
+	if !checksumcache.IsChecksumCacheEnabled() {
+		return calculateFileDetails, noOp
+	}
+	cache, err := checksumcache.LoadChecksumCache()
+	if err != nil {
+		log.Warn("Failed to load the checksum cache, the checksums will be calculated:", err.Error())
+		return calculateFileDetails, noOp
+	}
+	return cache.GetFileDetails, cache.Save
+}
+
 func (badc *BuildAddDependenciesCommand) collectLocalDependencies() (success, fail int, err error) {
 	var dependenciesDetails map[string]*fileutils.FileDetails
 	dependenciesPaths, errorOccurred := badc.doCollectLocalDependencies()
@@ -241,7 +265,7 @@ func getLocalDependencies(addDepsParams *specutils.CommonParams) ([]string, erro
 		return nil, err
 	}
 
-	isDir, err := fileutils.IsDirExists(rootPath, false)
+	isDir, err := fileutils.IsDirExists(coreioutils.ToLongPath(rootPath), false)
 	if err != nil {
 		return nil, err
 	}
@@ -264,14 +288,14 @@ func collectPatternMatchingFiles(addDepsParams *specutils.CommonParams, rootPath
 		return nil, err
 	}
 
-	paths, err := fspatterns.ListFiles(rootPath, addDepsParams.IsRecursive(), addDepsParams.IsIncludeDirs(), false, true, excludePathPattern)
+	paths, err := coreioutils.ListFiles(rootPath, addDepsParams.IsRecursive(), addDepsParams.IsIncludeDirs(), false, true, excludePathPattern)
 	if err != nil {
 		return nil, err
 	}
 	result := []string{}
 
 	for _, path := range paths {
-		matches, _, err := fspatterns.SearchPatterns(path, true, false, patternRegex)
+		matches, _, err := coreioutils.SearchPatterns(path, true, false, patternRegex)
 		if err != nil {
 			log.Error(err)
 			continue
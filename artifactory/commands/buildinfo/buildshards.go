@@ -0,0 +1,247 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	artclientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const shardFileExtension = ".json"
+
+var shardIdPattern = regexp.MustCompile(`^[\w.-]+$`)
+
+// BuildSaveShardCommand uploads the build-info collected locally to Artifactory as a shard of the build,
+// so that CI jobs running on different agents can collect the build-info of the same build.
+// The shards are merged into the build-info by the build publish command, when merging shards is enabled.
+type BuildSaveShardCommand struct {
+	buildConfiguration *build.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	shardId            string
+	// The repository to store the shards in. If empty, the repository is read from JFROG_CLI_BUILD_SHARDS_REPO.
+	shardsRepo string
+}
+
+func NewBuildSaveShardCommand() *BuildSaveShardCommand {
+	return &BuildSaveShardCommand{}
+}
+
+func (bsc *BuildSaveShardCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildSaveShardCommand {
+	bsc.buildConfiguration = buildConfiguration
+	return bsc
+}
+
+func (bsc *BuildSaveShardCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildSaveShardCommand {
+	bsc.serverDetails = serverDetails
+	return bsc
+}
+
+func (bsc *BuildSaveShardCommand) SetShardId(shardId string) *BuildSaveShardCommand {
+	bsc.shardId = shardId
+	return bsc
+}
+
+func (bsc *BuildSaveShardCommand) SetShardsRepo(shardsRepo string) *BuildSaveShardCommand {
+	bsc.shardsRepo = shardsRepo
+	return bsc
+}
+
+func (bsc *BuildSaveShardCommand) CommandName() string {
+	return "rt_build_save_shard"
+}
+
+func (bsc *BuildSaveShardCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bsc.serverDetails, nil
+}
+
+func (bsc *BuildSaveShardCommand) Run() (err error) {
+	if !shardIdPattern.MatchString(bsc.shardId) {
+		return errorutils.CheckErrorf("invalid shard ID '%s'. The shard ID may contain only letters, digits, '_', '-' and '.'", bsc.shardId)
+	}
+	shardsRepo, err := getShardsRepo(bsc.shardsRepo)
+	if err != nil {
+		return err
+	}
+	buildName, err := bsc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bsc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := bsc.buildConfiguration.GetProject()
+	localBuild, err := build.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, project)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	shard, err := localBuild.ToBuildInfo()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	content, err := json.Marshal(shard)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	tempDirPath, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDirPath))
+	}()
+	shardFilePath := filepath.Join(tempDirPath, bsc.shardId+shardFileExtension)
+	if err = os.WriteFile(shardFilePath, content, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	servicesManager, err := utils.CreateServiceManager(bsc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.CommonParams = &artclientutils.CommonParams{Pattern: shardFilePath, Target: getShardsPath(shardsRepo, buildName, buildNumber, project) + "/"}
+	uploadParams.Flat = true
+	_, totalFailed, err := servicesManager.UploadFiles(uploadParams)
+	if err != nil {
+		return err
+	}
+	if totalFailed > 0 {
+		return errorutils.CheckErrorf("failed uploading the shard '%s' of build %s/%s", bsc.shardId, buildName, buildNumber)
+	}
+	// The shard was saved in Artifactory, so it's removed locally to avoid publishing it twice.
+	if err = localBuild.Clean(); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Saved the shard '%s' of build %s/%s.", bsc.shardId, buildName, buildNumber))
+	return nil
+}
+
+func getShardsRepo(shardsRepo string) (string, error) {
+	if shardsRepo == "" {
+		shardsRepo = os.Getenv(coreutils.BuildShardsRepo)
+	}
+	if shardsRepo == "" {
+		return "", errorutils.CheckErrorf("the repository for storing the build-info shards must be provided, or set by the %s environment variable", coreutils.BuildShardsRepo)
+	}
+	return shardsRepo, nil
+}
+
+// Returns the path in Artifactory of the shards of the build, in the form of <repo>/[<project>/]<build-name>/<build-number>.
+func getShardsPath(shardsRepo, buildName, buildNumber, project string) string {
+	return path.Join(shardsRepo, project, buildName, buildNumber)
+}
+
+// Downloads the shards of the build saved in Artifactory, sorted by their IDs.
+func downloadBuildShards(servicesManager artifactory.ArtifactoryServicesManager, shardsPath string) (shards []*buildinfo.BuildInfo, err error) {
+	searchParams := services.NewSearchParams()
+	searchParams.CommonParams = &artclientutils.CommonParams{Pattern: shardsPath + "/*" + shardFileExtension}
+	searchParams.Recursive = false
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return nil, err
+	}
+	defer ioutils.Close(reader, &err)
+	var shardPaths []string
+	for item := new(artclientutils.ResultItem); reader.NextRecord(item) == nil; item = new(artclientutils.ResultItem) {
+		shardPaths = append(shardPaths, item.GetItemRelativePath())
+	}
+	if err = reader.GetError(); err != nil {
+		return nil, err
+	}
+	sort.Strings(shardPaths)
+	for _, shardPath := range shardPaths {
+		shard, err := readBuildShard(servicesManager, shardPath)
+		if err != nil {
+			return nil, err
+		}
+		shards = append(shards, shard)
+	}
+	return shards, nil
+}
+
+func readBuildShard(servicesManager artifactory.ArtifactoryServicesManager, shardPath string) (shard *buildinfo.BuildInfo, err error) {
+	log.Debug("Reading the build-info shard", shardPath)
+	body, err := servicesManager.ReadRemoteFile(shardPath)
+	if err != nil {
+		return nil, err
+	}
+	defer ioutils.Close(body, &err)
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	shard = new(buildinfo.BuildInfo)
+	if err = json.Unmarshal(content, shard); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the build-info shard %s: %s", shardPath, err.Error())
+	}
+	return shard, nil
+}
+
+// Deletes the shards of the build from Artifactory, after they were merged and published.
+func deleteBuildShards(servicesManager artifactory.ArtifactoryServicesManager, shardsPath string) (err error) {
+	deleteParams := services.NewDeleteParams()
+	deleteParams.CommonParams = &artclientutils.CommonParams{Pattern: shardsPath + "/"}
+	reader, err := servicesManager.GetPathsToDelete(deleteParams)
+	if err != nil {
+		return err
+	}
+	defer ioutils.Close(reader, &err)
+	_, err = servicesManager.DeleteFiles(reader)
+	return err
+}
+
+// Merges the shards into the build-info.
+// The modules are merged by their IDs, and the VCS details and properties are added if they don't already exist.
+// The start time of the build is the earliest start time of the build-info and the shards.
+func mergeBuildShards(buildInfo *buildinfo.BuildInfo, shards []*buildinfo.BuildInfo) {
+	startTime, _ := time.Parse(buildinfo.TimeFormat, buildInfo.Started)
+	for _, shard := range shards {
+		buildInfo.Append(shard)
+		for _, vcs := range shard.VcsList {
+			if !containsVcs(buildInfo.VcsList, vcs) {
+				buildInfo.VcsList = append(buildInfo.VcsList, vcs)
+			}
+		}
+		for key, value := range shard.Properties {
+			if buildInfo.Properties == nil {
+				buildInfo.Properties = map[string]string{}
+			}
+			if _, exists := buildInfo.Properties[key]; !exists {
+				buildInfo.Properties[key] = value
+			}
+		}
+		if shardStartTime, err := time.Parse(buildinfo.TimeFormat, shard.Started); err == nil && (startTime.IsZero() || shardStartTime.Before(startTime)) {
+			startTime = shardStartTime
+			buildInfo.Started = shard.Started
+		}
+	}
+}
+
+func containsVcs(vcsList []buildinfo.Vcs, vcs buildinfo.Vcs) bool {
+	for _, existing := range vcsList {
+		if existing.Url == vcs.Url && existing.Revision == vcs.Revision {
+			return true
+		}
+	}
+	return false
+}
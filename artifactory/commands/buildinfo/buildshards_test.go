@@ -0,0 +1,58 @@
+package buildinfo
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeBuildShards(t *testing.T) {
+	buildInfo := &buildinfo.BuildInfo{
+		Started:    "2024-05-01T10:05:00.000+0000",
+		Modules:    []buildinfo.Module{{Id: "app", Artifacts: []buildinfo.Artifact{{Name: "app.zip", Checksum: buildinfo.Checksum{Sha1: "1"}}}}},
+		VcsList:    []buildinfo.Vcs{{Url: "https://github.com/acme/app.git", Revision: "abc"}},
+		Properties: map[string]string{"buildInfo.env.JOB": "publish"},
+	}
+	shards := []*buildinfo.BuildInfo{
+		{
+			Started:    "2024-05-01T10:00:00.000+0000",
+			Modules:    []buildinfo.Module{{Id: "app", Artifacts: []buildinfo.Artifact{{Name: "app-linux.zip", Checksum: buildinfo.Checksum{Sha1: "2"}}}}, {Id: "lib"}},
+			VcsList:    []buildinfo.Vcs{{Url: "https://github.com/acme/app.git", Revision: "abc"}},
+			Properties: map[string]string{"buildInfo.env.JOB": "linux", "buildInfo.env.OS": "linux"},
+		},
+		{
+			Started: "2024-05-01T10:02:00.000+0000",
+			Modules: []buildinfo.Module{{Id: "docs"}},
+			VcsList: []buildinfo.Vcs{{Url: "https://github.com/acme/docs.git", Revision: "def"}},
+		},
+	}
+	mergeBuildShards(buildInfo, shards)
+
+	assert.Equal(t, "2024-05-01T10:00:00.000+0000", buildInfo.Started)
+	assert.Len(t, buildInfo.Modules, 3)
+	assert.ElementsMatch(t, []buildinfo.Artifact{{Name: "app.zip", Checksum: buildinfo.Checksum{Sha1: "1"}}, {Name: "app-linux.zip", Checksum: buildinfo.Checksum{Sha1: "2"}}}, buildInfo.Modules[0].Artifacts)
+	assert.Equal(t, []buildinfo.Vcs{{Url: "https://github.com/acme/app.git", Revision: "abc"}, {Url: "https://github.com/acme/docs.git", Revision: "def"}}, buildInfo.VcsList)
+	// The properties of the publishing job take precedence over the shards' properties.
+	assert.Equal(t, buildinfo.Env{"buildInfo.env.JOB": "publish", "buildInfo.env.OS": "linux"}, buildInfo.Properties)
+}
+
+func TestGetShardsPath(t *testing.T) {
+	assert.Equal(t, "shards-local/my-build/12", getShardsPath("shards-local", "my-build", "12", ""))
+	assert.Equal(t, "shards-local/proj/my-build/12", getShardsPath("shards-local", "my-build", "12", "proj"))
+}
+
+func TestGetShardsRepo(t *testing.T) {
+	t.Setenv(coreutils.BuildShardsRepo, "")
+	_, err := getShardsRepo("")
+	assert.ErrorContains(t, err, coreutils.BuildShardsRepo)
+
+	t.Setenv(coreutils.BuildShardsRepo, "env-shards")
+	repo, err := getShardsRepo("")
+	assert.NoError(t, err)
+	assert.Equal(t, "env-shards", repo)
+	repo, err = getShardsRepo("shards-local")
+	assert.NoError(t, err)
+	assert.Equal(t, "shards-local", repo)
+}
@@ -0,0 +1,343 @@
+package buildinfo
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+type EnrichedItemKind string
+
+const (
+	EnrichedArtifact   EnrichedItemKind = "artifact"
+	EnrichedDependency EnrichedItemKind = "dependency"
+)
+
+// EnrichedItem is an artifact or a dependency of the build, passed to the properties enrichers.
+type EnrichedItem struct {
+	Kind   EnrichedItemKind
+	Module string
+	// The name of the artifact, or the ID of the dependency.
+	Name string
+	Type string
+	// The path of the artifact. Dependencies have no path.
+	Path string
+	buildinfo.Checksum
+}
+
+// PropertiesEnricher computes properties to set on the artifacts and dependencies of a build before it's published.
+// Enrichers allow organizations to standardize the metadata of their builds, such as the owning team or the cost center.
+type PropertiesEnricher interface {
+	// Returns the properties to set on the item. Returning no properties leaves the item unchanged.
+	Enrich(buildInfo *buildinfo.BuildInfo, item EnrichedItem) (map[string]string, error)
+}
+
+var (
+	registeredEnrichers []PropertiesEnricher
+	enrichersMutex      sync.Mutex
+)
+
+// RegisterEnricher adds an enricher to run by every build publish command.
+func RegisterEnricher(enricher PropertiesEnricher) {
+	enrichersMutex.Lock()
+	defer enrichersMutex.Unlock()
+	registeredEnrichers = append(registeredEnrichers, enricher)
+}
+
+func getRegisteredEnrichers() []PropertiesEnricher {
+	enrichersMutex.Lock()
+	defer enrichersMutex.Unlock()
+	return append([]PropertiesEnricher{}, registeredEnrichers...)
+}
+
+// EnrichmentConfig is the YAML configuration of the properties added to the build's artifacts and dependencies. For example:
+//
+//	properties:
+//	  - key: team
+//	    value: payments
+//	  - key: cost-center
+//	    value: ${COST_CENTER}
+//	    applyTo: artifacts
+//	    include: "*.jar"
+//	  - key: git.last.author
+//	    command: git log -1 --format=%an -- {path}
+type EnrichmentConfig struct {
+	Properties []EnrichmentProperty `yaml:"properties"`
+}
+
+// EnrichmentProperty is a property computed for every artifact and dependency of the build.
+// The value and the command may contain environment variables, and the {name}, {path}, {type}, {module} and {sha1} placeholders of the item.
+type EnrichmentProperty struct {
+	Key string `yaml:"key"`
+	// A static value. Either the value or the command must be set.
+	Value string `yaml:"value,omitempty"`
+	// A command whose trimmed output is the value of the property. The command runs in the current directory, and isn't run by a shell.
+	Command string `yaml:"command,omitempty"`
+	// 'artifacts', 'dependencies' or 'all'. Defaults to 'all'.
+	ApplyTo string `yaml:"applyTo,omitempty"`
+	// A wildcard pattern matched against the name of the artifact or the ID of the dependency.
+	Include string `yaml:"include,omitempty"`
+}
+
+// LoadEnrichmentConfig reads the enrichment configuration from the provided YAML file.
+func LoadEnrichmentConfig(configPath string) (*EnrichmentConfig, error) {
+	fileContent, err := fileutils.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	enrichmentConfig := &EnrichmentConfig{}
+	if err = yaml.Unmarshal(fileContent, enrichmentConfig); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the enrichment configuration %s: %s", configPath, err.Error())
+	}
+	for _, property := range enrichmentConfig.Properties {
+		if property.Key == "" {
+			return nil, errorutils.CheckErrorf("the enrichment configuration %s contains a property without a key", configPath)
+		}
+		if (property.Value == "") == (property.Command == "") {
+			return nil, errorutils.CheckErrorf("the '%s' property in the enrichment configuration %s must have either a value or a command", property.Key, configPath)
+		}
+		switch property.ApplyTo {
+		case "", "all", "artifacts", "dependencies":
+		default:
+			return nil, errorutils.CheckErrorf("the '%s' property in the enrichment configuration %s has an unsupported applyTo value '%s'. Possible values are: all, artifacts, dependencies", property.Key, configPath, property.ApplyTo)
+		}
+	}
+	return enrichmentConfig, nil
+}
+
+// Enrich implements PropertiesEnricher.
+func (ec *EnrichmentConfig) Enrich(_ *buildinfo.BuildInfo, item EnrichedItem) (map[string]string, error) {
+	props := map[string]string{}
+	for _, property := range ec.Properties {
+		if !property.appliesTo(item) {
+			continue
+		}
+		if property.Value != "" {
+			props[property.Key] = expandPlaceholders(property.Value, item)
+			continue
+		}
+		value, err := runEnrichmentCommand(expandPlaceholders(property.Command, item))
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			props[property.Key] = value
+		}
+	}
+	return props, nil
+}
+
+func (ep *EnrichmentProperty) appliesTo(item EnrichedItem) bool {
+	if (ep.ApplyTo == "artifacts" && item.Kind != EnrichedArtifact) || (ep.ApplyTo == "dependencies" && item.Kind != EnrichedDependency) {
+		return false
+	}
+	if ep.Include == "" {
+		return true
+	}
+	match, err := path.Match(ep.Include, item.Name)
+	return err == nil && match
+}
+
+func expandPlaceholders(value string, item EnrichedItem) string {
+	value = strings.NewReplacer("{name}", item.Name, "{path}", item.Path, "{type}", item.Type, "{module}", item.Module, "{sha1}", item.Sha1).Replace(value)
+	return os.ExpandEnv(value)
+}
+
+func runEnrichmentCommand(command string) (string, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return "", nil
+	}
+	output, err := exec.Command(args[0], args[1:]...).Output()
+	if err != nil {
+		return "", errorutils.CheckErrorf("the enrichment command '%s' failed: %s", command, err.Error())
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Returns the properties computed by the enrichers for each sha1 of the build's artifacts and dependencies.
+// Items without a sha1 can't be located in Artifactory, and are skipped.
+func computeEnrichedProperties(buildInfo *buildinfo.BuildInfo, enrichers []PropertiesEnricher) (artifactsProps, dependenciesProps map[string]map[string]string, err error) {
+	artifactsProps, dependenciesProps = map[string]map[string]string{}, map[string]map[string]string{}
+	enrich := func(item EnrichedItem, result map[string]map[string]string) error {
+		if item.Sha1 == "" {
+			return nil
+		}
+		for _, enricher := range enrichers {
+			props, err := enricher.Enrich(buildInfo, item)
+			if err != nil {
+				return err
+			}
+			for key, value := range props {
+				if result[item.Sha1] == nil {
+					result[item.Sha1] = map[string]string{}
+				}
+				result[item.Sha1][key] = value
+			}
+		}
+		return nil
+	}
+	for _, module := range buildInfo.Modules {
+		for _, artifact := range module.Artifacts {
+			item := EnrichedItem{Kind: EnrichedArtifact, Module: module.Id, Name: artifact.Name, Type: artifact.Type, Path: artifact.Path, Checksum: artifact.Checksum}
+			if err = enrich(item, artifactsProps); err != nil {
+				return
+			}
+		}
+		for _, dependency := range module.Dependencies {
+			item := EnrichedItem{Kind: EnrichedDependency, Module: module.Id, Name: dependency.Id, Type: dependency.Type, Checksum: dependency.Checksum}
+			if err = enrich(item, dependenciesProps); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// buildEnrichment holds the properties computed by the enrichers for the build's artifacts and dependencies, by their sha1s.
+type buildEnrichment struct {
+	buildName         string
+	buildNumber       string
+	artifactsProps    map[string]map[string]string
+	dependenciesProps map[string]map[string]string
+}
+
+// Runs the enrichers on the build's artifacts and dependencies. Returns nil if there are no enrichers.
+func (bpc *BuildPublishCommand) computeEnrichment(buildInfo *buildinfo.BuildInfo) (*buildEnrichment, error) {
+	enrichers := append(getRegisteredEnrichers(), bpc.enrichers...)
+	if bpc.enrichmentConfigPath != "" {
+		enrichmentConfig, err := LoadEnrichmentConfig(bpc.enrichmentConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		enrichers = append(enrichers, enrichmentConfig)
+	}
+	if len(enrichers) == 0 {
+		return nil, nil
+	}
+	log.Info("Enriching the build artifacts and dependencies with properties...")
+	artifactsProps, dependenciesProps, err := computeEnrichedProperties(buildInfo, enrichers)
+	if err != nil {
+		return nil, err
+	}
+	return &buildEnrichment{buildName: buildInfo.Name, buildNumber: buildInfo.Number, artifactsProps: artifactsProps, dependenciesProps: dependenciesProps}, nil
+}
+
+// Sets the computed properties on the build's artifacts and dependencies in Artifactory.
+// The artifacts are located by their sha1 and build properties. The dependencies are located by their sha1 in the repositories
+// the resolver repository resolves from, since other repositories may contain the same files without serving the build.
+// Without a resolver repository, the properties aren't set on the dependencies.
+func (be *buildEnrichment) apply(servicesManager artifactory.ArtifactoryServicesManager, resolverRepo string, dryRun bool) error {
+	if be == nil {
+		return nil
+	}
+	if dryRun {
+		log.Info(fmt.Sprintf("[Dry run] Properties would be set on %d artifacts and %d dependencies.", len(be.artifactsProps), len(be.dependenciesProps)))
+		return nil
+	}
+	buildCriteria := fmt.Sprintf(`"@build.name":{"$eq":"%s"},"@build.number":{"$eq":"%s"},`, escapeAql(be.buildName), escapeAql(be.buildNumber))
+	if err := setEnrichedProperties(servicesManager, buildCriteria, be.artifactsProps); err != nil {
+		return err
+	}
+	if len(be.dependenciesProps) == 0 {
+		return nil
+	}
+	if resolverRepo == "" {
+		log.Warn("The dependencies of the build weren't enriched, since the repository they were resolved from is unknown.")
+		return nil
+	}
+	repositories, err := getRepositoriesConfigs(servicesManager)
+	if err != nil {
+		return err
+	}
+	if _, exists := repositories[resolverRepo]; !exists {
+		return errorutils.CheckErrorf("the resolver repository '%s' doesn't exist", resolverRepo)
+	}
+	return setEnrichedProperties(servicesManager, getReposCriteria(getResolutionOrder(resolverRepo, repositories)), be.dependenciesProps)
+}
+
+// Returns the AQL criteria matching items in any of the repositories.
+// The criteria is nested in $and, since the sha1s criteria is a top level $or as well.
+func getReposCriteria(repos []string) string {
+	repoCriteria := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		repoCriteria = append(repoCriteria, fmt.Sprintf(`{"repo":{"$eq":"%s"}}`, escapeAql(repo)))
+	}
+	return fmt.Sprintf(`"$and":[{"$or":[%s]}],`, strings.Join(repoCriteria, ","))
+}
+
+// Sets the properties on the items with the matching sha1s. Items with the same properties are updated together.
+func setEnrichedProperties(servicesManager artifactory.ArtifactoryServicesManager, criteria string, propsBySha1 map[string]map[string]string) error {
+	sha1sByProps := map[string][]string{}
+	for sha1, props := range propsBySha1 {
+		propsString := toPropsString(props)
+		sha1sByProps[propsString] = append(sha1sByProps[propsString], sha1)
+	}
+	for props, sha1s := range sha1sByProps {
+		items, err := searchBySha1s(servicesManager, criteria, sha1s)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			log.Warn("No items in Artifactory match the sha1s of the enriched build items.")
+			continue
+		}
+		success, err := setItemsProps(servicesManager, items, props)
+		if err != nil {
+			return err
+		}
+		if success != len(items) {
+			return errorutils.CheckErrorf("failed setting the enriched properties on %d out of %d items", len(items)-success, len(items))
+		}
+	}
+	return nil
+}
+
+func searchBySha1s(servicesManager artifactory.ArtifactoryServicesManager, criteria string, sha1s []string) (items []servicesutils.ResultItem, err error) {
+	sha1Criteria := make([]string, 0, len(sha1s))
+	for _, sha1 := range sha1s {
+		sha1Criteria = append(sha1Criteria, fmt.Sprintf(`{"actual_sha1":{"$eq":"%s"}}`, escapeAql(sha1)))
+	}
+	searchParams := services.NewSearchParams()
+	searchParams.CommonParams = &servicesutils.CommonParams{
+		Aql: servicesutils.Aql{ItemsFind: fmt.Sprintf(`{%s"type":"file","$or":[%s]}`, criteria, strings.Join(sha1Criteria, ","))},
+	}
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return nil, err
+	}
+	defer ioutils.Close(reader, &err)
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		items = append(items, *item)
+	}
+	return items, reader.GetError()
+}
+
+// Returns the properties in the format of the properties parameter - key1=value1;key2=value2, sorted by the keys.
+func toPropsString(props map[string]string) string {
+	escaper := strings.NewReplacer(";", "\\;", ",", "\\,")
+	pairs := make([]string, 0, len(props))
+	for key, value := range props {
+		pairs = append(pairs, key+"="+escaper.Replace(value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ";")
+}
+
+func escapeAql(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
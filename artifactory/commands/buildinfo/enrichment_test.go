@@ -0,0 +1,180 @@
+package buildinfo
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	biconf "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEnrichmentConfig = `properties:
+  - key: team
+    value: ${TEST_ENRICHMENT_TEAM}
+  - key: packaging
+    value: "{type}"
+    applyTo: artifacts
+    include: "*.jar"
+  - key: checksum
+    command: echo {sha1}
+    applyTo: dependencies
+`
+
+func createEnrichmentConfig(t *testing.T, content string) string {
+	configPath := filepath.Join(t.TempDir(), "enrichment.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+	return configPath
+}
+
+func createEnrichedBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{Name: "my-build", Number: "7", Modules: []buildinfo.Module{{
+		Id: "my-module",
+		Artifacts: []buildinfo.Artifact{
+			{Name: "app.jar", Type: "jar", Path: "org/app/app.jar", Checksum: buildinfo.Checksum{Sha1: "sha1-jar"}},
+			{Name: "app.pom", Type: "pom", Path: "org/app/app.pom", Checksum: buildinfo.Checksum{Sha1: "sha1-pom"}},
+			{Name: "no-checksum.txt"},
+		},
+		Dependencies: []buildinfo.Dependency{{Id: "org:lib:1.0", Type: "jar", Checksum: buildinfo.Checksum{Sha1: "sha1-lib"}}},
+	}}}
+}
+
+func TestLoadEnrichmentConfig(t *testing.T) {
+	t.Setenv("TEST_ENRICHMENT_TEAM", "payments")
+	enrichmentConfig, err := LoadEnrichmentConfig(createEnrichmentConfig(t, testEnrichmentConfig))
+	require.NoError(t, err)
+	artifactsProps, dependenciesProps, err := computeEnrichedProperties(createEnrichedBuildInfo(), []PropertiesEnricher{enrichmentConfig})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"sha1-jar": {"team": "payments", "packaging": "jar"},
+		"sha1-pom": {"team": "payments"},
+	}, artifactsProps)
+	assert.Equal(t, map[string]map[string]string{"sha1-lib": {"team": "payments", "checksum": "sha1-lib"}}, dependenciesProps)
+
+	_, err = LoadEnrichmentConfig(createEnrichmentConfig(t, "properties:\n  - key: team\n"))
+	assert.ErrorContains(t, err, "the 'team' property in the enrichment configuration")
+	_, err = LoadEnrichmentConfig(createEnrichmentConfig(t, "properties:\n  - key: team\n    value: a\n    applyTo: modules\n"))
+	assert.ErrorContains(t, err, "unsupported applyTo value 'modules'")
+}
+
+func TestToPropsString(t *testing.T) {
+	assert.Equal(t, "a=1;b=x\\,y\\;z", toPropsString(map[string]string{"b": "x,y;z", "a": "1"}))
+}
+
+// Simulates Artifactory, and records the AQL queries and the properties set on the items.
+func createEnrichmentServer(t *testing.T) (server *httptest.Server, queries *[]string, setProps map[string]string) {
+	var mutex sync.Mutex
+	queries = &[]string{}
+	setProps = map[string]string{}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		var response string
+		switch {
+		case r.URL.Path == "/api/system/version":
+			response = `{"version":"7.90.0"}`
+		case r.URL.Path == "/api/repositories":
+			response = `[{"key":"maven-remote","type":"REMOTE"},{"key":"maven-virtual","type":"VIRTUAL"},{"key":"libs-release","type":"LOCAL"}]`
+		case r.URL.Path == "/api/repositories/maven-virtual":
+			response = `{"key":"maven-virtual","rclass":"virtual","repositories":["maven-remote"]}`
+		case r.Method == http.MethodPost && r.URL.Path == "/api/search/aql":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			query := string(body)
+			*queries = append(*queries, query)
+			switch {
+			case strings.Contains(query, "sha1-jar"):
+				response = `{"results":[{"repo":"libs-release","path":"org/app","name":"app.jar","type":"file","actual_sha1":"sha1-jar"}]}`
+			case strings.Contains(query, "sha1-lib"):
+				response = `{"results":[{"repo":"maven-remote-cache","path":"org/lib","name":"lib-1.0.jar","type":"file","actual_sha1":"sha1-lib"}]}`
+			default:
+				response = `{"results":[]}`
+			}
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/storage/"):
+			setProps[strings.TrimPrefix(r.URL.Path, "/api/storage/")] = r.URL.Query().Get("properties")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case r.Method == http.MethodPut && r.URL.Path == "/api/build":
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	return
+}
+
+func createEnrichmentPublishCommand(t *testing.T, serverDetails *config.ServerDetails) *BuildPublishCommand {
+	return NewBuildPublishCommand().SetServerDetails(serverDetails).SetConfig(new(biconf.Configuration)).
+		SetEnrichmentConfigPath(createEnrichmentConfig(t, "properties:\n  - key: team\n    value: payments\n"))
+}
+
+func TestEnrichBuildInfo(t *testing.T) {
+	server, queries, setProps := createEnrichmentServer(t)
+	defer server.Close()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/"}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, 0, 0, false)
+	require.NoError(t, err)
+	publishCmd := createEnrichmentPublishCommand(t, serverDetails).SetDependencyResolverRepo("maven-virtual")
+	enrichment, err := publishCmd.computeEnrichment(createEnrichedBuildInfo())
+	require.NoError(t, err)
+	require.NoError(t, enrichment.apply(servicesManager, publishCmd.dependencyResolverRepo, false))
+
+	assert.Equal(t, map[string]string{
+		"libs-release/org/app/app.jar":           "team=payments",
+		"maven-remote-cache/org/lib/lib-1.0.jar": "team=payments",
+	}, setProps)
+	require.Len(t, *queries, 2)
+	// The artifacts are searched by their build properties, and the dependencies only in the repositories the resolver repository resolves from.
+	assert.Contains(t, (*queries)[0], `"@build.name":{"$eq":"my-build"},"@build.number":{"$eq":"7"}`)
+	assert.NotContains(t, (*queries)[1], "@build.name")
+	assert.Contains(t, (*queries)[1], `"$and":[{"$or":[{"repo":{"$eq":"maven-remote-cache"}}]}]`)
+
+	// Without a resolver repository, the properties are set only on the artifacts.
+	*queries = nil
+	require.NoError(t, enrichment.apply(servicesManager, "", false))
+	require.Len(t, *queries, 1)
+	assert.Contains(t, (*queries)[0], "sha1-jar")
+
+	// In dry run, the properties are computed but not set.
+	*queries = nil
+	require.NoError(t, enrichment.apply(servicesManager, publishCmd.dependencyResolverRepo, true))
+	assert.Empty(t, *queries)
+
+	// Without enrichers, there's nothing to apply.
+	enrichment, err = NewBuildPublishCommand().computeEnrichment(createEnrichedBuildInfo())
+	require.NoError(t, err)
+	assert.Nil(t, enrichment)
+	assert.NoError(t, enrichment.apply(servicesManager, "", false))
+}
+
+func TestEnrichBuildInfoMultipleServers(t *testing.T) {
+	firstServer, _, firstSetProps := createEnrichmentServer(t)
+	defer firstServer.Close()
+	secondServer, _, secondSetProps := createEnrichmentServer(t)
+	defer secondServer.Close()
+	serversDetails := []*config.ServerDetails{{ArtifactoryUrl: firstServer.URL + "/"}, {ArtifactoryUrl: secondServer.URL + "/"}}
+	publishCmd := createEnrichmentPublishCommand(t, serversDetails[0]).SetServersDetails(serversDetails).
+		SetBuildConfiguration(build.NewBuildConfiguration("my-build", "7", "", ""))
+	buildInfo := createEnrichedBuildInfo()
+	enrichment, err := publishCmd.computeEnrichment(buildInfo)
+	require.NoError(t, err)
+	require.NoError(t, publishCmd.publishToServers(buildInfo, enrichment))
+
+	// The properties are set on the artifacts in each of the servers.
+	assert.Equal(t, map[string]string{"libs-release/org/app/app.jar": "team=payments"}, firstSetProps)
+	assert.Equal(t, map[string]string{"libs-release/org/app/app.jar": "team=payments"}, secondSetProps)
+}
@@ -1,6 +1,8 @@
 package buildinfo
 
 import (
+	"strings"
+
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
@@ -12,6 +14,10 @@ type BuildPromotionCommand struct {
 	buildConfiguration *build.BuildConfiguration
 	serverDetails      *config.ServerDetails
 	dryRun             bool
+	stampProperties    string
+	verifyChecksums    bool
+	printReport        bool
+	report             *PromotionReport
 }
 
 func NewBuildPromotionCommand() *BuildPromotionCommand {
@@ -33,6 +39,29 @@ func (bpc *BuildPromotionCommand) SetPromotionParams(params services.PromotionPa
 	return bpc
 }
 
+// SetStampProperties sets properties to set on all the promoted artifacts, in the form of "key1=value1;key2=value2,value3".
+func (bpc *BuildPromotionCommand) SetStampProperties(stampProperties string) *BuildPromotionCommand {
+	bpc.stampProperties = stampProperties
+	return bpc
+}
+
+// SetVerifyChecksums sets whether to verify that the checksums of the artifacts at the target repository match their checksums before the promotion.
+func (bpc *BuildPromotionCommand) SetVerifyChecksums(verifyChecksums bool) *BuildPromotionCommand {
+	bpc.verifyChecksums = verifyChecksums
+	return bpc
+}
+
+// SetPrintReport sets whether to print a JSON report listing all the promoted artifacts.
+func (bpc *BuildPromotionCommand) SetPrintReport(printReport bool) *BuildPromotionCommand {
+	bpc.printReport = printReport
+	return bpc
+}
+
+// Report returns the promotion report. It is available only if checksums verification or a report were requested.
+func (bpc *BuildPromotionCommand) Report() *PromotionReport {
+	return bpc.report
+}
+
 func (bpc *BuildPromotionCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildPromotionCommand {
 	bpc.buildConfiguration = buildConfiguration
 	return bpc
@@ -55,7 +84,21 @@ func (bpc *BuildPromotionCommand) Run() error {
 		return err
 	}
 	bpc.BuildName, bpc.BuildNumber, bpc.ProjectKey = buildName, buildNumber, bpc.buildConfiguration.GetProject()
-	return servicesManager.PromoteBuild(bpc.PromotionParams)
+	if bpc.stampProperties != "" {
+		// The stamped properties are set on the promoted artifacts by the promotion, along with the other promotion properties.
+		bpc.Properties = strings.TrimPrefix(bpc.Properties+";"+bpc.stampProperties, ";")
+	}
+	if !bpc.shouldHandlePromotedArtifacts() {
+		return servicesManager.PromoteBuild(bpc.PromotionParams)
+	}
+	sourceItems, err := bpc.getBuildItems(servicesManager)
+	if err != nil {
+		return err
+	}
+	if err = servicesManager.PromoteBuild(bpc.PromotionParams); err != nil {
+		return err
+	}
+	return bpc.handlePromotedArtifacts(servicesManager, sourceItems)
 }
 
 func (bpc *BuildPromotionCommand) ServerDetails() (*config.ServerDetails, error) {
@@ -0,0 +1,96 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const buildItemsAqlResponse = `{"results":[
+	{"repo":"dev-local","path":"org/app/1.0","name":"app-1.0.jar","type":"file","actual_sha1":"sha1-app","sha256":"sha256-app"},
+	{"repo":"dev-local","path":"org/app/1.0","name":"app-1.0.pom","type":"file","actual_sha1":"sha1-pom","sha256":"sha256-pom"}
+]}`
+
+func createPromotionServer(t *testing.T, promotedPomSha1 string) (*httptest.Server, *[]string, *map[string][]string) {
+	var requests []string
+	var promotionProperties map[string][]string
+	var mutex sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/api/system/version":
+			_, err := w.Write([]byte(`{"version":"7.90.0"}`))
+			assert.NoError(t, err)
+		case r.URL.Path == "/api/build/app/1":
+			_, err := w.Write([]byte(`{"buildInfo":{"name":"app","number":"1","modules":[{"id":"org:app:1.0","artifacts":[{"name":"app-1.0.jar","sha1":"sha1-app"},{"name":"app-1.0.pom","sha1":"sha1-pom"}]}]}}`))
+			assert.NoError(t, err)
+		case r.URL.Path == "/api/search/aql":
+			_, err := w.Write([]byte(buildItemsAqlResponse))
+			assert.NoError(t, err)
+		case r.URL.Path == "/api/build/promote/app/1":
+			var body services.BuildPromotionBody
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "prod-local", body.TargetRepo)
+			promotionProperties = body.Properties
+		case r.URL.Path == "/api/storage/prod-local/org/app/1.0/app-1.0.jar":
+			_, err := w.Write([]byte(`{"checksums":{"sha1":"sha1-app","sha256":"sha256-app"}}`))
+			assert.NoError(t, err)
+		case r.URL.Path == "/api/storage/prod-local/org/app/1.0/app-1.0.pom":
+			_, err := w.Write([]byte(`{"checksums":{"sha1":"` + promotedPomSha1 + `"}}`))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, &requests, &promotionProperties
+}
+
+func createPromotionCommand(serverUrl string) *BuildPromotionCommand {
+	return NewBuildPromotionCommand().
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: serverUrl + "/"}).
+		SetBuildConfiguration(build.NewBuildConfiguration("app", "1", "", "")).
+		SetPromotionParams(services.PromotionParams{TargetRepo: "prod-local", Copy: true})
+}
+
+func TestBuildPromotionWithStampingAndVerification(t *testing.T) {
+	server, requests, promotionProperties := createPromotionServer(t, "sha1-pom")
+	defer server.Close()
+	promotionCmd := createPromotionCommand(server.URL).SetStampProperties("release=ga").SetVerifyChecksums(true)
+	promotionCmd.Properties = "stage=prod"
+	require.NoError(t, promotionCmd.Run())
+
+	// The properties are stamped by the promotion, without setting them on each artifact.
+	assert.Equal(t, map[string][]string{"stage": {"prod"}, "release": {"ga"}}, *promotionProperties)
+	for _, request := range *requests {
+		assert.NotContains(t, request, "PUT /api/storage/")
+	}
+	report := promotionCmd.Report()
+	require.NotNil(t, report)
+	assert.Equal(t, "copy", report.Action)
+	assert.Equal(t, "release=ga", report.StampedProperties)
+	assert.Equal(t, []PromotedArtifact{
+		{SourcePath: "dev-local/org/app/1.0/app-1.0.jar", TargetPath: "prod-local/org/app/1.0/app-1.0.jar", Sha1: "sha1-app", Sha256: "sha256-app", Status: ArtifactVerified},
+		{SourcePath: "dev-local/org/app/1.0/app-1.0.pom", TargetPath: "prod-local/org/app/1.0/app-1.0.pom", Sha1: "sha1-pom", Sha256: "sha256-pom", Status: ArtifactVerified},
+	}, report.Artifacts)
+}
+
+func TestBuildPromotionChecksumMismatch(t *testing.T) {
+	server, _, _ := createPromotionServer(t, "other-sha1")
+	defer server.Close()
+	promotionCmd := createPromotionCommand(server.URL).SetVerifyChecksums(true).SetPrintReport(true)
+	assert.EqualError(t, promotionCmd.Run(), "the checksum verification of 1 out of 2 promoted artifacts failed")
+	report := promotionCmd.Report()
+	assert.Equal(t, ArtifactVerified, report.Artifacts[0].Status)
+	assert.Equal(t, ArtifactChecksumMismatch, report.Artifacts[1].Status)
+	assert.Empty(t, report.StampedProperties)
+}
@@ -0,0 +1,199 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type PromotedArtifactStatus string
+
+const (
+	// The promotion ran in dry run mode, and the artifact would have been promoted.
+	ArtifactDryRun PromotedArtifactStatus = "dry-run"
+	// The artifact was promoted, and its checksums weren't verified.
+	ArtifactPromoted PromotedArtifactStatus = "promoted"
+	// The artifact was promoted, and its checksums at the target repository match its checksums before the promotion.
+	ArtifactVerified         PromotedArtifactStatus = "verified"
+	ArtifactMissing          PromotedArtifactStatus = "missing"
+	ArtifactChecksumMismatch PromotedArtifactStatus = "checksum-mismatch"
+)
+
+// PromotionReport lists the artifacts promoted by the build promotion.
+type PromotionReport struct {
+	BuildName   string `json:"buildName"`
+	BuildNumber string `json:"buildNumber"`
+	Project     string `json:"project,omitempty"`
+	SourceRepo  string `json:"sourceRepo,omitempty"`
+	TargetRepo  string `json:"targetRepo,omitempty"`
+	// 'copy' or 'move', or 'none' if the promotion only changes the status of the build.
+	Action string `json:"action"`
+	DryRun bool   `json:"dryRun,omitempty"`
+	// The properties stamped on all the promoted artifacts.
+	StampedProperties string             `json:"stampedProperties,omitempty"`
+	Artifacts         []PromotedArtifact `json:"artifacts"`
+}
+
+type PromotedArtifact struct {
+	SourcePath string                 `json:"sourcePath"`
+	TargetPath string                 `json:"targetPath"`
+	Sha1       string                 `json:"sha1,omitempty"`
+	Sha256     string                 `json:"sha256,omitempty"`
+	Status     PromotedArtifactStatus `json:"status"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+func (bpc *BuildPromotionCommand) shouldHandlePromotedArtifacts() bool {
+	return bpc.verifyChecksums || bpc.printReport
+}
+
+// Returns the artifacts of the build, and its dependencies if they are promoted as well.
+// The artifacts are searched before the promotion, since they may be moved by it.
+func (bpc *BuildPromotionCommand) getBuildItems(servicesManager artifactory.ArtifactoryServicesManager) (items []servicesutils.ResultItem, err error) {
+	searchParams := services.NewSearchParams()
+	searchParams.CommonParams = &servicesutils.CommonParams{
+		Pattern:     "*",
+		Build:       strings.ReplaceAll(bpc.BuildName, "/", "\\/") + "/" + bpc.BuildNumber,
+		Project:     bpc.ProjectKey,
+		Recursive:   true,
+		IncludeDeps: bpc.IncludeDependencies,
+	}
+	if bpc.SourceRepo != "" {
+		searchParams.Pattern = bpc.SourceRepo + "/*"
+	}
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return nil, err
+	}
+	defer ioutils.Close(reader, &err)
+	paths := map[string]bool{}
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		if item.Type == "folder" || paths[item.GetItemRelativePath()] {
+			continue
+		}
+		paths[item.GetItemRelativePath()] = true
+		items = append(items, *item)
+	}
+	return items, reader.GetError()
+}
+
+// Verifies the checksums of the promoted artifacts and prints the promotion report, as requested.
+func (bpc *BuildPromotionCommand) handlePromotedArtifacts(servicesManager artifactory.ArtifactoryServicesManager, sourceItems []servicesutils.ResultItem) (err error) {
+	bpc.report = bpc.createReport(sourceItems)
+	if !bpc.dryRun {
+		// The properties are stamped by the promotion itself.
+		bpc.report.StampedProperties = bpc.stampProperties
+	}
+	failures := 0
+	if !bpc.dryRun && bpc.verifyChecksums {
+		log.Info("Verifying the checksums of the promoted artifacts...")
+		for i := range bpc.report.Artifacts {
+			if !verifyPromotedArtifact(servicesManager, &bpc.report.Artifacts[i]) {
+				failures++
+			}
+		}
+	}
+	if bpc.printReport {
+		reportContent, err := json.Marshal(bpc.report)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(reportContent))
+	}
+	if failures > 0 {
+		return errorutils.CheckErrorf("the checksum verification of %d out of %d promoted artifacts failed", failures, len(bpc.report.Artifacts))
+	}
+	return nil
+}
+
+func (bpc *BuildPromotionCommand) createReport(sourceItems []servicesutils.ResultItem) *PromotionReport {
+	report := &PromotionReport{
+		BuildName:   bpc.BuildName,
+		BuildNumber: bpc.BuildNumber,
+		Project:     bpc.ProjectKey,
+		SourceRepo:  bpc.SourceRepo,
+		TargetRepo:  bpc.TargetRepo,
+		Action:      "move",
+		DryRun:      bpc.dryRun,
+		Artifacts:   []PromotedArtifact{},
+	}
+	if bpc.Copy {
+		report.Action = "copy"
+	}
+	if bpc.TargetRepo == "" {
+		report.Action = "none"
+	}
+	status := ArtifactPromoted
+	if bpc.dryRun {
+		status = ArtifactDryRun
+	}
+	targetItems := bpc.getTargetItems(sourceItems)
+	for i := range sourceItems {
+		report.Artifacts = append(report.Artifacts, PromotedArtifact{
+			SourcePath: sourceItems[i].GetItemRelativePath(),
+			TargetPath: targetItems[i].GetItemRelativePath(),
+			Sha1:       sourceItems[i].Actual_Sha1,
+			Sha256:     sourceItems[i].Sha256,
+			Status:     status,
+		})
+	}
+	return report
+}
+
+// Returns the items at the target repository. The path of each artifact in the target repository is the same as in the source repository.
+func (bpc *BuildPromotionCommand) getTargetItems(sourceItems []servicesutils.ResultItem) []servicesutils.ResultItem {
+	targetItems := make([]servicesutils.ResultItem, 0, len(sourceItems))
+	for _, item := range sourceItems {
+		if bpc.TargetRepo != "" {
+			item.Repo = bpc.TargetRepo
+		}
+		targetItems = append(targetItems, item)
+	}
+	return targetItems
+}
+
+// Sets the properties on the items, and returns the number of items updated successfully.
+func setItemsProps(servicesManager artifactory.ArtifactoryServicesManager, items []servicesutils.ResultItem, props string) (success int, err error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return 0, err
+	}
+	for _, item := range items {
+		writer.Write(item)
+	}
+	if err = writer.Close(); err != nil {
+		return 0, err
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer ioutils.Close(reader, &err)
+	return servicesManager.SetProps(services.PropsParams{Reader: reader, Props: props})
+}
+
+// Compares the checksums of the artifact at the target repository with its checksums before the promotion.
+// Returns false if the artifact is missing or its checksums don't match.
+func verifyPromotedArtifact(servicesManager artifactory.ArtifactoryServicesManager, artifact *PromotedArtifact) bool {
+	fileInfo, err := servicesManager.FileInfo(path.Clean(artifact.TargetPath))
+	if err != nil {
+		artifact.Status, artifact.Error = ArtifactMissing, err.Error()
+		log.Error(fmt.Sprintf("Couldn't verify the promoted artifact '%s': %s", artifact.TargetPath, err.Error()))
+		return false
+	}
+	if (artifact.Sha1 != "" && fileInfo.Checksums.Sha1 != artifact.Sha1) || (artifact.Sha256 != "" && fileInfo.Checksums.Sha256 != "" && fileInfo.Checksums.Sha256 != artifact.Sha256) {
+		artifact.Status = ArtifactChecksumMismatch
+		artifact.Error = fmt.Sprintf("the checksums at the target repository (sha1: %s, sha256: %s) don't match the checksums before the promotion", fileInfo.Checksums.Sha1, fileInfo.Checksums.Sha256)
+		log.Error(fmt.Sprintf("The checksums of the promoted artifact '%s' don't match the checksums of '%s'", artifact.TargetPath, artifact.SourcePath))
+		return false
+	}
+	artifact.Status = ArtifactVerified
+	return true
+}
@@ -0,0 +1,318 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The module properties recording the provenance of a dependency, with the ID of the dependency in place of the %s.
+	DependencyRepositoryProperty         = "dependency.%s.repository"
+	DependencyRepositoryTypeProperty     = "dependency.%s.repository.type"
+	DependencyRepositoryVirtualsProperty = "dependency.%s.repository.virtuals"
+	// Set to true if the repository was guessed from the download statistics, rather than found in the resolver repository.
+	DependencyRepositoryHeuristicProperty = "dependency.%s.repository.heuristic"
+
+	remoteCacheSuffix = "-cache"
+	// The number of sha1s searched by a single AQL query.
+	provenanceSearchBatchSize = 100
+)
+
+// The types of the modules whose dependencies are resolved from Artifactory, and have their provenance recorded.
+var provenanceModuleTypes = []buildinfo.ModuleType{buildinfo.Npm, buildinfo.Maven, buildinfo.Gradle, buildinfo.Go}
+
+// DependencyProvenance is the Artifactory repository which served a dependency of the build.
+type DependencyProvenance struct {
+	// The repository which contains the dependency. A dependency cached by a remote repository is attributed to the remote repository.
+	Repository string
+	// The class of the repository - local, remote or federated.
+	RepositoryType string
+	// The virtual repositories which include the repository, directly or through other virtual repositories.
+	VirtualRepositories []string
+	// True if the resolver repository is unknown, and the repository is only the one with the most recent download of the dependency
+	// on the instance. Such a repository didn't necessarily serve the build, and shouldn't be relied on as supply-chain evidence.
+	Heuristic bool
+}
+
+type repositoryConfig struct {
+	Key          string   `json:"key"`
+	Rclass       string   `json:"rclass"`
+	Repositories []string `json:"repositories"`
+}
+
+// Records the repository which served each dependency of the npm, Maven, Gradle and Go modules, as properties of the module.
+// The dependencies are located by their sha1. If the resolver repository is given, they're searched only in it and in the repositories
+// it resolves from, and the first of them in the resolution order is considered to have served the dependency. Otherwise, the
+// dependencies are searched in all the repositories, and the one from which a dependency was most recently downloaded is recorded
+// as a heuristic. Dependencies which aren't found in Artifactory are left without provenance.
+func recordDependencyProvenance(servicesManager artifactory.ArtifactoryServicesManager, buildInfo *buildinfo.BuildInfo, resolverRepo string) error {
+	var sha1s []string
+	for _, module := range buildInfo.Modules {
+		if !isProvenanceModule(module) {
+			continue
+		}
+		for _, dependency := range module.Dependencies {
+			if dependency.Sha1 != "" {
+				sha1s = append(sha1s, dependency.Sha1)
+			}
+		}
+	}
+	if len(sha1s) == 0 {
+		return nil
+	}
+	log.Info(fmt.Sprintf("Recording the repositories which served %d dependencies...", len(sha1s)))
+	repositories, err := getRepositoriesConfigs(servicesManager)
+	if err != nil {
+		return err
+	}
+	var resolutionOrder []string
+	if resolverRepo != "" {
+		if _, exists := repositories[resolverRepo]; !exists {
+			return errorutils.CheckErrorf("the resolver repository '%s' doesn't exist", resolverRepo)
+		}
+		resolutionOrder = getResolutionOrder(resolverRepo, repositories)
+	}
+	servingItems, err := searchServingItems(servicesManager, sha1s, resolutionOrder)
+	if err != nil {
+		return err
+	}
+	recorded := 0
+	for i := range buildInfo.Modules {
+		module := &buildInfo.Modules[i]
+		if !isProvenanceModule(*module) {
+			continue
+		}
+		for _, dependency := range module.Dependencies {
+			item, found := servingItems[dependency.Sha1]
+			if !found {
+				continue
+			}
+			provenance := getDependencyProvenance(item.Repo, repositories)
+			provenance.Heuristic = resolverRepo == ""
+			if err = setModuleProperties(module, getProvenanceProperties(dependency.Id, provenance)); err != nil {
+				return err
+			}
+			recorded++
+		}
+	}
+	log.Info(fmt.Sprintf("Recorded the provenance of %d dependencies.", recorded))
+	return nil
+}
+
+func isProvenanceModule(module buildinfo.Module) bool {
+	for _, moduleType := range provenanceModuleTypes {
+		if module.Type == moduleType {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the repositories from which the repository resolves artifacts, in their resolution order.
+// The members of virtual repositories are expanded recursively, and remote repositories are replaced by their caches.
+func getResolutionOrder(repo string, repositories map[string]*repositoryConfig) []string {
+	var order []string
+	visited := map[string]bool{}
+	var expand func(string)
+	expand = func(key string) {
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+		repository, exists := repositories[key]
+		switch {
+		case !exists:
+			return
+		case repository.Rclass == "virtual":
+			for _, member := range repository.Repositories {
+				expand(member)
+			}
+		case repository.Rclass == "remote":
+			order = append(order, key+remoteCacheSuffix)
+		default:
+			order = append(order, key)
+		}
+	}
+	expand(repo)
+	return order
+}
+
+// Returns the item which served each of the sha1s, by the sha1s. If the resolution order is given, only its repositories are searched,
+// and the item in the earliest of them is returned. Otherwise, the most recently downloaded item is returned.
+func searchServingItems(servicesManager artifactory.ArtifactoryServicesManager, sha1s, resolutionOrder []string) (map[string]servicesutils.ResultItem, error) {
+	precedence := map[string]int{}
+	for i, repo := range resolutionOrder {
+		precedence[repo] = i
+	}
+	servingItems := map[string]servicesutils.ResultItem{}
+	for start := 0; start < len(sha1s); start += provenanceSearchBatchSize {
+		end := start + provenanceSearchBatchSize
+		if end > len(sha1s) {
+			end = len(sha1s)
+		}
+		items, err := searchItemsWithStats(servicesManager, sha1s[start:end], resolutionOrder)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			current, exists := servingItems[item.Actual_Sha1]
+			if len(resolutionOrder) == 0 {
+				if !exists || isServedLater(item, current) {
+					servingItems[item.Actual_Sha1] = item
+				}
+				continue
+			}
+			if itemPrecedence, inOrder := precedence[item.Repo]; inOrder && (!exists || itemPrecedence < precedence[current.Repo]) {
+				servingItems[item.Actual_Sha1] = item
+			}
+		}
+	}
+	return servingItems, nil
+}
+
+// Searches the items with the sha1s. If repos are given, only they are searched.
+func searchItemsWithStats(servicesManager artifactory.ArtifactoryServicesManager, sha1s, repos []string) (items []servicesutils.ResultItem, err error) {
+	sha1Criteria := make([]string, 0, len(sha1s))
+	for _, sha1 := range sha1s {
+		sha1Criteria = append(sha1Criteria, fmt.Sprintf(`{"actual_sha1":{"$eq":"%s"}}`, escapeAql(sha1)))
+	}
+	criteria := fmt.Sprintf(`"$or":[%s]`, strings.Join(sha1Criteria, ","))
+	if len(repos) > 0 {
+		repoCriteria := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			repoCriteria = append(repoCriteria, fmt.Sprintf(`{"repo":{"$eq":"%s"}}`, escapeAql(repo)))
+		}
+		criteria = fmt.Sprintf(`"$and":[{%s},{"$or":[%s]}]`, criteria, strings.Join(repoCriteria, ","))
+	}
+	query := fmt.Sprintf(`items.find({"type":"file",%s}).include("repo","path","name","actual_sha1","stat.downloaded")`, criteria)
+	stream, err := servicesManager.Aql(query)
+	if err != nil {
+		return nil, err
+	}
+	defer ioutils.Close(stream, &err)
+	content, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	result := new(servicesutils.AqlSearchResult)
+	if err = json.Unmarshal(content, result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return result.Results, nil
+}
+
+// Returns true if the item was downloaded after the other item. Items which were never downloaded are considered the earliest,
+// and ties are broken by the repository name, to keep the result stable.
+func isServedLater(item, other servicesutils.ResultItem) bool {
+	itemTime, otherTime := getLastDownloaded(item), getLastDownloaded(other)
+	if !itemTime.Equal(otherTime) {
+		return itemTime.After(otherTime)
+	}
+	return item.Repo < other.Repo
+}
+
+func getLastDownloaded(item servicesutils.ResultItem) time.Time {
+	if len(item.Stats) == 0 {
+		return time.Time{}
+	}
+	downloaded, err := time.Parse(time.RFC3339, item.Stats[0].Downloaded)
+	if err != nil {
+		return time.Time{}
+	}
+	return downloaded
+}
+
+// Returns the configurations of all the repositories, by their keys. The members of the virtual repositories are read from their configurations.
+func getRepositoriesConfigs(servicesManager artifactory.ArtifactoryServicesManager) (map[string]*repositoryConfig, error) {
+	allRepositories, err := servicesManager.GetAllRepositories()
+	if err != nil {
+		return nil, err
+	}
+	repositories := map[string]*repositoryConfig{}
+	for _, details := range *allRepositories {
+		repository := &repositoryConfig{Key: details.Key, Rclass: strings.ToLower(details.GetRepoType())}
+		if repository.Rclass == "virtual" {
+			if err = servicesManager.GetRepository(details.Key, repository); err != nil {
+				return nil, err
+			}
+		}
+		repositories[details.Key] = repository
+	}
+	return repositories, nil
+}
+
+func getDependencyProvenance(repo string, repositories map[string]*repositoryConfig) DependencyProvenance {
+	provenance := DependencyProvenance{Repository: repo}
+	if remoteRepo, isCache := strings.CutSuffix(repo, remoteCacheSuffix); isCache {
+		if repository, exists := repositories[remoteRepo]; exists && repository.Rclass == "remote" {
+			provenance.Repository = remoteRepo
+		}
+	}
+	if repository, exists := repositories[provenance.Repository]; exists {
+		provenance.RepositoryType = repository.Rclass
+	}
+	// Collect the virtual repositories which include the repository, and the virtual repositories which include them.
+	included := map[string]bool{provenance.Repository: true}
+	for added := true; added; {
+		added = false
+		for _, repository := range repositories {
+			if repository.Rclass != "virtual" || included[repository.Key] {
+				continue
+			}
+			for _, member := range repository.Repositories {
+				if included[member] {
+					included[repository.Key] = true
+					provenance.VirtualRepositories = append(provenance.VirtualRepositories, repository.Key)
+					added = true
+					break
+				}
+			}
+		}
+	}
+	sort.Strings(provenance.VirtualRepositories)
+	return provenance
+}
+
+func getProvenanceProperties(dependencyId string, provenance DependencyProvenance) map[string]string {
+	props := map[string]string{fmt.Sprintf(DependencyRepositoryProperty, dependencyId): provenance.Repository}
+	if provenance.RepositoryType != "" {
+		props[fmt.Sprintf(DependencyRepositoryTypeProperty, dependencyId)] = provenance.RepositoryType
+	}
+	if len(provenance.VirtualRepositories) > 0 {
+		props[fmt.Sprintf(DependencyRepositoryVirtualsProperty, dependencyId)] = strings.Join(provenance.VirtualRepositories, ",")
+	}
+	if provenance.Heuristic {
+		props[fmt.Sprintf(DependencyRepositoryHeuristicProperty, dependencyId)] = "true"
+	}
+	return props
+}
+
+// Adds the properties to the properties of the module, which are a map of strings, or a map of any values when read from a partial build-info.
+func setModuleProperties(module *buildinfo.Module, props map[string]string) error {
+	switch moduleProps := module.Properties.(type) {
+	case nil:
+		module.Properties = props
+	case map[string]string:
+		for key, value := range props {
+			moduleProps[key] = value
+		}
+	case map[string]any:
+		for key, value := range props {
+			moduleProps[key] = value
+		}
+	default:
+		return errorutils.CheckErrorf("unexpected type of the properties of the module '%s': %T", module.Id, module.Properties)
+	}
+	return nil
+}
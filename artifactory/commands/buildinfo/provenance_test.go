@@ -0,0 +1,109 @@
+package buildinfo
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordDependencyProvenance(t *testing.T) {
+	var aqlQuery string
+	servicesManager := createProvenanceServicesManager(t, &aqlQuery)
+	buildInfo := createProvenanceBuildInfo()
+	require.NoError(t, recordDependencyProvenance(servicesManager, buildInfo, ""))
+	assert.NotContains(t, aqlQuery, `{"repo":`)
+	// Without the resolver repository, the most recently downloaded lodash, in the remote repository, is recorded as a heuristic.
+	assert.Equal(t, map[string]any{
+		"existing":                                       "value",
+		"dependency.lodash:4.17.21.repository":           "npm-remote",
+		"dependency.lodash:4.17.21.repository.type":      "remote",
+		"dependency.lodash:4.17.21.repository.virtuals":  "all-virtual,npm-virtual",
+		"dependency.lodash:4.17.21.repository.heuristic": "true",
+	}, buildInfo.Modules[0].Properties)
+	assert.Equal(t, map[string]string{
+		"dependency.org:lib:1.0.repository":           "libs-release",
+		"dependency.org:lib:1.0.repository.type":      "federated",
+		"dependency.org:lib:1.0.repository.heuristic": "true",
+	}, buildInfo.Modules[1].Properties)
+	assert.Nil(t, buildInfo.Modules[2].Properties)
+}
+
+func TestRecordDependencyProvenanceWithResolverRepo(t *testing.T) {
+	var aqlQuery string
+	servicesManager := createProvenanceServicesManager(t, &aqlQuery)
+	buildInfo := createProvenanceBuildInfo()
+	require.NoError(t, recordDependencyProvenance(servicesManager, buildInfo, "all-virtual"))
+	assert.Contains(t, aqlQuery, `"$or":[{"repo":{"$eq":"npm-local"}},{"repo":{"$eq":"npm-remote-cache"}}]`)
+	// lodash is resolved from the local repository, which precedes the remote repository in the virtual repository.
+	assert.Equal(t, map[string]any{
+		"existing":                                      "value",
+		"dependency.lodash:4.17.21.repository":          "npm-local",
+		"dependency.lodash:4.17.21.repository.type":     "local",
+		"dependency.lodash:4.17.21.repository.virtuals": "all-virtual,npm-virtual",
+	}, buildInfo.Modules[0].Properties)
+	// The federated repository isn't included in the resolver repository.
+	assert.Nil(t, buildInfo.Modules[1].Properties)
+
+	assert.EqualError(t, recordDependencyProvenance(servicesManager, createProvenanceBuildInfo(), "missing-virtual"),
+		"the resolver repository 'missing-virtual' doesn't exist")
+}
+
+func createProvenanceServicesManager(t *testing.T, aqlQuery *string) artifactory.ArtifactoryServicesManager {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.URL.Path {
+		case "/api/search/aql":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			*aqlQuery = string(body)
+			assert.Contains(t, *aqlQuery, `"stat.downloaded"`)
+			assert.NotContains(t, *aqlQuery, "sha1-generic")
+			// lodash exists in both the local and the remote repositories, and was most recently downloaded from the remote repository.
+			response = `{"results":[
+				{"repo":"npm-local","path":"lodash/-","name":"lodash-4.17.21.tgz","actual_sha1":"sha1-lodash","stats":[{"downloaded":"2024-01-01T10:00:00.000Z"}]},
+				{"repo":"npm-remote-cache","path":"lodash/-","name":"lodash-4.17.21.tgz","actual_sha1":"sha1-lodash","stats":[{"downloaded":"2024-03-01T10:00:00.000Z"}]},
+				{"repo":"libs-release","path":"org/lib/1.0","name":"lib-1.0.jar","actual_sha1":"sha1-lib"}]}`
+		case "/api/repositories":
+			response = `[{"key":"npm-local","type":"LOCAL"},{"key":"npm-remote","type":"REMOTE"},{"key":"npm-virtual","type":"VIRTUAL"},
+				{"key":"all-virtual","type":"VIRTUAL"},{"key":"libs-release","type":"FEDERATED"}]`
+		case "/api/repositories/npm-virtual":
+			response = `{"key":"npm-virtual","rclass":"virtual","repositories":["npm-local","npm-remote"]}`
+		case "/api/repositories/all-virtual":
+			response = `{"key":"all-virtual","rclass":"virtual","repositories":["npm-virtual"]}`
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, 0, false)
+	require.NoError(t, err)
+	return servicesManager
+}
+
+func createProvenanceBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{Modules: []buildinfo.Module{
+		{Id: "web", Type: buildinfo.Npm, Properties: map[string]any{"existing": "value"}, Dependencies: []buildinfo.Dependency{
+			{Id: "lodash:4.17.21", Checksum: buildinfo.Checksum{Sha1: "sha1-lodash"}},
+			{Id: "missing:1.0.0", Checksum: buildinfo.Checksum{Sha1: "sha1-missing"}},
+		}},
+		{Id: "org:app:1.0", Type: buildinfo.Maven, Dependencies: []buildinfo.Dependency{{Id: "org:lib:1.0", Checksum: buildinfo.Checksum{Sha1: "sha1-lib"}}}},
+		{Id: "files", Type: buildinfo.Generic, Dependencies: []buildinfo.Dependency{{Id: "file.txt", Checksum: buildinfo.Checksum{Sha1: "sha1-generic"}}}},
+	}}
+}
+
+func TestGetDependencyProvenance(t *testing.T) {
+	// A repository named with the cache suffix, which isn't the cache of a remote repository, is attributed to itself.
+	repositories := map[string]*repositoryConfig{"files-cache": {Key: "files-cache", Rclass: "local"}}
+	assert.Equal(t, DependencyProvenance{Repository: "files-cache", RepositoryType: "local"}, getDependencyProvenance("files-cache", repositories))
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/formats"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
@@ -31,6 +32,19 @@ type BuildPublishCommand struct {
 	config             *biconf.Configuration
 	detailedSummary    bool
 	summary            *clientutils.Sha256Summary
+	serversDetails     []*config.ServerDetails
+	// Enrichers computing properties for the build artifacts and dependencies, in addition to the registered enrichers.
+	enrichers []PropertiesEnricher
+	// A YAML file with the enrichment configuration.
+	enrichmentConfigPath string
+	// If true, the shards of the build saved in Artifactory by other CI jobs are merged into the published build-info.
+	mergeShards bool
+	// The repository the shards are stored in. If empty, the repository is read from JFROG_CLI_BUILD_SHARDS_REPO.
+	shardsRepo string
+	// If true, the repository which served each dependency of the npm, Maven, Gradle and Go modules is recorded in the module properties.
+	dependencyProvenance bool
+	// The repository the dependencies were resolved from. If empty, the recorded provenance is a heuristic.
+	dependencyResolverRepo string
 }
 
 func NewBuildPublishCommand() *BuildPublishCommand {
@@ -47,11 +61,60 @@ func (bpc *BuildPublishCommand) SetServerDetails(serverDetails *config.ServerDet
 	return bpc
 }
 
+// SetServersDetails sets multiple servers to publish the build-info to concurrently.
+// The first server is used for generating the build number and the build-info link.
+func (bpc *BuildPublishCommand) SetServersDetails(serversDetails []*config.ServerDetails) *BuildPublishCommand {
+	bpc.serversDetails = serversDetails
+	if len(serversDetails) > 0 {
+		bpc.serverDetails = serversDetails[0]
+	}
+	return bpc
+}
+
 func (bpc *BuildPublishCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildPublishCommand {
 	bpc.buildConfiguration = buildConfiguration
 	return bpc
 }
 
+// SetEnrichers sets enrichers which add properties to the artifacts and dependencies of the build before it's published.
+func (bpc *BuildPublishCommand) SetEnrichers(enrichers ...PropertiesEnricher) *BuildPublishCommand {
+	bpc.enrichers = enrichers
+	return bpc
+}
+
+// SetEnrichmentConfigPath sets the path to a YAML file which configures the properties to add to the artifacts and dependencies of the build.
+func (bpc *BuildPublishCommand) SetEnrichmentConfigPath(enrichmentConfigPath string) *BuildPublishCommand {
+	bpc.enrichmentConfigPath = enrichmentConfigPath
+	return bpc
+}
+
+// SetMergeShards sets whether to merge the shards of the build, saved in Artifactory by the build save shard command, into the published build-info.
+func (bpc *BuildPublishCommand) SetMergeShards(mergeShards bool) *BuildPublishCommand {
+	bpc.mergeShards = mergeShards
+	return bpc
+}
+
+func (bpc *BuildPublishCommand) SetShardsRepo(shardsRepo string) *BuildPublishCommand {
+	bpc.shardsRepo = shardsRepo
+	return bpc
+}
+
+// SetDependencyProvenance sets whether to record the Artifactory repository which served each dependency of the build,
+// for supply-chain audits. The provenance is recorded in the properties of the modules, such as 'dependency.<id>.repository'.
+func (bpc *BuildPublishCommand) SetDependencyProvenance(dependencyProvenance bool) *BuildPublishCommand {
+	bpc.dependencyProvenance = dependencyProvenance
+	return bpc
+}
+
+// SetDependencyResolverRepo sets the repository the dependencies of the build were resolved from, to record their provenance from.
+// The enriched properties of the dependencies are set only on the dependencies in the repositories it resolves from.
+// Without it, the provenance is only a guess based on the download statistics of the whole instance, and is marked as such by
+// the 'dependency.<id>.repository.heuristic' property.
+func (bpc *BuildPublishCommand) SetDependencyResolverRepo(dependencyResolverRepo string) *BuildPublishCommand {
+	bpc.dependencyResolverRepo = dependencyResolverRepo
+	return bpc
+}
+
 func (bpc *BuildPublishCommand) SetSummary(summary *clientutils.Sha256Summary) *BuildPublishCommand {
 	bpc.summary = summary
 	return bpc
@@ -70,6 +133,15 @@ func (bpc *BuildPublishCommand) IsDetailedSummary() bool {
 	return bpc.detailedSummary
 }
 
+// EnableDryRun implements commands.DryRunCommand.
+// In dry run mode, the build-info is logged instead of being published.
+func (bpc *BuildPublishCommand) EnableDryRun() {
+	if bpc.config == nil {
+		bpc.config = new(biconf.Configuration)
+	}
+	bpc.config.DryRun = true
+}
+
 func (bpc *BuildPublishCommand) CommandName() string {
 	return "rt_build_publish"
 }
@@ -78,6 +150,12 @@ func (bpc *BuildPublishCommand) ServerDetails() (*config.ServerDetails, error) {
 	return bpc.serverDetails, nil
 }
 
+// Returns the project to which the build-info is published.
+// The build-info is stored locally under the project of the build configuration, but if no project was set for the build, it's published to the default project of the server.
+func (bpc *BuildPublishCommand) getProject() string {
+	return config.GetProjectKey(bpc.buildConfiguration.GetProject(), bpc.serverDetails)
+}
+
 func (bpc *BuildPublishCommand) Run() error {
 	servicesManager, err := utils.CreateServiceManager(bpc.serverDetails, -1, 0, bpc.config.DryRun)
 	if err != nil {
@@ -102,12 +180,22 @@ func (bpc *BuildPublishCommand) Run() error {
 	build.SetAgentVersion(coreutils.GetCliUserAgentVersion())
 	build.SetBuildAgentVersion(coreutils.GetClientAgentVersion())
 	build.SetPrincipal(bpc.serverDetails.User)
-	build.SetBuildUrl(bpc.config.BuildUrl)
+	buildUrl := bpc.config.BuildUrl
+	if buildUrl == "" {
+		buildUrl = bpc.buildConfiguration.GetBuildUrl()
+	}
+	build.SetBuildUrl(buildUrl)
 
 	buildInfo, err := build.ToBuildInfo()
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
+	shardsPath := ""
+	if bpc.mergeShards {
+		if shardsPath, err = bpc.mergeBuildShards(servicesManager, buildInfo); err != nil {
+			return err
+		}
+	}
 	err = buildInfo.IncludeEnv(strings.Split(bpc.config.EnvInclude, ";")...)
 	if errorutils.CheckError(err) != nil {
 		return err
@@ -123,13 +211,36 @@ func (bpc *BuildPublishCommand) Run() error {
 		}
 		bpc.buildConfiguration.SetBuildNumber(buildInfo.Number)
 	}
-	summary, err := servicesManager.PublishBuildInfo(buildInfo, bpc.buildConfiguration.GetProject())
-	if bpc.IsDetailedSummary() {
-		bpc.SetSummary(summary)
+	if bpc.dependencyProvenance {
+		if err = recordDependencyProvenance(servicesManager, buildInfo, bpc.dependencyResolverRepo); err != nil {
+			return err
+		}
+	}
+	enrichment, err := bpc.computeEnrichment(buildInfo)
+	if err != nil {
+		return err
+	}
+	if len(bpc.serversDetails) > 1 {
+		err = bpc.publishToServers(buildInfo, enrichment)
+	} else {
+		if err = enrichment.apply(servicesManager, bpc.dependencyResolverRepo, bpc.config.DryRun); err != nil {
+			return err
+		}
+		var summary *clientutils.Sha256Summary
+		summary, err = servicesManager.PublishBuildInfo(buildInfo, bpc.getProject())
+		if bpc.IsDetailedSummary() {
+			bpc.SetSummary(summary)
+		}
 	}
 	if err != nil || bpc.config.DryRun {
 		return err
 	}
+	if shardsPath != "" {
+		// The shards were published as part of the build-info, so they're no longer needed.
+		if err = deleteBuildShards(servicesManager, shardsPath); err != nil {
+			log.Warn("Failed deleting the build-info shards from " + shardsPath + ": " + err.Error())
+		}
+	}
 
 	buildLink, err := bpc.constructBuildInfoUiUrl(servicesManager, buildInfo.Started)
 	if err != nil {
@@ -155,6 +266,50 @@ func (bpc *BuildPublishCommand) Run() error {
 	return logJsonOutput(buildLink)
 }
 
+// Merges the shards of the build into the build-info, and returns the path of the shards in Artifactory.
+func (bpc *BuildPublishCommand) mergeBuildShards(servicesManager artifactory.ArtifactoryServicesManager, buildInfo *buildinfo.BuildInfo) (string, error) {
+	shardsRepo, err := getShardsRepo(bpc.shardsRepo)
+	if err != nil {
+		return "", err
+	}
+	shardsPath := getShardsPath(shardsRepo, buildInfo.Name, buildInfo.Number, bpc.buildConfiguration.GetProject())
+	shards, err := downloadBuildShards(servicesManager, shardsPath)
+	if err != nil {
+		return "", err
+	}
+	if len(shards) == 0 {
+		log.Warn("No build-info shards were found in " + shardsPath + ".")
+		return "", nil
+	}
+	mergeBuildShards(buildInfo, shards)
+	log.Info(fmt.Sprintf("Merged %d build-info shards into build %s/%s.", len(shards), buildInfo.Name, buildInfo.Number))
+	return shardsPath, nil
+}
+
+// Publishes the build-info to all the servers concurrently, after setting the enriched properties on each of them.
+// The detailed summary is taken from the first server.
+// The local build-info is kept if the publishing to any of the servers failed, to allow publishing it again.
+func (bpc *BuildPublishCommand) publishToServers(buildInfo *buildinfo.BuildInfo, enrichment *buildEnrichment) error {
+	_, err := commandsutils.DeployToServers(bpc.serversDetails, func(serverDetails *config.ServerDetails) (int, int, error) {
+		servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, bpc.config.DryRun)
+		if err != nil {
+			return 0, 1, err
+		}
+		if err = enrichment.apply(servicesManager, bpc.dependencyResolverRepo, bpc.config.DryRun); err != nil {
+			return 0, 1, err
+		}
+		summary, err := servicesManager.PublishBuildInfo(buildInfo, config.GetProjectKey(bpc.buildConfiguration.GetProject(), serverDetails))
+		if serverDetails == bpc.serverDetails && bpc.IsDetailedSummary() {
+			bpc.SetSummary(summary)
+		}
+		if err != nil {
+			return 0, 1, err
+		}
+		return 1, 0, nil
+	})
+	return err
+}
+
 func logJsonOutput(buildInfoUiUrl string) error {
 	output := formats.BuildPublishOutput{BuildInfoUiUrl: buildInfoUiUrl}
 	results, err := output.JSON()
@@ -198,7 +353,7 @@ func (bpc *BuildPublishCommand) getBuildInfoUiUrl(majorVersion int, buildTime ti
 	}
 	baseUrl = clientutils.AddTrailingSlashIfNeeded(baseUrl)
 
-	project := bpc.buildConfiguration.GetProject()
+	project := bpc.getProject()
 	buildName, buildNumber, project = url.PathEscape(buildName), url.PathEscape(buildNumber), url.QueryEscape(project)
 
 	if majorVersion <= 6 {
@@ -48,6 +48,13 @@ func TestPrintBuildInfoLink(t *testing.T) {
 			nil,
 			true,
 			nil,
+			nil,
+			nil,
+			"",
+			false,
+			"",
+			false,
+			"",
 		}
 		buildPubComService, err := buildPubConf.getBuildInfoUiUrl(linkTypes[i].majorVersion, linkTypes[i].buildTime)
 		assert.NoError(t, err)
@@ -4,11 +4,14 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type DockerPromoteCommand struct {
 	serverDetails *config.ServerDetails
 	params        services.DockerPromoteParams
+	// If true, each platform image of a promoted multi-arch image is also tagged in the target repository as <target-tag>-<os>-<arch>[-<variant>].
+	retagPlatforms bool
 }
 
 func NewDockerPromoteCommand() *DockerPromoteCommand {
@@ -21,6 +24,16 @@ func (dp *DockerPromoteCommand) Run() error {
 	if err != nil {
 		return err
 	}
+	manifestList, err := dp.getManifestList(servicesManager)
+	if err != nil {
+		return err
+	}
+	if manifestList != nil {
+		return dp.promoteMultiArchImage(servicesManager, manifestList)
+	}
+	if dp.retagPlatforms {
+		log.Warn("The image isn't a multi-arch image, so its platform images aren't re-tagged.")
+	}
 	// Promote docker
 	return servicesManager.PromoteDocker(dp.params)
 }
@@ -42,3 +55,8 @@ func (dp *DockerPromoteCommand) SetParams(params services.DockerPromoteParams) *
 	dp.params = params
 	return dp
 }
+
+func (dp *DockerPromoteCommand) SetRetagPlatforms(retagPlatforms bool) *DockerPromoteCommand {
+	dp.retagPlatforms = retagPlatforms
+	return dp
+}
@@ -0,0 +1,150 @@
+package container
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	amd64Digest = "sha256:1111"
+	arm64Digest = "sha256:2222"
+	// The folders of the platform images in Artifactory
+	amd64Folder      = "sha256__1111"
+	arm64Folder      = "sha256__2222"
+	testManifestList = `{"manifests":[
+		{"digest":"` + amd64Digest + `","platform":{"architecture":"amd64","os":"linux"}},
+		{"digest":"` + arm64Digest + `","platform":{"architecture":"arm64","os":"linux","variant":"v8"}}
+	]}`
+)
+
+// Simulates the storage of Artifactory, and records the copy, delete and promote requests.
+func createPromotionServer(t *testing.T, existingFiles []string, failedCopyTarget string) (*httptest.Server, *[]string) {
+	var mutex sync.Mutex
+	var requests []string
+	files := map[string]bool{}
+	for _, file := range existingFiles {
+		files[file] = true
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/"+manifestListFileName) && files[strings.TrimPrefix(r.URL.Path, "/")]:
+			_, err := w.Write([]byte(testManifestList))
+			assert.NoError(t, err)
+		case r.URL.Path == "/api/search/aql":
+			// Returns the manifests of the source image.
+			var results []string
+			for file := range files {
+				folder, name := path.Split(strings.TrimPrefix(file, "docker-dev/"))
+				if strings.HasPrefix(file, "docker-dev/app/") && (name == "manifest.json" || name == manifestListFileName) {
+					results = append(results, fmt.Sprintf(`{"path":"%s","name":"%s","sha256":"%s"}`, path.Clean(folder), name, testManifestsSha256[file]))
+				}
+			}
+			_, err := w.Write([]byte(`{"results":[` + strings.Join(results, ",") + `]}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/storage/"):
+			if !files[strings.TrimPrefix(r.URL.Path, "/api/storage/")] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, err := w.Write([]byte(`{"path":"` + r.URL.Path + `"}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/api/copy/"):
+			target := r.URL.Query().Get("to")
+			requests = append(requests, "copy "+strings.TrimPrefix(r.URL.Path, "/api/copy/")+" "+target)
+			if target == failedCopyTarget {
+				w.WriteHeader(http.StatusConflict)
+			}
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/api/docker/"):
+			requests = append(requests, "promote "+r.URL.Path)
+		case r.Method == http.MethodDelete:
+			requests = append(requests, "delete "+strings.TrimPrefix(r.URL.Path, "/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})), &requests
+}
+
+func createDockerPromoteCommand(serverUrl string, copy bool) *DockerPromoteCommand {
+	return NewDockerPromoteCommand().
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: serverUrl + "/"}).
+		SetParams(services.DockerPromoteParams{SourceRepo: "docker-dev", TargetRepo: "docker-prod", SourceDockerImage: "app", SourceTag: "1.0", TargetTag: "1.0-rc", Copy: copy})
+}
+
+// The sha256 of the manifests of the single-arch tags, which is the digest of the image.
+var testManifestsSha256 = map[string]string{"docker-dev/app/1.1/manifest.json": "2222"}
+
+var multiArchImageFiles = []string{
+	"docker-dev/app/1.0/list.manifest.json",
+	"docker-dev/app/" + amd64Folder + "/manifest.json",
+	"docker-dev/app/" + arm64Folder + "/manifest.json",
+}
+
+func TestPromoteMultiArchImage(t *testing.T) {
+	// The amd64 image already exists in the target repository, so it shouldn't be copied.
+	server, requests := createPromotionServer(t, append(multiArchImageFiles, "docker-prod/app/"+amd64Folder+"/manifest.json"), "")
+	defer server.Close()
+	assert.NoError(t, createDockerPromoteCommand(server.URL, false).SetRetagPlatforms(true).Run())
+	assert.Equal(t, []string{
+		"copy docker-dev/app/" + arm64Folder + " docker-prod/app/" + arm64Folder,
+		"copy docker-prod/app/" + amd64Folder + " docker-prod/app/1.0-rc-linux-amd64",
+		"copy docker-prod/app/" + arm64Folder + " docker-prod/app/1.0-rc-linux-arm64-v8",
+		"copy docker-dev/app/1.0 docker-prod/app/1.0-rc",
+		"delete docker-dev/app/1.0",
+		"delete docker-dev/app/" + amd64Folder,
+		"delete docker-dev/app/" + arm64Folder,
+	}, *requests)
+}
+
+func TestPromoteMultiArchImageKeepsReferencedPlatforms(t *testing.T) {
+	// The 1.1 tag is a single-arch image of the arm64 platform image, so the arm64 platform image shouldn't be deleted.
+	server, requests := createPromotionServer(t, append(multiArchImageFiles, "docker-dev/app/1.1/manifest.json"), "")
+	defer server.Close()
+	assert.NoError(t, createDockerPromoteCommand(server.URL, false).Run())
+	assert.Equal(t, []string{"delete docker-dev/app/1.0", "delete docker-dev/app/" + amd64Folder}, (*requests)[3:])
+
+	// The latest tag references both platform images.
+	server, requests = createPromotionServer(t, append(multiArchImageFiles, "docker-dev/app/latest/"+manifestListFileName), "")
+	defer server.Close()
+	assert.NoError(t, createDockerPromoteCommand(server.URL, false).Run())
+	assert.Equal(t, []string{"delete docker-dev/app/1.0"}, (*requests)[3:])
+}
+
+func TestPromoteMultiArchImageMissingPlatform(t *testing.T) {
+	server, requests := createPromotionServer(t, multiArchImageFiles[:2], "")
+	defer server.Close()
+	err := createDockerPromoteCommand(server.URL, true).Run()
+	assert.ErrorContains(t, err, "the linux-arm64-v8 platform image ("+arm64Digest+") referenced by the manifest list of app:1.0 is missing")
+	assert.Empty(t, *requests)
+}
+
+func TestPromoteMultiArchImageRollback(t *testing.T) {
+	server, requests := createPromotionServer(t, multiArchImageFiles, "docker-prod/app/1.0-rc")
+	defer server.Close()
+	assert.Error(t, createDockerPromoteCommand(server.URL, true).Run())
+	assert.Equal(t, []string{
+		"copy docker-dev/app/" + amd64Folder + " docker-prod/app/" + amd64Folder,
+		"copy docker-dev/app/" + arm64Folder + " docker-prod/app/" + arm64Folder,
+		"copy docker-dev/app/1.0 docker-prod/app/1.0-rc",
+		"delete docker-prod/app/" + amd64Folder,
+		"delete docker-prod/app/" + arm64Folder,
+	}, *requests)
+}
+
+func TestPromoteSingleArchImage(t *testing.T) {
+	server, requests := createPromotionServer(t, nil, "")
+	defer server.Close()
+	assert.NoError(t, createDockerPromoteCommand(server.URL, true).Run())
+	assert.Equal(t, []string{"promote /api/docker/docker-dev/v2/promote"}, *requests)
+}
@@ -0,0 +1,235 @@
+package container
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Artifactory stores each tag of a multi-arch image in a folder containing the manifest list,
+// and each of the platform images it references in a folder named after the digest of the platform manifest, for example: sha256__<hex>.
+const manifestListFileName = "list.manifest.json"
+
+// Returns the manifest list of the promoted tag, or nil if the tag isn't a multi-arch image.
+func (dp *DockerPromoteCommand) getManifestList(servicesManager artifactory.ArtifactoryServicesManager) (*container.FatManifest, error) {
+	if dp.params.SourceTag == "" {
+		return nil, nil
+	}
+	return readManifestList(servicesManager, path.Join(dp.params.SourceRepo, dp.params.SourceDockerImage, dp.params.SourceTag))
+}
+
+// Returns the manifest list stored in the tag folder, or nil if it doesn't exist.
+func readManifestList(servicesManager artifactory.ArtifactoryServicesManager, tagPath string) (*container.FatManifest, error) {
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(servicesManager.GetConfig().GetServiceDetails().GetUrl()+path.Join(tagPath, manifestListFileName), true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	manifestList := &container.FatManifest{}
+	if err = json.Unmarshal(body, manifestList); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the manifest list of %s: %s", tagPath, err.Error())
+	}
+	return manifestList, nil
+}
+
+// Promotes the manifest list and all the platform images it references.
+// Nothing is promoted if any of the platform images is missing, and the promoted platform images are removed from the target repository if the promotion fails.
+// The manifest list is promoted last, so that the target tag never references platform images which weren't promoted.
+func (dp *DockerPromoteCommand) promoteMultiArchImage(servicesManager artifactory.ArtifactoryServicesManager, manifestList *container.FatManifest) (err error) {
+	sourceImagePath := path.Join(dp.params.SourceRepo, dp.params.SourceDockerImage)
+	targetImagePath := path.Join(dp.params.TargetRepo, dp.getTargetImage())
+	for _, manifest := range manifestList.Manifests {
+		if _, err = servicesManager.FileInfo(path.Join(sourceImagePath, container.DigestToLayer(manifest.Digest), "manifest.json")); err != nil {
+			return errorutils.CheckErrorf("the %s platform image (%s) referenced by the manifest list of %s:%s is missing, so nothing was promoted: %s",
+				getPlatformName(manifest.Platform), manifest.Digest, dp.params.SourceDockerImage, dp.params.SourceTag, err.Error())
+		}
+	}
+	log.Info(fmt.Sprintf("Promoting the multi-arch image %s:%s with %d platform images...", dp.params.SourceDockerImage, dp.params.SourceTag, len(manifestList.Manifests)))
+
+	// The paths created in the target repository, which should be deleted if the promotion fails.
+	var promotedPaths []string
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, rollbackPromotion(servicesManager, promotedPaths))
+		}
+	}()
+	for _, manifest := range manifestList.Manifests {
+		platformFolder := container.DigestToLayer(manifest.Digest)
+		targetPath := path.Join(targetImagePath, platformFolder)
+		// Platform images may be shared by several tags, so existing platform images are neither overridden nor deleted on failure.
+		if _, statErr := servicesManager.FileInfo(path.Join(targetPath, "manifest.json")); statErr == nil {
+			log.Debug("The platform image", manifest.Digest, "already exists in the target repository.")
+			continue
+		}
+		if err = copyPath(servicesManager, path.Join(sourceImagePath, platformFolder), targetPath); err != nil {
+			return
+		}
+		promotedPaths = append(promotedPaths, targetPath)
+	}
+	if dp.retagPlatforms {
+		for _, manifest := range manifestList.Manifests {
+			targetPath := path.Join(targetImagePath, dp.getPlatformTag(manifest.Platform))
+			if err = copyPath(servicesManager, path.Join(targetImagePath, container.DigestToLayer(manifest.Digest)), targetPath); err != nil {
+				return
+			}
+			promotedPaths = append(promotedPaths, targetPath)
+		}
+	}
+	if err = copyPath(servicesManager, path.Join(sourceImagePath, dp.params.SourceTag), path.Join(targetImagePath, dp.getTargetTag())); err != nil {
+		return
+	}
+	log.Info("Promoted image", dp.params.SourceDockerImage, "to:", dp.params.TargetRepo, "repository.")
+	if dp.params.Copy {
+		return nil
+	}
+	// The promotion succeeded, so failing to delete the source image shouldn't roll it back.
+	if deleteErr := deleteSourceImage(servicesManager, sourceImagePath, dp.params.SourceTag, manifestList); deleteErr != nil {
+		log.Warn("The image was promoted, but deleting it from the source repository failed:", deleteErr.Error())
+	}
+	return nil
+}
+
+func (dp *DockerPromoteCommand) getTargetImage() string {
+	if dp.params.TargetDockerImage != "" {
+		return dp.params.TargetDockerImage
+	}
+	return dp.params.SourceDockerImage
+}
+
+func (dp *DockerPromoteCommand) getTargetTag() string {
+	if dp.params.TargetTag != "" {
+		return dp.params.TargetTag
+	}
+	return dp.params.SourceTag
+}
+
+// Returns the tag of a platform image, for example: 1.0-linux-arm64-v8.
+func (dp *DockerPromoteCommand) getPlatformTag(platform container.Platform) string {
+	return dp.getTargetTag() + "-" + getPlatformName(platform)
+}
+
+func getPlatformName(platform container.Platform) string {
+	parts := []string{platform.Os, platform.Architecture}
+	if platform.Variant != "" {
+		parts = append(parts, platform.Variant)
+	}
+	return strings.Join(parts, "-")
+}
+
+// Deletes the manifest list and the platform images from the source repository, after moving them.
+// Platform images which are still referenced by other tags of the image are kept.
+func deleteSourceImage(servicesManager artifactory.ArtifactoryServicesManager, sourceImagePath, sourceTag string, manifestList *container.FatManifest) error {
+	err := deletePath(servicesManager, path.Join(sourceImagePath, sourceTag))
+	referencedDigests, refErr := getReferencedDigests(servicesManager, sourceImagePath, sourceTag)
+	if refErr != nil {
+		return errors.Join(err, refErr)
+	}
+	for _, manifest := range manifestList.Manifests {
+		if referencedDigests[manifest.Digest] {
+			log.Debug("The platform image", manifest.Digest, "is referenced by other tags, so it's kept in the source repository.")
+			continue
+		}
+		err = errors.Join(err, deletePath(servicesManager, path.Join(sourceImagePath, container.DigestToLayer(manifest.Digest))))
+	}
+	return err
+}
+
+// Returns the digests of the platform images referenced by the tags of the image, except for the excluded tag.
+// A tag references the platform images of its manifest list, or the image of its manifest if it's a single-arch image.
+func getReferencedDigests(servicesManager artifactory.ArtifactoryServicesManager, imagePath, excludedTag string) (referencedDigests map[string]bool, err error) {
+	repo, image, _ := strings.Cut(imagePath, "/")
+	query := fmt.Sprintf(`items.find({"repo":"%s","path":{"$match":"%s/*"},"$or":[{"name":{"$eq":"%s"}},{"name":{"$eq":"manifest.json"}}]}).include("path","name","sha256")`,
+		repo, image, manifestListFileName)
+	reader, err := servicesManager.Aql(query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	respBody, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	result := &servicesutils.AqlSearchResult{}
+	if err = json.Unmarshal(respBody, result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	referencedDigests = map[string]bool{}
+	for _, item := range result.Results {
+		tag := path.Base(item.Path)
+		// The folders of the platform images aren't tags.
+		if tag == excludedTag || strings.HasPrefix(tag, "sha256__") {
+			continue
+		}
+		if item.Name == "manifest.json" {
+			referencedDigests["sha256:"+item.Sha256] = true
+			continue
+		}
+		tagManifestList, err := readManifestList(servicesManager, path.Join(repo, item.Path))
+		if err != nil {
+			return nil, err
+		}
+		if tagManifestList == nil {
+			continue
+		}
+		for _, manifest := range tagManifestList.Manifests {
+			referencedDigests[manifest.Digest] = true
+		}
+	}
+	return referencedDigests, nil
+}
+
+func rollbackPromotion(servicesManager artifactory.ArtifactoryServicesManager, promotedPaths []string) error {
+	if len(promotedPaths) == 0 {
+		return nil
+	}
+	log.Info("The promotion failed. Deleting the promoted platform images from the target repository...")
+	var err error
+	for _, promotedPath := range promotedPaths {
+		err = errors.Join(err, deletePath(servicesManager, promotedPath))
+	}
+	return err
+}
+
+func copyPath(servicesManager artifactory.ArtifactoryServicesManager, sourcePath, targetPath string) error {
+	log.Debug("Copying", sourcePath, "to", targetPath)
+	rtUrl := servicesManager.GetConfig().GetServiceDetails().GetUrl()
+	requestUrl, err := clientutils.BuildUrl(rtUrl, path.Join("api", "copy", sourcePath), map[string]string{"to": targetPath, "suppressLayouts": "1"})
+	if err != nil {
+		return err
+	}
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPost(requestUrl, nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
+
+func deletePath(servicesManager artifactory.ArtifactoryServicesManager, pathToDelete string) error {
+	log.Debug("Deleting", pathToDelete)
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendDelete(servicesManager.GetConfig().GetServiceDetails().GetUrl()+pathToDelete, nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent, http.StatusNotFound)
+}
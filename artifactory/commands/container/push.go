@@ -2,15 +2,18 @@ package container
 
 import (
 	"path"
+	"strings"
 
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
 )
 
@@ -18,7 +21,13 @@ type PushCommand struct {
 	ContainerCommand
 	threads         int
 	detailedSummary bool
+	dryRun          bool
 	result          *commandsutils.Result
+	// Push the image layer by layer, rather than by the push command of the container manager.
+	layersPush     bool
+	retries        int
+	layerChunkSize int64
+	progress       ioUtils.ProgressMgr
 }
 
 func NewPushCommand(containerManagerType container.ContainerManagerType) *PushCommand {
@@ -26,6 +35,7 @@ func NewPushCommand(containerManagerType container.ContainerManagerType) *PushCo
 		ContainerCommand: ContainerCommand{
 			containerManagerType: containerManagerType,
 		},
+		retries: -1,
 	}
 }
 
@@ -38,6 +48,31 @@ func (pc *PushCommand) SetThreads(threads int) *PushCommand {
 	return pc
 }
 
+// SetLayersPush makes the command push the layers of the image by itself, instead of running the push command of the container manager.
+// The layers are uploaded concurrently by the command threads, in chunks, and a layer whose upload fails is retried without pushing the whole image again.
+func (pc *PushCommand) SetLayersPush(layersPush bool) *PushCommand {
+	pc.layersPush = layersPush
+	return pc
+}
+
+// SetRetries sets the number of retries of a failed layer upload, when pushing the image layer by layer. A negative value uses the default.
+func (pc *PushCommand) SetRetries(retries int) *PushCommand {
+	pc.retries = retries
+	return pc
+}
+
+// SetLayerChunkSize sets the size in bytes of the chunks in which the layers are uploaded, when pushing the image layer by layer.
+func (pc *PushCommand) SetLayerChunkSize(layerChunkSize int64) *PushCommand {
+	pc.layerChunkSize = layerChunkSize
+	return pc
+}
+
+// SetProgress sets the progress manager which displays the upload of the layers, when pushing the image layer by layer.
+func (pc *PushCommand) SetProgress(progress ioUtils.ProgressMgr) *PushCommand {
+	pc.progress = progress
+	return pc
+}
+
 func (pc *PushCommand) SetDetailedSummary(detailedSummary bool) *PushCommand {
 	pc.detailedSummary = detailedSummary
 	return pc
@@ -47,6 +82,12 @@ func (pc *PushCommand) IsDetailedSummary() bool {
 	return pc.detailedSummary
 }
 
+// EnableDryRun implements commands.DryRunCommand.
+// In dry run mode, the image isn't pushed and no build-info is collected.
+func (pc *PushCommand) EnableDryRun() {
+	pc.dryRun = true
+}
+
 func (pc *PushCommand) Result() *commandsutils.Result {
 	return pc.result
 }
@@ -70,13 +111,21 @@ func (pc *PushCommand) Run() error {
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
-	// Perform login
-	if err := pc.PerformLogin(serverDetails, pc.containerManagerType); err != nil {
-		return err
+	if pc.dryRun {
+		commands.LogDryRunAction("Pushing image:", pc.image.Name(), "to:", serverDetails.ArtifactoryUrl)
+		if pc.layersPush {
+			commands.LogDryRunAction("Pushing the image layer by layer, with threads:", pc.threads)
+		} else {
+			commands.LogDryRunAction("Running command:", pc.containerManagerType.String(), strings.Join(pc.cmdParams, " "))
+		}
+		return nil
 	}
-	// Perform push.
 	cm := container.NewManager(pc.containerManagerType)
-	err = cm.RunNativeCmd(pc.cmdParams)
+	if pc.layersPush {
+		err = pc.pushLayers(serverDetails, cm)
+	} else {
+		err = pc.pushNatively(serverDetails, cm)
+	}
 	if err != nil {
 		return err
 	}
@@ -134,6 +183,29 @@ func (pc *PushCommand) Run() error {
 	return nil
 }
 
+func (pc *PushCommand) pushNatively(serverDetails *config.ServerDetails, cm container.ContainerManager) error {
+	// Perform login
+	if err := pc.PerformLogin(serverDetails, pc.containerManagerType); err != nil {
+		return err
+	}
+	// Perform push.
+	return cm.RunNativeCmd(pc.cmdParams)
+}
+
+func (pc *PushCommand) pushLayers(serverDetails *config.ServerDetails, cm container.ContainerManager) error {
+	// The failed layer uploads are retried by the pusher, so the HTTP client doesn't retry the requests.
+	serviceManager, err := utils.CreateServiceManagerWithThreads(serverDetails, false, pc.threads, 0, 0)
+	if err != nil {
+		return err
+	}
+	return container.NewLayersPusher(pc.image, serviceManager, cm).
+		SetThreads(pc.threads).
+		SetRetries(pc.retries).
+		SetChunkSize(pc.layerChunkSize).
+		SetProgress(pc.progress).
+		Push()
+}
+
 func (pc *PushCommand) layersMapToFileTransferDetails(artifactoryUrl string, layers *[]servicesutils.ResultItem) error {
 	var details []clientutils.FileTransferDetails
 	for _, layer := range *layers {
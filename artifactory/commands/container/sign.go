@@ -0,0 +1,188 @@
+package container
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/signing"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+	"path"
+)
+
+// SignCommand signs an image in Artifactory, and stores a cosign-compatible signature alongside it in the same repository.
+// Images are signed either with a local private key, or keyless - with a short-lived certificate issued for an OIDC identity.
+type SignCommand struct {
+	ContainerCommandBase
+	keyPath   string
+	keyless   bool
+	idToken   string
+	fulcioUrl string
+	rekorUrl  string
+}
+
+func NewSignCommand() *SignCommand {
+	return &SignCommand{fulcioUrl: container.DefaultFulcioUrl, rekorUrl: container.DefaultRekorUrl}
+}
+
+// SetKeyPath sets the path to the PEM private key used for signing the image.
+func (sc *SignCommand) SetKeyPath(keyPath string) *SignCommand {
+	sc.keyPath = keyPath
+	return sc
+}
+
+func (sc *SignCommand) SetKeyless(keyless bool) *SignCommand {
+	sc.keyless = keyless
+	return sc
+}
+
+// SetIdToken sets the OIDC identity token for keyless signing. By default, the token is read from the SIGSTORE_ID_TOKEN environment variable.
+func (sc *SignCommand) SetIdToken(idToken string) *SignCommand {
+	sc.idToken = idToken
+	return sc
+}
+
+func (sc *SignCommand) SetFulcioUrl(fulcioUrl string) *SignCommand {
+	sc.fulcioUrl = fulcioUrl
+	return sc
+}
+
+func (sc *SignCommand) SetRekorUrl(rekorUrl string) *SignCommand {
+	sc.rekorUrl = rekorUrl
+	return sc
+}
+
+func (sc *SignCommand) CommandName() string {
+	return "rt_docker_sign"
+}
+
+func (sc *SignCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+func (sc *SignCommand) Run() error {
+	if sc.keyless == (sc.keyPath != "") {
+		return errorutils.CheckErrorf("either a private key or keyless signing should be used")
+	}
+	serviceManager, err := utils.CreateServiceManager(sc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	registryClient, manifestDigest, err := getImageDigest(sc.image, serviceManager)
+	if err != nil {
+		return err
+	}
+	dockerReference, err := getDockerReference(sc.image)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(container.NewSimpleSigningPayload(dockerReference, manifestDigest))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var annotations map[string]string
+	if sc.keyless {
+		annotations, err = sc.signKeyless(payload)
+	} else {
+		annotations, err = sc.signWithKey(payload)
+	}
+	if err != nil {
+		return err
+	}
+	if err = registryClient.AddSignatureLayer(manifestDigest, payload, annotations); err != nil {
+		return err
+	}
+	log.Info("Signed", dockerReference+"@"+manifestDigest)
+	return nil
+}
+
+func (sc *SignCommand) signWithKey(payload []byte) (map[string]string, error) {
+	privateKey, err := signing.LoadPrivateKey(sc.keyPath)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signing.Sign(payload, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{container.SignatureAnnotation: base64.StdEncoding.EncodeToString(signature)}, nil
+}
+
+// Signs the payload with an ephemeral key, certified by Fulcio for the OIDC identity, and records the signature in Rekor.
+func (sc *SignCommand) signKeyless(payload []byte) (map[string]string, error) {
+	idToken := sc.idToken
+	if idToken == "" {
+		idToken = os.Getenv(container.SigstoreIdTokenEnv)
+	}
+	if idToken == "" {
+		return nil, errorutils.CheckErrorf("keyless signing requires an OIDC identity token, provided by the %s environment variable", container.SigstoreIdTokenEnv)
+	}
+	var privateKey crypto.Signer
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	certificate, chain, err := container.GetFulcioCertificate(sc.fulcioUrl, idToken, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signing.Sign(payload, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := container.UploadToRekor(sc.rekorUrl, payload, signature, certificate)
+	if err != nil {
+		return nil, err
+	}
+	bundleJson, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return map[string]string{
+		container.SignatureAnnotation:   base64.StdEncoding.EncodeToString(signature),
+		container.CertificateAnnotation: certificate,
+		container.ChainAnnotation:       chain,
+		container.BundleAnnotation:      string(bundleJson),
+	}, nil
+}
+
+// Returns a registry client for the image repository, and the digest of the image manifest.
+func getImageDigest(image *container.Image, serviceManager artifactory.ArtifactoryServicesManager) (*container.RegistryClient, string, error) {
+	registryClient, err := container.NewRegistryClient(image, serviceManager)
+	if err != nil {
+		return nil, "", err
+	}
+	tag, err := image.GetImageTag()
+	if err != nil {
+		return nil, "", err
+	}
+	manifest, manifestDigest, err := registryClient.GetManifest(tag)
+	if err != nil {
+		return nil, "", err
+	}
+	if manifest == nil {
+		return nil, "", errorutils.CheckErrorf("the image %s wasn't found", image.Name())
+	}
+	return registryClient, manifestDigest, nil
+}
+
+// Returns the image name without the tag, for example: my-registry/docker-local/hello-world
+func getDockerReference(image *container.Image) (string, error) {
+	registry, err := image.GetRegistry()
+	if err != nil {
+		return "", err
+	}
+	longImageName, err := image.GetImageLongName()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(registry, longImageName), nil
+}
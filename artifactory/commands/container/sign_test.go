@@ -0,0 +1,117 @@
+package container
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testImageManifest = `{"schemaVersion":2,"mediaType":"application/vnd.docker.distribution.manifest.v2+json"}`
+
+// Simulates the manifests and blobs API of a container registry, storing the uploaded content in memory.
+func createTestRegistry(t *testing.T) *httptest.Server {
+	var mutex sync.Mutex
+	manifests := map[string][]byte{"1.0": []byte(testImageManifest)}
+	blobs := map[string][]byte{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		repoPath := strings.TrimPrefix(r.URL.Path, "/v2/docker-local/hello/")
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		switch {
+		case strings.HasPrefix(repoPath, "manifests/") && r.Method == http.MethodGet:
+			manifest, exists := manifests[strings.TrimPrefix(repoPath, "manifests/")]
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", container.GetDigest(manifest))
+			_, err = w.Write(manifest)
+			assert.NoError(t, err)
+		case strings.HasPrefix(repoPath, "manifests/") && r.Method == http.MethodPut:
+			manifests[strings.TrimPrefix(repoPath, "manifests/")] = content
+			w.WriteHeader(http.StatusCreated)
+		case repoPath == "blobs/uploads/" && r.Method == http.MethodPost:
+			w.Header().Set("Location", "/v2/docker-local/hello/blobs/uploads/session-id")
+			w.WriteHeader(http.StatusAccepted)
+		case strings.HasPrefix(repoPath, "blobs/uploads/") && r.Method == http.MethodPut:
+			digest := r.URL.Query().Get("digest")
+			assert.Equal(t, container.GetDigest(content), digest)
+			blobs[digest] = content
+			w.WriteHeader(http.StatusCreated)
+		case strings.HasPrefix(repoPath, "blobs/") && r.Method == http.MethodGet:
+			blob, exists := blobs[strings.TrimPrefix(repoPath, "blobs/")]
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, err = w.Write(blob)
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "unexpected request: "+r.Method+" "+r.URL.Path)
+		}
+	}))
+}
+
+func createTestKeyPair(t *testing.T, dir, name string) (privateKeyPath, publicKeyPath string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	privateKeyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	assert.NoError(t, err)
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	assert.NoError(t, err)
+	privateKeyPath = filepath.Join(dir, name+".key")
+	publicKeyPath = filepath.Join(dir, name+".pub")
+	assert.NoError(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKeyBytes}), 0600))
+	assert.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}), 0600))
+	return
+}
+
+func TestSignAndVerifyWithKey(t *testing.T) {
+	testServer := createTestRegistry(t)
+	defer testServer.Close()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: testServer.URL + "/artifactory/"}
+	imageTag := strings.TrimPrefix(testServer.URL, "http://") + "/docker-local/hello:1.0"
+	tempDir := t.TempDir()
+	privateKeyPath, publicKeyPath := createTestKeyPair(t, tempDir, "cosign")
+	_, otherPublicKeyPath := createTestKeyPair(t, tempDir, "other")
+
+	// Verify an unsigned image
+	verifyCmd := NewVerifyCommand().SetKeyPath(publicKeyPath)
+	verifyCmd.SetImageTag(imageTag).SetServerDetails(serverDetails)
+	assert.ErrorContains(t, verifyCmd.Run(), "no signatures were found")
+
+	// Sign twice, to make sure existing signatures are kept
+	for i := 0; i < 2; i++ {
+		signCmd := NewSignCommand().SetKeyPath(privateKeyPath)
+		signCmd.SetImageTag(imageTag).SetServerDetails(serverDetails)
+		assert.NoError(t, signCmd.Run())
+	}
+	assert.NoError(t, verifyCmd.Run())
+	assert.Equal(t, 2, verifyCmd.VerifiedCount())
+
+	// Verify with a different key
+	verifyCmd = NewVerifyCommand().SetKeyPath(otherPublicKeyPath)
+	verifyCmd.SetImageTag(imageTag).SetServerDetails(serverDetails)
+	assert.ErrorContains(t, verifyCmd.Run(), "no valid signatures were found")
+}
+
+func TestSignValidation(t *testing.T) {
+	signCmd := NewSignCommand().SetKeyless(true).SetKeyPath("cosign.key")
+	assert.Error(t, signCmd.Run())
+	assert.Error(t, NewVerifyCommand().Run())
+}
@@ -0,0 +1,133 @@
+package container
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/signing"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// VerifyCommand verifies an image in Artifactory has at least one valid cosign-compatible signature.
+// Signatures are verified either with a local public key, or keyless - against the expected identity of the signer.
+type VerifyCommand struct {
+	ContainerCommandBase
+	keyPath         string
+	keylessIdentity *container.KeylessIdentity
+	verifiedCount   int
+}
+
+func NewVerifyCommand() *VerifyCommand {
+	return &VerifyCommand{}
+}
+
+// SetKeyPath sets the path to the PEM public key used for verifying the signatures.
+func (vc *VerifyCommand) SetKeyPath(keyPath string) *VerifyCommand {
+	vc.keyPath = keyPath
+	return vc
+}
+
+func (vc *VerifyCommand) SetKeylessIdentity(keylessIdentity *container.KeylessIdentity) *VerifyCommand {
+	vc.keylessIdentity = keylessIdentity
+	return vc
+}
+
+// VerifiedCount returns the number of valid signatures found for the image.
+func (vc *VerifyCommand) VerifiedCount() int {
+	return vc.verifiedCount
+}
+
+func (vc *VerifyCommand) CommandName() string {
+	return "rt_docker_verify"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (vc *VerifyCommand) IsReadOnly() bool {
+	return true
+}
+
+func (vc *VerifyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return vc.serverDetails, nil
+}
+
+func (vc *VerifyCommand) Run() error {
+	if (vc.keylessIdentity != nil) == (vc.keyPath != "") {
+		return errorutils.CheckErrorf("either a public key or a keyless identity should be used")
+	}
+	var publicKey crypto.PublicKey
+	if vc.keyPath != "" {
+		var err error
+		if publicKey, err = signing.LoadPublicKey(vc.keyPath); err != nil {
+			return err
+		}
+	}
+	serviceManager, err := utils.CreateServiceManager(vc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	registryClient, manifestDigest, err := getImageDigest(vc.image, serviceManager)
+	if err != nil {
+		return err
+	}
+	signaturesManifest, err := registryClient.GetSignaturesManifest(manifestDigest)
+	if err != nil {
+		return err
+	}
+	if signaturesManifest == nil {
+		return errorutils.CheckErrorf("no signatures were found for %s@%s", vc.image.Name(), manifestDigest)
+	}
+	vc.verifiedCount = 0
+	for _, layer := range signaturesManifest.Layers {
+		if layer.MediaType != container.SimpleSigningMediaType {
+			continue
+		}
+		if err = vc.verifySignature(registryClient, layer, manifestDigest, publicKey); err != nil {
+			log.Debug(fmt.Sprintf("Skipping signature %s: %s", layer.Digest, err.Error()))
+			continue
+		}
+		vc.verifiedCount++
+	}
+	if vc.verifiedCount == 0 {
+		return errorutils.CheckErrorf("no valid signatures were found for %s@%s", vc.image.Name(), manifestDigest)
+	}
+	log.Info(fmt.Sprintf("Verified %d signatures of %s@%s", vc.verifiedCount, vc.image.Name(), manifestDigest))
+	return nil
+}
+
+// Verifies the signature layer signs the image digest. With keyless verification, the public key is taken from the signing certificate.
+func (vc *VerifyCommand) verifySignature(registryClient *container.RegistryClient, layer container.OciDescriptor, manifestDigest string, publicKey crypto.PublicKey) error {
+	payload, err := registryClient.GetBlob(layer.Digest)
+	if err != nil {
+		return err
+	}
+	var simpleSigning container.SimpleSigningPayload
+	if err = json.Unmarshal(payload, &simpleSigning); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if simpleSigning.Critical.Image.DockerManifestDigest != manifestDigest {
+		return errorutils.CheckErrorf("the signature is of a different image digest: %s", simpleSigning.Critical.Image.DockerManifestDigest)
+	}
+	signature, err := base64.StdEncoding.DecodeString(layer.Annotations[container.SignatureAnnotation])
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if vc.keylessIdentity != nil {
+		certificate := layer.Annotations[container.CertificateAnnotation]
+		signedAt, err := vc.keylessIdentity.VerifyBundle(layer.Annotations[container.BundleAnnotation], certificate, payload, signature)
+		if err != nil {
+			return err
+		}
+		if publicKey, err = vc.keylessIdentity.VerifyCertificate(certificate, layer.Annotations[container.ChainAnnotation], signedAt); err != nil {
+			return err
+		}
+	}
+	if !signing.Verify(payload, signature, publicKey) {
+		return errorutils.CheckErrorf("the signature doesn't match the public key")
+	}
+	return nil
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/jfrog/build-info-go/build"
 	"github.com/jfrog/build-info-go/build/utils/dotnet"
 	"github.com/jfrog/gofrog/io"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	commonBuild "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/auth"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -38,6 +40,12 @@ type DotnetCommand struct {
 	useNugetV2         bool
 	buildConfiguration *commonBuild.BuildConfiguration
 	serverDetails      *config.ServerDetails
+	// How packages which already exist in the repository are handled by the push command.
+	duplicatePolicy DuplicatePolicy
+	pushSummary     PushSummary
+	// The backups of the packages which were deleted from the repository, to be overwritten by the push.
+	overwrittenBackups *overwrittenBackups
+	dryRun             bool
 }
 
 func (dc *DotnetCommand) SetServerDetails(serverDetails *config.ServerDetails) *DotnetCommand {
@@ -80,6 +88,21 @@ func (dc *DotnetCommand) SetBasicCommand(subCommand string) *DotnetCommand {
 	return dc
 }
 
+func (dc *DotnetCommand) SetDuplicatePolicy(duplicatePolicy DuplicatePolicy) *DotnetCommand {
+	dc.duplicatePolicy = duplicatePolicy
+	return dc
+}
+
+// PushSummary returns the packages handled by the push command, if it runs with a duplicate policy.
+func (dc *DotnetCommand) PushSummary() PushSummary {
+	return dc.pushSummary
+}
+
+// Result returns the numbers of pushed packages and duplicates of the push command, if it runs with a duplicate policy.
+func (dc *DotnetCommand) Result() *commandsutils.Result {
+	return dc.pushResult()
+}
+
 func (dc *DotnetCommand) ServerDetails() (*config.ServerDetails, error) {
 	return dc.serverDetails, nil
 }
@@ -92,8 +115,23 @@ func (dc *DotnetCommand) CommandName() string {
 	return "rt_" + dc.toolchainType.String()
 }
 
+// IsReadOnly implements commands.ReadOnlyCommand. Only the push command deploys packages.
+func (dc *DotnetCommand) IsReadOnly() bool {
+	return !dc.isPushCommand()
+}
+
+// EnableDryRun implements commands.DryRunCommand.
+// In dry run mode, the push command reports the packages it would push, without running the package manager.
+func (dc *DotnetCommand) EnableDryRun() {
+	dc.dryRun = true
+}
+
 // Exec all consume type nuget commands, install, update, add, restore.
 func (dc *DotnetCommand) Exec() (err error) {
+	if dc.dryRun && dc.isPushCommand() {
+		commands.LogDryRunAction("Pushing packages to:", dc.repoName, "by running:", dc.toolchainType.String(), dc.subCommand, strings.Join(dc.argAndFlags, " "))
+		return nil
+	}
 	log.Info("Running " + dc.toolchainType.String() + "...")
 	buildName, err := dc.buildConfiguration.GetBuildName()
 	if err != nil {
@@ -122,12 +160,33 @@ func (dc *DotnetCommand) Exec() (err error) {
 			err = errors.Join(err, callbackFunc())
 		}
 	}()
+	if dc.isPushCommand() && dc.duplicatePolicy != DuplicateDefault {
+		var shouldPush bool
+		var cleanup func() error
+		shouldPush, cleanup, err = dc.applyDuplicatePolicy()
+		if cleanup != nil {
+			defer func() {
+				err = errors.Join(err, cleanup())
+			}()
+		}
+		if err != nil || !shouldPush {
+			dc.logPushSummary()
+			return err
+		}
+		buildInfoModule.SetArgAndFlags(dc.argAndFlags)
+	}
 	if err = buildInfoModule.CalcDependencies(); err != nil {
+		// The packages weren't pushed.
+		dc.pushSummary.Pushed = nil
+		err = errors.Join(err, dc.restoreOverwrittenPackages())
 		if dc.isDotnetTestCommand() {
 			return errors.New(dotnetTestError + err.Error())
 		}
 		return err
 	}
+	if dc.isPushCommand() && dc.duplicatePolicy != DuplicateDefault {
+		dc.logPushSummary()
+	}
 	log.Info(fmt.Sprintf("%s finished successfully.", dc.toolchainType))
 	return nil
 }
@@ -171,19 +230,35 @@ func (dc *DotnetCommand) AddNugetAuthToConfig(cmdType dotnet.ToolchainType, conf
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
-	return addSourceToNugetConfig(cmdType, configFile.Name(), sourceUrl, user, password)
+	return addSourceToNugetConfig(cmdType, configFile.Name(), SourceName, sourceUrl, user, password)
 }
 
-// Runs nuget sources add command
-func addSourceToNugetConfig(cmdType dotnet.ToolchainType, configFileName, sourceUrl, user, password string) error {
+// AddUserNugetSource sets the Artifactory repository as a source in the user's NuGet configuration, so that it's used by the toolchain outside JFrog CLI.
+// An existing source with the same name is replaced.
+func AddUserNugetSource(cmdType dotnet.ToolchainType, server *config.ServerDetails, repoName, sourceName string, useNugetV2 bool) error {
+	sourceUrl, user, password, err := getSourceDetails(server, repoName, useNugetV2)
+	if err != nil {
+		return err
+	}
+	if err = removeSourceFromNugetConfig(cmdType, sourceName); err != nil {
+		log.Debug("The '" + sourceName + "' source wasn't removed from the NuGet configuration: " + err.Error())
+	}
+	return addSourceToNugetConfig(cmdType, "", sourceName, sourceUrl, user, password)
+}
+
+// Runs nuget sources add command.
+// If the config file name is empty, the source is added to the user's NuGet configuration.
+func addSourceToNugetConfig(cmdType dotnet.ToolchainType, configFileName, sourceName, sourceUrl, user, password string) error {
 	cmd, err := dotnet.CreateDotnetAddSourceCmd(cmdType, sourceUrl)
 	if err != nil {
 		return err
 	}
 
 	flagPrefix := cmdType.GetTypeFlagPrefix()
-	cmd.CommandFlags = append(cmd.CommandFlags, flagPrefix+"configfile", configFileName)
-	cmd.CommandFlags = append(cmd.CommandFlags, flagPrefix+"name", SourceName)
+	if configFileName != "" {
+		cmd.CommandFlags = append(cmd.CommandFlags, flagPrefix+"configfile", configFileName)
+	}
+	cmd.CommandFlags = append(cmd.CommandFlags, flagPrefix+"name", sourceName)
 	cmd.CommandFlags = append(cmd.CommandFlags, flagPrefix+"username", user)
 	cmd.CommandFlags = append(cmd.CommandFlags, flagPrefix+"password", password)
 	output, err := io.RunCmdOutput(cmd)
@@ -191,6 +266,23 @@ func addSourceToNugetConfig(cmdType dotnet.ToolchainType, configFileName, source
 	return err
 }
 
+// Runs nuget sources remove command on the user's NuGet configuration.
+func removeSourceFromNugetConfig(cmdType dotnet.ToolchainType, sourceName string) error {
+	cmd, err := dotnet.NewToolchainCmd(cmdType)
+	if err != nil {
+		return err
+	}
+	if cmdType == dotnet.Nuget {
+		cmd.Command = append(cmd.Command, "sources", "remove")
+		cmd.CommandFlags = append(cmd.CommandFlags, "-name", sourceName)
+	} else {
+		cmd.Command = append(cmd.Command, "nuget", "remove", "source", sourceName)
+	}
+	output, err := io.RunCmdOutput(cmd)
+	log.Debug("'Remove source' command executed. Output:", output)
+	return err
+}
+
 // Checks if the user provided input such as -configfile flag or -Source flag.
 // If those flags were provided, NuGet will use the provided configs (default config file or the one with -configfile)
 // If neither provided, we are initializing our own config.
@@ -320,5 +412,6 @@ func getSourceDetails(details *config.ServerDetails, repoName string, useNugetV2
 		}
 		password = details.AccessToken
 	}
+	config.WarnIfApiKeyEmbedded(password, details.AccessToken, "NuGet source configuration")
 	return
 }
@@ -0,0 +1,429 @@
+package dotnet
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/build-info-go/build/utils/dotnet"
+	biutils "github.com/jfrog/build-info-go/utils"
+	ioutils "github.com/jfrog/gofrog/io"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// DuplicatePolicy determines how packages which already exist in the target repository are handled by the push command.
+type DuplicatePolicy string
+
+const (
+	// The packages are pushed as is, and duplicates are handled by Artifactory according to the repository configuration.
+	DuplicateDefault DuplicatePolicy = ""
+	// Packages which already exist in the repository aren't pushed.
+	DuplicateSkip DuplicatePolicy = "skip"
+	// Packages which already exist in the repository with a different content are deleted before the push.
+	// The deleted packages are backed up locally, and deployed back to the repository if the push fails.
+	DuplicateOverwrite DuplicatePolicy = "overwrite"
+	// The push fails, before pushing any package, if any of the packages already exists in the repository.
+	DuplicateFail DuplicatePolicy = "fail"
+)
+
+func ParseDuplicatePolicy(policy string) (DuplicatePolicy, error) {
+	switch DuplicatePolicy(strings.ToLower(policy)) {
+	case DuplicateDefault:
+		return DuplicateDefault, nil
+	case DuplicateSkip:
+		return DuplicateSkip, nil
+	case DuplicateOverwrite:
+		return DuplicateOverwrite, nil
+	case DuplicateFail:
+		return DuplicateFail, nil
+	}
+	return "", errorutils.CheckErrorf("invalid duplicate policy '%s'. Possible values are: %s, %s and %s", policy, DuplicateSkip, DuplicateOverwrite, DuplicateFail)
+}
+
+// PushSummary lists the packages handled by a push command with a duplicate policy, by their IDs and versions.
+type PushSummary struct {
+	Pushed      []string
+	Overwritten []string
+	Skipped     []string
+	Duplicates  []string
+}
+
+type nugetPackage struct {
+	localPath string
+	id        string
+	version   string
+	sha1      string
+	// The files of the package in the repository, if it already exists.
+	existing []servicesutils.ResultItem
+}
+
+func (np *nugetPackage) String() string {
+	return np.id + " " + np.version
+}
+
+// Returns true if one of the existing files in the repository has the same content as the local package.
+func (np *nugetPackage) isIdentical() bool {
+	for _, item := range np.existing {
+		if strings.EqualFold(item.Actual_Sha1, np.sha1) {
+			return true
+		}
+	}
+	return false
+}
+
+func (dc *DotnetCommand) isPushCommand() bool {
+	if dc.GetToolchain() == dotnet.Nuget {
+		return dc.subCommand == "push"
+	}
+	return dc.subCommand == "nuget" && len(dc.argAndFlags) > 0 && dc.argAndFlags[0] == "push"
+}
+
+// Returns the index of the packages path argument of the push command, or -1 if it isn't found.
+func (dc *DotnetCommand) getPushPackagesArgIndex() int {
+	for i, arg := range dc.argAndFlags {
+		if !strings.HasPrefix(arg, "-") && strings.HasSuffix(strings.ToLower(arg), ".nupkg") {
+			return i
+		}
+	}
+	return -1
+}
+
+// Handles the packages of the push command which already exist in the target repository according to the duplicate policy.
+// The packages path argument is replaced, so that only the packages which should be pushed are pushed.
+// Returns false if there are no packages to push, and a cleanup function which should be called after the push.
+func (dc *DotnetCommand) applyDuplicatePolicy() (shouldPush bool, cleanup func() error, err error) {
+	argIndex := dc.getPushPackagesArgIndex()
+	if argIndex < 0 {
+		log.Warn("The path of the packages to push wasn't found in the arguments, so the duplicate policy isn't applied.")
+		return true, nil, nil
+	}
+	packagesPaths, err := filepath.Glob(dc.argAndFlags[argIndex])
+	if err != nil || len(packagesPaths) == 0 {
+		// The toolchain reports the missing packages.
+		return true, nil, errorutils.CheckError(err)
+	}
+	servicesManager, err := utils.CreateServiceManager(dc.serverDetails, -1, 0, false)
+	if err != nil {
+		return false, nil, err
+	}
+	deploymentRepo, err := utils.GetDeploymentRepo(dc.repoName, servicesManager)
+	if err != nil {
+		return false, nil, err
+	}
+	var toPush []*nugetPackage
+	for _, packagePath := range packagesPaths {
+		pkg, err := readNugetPackage(packagePath)
+		if err != nil {
+			return false, nil, err
+		}
+		if pkg.existing, err = searchNugetPackage(servicesManager, deploymentRepo, pkg); err != nil {
+			return false, nil, err
+		}
+		if dc.handleExistingPackage(deploymentRepo, pkg) {
+			toPush = append(toPush, pkg)
+		}
+	}
+	if len(dc.pushSummary.Duplicates) > 0 {
+		return false, nil, errorutils.CheckErrorf("the following packages already exist in the '%s' repository:\n%s", deploymentRepo, strings.Join(dc.pushSummary.Duplicates, "\n"))
+	}
+	for _, pkg := range toPush {
+		dc.pushSummary.Pushed = append(dc.pushSummary.Pushed, pkg.String())
+	}
+	if len(toPush) == 0 {
+		return false, nil, nil
+	}
+	backupsCleanup, err := dc.deleteOverwrittenPackages(servicesManager, deploymentRepo, toPush)
+	if err != nil {
+		return false, backupsCleanup, errors.Join(err, dc.restoreOverwrittenPackages())
+	}
+	if len(toPush) == len(packagesPaths) {
+		return true, backupsCleanup, nil
+	}
+	packagesCleanup, err := dc.replacePushedPackages(argIndex, toPush)
+	return true, joinCleanups(backupsCleanup, packagesCleanup), err
+}
+
+// Returns a function which calls the non-nil cleanup functions, or nil if all of them are nil.
+func joinCleanups(cleanups ...func() error) func() error {
+	var nonNil []func() error
+	for _, cleanup := range cleanups {
+		if cleanup != nil {
+			nonNil = append(nonNil, cleanup)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return func() (err error) {
+		for _, cleanup := range nonNil {
+			err = errors.Join(err, cleanup())
+		}
+		return
+	}
+}
+
+// Returns true if the package should be pushed. Existing packages which should be overwritten are deleted by deleteOverwrittenPackages.
+func (dc *DotnetCommand) handleExistingPackage(repo string, pkg *nugetPackage) bool {
+	if len(pkg.existing) == 0 {
+		return true
+	}
+	switch dc.duplicatePolicy {
+	case DuplicateSkip:
+		log.Info(fmt.Sprintf("Skipping %s, which already exists in the '%s' repository.", pkg, repo))
+		dc.pushSummary.Skipped = append(dc.pushSummary.Skipped, pkg.String())
+		return false
+	case DuplicateOverwrite:
+		if pkg.isIdentical() {
+			log.Info(fmt.Sprintf("Skipping %s, which already exists in the '%s' repository with the same content.", pkg, repo))
+			dc.pushSummary.Skipped = append(dc.pushSummary.Skipped, pkg.String())
+			return false
+		}
+		dc.pushSummary.Overwritten = append(dc.pushSummary.Overwritten, pkg.String())
+		return true
+	default:
+		dc.pushSummary.Duplicates = append(dc.pushSummary.Duplicates, pkg.String())
+		return false
+	}
+}
+
+// The local backups of the files deleted from the repository, to be overwritten by the push.
+type overwrittenBackups struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	files           []backupFile
+}
+
+type backupFile struct {
+	item      servicesutils.ResultItem
+	localPath string
+}
+
+// Deletes the existing files of the packages to push from the repository, so that the push overwrites them.
+// The files are downloaded to a temp directory before any of them is deleted, so that they can be deployed back by
+// restoreOverwrittenPackages if the push fails. The returned cleanup function removes the backups.
+func (dc *DotnetCommand) deleteOverwrittenPackages(servicesManager artifactory.ArtifactoryServicesManager, repo string, toPush []*nugetPackage) (cleanup func() error, err error) {
+	var toDelete []*nugetPackage
+	for _, pkg := range toPush {
+		if len(pkg.existing) > 0 {
+			toDelete = append(toDelete, pkg)
+		}
+	}
+	if len(toDelete) == 0 {
+		return nil, nil
+	}
+	backupDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, err
+	}
+	cleanup = func() error {
+		return fileutils.RemoveTempDir(backupDir)
+	}
+	backups := &overwrittenBackups{servicesManager: servicesManager}
+	for _, pkg := range toDelete {
+		for _, item := range pkg.existing {
+			localPath := filepath.Join(backupDir, strconv.Itoa(len(backups.files)))
+			if err = downloadItem(servicesManager, item, localPath); err != nil {
+				return cleanup, err
+			}
+			backups.files = append(backups.files, backupFile{item: item, localPath: localPath})
+		}
+	}
+	dc.overwrittenBackups = backups
+	for _, pkg := range toDelete {
+		log.Info(fmt.Sprintf("Deleting %s from the '%s' repository, to overwrite it. It's deployed back if the push fails.", pkg, repo))
+		for _, item := range pkg.existing {
+			if err = deleteItem(servicesManager, item); err != nil {
+				return cleanup, err
+			}
+		}
+	}
+	return cleanup, nil
+}
+
+// Deploys the deleted files of the overwritten packages back to the repository, after a failed push.
+// Files which exist in the repository, because their packages were pushed before the failure, are kept.
+func (dc *DotnetCommand) restoreOverwrittenPackages() (err error) {
+	if dc.overwrittenBackups == nil {
+		return nil
+	}
+	servicesManager := dc.overwrittenBackups.servicesManager
+	for _, backup := range dc.overwrittenBackups.files {
+		itemPath := path.Join(backup.item.Repo, backup.item.Path, backup.item.Name)
+		exists, existsErr := isItemExists(servicesManager, itemPath)
+		if existsErr != nil || exists {
+			err = errors.Join(err, existsErr)
+			continue
+		}
+		log.Info(fmt.Sprintf("Deploying %s back to the repository, since the push failed.", itemPath))
+		err = errors.Join(err, uploadItem(servicesManager, backup.localPath, itemPath))
+	}
+	dc.overwrittenBackups = nil
+	dc.pushSummary.Overwritten = nil
+	return
+}
+
+// Replaces the packages path argument with a path of only the packages to push.
+// Multiple packages are copied to a temp directory, and pushed by a wildcard path.
+func (dc *DotnetCommand) replacePushedPackages(argIndex int, toPush []*nugetPackage) (cleanup func() error, err error) {
+	if len(toPush) == 1 {
+		dc.argAndFlags[argIndex] = toPush[0].localPath
+		return nil, nil
+	}
+	tempDirPath, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, err
+	}
+	cleanup = func() error {
+		return fileutils.RemoveTempDir(tempDirPath)
+	}
+	for _, pkg := range toPush {
+		if err = biutils.CopyFile(tempDirPath, pkg.localPath); err != nil {
+			return cleanup, errorutils.CheckError(err)
+		}
+	}
+	dc.argAndFlags[argIndex] = filepath.Join(tempDirPath, "*.nupkg")
+	return cleanup, nil
+}
+
+// Returns the result of the push command: the pushed packages succeeded, and the duplicates failed.
+func (dc *DotnetCommand) pushResult() *commandsutils.Result {
+	result := new(commandsutils.Result)
+	result.SetSuccessCount(len(dc.pushSummary.Pushed))
+	result.SetFailCount(len(dc.pushSummary.Duplicates))
+	return result
+}
+
+func (dc *DotnetCommand) logPushSummary() {
+	log.Info(fmt.Sprintf("Push summary: %d pushed (%d overwritten), %d skipped, %d duplicates.",
+		len(dc.pushSummary.Pushed), len(dc.pushSummary.Overwritten), len(dc.pushSummary.Skipped), len(dc.pushSummary.Duplicates)))
+}
+
+type nuspec struct {
+	Metadata struct {
+		Id      string `xml:"id"`
+		Version string `xml:"version"`
+	} `xml:"metadata"`
+}
+
+// Reads the ID and version of the package from its nuspec file.
+func readNugetPackage(packagePath string) (pkg *nugetPackage, err error) {
+	zipReader, err := zip.OpenReader(packagePath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed opening the NuGet package %s: %s", packagePath, err.Error())
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(zipReader.Close()))
+	}()
+	for _, file := range zipReader.File {
+		if strings.Contains(file.Name, "/") || !strings.HasSuffix(strings.ToLower(file.Name), ".nuspec") {
+			continue
+		}
+		var spec nuspec
+		reader, err := file.Open()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		err = errors.Join(xml.NewDecoder(reader).Decode(&spec), reader.Close())
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed parsing the nuspec file of %s: %s", packagePath, err.Error())
+		}
+		if spec.Metadata.Id == "" || spec.Metadata.Version == "" {
+			return nil, errorutils.CheckErrorf("the nuspec file of %s has no package ID or version", packagePath)
+		}
+		details, err := fileutils.GetFileDetails(packagePath, true)
+		if err != nil {
+			return nil, err
+		}
+		return &nugetPackage{localPath: packagePath, id: spec.Metadata.Id, version: spec.Metadata.Version, sha1: details.Checksum.Sha1}, nil
+	}
+	return nil, errorutils.CheckErrorf("no nuspec file was found in %s", packagePath)
+}
+
+// Searches the files of the package version in the repository, by the NuGet properties set by Artifactory or by the package file name.
+func searchNugetPackage(servicesManager artifactory.ArtifactoryServicesManager, repo string, pkg *nugetPackage) (items []servicesutils.ResultItem, err error) {
+	query := fmt.Sprintf(`items.find({"repo":"%s","type":"file","$or":[{"$and":[{"@nuget.id":{"$match":"%s"}},{"@nuget.version":{"$match":"%s"}}]},{"name":{"$match":"%s"}}]}).include("repo","path","name","actual_sha1")`,
+		repo, pkg.id, pkg.version, pkg.id+"."+pkg.version+".nupkg")
+	stream, err := servicesManager.Aql(query)
+	if err != nil {
+		return nil, err
+	}
+	defer ioutils.Close(stream, &err)
+	content, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	result := new(servicesutils.AqlSearchResult)
+	if err = json.Unmarshal(content, result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return result.Results, nil
+}
+
+func downloadItem(servicesManager artifactory.ArtifactoryServicesManager, item servicesutils.ResultItem, localPath string) (err error) {
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	itemPath := path.Join(item.Repo, item.Path, item.Name)
+	reader, resp, err := servicesManager.Client().ReadRemoteFile(servicesManager.GetConfig().GetServiceDetails().GetUrl()+itemPath, &httpDetails)
+	if err != nil {
+		return err
+	}
+	if reader == nil {
+		// The body of unsuccessful responses isn't returned by the client.
+		return errors.Join(errorutils.CheckErrorf("failed backing up %s: received status %s", itemPath, resp.Status), resp.Body.Close())
+	}
+	defer ioutils.Close(reader, &err)
+	localFile, err := os.Create(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer ioutils.Close(localFile, &err)
+	_, err = io.Copy(localFile, reader)
+	return errorutils.CheckError(err)
+}
+
+func uploadItem(servicesManager artifactory.ArtifactoryServicesManager, localPath, itemPath string) error {
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().UploadFile(localPath, servicesManager.GetConfig().GetServiceDetails().GetUrl()+itemPath, "", &httpDetails, nil)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated)
+}
+
+func isItemExists(servicesManager artifactory.ArtifactoryServicesManager, itemPath string) (bool, error) {
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendHead(servicesManager.GetConfig().GetServiceDetails().GetUrl()+itemPath, &httpDetails)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	return true, errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
+
+func deleteItem(servicesManager artifactory.ArtifactoryServicesManager, item servicesutils.ResultItem) error {
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	itemPath := path.Join(item.Repo, item.Path, item.Name)
+	resp, body, err := servicesManager.Client().SendDelete(servicesManager.GetConfig().GetServiceDetails().GetUrl()+itemPath, nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
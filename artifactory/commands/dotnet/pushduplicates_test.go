@@ -0,0 +1,154 @@
+package dotnet
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/build-info-go/build/utils/dotnet"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Creates a NuGet package with the ID and version in the directory, and returns its SHA-1 checksum.
+func createNugetPackage(t *testing.T, dir, id, version string) string {
+	packagePath := filepath.Join(dir, id+"."+version+".nupkg")
+	packageFile, err := os.Create(packagePath)
+	require.NoError(t, err)
+	zipWriter := zip.NewWriter(packageFile)
+	nuspecWriter, err := zipWriter.Create(id + ".nuspec")
+	require.NoError(t, err)
+	_, err = fmt.Fprintf(nuspecWriter, `<?xml version="1.0" encoding="utf-8"?>
+<package xmlns="http://schemas.microsoft.com/packaging/2013/05/nuspec.xsd"><metadata><id>%s</id><version>%s</version></metadata></package>`, id, version)
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, packageFile.Close())
+	details, err := fileutils.GetFileDetails(packagePath, true)
+	require.NoError(t, err)
+	return details.Checksum.Sha1
+}
+
+func TestApplyDuplicatePolicy(t *testing.T) {
+	packagesDir := t.TempDir()
+	createNugetPackage(t, packagesDir, "New.Pkg", "1.0.0")
+	identicalSha1 := createNugetPackage(t, packagesDir, "Identical.Pkg", "1.0.0")
+	createNugetPackage(t, packagesDir, "Changed.Pkg", "2.0.0")
+
+	const changedPkgPath = "/nuget-local/Changed.Pkg/Changed.Pkg.2.0.0.nupkg"
+	var deleted, restored []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/repositories/nuget-virtual":
+			_, err := w.Write([]byte(`{"key":"nuget-virtual","rclass":"virtual","defaultDeploymentRepo":"nuget-local"}`))
+			assert.NoError(t, err)
+		case r.URL.Path == "/api/search/aql":
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), `"repo":"nuget-local"`)
+			results := ""
+			if strings.Contains(string(body), "Identical.Pkg") {
+				results = `{"repo":"nuget-local","path":"Identical.Pkg","name":"Identical.Pkg.1.0.0.nupkg","actual_sha1":"` + identicalSha1 + `"}`
+			} else if strings.Contains(string(body), "Changed.Pkg") {
+				results = `{"repo":"nuget-local","path":"Changed.Pkg","name":"Changed.Pkg.2.0.0.nupkg","actual_sha1":"0000"}`
+			}
+			_, err = w.Write([]byte(`{"results":[` + results + `]}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodGet && r.URL.Path == changedPkgPath:
+			// The existing package is backed up before it's deleted.
+			assert.Empty(t, deleted)
+			_, err := w.Write([]byte("existing package"))
+			assert.NoError(t, err)
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			restored = append(restored, r.URL.Path+": "+string(body))
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	newPushCommand := func(policy DuplicatePolicy) *DotnetCommand {
+		pushCmd := &DotnetCommand{}
+		pushCmd.SetToolchainType(dotnet.DotnetCore).SetBasicCommand("nuget").SetRepoName("nuget-virtual").SetDuplicatePolicy(policy).
+			SetArgAndFlags([]string{"push", filepath.Join(packagesDir, "*.nupkg"), "--api-key", "key"}).
+			SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"})
+		return pushCmd
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		pushCmd := newPushCommand(DuplicateSkip)
+		shouldPush, cleanup, err := pushCmd.applyDuplicatePolicy()
+		assert.NoError(t, err)
+		assert.True(t, shouldPush)
+		assert.Nil(t, cleanup)
+		// Only the new package is pushed.
+		assert.Equal(t, filepath.Join(packagesDir, "New.Pkg.1.0.0.nupkg"), pushCmd.argAndFlags[1])
+		assert.Equal(t, PushSummary{Pushed: []string{"New.Pkg 1.0.0"}, Skipped: []string{"Changed.Pkg 2.0.0", "Identical.Pkg 1.0.0"}}, pushCmd.PushSummary())
+		assert.Equal(t, 1, pushCmd.Result().SuccessCount())
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		pushCmd := newPushCommand(DuplicateOverwrite)
+		shouldPush, cleanup, err := pushCmd.applyDuplicatePolicy()
+		assert.NoError(t, err)
+		assert.True(t, shouldPush)
+		require.NotNil(t, cleanup)
+		defer func() {
+			assert.NoError(t, cleanup())
+		}()
+		// The changed and new packages are copied to a temp directory and pushed.
+		pushedPackages, err := filepath.Glob(pushCmd.argAndFlags[1])
+		assert.NoError(t, err)
+		assert.Len(t, pushedPackages, 2)
+		assert.Equal(t, []string{changedPkgPath}, deleted)
+		assert.Equal(t, PushSummary{Pushed: []string{"Changed.Pkg 2.0.0", "New.Pkg 1.0.0"}, Overwritten: []string{"Changed.Pkg 2.0.0"}, Skipped: []string{"Identical.Pkg 1.0.0"}}, pushCmd.PushSummary())
+
+		// If the push fails, the deleted package is deployed back from its backup.
+		assert.NoError(t, pushCmd.restoreOverwrittenPackages())
+		assert.Equal(t, []string{changedPkgPath + ": existing package"}, restored)
+		assert.Empty(t, pushCmd.PushSummary().Overwritten)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		pushCmd := newPushCommand(DuplicateFail)
+		shouldPush, _, err := pushCmd.applyDuplicatePolicy()
+		assert.EqualError(t, err, "the following packages already exist in the 'nuget-local' repository:\nChanged.Pkg 2.0.0\nIdentical.Pkg 1.0.0")
+		assert.False(t, shouldPush)
+		assert.Equal(t, 2, pushCmd.Result().FailCount())
+	})
+}
+
+func TestParseDuplicatePolicy(t *testing.T) {
+	policy, err := ParseDuplicatePolicy("Overwrite")
+	assert.NoError(t, err)
+	assert.Equal(t, DuplicateOverwrite, policy)
+	_, err = ParseDuplicatePolicy("ignore")
+	assert.ErrorContains(t, err, "invalid duplicate policy 'ignore'")
+}
+
+func TestPushDryRun(t *testing.T) {
+	pushCmd := NewNugetCommand()
+	pushCmd.SetBasicCommand("restore")
+	assert.True(t, pushCmd.IsReadOnly())
+
+	// The push command isn't run in dry run mode, so no build configuration or package manager is needed.
+	pushCmd.SetBasicCommand("push").SetArgAndFlags([]string{"app.1.0.0.nupkg"}).SetRepoName("nuget-local")
+	assert.False(t, pushCmd.IsReadOnly())
+	pushCmd.EnableDryRun()
+	assert.NoError(t, pushCmd.Run())
+}
@@ -0,0 +1,68 @@
+package federation
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// FederationConvertCommand converts a local repository to a federated repository, keeping its content, and optionally
+// adds members to the new federation.
+type FederationConvertCommand struct {
+	serverDetails *config.ServerDetails
+	repoKey       string
+	memberUrls    []string
+}
+
+func NewFederationConvertCommand() *FederationConvertCommand {
+	return &FederationConvertCommand{}
+}
+
+func (fcc *FederationConvertCommand) SetServerDetails(serverDetails *config.ServerDetails) *FederationConvertCommand {
+	fcc.serverDetails = serverDetails
+	return fcc
+}
+
+func (fcc *FederationConvertCommand) SetRepoKey(repoKey string) *FederationConvertCommand {
+	fcc.repoKey = repoKey
+	return fcc
+}
+
+func (fcc *FederationConvertCommand) SetMemberUrls(memberUrls []string) *FederationConvertCommand {
+	fcc.memberUrls = memberUrls
+	return fcc
+}
+
+func (fcc *FederationConvertCommand) ServerDetails() (*config.ServerDetails, error) {
+	return fcc.serverDetails, nil
+}
+
+func (fcc *FederationConvertCommand) CommandName() string {
+	return "rt_federation_convert"
+}
+
+func (fcc *FederationConvertCommand) Run() error {
+	service, err := newFederationService(fcc.serverDetails)
+	if err != nil {
+		return err
+	}
+	repoConfig, err := service.getRepoConfig(fcc.repoKey)
+	if err != nil {
+		return err
+	}
+	switch repoConfig["rclass"] {
+	case federatedRclass:
+		log.Info("Repository " + fcc.repoKey + " is already a federated repository.")
+	case "local":
+		log.Info("Converting repository " + fcc.repoKey + " to a federated repository...")
+		if err = service.convertToFederated(fcc.repoKey); err != nil {
+			return err
+		}
+	default:
+		return errorutils.CheckErrorf("only local repositories can be converted to federated repositories, but '%s' is a %v repository", fcc.repoKey, repoConfig["rclass"])
+	}
+	if len(fcc.memberUrls) == 0 {
+		return nil
+	}
+	return addMembers(service, fcc.repoKey, fcc.memberUrls)
+}
@@ -0,0 +1,135 @@
+package federation
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"net/http"
+	"strings"
+)
+
+const (
+	repositoriesApi     = "api/repositories/"
+	federationMigrate   = "api/federation/migrate/"
+	federationStatusApi = "api/federation/status/repo/"
+	federatedRclass     = "federated"
+)
+
+// federationService sends the federated repositories requests to Artifactory.
+// The repository configuration is handled as a generic map, so that fields unknown to the CLI are kept when the members are updated.
+type federationService struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+}
+
+func newFederationService(serverDetails *config.ServerDetails) (*federationService, error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	return &federationService{servicesManager: servicesManager}, nil
+}
+
+func (fs *federationService) getRepoConfig(repoKey string) (map[string]any, error) {
+	body, err := fs.send(http.MethodGet, repositoriesApi+repoKey, nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	repoConfig := map[string]any{}
+	return repoConfig, errorutils.CheckError(json.Unmarshal(body, &repoConfig))
+}
+
+// Returns the configuration of a federated repository, and its members.
+func (fs *federationService) getFederatedRepo(repoKey string) (map[string]any, []services.FederatedRepositoryMember, error) {
+	repoConfig, err := fs.getRepoConfig(repoKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if repoConfig["rclass"] != federatedRclass {
+		return nil, nil, errorutils.CheckErrorf("repository '%s' is not a federated repository", repoKey)
+	}
+	content, err := json.Marshal(repoConfig["members"])
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	var members []services.FederatedRepositoryMember
+	if string(content) != "null" {
+		if err = json.Unmarshal(content, &members); err != nil {
+			return nil, nil, errorutils.CheckError(err)
+		}
+	}
+	return repoConfig, members, nil
+}
+
+func (fs *federationService) updateMembers(repoKey string, repoConfig map[string]any, members []services.FederatedRepositoryMember) error {
+	repoConfig["members"] = members
+	_, err := fs.send(http.MethodPost, repositoriesApi+repoKey, repoConfig, http.StatusOK)
+	return err
+}
+
+func (fs *federationService) convertToFederated(repoKey string) error {
+	_, err := fs.send(http.MethodPost, federationMigrate+repoKey, nil, http.StatusOK)
+	return err
+}
+
+func (fs *federationService) getStatus(repoKey string) (*FederationStatus, error) {
+	body, err := fs.send(http.MethodGet, federationStatusApi+repoKey, nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	status := &FederationStatus{}
+	return status, errorutils.CheckError(json.Unmarshal(body, status))
+}
+
+func (fs *federationService) send(method, restApi string, payload any, expectedStatusCodes ...int) ([]byte, error) {
+	serviceDetails := fs.servicesManager.GetConfig().GetServiceDetails()
+	httpDetails := serviceDetails.CreateHttpClientDetails()
+	requestUrl := serviceDetails.GetUrl() + restApi
+	var resp *http.Response
+	var body []byte
+	var err error
+	switch method {
+	case http.MethodGet:
+		resp, body, _, err = fs.servicesManager.Client().SendGet(requestUrl, true, &httpDetails)
+	default:
+		var content []byte
+		if payload != nil {
+			if content, err = json.Marshal(payload); err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			servicesUtils.SetContentType("application/json", &httpDetails.Headers)
+		}
+		resp, body, err = fs.servicesManager.Client().SendPost(requestUrl, content, &httpDetails)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errorutils.CheckErrorf("%s was not found: %s", strings.TrimSuffix(restApi, "/"), string(body))
+	}
+	return body, errorutils.CheckResponseStatusWithBody(resp, body, expectedStatusCodes...)
+}
+
+// Members are compared by their URL, ignoring a trailing slash and the letter case.
+func isSameMember(url1, url2 string) bool {
+	return strings.EqualFold(strings.TrimSuffix(url1, "/"), strings.TrimSuffix(url2, "/"))
+}
+
+func validateMemberUrl(memberUrl string) error {
+	if !strings.HasPrefix(memberUrl, "http://") && !strings.HasPrefix(memberUrl, "https://") {
+		return errorutils.CheckErrorf("invalid member URL '%s'. A member URL should be in the form of <artifactory-url>/<repo-key>", memberUrl)
+	}
+	return nil
+}
+
+func formatMembers(members []services.FederatedRepositoryMember) string {
+	var urls []string
+	for _, member := range members {
+		urls = append(urls, member.Url)
+	}
+	return fmt.Sprintf("[%s]", strings.Join(urls, ", "))
+}
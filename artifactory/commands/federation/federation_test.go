@@ -0,0 +1,102 @@
+package federation
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Simulates the repositories API of Artifactory, holding the configuration of a single repository.
+func createTestServer(t *testing.T, repoConfig map[string]any) (*httptest.Server, *[]string) {
+	var requests []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/api/repositories/repo1" && r.Method == http.MethodGet:
+			content, err := json.Marshal(repoConfig)
+			assert.NoError(t, err)
+			_, err = w.Write(content)
+			assert.NoError(t, err)
+		case r.URL.Path == "/api/repositories/repo1" && r.Method == http.MethodPost:
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(content, &repoConfig))
+		case r.URL.Path == "/api/federation/migrate/repo1" && r.Method == http.MethodPost:
+			repoConfig["rclass"] = federatedRclass
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return testServer, &requests
+}
+
+func getMemberUrls(t *testing.T, repoConfig map[string]any) []string {
+	var urls []string
+	members, ok := repoConfig["members"].([]any)
+	require.True(t, ok)
+	for _, member := range members {
+		urls = append(urls, member.(map[string]any)["url"].(string))
+	}
+	return urls
+}
+
+func TestFederationMembers(t *testing.T) {
+	repoConfig := map[string]any{"key": "repo1", "rclass": federatedRclass, "packageType": "maven", "xrayIndex": true,
+		"members": []any{map[string]any{"url": "https://site1.jfrog.io/artifactory/repo1", "enabled": true}}}
+	testServer, _ := createTestServer(t, repoConfig)
+	defer testServer.Close()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}
+
+	addCmd := NewFederationMemberAddCommand().SetServerDetails(serverDetails).SetRepoKey("repo1").
+		SetMemberUrls([]string{"https://site1.jfrog.io/artifactory/repo1/", "https://site2.jfrog.io/artifactory/repo1"})
+	require.NoError(t, addCmd.Run())
+	assert.Equal(t, []string{"https://site1.jfrog.io/artifactory/repo1", "https://site2.jfrog.io/artifactory/repo1"}, getMemberUrls(t, repoConfig))
+	// Unknown configuration fields are kept
+	assert.Equal(t, true, repoConfig["xrayIndex"])
+
+	removeCmd := NewFederationMemberRemoveCommand().SetServerDetails(serverDetails).SetRepoKey("repo1").
+		SetMemberUrls([]string{"https://site1.jfrog.io/artifactory/repo1"})
+	require.NoError(t, removeCmd.Run())
+	assert.Equal(t, []string{"https://site2.jfrog.io/artifactory/repo1"}, getMemberUrls(t, repoConfig))
+	assert.ErrorContains(t, removeCmd.Run(), "is not a member of repo1")
+
+	addCmd.SetMemberUrls([]string{"site3/repo1"})
+	assert.ErrorContains(t, addCmd.Run(), "invalid member URL")
+}
+
+func TestFederationConvert(t *testing.T) {
+	repoConfig := map[string]any{"key": "repo1", "rclass": "local", "packageType": "npm"}
+	testServer, requests := createTestServer(t, repoConfig)
+	defer testServer.Close()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}
+
+	convertCmd := NewFederationConvertCommand().SetServerDetails(serverDetails).SetRepoKey("repo1").
+		SetMemberUrls([]string{"https://site2.jfrog.io/artifactory/repo1"})
+	require.NoError(t, convertCmd.Run())
+	assert.Equal(t, federatedRclass, repoConfig["rclass"])
+	assert.Equal(t, []string{"https://site2.jfrog.io/artifactory/repo1"}, getMemberUrls(t, repoConfig))
+	assert.Contains(t, *requests, "POST /api/federation/migrate/repo1")
+
+	repoConfig["rclass"] = "remote"
+	assert.ErrorContains(t, convertCmd.Run(), "only local repositories can be converted")
+}
+
+func TestFederationStatus(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/federation/status/repo/repo1", r.URL.Path)
+		_, err := w.Write([]byte(`{"localKey":"repo1","binariesTasksInfo":{"inProgressTasks":2,"failingTasks":0},
+			"mirrorEventsStatusInfo":[{"remoteUrl":"https://site2.jfrog.io/artifactory/repo1","status":"HEALTHY","createEvents":3,"lagInMS":1500}]}`))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+
+	statusCmd := NewFederationStatusCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}).SetRepoKey("repo1")
+	require.NoError(t, statusCmd.Run())
+	assert.Equal(t, 2, statusCmd.Status().BinariesTasksInfo.InProgressTasks)
+	assert.Equal(t, int64(1500), statusCmd.Status().MirrorEventsStatusInfo[0].LagInMs)
+}
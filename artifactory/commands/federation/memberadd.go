@@ -0,0 +1,86 @@
+package federation
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// FederationMemberAddCommand adds members to a federated repository. Artifactory propagates the federation to the new members.
+type FederationMemberAddCommand struct {
+	serverDetails *config.ServerDetails
+	repoKey       string
+	memberUrls    []string
+}
+
+func NewFederationMemberAddCommand() *FederationMemberAddCommand {
+	return &FederationMemberAddCommand{}
+}
+
+func (fmac *FederationMemberAddCommand) SetServerDetails(serverDetails *config.ServerDetails) *FederationMemberAddCommand {
+	fmac.serverDetails = serverDetails
+	return fmac
+}
+
+func (fmac *FederationMemberAddCommand) SetRepoKey(repoKey string) *FederationMemberAddCommand {
+	fmac.repoKey = repoKey
+	return fmac
+}
+
+// SetMemberUrls sets the URLs of the members to add, in the form of <artifactory-url>/<repo-key>.
+func (fmac *FederationMemberAddCommand) SetMemberUrls(memberUrls []string) *FederationMemberAddCommand {
+	fmac.memberUrls = memberUrls
+	return fmac
+}
+
+func (fmac *FederationMemberAddCommand) ServerDetails() (*config.ServerDetails, error) {
+	return fmac.serverDetails, nil
+}
+
+func (fmac *FederationMemberAddCommand) CommandName() string {
+	return "rt_federation_member_add"
+}
+
+func (fmac *FederationMemberAddCommand) Run() error {
+	service, err := newFederationService(fmac.serverDetails)
+	if err != nil {
+		return err
+	}
+	return addMembers(service, fmac.repoKey, fmac.memberUrls)
+}
+
+func addMembers(service *federationService, repoKey string, memberUrls []string) error {
+	for _, memberUrl := range memberUrls {
+		if err := validateMemberUrl(memberUrl); err != nil {
+			return err
+		}
+	}
+	repoConfig, members, err := service.getFederatedRepo(repoKey)
+	if err != nil {
+		return err
+	}
+	added := false
+	for _, memberUrl := range memberUrls {
+		if containsMember(members, memberUrl) {
+			log.Info("Member " + memberUrl + " is already a member of " + repoKey + ".")
+			continue
+		}
+		enabled := true
+		members = append(members, services.FederatedRepositoryMember{Url: memberUrl, Enabled: &enabled})
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	log.Info("Updating the members of " + repoKey + " to " + formatMembers(members) + "...")
+	return service.updateMembers(repoKey, repoConfig, members)
+}
+
+func containsMember(members []services.FederatedRepositoryMember, memberUrl string) bool {
+	for _, member := range members {
+		if isSameMember(member.Url, memberUrl) {
+			return true
+		}
+	}
+	return false
+}
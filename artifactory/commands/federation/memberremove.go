@@ -0,0 +1,75 @@
+package federation
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// FederationMemberRemoveCommand removes members from a federated repository. The repositories of the removed members are kept.
+type FederationMemberRemoveCommand struct {
+	serverDetails *config.ServerDetails
+	repoKey       string
+	memberUrls    []string
+}
+
+func NewFederationMemberRemoveCommand() *FederationMemberRemoveCommand {
+	return &FederationMemberRemoveCommand{}
+}
+
+func (fmrc *FederationMemberRemoveCommand) SetServerDetails(serverDetails *config.ServerDetails) *FederationMemberRemoveCommand {
+	fmrc.serverDetails = serverDetails
+	return fmrc
+}
+
+func (fmrc *FederationMemberRemoveCommand) SetRepoKey(repoKey string) *FederationMemberRemoveCommand {
+	fmrc.repoKey = repoKey
+	return fmrc
+}
+
+func (fmrc *FederationMemberRemoveCommand) SetMemberUrls(memberUrls []string) *FederationMemberRemoveCommand {
+	fmrc.memberUrls = memberUrls
+	return fmrc
+}
+
+func (fmrc *FederationMemberRemoveCommand) ServerDetails() (*config.ServerDetails, error) {
+	return fmrc.serverDetails, nil
+}
+
+func (fmrc *FederationMemberRemoveCommand) CommandName() string {
+	return "rt_federation_member_remove"
+}
+
+func (fmrc *FederationMemberRemoveCommand) Run() error {
+	service, err := newFederationService(fmrc.serverDetails)
+	if err != nil {
+		return err
+	}
+	repoConfig, members, err := service.getFederatedRepo(fmrc.repoKey)
+	if err != nil {
+		return err
+	}
+	for _, memberUrl := range fmrc.memberUrls {
+		if !containsMember(members, memberUrl) {
+			return errorutils.CheckErrorf("%s is not a member of %s", memberUrl, fmrc.repoKey)
+		}
+	}
+	var remaining []services.FederatedRepositoryMember
+	for _, member := range members {
+		if !containsMemberUrl(fmrc.memberUrls, member.Url) {
+			remaining = append(remaining, member)
+		}
+	}
+	log.Info("Updating the members of " + fmrc.repoKey + " to " + formatMembers(remaining) + "...")
+	return service.updateMembers(fmrc.repoKey, repoConfig, remaining)
+}
+
+func containsMemberUrl(memberUrls []string, memberUrl string) bool {
+	for _, url := range memberUrls {
+		if isSameMember(url, memberUrl) {
+			return true
+		}
+	}
+	return false
+}
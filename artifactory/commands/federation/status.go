@@ -0,0 +1,130 @@
+package federation
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"time"
+)
+
+// FederationStatus is the mirroring status of a federated repository, as returned by Artifactory.
+type FederationStatus struct {
+	LocalKey          string `json:"localKey"`
+	BinariesTasksInfo struct {
+		InProgressTasks int `json:"inProgressTasks"`
+		FailingTasks    int `json:"failingTasks"`
+	} `json:"binariesTasksInfo"`
+	MirrorEventsStatusInfo []MirrorStatus `json:"mirrorEventsStatusInfo"`
+}
+
+// MirrorStatus is the status of the mirroring to a single member. The lag is the time the oldest pending event is waiting.
+type MirrorStatus struct {
+	RemoteUrl     string `json:"remoteUrl"`
+	RemoteRepoKey string `json:"remoteRepoKey"`
+	Status        string `json:"status"`
+	CreateEvents  int    `json:"createEvents"`
+	UpdateEvents  int    `json:"updateEvents"`
+	DeleteEvents  int    `json:"deleteEvents"`
+	PropsEvents   int    `json:"propsEvents"`
+	ErrorEvents   int    `json:"errorEvents"`
+	LagInMs       int64  `json:"lagInMS"`
+}
+
+type mirrorStatusRow struct {
+	Member        string `col-name:"Member"`
+	Status        string `col-name:"Status"`
+	PendingEvents int    `col-name:"Pending Events"`
+	ErrorEvents   int    `col-name:"Error Events"`
+	Lag           string `col-name:"Lag"`
+}
+
+// FederationStatusCommand shows the mirroring status and lag of each member of a federated repository.
+type FederationStatusCommand struct {
+	serverDetails *config.ServerDetails
+	repoKey       string
+	outputFormat  format.OutputFormat
+	status        *FederationStatus
+}
+
+func NewFederationStatusCommand() *FederationStatusCommand {
+	return &FederationStatusCommand{outputFormat: format.Table}
+}
+
+func (fsc *FederationStatusCommand) SetServerDetails(serverDetails *config.ServerDetails) *FederationStatusCommand {
+	fsc.serverDetails = serverDetails
+	return fsc
+}
+
+func (fsc *FederationStatusCommand) SetRepoKey(repoKey string) *FederationStatusCommand {
+	fsc.repoKey = repoKey
+	return fsc
+}
+
+// SetOutputFormat sets the output format - 'table' or 'json'.
+func (fsc *FederationStatusCommand) SetOutputFormat(outputFormat format.OutputFormat) *FederationStatusCommand {
+	fsc.outputFormat = outputFormat
+	return fsc
+}
+
+func (fsc *FederationStatusCommand) Status() *FederationStatus {
+	return fsc.status
+}
+
+func (fsc *FederationStatusCommand) ServerDetails() (*config.ServerDetails, error) {
+	return fsc.serverDetails, nil
+}
+
+func (fsc *FederationStatusCommand) CommandName() string {
+	return "rt_federation_status"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (fsc *FederationStatusCommand) IsReadOnly() bool {
+	return true
+}
+
+func (fsc *FederationStatusCommand) Run() (err error) {
+	service, err := newFederationService(fsc.serverDetails)
+	if err != nil {
+		return err
+	}
+	if fsc.status, err = service.getStatus(fsc.repoKey); err != nil {
+		return err
+	}
+	switch fsc.outputFormat {
+	case format.Json:
+		content, err := json.Marshal(fsc.status)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	case format.Table:
+		return printStatusTable(fsc.status)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s", fsc.outputFormat, format.Table, format.Json)
+	}
+}
+
+func printStatusTable(status *FederationStatus) error {
+	var rows []mirrorStatusRow
+	for _, mirror := range status.MirrorEventsStatusInfo {
+		rows = append(rows, mirrorStatusRow{
+			Member:        mirror.RemoteUrl,
+			Status:        mirror.Status,
+			PendingEvents: mirror.CreateEvents + mirror.UpdateEvents + mirror.DeleteEvents + mirror.PropsEvents,
+			ErrorEvents:   mirror.ErrorEvents,
+			Lag:           (time.Duration(mirror.LagInMs) * time.Millisecond).String(),
+		})
+	}
+	if err := coreutils.PrintTable(rows, "Federation Members of "+status.LocalKey, "The repository has no federation members", false); err != nil {
+		return err
+	}
+	log.Output(fmt.Sprintf("Binaries tasks in progress: %d, failing: %d", status.BinariesTasksInfo.InProgressTasks, status.BinariesTasksInfo.FailingTasks))
+	return nil
+}
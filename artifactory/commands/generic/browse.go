@@ -0,0 +1,311 @@
+package generic
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	browseParentOption  = ".."
+	browseActionsOption = "[Actions on this folder]"
+	browseExitOption    = "[Exit]"
+
+	browseInfoAction     = "Show properties and stats"
+	browseDownloadAction = "Download"
+	browseSetPropsAction = "Set properties"
+	browseDeleteAction   = "Delete"
+	browseBackAction     = "Back"
+)
+
+// BrowseCommand is an interactive terminal UI for navigating the repositories and folders of Artifactory,
+// and for running actions on the selected items.
+type BrowseCommand struct {
+	serverDetails   *config.ServerDetails
+	startPath       string
+	threads         int
+	servicesManager artifactory.ArtifactoryServicesManager
+}
+
+// An item displayed by the browser - a repository, a folder or a file.
+type browseEntry struct {
+	Name   string
+	Folder bool
+}
+
+func NewBrowseCommand() *BrowseCommand {
+	return &BrowseCommand{}
+}
+
+func (bc *BrowseCommand) SetServerDetails(serverDetails *config.ServerDetails) *BrowseCommand {
+	bc.serverDetails = serverDetails
+	return bc
+}
+
+// SetStartPath sets the path in Artifactory to start browsing from, for example: 'repo/folder'. By default, the repositories list is displayed.
+func (bc *BrowseCommand) SetStartPath(startPath string) *BrowseCommand {
+	bc.startPath = startPath
+	return bc
+}
+
+func (bc *BrowseCommand) SetThreads(threads int) *BrowseCommand {
+	bc.threads = threads
+	return bc
+}
+
+func (bc *BrowseCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bc.serverDetails, nil
+}
+
+func (bc *BrowseCommand) CommandName() string {
+	return "rt_browse"
+}
+
+func (bc *BrowseCommand) Run() (err error) {
+	bc.servicesManager, err = utils.CreateServiceManager(bc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	currentPath := strings.Trim(bc.startPath, "/")
+	for {
+		entries, err := bc.listEntries(currentPath)
+		if err != nil {
+			return err
+		}
+		var selected string
+		if err = ioutils.SelectString(createBrowseItems(currentPath, entries), getBrowseLabel(currentPath), true, func(item ioutils.PromptItem) {
+			selected = item.Option
+		}); err != nil {
+			return err
+		}
+		switch selected {
+		case browseExitOption:
+			return nil
+		case browseParentOption:
+			currentPath = getParentPath(currentPath)
+		case browseActionsOption:
+			deleted, err := bc.runItemActions(currentPath, true)
+			if err != nil {
+				return err
+			}
+			// The deleted folder can't be browsed anymore.
+			if deleted {
+				currentPath = getParentPath(currentPath)
+			}
+		default:
+			selectedPath := path.Join(currentPath, strings.TrimSuffix(selected, "/"))
+			if strings.HasSuffix(selected, "/") {
+				currentPath = selectedPath
+				continue
+			}
+			if _, err = bc.runItemActions(selectedPath, false); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Returns the repositories if the path is empty, or the children of the folder otherwise.
+// Folders are listed before files.
+func (bc *BrowseCommand) listEntries(currentPath string) ([]browseEntry, error) {
+	var entries []browseEntry
+	if currentPath == "" {
+		repositories, err := bc.servicesManager.GetAllRepositories()
+		if err != nil {
+			return nil, err
+		}
+		for _, repository := range *repositories {
+			entries = append(entries, browseEntry{Name: repository.Key, Folder: true})
+		}
+	} else {
+		folderInfo, err := bc.servicesManager.FolderInfo(currentPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range folderInfo.Children {
+			entries = append(entries, browseEntry{Name: strings.TrimPrefix(child.Uri, "/"), Folder: child.Folder})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Folder != entries[j].Folder {
+			return entries[i].Folder
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+func createBrowseItems(currentPath string, entries []browseEntry) []ioutils.PromptItem {
+	var items []ioutils.PromptItem
+	if currentPath != "" {
+		items = append(items, ioutils.PromptItem{Option: browseParentOption}, ioutils.PromptItem{Option: browseActionsOption})
+	}
+	for _, entry := range entries {
+		option := entry.Name
+		if entry.Folder {
+			option += "/"
+		}
+		items = append(items, ioutils.PromptItem{Option: option})
+	}
+	return append(items, ioutils.PromptItem{Option: browseExitOption})
+}
+
+func getBrowseLabel(currentPath string) string {
+	if currentPath == "" {
+		return "Select a repository"
+	}
+	return currentPath + "/"
+}
+
+func getParentPath(currentPath string) string {
+	parent := path.Dir(currentPath)
+	if parent == "." {
+		return ""
+	}
+	return parent
+}
+
+// Lets the user select an action to run on the item, until 'Back' is selected or the item is deleted.
+// Returns true if the item was deleted.
+func (bc *BrowseCommand) runItemActions(itemPath string, folder bool) (bool, error) {
+	actions := []ioutils.PromptItem{{Option: browseInfoAction}, {Option: browseDownloadAction}, {Option: browseSetPropsAction}, {Option: browseDeleteAction}, {Option: browseBackAction}}
+	for {
+		var action string
+		if err := ioutils.SelectString(actions, itemPath, false, func(item ioutils.PromptItem) {
+			action = item.Option
+		}); err != nil {
+			return false, err
+		}
+		var err error
+		switch action {
+		case browseInfoAction:
+			err = bc.showItemInfo(itemPath, folder)
+		case browseDownloadAction:
+			err = bc.download(itemPath, folder)
+		case browseSetPropsAction:
+			err = bc.setProps(itemPath, folder)
+		case browseDeleteAction:
+			var deleted bool
+			// The deleted item can't be browsed anymore.
+			if deleted, err = bc.delete(itemPath, folder); deleted {
+				return true, nil
+			}
+		default:
+			return false, nil
+		}
+		// Failing actions are reported, without quitting the browser.
+		if err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+func (bc *BrowseCommand) showItemInfo(itemPath string, folder bool) error {
+	if folder {
+		folderInfo, err := bc.servicesManager.FolderInfo(itemPath)
+		if err != nil {
+			return err
+		}
+		log.Output(formatFolderInfo(folderInfo))
+	} else {
+		fileInfo, err := bc.servicesManager.FileInfo(itemPath)
+		if err != nil {
+			return err
+		}
+		log.Output(formatFileInfo(fileInfo))
+	}
+	itemProps, err := bc.servicesManager.GetItemProps(itemPath)
+	if err != nil {
+		return err
+	}
+	log.Output(formatItemProps(itemProps))
+	return nil
+}
+
+func formatFolderInfo(folderInfo *servicesutils.FolderInfo) string {
+	return strings.Join([]string{
+		"Path:          " + path.Join(folderInfo.Repo, folderInfo.Path),
+		"Created:       " + folderInfo.Created + " by " + folderInfo.CreatedBy,
+		"Last modified: " + folderInfo.LastModified + " by " + folderInfo.ModifiedBy,
+		fmt.Sprintf("Children:      %d", len(folderInfo.Children)),
+	}, "\n")
+}
+
+func formatFileInfo(fileInfo *servicesutils.FileInfo) string {
+	return strings.Join([]string{
+		"Path:          " + path.Join(fileInfo.Repo, fileInfo.Path),
+		"Size:          " + fileInfo.Size + " bytes",
+		"Mime type:     " + fileInfo.MimeType,
+		"Created:       " + fileInfo.Created + " by " + fileInfo.CreatedBy,
+		"Last modified: " + fileInfo.LastModified + " by " + fileInfo.ModifiedBy,
+		"SHA-1:         " + fileInfo.Checksums.Sha1,
+		"SHA-256:       " + fileInfo.Checksums.Sha256,
+		"MD5:           " + fileInfo.Checksums.Md5,
+	}, "\n")
+}
+
+func formatItemProps(itemProps *servicesutils.ItemProperties) string {
+	if itemProps == nil || len(itemProps.Properties) == 0 {
+		return "Properties:    none"
+	}
+	keys := make([]string, 0, len(itemProps.Properties))
+	for key := range itemProps.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := []string{"Properties:"}
+	for _, key := range keys {
+		lines = append(lines, "  "+key+" = "+strings.Join(itemProps.Properties[key], ","))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns a spec matching the item. The spec of a folder matches the files under it.
+func createItemSpec(itemPath string, folder bool) *spec.SpecFiles {
+	if folder {
+		itemPath += "/"
+	}
+	return spec.NewBuilder().Pattern(itemPath).Recursive(true).BuildSpec()
+}
+
+func (bc *BrowseCommand) download(itemPath string, folder bool) error {
+	target := ioutils.AskStringWithDefault("Target directory", "", "./")
+	itemSpec := createItemSpec(itemPath, folder)
+	itemSpec.Get(0).Target = strings.TrimSuffix(target, "/") + "/"
+	downloadCmd := NewDownloadCommand()
+	downloadCmd.SetConfiguration(&utils.DownloadConfiguration{Threads: bc.threads})
+	downloadCmd.SetServerDetails(bc.serverDetails).SetSpec(itemSpec)
+	return commands.Exec(downloadCmd)
+}
+
+func (bc *BrowseCommand) setProps(itemPath string, folder bool) error {
+	props := ioutils.AskString("Properties to set, for example: key1=value1;key2=value2", "", false, false)
+	propsCmd := NewPropsCommand()
+	propsCmd.SetProps(props).SetThreads(bc.threads)
+	propsCmd.SetServerDetails(bc.serverDetails).SetSpec(createItemSpec(itemPath, folder))
+	return commands.Exec(NewSetPropsCommand().SetPropsCommand(*propsCmd))
+}
+
+// Returns true if the item was deleted.
+func (bc *BrowseCommand) delete(itemPath string, folder bool) (bool, error) {
+	if !coreutils.AskYesNo("Are you sure you want to delete '"+itemPath+"'?", false) {
+		return false, nil
+	}
+	deleteCmd := NewDeleteCommand()
+	deleteCmd.SetThreads(bc.threads).SetQuiet(true).SetServerDetails(bc.serverDetails).SetSpec(createItemSpec(itemPath, folder))
+	if err := commands.Exec(deleteCmd); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,52 @@
+package generic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrowseListEntries(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/storage/repo/folder", r.URL.Path)
+		_, err := w.Write([]byte(`{"repo":"repo","path":"/folder","children":[{"uri":"/b.txt","folder":false},{"uri":"/sub","folder":true},{"uri":"/a.txt","folder":false}]}`))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+
+	browseCmd := NewBrowseCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"})
+	var err error
+	browseCmd.servicesManager, err = utils.CreateServiceManager(browseCmd.serverDetails, -1, 0, false)
+	assert.NoError(t, err)
+
+	entries, err := browseCmd.listEntries("repo/folder")
+	assert.NoError(t, err)
+	assert.Equal(t, []browseEntry{{Name: "sub", Folder: true}, {Name: "a.txt"}, {Name: "b.txt"}}, entries)
+
+	items := createBrowseItems("repo/folder", entries)
+	assert.Equal(t, []ioutils.PromptItem{{Option: browseParentOption}, {Option: browseActionsOption}, {Option: "sub/"}, {Option: "a.txt"}, {Option: "b.txt"}, {Option: browseExitOption}}, items)
+}
+
+func TestBrowseGetParentPath(t *testing.T) {
+	assert.Equal(t, "", getParentPath("repo"))
+	assert.Equal(t, "repo", getParentPath("repo/folder"))
+	assert.Equal(t, "repo/folder", getParentPath("repo/folder/sub"))
+}
+
+func TestCreateItemSpec(t *testing.T) {
+	assert.Equal(t, "repo/folder/a.txt", createItemSpec("repo/folder/a.txt", false).Get(0).Pattern)
+	// Folders are addressed with a trailing slash, so that the folder itself is deleted and not only the files matching its name.
+	assert.Equal(t, "repo/folder/", createItemSpec("repo/folder", true).Get(0).Pattern)
+}
+
+func TestFormatItemProps(t *testing.T) {
+	assert.Equal(t, "Properties:    none", formatItemProps(nil))
+	itemProps := &servicesutils.ItemProperties{Properties: map[string][]string{"b": {"1", "2"}, "a": {"x"}}}
+	assert.Equal(t, "Properties:\n  a = x\n  b = 1,2", formatItemProps(itemProps))
+}
@@ -0,0 +1,30 @@
+package generic
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/checksumcache"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Removes the local checksum cache, which is used to avoid re-hashing unmodified files.
+type CacheCleanCommand struct{}
+
+func NewCacheCleanCommand() *CacheCleanCommand {
+	return &CacheCleanCommand{}
+}
+
+func (ccc *CacheCleanCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (ccc *CacheCleanCommand) CommandName() string {
+	return "rt_cache_clean"
+}
+
+func (ccc *CacheCleanCommand) Run() error {
+	if err := checksumcache.CleanChecksumCache(); err != nil {
+		return err
+	}
+	log.Info("The local checksum cache was successfully cleaned.")
+	return nil
+}
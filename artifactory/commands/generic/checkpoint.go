@@ -0,0 +1,260 @@
+package generic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// TransferCheckpoint describes the progress of an upload or a download which was interrupted, so that it can be resumed by the next run of the same command.
+type TransferCheckpoint struct {
+	Command   string    `json:"command"`
+	ServerUrl string    `json:"serverUrl"`
+	Created   time.Time `json:"created"`
+	// The files transferred before the interruption, including those of the previous interrupted runs.
+	Completed []CheckpointItem `json:"completed"`
+	// The files which were not transferred yet, if the command can list them.
+	Pending []CheckpointItem `json:"pending,omitempty"`
+}
+
+type CheckpointItem struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	// The SHA-256 checksum of the transferred file, if it's known. A resumed upload skips the file only if its checksum didn't change.
+	Sha256 string `json:"sha256,omitempty"`
+}
+
+// Returns the path of the checkpoint of the command. The checkpoints are stored in the checkpoints directory under the JFrog home directory,
+// with a name derived from the command, the server and the spec, so that only a run with the same arguments resumes from the checkpoint.
+func getCheckpointPath(commandName, serverUrl string, specFiles *spec.SpecFiles) (string, error) {
+	specContent, err := json.Marshal(specFiles)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	checkpointsDir, err := coreutils.GetJfrogCheckpointsDir()
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(commandName + "\n" + serverUrl + "\n" + string(specContent)))
+	return filepath.Join(checkpointsDir, hex.EncodeToString(hash[:])+".json"), nil
+}
+
+// Returns nil if the checkpoint doesn't exist.
+func loadCheckpoint(checkpointPath string) (*TransferCheckpoint, error) {
+	checkpointContent, err := os.ReadFile(checkpointPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	checkpoint := new(TransferCheckpoint)
+	if err = json.Unmarshal(checkpointContent, checkpoint); err != nil {
+		return nil, errorutils.CheckErrorf("failed reading the checkpoint %s: %s", checkpointPath, err.Error())
+	}
+	return checkpoint, nil
+}
+
+func saveCheckpoint(checkpointPath string, checkpoint *TransferCheckpoint) error {
+	checkpointContent, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(checkpointPath)); err != nil {
+		return err
+	}
+	if err = os.WriteFile(checkpointPath, checkpointContent, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info(fmt.Sprintf("The progress of the command was saved to %s, with %d transferred files. Run the command again with the same arguments and --resume to continue from it.",
+		checkpointPath, len(checkpoint.Completed)))
+	return nil
+}
+
+func removeCheckpoint(checkpointPath string) error {
+	if err := os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	return nil
+}
+
+// Reads the transferred files from the transfer details of the summary, and resets the reader so that it can be read again.
+func readCompletedItems(transferDetailsReader *content.ContentReader) (items []CheckpointItem, err error) {
+	if transferDetailsReader == nil {
+		return
+	}
+	for transferDetails := new(clientutils.FileTransferDetails); transferDetailsReader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		items = append(items, CheckpointItem{Source: transferDetails.SourcePath, Target: transferDetails.TargetPath, Sha256: transferDetails.Sha256})
+	}
+	if err = transferDetailsReader.GetError(); err != nil {
+		return nil, err
+	}
+	transferDetailsReader.Reset()
+	return
+}
+
+// Returns true if the running command was cancelled, for example by an interrupt signal.
+func isCommandCancelled() bool {
+	return coreutils.CommandContext().Err() != nil
+}
+
+// Returns the path of the checkpoint of the command, or an empty string if the progress of the command can't be saved.
+// If the command is resumed, the checkpoint of the previous run is returned, or nil if it doesn't exist.
+// The progress is saved from the summary of the transfer, which is collected whenever the checkpoint path is returned, so that
+// an interrupted run can be resumed even if it wasn't resumed itself.
+func prepareCheckpoint(commandName string, serverDetails *config.ServerDetails, specFiles *spec.SpecFiles, resume bool) (checkpointPath string, checkpoint *TransferCheckpoint, err error) {
+	if !resume && coreutils.CommandContext().Done() == nil {
+		// The command can't be interrupted.
+		return
+	}
+	if checkpointPath, err = getCheckpointPath(commandName, serverDetails.ArtifactoryUrl, specFiles); err != nil || !resume {
+		return
+	}
+	if checkpoint, err = loadCheckpoint(checkpointPath); err == nil && checkpoint == nil {
+		log.Info("No checkpoint of a previous run with the same arguments was found. Transferring all the files.")
+	}
+	return
+}
+
+// Returns the File-Spec groups which upload the files of the spec that were not uploaded before the checkpoint was saved.
+// The files which were changed since they were uploaded are uploaded again.
+func applyUploadCheckpoint(specFiles *spec.SpecFiles, checkpoint *TransferCheckpoint) (*spec.SpecFiles, error) {
+	completed := make(map[string]string, len(checkpoint.Completed))
+	for _, item := range checkpoint.Completed {
+		completed[item.Source] = item.Sha256
+	}
+	candidates, archiveGroups, err := collectCheckpointCandidates(specFiles)
+	if err != nil {
+		return nil, err
+	}
+	resumedSpec := &spec.SpecFiles{Files: archiveGroups}
+	skipped := 0
+	for _, candidate := range candidates {
+		unchanged, err := isUnchangedSinceUpload(candidate.localPath, completed)
+		if err != nil {
+			return nil, err
+		}
+		if unchanged {
+			skipped++
+			continue
+		}
+		resumedSpec.Files = append(resumedSpec.Files, candidate.file)
+	}
+	log.Info(fmt.Sprintf("Resuming the upload from the checkpoint saved at %s. Skipping %d files which were uploaded before the interruption.",
+		checkpoint.Created.Format(time.RFC3339), skipped))
+	return resumedSpec, nil
+}
+
+// Returns true if the file was uploaded before the checkpoint was saved, and its checksum didn't change since.
+func isUnchangedSinceUpload(localPath string, completed map[string]string) (bool, error) {
+	sha256, uploaded := completed[localPath]
+	if !uploaded || sha256 == "" {
+		return false, nil
+	}
+	details, err := fileutils.GetFileDetails(localPath, true)
+	if err != nil {
+		return false, err
+	}
+	return details.Checksum.Sha256 == sha256, nil
+}
+
+// Saves the files uploaded before the interruption, together with those of the resumed checkpoint, and the files which are still pending.
+func (uc *UploadCommand) saveUploadCheckpoint(checkpointPath string, resumed *TransferCheckpoint, specFiles *spec.SpecFiles, transferDetailsReader *content.ContentReader) error {
+	uploaded, err := readCompletedItems(transferDetailsReader)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := uc.ServerDetails()
+	if err != nil {
+		return err
+	}
+	checkpoint := &TransferCheckpoint{Command: uc.CommandName(), ServerUrl: serverDetails.ArtifactoryUrl, Created: time.Now()}
+	completed := map[string]bool{}
+	if resumed != nil {
+		for _, item := range resumed.Completed {
+			completed[item.Source] = true
+			checkpoint.Completed = append(checkpoint.Completed, item)
+		}
+	}
+	for _, item := range uploaded {
+		if item.Source, err = filepath.Abs(item.Source); err != nil {
+			return errorutils.CheckError(err)
+		}
+		if !completed[item.Source] {
+			completed[item.Source] = true
+			checkpoint.Completed = append(checkpoint.Completed, item)
+		}
+	}
+	candidates, archiveGroups, err := collectCheckpointCandidates(specFiles)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range candidates {
+		if !completed[candidate.localPath] {
+			checkpoint.Pending = append(checkpoint.Pending, CheckpointItem{Source: candidate.localPath, Target: path.Join(candidate.repo, candidate.targetPath)})
+		}
+	}
+	for _, group := range archiveGroups {
+		checkpoint.Pending = append(checkpoint.Pending, CheckpointItem{Source: group.Pattern, Target: group.Target})
+	}
+	return saveCheckpoint(checkpointPath, checkpoint)
+}
+
+// Lists the local files of the spec with their absolute paths. The groups which upload archives are returned as they are, since their files can't be uploaded separately.
+func collectCheckpointCandidates(specFiles *spec.SpecFiles) (candidates []uploadCandidate, archiveGroups []spec.File, err error) {
+	for _, file := range specFiles.Files {
+		if file.Archive != "" {
+			archiveGroups = append(archiveGroups, file)
+			continue
+		}
+		groupCandidates, err := collectUploadCandidates(&spec.SpecFiles{Files: []spec.File{file}}, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, candidate := range groupCandidates {
+			if candidate.localPath, err = filepath.Abs(candidate.localPath); err != nil {
+				return nil, nil, errorutils.CheckError(err)
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+	return
+}
+
+// Saves the files downloaded before the interruption, together with those of the resumed checkpoint.
+// The pending files are described by the File-Spec groups of the download, since they're searched by the download itself.
+func (dc *DownloadCommand) saveDownloadCheckpoint(checkpointPath string, resumed *TransferCheckpoint, transferDetailsReader *content.ContentReader) error {
+	downloaded, err := readCompletedItems(transferDetailsReader)
+	if err != nil {
+		return err
+	}
+	checkpoint := &TransferCheckpoint{Command: dc.CommandName(), ServerUrl: dc.serverDetails.ArtifactoryUrl, Created: time.Now()}
+	completed := map[string]bool{}
+	if resumed != nil {
+		downloaded = append(resumed.Completed, downloaded...)
+	}
+	for _, item := range downloaded {
+		if !completed[item.Target] {
+			completed[item.Target] = true
+			checkpoint.Completed = append(checkpoint.Completed, item)
+		}
+	}
+	for _, file := range dc.Spec().Files {
+		checkpoint.Pending = append(checkpoint.Pending, CheckpointItem{Source: file.Pattern, Target: file.Target})
+	}
+	return saveCheckpoint(checkpointPath, checkpoint)
+}
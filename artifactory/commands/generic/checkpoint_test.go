@@ -0,0 +1,144 @@
+package generic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadCheckpoint(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.HomeDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mutex sync.Mutex
+	var uploaded []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		if len(uploaded) == 1 && ctx.Err() == nil {
+			// The upload is interrupted while the second file is uploaded.
+			cancel()
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		uploaded = append(uploaded, strings.Split(r.URL.Path, ";")[0])
+		// Artifactory returns the checksums of the uploaded file.
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		sha256Sum := sha256.Sum256(body)
+		w.WriteHeader(http.StatusCreated)
+		_, err = fmt.Fprintf(w, `{"checksums":{"sha256":"%s"}}`, hex.EncodeToString(sha256Sum[:]))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	localDir := t.TempDir()
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, os.WriteFile(filepath.Join(localDir, name+".txt"), []byte(name), 0644))
+	}
+	newUploadCommand := func() *UploadCommand {
+		uploadCmd := NewUploadCommand().SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1})
+		uploadCmd.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+			SetSpec(spec.NewBuilder().Pattern(filepath.Join(localDir, "*.txt")).Target("generic-local/app/").Flat(true).BuildSpec()).
+			SetRetries(0)
+		return uploadCmd
+	}
+
+	// The progress of an interrupted upload is saved, even if it isn't resumed itself.
+	restore := coreutils.SetCommandContext(ctx)
+	uploadCmd := newUploadCommand()
+	_ = uploadCmd.Run()
+	restore()
+	require.Len(t, uploaded, 1)
+	var completed, pending []CheckpointItem
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		item := CheckpointItem{Source: filepath.Join(localDir, name), Target: "generic-local/app/" + name}
+		if uploaded[0] == "/"+item.Target {
+			details, err := fileutils.GetFileDetails(item.Source, true)
+			require.NoError(t, err)
+			item.Sha256 = details.Checksum.Sha256
+			completed = append(completed, item)
+		} else {
+			pending = append(pending, item)
+		}
+	}
+
+	// The checkpoint describes the uploaded and the pending files.
+	checkpointPath, err := getCheckpointPath(uploadCmd.CommandName(), server.URL+"/", uploadCmd.Spec())
+	require.NoError(t, err)
+	checkpoint, err := loadCheckpoint(checkpointPath)
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+	assert.Equal(t, completed, checkpoint.Completed)
+	assert.Equal(t, pending, checkpoint.Pending)
+
+	// The resumed upload skips the uploaded files, and removes the checkpoint once it completes.
+	uploaded = nil
+	uploadCmd = newUploadCommand().SetResume(true)
+	require.NoError(t, uploadCmd.Run())
+	sort.Strings(uploaded)
+	assert.Equal(t, []string{"/" + pending[0].Target, "/" + pending[1].Target}, uploaded)
+	checkpoint, err = loadCheckpoint(checkpointPath)
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func TestUploadCheckpointChangedFile(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.HomeDir)
+
+	localDir := t.TempDir()
+	for _, name := range []string{"a", "b"} {
+		require.NoError(t, os.WriteFile(filepath.Join(localDir, name+".txt"), []byte(name), 0644))
+	}
+	details, err := fileutils.GetFileDetails(filepath.Join(localDir, "a.txt"), true)
+	require.NoError(t, err)
+	checkpoint := &TransferCheckpoint{Completed: []CheckpointItem{
+		{Source: filepath.Join(localDir, "a.txt"), Target: "generic-local/app/a.txt", Sha256: details.Checksum.Sha256},
+		{Source: filepath.Join(localDir, "b.txt"), Target: "generic-local/app/b.txt", Sha256: details.Checksum.Sha256},
+	}}
+	specFiles := spec.NewBuilder().Pattern(filepath.Join(localDir, "*.txt")).Target("generic-local/app/").Flat(true).BuildSpec()
+
+	// Only the file which wasn't changed since it was uploaded is skipped.
+	resumedSpec, err := applyUploadCheckpoint(specFiles, checkpoint)
+	require.NoError(t, err)
+	require.Len(t, resumedSpec.Files, 1)
+	assert.Equal(t, filepath.Join(localDir, "b.txt"), resumedSpec.Files[0].Pattern)
+
+	// The files uploaded without a known checksum are uploaded again.
+	checkpoint.Completed[0].Sha256 = ""
+	resumedSpec, err = applyUploadCheckpoint(specFiles, checkpoint)
+	require.NoError(t, err)
+	assert.Len(t, resumedSpec.Files, 2)
+}
+
+func TestUploadResumeWithSyncDeletes(t *testing.T) {
+	uploadCmd := NewUploadCommand().SetResume(true)
+	uploadCmd.SetSyncDeletesPath("generic-local/app/")
+	assert.EqualError(t, uploadCmd.Run(), "the upload cannot be resumed with sync-deletes, since the files skipped by the checkpoint would be deleted")
+}
@@ -5,13 +5,17 @@ import (
 
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type CopyCommand struct {
 	GenericCommand
-	threads int
+	threads             int
+	targetServerDetails *config.ServerDetails
+	preserveProperties  bool
+	preserveStats       bool
 }
 
 func NewCopyCommand() *CopyCommand {
@@ -27,6 +31,26 @@ func (cc *CopyCommand) SetThreads(threads int) *CopyCommand {
 	return cc
 }
 
+// SetTargetServerDetails sets the server to copy the artifacts to, if it's not the source server.
+// If the target server is another instance, the artifacts are streamed from the source server to the target server.
+func (cc *CopyCommand) SetTargetServerDetails(targetServerDetails *config.ServerDetails) *CopyCommand {
+	cc.targetServerDetails = targetServerDetails
+	return cc
+}
+
+// SetPreserveProperties sets whether to set the properties of the artifacts on their copies on another instance.
+// The properties are always preserved by a copy within an instance.
+func (cc *CopyCommand) SetPreserveProperties(preserveProperties bool) *CopyCommand {
+	cc.preserveProperties = preserveProperties
+	return cc
+}
+
+// SetPreserveStats sets whether to keep the download statistics of the artifacts as properties of their copies on another instance.
+func (cc *CopyCommand) SetPreserveStats(preserveStats bool) *CopyCommand {
+	cc.preserveStats = preserveStats
+	return cc
+}
+
 func (cc *CopyCommand) CommandName() string {
 	return "rt_copy"
 }
@@ -38,6 +62,14 @@ func (cc *CopyCommand) Run() error {
 	if err != nil {
 		return err
 	}
+	transfer, err := newCrossInstanceTransfer(servicesManager, cc.serverDetails, cc.targetServerDetails, cc.dryRun, cc.threads, cc.retries, cc.retryWaitTimeMilliSecs)
+	if err != nil {
+		return err
+	}
+	if transfer != nil {
+		transfer.preserveProperties, transfer.preserveStats = cc.preserveProperties, cc.preserveStats
+		return transfer.run(cc.spec, cc.result)
+	}
 
 	var errorOccurred = false
 	var copyParamsArray []services.MoveCopyParams
@@ -0,0 +1,300 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/jfrog/gofrog/parallel"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The properties set on the copies on the target server with the download statistics of the source artifacts, if the statistics are preserved.
+	// Artifactory doesn't allow setting the statistics of an artifact, so they're kept as properties.
+	SourceDownloadsProperty      = "copy.source.stats.downloads"
+	SourceLastDownloadedProperty = "copy.source.stats.downloaded"
+
+	defaultCrossInstanceThreads = 3
+)
+
+// crossInstanceTransfer copies or moves artifacts to another JFrog instance.
+// Each artifact is streamed from the source to the target, unless the target already has a binary with the same checksum.
+type crossInstanceTransfer struct {
+	sourceManager      artifactory.ArtifactoryServicesManager
+	targetManager      artifactory.ArtifactoryServicesManager
+	move               bool
+	preserveProperties bool
+	preserveStats      bool
+	dryRun             bool
+	threads            int
+}
+
+// Returns the transfer to the target server, or nil if there's no target server or if it's the source instance,
+// in which case the artifacts are copied or moved within the instance.
+func newCrossInstanceTransfer(sourceManager artifactory.ArtifactoryServicesManager, source, target *config.ServerDetails, dryRun bool, threads, retries, retryWaitMilliSecs int) (*crossInstanceTransfer, error) {
+	if target == nil {
+		return nil, nil
+	}
+	if target.ArtifactoryUrl == "" {
+		return nil, errorutils.CheckErrorf("the target server must have an Artifactory URL")
+	}
+	targetManager, err := utils.CreateServiceManagerWithThreads(target, dryRun, threads, retries, retryWaitMilliSecs)
+	if err != nil {
+		return nil, err
+	}
+	if isSameInstance(source, target, sourceManager, targetManager) {
+		log.Debug("The target server is the source instance. The artifacts are transferred within the instance.")
+		return nil, nil
+	}
+	log.Info("The target server is another instance. Streaming the artifacts from the source server to", target.ArtifactoryUrl)
+	return &crossInstanceTransfer{sourceManager: sourceManager, targetManager: targetManager, dryRun: dryRun, threads: threads}, nil
+}
+
+// Returns true if the source and the target servers are the same instance, in which case the artifacts are copied or moved by Artifactory, without transferring them.
+// The servers are compared by their URLs, and if they differ, by the service IDs of the instances.
+func isSameInstance(source, target *config.ServerDetails, sourceManager, targetManager artifactory.ArtifactoryServicesManager) bool {
+	if strings.TrimSuffix(source.ArtifactoryUrl, "/") == strings.TrimSuffix(target.ArtifactoryUrl, "/") {
+		return true
+	}
+	sourceId, err := sourceManager.GetServiceId()
+	if err != nil {
+		log.Debug("Couldn't get the service ID of the source server, assuming the target server is another instance:", err.Error())
+		return false
+	}
+	targetId, err := targetManager.GetServiceId()
+	if err != nil {
+		log.Debug("Couldn't get the service ID of the target server, assuming it's another instance:", err.Error())
+		return false
+	}
+	return sourceId != "" && sourceId == targetId
+}
+
+// Copies or moves the artifacts of the spec to the target server. The target paths are calculated as if the artifacts were copied within the source server.
+func (cit *crossInstanceTransfer) run(specFiles *spec.SpecFiles, result *commandsutils.Result) error {
+	threads := cit.threads
+	if threads <= 0 {
+		threads = defaultCrossInstanceThreads
+	}
+	var succeeded, failed int32
+	var searchErr error
+	runner := parallel.NewBounedRunner(threads, false)
+	go func() {
+		defer runner.Done()
+		for i := range specFiles.Files {
+			if err := cit.addTransferTasks(runner, specFiles.Get(i), &succeeded, &failed); err != nil {
+				searchErr = errors.Join(searchErr, err)
+			}
+		}
+	}()
+	runner.Run()
+	result.SetSuccessCount(int(succeeded))
+	result.SetFailCount(int(failed))
+	if searchErr != nil {
+		return searchErr
+	}
+	if failed > 0 {
+		return errorutils.CheckErrorf("failed transferring %d out of %d artifacts to the target server", failed, failed+succeeded)
+	}
+	return nil
+}
+
+// Searches the artifacts of the File-Spec group on the source server, and adds a task for transferring each of them.
+func (cit *crossInstanceTransfer) addTransferTasks(runner parallel.Runner, file *spec.File, succeeded, failed *int32) (err error) {
+	searchParams, err := utils.GetSearchParams(file)
+	if err != nil {
+		return
+	}
+	// Folders are created by the uploads of their artifacts.
+	searchParams.IncludeDirs = false
+	searchParams.Include = []string{"name", "repo", "path", "type", "size", "actual_md5", "actual_sha1", "sha256"}
+	if cit.preserveStats {
+		searchParams.Include = append(searchParams.Include, "stat")
+	}
+	flat, err := file.IsFlat(false)
+	if err != nil {
+		return
+	}
+	reader, err := cit.sourceManager.SearchFiles(searchParams)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		if item.Type == "folder" {
+			continue
+		}
+		targetPath, err := getCrossInstanceTargetPath(file, item, flat)
+		if err != nil {
+			return err
+		}
+		sourceItem := item
+		_, _ = runner.AddTask(func(int) error {
+			if err := cit.transfer(sourceItem, targetPath); err != nil {
+				log.Error(fmt.Sprintf("Failed transferring %s to %s: %s", sourceItem.GetItemRelativePath(), targetPath, err.Error()))
+				atomic.AddInt32(failed, 1)
+				return nil
+			}
+			atomic.AddInt32(succeeded, 1)
+			return nil
+		})
+	}
+	return reader.GetError()
+}
+
+// Returns the path of the artifact on the target server, the same way it's calculated when copying within a server.
+func getCrossInstanceTargetPath(file *spec.File, item *servicesutils.ResultItem, flat bool) (string, error) {
+	targetPath, placeholdersUsed, err := clientutils.BuildTargetPath(file.Pattern, item.GetItemRelativePath(), file.Target, true)
+	if err != nil {
+		return "", err
+	}
+	// When placeholders are used, the path of the artifact isn't taken into account (or in other words, flat = true).
+	if !flat && !placeholdersUsed {
+		if strings.Contains(file.Target, "/") {
+			fileName, dir := fileutils.GetFileAndDirFromPath(file.Target)
+			targetPath = clientutils.TrimPath(dir + "/" + item.Path + "/" + fileName)
+		} else {
+			targetPath = clientutils.TrimPath(file.Target + "/" + item.Path + "/")
+		}
+	}
+	if strings.HasSuffix(targetPath, "/") {
+		targetPath += item.Name
+	}
+	return targetPath, nil
+}
+
+// Transfers the artifact to the target path on the target server, and deletes it from the source server if it's moved.
+func (cit *crossInstanceTransfer) transfer(item *servicesutils.ResultItem, targetPath string) error {
+	sourcePath := item.GetItemRelativePath()
+	action := "Copying"
+	if cit.move {
+		action = "Moving"
+	}
+	if cit.dryRun {
+		log.Info(fmt.Sprintf("[Dry run] %s %s to %s on the target server", action, sourcePath, targetPath))
+		return nil
+	}
+	log.Info(fmt.Sprintf("%s %s to %s on the target server", action, sourcePath, targetPath))
+	targetUrl, err := cit.getTargetUrl(item, targetPath)
+	if err != nil {
+		return err
+	}
+	deployed, err := cit.deployByChecksum(item, targetUrl)
+	if err != nil {
+		return err
+	}
+	if !deployed {
+		if err = cit.stream(item, targetUrl); err != nil {
+			return err
+		}
+	}
+	if !cit.move {
+		return nil
+	}
+	return cit.deleteSource(sourcePath)
+}
+
+// Returns the URL for deploying the artifact to the target path, with the preserved properties as matrix parameters.
+func (cit *crossInstanceTransfer) getTargetUrl(item *servicesutils.ResultItem, targetPath string) (string, error) {
+	targetUrl, err := clientutils.BuildUrl(cit.targetManager.GetConfig().GetServiceDetails().GetUrl(), targetPath, map[string]string{})
+	if err != nil {
+		return "", err
+	}
+	props := servicesutils.NewProperties()
+	if cit.preserveProperties {
+		for _, prop := range item.Properties {
+			props.AddProperty(prop.Key, prop.Value)
+		}
+	}
+	if cit.preserveStats && len(item.Stats) > 0 {
+		stats := item.Stats[0]
+		if stats.Downloads.String() != "" {
+			props.AddProperty(SourceDownloadsProperty, stats.Downloads.String())
+		}
+		if stats.Downloaded != "" {
+			props.AddProperty(SourceLastDownloadedProperty, stats.Downloaded)
+		}
+	}
+	if encodedProps := props.ToEncodedString(false); encodedProps != "" {
+		targetUrl += ";" + encodedProps
+	}
+	return targetUrl, nil
+}
+
+// Deploys the artifact to the target server by its checksums, without sending its content.
+// Returns false if the target server doesn't have a binary with the same checksums.
+func (cit *crossInstanceTransfer) deployByChecksum(item *servicesutils.ResultItem, targetUrl string) (bool, error) {
+	if item.Actual_Sha1 == "" {
+		return false, nil
+	}
+	httpDetails := cit.getTargetHttpDetails(item)
+	httpDetails.Headers["X-Checksum-Deploy"] = "true"
+	resp, body, err := cit.targetManager.Client().SendPut(targetUrl, nil, &httpDetails)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusCreated, http.StatusOK); err != nil {
+		return false, err
+	}
+	log.Debug("Deployed", targetUrl, "by its checksum")
+	return true, nil
+}
+
+// Streams the content of the artifact from the source server to the target server.
+func (cit *crossInstanceTransfer) stream(item *servicesutils.ResultItem, targetUrl string) (err error) {
+	content, err := cit.sourceManager.ReadRemoteFile(item.GetItemRelativePath())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(content.Close()))
+	}()
+	httpDetails := cit.getTargetHttpDetails(item)
+	_, _, err = cit.targetManager.Client().UploadFileFromReader(content, targetUrl, &httpDetails, item.Size)
+	return err
+}
+
+// Returns the details of the requests to the target server, with the checksums of the artifact, which are validated by the target server.
+func (cit *crossInstanceTransfer) getTargetHttpDetails(item *servicesutils.ResultItem) (httpDetails httputils.HttpClientDetails) {
+	httpDetails = cit.targetManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	if httpDetails.Headers == nil {
+		httpDetails.Headers = map[string]string{}
+	}
+	checksums := map[string]string{"X-Checksum-Sha1": item.Actual_Sha1, "X-Checksum-Sha256": item.Sha256, "X-Checksum": item.Actual_Md5}
+	for header, checksum := range checksums {
+		if checksum != "" {
+			httpDetails.Headers[header] = checksum
+		}
+	}
+	return
+}
+
+func (cit *crossInstanceTransfer) deleteSource(sourcePath string) error {
+	deleteUrl, err := clientutils.BuildUrl(cit.sourceManager.GetConfig().GetServiceDetails().GetUrl(), sourcePath, map[string]string{})
+	if err != nil {
+		return err
+	}
+	httpDetails := cit.sourceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := cit.sourceManager.Client().SendDelete(deleteUrl, nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusNoContent, http.StatusOK)
+}
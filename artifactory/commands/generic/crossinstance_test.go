@@ -0,0 +1,164 @@
+package generic
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type crossInstanceTestServers struct {
+	source, target *httptest.Server
+	mutex          sync.Mutex
+	// The requests received by the servers, in the form of "<method> <path>".
+	sourceRequests, targetRequests []string
+	// The content of the artifacts streamed to the target, by their paths with the matrix parameters.
+	streamed map[string]string
+}
+
+func createCrossInstanceTestServers(t *testing.T, targetServiceId string) *crossInstanceTestServers {
+	servers := &crossInstanceTestServers{streamed: map[string]string{}}
+	servers.source = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		servers.mutex.Lock()
+		defer servers.mutex.Unlock()
+		servers.sourceRequests = append(servers.sourceRequests, r.Method+" "+r.URL.Path)
+		var err error
+		switch {
+		case r.URL.Path == "/api/system/service_id":
+			_, err = w.Write([]byte("jfrt@source"))
+		case r.URL.Path == "/api/system/version":
+			_, err = w.Write([]byte(`{"version":"7.80.0"}`))
+		case r.URL.Path == "/api/search/aql":
+			_, err = fmt.Fprintf(w, `{"results":[
+				{"repo":"generic-local","path":"app","name":"a.txt","type":"file","size":1,"actual_sha1":"%s","properties":[{"key":"stage","value":"dev"}],"stats":[{"downloads":7,"downloaded":"2026-10-01T10:00:00.000Z"}]},
+				{"repo":"generic-local","path":"app/lib","name":"b.txt","type":"file","size":1,"actual_sha1":"%s"}
+			]}`, sha1Hex("a"), sha1Hex("b"))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/generic-local/app/"):
+			_, err = w.Write([]byte(strings.TrimSuffix(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], ".txt")))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+		assert.NoError(t, err)
+	}))
+	servers.target = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		servers.mutex.Lock()
+		defer servers.mutex.Unlock()
+		servers.targetRequests = append(servers.targetRequests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/api/system/service_id":
+			_, err := w.Write([]byte(targetServiceId))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPut && r.Header.Get("X-Checksum-Deploy") == "true":
+			// Only the binary of b.txt exists on the target.
+			if r.Header.Get("X-Checksum-Sha1") == sha1Hex("b") {
+				w.WriteHeader(http.StatusCreated)
+			} else {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case r.Method == http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			servers.streamed[r.URL.Path] = string(body)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(servers.source.Close)
+	t.Cleanup(servers.target.Close)
+	return servers
+}
+
+func (s *crossInstanceTestServers) sourceDetails() *config.ServerDetails {
+	return &config.ServerDetails{ArtifactoryUrl: s.source.URL + "/"}
+}
+
+func (s *crossInstanceTestServers) targetDetails() *config.ServerDetails {
+	return &config.ServerDetails{ArtifactoryUrl: s.target.URL + "/"}
+}
+
+func filterRequests(requests []string, method string) (filtered []string) {
+	for _, request := range requests {
+		if strings.HasPrefix(request, method+" ") {
+			filtered = append(filtered, request)
+		}
+	}
+	sort.Strings(filtered)
+	return
+}
+
+func TestCopyToAnotherInstance(t *testing.T) {
+	servers := createCrossInstanceTestServers(t, "jfrt@target")
+	copyCmd := NewCopyCommand().SetTargetServerDetails(servers.targetDetails()).SetPreserveProperties(true).SetPreserveStats(true)
+	copyCmd.SetServerDetails(servers.sourceDetails()).SetSpec(spec.NewBuilder().Pattern("generic-local/app/").Target("backup-local/").BuildSpec())
+	require.NoError(t, copyCmd.Run())
+	assert.Equal(t, 2, copyCmd.Result().SuccessCount())
+	assert.Zero(t, copyCmd.Result().FailCount())
+
+	// a.txt is streamed with its properties and statistics, and b.txt is deployed by its checksum.
+	require.Len(t, servers.streamed, 1)
+	for targetPath, content := range servers.streamed {
+		assert.True(t, strings.HasPrefix(targetPath, "/backup-local/app/a.txt;"))
+		assert.Contains(t, targetPath, "stage=dev")
+		assert.Contains(t, targetPath, SourceDownloadsProperty+"=7")
+		assert.Equal(t, "a", content)
+	}
+	assert.Contains(t, servers.targetRequests, "PUT /backup-local/app/lib/b.txt")
+	// The source artifacts aren't deleted.
+	assert.Empty(t, filterRequests(servers.sourceRequests, http.MethodDelete))
+}
+
+func TestMoveToAnotherInstance(t *testing.T) {
+	servers := createCrossInstanceTestServers(t, "jfrt@target")
+	moveCmd := NewMoveCommand().SetTargetServerDetails(servers.targetDetails())
+	moveCmd.SetServerDetails(servers.sourceDetails()).SetSpec(spec.NewBuilder().Pattern("generic-local/app/").Target("backup-local/").Flat(true).BuildSpec())
+	require.NoError(t, moveCmd.Run())
+	assert.Equal(t, 2, moveCmd.Result().SuccessCount())
+
+	// The properties aren't preserved unless requested.
+	assert.Equal(t, map[string]string{"/backup-local/a.txt": "a"}, servers.streamed)
+	assert.Equal(t, []string{"DELETE /generic-local/app/a.txt", "DELETE /generic-local/app/lib/b.txt"}, filterRequests(servers.sourceRequests, http.MethodDelete))
+}
+
+func TestCopyToSameInstance(t *testing.T) {
+	// The target server has another URL of the source instance.
+	servers := createCrossInstanceTestServers(t, "jfrt@source")
+	copyCmd := NewCopyCommand().SetTargetServerDetails(servers.targetDetails())
+	copyCmd.SetServerDetails(servers.sourceDetails()).SetSpec(spec.NewBuilder().Pattern("generic-local/app/").Target("backup-local/").BuildSpec())
+	require.NoError(t, copyCmd.Run())
+
+	// The artifacts are copied by the source instance.
+	assert.Empty(t, filterRequests(servers.targetRequests, http.MethodPut))
+	assert.NotEmpty(t, filterRequests(servers.sourceRequests, http.MethodPost))
+}
+
+func TestGetCrossInstanceTargetPath(t *testing.T) {
+	item := &servicesutils.ResultItem{Repo: "generic-local", Path: "app/lib", Name: "b.txt"}
+	tests := []struct {
+		pattern, target, expected string
+		flat                      bool
+	}{
+		{"generic-local/app/", "backup-local/", "backup-local/app/lib/b.txt", false},
+		{"generic-local/app/", "backup-local/", "backup-local/b.txt", true},
+		{"generic-local/app/(*)", "backup-local/{1}", "backup-local/lib/b.txt", false},
+		{"generic-local/app/lib/b.txt", "backup-local/c.txt", "backup-local/c.txt", true},
+	}
+	for _, test := range tests {
+		file := spec.NewBuilder().Pattern(test.pattern).Target(test.target).BuildSpec().Files[0]
+		targetPath, err := getCrossInstanceTargetPath(&file, item, test.flat)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, targetPath, test.pattern)
+	}
+}
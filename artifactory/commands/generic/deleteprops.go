@@ -36,7 +36,12 @@ func (dp *DeletePropsCommand) Run() error {
 	}
 	defer reader.Close()
 	propsParams := GetPropsParams(reader, dp.props)
-	success, err := servicesManager.DeleteProps(propsParams)
+	var success int
+	if dp.DryRun() {
+		success, err = logDryRunProps("Deleting properties", dp.props, reader)
+	} else {
+		success, err = servicesManager.DeleteProps(propsParams)
+	}
 	result := dp.Result()
 	result.SetSuccessCount(success)
 	totalLength, totalLengthErr := reader.Length()
@@ -2,16 +2,19 @@ package generic
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	buildinfo "github.com/jfrog/build-info-go/entities"
 	gofrog "github.com/jfrog/gofrog/io"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	coreioutils "github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
@@ -27,6 +30,9 @@ type DownloadCommand struct {
 	GenericCommand
 	configuration *utils.DownloadConfiguration
 	progress      ioUtils.ProgressMgr
+	resume        bool
+	// The bandwidth limit of the download in bytes per second, or 0 to use the JFROG_CLI_RATE_LIMIT environment variable.
+	rateLimit int64
 }
 
 func NewDownloadCommand() *DownloadCommand {
@@ -47,6 +53,19 @@ func (dc *DownloadCommand) SetConfiguration(configuration *utils.DownloadConfigu
 	return dc
 }
 
+// SetResume sets whether to continue from the checkpoint saved by a previous interrupted run with the same arguments.
+func (dc *DownloadCommand) SetResume(resume bool) *DownloadCommand {
+	dc.resume = resume
+	return dc
+}
+
+// SetRateLimit caps the bandwidth of the download in bytes per second. See utils.ParseRateLimit for parsing sizes such as '50MB'.
+// Overrides the rate limit configured by the JFROG_CLI_RATE_LIMIT environment variable.
+func (dc *DownloadCommand) SetRateLimit(rateLimit int64) *DownloadCommand {
+	dc.rateLimit = rateLimit
+	return dc
+}
+
 func (dc *DownloadCommand) SetProgress(progress ioUtils.ProgressMgr) {
 	dc.progress = progress
 }
@@ -68,8 +87,18 @@ func (dc *DownloadCommand) download() (err error) {
 	if dc.progress != nil {
 		dc.progress.InitProgressReaders()
 	}
+	if !dc.configuration.ValidateChecksums {
+		if dc.configuration.ValidateChecksums, dc.configuration.ChecksumMismatchAction, err = utils.GetChecksumMismatchActionFromEnv(); err != nil {
+			return err
+		}
+	}
+	validateChecksums := dc.configuration.ValidateChecksums && !dc.DryRun()
+	var checksumValidator *utils.ChecksumValidator
+	if validateChecksums {
+		checksumValidator = utils.NewChecksumValidator()
+	}
 	// Create Service Manager:
-	servicesManager, err := utils.CreateDownloadServiceManager(dc.serverDetails, dc.configuration.Threads, dc.retries, dc.retryWaitTimeMilliSecs, dc.DryRun(), dc.progress)
+	servicesManager, err := utils.CreateDownloadServiceManager(dc.serverDetails, dc.configuration.Threads, dc.retries, dc.retryWaitTimeMilliSecs, dc.DryRun(), dc.progress, dc.rateLimit, checksumValidator)
 	if err != nil {
 		return err
 	}
@@ -94,6 +123,19 @@ func (dc *DownloadCommand) download() (err error) {
 		}
 	}
 
+	var checkpointPath string
+	var checkpoint *TransferCheckpoint
+	if !dc.DryRun() {
+		if checkpointPath, checkpoint, err = prepareCheckpoint(dc.CommandName(), dc.serverDetails, dc.Spec(), dc.resume); err != nil {
+			return err
+		}
+		if checkpoint != nil {
+			// The files downloaded before the interruption are skipped by the download, since their local copies have the same checksums.
+			log.Info(fmt.Sprintf("Resuming the download from the checkpoint saved at %s. The %d files downloaded before the interruption are skipped, unless they were changed locally.",
+				checkpoint.Created.Format(time.RFC3339), len(checkpoint.Completed)))
+		}
+	}
+
 	var errorOccurred = false
 	var downloadParamsArray []services.DownloadParams
 	// Create DownloadParams for all File-Spec groups.
@@ -112,7 +154,7 @@ func (dc *DownloadCommand) download() (err error) {
 	// otherwise we use the download service which provides only general counters.
 	var totalDownloaded, totalFailed int
 	var summary *serviceutils.OperationSummary
-	if toCollect || dc.SyncDeletesPath() != "" || dc.DetailedSummary() {
+	if toCollect || dc.SyncDeletesPath() != "" || dc.DetailedSummary() || validateChecksums || checkpointPath != "" {
 		summary, err = servicesManager.DownloadFilesWithSummary(downloadParamsArray...)
 		if err != nil {
 			errorOccurred = true
@@ -139,10 +181,25 @@ func (dc *DownloadCommand) download() (err error) {
 	}
 	dc.result.SetSuccessCount(totalDownloaded)
 	dc.result.SetFailCount(totalFailed)
+	if checkpointPath != "" && summary != nil && isCommandCancelled() {
+		if checkpointErr := dc.saveDownloadCheckpoint(checkpointPath, checkpoint, summary.TransferDetailsReader); checkpointErr != nil {
+			log.Error("Failed saving the checkpoint of the download:", checkpointErr.Error())
+		}
+	}
 	// Check for errors.
 	if errorOccurred {
 		return errors.New("download finished with errors, please review the logs")
 	}
+	if checkpointPath != "" && totalFailed == 0 {
+		if err = removeCheckpoint(checkpointPath); err != nil {
+			return err
+		}
+	}
+	if validateChecksums && summary != nil {
+		if err = dc.validateChecksums(servicesManager, checksumValidator, summary); err != nil {
+			return err
+		}
+	}
 	if dc.DryRun() {
 		dc.result.SetSuccessCount(totalDownloaded)
 		dc.result.SetFailCount(0)
@@ -153,7 +210,7 @@ func (dc *DownloadCommand) download() (err error) {
 		if err != nil {
 			return errorutils.CheckError(err)
 		}
-		if _, err = os.Stat(absSyncDeletesPath); err == nil {
+		if _, err = os.Stat(coreioutils.ToLongPath(absSyncDeletesPath)); err == nil {
 			// Unmarshal the local paths of the downloaded files from the results file reader
 			var tmpRoot string
 			tmpRoot, err = createDownloadResultEmptyTmpReflection(summary.TransferDetailsReader)
@@ -164,7 +221,7 @@ func (dc *DownloadCommand) download() (err error) {
 				return err
 			}
 			walkFn := createSyncDeletesWalkFunction(tmpRoot)
-			err = gofrog.Walk(dc.SyncDeletesPath(), walkFn, false)
+			err = gofrog.Walk(coreioutils.ToLongPath(dc.SyncDeletesPath()), walkFn, false)
 			if err != nil {
 				return errorutils.CheckError(err)
 			}
@@ -277,12 +334,12 @@ func createDownloadResultEmptyTmpReflection(reader *content.ContentReader) (tmpR
 		}
 		legalPath := createLegalPath(tmpRoot, absDownloadPath)
 		tmpFileRoot := filepath.Dir(legalPath)
-		err = os.MkdirAll(tmpFileRoot, os.ModePerm)
+		err = os.MkdirAll(coreioutils.ToLongPath(tmpFileRoot), os.ModePerm)
 		if errorutils.CheckError(err) != nil {
 			return
 		}
 		var tmpFile *os.File
-		tmpFile, err = os.Create(legalPath)
+		tmpFile, err = os.Create(coreioutils.ToLongPath(legalPath))
 		if errorutils.CheckError(err) != nil {
 			return
 		}
@@ -312,27 +369,27 @@ func createSyncDeletesWalkFunction(tempRoot string) gofrog.WalkFunc {
 		if err != nil {
 			return err
 		}
-		// Convert path to absolute path
-		path, err = filepath.Abs(path)
+		// Convert path to absolute path, without the long path prefix of the walked root
+		path, err = filepath.Abs(coreioutils.FromLongPath(path))
 		if errorutils.CheckError(err) != nil {
 			return err
 		}
 		pathToCheck := createLegalPath(tempRoot, path)
 
 		// If the path exists under the temp root directory, it means it's been downloaded during the last operations, and cannot be deleted.
-		if fileutils.IsPathExists(pathToCheck, false) {
+		if fileutils.IsPathExists(coreioutils.ToLongPath(pathToCheck), false) {
 			return nil
 		}
 		log.Info("Deleting:", path)
 		if info.IsDir() {
 			// If current path is a dir - remove all content and return ErrSkipDir to stop walking this path
-			err = fileutils.RemoveTempDir(path)
+			err = fileutils.RemoveTempDir(coreioutils.ToLongPath(path))
 			if err == nil {
 				return gofrog.ErrSkipDir
 			}
 		} else {
 			// Path is a file
-			err = os.Remove(path)
+			err = os.Remove(coreioutils.ToLongPath(path))
 		}
 
 		return errorutils.CheckError(err)
@@ -0,0 +1,127 @@
+package generic
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Validates the checksums of the downloaded files against the checksums reported by Artifactory, and handles the mismatches by the
+// configured action. The content of most files is validated by the checksum validator while it's downloaded. The files which weren't
+// validated during the download, such as files downloaded in chunks, are hashed and compared to the SHA-1 or MD5 checksums returned by the search of the download.
+func (dc *DownloadCommand) validateChecksums(servicesManager artifactory.ArtifactoryServicesManager, checksumValidator *utils.ChecksumValidator, summary *servicesutils.OperationSummary) (err error) {
+	// The checksums reported by Artifactory, by the paths of the files in Artifactory.
+	checksums := map[string]entities.Checksum{}
+	for details := new(servicesutils.ArtifactDetails); summary.ArtifactsDetailsReader.NextRecord(details) == nil; details = new(servicesutils.ArtifactDetails) {
+		checksums[details.ArtifactoryPath] = details.Checksums
+	}
+	if err = summary.ArtifactsDetailsReader.GetError(); err != nil {
+		return
+	}
+	// The readers are read again by the build-info collection, the sync-deletes and the detailed summary.
+	summary.ArtifactsDetailsReader.Reset()
+	defer summary.TransferDetailsReader.Reset()
+	if len(checksums) == 0 {
+		return nil
+	}
+	log.Info("Validating the checksums of the downloaded files...")
+	var mismatches []string
+	for details := new(clientutils.FileTransferDetails); summary.TransferDetailsReader.NextRecord(details) == nil; details = new(clientutils.FileTransferDetails) {
+		fileChecksums, exists := checksums[details.SourcePath]
+		if !exists || fileChecksums.IsEmpty() {
+			// Folders have no checksums.
+			continue
+		}
+		match, err := dc.validateDownloadedFile(servicesManager, checksumValidator, details, fileChecksums)
+		if err != nil {
+			return err
+		}
+		if !match {
+			mismatches = append(mismatches, details.TargetPath)
+		}
+	}
+	if err = summary.TransferDetailsReader.GetError(); err != nil {
+		return
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+	dc.result.SetSuccessCount(dc.result.SuccessCount() - len(mismatches))
+	dc.result.SetFailCount(dc.result.FailCount() + len(mismatches))
+	return errorutils.CheckErrorf("the checksums of %d downloaded files don't match the checksums in Artifactory:\n%s", len(mismatches), strings.Join(mismatches, "\n"))
+}
+
+// Returns true if the checksum of the downloaded file matches, possibly after downloading it again by the retry action.
+func (dc *DownloadCommand) validateDownloadedFile(servicesManager artifactory.ArtifactoryServicesManager, checksumValidator *utils.ChecksumValidator,
+	details *clientutils.FileTransferDetails, checksums entities.Checksum) (bool, error) {
+	match, err := isDownloadChecksumMatch(checksumValidator, details, checksums)
+	if err != nil || match {
+		return match, err
+	}
+	localPath := details.TargetPath
+	switch dc.configuration.ChecksumMismatchAction {
+	case utils.ChecksumMismatchRetry:
+		for attempt := 1; attempt <= utils.ChecksumMismatchRetries && !match; attempt++ {
+			log.Warn(fmt.Sprintf("The checksum of '%s' doesn't match the checksum in Artifactory. Downloading it again (attempt %d of %d)...", localPath, attempt, utils.ChecksumMismatchRetries))
+			if err = downloadFileAgain(servicesManager, details.SourcePath, localPath); err != nil {
+				return false, err
+			}
+			if match, err = isDownloadChecksumMatch(checksumValidator, details, checksums); err != nil {
+				return false, err
+			}
+		}
+	case utils.ChecksumMismatchQuarantine:
+		quarantinePath, err := utils.QuarantineFile(localPath)
+		if err != nil {
+			return false, err
+		}
+		log.Warn(fmt.Sprintf("The checksum of '%s' doesn't match the checksum in Artifactory. The file was moved to '%s'.", localPath, quarantinePath))
+	}
+	return match, nil
+}
+
+// Returns the result of the validation of the file during its download. The local file is hashed only if it wasn't validated during the download.
+func isDownloadChecksumMatch(checksumValidator *utils.ChecksumValidator, details *clientutils.FileTransferDetails, checksums entities.Checksum) (bool, error) {
+	if validated, mismatchErr := checksumValidator.Result(details.RtUrl, details.SourcePath); validated {
+		if mismatchErr != nil {
+			log.Debug(fmt.Sprintf("%s: %s", details.SourcePath, mismatchErr.Error()))
+		}
+		return mismatchErr == nil, nil
+	}
+	localDetails, err := fileutils.GetFileDetails(ioutils.ToLongPath(details.TargetPath), true)
+	if err != nil {
+		return false, err
+	}
+	return isChecksumMatch(&servicesutils.ResultItem{Sha256: checksums.Sha256, Actual_Sha1: checksums.Sha1, Actual_Md5: checksums.Md5}, localDetails), nil
+}
+
+// Downloads a single file to its local path. The corrupted file is removed first, so that it isn't reused by the download.
+func downloadFileAgain(servicesManager artifactory.ArtifactoryServicesManager, relativePath, localPath string) error {
+	if err := os.Remove(ioutils.ToLongPath(localPath)); err != nil {
+		return errorutils.CheckError(err)
+	}
+	params := services.NewDownloadParams()
+	params.Pattern = relativePath
+	params.Target = localPath
+	params.Flat = true
+	params.Recursive = false
+	_, failed, err := servicesManager.DownloadFiles(params)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errorutils.CheckErrorf("failed downloading '%s' again", relativePath)
+	}
+	return nil
+}
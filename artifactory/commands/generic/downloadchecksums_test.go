@@ -0,0 +1,111 @@
+package generic
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Creates a server which serves corrupted content for the first corruptedDownloads downloads of the file.
+// If checksumHeader is true, the SHA-256 checksum of the file is returned in the download responses, as Artifactory returns it.
+// Otherwise, only the MD5 checksum is returned by the search, to validate the downloaded file after the download.
+func createChecksumsServer(t *testing.T, corruptedDownloads int32, checksumHeader bool) (server *httptest.Server, downloads, searches *int32) {
+	downloads, searches = new(int32), new(int32)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch r.URL.Path {
+		case "/api/system/version":
+			_, err = w.Write([]byte(`{"version":"7.80.0"}`))
+		case "/api/search/aql":
+			atomic.AddInt32(searches, 1)
+			_, err = fmt.Fprintf(w, `{"results":[{"repo":"generic-local","path":"app","name":"a.txt","type":"file","size":7,"actual_md5":"%s"}]}`, md5Hex("content"))
+		case "/generic-local/app/a.txt":
+			if checksumHeader {
+				w.Header().Set("X-Checksum-Sha256", sha256Hex("content"))
+			}
+			if atomic.AddInt32(downloads, 1) <= corruptedDownloads {
+				_, err = w.Write([]byte("corrupt"))
+			} else {
+				_, err = w.Write([]byte("content"))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		assert.NoError(t, err)
+	}))
+	return
+}
+
+func md5Hex(content string) string {
+	//#nosec G401 -- md5 is supported by Artifactory.
+	checksum := md5.Sum([]byte(content))
+	return hex.EncodeToString(checksum[:])
+}
+
+func runChecksumsDownload(t *testing.T, serverUrl, targetDir string, action utils.ChecksumMismatchAction) (*DownloadCommand, error) {
+	downloadCmd := NewDownloadCommand()
+	downloadCmd.SetConfiguration(&utils.DownloadConfiguration{Threads: 1, ValidateChecksums: true, ChecksumMismatchAction: action}).
+		SetBuildConfiguration(new(build.BuildConfiguration))
+	downloadCmd.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: serverUrl + "/"}).
+		SetSpec(spec.NewBuilder().Pattern("generic-local/app/").Target(targetDir + "/").BuildSpec())
+	return downloadCmd, downloadCmd.Run()
+}
+
+func TestDownloadValidateChecksums(t *testing.T) {
+	for _, checksumHeader := range []bool{true, false} {
+		t.Run(fmt.Sprintf("checksum header %t", checksumHeader), func(t *testing.T) {
+			testDownloadValidateChecksums(t, checksumHeader)
+		})
+	}
+}
+
+func testDownloadValidateChecksums(t *testing.T, checksumHeader bool) {
+	t.Run("fail", func(t *testing.T) {
+		server, _, searches := createChecksumsServer(t, 1, checksumHeader)
+		defer server.Close()
+		targetDir := t.TempDir()
+		downloadCmd, err := runChecksumsDownload(t, server.URL, targetDir, utils.ChecksumMismatchFail)
+		assert.ErrorContains(t, err, "the checksums of 1 downloaded files don't match the checksums in Artifactory")
+		assert.Equal(t, 0, downloadCmd.Result().SuccessCount())
+		assert.Equal(t, 1, downloadCmd.Result().FailCount())
+		assert.FileExists(t, filepath.Join(targetDir, "app", "a.txt"))
+		// The checksums aren't searched again for the validation.
+		assert.Equal(t, int32(1), atomic.LoadInt32(searches))
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		server, downloads, _ := createChecksumsServer(t, 2, checksumHeader)
+		defer server.Close()
+		targetDir := t.TempDir()
+		downloadCmd, err := runChecksumsDownload(t, server.URL, targetDir, utils.ChecksumMismatchRetry)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(downloads))
+		assert.Equal(t, 1, downloadCmd.Result().SuccessCount())
+		content, err := os.ReadFile(filepath.Join(targetDir, "app", "a.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(content))
+	})
+
+	t.Run("quarantine", func(t *testing.T) {
+		server, _, _ := createChecksumsServer(t, 1, checksumHeader)
+		defer server.Close()
+		targetDir := t.TempDir()
+		_, err := runChecksumsDownload(t, server.URL, targetDir, utils.ChecksumMismatchQuarantine)
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(targetDir, "app", "a.txt"))
+		assert.FileExists(t, filepath.Join(targetDir, "app", "a.txt"+utils.QuarantineSuffix))
+	})
+}
@@ -32,6 +32,11 @@ func (gc *GenericCommand) SetDryRun(dryRun bool) *GenericCommand {
 	return gc
 }
 
+// EnableDryRun implements commands.DryRunCommand.
+func (gc *GenericCommand) EnableDryRun() {
+	gc.dryRun = true
+}
+
 func (gc *GenericCommand) SyncDeletesPath() string {
 	return gc.syncDeletesPath
 }
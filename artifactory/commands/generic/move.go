@@ -5,13 +5,17 @@ import (
 
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type MoveCommand struct {
 	GenericCommand
-	threads int
+	threads             int
+	targetServerDetails *config.ServerDetails
+	preserveProperties  bool
+	preserveStats       bool
 }
 
 func NewMoveCommand() *MoveCommand {
@@ -27,6 +31,26 @@ func (mc *MoveCommand) SetThreads(threads int) *MoveCommand {
 	return mc
 }
 
+// SetTargetServerDetails sets the server to move the artifacts to, if it's not the source server.
+// If the target server is another instance, the artifacts are streamed from the source server to the target server.
+func (mc *MoveCommand) SetTargetServerDetails(targetServerDetails *config.ServerDetails) *MoveCommand {
+	mc.targetServerDetails = targetServerDetails
+	return mc
+}
+
+// SetPreserveProperties sets whether to set the properties of the artifacts on their copies on another instance.
+// The properties are always preserved by a move within an instance.
+func (mc *MoveCommand) SetPreserveProperties(preserveProperties bool) *MoveCommand {
+	mc.preserveProperties = preserveProperties
+	return mc
+}
+
+// SetPreserveStats sets whether to keep the download statistics of the artifacts as properties of their copies on another instance.
+func (mc *MoveCommand) SetPreserveStats(preserveStats bool) *MoveCommand {
+	mc.preserveStats = preserveStats
+	return mc
+}
+
 // Moves the artifacts using the specified move pattern.
 func (mc *MoveCommand) Run() error {
 	// Create Service Manager:
@@ -34,6 +58,15 @@ func (mc *MoveCommand) Run() error {
 	if err != nil {
 		return err
 	}
+	transfer, err := newCrossInstanceTransfer(servicesManager, mc.serverDetails, mc.targetServerDetails, mc.DryRun(), mc.threads, mc.retries, mc.retryWaitTimeMilliSecs)
+	if err != nil {
+		return err
+	}
+	if transfer != nil {
+		transfer.move = true
+		transfer.preserveProperties, transfer.preserveStats = mc.preserveProperties, mc.preserveStats
+		return transfer.run(mc.Spec(), mc.result)
+	}
 
 	var errorOccurred = false
 	var moveParamsArray []services.MoveCopyParams
@@ -1,17 +1,28 @@
 package generic
 
 import (
+	"encoding/json"
+
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 )
 
 type PingCommand struct {
 	serverDetails *config.ServerDetails
 	response      []byte
+	// If true, the reachability of all the configured services, the authentication and the throughput are checked, and a health report is returned.
+	deep bool
+	// The repository to which a probe file is uploaded, to measure the throughput. If empty, the throughput isn't measured.
+	probeRepo    string
+	probeSize    int
+	healthReport *HealthReport
+	// In dry run mode, the probe file isn't uploaded, so the throughput isn't measured.
+	dryRun bool
 }
 
 func NewPingCommand() *PingCommand {
-	return &PingCommand{}
+	return &PingCommand{probeSize: DefaultProbeSize}
 }
 
 func (pc *PingCommand) Response() []byte {
@@ -27,11 +38,43 @@ func (pc *PingCommand) SetServerDetails(serverDetails *config.ServerDetails) *Pi
 	return pc
 }
 
+func (pc *PingCommand) SetDeep(deep bool) *PingCommand {
+	pc.deep = deep
+	return pc
+}
+
+func (pc *PingCommand) SetProbeRepo(probeRepo string) *PingCommand {
+	pc.probeRepo = probeRepo
+	return pc
+}
+
+func (pc *PingCommand) SetProbeSize(probeSize int) *PingCommand {
+	pc.probeSize = probeSize
+	return pc
+}
+
+func (pc *PingCommand) HealthReport() *HealthReport {
+	return pc.healthReport
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand. Only the deep health check with a probe repository uploads a file.
+func (pc *PingCommand) IsReadOnly() bool {
+	return !pc.deep || pc.probeRepo == ""
+}
+
+// EnableDryRun implements commands.DryRunCommand.
+func (pc *PingCommand) EnableDryRun() {
+	pc.dryRun = true
+}
+
 func (pc *PingCommand) CommandName() string {
 	return "rt_ping"
 }
 
 func (pc *PingCommand) Run() error {
+	if pc.deep {
+		return pc.checkHealth()
+	}
 	var err error
 	pc.response, err = pc.Ping()
 	if err != nil {
@@ -47,3 +90,19 @@ func (pc *PingCommand) Ping() ([]byte, error) {
 	}
 	return servicesManager.Ping()
 }
+
+// Runs the deep health check. The response is the JSON health report.
+func (pc *PingCommand) checkHealth() error {
+	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, 0, 0, false)
+	if err != nil {
+		return err
+	}
+	pc.healthReport = pc.runHealthCheck(servicesManager)
+	if pc.response, err = json.Marshal(pc.healthReport); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if !pc.healthReport.Healthy {
+		return errorutils.CheckErrorf("the health check of %s failed", pc.serverDetails.ArtifactoryUrl)
+	}
+	return nil
+}
@@ -3,8 +3,12 @@ package generic
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
@@ -44,3 +48,87 @@ func TestPingFailed(t *testing.T) {
 		t.Fail()
 	}
 }
+
+// Simulates Artifactory and Xray. The Distribution service is unreachable.
+func createHealthCheckServer(t *testing.T) (*httptest.Server, *[]string) {
+	var mutex sync.Mutex
+	var probeRequests []string
+	var probe []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case r.URL.Path == "/artifactory/api/system/ping" || r.URL.Path == "/xray/api/v1/system/ping" || r.URL.Path == "/access/api/v1/system/ping":
+			_, err := fmt.Fprint(w, "OK")
+			assert.NoError(t, err)
+		case r.URL.Path == "/artifactory/api/system/version":
+			_, err := fmt.Fprint(w, `{"version":"7.90.0"}`)
+			assert.NoError(t, err)
+		case strings.HasPrefix(r.URL.Path, "/artifactory/probe-local/"+probeFolder+"/"):
+			probeRequests = append(probeRequests, r.Method)
+			switch r.Method {
+			case http.MethodPut:
+				var err error
+				probe, err = io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				w.WriteHeader(http.StatusCreated)
+			case http.MethodGet:
+				_, err := w.Write(probe)
+				assert.NoError(t, err)
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			}
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	return server, &probeRequests
+}
+
+func TestPingHealthCheck(t *testing.T) {
+	server, probeRequests := createHealthCheckServer(t)
+	defer server.Close()
+	serverDetails := &config.ServerDetails{Url: server.URL + "/", ArtifactoryUrl: server.URL + "/artifactory/", XrayUrl: server.URL + "/xray/", AccessToken: "token"}
+	pingCmd := NewPingCommand().SetServerDetails(serverDetails).SetDeep(true).SetProbeRepo("probe-local").SetProbeSize(1024)
+	require.NoError(t, pingCmd.Run())
+	report := pingCmd.HealthReport()
+	assert.True(t, report.Healthy)
+	var statuses []string
+	for _, check := range report.Checks {
+		statuses = append(statuses, check.Name+":"+string(check.Status))
+	}
+	assert.Equal(t, []string{"Artifactory:OK", "Authentication:OK", "Access:OK", "Xray:OK", "Distribution:SKIPPED", "Upload throughput:OK", "Download throughput:OK"}, statuses)
+	assert.Equal(t, "Artifactory version: 7.90.0", report.Checks[1].Details)
+	assert.Equal(t, 1024, report.Throughput.ProbeSizeBytes)
+	assert.Equal(t, []string{http.MethodPut, http.MethodGet, http.MethodDelete}, *probeRequests)
+	assert.Contains(t, string(pingCmd.Response()), `"healthy":true`)
+
+	// The Distribution service is configured, but unreachable.
+	serverDetails.DistributionUrl = server.URL + "/distribution/"
+	pingCmd = NewPingCommand().SetServerDetails(serverDetails).SetDeep(true)
+	assert.EqualError(t, pingCmd.Run(), "the health check of "+serverDetails.ArtifactoryUrl+" failed")
+	report = pingCmd.HealthReport()
+	assert.False(t, report.Healthy)
+	assert.Nil(t, report.Throughput)
+	assert.Equal(t, HealthFailed, report.Checks[4].Status)
+	assert.NotEmpty(t, report.Checks[4].Error)
+}
+
+func TestPingHealthCheckDryRun(t *testing.T) {
+	server, probeRequests := createHealthCheckServer(t)
+	defer server.Close()
+	serverDetails := &config.ServerDetails{Url: server.URL + "/", ArtifactoryUrl: server.URL + "/artifactory/", AccessToken: "token"}
+	pingCmd := NewPingCommand().SetServerDetails(serverDetails)
+	assert.True(t, pingCmd.IsReadOnly())
+	pingCmd.SetDeep(true).SetProbeRepo("probe-local")
+	assert.False(t, pingCmd.IsReadOnly())
+
+	// The probe file isn't uploaded in dry run mode.
+	pingCmd.EnableDryRun()
+	require.NoError(t, pingCmd.Run())
+	report := pingCmd.HealthReport()
+	assert.True(t, report.Healthy)
+	assert.Equal(t, HealthSkipped, report.Checks[len(report.Checks)-2].Status)
+	assert.Equal(t, HealthSkipped, report.Checks[len(report.Checks)-1].Status)
+	assert.Empty(t, *probeRequests)
+}
@@ -0,0 +1,181 @@
+package generic
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type HealthStatus string
+
+const (
+	HealthOk      HealthStatus = "OK"
+	HealthFailed  HealthStatus = "FAILED"
+	HealthSkipped HealthStatus = "SKIPPED"
+
+	// The size of the probe file, uploaded and downloaded to measure the throughput.
+	DefaultProbeSize = 1024 * 1024
+	probeFolder      = ".jfrog-health-probe"
+)
+
+// HealthReport is the result of the deep health check of the JFrog Platform.
+type HealthReport struct {
+	Healthy    bool              `json:"healthy"`
+	Checks     []HealthCheck     `json:"checks"`
+	Throughput *ThroughputReport `json:"throughput,omitempty"`
+}
+
+type HealthCheck struct {
+	Name      string       `json:"name"`
+	Url       string       `json:"url,omitempty"`
+	Status    HealthStatus `json:"status"`
+	LatencyMs int64        `json:"latencyMs,omitempty"`
+	Details   string       `json:"details,omitempty"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// ThroughputReport is the throughput measured by uploading and downloading a probe file.
+type ThroughputReport struct {
+	Repository     string  `json:"repository"`
+	ProbeSizeBytes int     `json:"probeSizeBytes"`
+	UploadMBps     float64 `json:"uploadMBps,omitempty"`
+	DownloadMBps   float64 `json:"downloadMBps,omitempty"`
+}
+
+func (pc *PingCommand) runHealthCheck(servicesManager artifactory.ArtifactoryServicesManager) *HealthReport {
+	report := &HealthReport{Healthy: true}
+	rtUrl := pc.serverDetails.ArtifactoryUrl
+	report.addCheck(pc.pingService(servicesManager, "Artifactory", rtUrl, "api/system/ping"))
+	report.addCheck(checkAuthentication(servicesManager, rtUrl))
+	if pc.serverDetails.Url != "" {
+		report.addCheck(pc.pingService(servicesManager, "Access", clientutils.AddTrailingSlashIfNeeded(pc.serverDetails.Url)+"access/", "api/v1/system/ping"))
+	}
+	report.addCheck(pc.pingService(servicesManager, "Xray", pc.serverDetails.XrayUrl, "api/v1/system/ping"))
+	report.addCheck(pc.pingService(servicesManager, "Distribution", pc.serverDetails.DistributionUrl, "api/v1/system/ping"))
+	if pc.probeRepo != "" {
+		var checks []HealthCheck
+		report.Throughput, checks = pc.measureThroughput(servicesManager)
+		for _, check := range checks {
+			report.addCheck(check)
+		}
+	}
+	return report
+}
+
+func (hr *HealthReport) addCheck(check HealthCheck) {
+	if check.Status == HealthFailed {
+		hr.Healthy = false
+		log.Debug(fmt.Sprintf("The '%s' health check failed: %s", check.Name, check.Error))
+	}
+	hr.Checks = append(hr.Checks, check)
+}
+
+// Checks the reachability of a service by its ping endpoint. Services without a configured URL are skipped.
+func (pc *PingCommand) pingService(servicesManager artifactory.ArtifactoryServicesManager, name, serviceUrl, pingApi string) HealthCheck {
+	check := HealthCheck{Name: name, Url: serviceUrl, Status: HealthSkipped}
+	if serviceUrl == "" {
+		check.Details = "The service URL isn't configured"
+		return check
+	}
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	start := time.Now()
+	resp, body, _, err := servicesManager.Client().SendGet(clientutils.AddTrailingSlashIfNeeded(serviceUrl)+pingApi, true, &httpDetails)
+	check.LatencyMs = time.Since(start).Milliseconds()
+	if err == nil {
+		err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+	}
+	return check.complete(err)
+}
+
+// The ping endpoint of Artifactory doesn't require authentication, so the credentials are verified by getting the Artifactory version.
+func checkAuthentication(servicesManager artifactory.ArtifactoryServicesManager, rtUrl string) HealthCheck {
+	check := HealthCheck{Name: "Authentication", Url: rtUrl}
+	start := time.Now()
+	version, err := servicesManager.GetVersion()
+	check.LatencyMs = time.Since(start).Milliseconds()
+	if err == nil {
+		check.Details = "Artifactory version: " + version
+	}
+	return check.complete(err)
+}
+
+// Uploads a probe file to the probe repository, downloads it and deletes it, measuring the upload and download throughput.
+func (pc *PingCommand) measureThroughput(servicesManager artifactory.ArtifactoryServicesManager) (*ThroughputReport, []HealthCheck) {
+	report := &ThroughputReport{Repository: pc.probeRepo, ProbeSizeBytes: pc.probeSize}
+	probeUrl := servicesManager.GetConfig().GetServiceDetails().GetUrl() + pc.probeRepo + "/" + probeFolder + "/probe-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	uploadCheck := HealthCheck{Name: "Upload throughput", Url: probeUrl}
+	downloadCheck := HealthCheck{Name: "Download throughput", Url: probeUrl}
+	if pc.dryRun {
+		commands.LogDryRunAction("Uploading a probe file of", pc.probeSize, "bytes to:", probeUrl)
+		uploadCheck.Details, downloadCheck.Details = "Dry run", "Dry run"
+		return report, []HealthCheck{uploadCheck.skip(), downloadCheck.skip()}
+	}
+	probe := make([]byte, pc.probeSize)
+	if _, err := rand.Read(probe); err != nil {
+		return report, []HealthCheck{uploadCheck.complete(err), downloadCheck.skip()}
+	}
+
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	start := time.Now()
+	resp, body, err := servicesManager.Client().SendPut(probeUrl, probe, &httpDetails)
+	uploadCheck.LatencyMs = time.Since(start).Milliseconds()
+	if err == nil {
+		err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated)
+	}
+	if err != nil {
+		return report, []HealthCheck{uploadCheck.complete(err), downloadCheck.skip()}
+	}
+	report.UploadMBps = getMBps(pc.probeSize, time.Since(start))
+	uploadCheck.Details = fmt.Sprintf("%.2f MB/s", report.UploadMBps)
+	defer func() {
+		deleteDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+		if resp, body, err := servicesManager.Client().SendDelete(probeUrl, nil, &deleteDetails); err != nil || errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent) != nil {
+			log.Warn("Failed to delete the health check probe file", probeUrl)
+		}
+	}()
+
+	start = time.Now()
+	resp, body, _, err = servicesManager.Client().SendGet(probeUrl, true, &httpDetails)
+	downloadCheck.LatencyMs = time.Since(start).Milliseconds()
+	if err == nil {
+		err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+	}
+	if err == nil && !bytes.Equal(body, probe) {
+		err = errorutils.CheckErrorf("the content of the downloaded probe file doesn't match the uploaded content")
+	}
+	if err == nil {
+		report.DownloadMBps = getMBps(pc.probeSize, time.Since(start))
+		downloadCheck.Details = fmt.Sprintf("%.2f MB/s", report.DownloadMBps)
+	}
+	return report, []HealthCheck{uploadCheck.complete(nil), downloadCheck.complete(err)}
+}
+
+func (check HealthCheck) complete(err error) HealthCheck {
+	if err != nil {
+		check.Status, check.Error = HealthFailed, err.Error()
+		return check
+	}
+	check.Status = HealthOk
+	return check
+}
+
+func (check HealthCheck) skip() HealthCheck {
+	check.Status = HealthSkipped
+	return check
+}
+
+func getMBps(size int, duration time.Duration) float64 {
+	if duration <= 0 {
+		duration = time.Millisecond
+	}
+	return float64(size) / (1024 * 1024) / duration.Seconds()
+}
@@ -2,11 +2,13 @@ package generic
 
 import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientConfig "github.com/jfrog/jfrog-client-go/config"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
@@ -101,6 +103,20 @@ func searchItems(spec *spec.SpecFiles, servicesManager artifactory.ArtifactorySe
 	return
 }
 
+// Reports the items whose properties would have been modified in dry run mode, instead of modifying them.
+// Returns the number of reported items.
+func logDryRunProps(action, props string, reader *content.ContentReader) (count int, err error) {
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		commands.LogDryRunAction(action, "'"+props+"'", "on:", item.GetItemRelativePath())
+		count++
+	}
+	if err = reader.GetError(); err != nil {
+		return
+	}
+	reader.Reset()
+	return
+}
+
 func GetPropsParams(reader *content.ContentReader, properties string) (propsParams services.PropsParams) {
 	propsParams = services.NewPropsParams()
 	propsParams.Reader = reader
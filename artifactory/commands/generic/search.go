@@ -3,6 +3,7 @@ package generic
 import (
 	"errors"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	clientartutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
@@ -37,6 +38,10 @@ func (sc *SearchCommand) Search() (*content.ContentReader, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Searches by build use the default project, if no project was provided.
+	for i := range sc.Spec().Files {
+		sc.Spec().Files[i].Project = config.GetProjectKey(sc.Spec().Files[i].Project, serverDetails)
+	}
 	// Search Loop
 	log.Info("Searching artifacts...")
 
@@ -37,7 +37,12 @@ func (setProps *SetPropsCommand) Run() error {
 	}
 	defer reader.Close()
 	propsParams := GetPropsParams(reader, setProps.props)
-	success, err := servicesManager.SetProps(propsParams)
+	var success int
+	if setProps.DryRun() {
+		success, err = logDryRunProps("Setting properties", setProps.props, reader)
+	} else {
+		success, err = servicesManager.SetProps(propsParams)
+	}
 
 	result := setProps.Result()
 	result.SetSuccessCount(success)
@@ -9,9 +9,11 @@ import (
 	buildInfo "github.com/jfrog/build-info-go/entities"
 
 	ioutils "github.com/jfrog/gofrog/io"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientUtils "github.com/jfrog/jfrog-client-go/utils"
@@ -20,6 +22,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -28,10 +31,20 @@ type UploadCommand struct {
 	uploadConfiguration *utils.UploadConfiguration
 	buildConfiguration  *build.BuildConfiguration
 	progress            ioUtils.ProgressMgr
+	watch               bool
+	watchDebounce       time.Duration
+	serversDetails      []*config.ServerDetails
+	syncOnlyIf          []UploadPredicate
+	resume              bool
+	// The bandwidth limit of the upload in bytes per second, or 0 to use the JFROG_CLI_RATE_LIMIT environment variable.
+	rateLimit int64
+	// True if uploading to one of the additional servers, when uploading to multiple servers.
+	// The build-info artifacts and the command summary are recorded only by the upload to the first server.
+	isSecondaryServer bool
 }
 
 func NewUploadCommand() *UploadCommand {
-	return &UploadCommand{GenericCommand: *NewGenericCommand()}
+	return &UploadCommand{GenericCommand: *NewGenericCommand(), watchDebounce: DefaultWatchDebounce}
 }
 
 func (uc *UploadCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *UploadCommand {
@@ -48,6 +61,50 @@ func (uc *UploadCommand) SetUploadConfiguration(uploadConfiguration *utils.Uploa
 	return uc
 }
 
+// SetWatch sets whether to keep watching the local directories of the spec after the upload,
+// and upload new and changed files as they appear, until the process is interrupted.
+func (uc *UploadCommand) SetWatch(watch bool) *UploadCommand {
+	uc.watch = watch
+	return uc
+}
+
+// SetWatchDebounce sets the duration with no changes to wait for, before uploading the changed files in watch mode.
+func (uc *UploadCommand) SetWatchDebounce(watchDebounce time.Duration) *UploadCommand {
+	uc.watchDebounce = watchDebounce
+	return uc
+}
+
+// SetServersDetails sets multiple servers to upload the artifacts to concurrently.
+func (uc *UploadCommand) SetServersDetails(serversDetails []*config.ServerDetails) *UploadCommand {
+	uc.serversDetails = serversDetails
+	if len(serversDetails) > 0 {
+		uc.SetServerDetails(serversDetails[0])
+	}
+	return uc
+}
+
+// SetSyncOnlyIf sets predicates which each file must satisfy to be uploaded. The files which don't satisfy them are skipped.
+// The predicates cannot be combined with sync-deletes, which would delete the skipped files from the target.
+func (uc *UploadCommand) SetSyncOnlyIf(syncOnlyIf []UploadPredicate) *UploadCommand {
+	uc.syncOnlyIf = syncOnlyIf
+	return uc
+}
+
+// SetResume sets whether to continue from the checkpoint saved by a previous interrupted run with the same arguments.
+// The files uploaded before the interruption are skipped, unless they were changed since.
+// Resuming cannot be combined with sync-deletes, which would delete the skipped files from the target.
+func (uc *UploadCommand) SetResume(resume bool) *UploadCommand {
+	uc.resume = resume
+	return uc
+}
+
+// SetRateLimit caps the bandwidth of the upload in bytes per second. See utils.ParseRateLimit for parsing sizes such as '50MB'.
+// Overrides the rate limit configured by the JFROG_CLI_RATE_LIMIT environment variable.
+func (uc *UploadCommand) SetRateLimit(rateLimit int64) *UploadCommand {
+	uc.rateLimit = rateLimit
+	return uc
+}
+
 func (uc *UploadCommand) SetProgress(progress ioUtils.ProgressMgr) {
 	uc.progress = progress
 }
@@ -64,7 +121,24 @@ func (uc *UploadCommand) CommandName() string {
 	return "rt_upload"
 }
 
+// In watch mode, the upload keeps running until it's interrupted, and stops by itself when it is.
+func (uc *UploadCommand) HandlesInterrupts() bool {
+	return uc.watch
+}
+
 func (uc *UploadCommand) Run() error {
+	if len(uc.syncOnlyIf) > 0 && uc.SyncDeletesPath() != "" {
+		return errorutils.CheckErrorf("the sync-only-if predicates cannot be used with sync-deletes, since the skipped files would be deleted")
+	}
+	if uc.resume && uc.SyncDeletesPath() != "" {
+		return errorutils.CheckErrorf("the upload cannot be resumed with sync-deletes, since the files skipped by the checkpoint would be deleted")
+	}
+	if len(uc.serversDetails) > 1 {
+		return uc.uploadToServers()
+	}
+	if uc.watch {
+		return uc.uploadAndWatch()
+	}
 	return uc.upload()
 }
 
@@ -75,6 +149,26 @@ func (uc *UploadCommand) upload() (err error) {
 	if uc.progress != nil {
 		uc.progress.InitProgressReaders()
 	}
+	// The checkpoint is identified by the spec of the command, before it's modified by the upload.
+	var checkpointPath string
+	var checkpoint *TransferCheckpoint
+	if !uc.DryRun() && !uc.watch {
+		var serverDetails *config.ServerDetails
+		if serverDetails, err = uc.ServerDetails(); err != nil {
+			return
+		}
+		if checkpointPath, checkpoint, err = prepareCheckpoint(uc.CommandName(), serverDetails, uc.Spec(), uc.resume); err != nil {
+			return
+		}
+	}
+	// Package the files of the groups with local archives, which are uploaded instead of them.
+	cleanupArchives, err := uc.prepareLocalArchives()
+	defer func() {
+		err = errors.Join(err, cleanupArchives())
+	}()
+	if err != nil {
+		return
+	}
 	// In case of sync-delete get the user to confirm first, and save the operation timestamp.
 	syncDeletesProp := ""
 	if uc.syncDelete() {
@@ -91,12 +185,12 @@ func (uc *UploadCommand) upload() (err error) {
 	if errorutils.CheckError(err) != nil {
 		return
 	}
-	servicesManager, err := utils.CreateUploadServiceManager(serverDetails, uc.uploadConfiguration.Threads, uc.retries, uc.retryWaitTimeMilliSecs, uc.DryRun(), uc.progress)
+	servicesManager, err := utils.CreateUploadServiceManager(serverDetails, uc.uploadConfiguration.Threads, uc.retries, uc.retryWaitTimeMilliSecs, uc.DryRun(), uc.progress, uc.rateLimit)
 	if err != nil {
 		return
 	}
 
-	addVcsProps := false
+	addVcsProps := uc.uploadConfiguration.AddVcsProps && !uc.DryRun()
 	buildProps := ""
 	// Build Info Collection:
 	toCollect, err := uc.buildConfiguration.IsCollectBuildInfo()
@@ -111,15 +205,36 @@ func (uc *UploadCommand) upload() (err error) {
 		}
 	}
 
-	var errorOccurred = false
-	var uploadParamsArray []services.UploadParams
-	// Create UploadParams for all File-Spec groups.
-	for i := 0; i < len(uc.Spec().Files); i++ {
-		file := uc.Spec().Get(i)
+	specFiles := uc.Spec()
+	if checkpoint != nil {
+		if specFiles, err = applyUploadCheckpoint(specFiles, checkpoint); err != nil {
+			return
+		}
+	}
+	// Skip the files which don't satisfy the sync-only-if predicates, before uploading any file.
+	if len(uc.syncOnlyIf) > 0 {
+		if specFiles, err = uc.applySyncOnlyIf(servicesManager, specFiles); err != nil {
+			return
+		}
+	}
+
+	for i := 0; i < len(specFiles.Files); i++ {
+		file := specFiles.Get(i)
 		file.TargetProps = clientUtils.AddProps(file.TargetProps, file.Props)
 		file.TargetProps = clientUtils.AddProps(file.TargetProps, syncDeletesProp)
 		file.Props += syncDeletesProp
-		uploadParams, err := getUploadParams(file, uc.uploadConfiguration, buildProps, addVcsProps)
+	}
+	// Deploy the files whose checksums are cached by their checksums, and upload only the rest of the files.
+	specFiles, checksumCacheDeploys, err := uc.deployByCachedChecksums(servicesManager, specFiles, buildProps, addVcsProps)
+	if err != nil {
+		return
+	}
+
+	var errorOccurred = false
+	var uploadParamsArray []services.UploadParams
+	// Create UploadParams for all File-Spec groups.
+	for i := 0; i < len(specFiles.Files); i++ {
+		uploadParams, err := getUploadParams(specFiles.Get(i), uc.uploadConfiguration, buildProps, addVcsProps)
 		if err != nil {
 			errorOccurred = true
 			log.Error(err)
@@ -131,42 +246,54 @@ func (uc *UploadCommand) upload() (err error) {
 	// Perform upload.
 	// In case of build-info collection or a detailed summary request, we use the upload service which provides results file reader,
 	// otherwise we use the upload service which provides only general counters.
-	var successCount, failCount int
-	var artifactsDetailsReader *content.ContentReader = nil
-	if uc.DetailedSummary() || toCollect {
-		var summary *rtServicesUtils.OperationSummary
-		summary, err = servicesManager.UploadFilesWithSummary(uploadParamsArray...)
-		if err != nil {
-			errorOccurred = true
-			log.Error(err)
-		}
+	// The uploaded files are also read from the results, to save them in the checkpoint if the upload is interrupted.
+	withSummary := uc.DetailedSummary() || toCollect || checkpointPath != ""
+	summary, successCount, failCount, uploadErr := performUpload(servicesManager, withSummary, uploadParamsArray)
+	if isMultipartUploadFailure(uploadErr) && !isCommandCancelled() && uc.shouldFallbackToStandardUpload(serverDetails, servicesManager, uploadParamsArray) {
+		log.Warn("Some files failed to upload. Retrying the upload without direct cloud storage multipart uploads...")
 		if summary != nil {
-			artifactsDetailsReader = summary.ArtifactsDetailsReader
-			defer ioutils.Close(artifactsDetailsReader, &err)
-			// If 'detailed summary' was requested, then the reader should not be closed here.
-			// It will be closed after it will be used to generate the summary.
-			if uc.DetailedSummary() {
-				uc.result.SetReader(summary.TransferDetailsReader)
-			} else {
-				err = summary.TransferDetailsReader.Close()
-				if err != nil {
-					errorOccurred = true
-					log.Error(err)
-				}
+			if err = summary.Close(); err != nil {
+				return
 			}
-			successCount = summary.TotalSucceeded
-			failCount = summary.TotalFailed
+		}
+		summary, successCount, failCount, uploadErr = performUpload(servicesManager, withSummary, disableMultipartUpload(uploadParamsArray))
+	}
+	if uploadErr != nil {
+		errorOccurred = true
+		log.Error(uploadErr)
+	}
+	successCount += checksumCacheDeploys.count()
+	if summary != nil {
+		if err = mergeChecksumCacheDeploys(summary, checksumCacheDeploys); err != nil {
+			return
+		}
+	}
+	if checkpointPath != "" && summary != nil && isCommandCancelled() {
+		if checkpointErr := uc.saveUploadCheckpoint(checkpointPath, checkpoint, specFiles, summary.TransferDetailsReader); checkpointErr != nil {
+			log.Error("Failed saving the checkpoint of the upload:", checkpointErr.Error())
+		}
+	}
+	var artifactsDetailsReader *content.ContentReader = nil
+	if summary != nil {
+		artifactsDetailsReader = summary.ArtifactsDetailsReader
+		defer ioutils.Close(artifactsDetailsReader, &err)
+		// If 'detailed summary' was requested, then the reader should not be closed here.
+		// It will be closed after it will be used to generate the summary.
+		if uc.DetailedSummary() {
+			uc.result.SetReader(summary.TransferDetailsReader)
+		} else {
+			err = summary.TransferDetailsReader.Close()
+			if err != nil {
+				errorOccurred = true
+				log.Error(err)
+			}
+		}
 
+		if !uc.isSecondaryServer {
 			if err = recordCommandSummary(summary, serverDetails.Url, uc.buildConfiguration); err != nil {
 				return
 			}
 		}
-	} else {
-		successCount, failCount, err = servicesManager.UploadFiles(uploadParamsArray...)
-		if err != nil {
-			errorOccurred = true
-			log.Error(err)
-		}
 	}
 	uc.result.SetSuccessCount(successCount)
 	uc.result.SetFailCount(failCount)
@@ -177,6 +304,11 @@ func (uc *UploadCommand) upload() (err error) {
 	if failCount > 0 {
 		return
 	}
+	if checkpointPath != "" {
+		if err = removeCheckpoint(checkpointPath); err != nil {
+			return
+		}
+	}
 
 	// Handle sync-deletes
 	if uc.syncDelete() {
@@ -187,7 +319,7 @@ func (uc *UploadCommand) upload() (err error) {
 	}
 
 	// Build info
-	if !uc.DryRun() && toCollect {
+	if !uc.DryRun() && toCollect && !uc.isSecondaryServer {
 		var buildArtifacts []buildInfo.Artifact
 		buildArtifacts, err = rtServicesUtils.ConvertArtifactsDetailsToBuildInfoArtifacts(artifactsDetailsReader)
 		if err != nil {
@@ -199,6 +331,39 @@ func (uc *UploadCommand) upload() (err error) {
 	return
 }
 
+// Uploads the artifacts to all the servers concurrently, and reports the results of each server.
+// The results of the command are the total results of all the servers. The detailed summary is taken from the first server.
+func (uc *UploadCommand) uploadToServers() error {
+	if uc.watch {
+		return errorutils.CheckErrorf("watch mode is not supported when uploading to multiple servers")
+	}
+	var mutex sync.Mutex
+	_, err := commandsutils.DeployToServers(uc.serversDetails, func(serverDetails *config.ServerDetails) (int, int, error) {
+		serverCommand := *uc
+		serverCommand.serverDetails = serverDetails
+		serverCommand.result = new(commandsutils.Result)
+		serverCommand.isSecondaryServer = serverDetails != uc.serversDetails[0]
+		// The progress bar doesn't support concurrent uploads.
+		serverCommand.progress = nil
+		// The upload modifies the File-Spec groups.
+		serverCommand.spec = &spec.SpecFiles{Files: append([]spec.File(nil), uc.spec.Files...)}
+		err := serverCommand.upload()
+		mutex.Lock()
+		defer mutex.Unlock()
+		uc.result.SetSuccessCount(uc.result.SuccessCount() + serverCommand.result.SuccessCount())
+		uc.result.SetFailCount(uc.result.FailCount() + serverCommand.result.FailCount())
+		if reader := serverCommand.result.Reader(); reader != nil {
+			if serverCommand.isSecondaryServer {
+				err = errors.Join(err, reader.Close())
+			} else {
+				uc.result.SetReader(reader)
+			}
+		}
+		return serverCommand.result.SuccessCount(), serverCommand.result.FailCount(), err
+	})
+	return err
+}
+
 func getUploadParams(f *spec.File, configuration *utils.UploadConfiguration, buildProps string, addVcsProps bool) (uploadParams services.UploadParams, err error) {
 	uploadParams = services.NewUploadParams()
 	uploadParams.CommonParams, err = f.ToCommonParams()
@@ -0,0 +1,61 @@
+package generic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadToServers(t *testing.T) {
+	var mutex sync.Mutex
+	uploads := map[string][]string{}
+	createServer := func(name string, status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if r.Method == http.MethodPut {
+				uploads[name] = append(uploads[name], strings.Split(r.URL.Path, ";")[0])
+			}
+			w.WriteHeader(status)
+		}))
+	}
+	first, second, failing := createServer("first", http.StatusCreated), createServer("second", http.StatusCreated), createServer("failing", http.StatusForbidden)
+	defer first.Close()
+	defer second.Close()
+	defer failing.Close()
+
+	rootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "a.txt"), []byte("a"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "b.txt"), []byte("b"), 0644))
+	uploadCmd := NewUploadCommand().
+		SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1}).
+		SetServersDetails([]*config.ServerDetails{
+			{ServerId: "first", ArtifactoryUrl: first.URL + "/"},
+			{ServerId: "second", ArtifactoryUrl: second.URL + "/"},
+		})
+	uploadCmd.SetSpec(spec.NewBuilder().Pattern(filepath.Join(rootDir, "*.txt")).Target("repo/").Flat(true).BuildSpec())
+	require.NoError(t, uploadCmd.Run())
+	assert.ElementsMatch(t, []string{"/repo/a.txt", "/repo/b.txt"}, uploads["first"])
+	assert.ElementsMatch(t, []string{"/repo/a.txt", "/repo/b.txt"}, uploads["second"])
+	assert.Equal(t, 4, uploadCmd.Result().SuccessCount())
+
+	uploadCmd.SetServersDetails([]*config.ServerDetails{
+		{ServerId: "first", ArtifactoryUrl: first.URL + "/"},
+		{ServerId: "failing", ArtifactoryUrl: failing.URL + "/"},
+	})
+	uploadCmd.result = new(commandsutils.Result)
+	assert.EqualError(t, uploadCmd.Run(), "the deployment to 'failing' finished with errors")
+	assert.Equal(t, 2, uploadCmd.Result().SuccessCount())
+	assert.Equal(t, 2, uploadCmd.Result().FailCount())
+}
@@ -0,0 +1,351 @@
+package generic
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ArchiveFormatZip   = "zip"
+	ArchiveFormatTarGz = "tar.gz"
+	// The manifest of an archive is uploaded next to it, with this suffix.
+	ArchiveManifestSuffix = ".manifest.json"
+)
+
+// The modification time of the entries of deterministic archives. This is the earliest time which can be stored in a zip archive.
+var deterministicModTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ArchiveManifest lists the files packaged in an archive, which is created locally and uploaded.
+type ArchiveManifest struct {
+	Archive       string                 `json:"archive"`
+	Format        string                 `json:"format"`
+	Deterministic bool                   `json:"deterministic"`
+	Sha256        string                 `json:"sha256"`
+	Files         []ArchiveManifestEntry `json:"files"`
+}
+
+type ArchiveManifestEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256"`
+}
+
+type archiveEntry struct {
+	localPath string
+	// The path of the entry in the archive.
+	name string
+}
+
+// Returns true if the archive of the File-Spec group is created locally by the command, rather than while uploading.
+// The tar.gz archives and the deterministic archives are created locally.
+func isLocalArchive(file *spec.File) (bool, error) {
+	if file.Archive == "" {
+		return false, nil
+	}
+	deterministic, err := file.IsDeterministic(false)
+	return deterministic || file.Archive == ArchiveFormatTarGz, err
+}
+
+// Packages the files of the File-Spec groups with local archives into archives in a temp directory, and replaces each such group with
+// two groups uploading its archive and the manifest of the archive. The other groups are unchanged.
+// Returns a function which removes the archives.
+func (uc *UploadCommand) prepareLocalArchives() (cleanup func() error, err error) {
+	cleanup = func() error { return nil }
+	var tempDir string
+	var files []spec.File
+	for i, file := range uc.Spec().Files {
+		isLocal, err := isLocalArchive(&file)
+		if err != nil {
+			return cleanup, err
+		}
+		if !isLocal {
+			files = append(files, file)
+			continue
+		}
+		if tempDir == "" {
+			if tempDir, err = fileutils.CreateTempDir(); err != nil {
+				return cleanup, err
+			}
+			archivesDir := tempDir
+			cleanup = func() error {
+				return fileutils.RemoveTempDir(archivesDir)
+			}
+		}
+		archiveFiles, err := createLocalArchive(file, filepath.Join(tempDir, fmt.Sprint(i)))
+		if err != nil {
+			return cleanup, err
+		}
+		files = append(files, archiveFiles...)
+	}
+	uc.Spec().Files = files
+	return cleanup, nil
+}
+
+// Creates the archive of the File-Spec group and its manifest in the directory, and returns the File-Spec groups uploading them.
+func createLocalArchive(file spec.File, dir string) ([]spec.File, error) {
+	if strings.HasSuffix(file.Target, "/") || !strings.Contains(strings.TrimPrefix(file.Target, "/"), "/") {
+		return nil, errorutils.CheckErrorf("an archive's target cannot be a directory")
+	}
+	deterministic, err := file.IsDeterministic(false)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := collectArchiveEntries(&file)
+	if err != nil {
+		return nil, err
+	}
+	if err = fileutils.CreateDirIfNotExist(dir); err != nil {
+		return nil, err
+	}
+	archivePath := filepath.Join(dir, path.Base(file.Target))
+	manifest := &ArchiveManifest{Archive: path.Base(file.Target), Format: file.Archive, Deterministic: deterministic}
+	if file.Archive == ArchiveFormatTarGz {
+		manifest.Files, err = writeTarGzArchive(archivePath, entries, deterministic)
+	} else {
+		manifest.Files, err = writeZipArchive(archivePath, entries, deterministic)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Sha256, err = getFileSha256(archivePath); err != nil {
+		return nil, err
+	}
+	manifestPath := archivePath + ArchiveManifestSuffix
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if err = os.WriteFile(manifestPath, content, 0600); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	log.Info(fmt.Sprintf("Packaged %d files into the archive %s (sha256: %s).", len(manifest.Files), manifest.Archive, manifest.Sha256))
+
+	archiveFile := file
+	archiveFile.Pattern = archivePath
+	archiveFile.Exclusions = nil
+	archiveFile.Archive = ""
+	archiveFile.Deterministic = ""
+	archiveFile.TargetPathInArchive = ""
+	archiveFile.Recursive = "false"
+	archiveFile.Flat = "true"
+	archiveFile.Regexp = "false"
+	archiveFile.Ant = "false"
+	archiveFile.IncludeDirs = "false"
+	archiveFile.Symlinks = "false"
+	// The manifest is uploaded next to the archive, and is never exploded.
+	manifestFile := archiveFile
+	manifestFile.Pattern = manifestPath
+	manifestFile.Target = strings.TrimPrefix(file.Target, "/") + ArchiveManifestSuffix
+	manifestFile.Explode = "false"
+	manifestFile.BypassArchiveInspection = "false"
+	return []spec.File{archiveFile, manifestFile}, nil
+}
+
+// Returns the files matching the pattern of the File-Spec group, with their paths in the archive, sorted by their paths.
+// The paths in the archive are determined like in the archives created while uploading: by the target path in the archive if provided,
+// by the file names if the group is flat, or by the local paths otherwise.
+func collectArchiveEntries(file *spec.File) ([]archiveEntry, error) {
+	pattern := clientUtils.ReplaceTildeWithUserHome(file.Pattern)
+	patternType := file.GetPatternType()
+	recursive, err := file.IsRecursive(true)
+	if err != nil {
+		return nil, err
+	}
+	flat, err := file.IsFlat(true)
+	if err != nil {
+		return nil, err
+	}
+	rootPath, err := fspatterns.GetRootPath(pattern, file.Target, file.TargetPathInArchive, patternType, false)
+	if err != nil {
+		return nil, err
+	}
+	isDir, err := fileutils.IsDirExists(ioutils.ToLongPath(rootPath), false)
+	if err != nil {
+		return nil, err
+	}
+	var entries []archiveEntry
+	if !isDir {
+		entries = append(entries, archiveEntry{localPath: rootPath, name: getArchiveEntryName(rootPath, file.TargetPathInArchive, flat)})
+	} else {
+		if patternType != clientUtils.RegExp {
+			pattern = clientUtils.ConvertLocalPatternToRegexp(clientUtils.AddEscapingParentheses(pattern, file.Target, file.TargetPathInArchive), patternType)
+		}
+		patternRegex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		excludePathPattern := fspatterns.PrepareExcludePathPattern(file.Exclusions, patternType, recursive)
+		paths, err := ioutils.ListFiles(rootPath, recursive, false, false, false, excludePathPattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, localPath := range paths {
+			matches, isDir, err := ioutils.SearchPatterns(localPath, false, false, patternRegex)
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 || isDir {
+				continue
+			}
+			targetPathInArchive, _, err := clientUtils.ReplacePlaceHolders(matches, file.TargetPathInArchive, patternType == clientUtils.RegExp)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, archiveEntry{localPath: localPath, name: getArchiveEntryName(localPath, targetPathInArchive, flat)})
+		}
+	}
+	if len(entries) == 0 && !strings.EqualFold(os.Getenv(services.JfrogCliUploadEmptyArchiveEnv), "true") {
+		return nil, errorutils.CheckErrorf("no files matching the pattern '%s' were found to archive", file.Pattern)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+	for i := 1; i < len(entries); i++ {
+		if entries[i].name == entries[i-1].name {
+			return nil, errorutils.CheckErrorf("the files '%s' and '%s' have the same path in the archive: %s", entries[i-1].localPath, entries[i].localPath, entries[i].name)
+		}
+	}
+	return entries, nil
+}
+
+func getArchiveEntryName(localPath, targetPathInArchive string, flat bool) string {
+	name := targetPathInArchive
+	if name == "" {
+		if flat {
+			name = filepath.Base(localPath)
+		} else {
+			name = clientUtils.TrimPath(localPath)
+		}
+	}
+	return strings.TrimLeft(filepath.ToSlash(name), "/")
+}
+
+// Returns the modification time and the mode of an archive entry. Deterministic archives have a fixed time,
+// and only preserve whether the file is executable.
+func getArchiveEntryAttributes(info os.FileInfo, deterministic bool) (time.Time, os.FileMode) {
+	if !deterministic {
+		return info.ModTime(), info.Mode().Perm()
+	}
+	if info.Mode().Perm()&0111 != 0 {
+		return deterministicModTime, 0755
+	}
+	return deterministicModTime, 0644
+}
+
+func writeTarGzArchive(archivePath string, entries []archiveEntry, deterministic bool) (files []ArchiveManifestEntry, err error) {
+	archive, err := os.Create(archivePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(archive.Close()))
+	}()
+	// The gzip header has no name and no modification time, so the compressed archive only depends on its entries.
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, entry := range entries {
+		var manifestEntry *ArchiveManifestEntry
+		manifestEntry, err = addArchiveEntry(entry, func(info os.FileInfo) (io.Writer, error) {
+			modTime, mode := getArchiveEntryAttributes(info, deterministic)
+			header := &tar.Header{Typeflag: tar.TypeReg, Name: entry.name, Size: info.Size(), Mode: int64(mode), ModTime: modTime, Format: tar.FormatPAX}
+			return tarWriter, errorutils.CheckError(tarWriter.WriteHeader(header))
+		})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, *manifestEntry)
+	}
+	if err = tarWriter.Close(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return files, errorutils.CheckError(gzipWriter.Close())
+}
+
+func writeZipArchive(archivePath string, entries []archiveEntry, deterministic bool) (files []ArchiveManifestEntry, err error) {
+	archive, err := os.Create(archivePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(archive.Close()))
+	}()
+	zipWriter := zip.NewWriter(archive)
+	for _, entry := range entries {
+		var manifestEntry *ArchiveManifestEntry
+		manifestEntry, err = addArchiveEntry(entry, func(info os.FileInfo) (io.Writer, error) {
+			modTime, mode := getArchiveEntryAttributes(info, deterministic)
+			header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: modTime}
+			header.SetMode(mode)
+			writer, err := zipWriter.CreateHeader(header)
+			return writer, errorutils.CheckError(err)
+		})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, *manifestEntry)
+	}
+	return files, errorutils.CheckError(zipWriter.Close())
+}
+
+// Writes the file of the entry to the writer created for it, and returns the entry of the file in the manifest.
+func addArchiveEntry(entry archiveEntry, createWriter func(info os.FileInfo) (io.Writer, error)) (manifestEntry *ArchiveManifestEntry, err error) {
+	file, err := os.Open(ioutils.ToLongPath(entry.localPath))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	writer, err := createWriter(info)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(writer, hash), file)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &ArchiveManifestEntry{Path: entry.name, Size: size, Sha256: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+func getFileSha256(filePath string) (checksum string, err error) {
+	file, err := os.Open(ioutils.ToLongPath(filePath))
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,194 @@
+package generic
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createArchiveTestFiles(t *testing.T) string {
+	rootDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "bin"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "b.txt"), []byte("b"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "a.txt"), []byte("a"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "bin", "run.sh"), []byte("#!/bin/sh"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "bin", "ignored.log"), []byte("log"), 0644))
+	return rootDir
+}
+
+func createArchiveTestFile(rootDir, format string, deterministic bool) spec.File {
+	return spec.NewBuilder().Pattern(filepath.Join(rootDir, "(*).txt")).Target("repo/dir/out." + format).Archive(format).
+		Deterministic(deterministic).TargetPathInArchive("texts/{1}.txt").Recursive(true).Flat(true).BuildSpec().Files[0]
+}
+
+func readTarGzEntries(t *testing.T, content []byte) []*tar.Header {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	var headers []*tar.Header
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return headers
+		}
+		require.NoError(t, err)
+		headers = append(headers, header)
+	}
+}
+
+func TestCreateLocalArchive(t *testing.T) {
+	rootDir := createArchiveTestFiles(t)
+	// Executable files keep their executable mode, and the other files are stored with the same mode.
+	file := spec.NewBuilder().Pattern(filepath.Join(rootDir, "*")).Exclusions([]string{"*.log"}).Target("repo/out.tar.gz").
+		Archive(ArchiveFormatTarGz).Deterministic(true).Recursive(true).Flat(true).BuildSpec().Files[0]
+	files, err := createLocalArchive(file, t.TempDir())
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "repo/out.tar.gz", files[0].Target)
+	assert.Equal(t, "", files[0].Archive)
+	assert.Equal(t, "repo/out.tar.gz"+ArchiveManifestSuffix, files[1].Target)
+
+	content, err := os.ReadFile(files[0].Pattern)
+	require.NoError(t, err)
+	headers := readTarGzEntries(t, content)
+	require.Len(t, headers, 3)
+	expected := []struct {
+		name string
+		mode int64
+	}{{"a.txt", 0644}, {"b.txt", 0644}, {"run.sh", 0755}}
+	for i, header := range headers {
+		assert.Equal(t, expected[i].name, header.Name)
+		assert.Equal(t, expected[i].mode, header.Mode)
+		assert.True(t, deterministicModTime.Equal(header.ModTime))
+		assert.Zero(t, header.Uid)
+	}
+
+	var manifest ArchiveManifest
+	manifestContent, err := os.ReadFile(files[1].Pattern)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(manifestContent, &manifest))
+	assert.Equal(t, "out.tar.gz", manifest.Archive)
+	assert.True(t, manifest.Deterministic)
+	sha256, err := getFileSha256(files[0].Pattern)
+	require.NoError(t, err)
+	assert.Equal(t, sha256, manifest.Sha256)
+	require.Len(t, manifest.Files, 3)
+	runSha256, err := getFileSha256(filepath.Join(rootDir, "bin", "run.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, ArchiveManifestEntry{Path: "run.sh", Size: 9, Sha256: runSha256}, manifest.Files[2])
+}
+
+func TestCreateLocalArchiveDeterministic(t *testing.T) {
+	for _, format := range []string{ArchiveFormatTarGz, ArchiveFormatZip} {
+		t.Run(format, func(t *testing.T) {
+			rootDir := createArchiveTestFiles(t)
+			file := createArchiveTestFile(rootDir, format, true)
+			first, err := createLocalArchive(file, t.TempDir())
+			require.NoError(t, err)
+			// Changing the modification times of the files doesn't change the archive.
+			modTime := time.Now().Add(-time.Hour)
+			require.NoError(t, os.Chtimes(filepath.Join(rootDir, "a.txt"), modTime, modTime))
+			second, err := createLocalArchive(file, t.TempDir())
+			require.NoError(t, err)
+			firstSha256, err := getFileSha256(first[0].Pattern)
+			require.NoError(t, err)
+			secondSha256, err := getFileSha256(second[0].Pattern)
+			require.NoError(t, err)
+			assert.Equal(t, firstSha256, secondSha256)
+
+			// The non-deterministic archive keeps the modification times.
+			third, err := createLocalArchive(createArchiveTestFile(rootDir, format, false), t.TempDir())
+			require.NoError(t, err)
+			thirdSha256, err := getFileSha256(third[0].Pattern)
+			require.NoError(t, err)
+			assert.NotEqual(t, firstSha256, thirdSha256)
+		})
+	}
+}
+
+func TestCollectArchiveEntries(t *testing.T) {
+	rootDir := createArchiveTestFiles(t)
+	file := createArchiveTestFile(rootDir, ArchiveFormatTarGz, true)
+	entries, err := collectArchiveEntries(&file)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "texts/a.txt", entries[0].name)
+	assert.Equal(t, "texts/b.txt", entries[1].name)
+
+	// Files with the same path in the archive.
+	file.Pattern = filepath.Join(rootDir, "*.txt")
+	file.TargetPathInArchive = "same.txt"
+	_, err = collectArchiveEntries(&file)
+	assert.ErrorContains(t, err, "have the same path in the archive: same.txt")
+
+	file.Pattern = filepath.Join(rootDir, "*.none")
+	file.TargetPathInArchive = ""
+	_, err = collectArchiveEntries(&file)
+	assert.ErrorContains(t, err, "were found to archive")
+
+	file = createArchiveTestFile(rootDir, ArchiveFormatTarGz, true)
+	file.Target = "repo/dir/"
+	_, err = createLocalArchive(file, t.TempDir())
+	assert.EqualError(t, err, "an archive's target cannot be a directory")
+}
+
+func TestUploadLocalArchive(t *testing.T) {
+	var mutex sync.Mutex
+	uploads := map[string][]byte{}
+	exploded := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if r.Method == http.MethodPut {
+			uploadPath := strings.Split(r.URL.Path, ";")[0]
+			uploads[uploadPath], _ = io.ReadAll(r.Body)
+			exploded[uploadPath] = r.Header.Get("X-Explode-Archive") == "true"
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	rootDir := createArchiveTestFiles(t)
+	file := createArchiveTestFile(rootDir, ArchiveFormatTarGz, true)
+	file.Explode = "true"
+	uploadCmd := NewUploadCommand().SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1})
+	uploadCmd.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"})
+	uploadCmd.SetSpec(&spec.SpecFiles{Files: []spec.File{file}})
+	require.NoError(t, uploadCmd.Run())
+
+	require.Len(t, uploads, 2)
+	archive := uploads["/repo/dir/out.tar.gz"]
+	headers := readTarGzEntries(t, archive)
+	require.Len(t, headers, 2)
+	assert.Equal(t, "texts/a.txt", headers[0].Name)
+	assert.True(t, exploded["/repo/dir/out.tar.gz"])
+	assert.False(t, exploded["/repo/dir/out.tar.gz"+ArchiveManifestSuffix])
+	var manifest ArchiveManifest
+	require.NoError(t, json.Unmarshal(uploads["/repo/dir/out.tar.gz"+ArchiveManifestSuffix], &manifest))
+	assert.Len(t, manifest.Files, 2)
+	assert.Equal(t, 2, uploadCmd.Result().SuccessCount())
+}
+
+func TestValidateDeterministicSpec(t *testing.T) {
+	assert.NoError(t, spec.ValidateSpec(spec.NewBuilder().Pattern("*").Target("repo/out.tar.gz").Archive("tar.gz").Deterministic(true).BuildSpec().Files, true, false))
+	assert.EqualError(t, spec.ValidateSpec(spec.NewBuilder().Pattern("*").Target("repo/").Deterministic(true).BuildSpec().Files, true, false),
+		"spec cannot include 'deterministic' if 'archive' is not included")
+	assert.EqualError(t, spec.ValidateSpec(spec.NewBuilder().Pattern("*").Target("repo/out.tar").Archive("tar").BuildSpec().Files, true, false),
+		"the value of 'archive' (if provided) must be 'zip' or 'tar.gz'")
+}
@@ -0,0 +1,243 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/checksumcache"
+	coreioutils "github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The files which were deployed by their cached checksums, before uploading the rest of the files.
+type checksumCacheDeploys struct {
+	mutex           sync.Mutex
+	transferDetails []clientUtils.FileTransferDetails
+	artifacts       []rtServicesUtils.ArtifactDetails
+}
+
+func (ccd *checksumCacheDeploys) add(candidate uploadCandidate, rtUrl string, checksums entities.Checksum) {
+	ccd.mutex.Lock()
+	defer ccd.mutex.Unlock()
+	targetPath := candidate.repo + "/" + candidate.targetPath
+	ccd.transferDetails = append(ccd.transferDetails, clientUtils.FileTransferDetails{SourcePath: candidate.localPath, TargetPath: targetPath, RtUrl: rtUrl, Sha256: checksums.Sha256})
+	ccd.artifacts = append(ccd.artifacts, rtServicesUtils.ArtifactDetails{ArtifactoryPath: targetPath, Checksums: checksums})
+}
+
+func (ccd *checksumCacheDeploys) count() int {
+	return len(ccd.transferDetails)
+}
+
+// When the checksum cache is enabled, deploys the files of the spec by their cached checksums, before uploading the rest of the files.
+// Files which weren't modified since they were last hashed aren't read at all, and Artifactory doesn't receive their content if it already has it.
+// Returns the File-Spec groups which upload the files that weren't deployed.
+// Groups which can't be deployed file by file, such as archives, and groups with no deployed files, are returned as they are.
+func (uc *UploadCommand) deployByCachedChecksums(servicesManager artifactory.ArtifactoryServicesManager, specFiles *spec.SpecFiles, buildProps string, addVcsProps bool) (*spec.SpecFiles, *checksumCacheDeploys, error) {
+	deploys := new(checksumCacheDeploys)
+	if !checksumcache.IsChecksumCacheEnabled() || uc.DryRun() || uc.uploadConfiguration.Deb != "" {
+		return specFiles, deploys, nil
+	}
+	cache, err := checksumcache.LoadChecksumCache()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if saveErr := cache.Save(); saveErr != nil {
+			log.Warn("Failed saving the checksum cache:", saveErr.Error())
+		}
+	}()
+	remaining := new(spec.SpecFiles)
+	for i := range specFiles.Files {
+		file := specFiles.Get(i)
+		canDeploy, err := canDeployByChecksum(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !canDeploy {
+			remaining.Files = append(remaining.Files, *file)
+			continue
+		}
+		candidates, err := collectUploadCandidates(&spec.SpecFiles{Files: []spec.File{*file}}, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		deployed := uc.deployCandidatesByChecksum(servicesManager, cache, candidates, buildProps, addVcsProps, deploys)
+		if len(deployed) == 0 {
+			remaining.Files = append(remaining.Files, *file)
+			continue
+		}
+		for i, candidate := range candidates {
+			if !deployed[i] {
+				remaining.Files = append(remaining.Files, candidate.file)
+			}
+		}
+	}
+	if deploys.count() > 0 {
+		log.Info(fmt.Sprintf("Deployed %d files by their cached checksums.", deploys.count()))
+	}
+	return remaining, deploys, nil
+}
+
+// Archives, exploded archives, symlinks and directories are left to the upload service.
+func canDeployByChecksum(file *spec.File) (bool, error) {
+	if file.Archive != "" {
+		return false, nil
+	}
+	for _, isSet := range []func(bool) (bool, error){file.IsExplode, file.IsSymlinks, file.IsIncludeDirs} {
+		value, err := isSet(false)
+		if err != nil || value {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// Deploys the candidates in parallel. Returns the indexes of the deployed candidates, or nil if none of them was deployed.
+func (uc *UploadCommand) deployCandidatesByChecksum(servicesManager artifactory.ArtifactoryServicesManager, cache *checksumcache.ChecksumCache, candidates []uploadCandidate,
+	buildProps string, addVcsProps bool, deploys *checksumCacheDeploys) map[int]bool {
+	var mutex sync.Mutex
+	deployed := map[int]bool{}
+	runner := parallel.NewBounedRunner(uc.uploadConfiguration.Threads, false)
+	go func() {
+		defer runner.Done()
+		for i := range candidates {
+			i := i
+			_, _ = runner.AddTask(func(int) error {
+				ok, err := uc.deployCandidateByChecksum(servicesManager, cache, candidates[i], buildProps, addVcsProps, deploys)
+				if err != nil {
+					// The file is uploaded by the upload service instead.
+					log.Debug(fmt.Sprintf("Couldn't deploy %s by its cached checksums: %s", candidates[i].localPath, err.Error()))
+				}
+				if ok {
+					mutex.Lock()
+					deployed[i] = true
+					mutex.Unlock()
+				}
+				return nil
+			})
+		}
+	}()
+	runner.Run()
+	if len(deployed) == 0 {
+		return nil
+	}
+	return deployed
+}
+
+// Returns false if the file is smaller than the minimum checksum deploy size, or if Artifactory doesn't have a binary with the same checksums.
+func (uc *UploadCommand) deployCandidateByChecksum(servicesManager artifactory.ArtifactoryServicesManager, cache *checksumcache.ChecksumCache, candidate uploadCandidate,
+	buildProps string, addVcsProps bool, deploys *checksumCacheDeploys) (bool, error) {
+	fileInfo, err := os.Stat(coreioutils.ToLongPath(candidate.localPath))
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	if fileInfo.Size() < uc.uploadConfiguration.MinChecksumDeploySize {
+		return false, nil
+	}
+	details, err := cache.GetFileDetails(candidate.localPath)
+	if err != nil {
+		return false, err
+	}
+	if addVcsProps {
+		vcsProps, err := utils.CreateVcsProps(candidate.localPath)
+		if err != nil {
+			return false, err
+		}
+		buildProps = clientUtils.AddProps(buildProps, vcsProps)
+	}
+	rtUrl := servicesManager.GetConfig().GetServiceDetails().GetUrl()
+	targetUrl, err := getChecksumDeployUrl(rtUrl, candidate, buildProps)
+	if err != nil {
+		return false, err
+	}
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	if httpDetails.Headers == nil {
+		httpDetails.Headers = map[string]string{}
+	}
+	httpDetails.Headers["X-Checksum-Deploy"] = "true"
+	httpDetails.Headers["X-Checksum-Sha1"] = details.Checksum.Sha1
+	httpDetails.Headers["X-Checksum-Sha256"] = details.Checksum.Sha256
+	httpDetails.Headers["X-Checksum"] = details.Checksum.Md5
+	resp, body, err := servicesManager.Client().SendPut(targetUrl, nil, &httpDetails)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusCreated, http.StatusOK); err != nil {
+		return false, err
+	}
+	log.Info(fmt.Sprintf("Deployed %s to %s/%s by its cached checksums.", candidate.localPath, candidate.repo, candidate.targetPath))
+	deploys.add(candidate, rtUrl, details.Checksum)
+	return true, nil
+}
+
+// Returns the URL for deploying the candidate, with its properties as matrix parameters, in the same way as the upload service.
+func getChecksumDeployUrl(rtUrl string, candidate uploadCandidate, buildProps string) (string, error) {
+	targetUrl, err := clientUtils.BuildUrl(rtUrl, candidate.repo+"/"+candidate.targetPath, map[string]string{})
+	if err != nil {
+		return "", err
+	}
+	for _, propsAndEncoding := range []struct {
+		props       string
+		encodeSlash bool
+	}{{candidate.file.TargetProps, false}, {buildProps, true}} {
+		props, err := rtServicesUtils.ParseProperties(propsAndEncoding.props)
+		if err != nil {
+			return "", err
+		}
+		if encodedProps := props.ToEncodedString(propsAndEncoding.encodeSlash); encodedProps != "" {
+			targetUrl += ";" + encodedProps
+		}
+	}
+	return targetUrl, nil
+}
+
+// Adds the files deployed by their cached checksums to the results of the upload service,
+// so that they're included in the detailed summary, the checkpoint and the build-info.
+func mergeChecksumCacheDeploys(summary *rtServicesUtils.OperationSummary, deploys *checksumCacheDeploys) (err error) {
+	if deploys.count() == 0 {
+		return nil
+	}
+	summary.TotalSucceeded += deploys.count()
+	if summary.TransferDetailsReader, err = appendToReader(summary.TransferDetailsReader, deploys.transferDetails); err != nil {
+		return
+	}
+	summary.ArtifactsDetailsReader, err = appendToReader(summary.ArtifactsDetailsReader, deploys.artifacts)
+	return
+}
+
+// Returns a reader of the records of the reader, followed by the provided records. The provided reader is closed.
+func appendToReader[T any](reader *content.ContentReader, records []T) (merged *content.ContentReader, err error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		writer.Write(record)
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	recordsReader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	if reader == nil {
+		return recordsReader, nil
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close(), recordsReader.Close())
+	}()
+	return content.MergeReaders([]*content.ContentReader{reader, recordsReader}, content.DefaultKey)
+}
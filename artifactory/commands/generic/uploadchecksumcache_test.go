@@ -0,0 +1,86 @@
+package generic
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadDeployByCachedChecksums(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	t.Setenv(coreutils.ChecksumsCache, "true")
+	t.Setenv("JFROG_CLI_MIN_CHECKSUM_DEPLOY_SIZE_KB", "0")
+
+	var mutex sync.Mutex
+	// The SHA1 checksums of the binaries stored in Artifactory.
+	stored := map[string]bool{}
+	var uploaded, checksumDeployed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("X-Checksum-Deploy") == "true" {
+			if !stored[r.Header.Get("X-Checksum-Sha1")] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			checksumDeployed = append(checksumDeployed, r.URL.Path)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		stored[sha1Hex(string(body))] = true
+		uploaded = append(uploaded, strings.Split(r.URL.Path, ";")[0])
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	localDir := t.TempDir()
+	for _, name := range []string{"a", "b"} {
+		require.NoError(t, os.WriteFile(filepath.Join(localDir, name+".txt"), []byte(name), 0644))
+	}
+	uploadCmd := NewUploadCommand().SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1})
+	uploadCmd.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetSpec(spec.NewBuilder().Pattern(filepath.Join(localDir, "*.txt")).Target("generic-local/app/").Props("team=web").Flat(true).BuildSpec())
+	// The files are hashed and uploaded by the first upload.
+	require.NoError(t, uploadCmd.Run())
+	sort.Strings(uploaded)
+	assert.Equal(t, []string{"/generic-local/app/a.txt", "/generic-local/app/b.txt"}, uploaded)
+	assert.Empty(t, checksumDeployed)
+
+	// Change the content of a.txt without changing its size and modification time,
+	// to verify that the second upload deploys it by the cached checksums, without reading it.
+	aPath := filepath.Join(localDir, "a.txt")
+	aInfo, err := os.Stat(aPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(aPath, []byte("c"), 0644))
+	require.NoError(t, os.Chtimes(aPath, time.Now(), aInfo.ModTime()))
+
+	uploaded = nil
+	uploadCmd.result = new(commandsutils.Result)
+	require.NoError(t, uploadCmd.Run())
+	assert.Empty(t, uploaded)
+	sort.Strings(checksumDeployed)
+	assert.Equal(t, []string{"/generic-local/app/a.txt;team=web", "/generic-local/app/b.txt;team=web"}, checksumDeployed)
+	assert.Equal(t, 2, uploadCmd.Result().SuccessCount())
+}
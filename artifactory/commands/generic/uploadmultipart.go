@@ -0,0 +1,107 @@
+package generic
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Uploads the files. The summary is returned only if withSummary is true.
+// The upload service modifies the patterns of the params, so it receives a copy of them, to allow retrying the upload.
+func performUpload(servicesManager artifactory.ArtifactoryServicesManager, withSummary bool, uploadParamsArray []services.UploadParams) (summary *rtServicesUtils.OperationSummary, successCount, failCount int, err error) {
+	uploadParamsArray = copyUploadParams(uploadParamsArray)
+	if !withSummary {
+		successCount, failCount, err = servicesManager.UploadFiles(uploadParamsArray...)
+		return
+	}
+	summary, err = servicesManager.UploadFilesWithSummary(uploadParamsArray...)
+	if summary != nil {
+		successCount, failCount = summary.TotalSucceeded, summary.TotalFailed
+	}
+	return
+}
+
+// When the Artifactory instance supports direct cloud storage uploads, large files are uploaded in parts directly to the cloud storage,
+// bypassing the Artifactory node. Returns true if such uploads were attempted, in which case uploads which failed because of them should be retried through the standard upload endpoint.
+// Files which were already uploaded are then deployed by their checksum, without uploading their content again.
+func (uc *UploadCommand) shouldFallbackToStandardUpload(serverDetails *config.ServerDetails, servicesManager artifactory.ArtifactoryServicesManager, uploadParamsArray []services.UploadParams) bool {
+	if uc.DryRun() || !hasMultipartUploadCandidates(uploadParamsArray) {
+		return false
+	}
+	artAuth, err := serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		log.Debug("Couldn't check whether direct cloud storage uploads are supported:", err.Error())
+		return false
+	}
+	httpClientDetails := artAuth.CreateHttpClientDetails()
+	supported, err := rtServicesUtils.NewMultipartUpload(servicesManager.Client(), &httpClientDetails, artAuth.GetUrl()).IsSupported(artAuth)
+	if err != nil {
+		log.Debug("Couldn't check whether direct cloud storage uploads are supported:", err.Error())
+		return false
+	}
+	return supported
+}
+
+// Returns true if the upload error may have been caused by a direct cloud storage multipart upload.
+// The upload service returns the errors of multipart uploads, while failed standard uploads are only counted.
+// Client errors, such as authentication and permission errors, would fail the standard upload as well, so they aren't retried.
+func isMultipartUploadFailure(uploadErr error) bool {
+	if uploadErr == nil {
+		return false
+	}
+	_, status, found := strings.Cut(uploadErr.Error(), "server response: ")
+	if !found {
+		return true
+	}
+	statusCode, err := strconv.Atoi(strings.SplitN(status, " ", 2)[0])
+	return err != nil || statusCode >= 500
+}
+
+// Returns true if any of the files to upload is large enough to be uploaded in parts.
+func hasMultipartUploadCandidates(uploadParamsArray []services.UploadParams) bool {
+	found := false
+	for _, uploadParams := range uploadParamsArray {
+		// Archives and exploded archives are never uploaded in parts.
+		if found || uploadParams.SplitCount == 0 || uploadParams.Archive != "" || uploadParams.IsExplodeArchive() {
+			continue
+		}
+		uploadParams = services.DeepCopyUploadParams(&uploadParams)
+		uploadParams.AddVcsProps = false
+		err := services.CollectFilesForUpload(uploadParams, nil, nil, func(data services.UploadData) {
+			if found || data.IsDir {
+				return
+			}
+			fileInfo, err := os.Stat(data.Artifact.LocalPath)
+			if err != nil || fileInfo.IsDir() {
+				return
+			}
+			found = fileInfo.Size() >= uploadParams.MinSplitSize && fileInfo.Size() <= rtServicesUtils.MaxMultipartUploadFileSize
+		})
+		if err != nil {
+			log.Debug("Couldn't collect the files to upload:", err.Error())
+		}
+	}
+	return found
+}
+
+func disableMultipartUpload(uploadParamsArray []services.UploadParams) []services.UploadParams {
+	standardUploadParams := copyUploadParams(uploadParamsArray)
+	for i := range standardUploadParams {
+		standardUploadParams[i].SplitCount = 0
+	}
+	return standardUploadParams
+}
+
+func copyUploadParams(uploadParamsArray []services.UploadParams) []services.UploadParams {
+	paramsCopy := make([]services.UploadParams, 0, len(uploadParamsArray))
+	for i := range uploadParamsArray {
+		paramsCopy = append(paramsCopy, services.DeepCopyUploadParams(&uploadParamsArray[i]))
+	}
+	return paramsCopy
+}
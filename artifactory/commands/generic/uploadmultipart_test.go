@@ -0,0 +1,128 @@
+package generic
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasMultipartUploadCandidates(t *testing.T) {
+	rootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "small.bin"), make([]byte, 10), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "large.bin"), make([]byte, 100), 0644))
+	createParams := func(pattern string, minSplitSize int64, splitCount int) services.UploadParams {
+		uploadParams := services.NewUploadParams()
+		uploadParams.CommonParams = &rtServicesUtils.CommonParams{Pattern: filepath.Join(rootDir, pattern), Target: "repo/"}
+		uploadParams.MinSplitSize = minSplitSize
+		uploadParams.SplitCount = splitCount
+		return uploadParams
+	}
+	assert.True(t, hasMultipartUploadCandidates([]services.UploadParams{createParams("*.bin", 50, 5)}))
+	assert.False(t, hasMultipartUploadCandidates([]services.UploadParams{createParams("small.bin", 50, 5)}))
+	assert.False(t, hasMultipartUploadCandidates([]services.UploadParams{createParams("*.bin", 50, 0)}))
+	assert.False(t, hasMultipartUploadCandidates([]services.UploadParams{createParams("*.bin", 200, 5)}))
+	archiveParams := createParams("*.bin", 50, 5)
+	archiveParams.Archive = "zip"
+	assert.False(t, hasMultipartUploadCandidates([]services.UploadParams{archiveParams}))
+}
+
+func TestUploadFallbackToStandardUpload(t *testing.T) {
+	var mutex sync.Mutex
+	var multipartRequests, standardUploads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case r.URL.Path == "/api/system/version":
+			_, err := w.Write([]byte(`{"version":"7.90.0"}`))
+			assert.NoError(t, err)
+		case r.URL.Path == "/api/v1/uploads/config":
+			_, err := w.Write([]byte(`{"supported":true}`))
+			assert.NoError(t, err)
+		case strings.HasPrefix(r.URL.Path, "/api/v1/uploads/"):
+			// The cloud storage is unreachable.
+			multipartRequests = append(multipartRequests, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.Method == http.MethodPut && r.Header.Get("X-Checksum-Deploy") == "true":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut:
+			standardUploads = append(standardUploads, strings.Split(r.URL.Path, ";")[0])
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	rootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "large.bin"), make([]byte, 100), 0644))
+	uploadCmd := NewUploadCommand()
+	uploadCmd.SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1, SplitCount: 2}).
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetSpec(spec.NewBuilder().Pattern(filepath.Join(rootDir, "*.bin")).Target("repo/").Flat(true).BuildSpec())
+	require.NoError(t, uploadCmd.Run())
+
+	assert.Equal(t, []string{"/api/v1/uploads/create"}, multipartRequests)
+	assert.Equal(t, []string{"/repo/large.bin"}, standardUploads)
+	assert.Equal(t, 1, uploadCmd.Result().SuccessCount())
+	assert.Equal(t, 0, uploadCmd.Result().FailCount())
+}
+
+func TestUploadNoFallbackOnClientError(t *testing.T) {
+	var mutex sync.Mutex
+	var standardUploads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case r.URL.Path == "/api/system/version":
+			_, err := w.Write([]byte(`{"version":"7.90.0"}`))
+			assert.NoError(t, err)
+		case r.URL.Path == "/api/v1/uploads/config":
+			_, err := w.Write([]byte(`{"supported":true}`))
+			assert.NoError(t, err)
+		case strings.HasPrefix(r.URL.Path, "/api/v1/uploads/"):
+			// The user has no deploy permission, so a standard upload would fail as well.
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == http.MethodPut && r.Header.Get("X-Checksum-Deploy") == "true":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut:
+			standardUploads = append(standardUploads, r.URL.Path)
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	rootDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "large.bin"), make([]byte, 100), 0644))
+	uploadCmd := NewUploadCommand()
+	uploadCmd.SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1, SplitCount: 2}).
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetSpec(spec.NewBuilder().Pattern(filepath.Join(rootDir, "*.bin")).Target("repo/").Flat(true).BuildSpec())
+	assert.Error(t, uploadCmd.Run())
+	assert.Empty(t, standardUploads)
+	assert.Equal(t, 1, uploadCmd.Result().FailCount())
+}
+
+func TestIsMultipartUploadFailure(t *testing.T) {
+	assert.False(t, isMultipartUploadFailure(nil))
+	assert.False(t, isMultipartUploadFailure(errors.New("server response: 401 Unauthorized")))
+	assert.False(t, isMultipartUploadFailure(errors.New("server response: 403 Forbidden\n{\"errors\":[]}")))
+	assert.True(t, isMultipartUploadFailure(errors.New("server response: 500 Internal Server Error")))
+	assert.True(t, isMultipartUploadFailure(errors.New("multipart upload failed after 3 attempts")))
+}
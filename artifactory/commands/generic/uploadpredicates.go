@@ -0,0 +1,307 @@
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	coreioutils "github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type UploadPredicateType string
+
+const (
+	// The file is uploaded only if no file with the same checksum exists anywhere in the target repository.
+	ChecksumAbsentPredicate UploadPredicateType = "checksum-absent"
+	// The file is uploaded only if its target doesn't exist.
+	TargetAbsentPredicate UploadPredicateType = "target-absent"
+	// The file is uploaded only if its target doesn't exist, or if the existing target has all the properties of the predicate.
+	TargetPropsPredicate UploadPredicateType = "target-props"
+
+	syncOnlyIfSearchBatchSize = 100
+)
+
+type UploadPredicate struct {
+	Type UploadPredicateType
+	// The properties required by the target-props predicate.
+	Props []servicesutils.Property
+}
+
+// ParseUploadPredicates parses the value of the sync-only-if option.
+// The predicates are separated by semicolons, and the properties of the target-props predicate follow a colon and are separated by commas.
+// For example: "checksum-absent;target-props:stage=dev,team=web".
+func ParseUploadPredicates(value string) (predicates []UploadPredicate, err error) {
+	for _, predicateValue := range strings.Split(value, ";") {
+		predicateValue = strings.TrimSpace(predicateValue)
+		if predicateValue == "" {
+			continue
+		}
+		predicateType, propsValue, hasProps := strings.Cut(predicateValue, ":")
+		predicate := UploadPredicate{Type: UploadPredicateType(predicateType)}
+		switch predicate.Type {
+		case ChecksumAbsentPredicate, TargetAbsentPredicate:
+			if hasProps {
+				return nil, errorutils.CheckErrorf("the '%s' predicate doesn't accept a value", predicateType)
+			}
+		case TargetPropsPredicate:
+			if predicate.Props, err = parsePredicateProps(propsValue); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errorutils.CheckErrorf("unknown sync-only-if predicate '%s'. Possible predicates are: %s, %s and %s",
+				predicateType, ChecksumAbsentPredicate, TargetAbsentPredicate, TargetPropsPredicate)
+		}
+		predicates = append(predicates, predicate)
+	}
+	return predicates, nil
+}
+
+func parsePredicateProps(value string) (props []servicesutils.Property, err error) {
+	for _, prop := range strings.Split(value, ",") {
+		key, propValue, found := strings.Cut(strings.TrimSpace(prop), "=")
+		if !found || key == "" {
+			return nil, errorutils.CheckErrorf("invalid property '%s' in the '%s' predicate. The expected format is key=value", prop, TargetPropsPredicate)
+		}
+		props = append(props, servicesutils.Property{Key: key, Value: propValue})
+	}
+	return props, nil
+}
+
+// uploadCandidate is a local file to upload, with the File-Spec group which uploads only this file.
+type uploadCandidate struct {
+	file      spec.File
+	localPath string
+	repo      string
+	// The path of the target in the repository.
+	targetPath string
+	sha1       string
+}
+
+// Returns the File-Spec groups which upload the files of the spec that satisfy all the sync-only-if predicates.
+// The existing artifacts needed for evaluating the predicates are searched with AQL, before uploading any file.
+func (uc *UploadCommand) applySyncOnlyIf(servicesManager artifactory.ArtifactoryServicesManager, specFiles *spec.SpecFiles) (*spec.SpecFiles, error) {
+	candidates, err := collectUploadCandidates(specFiles, hasPredicate(uc.syncOnlyIf, ChecksumAbsentPredicate))
+	if err != nil {
+		return nil, err
+	}
+	existingChecksums, existingTargets, err := searchPredicatesArtifacts(servicesManager, uc.syncOnlyIf, candidates)
+	if err != nil {
+		return nil, err
+	}
+	filteredSpec := new(spec.SpecFiles)
+	for _, candidate := range candidates {
+		if reason := getUnsatisfiedPredicate(uc.syncOnlyIf, candidate, existingChecksums, existingTargets); reason != "" {
+			log.Info(fmt.Sprintf("Skipping the upload of %s to %s/%s: %s.", candidate.localPath, candidate.repo, candidate.targetPath, reason))
+			continue
+		}
+		filteredSpec.Files = append(filteredSpec.Files, candidate.file)
+	}
+	if skipped := len(candidates) - len(filteredSpec.Files); skipped > 0 {
+		log.Info(fmt.Sprintf("Skipped %d out of %d files, which don't satisfy the sync-only-if predicates.", skipped, len(candidates)))
+	}
+	return filteredSpec, nil
+}
+
+func hasPredicate(predicates []UploadPredicate, predicateType UploadPredicateType) bool {
+	for _, predicate := range predicates {
+		if predicate.Type == predicateType {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the reason for skipping the candidate, or an empty string if it satisfies all the predicates.
+func getUnsatisfiedPredicate(predicates []UploadPredicate, candidate uploadCandidate, existingChecksums map[string]bool, existingTargets map[string]*servicesutils.ResultItem) string {
+	target, targetExists := existingTargets[path.Join(candidate.repo, candidate.targetPath)]
+	for _, predicate := range predicates {
+		switch predicate.Type {
+		case ChecksumAbsentPredicate:
+			if existingChecksums[candidate.repo+"/"+candidate.sha1] {
+				return fmt.Sprintf("a file with the same checksum exists in the '%s' repository", candidate.repo)
+			}
+		case TargetAbsentPredicate:
+			if targetExists {
+				return "the target already exists"
+			}
+		case TargetPropsPredicate:
+			if !targetExists {
+				continue
+			}
+			for _, prop := range predicate.Props {
+				if !hasProperty(target, prop) {
+					return fmt.Sprintf("the existing target doesn't have the property %s=%s", prop.Key, prop.Value)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func hasProperty(item *servicesutils.ResultItem, prop servicesutils.Property) bool {
+	for _, itemProp := range item.Properties {
+		if itemProp.Key == prop.Key && itemProp.Value == prop.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// Lists the local files of the spec, and creates a File-Spec group for uploading each of them to the same target path as the upload of the whole spec.
+func collectUploadCandidates(specFiles *spec.SpecFiles, withChecksums bool) (candidates []uploadCandidate, err error) {
+	for i := range specFiles.Files {
+		file := specFiles.Get(i)
+		if file.Archive != "" {
+			return nil, errorutils.CheckErrorf("the sync-only-if predicates cannot be used with the 'archive' option, unless the archive is deterministic")
+		}
+		group, err := newWatchedFileGroup(file)
+		if err != nil {
+			return nil, err
+		}
+		paths := []string{group.rootPath}
+		if isDir, err := fileutils.IsDirExists(coreioutils.ToLongPath(group.rootPath), false); err != nil {
+			return nil, err
+		} else if isDir {
+			if paths, err = coreioutils.ListFiles(group.rootPath, group.recursive, false, false, false, group.excludePathPattern); err != nil {
+				return nil, err
+			}
+		}
+		for _, localPath := range paths {
+			candidate, err := createUploadCandidate(group, filepath.Clean(localPath), withChecksums)
+			if err != nil {
+				return nil, err
+			}
+			if candidate != nil {
+				candidates = append(candidates, *candidate)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// Returns nil if the file doesn't match the File-Spec group.
+func createUploadCandidate(group *watchedFileGroup, localPath string, withChecksum bool) (*uploadCandidate, error) {
+	if isDir, err := fileutils.IsDirExists(coreioutils.ToLongPath(localPath), false); err != nil || isDir {
+		return nil, err
+	}
+	file, err := group.getFileGroup(localPath)
+	if err != nil || file == nil {
+		return nil, err
+	}
+	// Resolve the target path the same way as the upload service, and upload the file to it.
+	flat, err := file.IsFlat(true)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(file.Target, "/") {
+		if flat {
+			file.Target += filepath.Base(localPath)
+		} else {
+			file.Target += clientUtils.TrimPath(localPath)
+		}
+	}
+	repo, targetPath, _ := strings.Cut(file.Target, "/")
+	candidate := &uploadCandidate{file: *file, localPath: localPath, repo: repo, targetPath: strings.TrimPrefix(path.Clean("/"+targetPath), "/")}
+	if withChecksum {
+		details, err := fileutils.GetFileDetails(coreioutils.ToLongPath(localPath), true)
+		if err != nil {
+			return nil, err
+		}
+		candidate.sha1 = details.Checksum.Sha1
+	}
+	return candidate, nil
+}
+
+// Searches the artifacts the predicates depend on.
+// Returns the checksums existing in the target repositories, by the repository and checksum, and the existing targets, by their full paths.
+func searchPredicatesArtifacts(servicesManager artifactory.ArtifactoryServicesManager, predicates []UploadPredicate, candidates []uploadCandidate) (existingChecksums map[string]bool, existingTargets map[string]*servicesutils.ResultItem, err error) {
+	existingChecksums, existingTargets = map[string]bool{}, map[string]*servicesutils.ResultItem{}
+	searchChecksums := hasPredicate(predicates, ChecksumAbsentPredicate)
+	searchTargets := hasPredicate(predicates, TargetAbsentPredicate) || hasPredicate(predicates, TargetPropsPredicate)
+	candidatesByRepo := map[string][]uploadCandidate{}
+	var repos []string
+	for _, candidate := range candidates {
+		if _, exists := candidatesByRepo[candidate.repo]; !exists {
+			repos = append(repos, candidate.repo)
+		}
+		candidatesByRepo[candidate.repo] = append(candidatesByRepo[candidate.repo], candidate)
+	}
+	for _, repo := range repos {
+		repoCandidates := candidatesByRepo[repo]
+		for start := 0; start < len(repoCandidates); start += syncOnlyIfSearchBatchSize {
+			end := start + syncOnlyIfSearchBatchSize
+			if end > len(repoCandidates) {
+				end = len(repoCandidates)
+			}
+			batch := repoCandidates[start:end]
+			if searchChecksums {
+				items, err := searchRepoItems(servicesManager, createChecksumsQuery(repo, batch))
+				if err != nil {
+					return nil, nil, err
+				}
+				for _, item := range items {
+					existingChecksums[repo+"/"+item.Actual_Sha1] = true
+				}
+			}
+			if searchTargets {
+				items, err := searchRepoItems(servicesManager, createTargetsQuery(repo, batch))
+				if err != nil {
+					return nil, nil, err
+				}
+				for i := range items {
+					existingTargets[path.Join(items[i].Repo, items[i].Path, items[i].Name)] = &items[i]
+				}
+			}
+		}
+	}
+	return existingChecksums, existingTargets, nil
+}
+
+func createChecksumsQuery(repo string, candidates []uploadCandidate) string {
+	criteria := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		criteria = append(criteria, fmt.Sprintf(`{"actual_sha1":{"$eq":"%s"}}`, escapeAql(candidate.sha1)))
+	}
+	return fmt.Sprintf(`items.find({"repo":"%s","type":"file","$or":[%s]}).include("repo","path","name","actual_sha1")`, escapeAql(repo), strings.Join(criteria, ","))
+}
+
+func createTargetsQuery(repo string, candidates []uploadCandidate) string {
+	criteria := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		criteria = append(criteria, fmt.Sprintf(`{"$and":[{"path":{"$eq":"%s"}},{"name":{"$eq":"%s"}}]}`,
+			escapeAql(path.Dir(candidate.targetPath)), escapeAql(path.Base(candidate.targetPath))))
+	}
+	return fmt.Sprintf(`items.find({"repo":"%s","type":"file","$or":[%s]}).include("repo","path","name","property")`, escapeAql(repo), strings.Join(criteria, ","))
+}
+
+func searchRepoItems(servicesManager artifactory.ArtifactoryServicesManager, query string) (items []servicesutils.ResultItem, err error) {
+	stream, err := servicesManager.Aql(query)
+	if err != nil {
+		return nil, err
+	}
+	defer ioutils.Close(stream, &err)
+	content, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	result := new(servicesutils.AqlSearchResult)
+	if err = json.Unmarshal(content, result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return result.Results, nil
+}
+
+func escapeAql(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
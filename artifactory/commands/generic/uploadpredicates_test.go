@@ -0,0 +1,120 @@
+package generic
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUploadPredicates(t *testing.T) {
+	predicates, err := ParseUploadPredicates("checksum-absent; target-props:stage=dev,team=web;target-absent")
+	require.NoError(t, err)
+	assert.Equal(t, []UploadPredicate{
+		{Type: ChecksumAbsentPredicate},
+		{Type: TargetPropsPredicate, Props: []servicesutils.Property{{Key: "stage", Value: "dev"}, {Key: "team", Value: "web"}}},
+		{Type: TargetAbsentPredicate},
+	}, predicates)
+
+	_, err = ParseUploadPredicates("target-exists")
+	assert.ErrorContains(t, err, "unknown sync-only-if predicate 'target-exists'")
+	_, err = ParseUploadPredicates("target-props:stage")
+	assert.ErrorContains(t, err, "invalid property 'stage'")
+	_, err = ParseUploadPredicates("checksum-absent:true")
+	assert.ErrorContains(t, err, "the 'checksum-absent' predicate doesn't accept a value")
+}
+
+func sha1Hex(content string) string {
+	checksum := sha1.Sum([]byte(content))
+	return hex.EncodeToString(checksum[:])
+}
+
+func TestUploadSyncOnlyIf(t *testing.T) {
+	var mutex sync.Mutex
+	var uploaded, queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case r.URL.Path == "/api/search/aql":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			queries = append(queries, string(body))
+			if strings.Contains(string(body), `"actual_sha1":{"$eq"`) {
+				// The content of a.txt exists in another path of the repository.
+				_, err = fmt.Fprintf(w, `{"results":[{"repo":"generic-local","path":"old","name":"a.txt","actual_sha1":"%s"}]}`, sha1Hex("a"))
+			} else {
+				_, err = w.Write([]byte(`{"results":[
+					{"repo":"generic-local","path":"app","name":"b.txt","properties":[{"key":"stage","value":"dev"}]},
+					{"repo":"generic-local","path":"app","name":"c.txt","properties":[{"key":"stage","value":"prod"}]}
+				]}`))
+			}
+			assert.NoError(t, err)
+		case r.Method == http.MethodPut:
+			uploaded = append(uploaded, strings.Split(r.URL.Path, ";")[0])
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	localDir := t.TempDir()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, os.WriteFile(filepath.Join(localDir, name+".txt"), []byte(name), 0644))
+	}
+	predicates, err := ParseUploadPredicates("checksum-absent;target-props:stage=dev")
+	require.NoError(t, err)
+	uploadCmd := NewUploadCommand().SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1}).SetSyncOnlyIf(predicates)
+	uploadCmd.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetSpec(spec.NewBuilder().Pattern(filepath.Join(localDir, "*.txt")).Target("generic-local/app/").Flat(true).BuildSpec())
+	require.NoError(t, uploadCmd.Run())
+
+	sort.Strings(uploaded)
+	assert.Equal(t, []string{"/generic-local/app/b.txt", "/generic-local/app/d.txt"}, uploaded)
+	assert.Equal(t, 2, uploadCmd.Result().SuccessCount())
+	require.Len(t, queries, 2)
+	assert.Contains(t, queries[1], `{"$and":[{"path":{"$eq":"app"}},{"name":{"$eq":"a.txt"}}]}`)
+	// The spec of the command isn't changed by the predicates.
+	assert.Len(t, uploadCmd.Spec().Files, 1)
+}
+
+func TestUploadSyncOnlyIfTargetAbsent(t *testing.T) {
+	rootDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "lib"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "a.txt"), []byte("a"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "lib", "b.txt"), []byte("b"), 0644))
+	file := spec.NewBuilder().Pattern(filepath.Join(rootDir, "(*).txt")).Target("generic-local/{1}.bin").Recursive(true).BuildSpec().Files[0]
+	specFiles := &spec.SpecFiles{Files: []spec.File{file}}
+	candidates, err := collectUploadCandidates(specFiles, false)
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, "a.bin", candidates[0].targetPath)
+	assert.Equal(t, "lib/b.bin", candidates[1].targetPath)
+
+	predicates := []UploadPredicate{{Type: TargetAbsentPredicate}}
+	existingTargets := map[string]*servicesutils.ResultItem{"generic-local/lib/b.bin": {Repo: "generic-local", Path: "lib", Name: "b.bin"}}
+	assert.Empty(t, getUnsatisfiedPredicate(predicates, candidates[0], nil, existingTargets))
+	assert.Equal(t, "the target already exists", getUnsatisfiedPredicate(predicates, candidates[1], nil, existingTargets))
+}
+
+func TestUploadSyncOnlyIfWithSyncDeletes(t *testing.T) {
+	uploadCmd := NewUploadCommand().SetSyncOnlyIf([]UploadPredicate{{Type: TargetAbsentPredicate}})
+	uploadCmd.SetSyncDeletesPath("generic-local/app")
+	assert.EqualError(t, uploadCmd.Run(), "the sync-only-if predicates cannot be used with sync-deletes, since the skipped files would be deleted")
+}
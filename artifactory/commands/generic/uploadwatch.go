@@ -0,0 +1,359 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const DefaultWatchDebounce = 2 * time.Second
+
+// watchedFileGroup is a File-Spec group of the upload spec, prepared for matching the local paths reported by the watcher.
+// The matching follows the same rules as the upload service.
+type watchedFileGroup struct {
+	file               *spec.File
+	rootPath           string
+	patternRegex       *regexp.Regexp
+	excludePathPattern string
+	recursive          bool
+	isRegexp           bool
+	flat               bool
+}
+
+func newWatchedFileGroup(file *spec.File) (*watchedFileGroup, error) {
+	group := &watchedFileGroup{file: file}
+	var err error
+	if group.recursive, err = file.IsRecursive(true); err != nil {
+		return nil, err
+	}
+	if group.isRegexp, err = file.IsRegexp(false); err != nil {
+		return nil, err
+	}
+	if group.flat, err = file.IsFlat(true); err != nil {
+		return nil, err
+	}
+	isAnt, err := file.IsAnt(false)
+	if err != nil {
+		return nil, err
+	}
+	isSymlinks, err := file.IsSymlinks(false)
+	if err != nil {
+		return nil, err
+	}
+	patternType := file.GetPatternType()
+	pattern := clientUtils.ReplaceTildeWithUserHome(file.Pattern)
+	if group.rootPath, err = fspatterns.GetRootPath(pattern, file.Target, file.TargetPathInArchive, patternType, isSymlinks); err != nil {
+		return nil, err
+	}
+	// The paths reported by the watcher are clean.
+	group.rootPath = filepath.Clean(group.rootPath)
+	if isAnt {
+		pattern = clientUtils.AddEscapingParentheses(pattern, file.Target, file.TargetPathInArchive)
+		pattern = clientUtils.ConvertLocalPatternToRegexp(pattern, patternType)
+	} else {
+		pattern = clientUtils.ConvertLocalPatternToRegexp(pattern, patternType)
+		if !group.isRegexp {
+			pattern = clientUtils.AddEscapingParentheses(pattern, file.Target, file.TargetPathInArchive)
+		}
+	}
+	if group.patternRegex, err = regexp.Compile(pattern); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	group.excludePathPattern = fspatterns.PrepareExcludePathPattern(file.Exclusions, patternType, group.recursive)
+	return group, nil
+}
+
+// Returns the File-Spec group which uploads the file in the given path, or nil if the file doesn't match the group.
+// The returned group uploads only this file, to the same target path as if the whole group was uploaded.
+func (wfg *watchedFileGroup) getFileGroup(path string) (*spec.File, error) {
+	if path != wfg.rootPath && !wfg.recursive && filepath.Dir(path) != wfg.rootPath {
+		return nil, nil
+	}
+	if wfg.excludePathPattern != "" {
+		excluded, err := regexp.MatchString(wfg.excludePathPattern, path)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if excluded {
+			return nil, nil
+		}
+	}
+	groups := wfg.patternRegex.FindStringSubmatch(path)
+	if path != wfg.rootPath && len(groups) == 0 {
+		return nil, nil
+	}
+	target := strings.TrimPrefix(wfg.file.Target, "/")
+	if !strings.Contains(target, "/") {
+		target += "/"
+	}
+	target, placeholdersUsed, err := clientUtils.ReplacePlaceHolders(groups, target, wfg.isRegexp)
+	if err != nil {
+		return nil, err
+	}
+	file := *wfg.file
+	file.Pattern = path
+	file.Target = target
+	file.Exclusions = nil
+	file.Regexp, file.Ant, file.Recursive = "false", "false", "false"
+	// When placeholders are used, the local path of the file isn't taken into account in the target path.
+	file.Flat = fmt.Sprint(wfg.flat || placeholdersUsed)
+	return &file, nil
+}
+
+// uploadWatcher uploads new and changed files matching the upload spec, as they appear in the watched directories.
+// The changes are collected until no change is reported for the debounce duration, and are then uploaded together.
+type uploadWatcher struct {
+	watcher  *fsnotify.Watcher
+	groups   []*watchedFileGroup
+	debounce time.Duration
+	// The paths of the files changed since the last upload.
+	pending map[string]bool
+	upload  func(files *spec.SpecFiles) error
+}
+
+func newUploadWatcher(specFiles *spec.SpecFiles, debounce time.Duration, upload func(files *spec.SpecFiles) error) (uw *uploadWatcher, err error) {
+	uw = &uploadWatcher{debounce: debounce, pending: map[string]bool{}, upload: upload}
+	for i := range specFiles.Files {
+		group, err := newWatchedFileGroup(specFiles.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		uw.groups = append(uw.groups, group)
+	}
+	if uw.watcher, err = fsnotify.NewWatcher(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, group := range uw.groups {
+		if err = uw.addGroupWatches(group); err != nil {
+			return nil, errors.Join(err, uw.close())
+		}
+	}
+	return uw, nil
+}
+
+// Watches the root path of the group, and its subdirectories if the group is recursive.
+// A single file is watched through its parent directory, to keep watching it after it is replaced.
+func (uw *uploadWatcher) addGroupWatches(group *watchedFileGroup) error {
+	isDir, err := fileutils.IsDirExists(group.rootPath, false)
+	if err != nil {
+		return err
+	}
+	if !isDir {
+		return uw.addWatch(filepath.Dir(group.rootPath))
+	}
+	if !group.recursive {
+		return uw.addWatch(group.rootPath)
+	}
+	_, err = uw.addWatchRecursively(group.rootPath)
+	return err
+}
+
+func (uw *uploadWatcher) addWatch(dir string) error {
+	log.Debug("Watching", dir)
+	return errorutils.CheckError(uw.watcher.Add(dir))
+}
+
+// Watches the directory and its subdirectories, and returns the files which already exist in them.
+func (uw *uploadWatcher) addWatchRecursively(dir string) (files []string, err error) {
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return uw.addWatch(filepath.Clean(path))
+		}
+		files = append(files, path)
+		return nil
+	})
+	return files, errorutils.CheckError(err)
+}
+
+// Handles an event of the watcher, and returns true if a file was added to the pending upload.
+func (uw *uploadWatcher) handleEvent(event fsnotify.Event) (bool, error) {
+	if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+		return false, nil
+	}
+	path := filepath.Clean(event.Name)
+	info, err := os.Stat(path)
+	if err != nil {
+		// The file was removed or renamed before it was handled.
+		return false, nil
+	}
+	if !info.IsDir() {
+		return uw.addPending(path), nil
+	}
+	if !event.Has(fsnotify.Create) || !uw.isWatchedRecursively(path) {
+		return false, nil
+	}
+	// Files may be created in the new directory before it is watched.
+	files, err := uw.addWatchRecursively(path)
+	if err != nil {
+		return false, err
+	}
+	added := false
+	for _, file := range files {
+		added = uw.addPending(file) || added
+	}
+	return added, nil
+}
+
+func (uw *uploadWatcher) isWatchedRecursively(dir string) bool {
+	for _, group := range uw.groups {
+		if !group.recursive {
+			continue
+		}
+		if relativePath, err := filepath.Rel(group.rootPath, dir); err == nil && relativePath != "." && !strings.HasPrefix(relativePath, "..") {
+			return true
+		}
+	}
+	return false
+}
+
+func (uw *uploadWatcher) addPending(path string) bool {
+	for _, group := range uw.groups {
+		if group.patternRegex.MatchString(path) || path == group.rootPath {
+			uw.pending[path] = true
+			return true
+		}
+	}
+	return false
+}
+
+// Creates the spec for uploading the pending files. Each pending file is uploaded by each File-Spec group it matches.
+func (uw *uploadWatcher) createPendingSpec() (*spec.SpecFiles, error) {
+	paths := make([]string, 0, len(uw.pending))
+	for path := range uw.pending {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	pendingSpec := new(spec.SpecFiles)
+	for _, path := range paths {
+		for _, group := range uw.groups {
+			file, err := group.getFileGroup(path)
+			if err != nil {
+				return nil, err
+			}
+			if file != nil {
+				pendingSpec.Files = append(pendingSpec.Files, *file)
+			}
+		}
+	}
+	return pendingSpec, nil
+}
+
+// Uploads the pending files. An upload failure doesn't stop the watcher, and is returned after it stops.
+func (uw *uploadWatcher) uploadPending() error {
+	if len(uw.pending) == 0 {
+		return nil
+	}
+	pendingSpec, err := uw.createPendingSpec()
+	uw.pending = map[string]bool{}
+	if err != nil || len(pendingSpec.Files) == 0 {
+		return err
+	}
+	log.Info(fmt.Sprintf("Uploading %d new or changed files...", len(pendingSpec.Files)))
+	return uw.upload(pendingSpec)
+}
+
+// Watches until a value is received from the stop channel. The pending files are uploaded before returning.
+func (uw *uploadWatcher) watch(stop <-chan os.Signal) (err error) {
+	defer func() {
+		err = errors.Join(err, uw.close())
+	}()
+	timer := time.NewTimer(uw.debounce)
+	timer.Stop()
+	var uploadErr error
+	for {
+		select {
+		case event, ok := <-uw.watcher.Events:
+			if !ok {
+				return errors.Join(uploadErr, uw.uploadPending())
+			}
+			added, err := uw.handleEvent(event)
+			if err != nil {
+				log.Error(err)
+			}
+			if added {
+				timer.Reset(uw.debounce)
+			}
+		case err, ok := <-uw.watcher.Errors:
+			if ok {
+				log.Error(errorutils.CheckError(err))
+			}
+		case <-timer.C:
+			if err := uw.uploadPending(); err != nil {
+				log.Error(err)
+				uploadErr = errors.New("upload finished with errors. Review the logs for more information")
+			}
+		case <-stop:
+			log.Info("Stopping the watch, after uploading the pending files...")
+			timer.Stop()
+			return errors.Join(uploadErr, uw.uploadPending())
+		}
+	}
+}
+
+func (uw *uploadWatcher) close() error {
+	return errorutils.CheckError(uw.watcher.Close())
+}
+
+func (uc *UploadCommand) validateWatch() error {
+	if uc.syncDelete() {
+		return errorutils.CheckErrorf("the sync-deletes option is not supported in watch mode")
+	}
+	if uc.DetailedSummary() {
+		return errorutils.CheckErrorf("the detailed summary is not supported in watch mode")
+	}
+	for _, file := range uc.Spec().Files {
+		if file.Archive != "" {
+			return errorutils.CheckErrorf("the archive option is not supported in watch mode")
+		}
+	}
+	return nil
+}
+
+// Uploads the files matching the spec, and then keeps uploading new and changed files until the process is interrupted.
+func (uc *UploadCommand) uploadAndWatch() error {
+	if err := uc.validateWatch(); err != nil {
+		return err
+	}
+	if err := uc.upload(); err != nil {
+		return err
+	}
+	watcher, err := newUploadWatcher(uc.Spec(), uc.watchDebounce, uc.uploadFiles)
+	if err != nil {
+		return err
+	}
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+	log.Info("Watching for new and changed files. Press Ctrl+C to stop.")
+	return watcher.watch(stop)
+}
+
+// Uploads the files of the spec, and adds the results to the results of the command.
+func (uc *UploadCommand) uploadFiles(files *spec.SpecFiles) error {
+	filesCommand := *uc
+	filesCommand.result = new(commandsutils.Result)
+	filesCommand.SetSpec(files)
+	err := filesCommand.upload()
+	uc.result.SetSuccessCount(uc.result.SuccessCount() + filesCommand.result.SuccessCount())
+	uc.result.SetFailCount(uc.result.FailCount() + filesCommand.result.FailCount())
+	return err
+}
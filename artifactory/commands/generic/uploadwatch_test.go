@@ -0,0 +1,106 @@
+package generic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchedFileGroup(t *testing.T) {
+	rootDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "out", "sub"), 0755))
+	tests := []struct {
+		name           string
+		file           spec.File
+		path           string
+		expectedTarget string
+		expectedFlat   string
+	}{
+		{"flat", spec.File{Pattern: filepath.Join(rootDir, "out", "*.txt"), Target: "repo/dir/"}, filepath.Join(rootDir, "out", "sub", "a.txt"), "repo/dir/", "true"},
+		{"notMatching", spec.File{Pattern: filepath.Join(rootDir, "out", "*.txt"), Target: "repo/dir/"}, filepath.Join(rootDir, "out", "a.zip"), "", ""},
+		{"notRecursive", spec.File{Pattern: filepath.Join(rootDir, "out", "*.txt"), Target: "repo/", Recursive: "false"}, filepath.Join(rootDir, "out", "sub", "a.txt"), "", ""},
+		{"excluded", spec.File{Pattern: filepath.Join(rootDir, "out", "*"), Target: "repo/", Exclusions: []string{"*sub*"}}, filepath.Join(rootDir, "out", "sub", "a.txt"), "", ""},
+		{"placeholders", spec.File{Pattern: filepath.Join(rootDir, "out", "(*).txt"), Target: "repo/{1}/", Flat: "false"}, filepath.Join(rootDir, "out", "a.txt"), "repo/a/", "true"},
+		{"repositoryOnly", spec.File{Pattern: filepath.Join(rootDir, "out", "*"), Target: "repo", Flat: "false"}, filepath.Join(rootDir, "out", "a.txt"), "repo/", "false"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			group, err := newWatchedFileGroup(&test.file)
+			require.NoError(t, err)
+			file, err := group.getFileGroup(test.path)
+			require.NoError(t, err)
+			if test.expectedTarget == "" {
+				assert.Nil(t, file)
+				return
+			}
+			require.NotNil(t, file)
+			assert.Equal(t, test.path, file.Pattern)
+			assert.Equal(t, test.expectedTarget, file.Target)
+			assert.Equal(t, test.expectedFlat, file.Flat)
+			assert.Equal(t, "false", file.Recursive)
+			assert.Empty(t, file.Exclusions)
+		})
+	}
+}
+
+func startUploadWatcher(t *testing.T, rootDir string, debounce time.Duration) (uploaded chan []string, stop chan os.Signal, watchErr chan error) {
+	uploaded = make(chan []string, 10)
+	upload := func(files *spec.SpecFiles) error {
+		var patterns []string
+		for _, file := range files.Files {
+			patterns = append(patterns, file.Pattern)
+		}
+		uploaded <- patterns
+		return nil
+	}
+	specFiles := spec.NewBuilder().Pattern(filepath.Join(rootDir, "*.txt")).Target("repo/").Recursive(true).BuildSpec()
+	watcher, err := newUploadWatcher(specFiles, debounce, upload)
+	require.NoError(t, err)
+	stop = make(chan os.Signal, 1)
+	watchErr = make(chan error, 1)
+	go func() {
+		watchErr <- watcher.watch(stop)
+	}()
+	return
+}
+
+func TestUploadWatcher(t *testing.T) {
+	rootDir := t.TempDir()
+	uploaded, stop, watchErr := startUploadWatcher(t, rootDir, 100*time.Millisecond)
+	// Files in new directories are uploaded as well.
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "a.txt"), []byte("a"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "ignored.zip"), []byte("b"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "sub", "b.txt"), []byte("b"), 0644))
+	expected := []string{filepath.Join(rootDir, "a.txt"), filepath.Join(rootDir, "sub", "b.txt")}
+	var actual []string
+	timeout := time.After(5 * time.Second)
+	for len(actual) < len(expected) {
+		select {
+		case patterns := <-uploaded:
+			actual = append(actual, patterns...)
+		case <-timeout:
+			require.Fail(t, "the changed files were not uploaded", actual)
+		}
+	}
+	assert.ElementsMatch(t, expected, actual)
+	stop <- os.Interrupt
+	assert.NoError(t, <-watchErr)
+}
+
+func TestUploadWatcherUploadsPendingFilesOnStop(t *testing.T) {
+	rootDir := t.TempDir()
+	uploaded, stop, watchErr := startUploadWatcher(t, rootDir, time.Hour)
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "a.txt"), []byte("a"), 0644))
+	// Wait for the event to be handled.
+	time.Sleep(200 * time.Millisecond)
+	stop <- os.Interrupt
+	assert.NoError(t, <-watchErr)
+	require.Len(t, uploaded, 1)
+	assert.Equal(t, []string{filepath.Join(rootDir, "a.txt")}, <-uploaded)
+}
@@ -0,0 +1,206 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	VerifyStatusMissing  = "missing"
+	VerifyStatusMismatch = "checksum mismatch"
+)
+
+var targetPlaceholderRegexp = regexp.MustCompile(`\{\d+\}`)
+
+// VerifyMismatch is a file in Artifactory whose local copy is missing or has different content.
+type VerifyMismatch struct {
+	RemotePath string `json:"remotePath" col-name:"Remote Path"`
+	LocalPath  string `json:"localPath" col-name:"Local Path"`
+	Status     string `json:"status" col-name:"Status"`
+}
+
+// VerifyCommand verifies that the local files match the files in Artifactory, according to the spec.
+// The files in Artifactory are mapped to local paths the same way the download command maps them, and the checksums of the local files
+// are compared to the checksums returned by the search, so that no file content is downloaded.
+type VerifyCommand struct {
+	GenericCommand
+	outputFormat format.OutputFormat
+	mismatches   []VerifyMismatch
+}
+
+func NewVerifyCommand() *VerifyCommand {
+	return &VerifyCommand{GenericCommand: *NewGenericCommand(), outputFormat: format.Table}
+}
+
+// SetOutputFormat sets the output format of the mismatches - 'table' or 'json'.
+func (vc *VerifyCommand) SetOutputFormat(outputFormat format.OutputFormat) *VerifyCommand {
+	vc.outputFormat = outputFormat
+	return vc
+}
+
+// Mismatches returns the files which failed the verification.
+func (vc *VerifyCommand) Mismatches() []VerifyMismatch {
+	return vc.mismatches
+}
+
+func (vc *VerifyCommand) CommandName() string {
+	return "rt_verify"
+}
+
+func (vc *VerifyCommand) Run() (err error) {
+	serverDetails, err := vc.ServerDetails()
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, vc.retries, vc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return
+	}
+	for i := range vc.Spec().Files {
+		vc.Spec().Files[i].Project = config.GetProjectKey(vc.Spec().Files[i].Project, serverDetails)
+	}
+	log.Info("Searching artifacts...")
+	searchResults, callbackFunc, err := utils.SearchFiles(servicesManager, vc.Spec())
+	defer func() {
+		err = errors.Join(err, callbackFunc())
+	}()
+	if err != nil {
+		return
+	}
+
+	verified := 0
+	for i, reader := range searchResults {
+		var items []*servicesutils.ResultItem
+		for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+			items = append(items, item)
+		}
+		if err = reader.GetError(); err != nil {
+			return
+		}
+		for _, item := range items {
+			localPath, err := getVerifyLocalPath(vc.Spec().Get(i), item, len(items))
+			if err != nil {
+				return err
+			}
+			if err = vc.verifyFile(item, localPath); err != nil {
+				return err
+			}
+			verified++
+		}
+	}
+	vc.Result().SetSuccessCount(verified - len(vc.mismatches))
+	vc.Result().SetFailCount(len(vc.mismatches))
+	if err = vc.printMismatches(); err != nil {
+		return
+	}
+	if len(vc.mismatches) > 0 {
+		return errorutils.CheckErrorf("%d out of %d files failed the verification", len(vc.mismatches), verified)
+	}
+	log.Info(fmt.Sprintf("Verified %d files.", verified))
+	return nil
+}
+
+func (vc *VerifyCommand) verifyFile(item *servicesutils.ResultItem, localPath string) error {
+	remotePath := item.GetItemRelativePath()
+	if !fileutils.IsPathExists(localPath, false) {
+		vc.mismatches = append(vc.mismatches, VerifyMismatch{RemotePath: remotePath, LocalPath: localPath, Status: VerifyStatusMissing})
+		return nil
+	}
+	details, err := fileutils.GetFileDetails(ioutils.ToLongPath(localPath), true)
+	if err != nil {
+		return err
+	}
+	if !isChecksumMatch(item, details) {
+		vc.mismatches = append(vc.mismatches, VerifyMismatch{RemotePath: remotePath, LocalPath: localPath, Status: VerifyStatusMismatch})
+	}
+	return nil
+}
+
+// Compares the strongest checksum available in Artifactory to the checksum of the local file.
+func isChecksumMatch(item *servicesutils.ResultItem, details *fileutils.FileDetails) bool {
+	switch {
+	case item.Sha256 != "":
+		return strings.EqualFold(item.Sha256, details.Checksum.Sha256)
+	case item.Actual_Sha1 != "":
+		return strings.EqualFold(item.Actual_Sha1, details.Checksum.Sha1)
+	default:
+		return strings.EqualFold(item.Actual_Md5, details.Checksum.Md5)
+	}
+}
+
+// Returns the local path of the file in Artifactory, as the download command would download it to.
+// If the target doesn't end with a slash and the pattern matches a single file, the target is the local file path.
+// Placeholders in the target, such as {1}, are replaced by the matching parenthesized parts of the pattern.
+func getVerifyLocalPath(file *spec.File, item *servicesutils.ResultItem, resultsCount int) (string, error) {
+	flat, err := file.IsFlat(false)
+	if err != nil {
+		return "", err
+	}
+	target := file.Target
+	if targetPlaceholderRegexp.MatchString(target) {
+		resolvedTarget, placeholdersUsed, err := clientUtils.BuildTargetPath(file.Pattern, item.GetItemRelativePath(), target, true)
+		if err != nil {
+			return "", err
+		}
+		if !placeholdersUsed || targetPlaceholderRegexp.MatchString(resolvedTarget) {
+			return "", errorutils.CheckErrorf("couldn't resolve the placeholders of the target '%s' for %s by the pattern '%s'", target, item.GetItemRelativePath(), file.Pattern)
+		}
+		// When placeholders are used, the path of the file in Artifactory isn't included in the local path, as in the download command.
+		localPath, fileName := fileutils.GetLocalPathAndFile(item.Name, item.Path, filepath.FromSlash(resolvedTarget), flat, true)
+		return filepath.Join(localPath, fileName), nil
+	}
+	if target != "" && !strings.HasSuffix(target, "/") && !strings.HasSuffix(target, string(filepath.Separator)) {
+		if resultsCount == 1 && !isExistingDir(target) {
+			return filepath.FromSlash(target), nil
+		}
+	}
+	if flat || item.Path == "." || item.Path == "" {
+		return filepath.Join(filepath.FromSlash(target), item.Name), nil
+	}
+	return filepath.Join(filepath.FromSlash(target), filepath.FromSlash(item.Path), item.Name), nil
+}
+
+func isExistingDir(path string) bool {
+	info, err := os.Stat(ioutils.ToLongPath(path))
+	return err == nil && info.IsDir()
+}
+
+func (vc *VerifyCommand) printMismatches() error {
+	switch vc.outputFormat {
+	case format.Json:
+		mismatches := vc.mismatches
+		if mismatches == nil {
+			mismatches = []VerifyMismatch{}
+		}
+		content, err := json.Marshal(mismatches)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	case format.Table:
+		if len(vc.mismatches) == 0 {
+			return nil
+		}
+		return coreutils.PrintTable(vc.mismatches, "Verification Mismatches", "", false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s", vc.outputFormat, format.Table, format.Json)
+	}
+}
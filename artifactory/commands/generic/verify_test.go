@@ -0,0 +1,90 @@
+package generic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Hex(content string) string {
+	checksum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(checksum[:])
+}
+
+func TestVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/system/version":
+			_, err := w.Write([]byte(`{"version":"7.80.0"}`))
+			assert.NoError(t, err)
+		case "/api/search/aql":
+			_, err := fmt.Fprintf(w, `{"results":[
+				{"repo":"generic-local","path":"app","name":"ok.txt","type":"file","sha256":"%s"},
+				{"repo":"generic-local","path":"app","name":"changed.txt","type":"file","sha256":"%s"},
+				{"repo":"generic-local","path":"app/lib","name":"missing.txt","type":"file","sha256":"%s"}
+			]}`, sha256Hex("ok"), sha256Hex("original"), sha256Hex("missing"))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(targetDir, "app"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "app", "ok.txt"), []byte("ok"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "app", "changed.txt"), []byte("changed"), 0644))
+
+	verifyCmd := NewVerifyCommand().SetOutputFormat(format.Json)
+	verifyCmd.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetSpec(spec.NewBuilder().Pattern("generic-local/app/").Target(targetDir + "/").BuildSpec())
+	assert.ErrorContains(t, verifyCmd.Run(), "2 out of 3 files failed the verification")
+	assert.Equal(t, []VerifyMismatch{
+		{RemotePath: "generic-local/app/changed.txt", LocalPath: filepath.Join(targetDir, "app", "changed.txt"), Status: VerifyStatusMismatch},
+		{RemotePath: "generic-local/app/lib/missing.txt", LocalPath: filepath.Join(targetDir, "app", "lib", "missing.txt"), Status: VerifyStatusMissing},
+	}, verifyCmd.Mismatches())
+	assert.Equal(t, 1, verifyCmd.Result().SuccessCount())
+	assert.Equal(t, 2, verifyCmd.Result().FailCount())
+}
+
+func TestGetVerifyLocalPath(t *testing.T) {
+	item := &servicesutils.ResultItem{Repo: "generic-local", Path: "app/lib", Name: "a.zip"}
+	testCases := []struct {
+		name     string
+		file     *spec.File
+		count    int
+		expected string
+	}{
+		{"directory target", &spec.File{Target: "out/"}, 2, filepath.Join("out", "app", "lib", "a.zip")},
+		{"flat", &spec.File{Target: "out/", Flat: "true"}, 2, filepath.Join("out", "a.zip")},
+		{"file target", &spec.File{Target: "out/renamed.zip"}, 1, filepath.Join("out", "renamed.zip")},
+		{"no target", &spec.File{}, 2, filepath.Join("app", "lib", "a.zip")},
+		{"placeholders", &spec.File{Pattern: "generic-local/(*)/lib/(*).zip", Target: "out/{1}/{2}/"}, 2, filepath.Join("out", "app", "a", "a.zip")},
+		{"placeholders file target", &spec.File{Pattern: "generic-local/app/(*)/*", Target: "out/{1}.zip"}, 2, filepath.Join("out", "lib.zip")},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			localPath, err := getVerifyLocalPath(testCase.file, item, testCase.count)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, localPath)
+		})
+	}
+}
+
+func TestGetVerifyLocalPathUnresolvedPlaceholders(t *testing.T) {
+	item := &servicesutils.ResultItem{Repo: "generic-local", Path: "app/lib", Name: "a.zip"}
+	_, err := getVerifyLocalPath(&spec.File{Pattern: "generic-local/app/lib/*", Target: "out/{1}/"}, item, 2)
+	assert.ErrorContains(t, err, "couldn't resolve the placeholders of the target 'out/{1}/'")
+}
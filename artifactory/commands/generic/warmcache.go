@@ -0,0 +1,272 @@
+package generic
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/npmaudit"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/mod/module"
+)
+
+const defaultWarmCacheThreads = 3
+
+// WarmCacheCommand pre-fetches packages through a remote repository, so that they are cached in Artifactory before the first build needs them.
+// The packages are listed in a lockfile - package-lock.json, npm-shrinkwrap.json, yarn.lock or go.sum - or in a spec file,
+// whose patterns are the paths of the files in the remote repository. Wildcards aren't supported in the spec patterns, since the files
+// don't exist in the cache yet.
+type WarmCacheCommand struct {
+	GenericCommand
+	repo       string
+	sourceFile string
+	threads    int
+	// If true, the SHA-256 checksums of the fetched packages are validated while they're read.
+	validateChecksums      bool
+	checksumMismatchAction utils.ChecksumMismatchAction
+}
+
+func NewWarmCacheCommand() *WarmCacheCommand {
+	return &WarmCacheCommand{GenericCommand: *NewGenericCommand(), threads: defaultWarmCacheThreads}
+}
+
+// SetRepo sets the remote repository, or the virtual repository including it, to fetch the packages through.
+func (wcc *WarmCacheCommand) SetRepo(repo string) *WarmCacheCommand {
+	wcc.repo = repo
+	return wcc
+}
+
+// SetSourceFile sets the lockfile or the spec file listing the packages to fetch.
+func (wcc *WarmCacheCommand) SetSourceFile(sourceFile string) *WarmCacheCommand {
+	wcc.sourceFile = sourceFile
+	return wcc
+}
+
+func (wcc *WarmCacheCommand) SetThreads(threads int) *WarmCacheCommand {
+	wcc.threads = threads
+	return wcc
+}
+
+// SetValidateChecksums enables validating the checksums of the fetched packages against the checksums reported by Artifactory.
+// Since nothing is stored locally, the quarantine action fails the command like the fail action.
+func (wcc *WarmCacheCommand) SetValidateChecksums(validateChecksums bool, action utils.ChecksumMismatchAction) *WarmCacheCommand {
+	wcc.validateChecksums = validateChecksums
+	wcc.checksumMismatchAction = action
+	return wcc
+}
+
+func (wcc *WarmCacheCommand) CommandName() string {
+	return "rt_warm_cache"
+}
+
+func (wcc *WarmCacheCommand) Run() error {
+	if wcc.repo == "" {
+		return errorutils.CheckErrorf("the repository to warm up must be provided")
+	}
+	paths, err := wcc.getPackagesPaths()
+	if err != nil {
+		return err
+	}
+	if !wcc.validateChecksums {
+		if wcc.validateChecksums, wcc.checksumMismatchAction, err = utils.GetChecksumMismatchActionFromEnv(); err != nil {
+			return err
+		}
+	}
+	if len(paths) == 0 {
+		log.Info("No packages to fetch were found in", wcc.sourceFile)
+		return nil
+	}
+	serverDetails, err := wcc.ServerDetails()
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, wcc.retries, wcc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	if err = utils.ValidateRepoExists(wcc.repo, servicesManager.GetConfig().GetServiceDetails()); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Fetching %d packages through the '%s' repository...", len(paths), wcc.repo))
+	threads := wcc.threads
+	if threads <= 0 {
+		threads = defaultWarmCacheThreads
+	}
+	var failures int32
+	runner := parallel.NewRunner(threads, uint(len(paths)), false)
+	go func() {
+		defer runner.Done()
+		for _, packagePath := range paths {
+			packagePath := packagePath
+			_, _ = runner.AddTask(func(int) error {
+				if err := wcc.fetchPackage(servicesManager, packagePath); err != nil {
+					log.Warn(fmt.Sprintf("Failed fetching '%s': %s", packagePath, err.Error()))
+					atomic.AddInt32(&failures, 1)
+					return nil
+				}
+				log.Debug("Fetched", packagePath)
+				return nil
+			})
+		}
+	}()
+	runner.Run()
+	wcc.Result().SetSuccessCount(len(paths) - int(failures))
+	wcc.Result().SetFailCount(int(failures))
+	if failures > 0 {
+		return errorutils.CheckErrorf("failed fetching %d out of %d packages", failures, len(paths))
+	}
+	log.Info(fmt.Sprintf("Fetched %d packages.", len(paths)))
+	return nil
+}
+
+// Returns the paths of the packages to fetch, relative to the Artifactory URL.
+func (wcc *WarmCacheCommand) getPackagesPaths() ([]string, error) {
+	if wcc.sourceFile == "" {
+		return nil, errorutils.CheckErrorf("a lockfile or a spec file listing the packages to fetch must be provided")
+	}
+	switch filepath.Base(wcc.sourceFile) {
+	case "package-lock.json", "npm-shrinkwrap.json", "yarn.lock":
+		return getNpmPackagesPaths(wcc.repo, wcc.sourceFile)
+	case "go.sum":
+		return getGoModulesPaths(wcc.repo, wcc.sourceFile)
+	default:
+		return getSpecFilesPaths(wcc.repo, wcc.sourceFile)
+	}
+}
+
+func getNpmPackagesPaths(repo, lockfilePath string) ([]string, error) {
+	lockfile, err := npmaudit.ReadNpmLockfileFromPath(lockfilePath)
+	if err != nil {
+		return nil, err
+	}
+	pathsSet := map[string]bool{}
+	for _, pkg := range lockfile.Packages {
+		if pkg.Name == "" || pkg.Version == "" {
+			continue
+		}
+		// The tarballs of scoped packages are named without the scope, like @scope/name/-/name-1.0.0.tgz.
+		pathsSet[fmt.Sprintf("api/npm/%s/%s/-/%s-%s.tgz", repo, pkg.Name, path.Base(pkg.Name), pkg.Version)] = true
+	}
+	return toSortedSlice(pathsSet), nil
+}
+
+// Returns the paths of the modules in go.sum. The go.mod files are fetched for the modules listed with their go.mod checksum only,
+// which are needed by the module graph but not downloaded by the build.
+func getGoModulesPaths(repo, goSumPath string) ([]string, error) {
+	content, err := os.ReadFile(goSumPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	pathsSet := map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		version, extension := fields[1], ".zip"
+		if strings.HasSuffix(version, "/go.mod") {
+			version, extension = strings.TrimSuffix(version, "/go.mod"), ".mod"
+		}
+		escapedPath, err := module.EscapePath(fields[0])
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		escapedVersion, err := module.EscapeVersion(version)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		pathsSet[fmt.Sprintf("api/go/%s/%s/@v/%s%s", repo, escapedPath, escapedVersion, extension)] = true
+	}
+	return toSortedSlice(pathsSet), nil
+}
+
+// The patterns of the spec are the paths of the files in the repository, optionally prefixed by the repository name.
+func getSpecFilesPaths(repo, specFilePath string) ([]string, error) {
+	specFiles, err := spec.CreateSpecFromFile(specFilePath, nil)
+	if err != nil {
+		return nil, err
+	}
+	pathsSet := map[string]bool{}
+	for _, file := range specFiles.Files {
+		if strings.ContainsAny(file.Pattern, "*?") {
+			return nil, errorutils.CheckErrorf("the pattern '%s' includes wildcards, which aren't supported when warming up the cache", file.Pattern)
+		}
+		filePath := strings.TrimPrefix(strings.TrimPrefix(file.Pattern, "/"), repo+"/")
+		if filePath != "" {
+			pathsSet[repo+"/"+filePath] = true
+		}
+	}
+	return toSortedSlice(pathsSet), nil
+}
+
+func toSortedSlice(set map[string]bool) []string {
+	slice := make([]string, 0, len(set))
+	for value := range set {
+		slice = append(slice, value)
+	}
+	sort.Strings(slice)
+	return slice
+}
+
+func (wcc *WarmCacheCommand) fetchPackage(servicesManager artifactory.ArtifactoryServicesManager, packagePath string) error {
+	attempts := 1
+	if wcc.validateChecksums && wcc.checksumMismatchAction == utils.ChecksumMismatchRetry {
+		attempts += utils.ChecksumMismatchRetries
+	}
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var checksumErr error
+		if checksumErr, err = fetchPackage(servicesManager, packagePath, wcc.validateChecksums); err != nil || checksumErr == nil {
+			return err
+		}
+		err = checksumErr
+		if attempt < attempts {
+			log.Warn(fmt.Sprintf("Fetching '%s' again: %s", packagePath, checksumErr.Error()))
+		}
+	}
+	return err
+}
+
+// Downloads the package and discards its content. The remote repository caches the package while serving it.
+// If validateChecksum is true, the SHA-256 checksum of the content is compared to the checksum in the response headers,
+// and a mismatch is returned as checksumErr.
+func fetchPackage(servicesManager artifactory.ArtifactoryServicesManager, packagePath string, validateChecksum bool) (checksumErr, err error) {
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	reader, resp, err := servicesManager.Client().ReadRemoteFile(servicesManager.GetConfig().GetServiceDetails().GetUrl()+packagePath, &httpDetails)
+	if err != nil {
+		return
+	}
+	if reader == nil {
+		// The body of unsuccessful responses isn't returned by the client.
+		_ = resp.Body.Close()
+		return nil, errorutils.CheckErrorf("received status %s", resp.Status)
+	}
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	expectedSha256 := resp.Header.Get("X-Checksum-Sha256")
+	if !validateChecksum || expectedSha256 == "" {
+		_, err = io.Copy(io.Discard, reader)
+		return nil, errorutils.CheckError(err)
+	}
+	validatingReader := utils.NewSha256ValidatingReader(reader, expectedSha256)
+	if _, err = io.Copy(io.Discard, validatingReader); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return validatingReader.Validate(), nil
+}
@@ -0,0 +1,128 @@
+package generic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const warmCacheLockfile = `{
+  "name": "my-app",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "my-app", "version": "1.0.0", "dependencies": {"@types/node": "^20.0.0", "lodash": "^4.17.0"}},
+    "node_modules/@types/node": {"version": "20.1.0"},
+    "node_modules/lodash": {"version": "4.17.21"}
+  }
+}`
+
+func TestWarmCache(t *testing.T) {
+	var mutex sync.Mutex
+	var fetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/repositories/npm-remote":
+			w.WriteHeader(http.StatusOK)
+		case "/api/npm/npm-remote/lodash/-/lodash-4.17.21.tgz":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			mutex.Lock()
+			fetched = append(fetched, r.URL.Path)
+			mutex.Unlock()
+			_, err := w.Write([]byte("content"))
+			assert.NoError(t, err)
+		}
+	}))
+	defer server.Close()
+
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "package-lock.json"), []byte(warmCacheLockfile), 0644))
+	warmCacheCmd := NewWarmCacheCommand().SetRepo("npm-remote").SetSourceFile(filepath.Join(projectDir, "package-lock.json"))
+	warmCacheCmd.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"})
+	assert.EqualError(t, warmCacheCmd.Run(), "failed fetching 1 out of 2 packages")
+	assert.Equal(t, []string{"/api/npm/npm-remote/@types/node/-/node-20.1.0.tgz"}, fetched)
+	assert.Equal(t, 1, warmCacheCmd.Result().SuccessCount())
+	assert.Equal(t, 1, warmCacheCmd.Result().FailCount())
+}
+
+func TestGetNpmPackagesPathsFromYarnLock(t *testing.T) {
+	// The project has both lockfiles, and the packages are read from the provided one.
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "package-lock.json"), []byte(warmCacheLockfile), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "package.json"), []byte(`{"name":"my-app","version":"1.0.0","dependencies":{"lodash":"^4.17.0"}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "yarn.lock"), []byte(`# yarn lockfile v1
+
+
+lodash@^4.17.0:
+  version "4.17.20"
+  resolved "https://registry.yarnpkg.com/lodash/-/lodash-4.17.20.tgz"
+`), 0644))
+	paths, err := getNpmPackagesPaths("npm-remote", filepath.Join(projectDir, "yarn.lock"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api/npm/npm-remote/lodash/-/lodash-4.17.20.tgz"}, paths)
+}
+
+func TestGetGoModulesPaths(t *testing.T) {
+	goSumPath := filepath.Join(t.TempDir(), "go.sum")
+	require.NoError(t, os.WriteFile(goSumPath, []byte(`github.com/BurntSushi/toml v1.3.2 h1:abc=
+github.com/BurntSushi/toml v1.3.2/go.mod h1:def=
+golang.org/x/mod v0.17.0/go.mod h1:ghi=
+`), 0644))
+	paths, err := getGoModulesPaths("go-remote", goSumPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"api/go/go-remote/github.com/!burnt!sushi/toml/@v/v1.3.2.mod",
+		"api/go/go-remote/github.com/!burnt!sushi/toml/@v/v1.3.2.zip",
+		"api/go/go-remote/golang.org/x/mod/@v/v0.17.0.mod",
+	}, paths)
+}
+
+func TestGetSpecFilesPaths(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.json")
+	require.NoError(t, os.WriteFile(specPath, []byte(`{"files":[{"pattern":"generic-remote/tools/a.zip"},{"pattern":"tools/b.zip"}]}`), 0644))
+	paths, err := getSpecFilesPaths("generic-remote", specPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"generic-remote/tools/a.zip", "generic-remote/tools/b.zip"}, paths)
+
+	require.NoError(t, os.WriteFile(specPath, []byte(`{"files":[{"pattern":"generic-remote/tools/*.zip"}]}`), 0644))
+	_, err = getSpecFilesPaths("generic-remote", specPath)
+	assert.ErrorContains(t, err, "includes wildcards")
+}
+
+func TestWarmCacheValidateChecksums(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/repositories/generic-remote" {
+			return
+		}
+		w.Header().Set("X-Checksum-Sha256", sha256Hex("content"))
+		content := "content"
+		if atomic.AddInt32(&fetches, 1) == 1 {
+			content = "corrupt"
+		}
+		_, err := w.Write([]byte(content))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	specPath := filepath.Join(t.TempDir(), "spec.json")
+	require.NoError(t, os.WriteFile(specPath, []byte(`{"files":[{"pattern":"tools/a.zip"}]}`), 0644))
+	warmCacheCmd := NewWarmCacheCommand().SetRepo("generic-remote").SetSourceFile(specPath).SetValidateChecksums(true, utils.ChecksumMismatchFail)
+	warmCacheCmd.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"})
+	assert.EqualError(t, warmCacheCmd.Run(), "failed fetching 1 out of 1 packages")
+
+	atomic.StoreInt32(&fetches, 0)
+	warmCacheCmd.SetValidateChecksums(true, utils.ChecksumMismatchRetry)
+	assert.NoError(t, warmCacheCmd.Run())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
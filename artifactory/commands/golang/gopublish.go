@@ -1,16 +1,23 @@
 package golang
 
 import (
+	"errors"
+	"os"
 	"os/exec"
+	"path/filepath"
 
 	"github.com/jfrog/build-info-go/build"
+	buildinfo "github.com/jfrog/build-info-go/entities"
 	commandutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
 	goutils "github.com/jfrog/jfrog-cli-core/v2/utils/golang"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 const minSupportedArtifactoryVersion = "6.2.0"
@@ -20,7 +27,14 @@ type GoPublishCommandArgs struct {
 	version            string
 	detailedSummary    bool
 	excludedPatterns   []string
-	result             *commandutils.Result
+	addVcsProps        bool
+	// Whether to publish the project with the version of its current commit, which is a pseudo-version if the commit isn't tagged.
+	pseudoVersion bool
+	// The path of a module zip file built by other tooling, which is published with the mod and info files next to it instead of the project.
+	moduleZipPath string
+	// In dry run mode, the module files are reported instead of being published, and no build-info is collected.
+	dryRun bool
+	result *commandutils.Result
 	project.RepositoryConfig
 }
 
@@ -38,6 +52,11 @@ func (gpc *GoPublishCommand) CommandName() string {
 	return gpc.internalCommandName
 }
 
+// EnableDryRun implements commands.DryRunCommand.
+func (gpc *GoPublishCommand) EnableDryRun() {
+	gpc.dryRun = true
+}
+
 func (gpc *GoPublishCommand) SetConfigFilePath(configFilePath string) *GoPublishCommand {
 	gpc.configFilePath = configFilePath
 	return gpc
@@ -57,6 +76,25 @@ func (gpc *GoPublishCommand) Run() error {
 	if err != nil {
 		return err
 	}
+	var files *moduleFiles
+	switch {
+	case gpc.moduleZipPath != "":
+		if gpc.pseudoVersion {
+			return errorutils.CheckErrorf("a pseudo-version can't be used when publishing a module zip file")
+		}
+		// Validate the module files before connecting to Artifactory.
+		if files, err = readModuleFiles(gpc.moduleZipPath, gpc.version); err != nil {
+			return err
+		}
+	case gpc.pseudoVersion:
+		if gpc.version != "" {
+			return errorutils.CheckErrorf("a version can't be set when publishing with a pseudo-version")
+		}
+		if gpc.version, err = getPseudoVersion(); err != nil {
+			return err
+		}
+		log.Info("Publishing the version of the current commit:", gpc.version)
+	}
 
 	err = goutils.LogGoVersion()
 	if err != nil {
@@ -94,6 +132,7 @@ func (gpc *GoPublishCommand) Run() error {
 	if err != nil {
 		return err
 	}
+	collectBuildInfo = collectBuildInfo && !gpc.dryRun
 	if collectBuildInfo {
 		buildName, err = gpc.buildConfiguration.GetBuildName()
 		if err != nil {
@@ -112,21 +151,27 @@ func (gpc *GoPublishCommand) Run() error {
 	}
 
 	// Publish the package to Artifactory.
-	summary, artifacts, err := publishPackage(gpc.version, gpc.TargetRepo(), buildName, buildNumber, project, gpc.GetExcludedPatterns(), serviceManager)
+	var summary *servicesutils.OperationSummary
+	var artifacts []buildinfo.Artifact
+	if files != nil {
+		summary, artifacts, err = publishModuleFiles(files, gpc.TargetRepo(), buildName, buildNumber, project, gpc.dryRun, serviceManager)
+	} else {
+		summary, artifacts, err = publishPackage(gpc.version, gpc.TargetRepo(), buildName, buildNumber, project, gpc.GetExcludedPatterns(), gpc.addVcsProps, gpc.dryRun, serviceManager)
+	}
 	if err != nil {
 		return err
 	}
 	result := gpc.Result()
 	result.SetSuccessCount(summary.TotalSucceeded)
 	result.SetFailCount(summary.TotalFailed)
-	if gpc.detailedSummary {
+	if gpc.detailedSummary && summary.TransferDetailsReader != nil {
 		result.SetReader(summary.TransferDetailsReader)
 	}
 	// Publish the build-info to Artifactory
 	if collectBuildInfo {
-		goModule, err := goBuild.AddGoModule("")
+		goModule, err := addGoModule(goBuild, files)
 		if err != nil {
-			return errorutils.CheckError(err)
+			return err
 		}
 		if gpc.buildConfiguration.GetModule() != "" {
 			goModule.SetName(gpc.buildConfiguration.GetModule())
@@ -159,10 +204,50 @@ func (gpc *GoPublishCommandArgs) SetDetailedSummary(detailedSummary bool) *GoPub
 	return gpc
 }
 
+// SetAddVcsProps sets whether to set the vcs.revision, vcs.branch and vcs.url properties of the project's git repository on the published module.
+func (gpc *GoPublishCommandArgs) SetAddVcsProps(addVcsProps bool) *GoPublishCommandArgs {
+	gpc.addVcsProps = addVcsProps
+	return gpc
+}
+
+// SetPseudoVersion sets whether to publish the project with the version of its current commit, instead of a provided version.
+// If the commit isn't tagged with a release version, it's published with a pseudo-version, such as v1.2.4-0.20240102150405-abcdef123456.
+func (gpc *GoPublishCommandArgs) SetPseudoVersion(pseudoVersion bool) *GoPublishCommandArgs {
+	gpc.pseudoVersion = pseudoVersion
+	return gpc
+}
+
+// SetModuleZipPath sets the path of a module zip file built by other tooling, such as <version>.zip, to publish instead of the project.
+// The <version>.mod file, and optionally the <version>.info file, are expected next to it.
+func (gpc *GoPublishCommandArgs) SetModuleZipPath(moduleZipPath string) *GoPublishCommandArgs {
+	gpc.moduleZipPath = moduleZipPath
+	return gpc
+}
+
 func (gpc *GoPublishCommandArgs) IsDetailedSummary() bool {
 	return gpc.detailedSummary
 }
 
+// Adds the module to the build. The module of published module files is read from their mod file, rather than from the project.
+func addGoModule(goBuild *build.Build, files *moduleFiles) (goModule *build.GoModule, err error) {
+	if files == nil {
+		goModule, err = goBuild.AddGoModule("")
+		return goModule, errorutils.CheckError(err)
+	}
+	tempDirPath, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDirPath))
+	}()
+	if err = os.WriteFile(filepath.Join(tempDirPath, "go.mod"), files.modContent, 0600); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	goModule, err = goBuild.AddGoModule(tempDirPath)
+	return goModule, errorutils.CheckError(err)
+}
+
 func validatePrerequisites() error {
 	_, err := exec.LookPath("go")
 	if err != nil {
@@ -0,0 +1,183 @@
+package golang
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	_go "github.com/jfrog/jfrog-client-go/artifactory/services/go"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	gozip "golang.org/x/mod/zip"
+)
+
+// The files of a module version, as served by a Go module proxy: <version>.zip, <version>.mod and optionally <version>.info.
+type moduleFiles struct {
+	module.Version
+	zipPath    string
+	modPath    string
+	modContent []byte
+	// Empty if the info file doesn't exist.
+	infoPath string
+}
+
+// Reads the files of a module which was built by other tooling, and validates them as the go command does when downloading the module.
+// The mod and info files are expected next to the zip file, with the same base name. The version is taken from the base name, if not provided.
+func readModuleFiles(zipPath, packageVersion string) (*moduleFiles, error) {
+	if !strings.HasSuffix(zipPath, ".zip") {
+		return nil, errorutils.CheckErrorf("the module zip file must have a .zip extension: %s", zipPath)
+	}
+	basePath := strings.TrimSuffix(zipPath, ".zip")
+	if packageVersion == "" {
+		packageVersion = filepath.Base(basePath)
+	} else if packageVersion != filepath.Base(basePath) {
+		return nil, errorutils.CheckErrorf("the module zip file name %s doesn't match the version %s", filepath.Base(zipPath), packageVersion)
+	}
+	files := &moduleFiles{zipPath: zipPath, modPath: basePath + ".mod"}
+	var err error
+	if files.modContent, err = os.ReadFile(files.modPath); err != nil {
+		return nil, errorutils.CheckErrorf("failed reading the mod file of the module: %s", err.Error())
+	}
+	files.Path = modfile.ModulePath(files.modContent)
+	if files.Path == "" {
+		return nil, errorutils.CheckErrorf("no module directive was found in %s", files.modPath)
+	}
+	files.Version.Version = packageVersion
+	if err = module.Check(files.Path, files.Version.Version); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if err = files.validateZip(); err != nil {
+		return nil, err
+	}
+	infoPath := basePath + ".info"
+	exists, err := fileutils.IsFileExists(infoPath, false)
+	if err != nil || !exists {
+		return files, err
+	}
+	files.infoPath = infoPath
+	return files, files.validateInfo()
+}
+
+// Validates the layout of the zip file, and that its go.mod file, if exists, is identical to the mod file.
+func (files *moduleFiles) validateZip() (err error) {
+	checkedFiles, err := gozip.CheckZip(files.Version, files.zipPath)
+	if err != nil {
+		return errorutils.CheckErrorf("invalid module zip file %s: %s", files.zipPath, err.Error())
+	}
+	if err = checkedFiles.Err(); err != nil {
+		return errorutils.CheckErrorf("invalid module zip file %s: %s", files.zipPath, err.Error())
+	}
+	zipReader, err := zip.OpenReader(files.zipPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(zipReader.Close()))
+	}()
+	goModPath := files.String() + "/go.mod"
+	for _, file := range zipReader.File {
+		if file.Name != goModPath {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		content, err := io.ReadAll(reader)
+		err = errors.Join(err, reader.Close())
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if !bytes.Equal(content, files.modContent) {
+			return errorutils.CheckErrorf("the go.mod file in %s is different from %s", files.zipPath, files.modPath)
+		}
+	}
+	return nil
+}
+
+func (files *moduleFiles) validateInfo() error {
+	content, err := os.ReadFile(files.infoPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var info goInfo
+	if err = json.Unmarshal(content, &info); err != nil {
+		return errorutils.CheckErrorf("failed parsing the info file %s: %s", files.infoPath, err.Error())
+	}
+	if info.Version != files.Version.Version {
+		return errorutils.CheckErrorf("the version in the info file %s is %s, but the module version is %s", files.infoPath, info.Version, files.Version.Version)
+	}
+	return nil
+}
+
+// Returns the build-info artifacts of the module files.
+func (files *moduleFiles) createArtifacts() ([]buildinfo.Artifact, error) {
+	paths := map[string]string{"zip": files.zipPath, "mod": files.modPath, "info": files.infoPath}
+	var artifacts []buildinfo.Artifact
+	for _, fileType := range []string{"mod", "zip", "info"} {
+		if paths[fileType] == "" {
+			continue
+		}
+		fileDetails, err := fileutils.GetFileDetails(paths[fileType], true)
+		if err != nil {
+			return nil, err
+		}
+		artifact := buildinfo.Artifact{Name: files.Version.Version + "." + fileType, Type: fileType}
+		artifact.Checksum = buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts, nil
+}
+
+// Publishes the files of a module which was built by other tooling to Artifactory.
+func publishModuleFiles(files *moduleFiles, targetRepo, buildName, buildNumber, projectKey string, dryRun bool, servicesManager artifactory.ArtifactoryServicesManager) (summary *servicesutils.OperationSummary, artifacts []buildinfo.Artifact, err error) {
+	log.Info("Publishing", files.String(), "to", targetRepo)
+	props, err := build.CreateBuildProperties(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	params := _go.NewGoParams()
+	params.Version = files.Version.Version
+	params.Props = props
+	params.TargetRepo = targetRepo
+	params.ModuleId = files.Path
+	params.ModContent = files.modContent
+	params.ModPath = files.modPath
+	params.ZipPath = files.zipPath
+	if files.infoPath == "" {
+		// Create the info file if Artifactory version is 6.10.0 and above.
+		var artifactoryVersion string
+		if artifactoryVersion, err = servicesManager.GetConfig().GetServiceDetails().GetVersion(); err != nil {
+			return nil, nil, err
+		}
+		if version.NewVersion(artifactoryVersion).AtLeast(_go.ArtifactoryMinSupportedVersion) {
+			if files.infoPath, err = createInfoFile(files.Version.Version); err != nil {
+				return nil, nil, err
+			}
+			defer func() {
+				err = errors.Join(err, errorutils.CheckError(os.Remove(files.infoPath)))
+			}()
+		}
+	}
+	params.InfoPath = files.infoPath
+	if len(buildName) > 0 && len(buildNumber) > 0 {
+		if artifacts, err = files.createArtifacts(); err != nil {
+			return nil, nil, err
+		}
+	}
+	summary, err = publishGoProject(params, dryRun, servicesManager)
+	return summary, artifacts, err
+}
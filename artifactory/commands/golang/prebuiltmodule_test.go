@@ -0,0 +1,93 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+	gozip "golang.org/x/mod/zip"
+)
+
+const testModContent = "module github.com/jfrog/test-module\n\ngo 1.20\n"
+
+// Creates the zip, mod and info files of a module version in a temp directory, and returns the path of the zip file.
+func createModuleFiles(t *testing.T, modulePath, version, modContent, infoContent string) string {
+	sourceDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "go.mod"), []byte(testModContent), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "main.go"), []byte("package main\n"), 0644))
+	targetDir := t.TempDir()
+	zipFile, err := os.Create(filepath.Join(targetDir, version+".zip"))
+	require.NoError(t, err)
+	require.NoError(t, gozip.CreateFromDir(zipFile, module.Version{Path: modulePath, Version: version}, sourceDir))
+	require.NoError(t, zipFile.Close())
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, version+".mod"), []byte(modContent), 0644))
+	if infoContent != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(targetDir, version+".info"), []byte(infoContent), 0644))
+	}
+	return zipFile.Name()
+}
+
+func TestReadModuleFiles(t *testing.T) {
+	pseudoVersion := "v0.0.0-20240102150405-abcdef123456"
+	zipPath := createModuleFiles(t, "github.com/jfrog/test-module", pseudoVersion, testModContent, `{"Version":"`+pseudoVersion+`","Time":"2024-01-02T15:04:05Z"}`)
+	files, err := readModuleFiles(zipPath, "")
+	assert.NoError(t, err)
+	assert.Equal(t, module.Version{Path: "github.com/jfrog/test-module", Version: pseudoVersion}, files.Version)
+	assert.NotEmpty(t, files.infoPath)
+	artifacts, err := files.createArtifacts()
+	assert.NoError(t, err)
+	if assert.Len(t, artifacts, 3) {
+		assert.Equal(t, pseudoVersion+".mod", artifacts[0].Name)
+		assert.Equal(t, pseudoVersion+".zip", artifacts[1].Name)
+		assert.Equal(t, pseudoVersion+".info", artifacts[2].Name)
+		assert.NotEmpty(t, artifacts[1].Sha1)
+	}
+
+	_, err = readModuleFiles(zipPath, "v1.0.0")
+	assert.ErrorContains(t, err, "doesn't match the version v1.0.0")
+
+	// The info file is optional.
+	zipPath = createModuleFiles(t, "github.com/jfrog/test-module", "v1.0.0", testModContent, "")
+	files, err = readModuleFiles(zipPath, "v1.0.0")
+	assert.NoError(t, err)
+	assert.Empty(t, files.infoPath)
+}
+
+func TestReadModuleFilesInvalid(t *testing.T) {
+	// The info file doesn't match the version.
+	zipPath := createModuleFiles(t, "github.com/jfrog/test-module", "v1.0.0", testModContent, `{"Version":"v1.0.1"}`)
+	_, err := readModuleFiles(zipPath, "")
+	assert.ErrorContains(t, err, "but the module version is v1.0.0")
+
+	// The mod file doesn't match the go.mod file in the zip.
+	zipPath = createModuleFiles(t, "github.com/jfrog/test-module", "v1.0.0", testModContent+"\nrequire golang.org/x/mod v0.17.0\n", "")
+	_, err = readModuleFiles(zipPath, "")
+	assert.ErrorContains(t, err, "is different from")
+
+	// The files in the zip aren't under the module path and version.
+	zipPath = createModuleFiles(t, "github.com/jfrog/other-module", "v1.0.0", testModContent, "")
+	_, err = readModuleFiles(zipPath, "")
+	assert.ErrorContains(t, err, "invalid module zip file")
+
+	// The major version doesn't match the module path.
+	zipPath = createModuleFiles(t, "github.com/jfrog/test-module", "v1.0.0", testModContent, "")
+	v2ZipPath := filepath.Join(filepath.Dir(zipPath), "v2.0.0.zip")
+	require.NoError(t, os.Rename(zipPath, v2ZipPath))
+	require.NoError(t, os.Rename(filepath.Join(filepath.Dir(zipPath), "v1.0.0.mod"), filepath.Join(filepath.Dir(zipPath), "v2.0.0.mod")))
+	_, err = readModuleFiles(v2ZipPath, "")
+	assert.ErrorContains(t, err, "should be v0 or v1, not v2")
+}
+
+func TestPublishModuleFilesDryRun(t *testing.T) {
+	zipPath := createModuleFiles(t, "github.com/jfrog/test-module", "v1.0.0", testModContent, `{"Version":"v1.0.0","Time":"2024-01-02T15:04:05Z"}`)
+	files, err := readModuleFiles(zipPath, "v1.0.0")
+	require.NoError(t, err)
+	// In dry run mode, nothing is published, so no services manager is needed. No build-info is collected either.
+	summary, artifacts, err := publishModuleFiles(files, "go-local", "", "", "", true, nil)
+	require.NoError(t, err)
+	assert.Zero(t, summary.TotalSucceeded)
+	assert.Empty(t, artifacts)
+}
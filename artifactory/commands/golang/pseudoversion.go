@@ -0,0 +1,88 @@
+package golang
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	goutils "github.com/jfrog/jfrog-cli-core/v2/utils/golang"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// Returns the version of the current commit of the project, as resolved by the go command for a commit-based module query.
+// If the commit is tagged with a release version, the version is the tag. Otherwise, it's a pseudo-version based on the latest
+// tag of the module's major version which is an ancestor of the commit, such as v1.2.4-0.20240102150405-abcdef123456.
+func getPseudoVersion() (string, error) {
+	projectPath, err := goutils.GetProjectRoot()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	moduleName, err := goutils.GetModuleName(projectPath)
+	if err != nil {
+		return "", err
+	}
+	output, err := runGit(projectPath, "log", "-1", "--format=%H %ct")
+	if err != nil {
+		return "", err
+	}
+	revision, commitTime, found := strings.Cut(output, " ")
+	if !found || len(revision) < 12 {
+		return "", errorutils.CheckErrorf("unexpected output of git log: %s", output)
+	}
+	commitUnixTime, err := strconv.ParseInt(commitTime, 10, 64)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	_, pathMajor, ok := module.SplitPathVersion(moduleName)
+	if !ok {
+		return "", errorutils.CheckErrorf("invalid module path: %s", moduleName)
+	}
+	major := module.PathMajorPrefix(pathMajor)
+	pointingTags, err := runGit(projectPath, "tag", "--points-at", "HEAD", "--list", "v*")
+	if err != nil {
+		return "", err
+	}
+	if tag := getLatestMajorTag(strings.Fields(pointingTags), major); tag != "" && semver.Prerelease(tag) == "" {
+		return tag, nil
+	}
+	mergedTags, err := runGit(projectPath, "tag", "--merged", "HEAD", "--list", "v*")
+	if err != nil {
+		return "", err
+	}
+	version := module.PseudoVersion(major, getLatestMajorTag(strings.Fields(mergedTags), major), time.Unix(commitUnixTime, 0), revision[:12])
+	return version, errorutils.CheckError(module.Check(moduleName, version))
+}
+
+// Returns the latest valid semantic version tag of the major version, or an empty string if there's no such tag.
+// If major is empty, the tags of the v0 and v1 major versions are considered.
+func getLatestMajorTag(tags []string, major string) (latest string) {
+	for _, tag := range tags {
+		if !semver.IsValid(tag) || semver.Build(tag) != "" {
+			continue
+		}
+		tagMajor := semver.Major(tag)
+		if major == "" && tagMajor != "v0" && tagMajor != "v1" || major != "" && tagMajor != major {
+			continue
+		}
+		if latest == "" || semver.Compare(tag, latest) > 0 {
+			latest = tag
+		}
+	}
+	return
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", errorutils.CheckErrorf("'git %s' failed: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", errorutils.CheckErrorf("failed running git: %s", err.Error())
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,56 @@
+package golang
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLatestMajorTag(t *testing.T) {
+	tags := []string{"v0.9.0", "v1.2.3", "v1.10.0-rc.1", "v1.9.0", "v2.0.0", "release-1", "v1.11.0+build"}
+	assert.Equal(t, "v1.10.0-rc.1", getLatestMajorTag(tags, ""))
+	assert.Equal(t, "v2.0.0", getLatestMajorTag(tags, "v2"))
+	assert.Empty(t, getLatestMajorTag(tags, "v3"))
+	assert.Empty(t, getLatestMajorTag(nil, ""))
+}
+
+func TestGetPseudoVersion(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	projectDir := t.TempDir()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	defer tests.ChangeDirWithCallback(t, wd, projectDir)()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module github.com/jfrog/test-module/v2\n\ngo 1.20\n"), 0644))
+	git := func(args ...string) {
+		_, err := runGit(projectDir, append([]string{"-c", "user.name=test", "-c", "user.email=test@jfrog.com"}, args...)...)
+		require.NoError(t, err)
+	}
+	git("init")
+	git("add", "go.mod")
+	git("commit", "-m", "first")
+
+	// No tags of the major version.
+	version, err := getPseudoVersion()
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^v2\.0\.0-\d{14}-[0-9a-f]{12}$`), version)
+
+	// The commit is tagged.
+	git("tag", "v2.1.0")
+	version, err = getPseudoVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.1.0", version)
+
+	// The commit is after the tag.
+	git("commit", "--allow-empty", "-m", "second")
+	version, err = getPseudoVersion()
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^v2\.1\.1-0\.\d{14}-[0-9a-f]{12}$`), version)
+}
@@ -15,7 +15,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
 	goutils "github.com/jfrog/jfrog-cli-core/v2/utils/golang"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	_go "github.com/jfrog/jfrog-client-go/artifactory/services/go"
@@ -26,7 +28,7 @@ import (
 )
 
 // Publish go project to Artifactory.
-func publishPackage(packageVersion, targetRepo, buildName, buildNumber, projectKey string, excludedPatterns []string, servicesManager artifactory.ArtifactoryServicesManager) (summary *servicesutils.OperationSummary, artifacts []buildinfo.Artifact, err error) {
+func publishPackage(packageVersion, targetRepo, buildName, buildNumber, projectKey string, excludedPatterns []string, addVcsProps, dryRun bool, servicesManager artifactory.ArtifactoryServicesManager) (summary *servicesutils.OperationSummary, artifacts []buildinfo.Artifact, err error) {
 	projectPath, err := goutils.GetProjectRoot()
 	if err != nil {
 		return nil, nil, errorutils.CheckError(err)
@@ -50,6 +52,16 @@ func publishPackage(packageVersion, targetRepo, buildName, buildNumber, projectK
 	if err != nil {
 		return nil, nil, err
 	}
+	if addVcsProps {
+		vcsProps, err := utils.CreateVcsProps(projectPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if props != "" && vcsProps != "" {
+			props += ";"
+		}
+		props += vcsProps
+	}
 
 	// Temp directory for the project archive.
 	// The directory will be deleted at the end.
@@ -104,10 +116,24 @@ func publishPackage(packageVersion, targetRepo, buildName, buildNumber, projectK
 		params.InfoPath = pathToInfo
 	}
 
-	summary, err = servicesManager.PublishGoProject(params)
+	summary, err = publishGoProject(params, dryRun, servicesManager)
 	return summary, artifacts, err
 }
 
+// Publishes the module files to Artifactory. In dry run mode, the files are only reported.
+func publishGoProject(params _go.GoParams, dryRun bool, servicesManager artifactory.ArtifactoryServicesManager) (*servicesutils.OperationSummary, error) {
+	if !dryRun {
+		return servicesManager.PublishGoProject(params)
+	}
+	commands.LogDryRunAction("Publishing module:", params.ModuleId+"@"+params.Version, "to:", params.TargetRepo)
+	for _, path := range []string{params.ModPath, params.ZipPath, params.InfoPath} {
+		if path != "" {
+			commands.LogDryRunAction("Deploying file:", path)
+		}
+	}
+	return new(servicesutils.OperationSummary), nil
+}
+
 // Creates the info file.
 // Returns the path to that file.
 func createInfoFile(packageVersion string) (path string, err error) {
@@ -1,6 +1,8 @@
 package gradle
 
 import (
+	"os"
+
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
@@ -26,6 +28,10 @@ type GradleCommand struct {
 	scanOutputFormat   format.OutputFormat
 	result             *commandsutils.Result
 	deploymentDisabled bool
+	// Repositories to which the deployed artifacts are copied after the deployment.
+	mirrorRepos []string
+	// Whether to run the build with the project's wrapper. If nil, the wrapper is used if enabled in the config or found in the project.
+	useWrapper *bool
 	// File path for Gradle extractor in which all build's artifacts details will be listed at the end of the build.
 	buildArtifactsDetailsFile string
 }
@@ -62,6 +68,9 @@ func (gc *GradleCommand) init() (vConfig *viper.Viper, err error) {
 	if err != nil {
 		return
 	}
+	if err = gc.setUseWrapper(vConfig); err != nil {
+		return
+	}
 	if gc.IsXrayScan() && !vConfig.IsSet("deployer") {
 		err = errorutils.CheckErrorf("Conditional upload can only be performed if deployer is set in the config")
 		return
@@ -69,6 +78,11 @@ func (gc *GradleCommand) init() (vConfig *viper.Viper, err error) {
 	// Gradle extractor is needed to run, in order to get the details of the build's artifacts.
 	// Gradle's extractor deploy build artifacts. This should be disabled since there is no intent to deploy anything or deploy upon Xray scan results.
 	gc.deploymentDisabled = gc.IsXrayScan() || !vConfig.IsSet("deployer")
+	gc.mirrorRepos = vConfig.GetStringSlice(build.DeployerPrefix + build.MirrorRepos)
+	if len(gc.mirrorRepos) > 0 && gc.IsXrayScan() {
+		err = errorutils.CheckErrorf("mirroring the deployed artifacts can't be performed with conditional upload")
+		return
+	}
 	if gc.shouldCreateBuildArtifactsFile() {
 		// Created a file that will contain all the details about the build's artifacts
 		tempFile, err := fileutils.CreateTempFile()
@@ -85,10 +99,10 @@ func (gc *GradleCommand) init() (vConfig *viper.Viper, err error) {
 }
 
 // Gradle extractor generates the details of the build's artifacts.
-// This is required for Xray scan and for the detailed summary.
+// This is required for Xray scan, for mirroring the deployed artifacts and for the detailed summary.
 // We can either scan or print the generated artifacts.
 func (gc *GradleCommand) shouldCreateBuildArtifactsFile() bool {
-	return (gc.IsDetailedSummary() && !gc.deploymentDisabled) || gc.IsXrayScan()
+	return ((gc.IsDetailedSummary() || len(gc.mirrorRepos) > 0) && !gc.deploymentDisabled) || gc.IsXrayScan()
 }
 
 func (gc *GradleCommand) Run() error {
@@ -100,6 +114,11 @@ func (gc *GradleCommand) Run() error {
 	if err != nil {
 		return err
 	}
+	if vConfig.GetBool("usewrapper") {
+		if err = gc.saveWrapperVersion(); err != nil {
+			return err
+		}
+	}
 	if gc.buildArtifactsDetailsFile != "" {
 		err = gc.unmarshalDeployableArtifacts(gc.buildArtifactsDetailsFile)
 		if err != nil {
@@ -108,6 +127,9 @@ func (gc *GradleCommand) Run() error {
 		if gc.IsXrayScan() {
 			return gc.conditionalUpload()
 		}
+		if len(gc.mirrorRepos) > 0 {
+			return gc.mirrorDeployedArtifacts()
+		}
 	}
 	return nil
 }
@@ -167,6 +189,28 @@ func (gc *GradleCommand) conditionalUpload() error {
 	return err
 }
 
+// Sets whether the build runs with the project's wrapper in the configuration.
+func (gc *GradleCommand) setUseWrapper(vConfig *viper.Viper) error {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	useWrapper, err := build.ShouldUseWrapper(gc.useWrapper, vConfig.GetBool("usewrapper"), workingDir, project.Gradle)
+	if err != nil {
+		return err
+	}
+	vConfig.Set("usewrapper", useWrapper)
+	return nil
+}
+
+func (gc *GradleCommand) saveWrapperVersion() error {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return build.SaveWrapperVersion(gc.configuration, workingDir, project.Gradle)
+}
+
 func (gc *GradleCommand) CommandName() string {
 	return "rt_gradle"
 }
@@ -191,6 +235,11 @@ func (gc *GradleCommand) SetThreads(threads int) *GradleCommand {
 	return gc
 }
 
+func (gc *GradleCommand) SetUseWrapper(useWrapper *bool) *GradleCommand {
+	gc.useWrapper = useWrapper
+	return gc
+}
+
 func (gc *GradleCommand) SetDetailedSummary(detailedSummary bool) *GradleCommand {
 	gc.detailedSummary = detailedSummary
 	return gc
@@ -0,0 +1,178 @@
+package gradle
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	gofrog "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Copies the deployed artifacts to the mirror repositories. The copies keep the build properties of the deployed artifacts,
+// and are added to the result, so that the detailed summary lists all the targets of each artifact.
+// If build-info is collected, the copies are recorded in the build-info, in the modules which deployed them.
+func (gc *GradleCommand) mirrorDeployedArtifacts() (err error) {
+	if _, err = gc.ServerDetails(); err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManagerWithThreads(gc.serverDetails, false, gc.threads, -1, 0)
+	if err != nil {
+		return err
+	}
+	deployedArtifacts, err := readTransferDetails(gc.result.Reader())
+	if err != nil {
+		return err
+	}
+	// The file of the reader is replaced by a file which includes the mirrored artifacts.
+	if err = gc.result.Reader().Close(); err != nil {
+		return err
+	}
+	var mirroredArtifacts []clientutils.FileTransferDetails
+	var failedCopies []string
+	for _, mirrorRepo := range gc.mirrorRepos {
+		log.Info(fmt.Sprintf("Copying %d deployed artifacts to the '%s' mirror repository...", len(deployedArtifacts), mirrorRepo))
+		for _, artifact := range deployedArtifacts {
+			mirroredArtifact, err := copyToMirror(servicesManager, artifact, mirrorRepo)
+			if err != nil {
+				log.Warn(err.Error())
+				failedCopies = append(failedCopies, artifact.TargetPath+" -> "+mirrorRepo)
+				continue
+			}
+			mirroredArtifacts = append(mirroredArtifacts, mirroredArtifact)
+		}
+	}
+	gc.result.SetSuccessCount(gc.result.SuccessCount() + len(mirroredArtifacts))
+	gc.result.SetFailCount(gc.result.FailCount() + len(failedCopies))
+	allArtifacts := append(deployedArtifacts, mirroredArtifacts...)
+	if err = clientutils.SaveFileTransferDetailsInFile(gc.buildArtifactsDetailsFile, &allArtifacts); err != nil {
+		return err
+	}
+	gc.result.SetReader(content.NewContentReader(gc.buildArtifactsDetailsFile, "files"))
+	// If the detailed summary wasn't requested, the reader should be closed here.
+	// (otherwise it will be closed by the detailed summary print method)
+	if !gc.detailedSummary {
+		defer gofrog.Close(gc.result.Reader(), &err)
+	}
+	if err = gc.saveMirroredArtifactsBuildInfo(mirroredArtifacts); err != nil {
+		return err
+	}
+	if len(failedCopies) > 0 {
+		return errorutils.CheckErrorf("failed copying %d artifacts to the mirror repositories:\n%s", len(failedCopies), strings.Join(failedCopies, "\n"))
+	}
+	return nil
+}
+
+// The property of a module which lists the copies of its artifacts in the mirror repositories.
+const mirroredArtifactsProperty = "mirroredArtifacts"
+
+// Records the mirrored artifacts in the build-info generated by the Gradle extractor, if build-info is collected.
+// Since the artifacts of the build-info don't include their repositories, the copies are listed in a property of the module
+// which deployed them, with the checksums of the deployed artifacts and with their paths in the mirror repositories.
+func (gc *GradleCommand) saveMirroredArtifactsBuildInfo(mirroredArtifacts []clientutils.FileTransferDetails) error {
+	if len(mirroredArtifacts) == 0 || gc.configuration == nil {
+		return nil
+	}
+	toCollect, err := gc.configuration.IsCollectBuildInfo()
+	if err != nil || !toCollect {
+		return err
+	}
+	buildName, err := gc.configuration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := gc.configuration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	recordedArtifacts := 0
+	err = build.UpdateGeneratedBuildsInfo(buildName, buildNumber, gc.configuration.GetProject(), func(buildInfo *buildinfo.BuildInfo) bool {
+		updated := false
+		for i := range buildInfo.Modules {
+			module := &buildInfo.Modules[i]
+			var mirroredCopies []buildinfo.Artifact
+			for _, mirroredArtifact := range mirroredArtifacts {
+				mirrorRepo, artifactPath, _ := strings.Cut(mirroredArtifact.TargetPath, "/")
+				if deployedArtifact := findDeployedArtifact(module, artifactPath, mirroredArtifact.Sha256); deployedArtifact != nil {
+					mirroredCopy := *deployedArtifact
+					mirroredCopy.Path = mirrorRepo + "/" + artifactPath
+					mirroredCopies = append(mirroredCopies, mirroredCopy)
+				}
+			}
+			if len(mirroredCopies) == 0 {
+				continue
+			}
+			if module.Properties == nil {
+				module.Properties = map[string]interface{}{}
+			}
+			properties, ok := module.Properties.(map[string]interface{})
+			if !ok {
+				log.Warn(fmt.Sprintf("The mirrored artifacts of the '%s' module can't be recorded in its properties.", module.Id))
+				continue
+			}
+			properties[mirroredArtifactsProperty] = mirroredCopies
+			recordedArtifacts += len(mirroredCopies)
+			updated = true
+		}
+		return updated
+	})
+	if err != nil {
+		return err
+	}
+	if recordedArtifacts < len(mirroredArtifacts) {
+		log.Warn(fmt.Sprintf("%d of the mirrored artifacts weren't found in the build-info modules, and aren't recorded in the build-info.", len(mirroredArtifacts)-recordedArtifacts))
+	}
+	return nil
+}
+
+// Returns the artifact of the module which was deployed to the path, or nil if the module didn't deploy it.
+func findDeployedArtifact(module *buildinfo.Module, artifactPath, sha256 string) *buildinfo.Artifact {
+	for i, artifact := range module.Artifacts {
+		if artifact.Path == artifactPath || (artifact.Path == "" && artifact.Name == path.Base(artifactPath) && artifact.Sha256 == sha256) {
+			return &module.Artifacts[i]
+		}
+	}
+	return nil
+}
+
+// Copies the deployed artifact to the same path in the mirror repository, and returns the details of the copy.
+func copyToMirror(servicesManager artifactory.ArtifactoryServicesManager, artifact clientutils.FileTransferDetails, mirrorRepo string) (clientutils.FileTransferDetails, error) {
+	_, artifactPath, found := strings.Cut(artifact.TargetPath, "/")
+	if !found {
+		return clientutils.FileTransferDetails{}, errorutils.CheckErrorf("unexpected path of a deployed artifact: %s", artifact.TargetPath)
+	}
+	params := services.NewMoveCopyParams()
+	params.Pattern = artifact.TargetPath
+	params.Target = mirrorRepo + "/" + artifactPath
+	params.Flat = true
+	succeeded, _, err := servicesManager.Copy(params)
+	if err != nil {
+		return clientutils.FileTransferDetails{}, err
+	}
+	if succeeded == 0 {
+		return clientutils.FileTransferDetails{}, errorutils.CheckErrorf("failed copying '%s' to the '%s' mirror repository", artifact.TargetPath, mirrorRepo)
+	}
+	mirroredArtifact := artifact
+	mirroredArtifact.TargetPath = params.Target
+	return mirroredArtifact, nil
+}
+
+func readTransferDetails(reader *content.ContentReader) ([]clientutils.FileTransferDetails, error) {
+	var transferDetails []clientutils.FileTransferDetails
+	for details := new(clientutils.FileTransferDetails); reader.NextRecord(details) == nil; details = new(clientutils.FileTransferDetails) {
+		transferDetails = append(transferDetails, *details)
+	}
+	if err := reader.GetError(); err != nil {
+		return nil, err
+	}
+	reader.Reset()
+	return transferDetails, nil
+}
@@ -0,0 +1,97 @@
+package gradle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirrorDeployedArtifacts(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	// The partial build-info files are stored in the temp dir of the CLI, which is shared with previous runs.
+	require.NoError(t, build.RemoveBuildDir("mirror-build", "1", ""))
+	defer func() {
+		assert.NoError(t, build.RemoveBuildDir("mirror-build", "1", ""))
+	}()
+	var copies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/search/aql":
+			_, err := fmt.Fprint(w, `{"results":[{"repo":"libs-release-local","path":"org/app/1.0","name":"app-1.0.jar","type":"file"}]}`)
+			assert.NoError(t, err)
+		case strings.HasPrefix(r.URL.Path, "/api/copy/"):
+			if strings.HasPrefix(r.URL.Query().Get("to"), "mirror-b/") {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			copies = append(copies, strings.TrimPrefix(r.URL.Path, "/api/copy/")+" -> "+r.URL.Query().Get("to"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// The build-info generated by the Gradle extractor.
+	deployedArtifact := buildinfo.Artifact{Name: "app-1.0.jar", Type: "jar", Path: "org/app/1.0/app-1.0.jar", Checksum: buildinfo.Checksum{Sha1: "def", Md5: "ghi", Sha256: "abc"}}
+	require.NoError(t, build.SaveBuildInfo("mirror-build", "1", "", &buildinfo.BuildInfo{
+		Name:    "mirror-build",
+		Number:  "1",
+		Modules: []buildinfo.Module{{Type: buildinfo.Gradle, Id: "org:app:1.0", Artifacts: []buildinfo.Artifact{deployedArtifact}}},
+	}))
+
+	artifactsFile := filepath.Join(t.TempDir(), "artifacts")
+	deployedArtifacts := []clientutils.FileTransferDetails{{SourcePath: "build/libs/app-1.0.jar", TargetPath: "libs-release-local/org/app/1.0/app-1.0.jar", Sha256: "abc", RtUrl: server.URL + "/"}}
+	require.NoError(t, clientutils.SaveFileTransferDetailsInFile(artifactsFile, &deployedArtifacts))
+	result := new(commandsutils.Result)
+	result.SetSuccessCount(1)
+	result.SetReader(content.NewContentReader(artifactsFile, "files"))
+
+	gradleCmd := NewGradleCommand().SetDetailedSummary(true).SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetConfiguration(build.NewBuildConfiguration("mirror-build", "1", "", ""))
+	gradleCmd.setResult(result)
+	gradleCmd.buildArtifactsDetailsFile = artifactsFile
+	gradleCmd.mirrorRepos = []string{"mirror-a", "mirror-b"}
+	err := gradleCmd.mirrorDeployedArtifacts()
+	assert.ErrorContains(t, err, "failed copying 1 artifacts to the mirror repositories:\nlibs-release-local/org/app/1.0/app-1.0.jar -> mirror-b")
+	assert.Equal(t, []string{"libs-release-local/org/app/1.0/app-1.0.jar -> mirror-a/org/app/1.0/app-1.0.jar"}, copies)
+	assert.Equal(t, 2, gradleCmd.Result().SuccessCount())
+	assert.Equal(t, 1, gradleCmd.Result().FailCount())
+
+	var targetPaths []string
+	reader := gradleCmd.Result().Reader()
+	for details := new(clientutils.FileTransferDetails); reader.NextRecord(details) == nil; details = new(clientutils.FileTransferDetails) {
+		assert.Equal(t, "build/libs/app-1.0.jar", details.SourcePath)
+		targetPaths = append(targetPaths, details.TargetPath)
+	}
+	assert.NoError(t, reader.Close())
+	assert.Equal(t, []string{"libs-release-local/org/app/1.0/app-1.0.jar", "mirror-a/org/app/1.0/app-1.0.jar"}, targetPaths)
+
+	// The copies are recorded in a property of the module which deployed them, with all the checksums of the deployed artifacts.
+	generatedBuildsInfo, err := build.GetGeneratedBuildsInfo("mirror-build", "1", "")
+	require.NoError(t, err)
+	require.Len(t, generatedBuildsInfo, 1)
+	require.Len(t, generatedBuildsInfo[0].Modules, 1)
+	module := generatedBuildsInfo[0].Modules[0]
+	assert.Equal(t, []buildinfo.Artifact{deployedArtifact}, module.Artifacts)
+	mirroredCopies, err := json.Marshal(module.Properties.(map[string]interface{})[mirroredArtifactsProperty])
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"app-1.0.jar","type":"jar","path":"mirror-a/org/app/1.0/app-1.0.jar","sha1":"def","md5":"ghi","sha256":"abc"}]`, string(mirroredCopies))
+	partials, err := build.ReadPartialBuildInfoFiles("mirror-build", "1", "")
+	require.NoError(t, err)
+	assert.Empty(t, partials)
+}
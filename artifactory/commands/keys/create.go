@@ -0,0 +1,155 @@
+package keys
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KeyCreateCommand generates a signing key pair locally, saves it to the output directory and uploads it to Artifactory.
+// With 'publicOnly', only the public part is uploaded as a trusted key, and the private part never leaves the local machine.
+type KeyCreateCommand struct {
+	serverDetails *config.ServerDetails
+	name          string
+	alias         string
+	keyType       KeyType
+	email         string
+	passphrase    string
+	outputDir     string
+	publicOnly    bool
+}
+
+func NewKeyCreateCommand() *KeyCreateCommand {
+	return &KeyCreateCommand{keyType: Gpg}
+}
+
+func (kcc *KeyCreateCommand) SetServerDetails(serverDetails *config.ServerDetails) *KeyCreateCommand {
+	kcc.serverDetails = serverDetails
+	return kcc
+}
+
+func (kcc *KeyCreateCommand) SetName(name string) *KeyCreateCommand {
+	kcc.name = name
+	return kcc
+}
+
+// SetAlias sets the alias of the key in Artifactory. The key name is used by default.
+func (kcc *KeyCreateCommand) SetAlias(alias string) *KeyCreateCommand {
+	kcc.alias = alias
+	return kcc
+}
+
+func (kcc *KeyCreateCommand) SetKeyType(keyType KeyType) *KeyCreateCommand {
+	kcc.keyType = keyType
+	return kcc
+}
+
+// SetEmail sets the email of the GPG key identity.
+func (kcc *KeyCreateCommand) SetEmail(email string) *KeyCreateCommand {
+	kcc.email = email
+	return kcc
+}
+
+func (kcc *KeyCreateCommand) SetPassphrase(passphrase string) *KeyCreateCommand {
+	kcc.passphrase = passphrase
+	return kcc
+}
+
+func (kcc *KeyCreateCommand) SetOutputDir(outputDir string) *KeyCreateCommand {
+	kcc.outputDir = outputDir
+	return kcc
+}
+
+func (kcc *KeyCreateCommand) SetPublicOnly(publicOnly bool) *KeyCreateCommand {
+	kcc.publicOnly = publicOnly
+	return kcc
+}
+
+func (kcc *KeyCreateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return kcc.serverDetails, nil
+}
+
+func (kcc *KeyCreateCommand) CommandName() string {
+	return "rt_keys_create"
+}
+
+func (kcc *KeyCreateCommand) Run() error {
+	if err := ValidateKeyType(kcc.keyType); err != nil {
+		return err
+	}
+	if kcc.name == "" {
+		return errorutils.CheckErrorf("a key name is mandatory")
+	}
+	log.Info("Generating a", kcc.keyType, "key pair...")
+	key, err := GenerateKey(kcc.keyType, kcc.name, kcc.email, kcc.passphrase)
+	if err != nil {
+		return err
+	}
+	publicKeyPath, privateKeyPath, err := kcc.saveKey(key)
+	if err != nil {
+		return err
+	}
+	log.Info("The public key was saved to", publicKeyPath, "and the private key was saved to", privateKeyPath)
+
+	keysService, err := newKeysService(kcc.serverDetails)
+	if err != nil {
+		return err
+	}
+	alias := getAlias(kcc.alias, kcc.name)
+	if kcc.publicOnly {
+		if err = keysService.createTrustedKey(alias, key.PublicKey); err != nil {
+			return err
+		}
+		log.Info("The public key was uploaded to Artifactory as the trusted key", alias)
+		return nil
+	}
+	if err = keysService.createKeyPair(KeyPair{PairName: kcc.name, PairType: kcc.keyType, Alias: alias,
+		PublicKey: key.PublicKey, PrivateKey: key.PrivateKey, Passphrase: kcc.passphrase}); err != nil {
+		return err
+	}
+	log.Info("The key pair", kcc.name, "was uploaded to Artifactory")
+	return nil
+}
+
+// Saves the key files to the output directory. The private key file is readable by the current user only.
+func (kcc *KeyCreateCommand) saveKey(key *GeneratedKey) (publicKeyPath, privateKeyPath string, err error) {
+	outputDir := kcc.outputDir
+	if outputDir == "" {
+		outputDir = "."
+	}
+	if err = fileutils.CreateDirIfNotExist(outputDir); err != nil {
+		return
+	}
+	extension := ".pem"
+	if kcc.keyType == Gpg {
+		extension = ".asc"
+	}
+	publicKeyPath = filepath.Join(outputDir, kcc.name+"-public"+extension)
+	privateKeyPath = filepath.Join(outputDir, kcc.name+"-private"+extension)
+	for _, path := range []string{publicKeyPath, privateKeyPath} {
+		var exists bool
+		if exists, err = fileutils.IsFileExists(path, false); err != nil {
+			return
+		}
+		if exists {
+			err = errorutils.CheckErrorf("the key file %s already exists", path)
+			return
+		}
+	}
+	if err = errorutils.CheckError(os.WriteFile(publicKeyPath, []byte(key.PublicKey), 0644)); err != nil {
+		return
+	}
+	err = errorutils.CheckError(os.WriteFile(privateKeyPath, []byte(key.PrivateKey), 0600))
+	return
+}
+
+func getAlias(alias, name string) string {
+	if strings.TrimSpace(alias) != "" {
+		return alias
+	}
+	return name
+}
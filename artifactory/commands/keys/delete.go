@@ -0,0 +1,72 @@
+package keys
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// KeyDeleteCommand deletes a signing key pair, or a trusted key identified by its key ID, from Artifactory.
+type KeyDeleteCommand struct {
+	serverDetails *config.ServerDetails
+	name          string
+	trusted       bool
+	quiet         bool
+}
+
+func NewKeyDeleteCommand() *KeyDeleteCommand {
+	return &KeyDeleteCommand{}
+}
+
+func (kdc *KeyDeleteCommand) SetServerDetails(serverDetails *config.ServerDetails) *KeyDeleteCommand {
+	kdc.serverDetails = serverDetails
+	return kdc
+}
+
+// SetName sets the name of the key pair to delete, or the key ID of the trusted key to delete.
+func (kdc *KeyDeleteCommand) SetName(name string) *KeyDeleteCommand {
+	kdc.name = name
+	return kdc
+}
+
+func (kdc *KeyDeleteCommand) SetTrusted(trusted bool) *KeyDeleteCommand {
+	kdc.trusted = trusted
+	return kdc
+}
+
+func (kdc *KeyDeleteCommand) SetQuiet(quiet bool) *KeyDeleteCommand {
+	kdc.quiet = quiet
+	return kdc
+}
+
+func (kdc *KeyDeleteCommand) ServerDetails() (*config.ServerDetails, error) {
+	return kdc.serverDetails, nil
+}
+
+func (kdc *KeyDeleteCommand) CommandName() string {
+	return "rt_keys_delete"
+}
+
+func (kdc *KeyDeleteCommand) Run() error {
+	keyDescription := "key pair " + kdc.name
+	if kdc.trusted {
+		keyDescription = "trusted key " + kdc.name
+	}
+	if !kdc.quiet && !coreutils.AskYesNo("Are you sure you want to permanently delete the "+keyDescription+"?", false) {
+		return nil
+	}
+	keysService, err := newKeysService(kdc.serverDetails)
+	if err != nil {
+		return err
+	}
+	if kdc.trusted {
+		err = keysService.deleteTrustedKey(kdc.name)
+	} else {
+		err = keysService.deleteKeyPair(kdc.name)
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("The", keyDescription, "was deleted")
+	return nil
+}
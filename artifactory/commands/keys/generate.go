@@ -0,0 +1,99 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const rsaKeyBits = 3072
+
+// GeneratedKey is a key pair generated locally, encoded as armored GPG keys or PEM RSA keys.
+type GeneratedKey struct {
+	PublicKey  string
+	PrivateKey string
+}
+
+// GenerateKey generates a key pair of the provided type.
+// The passphrase is used to encrypt the private part of GPG keys, and is ignored for RSA keys.
+func GenerateKey(keyType KeyType, name, email, passphrase string) (*GeneratedKey, error) {
+	switch keyType {
+	case Gpg:
+		return generateGpgKey(name, email, passphrase)
+	case Rsa:
+		return generateRsaKey()
+	default:
+		return nil, ValidateKeyType(keyType)
+	}
+}
+
+func generateGpgKey(name, email, passphrase string) (*GeneratedKey, error) {
+	config := &packet.Config{Algorithm: packet.PubKeyAlgoRSA, RSABits: rsaKeyBits}
+	entity, err := openpgp.NewEntity(name, "", email, config)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	// The identities are self-signed by NewEntity, so the private keys can be encrypted before they are serialized.
+	if passphrase != "" {
+		if err = entity.EncryptPrivateKeys([]byte(passphrase), config); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	var privateKey bytes.Buffer
+	if err = armorEncode(&privateKey, openpgp.PrivateKeyType, func(w *bytes.Buffer) error {
+		return entity.SerializePrivateWithoutSigning(w, config)
+	}); err != nil {
+		return nil, err
+	}
+	var publicKey bytes.Buffer
+	if err = armorEncode(&publicKey, openpgp.PublicKeyType, func(w *bytes.Buffer) error {
+		return entity.Serialize(w)
+	}); err != nil {
+		return nil, err
+	}
+	return &GeneratedKey{PublicKey: publicKey.String(), PrivateKey: privateKey.String()}, nil
+}
+
+func armorEncode(out *bytes.Buffer, blockType string, serialize func(w *bytes.Buffer) error) error {
+	var serialized bytes.Buffer
+	if err := serialize(&serialized); err != nil {
+		return errorutils.CheckError(err)
+	}
+	writer, err := armor.Encode(out, blockType, nil)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if _, err = writer.Write(serialized.Bytes()); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = writer.Close(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	out.WriteString("\n")
+	return nil
+}
+
+func generateRsaKey() (*GeneratedKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &GeneratedKey{
+		PublicKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes})),
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes})),
+	}, nil
+}
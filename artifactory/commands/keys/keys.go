@@ -0,0 +1,150 @@
+package keys
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"net/http"
+)
+
+const (
+	keyPairsApi    = "api/security/keypair"
+	trustedKeysApi = "api/security/keys/trusted"
+)
+
+type KeyType string
+
+const (
+	Gpg KeyType = "GPG"
+	Rsa KeyType = "RSA"
+)
+
+// KeyPair is a signing key pair stored in Artifactory, used to sign release bundles and package metadata.
+type KeyPair struct {
+	PairName   string  `json:"pairName"`
+	PairType   KeyType `json:"pairType"`
+	Alias      string  `json:"alias"`
+	PublicKey  string  `json:"publicKey,omitempty"`
+	PrivateKey string  `json:"privateKey,omitempty"`
+	Passphrase string  `json:"passphrase,omitempty"`
+}
+
+// TrustedKey is a public key stored in Artifactory, used to verify signatures. The private part of a trusted key is never uploaded.
+type TrustedKey struct {
+	Kid         string `json:"kid,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Alias       string `json:"alias"`
+	PublicKey   string `json:"public_key,omitempty"`
+	IssuedOn    string `json:"issued_on,omitempty"`
+	IssuedBy    string `json:"issued_by,omitempty"`
+	ValidUntil  string `json:"valid_until,omitempty"`
+}
+
+type trustedKeysResponse struct {
+	Keys []struct {
+		TrustedKey
+		// The trusted keys API returns the public key in the 'key' field.
+		Key string `json:"key,omitempty"`
+	} `json:"keys"`
+}
+
+func ValidateKeyType(keyType KeyType) error {
+	if keyType != Gpg && keyType != Rsa {
+		return errorutils.CheckErrorf("unsupported key type '%s'. Possible values are: %s, %s", keyType, Gpg, Rsa)
+	}
+	return nil
+}
+
+// keysService sends the signing keys REST API requests to Artifactory.
+type keysService struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+}
+
+func newKeysService(serverDetails *config.ServerDetails) (*keysService, error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	return &keysService{servicesManager: servicesManager}, nil
+}
+
+func (ks *keysService) getKeyPairs() ([]KeyPair, error) {
+	body, err := ks.sendGet(keyPairsApi)
+	if err != nil {
+		return nil, err
+	}
+	var keyPairs []KeyPair
+	return keyPairs, errorutils.CheckError(json.Unmarshal(body, &keyPairs))
+}
+
+func (ks *keysService) createKeyPair(keyPair KeyPair) error {
+	return ks.sendPost(keyPairsApi, keyPair)
+}
+
+func (ks *keysService) deleteKeyPair(pairName string) error {
+	return ks.sendDelete(keyPairsApi + "/" + pairName)
+}
+
+func (ks *keysService) getTrustedKeys() ([]TrustedKey, error) {
+	body, err := ks.sendGet(trustedKeysApi)
+	if err != nil {
+		return nil, err
+	}
+	var response trustedKeysResponse
+	if err = json.Unmarshal(body, &response); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	trustedKeys := []TrustedKey{}
+	for _, key := range response.Keys {
+		trustedKey := key.TrustedKey
+		trustedKey.PublicKey = key.Key
+		trustedKeys = append(trustedKeys, trustedKey)
+	}
+	return trustedKeys, nil
+}
+
+func (ks *keysService) createTrustedKey(alias, publicKey string) error {
+	return ks.sendPost(trustedKeysApi, TrustedKey{Alias: alias, PublicKey: publicKey})
+}
+
+func (ks *keysService) deleteTrustedKey(kid string) error {
+	return ks.sendDelete(trustedKeysApi + "/" + kid)
+}
+
+func (ks *keysService) sendGet(restApi string) ([]byte, error) {
+	serviceDetails := ks.servicesManager.GetConfig().GetServiceDetails()
+	httpDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := ks.servicesManager.Client().SendGet(serviceDetails.GetUrl()+restApi, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	return body, errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
+
+func (ks *keysService) sendPost(restApi string, payload any) error {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	serviceDetails := ks.servicesManager.GetConfig().GetServiceDetails()
+	httpDetails := serviceDetails.CreateHttpClientDetails()
+	clientUtils.SetContentType("application/json", &httpDetails.Headers)
+	resp, body, err := ks.servicesManager.Client().SendPost(serviceDetails.GetUrl()+restApi, content, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated)
+}
+
+func (ks *keysService) sendDelete(restApi string) error {
+	serviceDetails := ks.servicesManager.GetConfig().GetServiceDetails()
+	httpDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, err := ks.servicesManager.Client().SendDelete(serviceDetails.GetUrl()+restApi, nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
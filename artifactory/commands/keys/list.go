@@ -0,0 +1,107 @@
+package keys
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// KeysListCommand lists the signing key pairs and the trusted keys stored in Artifactory.
+type KeysListCommand struct {
+	serverDetails *config.ServerDetails
+	outputFormat  format.OutputFormat
+}
+
+type keysListResult struct {
+	KeyPairs    []KeyPair    `json:"keyPairs"`
+	TrustedKeys []TrustedKey `json:"trustedKeys"`
+}
+
+type keyPairRow struct {
+	Name  string `col-name:"Name"`
+	Type  string `col-name:"Type"`
+	Alias string `col-name:"Alias"`
+}
+
+type trustedKeyRow struct {
+	Alias       string `col-name:"Alias"`
+	Kid         string `col-name:"Key ID"`
+	Fingerprint string `col-name:"Fingerprint"`
+	ValidUntil  string `col-name:"Valid Until"`
+}
+
+func NewKeysListCommand() *KeysListCommand {
+	return &KeysListCommand{outputFormat: format.Table}
+}
+
+func (klc *KeysListCommand) SetServerDetails(serverDetails *config.ServerDetails) *KeysListCommand {
+	klc.serverDetails = serverDetails
+	return klc
+}
+
+// SetOutputFormat sets the output format - 'table' or 'json'.
+func (klc *KeysListCommand) SetOutputFormat(outputFormat format.OutputFormat) *KeysListCommand {
+	klc.outputFormat = outputFormat
+	return klc
+}
+
+func (klc *KeysListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return klc.serverDetails, nil
+}
+
+func (klc *KeysListCommand) CommandName() string {
+	return "rt_keys_list"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (klc *KeysListCommand) IsReadOnly() bool {
+	return true
+}
+
+func (klc *KeysListCommand) Run() error {
+	keysService, err := newKeysService(klc.serverDetails)
+	if err != nil {
+		return err
+	}
+	keyPairs, err := keysService.getKeyPairs()
+	if err != nil {
+		return err
+	}
+	trustedKeys, err := keysService.getTrustedKeys()
+	if err != nil {
+		return err
+	}
+	switch klc.outputFormat {
+	case format.Json:
+		// The private parts of the key pairs are never returned by Artifactory, so the keys can be safely printed.
+		content, err := json.Marshal(keysListResult{KeyPairs: keyPairs, TrustedKeys: trustedKeys})
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	case format.Table:
+		return printKeysTables(keyPairs, trustedKeys)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s", klc.outputFormat, format.Table, format.Json)
+	}
+}
+
+func printKeysTables(keyPairs []KeyPair, trustedKeys []TrustedKey) error {
+	var keyPairRows []keyPairRow
+	for _, keyPair := range keyPairs {
+		keyPairRows = append(keyPairRows, keyPairRow{Name: keyPair.PairName, Type: string(keyPair.PairType), Alias: keyPair.Alias})
+	}
+	if err := coreutils.PrintTable(keyPairRows, "Key Pairs", "No key pairs were found", false); err != nil {
+		return err
+	}
+	var trustedKeyRows []trustedKeyRow
+	for _, trustedKey := range trustedKeys {
+		trustedKeyRows = append(trustedKeyRows, trustedKeyRow{Alias: trustedKey.Alias, Kid: trustedKey.Kid, Fingerprint: trustedKey.Fingerprint, ValidUntil: trustedKey.ValidUntil})
+	}
+	return coreutils.PrintTable(trustedKeyRows, "Trusted Keys", "No trusted keys were found", false)
+}
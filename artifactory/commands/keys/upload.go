@@ -0,0 +1,102 @@
+package keys
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// KeyUploadCommand uploads an existing signing key to Artifactory.
+// If no private key is provided, the public key is uploaded as a trusted key.
+type KeyUploadCommand struct {
+	serverDetails  *config.ServerDetails
+	name           string
+	alias          string
+	keyType        KeyType
+	publicKeyPath  string
+	privateKeyPath string
+	passphrase     string
+}
+
+func NewKeyUploadCommand() *KeyUploadCommand {
+	return &KeyUploadCommand{keyType: Gpg}
+}
+
+func (kuc *KeyUploadCommand) SetServerDetails(serverDetails *config.ServerDetails) *KeyUploadCommand {
+	kuc.serverDetails = serverDetails
+	return kuc
+}
+
+func (kuc *KeyUploadCommand) SetName(name string) *KeyUploadCommand {
+	kuc.name = name
+	return kuc
+}
+
+func (kuc *KeyUploadCommand) SetAlias(alias string) *KeyUploadCommand {
+	kuc.alias = alias
+	return kuc
+}
+
+func (kuc *KeyUploadCommand) SetKeyType(keyType KeyType) *KeyUploadCommand {
+	kuc.keyType = keyType
+	return kuc
+}
+
+func (kuc *KeyUploadCommand) SetPublicKeyPath(publicKeyPath string) *KeyUploadCommand {
+	kuc.publicKeyPath = publicKeyPath
+	return kuc
+}
+
+func (kuc *KeyUploadCommand) SetPrivateKeyPath(privateKeyPath string) *KeyUploadCommand {
+	kuc.privateKeyPath = privateKeyPath
+	return kuc
+}
+
+func (kuc *KeyUploadCommand) SetPassphrase(passphrase string) *KeyUploadCommand {
+	kuc.passphrase = passphrase
+	return kuc
+}
+
+func (kuc *KeyUploadCommand) ServerDetails() (*config.ServerDetails, error) {
+	return kuc.serverDetails, nil
+}
+
+func (kuc *KeyUploadCommand) CommandName() string {
+	return "rt_keys_upload"
+}
+
+func (kuc *KeyUploadCommand) Run() error {
+	if err := ValidateKeyType(kuc.keyType); err != nil {
+		return err
+	}
+	if kuc.name == "" || kuc.publicKeyPath == "" {
+		return errorutils.CheckErrorf("a key name and a public key file are mandatory")
+	}
+	publicKey, err := fileutils.ReadFile(kuc.publicKeyPath)
+	if err != nil {
+		return err
+	}
+	keysService, err := newKeysService(kuc.serverDetails)
+	if err != nil {
+		return err
+	}
+	alias := getAlias(kuc.alias, kuc.name)
+	if kuc.privateKeyPath == "" {
+		if err = keysService.createTrustedKey(alias, string(publicKey)); err != nil {
+			return err
+		}
+		log.Info("The public key was uploaded to Artifactory as the trusted key", alias)
+		return nil
+	}
+	privateKey, err := fileutils.ReadFile(kuc.privateKeyPath)
+	if err != nil {
+		return err
+	}
+	if err = keysService.createKeyPair(KeyPair{PairName: kuc.name, PairType: kuc.keyType, Alias: alias,
+		PublicKey: string(publicKey), PrivateKey: string(privateKey), Passphrase: kuc.passphrase}); err != nil {
+		return err
+	}
+	log.Info("The key pair", kuc.name, "was uploaded to Artifactory")
+	return nil
+}
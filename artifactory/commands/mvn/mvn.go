@@ -1,6 +1,8 @@
 package mvn
 
 import (
+	"os"
+
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
@@ -27,6 +29,8 @@ type MvnCommand struct {
 	scanOutputFormat   format.OutputFormat
 	result             *commandsutils.Result
 	deploymentDisabled bool
+	// Whether to run the build with the project's wrapper. If nil, the wrapper is used if enabled in the config or found in the project.
+	useWrapper *bool
 	// File path for Maven extractor in which all build's artifacts details will be listed at the end of the build.
 	buildArtifactsDetailsFile string
 }
@@ -60,6 +64,11 @@ func (mc *MvnCommand) SetThreads(threads int) *MvnCommand {
 	return mc
 }
 
+func (mc *MvnCommand) SetUseWrapper(useWrapper *bool) *MvnCommand {
+	mc.useWrapper = useWrapper
+	return mc
+}
+
 func (mc *MvnCommand) SetInsecureTls(insecureTls bool) *MvnCommand {
 	mc.insecureTls = insecureTls
 	return mc
@@ -103,6 +112,9 @@ func (mc *MvnCommand) init() (vConfig *viper.Viper, err error) {
 	if err != nil {
 		return
 	}
+	if err = mc.setUseWrapper(vConfig); err != nil {
+		return
+	}
 	if mc.IsXrayScan() && !vConfig.IsSet("deployer") {
 		err = errorutils.CheckErrorf("Conditional upload can only be performed if deployer is set in the config")
 		return
@@ -148,6 +160,11 @@ func (mc *MvnCommand) Run() error {
 	if err = mvnutils.RunMvn(mvnParams); err != nil {
 		return err
 	}
+	if vConfig.GetBool("useWrapper") {
+		if err = mc.saveWrapperVersion(); err != nil {
+			return err
+		}
+	}
 
 	if mc.buildArtifactsDetailsFile == "" {
 		return nil
@@ -187,6 +204,28 @@ func (mc *MvnCommand) unmarshalDeployableArtifacts(filesPath string) error {
 	return nil
 }
 
+// Sets whether the build runs with the project's wrapper in the configuration.
+func (mc *MvnCommand) setUseWrapper(vConfig *viper.Viper) error {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	useWrapper, err := build.ShouldUseWrapper(mc.useWrapper, vConfig.GetBool("useWrapper"), workingDir, project.Maven)
+	if err != nil {
+		return err
+	}
+	vConfig.Set("useWrapper", useWrapper)
+	return nil
+}
+
+func (mc *MvnCommand) saveWrapperVersion() error {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return build.SaveWrapperVersion(mc.configuration, workingDir, project.Maven)
+}
+
 func (mc *MvnCommand) CommandName() string {
 	return "rt_maven"
 }
@@ -8,6 +8,7 @@ import (
 	biUtils "github.com/jfrog/build-info-go/build/utils"
 	"github.com/jfrog/gofrog/version"
 	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/npm"
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
@@ -41,7 +42,9 @@ type NpmCommand struct {
 	// Npm registry as exposed by Artifactory.
 	registry string
 	// Npm token generated by Artifactory using the user's provided credentials.
-	npmAuth             string
+	npmAuth string
+	// TLS settings of the Artifactory server, propagated to npm.
+	tlsConfig           *utils.PackageManagerTlsConfig
 	authArtDetails      auth.ServiceDetails
 	npmVersion          *version.Version
 	internalCommandName string
@@ -169,6 +172,13 @@ func (nc *NpmCommand) PreparePrerequisites(repo string) error {
 		return err
 	}
 
+	if nc.tlsConfig, err = utils.GetPackageManagerTlsConfig(nc.serverDetails); err != nil {
+		return err
+	}
+	if err = nc.tlsConfig.SetNpmEnv(); err != nil {
+		return err
+	}
+
 	return nc.setRestoreNpmrcFunc()
 }
 
@@ -268,6 +278,9 @@ func (nc *NpmCommand) prepareConfigData(data []byte) ([]byte, error) {
 
 	filteredConf = append(filteredConf, "json = ", strconv.FormatBool(nc.jsonOutput), "\n")
 	filteredConf = append(filteredConf, "registry = ", nc.registry, "\n")
+	if nc.tlsConfig != nil {
+		filteredConf = append(filteredConf, nc.tlsConfig.NpmConfig()...)
+	}
 	return []byte(strings.Join(filteredConf, "")), nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	biutils "github.com/jfrog/build-info-go/utils"
 	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	commonTests "github.com/jfrog/jfrog-cli-core/v2/common/tests"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/tests"
 	testsUtils "github.com/jfrog/jfrog-client-go/utils/tests"
@@ -65,6 +66,14 @@ func TestPrepareConfigData(t *testing.T) {
 	testsUtils.UnSetEnvAndAssert(t, fmt.Sprintf(npmConfigAuthEnv, "//goodRegistry"))
 }
 
+func TestPrepareConfigDataWithTlsConfig(t *testing.T) {
+	npmi := NpmCommand{registry: "http://goodRegistry", tlsConfig: &utils.PackageManagerTlsConfig{CaBundlePath: "/ca-bundle.pem", InsecureTls: true}}
+	configAfter, err := npmi.prepareConfigData([]byte("strict-ssl=true"))
+	assert.NoError(t, err)
+	// The settings are added last, to override the existing ones.
+	assert.True(t, strings.HasSuffix(string(configAfter), "registry = http://goodRegistry\nstrict-ssl = false\n"))
+}
+
 func TestSetNpmConfigAuthEnv(t *testing.T) {
 	testCases := []struct {
 		name        string
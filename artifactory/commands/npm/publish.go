@@ -18,6 +18,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	coreioutils "github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
@@ -49,6 +50,7 @@ type NpmPublishCommandArgs struct {
 	xrayScan               bool
 	scanOutputFormat       format.OutputFormat
 	distTag                string
+	addVcsProps            bool
 }
 
 type NpmPublishCommand struct {
@@ -56,6 +58,7 @@ type NpmPublishCommand struct {
 	commandName     string
 	result          *commandsutils.Result
 	detailedSummary bool
+	dryRun          bool
 	npmVersion      *version.Version
 	*NpmPublishCommandArgs
 }
@@ -106,6 +109,18 @@ func (npc *NpmPublishCommand) SetDistTag(tag string) *NpmPublishCommand {
 	return npc
 }
 
+// SetAddVcsProps sets whether to set the vcs.revision, vcs.branch and vcs.url properties of the project's git repository on the published package.
+func (npc *NpmPublishCommand) SetAddVcsProps(addVcsProps bool) *NpmPublishCommand {
+	npc.addVcsProps = addVcsProps
+	return npc
+}
+
+// EnableDryRun implements commands.DryRunCommand.
+// In dry run mode, the package is packed but not deployed, and no build-info is collected.
+func (npc *NpmPublishCommand) EnableDryRun() {
+	npc.dryRun = true
+}
+
 func (npc *NpmPublishCommand) Result() *commandsutils.Result {
 	return npc.result
 }
@@ -155,6 +170,9 @@ func (npc *NpmPublishCommand) Run() (err error) {
 	if err != nil {
 		return err
 	}
+	if npc.dryRun {
+		npc.collectBuildInfo = false
+	}
 
 	var npmBuild *build.Build
 	var buildName, buildNumber, projectKey string
@@ -315,12 +333,13 @@ func (npc *NpmPublishCommand) publish() (err error) {
 }
 
 func (npc *NpmPublishCommand) doDeploy(target string, artDetails *config.ServerDetails, packedFilePath string) error {
-	servicesManager, err := utils.CreateServiceManager(artDetails, -1, 0, false)
+	servicesManager, err := utils.CreateServiceManager(artDetails, -1, 0, npc.dryRun)
 	if err != nil {
 		return err
 	}
 	up := services.NewUploadParams()
 	up.CommonParams = &specutils.CommonParams{Pattern: packedFilePath, Target: target}
+	up.AddVcsProps = npc.addVcsProps
 	if err = npc.addDistTagIfSet(up.CommonParams); err != nil {
 		return err
 	}
@@ -435,7 +454,7 @@ func (npc *NpmPublishCommand) setPublishPath() error {
 
 func (npc *NpmPublishCommand) setPackageInfo() error {
 	log.Debug("Setting Package Info.")
-	fileInfo, err := os.Stat(npc.publishPath)
+	fileInfo, err := os.Stat(coreioutils.ToLongPath(npc.publishPath))
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
@@ -453,7 +472,7 @@ func (npc *NpmPublishCommand) setPackageInfo() error {
 
 func (npc *NpmPublishCommand) readPackageInfoFromTarball(packedFilePath string) (err error) {
 	log.Debug("Extracting info from npm package:", packedFilePath)
-	tarball, err := os.Open(packedFilePath)
+	tarball, err := os.Open(coreioutils.ToLongPath(packedFilePath))
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
@@ -0,0 +1,171 @@
+package npm
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/general/token"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// NpmTokenCreateCommand creates an access token scoped to an npm repository, and generates the .npmrc configuration authenticating with it.
+// The configuration can be used by tools which run npm without JFrog CLI, such as IDEs and Dockerfiles.
+type NpmTokenCreateCommand struct {
+	serverDetails *config.ServerDetails
+	repo          string
+	// The permitted actions on the repository, such as read and deploy. Read only by default.
+	actions []string
+	// The expiry of the token in seconds. If nil, the default expiry of the server is used.
+	expiry *uint
+	// The npm scope resolved from the repository, such as @acme. If empty, the repository is the default registry.
+	npmScope string
+	// If set, the configuration is written to the npmrc in this path instead of printed.
+	npmrcPath string
+	npmrc     string
+}
+
+func NewNpmTokenCreateCommand() *NpmTokenCreateCommand {
+	return &NpmTokenCreateCommand{actions: []string{"read"}}
+}
+
+func (ntc *NpmTokenCreateCommand) SetServerDetails(serverDetails *config.ServerDetails) *NpmTokenCreateCommand {
+	ntc.serverDetails = serverDetails
+	return ntc
+}
+
+func (ntc *NpmTokenCreateCommand) SetRepo(repo string) *NpmTokenCreateCommand {
+	ntc.repo = repo
+	return ntc
+}
+
+func (ntc *NpmTokenCreateCommand) SetActions(actions []string) *NpmTokenCreateCommand {
+	ntc.actions = actions
+	return ntc
+}
+
+func (ntc *NpmTokenCreateCommand) SetExpiry(expiry *uint) *NpmTokenCreateCommand {
+	ntc.expiry = expiry
+	return ntc
+}
+
+func (ntc *NpmTokenCreateCommand) SetNpmScope(npmScope string) *NpmTokenCreateCommand {
+	ntc.npmScope = npmScope
+	return ntc
+}
+
+func (ntc *NpmTokenCreateCommand) SetNpmrcPath(npmrcPath string) *NpmTokenCreateCommand {
+	ntc.npmrcPath = npmrcPath
+	return ntc
+}
+
+// Npmrc returns the generated .npmrc configuration.
+func (ntc *NpmTokenCreateCommand) Npmrc() string {
+	return ntc.npmrc
+}
+
+func (ntc *NpmTokenCreateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ntc.serverDetails, nil
+}
+
+func (ntc *NpmTokenCreateCommand) CommandName() string {
+	return "rt_npm_token_create"
+}
+
+func (ntc *NpmTokenCreateCommand) Run() error {
+	if ntc.repo == "" {
+		return errorutils.CheckErrorf("the repository is mandatory for creating an npm token")
+	}
+	if ntc.npmScope != "" && !strings.HasPrefix(ntc.npmScope, "@") {
+		ntc.npmScope = "@" + ntc.npmScope
+	}
+	tokenCreateCmd := token.NewAccessTokenCreateCommand().SetServerDetails(ntc.serverDetails).SetUsername(ntc.serverDetails.User).
+		SetRepoPermissions([]string{ntc.repo + ":" + strings.Join(ntc.actions, ",")}).SetExpiry(ntc.expiry).
+		SetDescription("npm token for the " + ntc.repo + " repository")
+	if err := tokenCreateCmd.Run(); err != nil {
+		return err
+	}
+	accessToken := tokenCreateCmd.AccessToken()
+	if accessToken == "" {
+		return errorutils.CheckErrorf("the access token wasn't returned by the server")
+	}
+
+	// The npm auth endpoint returns the npm configuration of the authenticated user, so it's called with the new token.
+	tokenDetails := *ntc.serverDetails
+	tokenDetails.User, tokenDetails.Password, tokenDetails.AccessToken, tokenDetails.RefreshToken = "", "", accessToken, ""
+	authArtDetails, err := tokenDetails.CreateArtAuthConfig()
+	if err != nil {
+		return err
+	}
+	npmAuth, registry, err := commandsutils.GetArtifactoryNpmRepoDetails(ntc.repo, &authArtDetails)
+	if err != nil {
+		return err
+	}
+	if ntc.npmrc, err = createNpmrcAuthBlock(npmAuth, registry, ntc.npmScope); err != nil {
+		return err
+	}
+	if ntc.npmrcPath == "" {
+		log.Output(ntc.npmrc)
+		return nil
+	}
+	if err = mergeNpmrc(ntc.npmrcPath, ntc.npmrc); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("The npm configuration of the %s repository was written to %s.", ntc.repo, ntc.npmrcPath))
+	return nil
+}
+
+// Converts the configuration returned by the npm auth endpoint to an .npmrc block.
+// The credentials are bound to the registry (//host/path/:_auth), since npm 9 and above don't send unbound credentials.
+func createNpmrcAuthBlock(npmAuth, registry, npmScope string) (string, error) {
+	registry = strings.TrimSuffix(registry, "/") + "/"
+	registryUrl, err := url.Parse(registry)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	nerfDart := "//" + registryUrl.Host + registryUrl.Path
+	registryKey := "registry"
+	if npmScope != "" {
+		registryKey = npmScope + ":registry"
+	}
+	lines := []string{registryKey + "=" + registry}
+	for _, line := range strings.Split(npmAuth, "\n") {
+		key, value, found := strings.Cut(line, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !found || key == "" {
+			continue
+		}
+		switch key {
+		case "_auth", "_authToken", "_password", "username", "email":
+			lines = append(lines, nerfDart+":"+key+"="+value)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// Writes the block to the npmrc file, replacing the existing settings of the same keys.
+func mergeNpmrc(npmrcPath, block string) error {
+	blockKeys := map[string]bool{}
+	for _, line := range strings.Split(block, "\n") {
+		key, _, _ := strings.Cut(line, "=")
+		blockKeys[strings.TrimSpace(key)] = true
+	}
+	var lines []string
+	content, err := os.ReadFile(npmrcPath)
+	if err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		key, _, _ := strings.Cut(line, "=")
+		if strings.TrimSpace(line) == "" || blockKeys[strings.TrimSpace(key)] {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, block)
+	return errorutils.CheckError(os.WriteFile(npmrcPath, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+}
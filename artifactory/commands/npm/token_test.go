@@ -0,0 +1,71 @@
+package npm
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	commonTests "github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// #nosec G101 -- Dummy token for tests.
+const npmScopedToken = "scoped-npm-token"
+
+func TestNpmTokenCreate(t *testing.T) {
+	var tokenRequest map[string]any
+	testServer := commonTests.CreateRestsMockServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/access/api/v1/tokens":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(body, &tokenRequest))
+			_, err = w.Write([]byte(`{"access_token":"` + npmScopedToken + `"}`))
+			assert.NoError(t, err)
+		case "/artifactory/api/system/version":
+			_, err := w.Write([]byte(`{"version":"7.75.4"}`))
+			assert.NoError(t, err)
+		case "/artifactory/api/repositories/npm-local":
+			w.WriteHeader(http.StatusOK)
+		case "/artifactory/api/npm/auth":
+			// The npm configuration must be requested with the scoped token.
+			assert.Equal(t, "Bearer "+npmScopedToken, r.Header.Get("Authorization"))
+			_, err := w.Write([]byte("_auth = c2NvcGVkOnRva2Vu\nalways-auth = true\nemail = user@acme.io\n"))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer testServer.Close()
+	serverDetails := &config.ServerDetails{Url: testServer.URL + "/", ArtifactoryUrl: testServer.URL + "/artifactory/", AccessToken: "admin-token"}
+
+	npmrcPath := filepath.Join(t.TempDir(), ".npmrc")
+	require.NoError(t, os.WriteFile(npmrcPath, []byte("save-exact=true\n@acme:registry=https://old.acme.io/\n"), 0600))
+	expiry := uint(3600)
+	tokenCreateCmd := NewNpmTokenCreateCommand().SetServerDetails(serverDetails).SetRepo("npm-local").SetExpiry(&expiry).
+		SetNpmScope("acme").SetNpmrcPath(npmrcPath)
+	require.NoError(t, tokenCreateCmd.Run())
+
+	assert.Equal(t, "artifact:npm-local:r", tokenRequest["scope"])
+	assert.EqualValues(t, expiry, tokenRequest["expires_in"])
+	registry := testServer.URL + "/artifactory/api/npm/npm-local/"
+	nerfDart := registry[len("http:"):]
+	expected := "@acme:registry=" + registry + "\n" +
+		nerfDart + ":_auth=c2NvcGVkOnRva2Vu\n" +
+		nerfDart + ":email=user@acme.io"
+	assert.Equal(t, expected, tokenCreateCmd.Npmrc())
+
+	// The existing registry of the scope is replaced, and the other settings are kept.
+	content, err := os.ReadFile(npmrcPath)
+	require.NoError(t, err)
+	assert.Equal(t, "save-exact=true\n"+expected+"\n", string(content))
+}
+
+func TestNpmTokenCreateWithoutRepo(t *testing.T) {
+	assert.Error(t, NewNpmTokenCreateCommand().Run())
+}
@@ -0,0 +1,103 @@
+package permissiontarget
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+// PermissionTargetImportCommand creates or updates multiple permission targets, read from a YAML or a JSON file.
+// The file holds a list of permission targets in the format of the Artifactory permission targets REST API.
+type PermissionTargetImportCommand struct {
+	rtDetails *config.ServerDetails
+	filePath  string
+}
+
+func NewPermissionTargetImportCommand() *PermissionTargetImportCommand {
+	return &PermissionTargetImportCommand{}
+}
+
+func (ptic *PermissionTargetImportCommand) SetFilePath(filePath string) *PermissionTargetImportCommand {
+	ptic.filePath = filePath
+	return ptic
+}
+
+func (ptic *PermissionTargetImportCommand) SetServerDetails(serverDetails *config.ServerDetails) *PermissionTargetImportCommand {
+	ptic.rtDetails = serverDetails
+	return ptic
+}
+
+func (ptic *PermissionTargetImportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ptic.rtDetails, nil
+}
+
+func (ptic *PermissionTargetImportCommand) CommandName() string {
+	return "rt_permission_target_import"
+}
+
+func (ptic *PermissionTargetImportCommand) Run() error {
+	permissionTargets, err := ReadPermissionTargetsFile(ptic.filePath)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(ptic.rtDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	for _, permissionTarget := range permissionTargets {
+		existing, err := servicesManager.GetPermissionTarget(permissionTarget.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			log.Info(fmt.Sprintf("Permission target %s already exists and will be updated.", permissionTarget.Name))
+			err = servicesManager.UpdatePermissionTarget(permissionTarget)
+		} else {
+			err = servicesManager.CreatePermissionTarget(permissionTarget)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ReadPermissionTargetsFile(filePath string) ([]services.PermissionTargetParams, error) {
+	content, err := fileutils.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+	case ".yaml", ".yml":
+		// The permission targets structs have JSON tags only, so the YAML content is converted to JSON.
+		var permissionTargets []map[string]any
+		if err = yaml.Unmarshal(content, &permissionTargets); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse %s: %s", filePath, err.Error())
+		}
+		if content, err = json.Marshal(permissionTargets); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	default:
+		return nil, errorutils.CheckErrorf("unsupported file type '%s'. Possible types are: yaml, json", filePath)
+	}
+	var permissionTargets []services.PermissionTargetParams
+	if err = json.Unmarshal(content, &permissionTargets); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", filePath, err.Error())
+	}
+	for _, permissionTarget := range permissionTargets {
+		if permissionTarget.Name == "" {
+			return nil, errorutils.CheckErrorf("%s: a name is mandatory for each permission target", filePath)
+		}
+	}
+	return permissionTargets, nil
+}
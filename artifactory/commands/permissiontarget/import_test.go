@@ -0,0 +1,21 @@
+package permissiontarget
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPermissionTargetsFile(t *testing.T) {
+	permissionTargets, err := ReadPermissionTargetsFile(filepath.Join("testdata", "permissions.yaml"))
+	require.NoError(t, err)
+	require.Len(t, permissionTargets, 2)
+	assert.Equal(t, "dev-readers", permissionTargets[0].Name)
+	assert.Equal(t, []string{"ANY LOCAL"}, permissionTargets[0].Repo.Repositories)
+	assert.Equal(t, []string{"**"}, permissionTargets[0].Repo.IncludePatterns)
+	assert.Equal(t, []string{"read"}, permissionTargets[0].Repo.Actions.Groups["readers"])
+	assert.Nil(t, permissionTargets[0].Build)
+	assert.Equal(t, []string{"read", "write"}, permissionTargets[1].Build.Actions.Users["alice"])
+}
@@ -0,0 +1,88 @@
+package permissiontarget
+
+import (
+	"encoding/json"
+	"net/http"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const permissionTargetsApi = "api/v2/security/permissions"
+
+type PermissionTargetListCommand struct {
+	rtDetails    *config.ServerDetails
+	outputFormat format.OutputFormat
+}
+
+type permissionTargetRow struct {
+	Name string `col-name:"Name" json:"name"`
+	Uri  string `col-name:"URI" json:"uri"`
+}
+
+func NewPermissionTargetListCommand() *PermissionTargetListCommand {
+	return &PermissionTargetListCommand{outputFormat: format.Table}
+}
+
+// SetOutputFormat sets the output format - 'table' or 'json'.
+func (ptlc *PermissionTargetListCommand) SetOutputFormat(outputFormat format.OutputFormat) *PermissionTargetListCommand {
+	ptlc.outputFormat = outputFormat
+	return ptlc
+}
+
+func (ptlc *PermissionTargetListCommand) SetServerDetails(serverDetails *config.ServerDetails) *PermissionTargetListCommand {
+	ptlc.rtDetails = serverDetails
+	return ptlc
+}
+
+func (ptlc *PermissionTargetListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ptlc.rtDetails, nil
+}
+
+func (ptlc *PermissionTargetListCommand) CommandName() string {
+	return "rt_permission_target_list"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (ptlc *PermissionTargetListCommand) IsReadOnly() bool {
+	return true
+}
+
+func (ptlc *PermissionTargetListCommand) Run() error {
+	// Listing the permission targets isn't supported by the services manager, so the REST API is called directly.
+	servicesManager, err := rtUtils.CreateServiceManager(ptlc.rtDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(serviceDetails.GetUrl()+permissionTargetsApi, true, &httpDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return err
+	}
+	rows := []permissionTargetRow{}
+	if err = json.Unmarshal(body, &rows); err != nil {
+		return errorutils.CheckError(err)
+	}
+	switch ptlc.outputFormat {
+	case format.Json:
+		content, err := json.Marshal(rows)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	case format.Table:
+		return coreutils.PrintTable(rows, "Permission Targets", "No permission targets were found", false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s", ptlc.outputFormat, format.Table, format.Json)
+	}
+}
@@ -0,0 +1,417 @@
+package publish
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	dockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+	dockerConfigMediaType   = "application/vnd.docker.container.image.v1+json"
+	dockerLayerMediaType    = "application/vnd.docker.image.rootfs.diff.tar"
+	dockerGzipLayerType     = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
+// A package resolved from the publish spec, ready to be deployed.
+type resolvedPackage struct {
+	spec       PackageSpec
+	moduleId   string
+	moduleType buildinfo.ModuleType
+	files      []packageFile
+}
+
+// A file deployed as part of a package.
+type packageFile struct {
+	localPath string
+	// The target path, including the repository.
+	target string
+}
+
+// Reads the metadata of the package, and determines the files to deploy and their target paths.
+// Files extracted from the package, such as the layers of docker images, are written to tempDir.
+func resolvePackage(packageSpec PackageSpec, tempDir string) (*resolvedPackage, error) {
+	var resolved *resolvedPackage
+	var err error
+	switch packageSpec.Type {
+	case Npm:
+		resolved, err = resolveNpmPackage(packageSpec)
+	case Pypi:
+		resolved, err = resolvePypiPackage(packageSpec)
+	case Maven:
+		resolved, err = resolveMavenPackage(packageSpec, tempDir)
+	case Docker:
+		resolved, err = resolveDockerImage(packageSpec, tempDir)
+	default:
+		resolved = resolveGenericPackage(packageSpec)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resolved.spec = packageSpec
+	if packageSpec.Module != "" {
+		resolved.moduleId = packageSpec.Module
+	}
+	return resolved, nil
+}
+
+// Returns the target of the package's main file: the target set in the spec, or the default path in the repository.
+func getTarget(packageSpec PackageSpec, defaultPath string) string {
+	if packageSpec.Target != "" {
+		return path.Join(packageSpec.Repo, packageSpec.Target)
+	}
+	return path.Join(packageSpec.Repo, defaultPath)
+}
+
+func resolveGenericPackage(packageSpec PackageSpec) *resolvedPackage {
+	fileName := filepath.Base(packageSpec.Path)
+	return &resolvedPackage{
+		moduleId:   fileName,
+		moduleType: buildinfo.Generic,
+		files:      []packageFile{{localPath: packageSpec.Path, target: getTarget(packageSpec, fileName)}},
+	}
+}
+
+// npm packages are deployed according to the layout of the npm registry: <name>/-/<name>-<version>.tgz.
+func resolveNpmPackage(packageSpec PackageSpec) (*resolvedPackage, error) {
+	var packageInfo *biutils.PackageInfo
+	err := readTarFile(packageSpec.Path, true, func(header *tar.Header, reader io.Reader) (bool, error) {
+		if header.Name != "package/package.json" {
+			return false, nil
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return false, errorutils.CheckError(err)
+		}
+		packageInfo, err = biutils.ReadPackageInfo(content, nil)
+		return true, errorutils.CheckError(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if packageInfo == nil || packageInfo.Name == "" || packageInfo.Version == "" {
+		return nil, errorutils.CheckErrorf("couldn't read the name and version of the npm package %s from its package.json", packageSpec.Path)
+	}
+	return &resolvedPackage{
+		moduleId:   packageInfo.BuildInfoModuleId(),
+		moduleType: buildinfo.Npm,
+		files:      []packageFile{{localPath: packageSpec.Path, target: getTarget(packageSpec, packageInfo.GetDeployPath())}},
+	}, nil
+}
+
+// Python wheels and sdists are deployed to <name>/<version>/<file>. The name and version are parsed from the file name,
+// which is <name>-<version>(-<build>)?-<python>-<abi>-<platform>.whl for wheels, and <name>-<version>.tar.gz for sdists.
+func resolvePypiPackage(packageSpec PackageSpec) (*resolvedPackage, error) {
+	fileName := filepath.Base(packageSpec.Path)
+	var name, version string
+	switch {
+	case strings.HasSuffix(fileName, ".whl"):
+		parts := strings.Split(strings.TrimSuffix(fileName, ".whl"), "-")
+		if len(parts) >= 5 {
+			name, version = parts[0], parts[1]
+		}
+	case strings.HasSuffix(fileName, ".tar.gz"), strings.HasSuffix(fileName, ".zip"):
+		baseName := strings.TrimSuffix(strings.TrimSuffix(fileName, ".tar.gz"), ".zip")
+		if index := strings.LastIndex(baseName, "-"); index > 0 {
+			name, version = baseName[:index], baseName[index+1:]
+		}
+	}
+	if name == "" || version == "" {
+		return nil, errorutils.CheckErrorf("couldn't parse the name and version of the Python package from its file name: %s", fileName)
+	}
+	return &resolvedPackage{
+		moduleId:   name + ":" + version,
+		moduleType: buildinfo.Python,
+		files:      []packageFile{{localPath: packageSpec.Path, target: getTarget(packageSpec, path.Join(name, version, fileName))}},
+	}, nil
+}
+
+// Jars are deployed according to the Maven layout, using the coordinates in the pom.properties file which Maven adds to the jar.
+// The pom.xml from the jar is deployed next to it.
+func resolveMavenPackage(packageSpec PackageSpec, tempDir string) (resolved *resolvedPackage, err error) {
+	zipReader, err := zip.OpenReader(packageSpec.Path)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to open the jar %s: %s", packageSpec.Path, err.Error())
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(zipReader.Close()))
+	}()
+	var propertiesFile, pomFile *zip.File
+	for _, file := range zipReader.File {
+		if matched, _ := path.Match("META-INF/maven/*/*/pom.properties", file.Name); matched {
+			if propertiesFile != nil {
+				return nil, errorutils.CheckErrorf("the jar %s contains the pom.properties of more than one Maven artifact", packageSpec.Path)
+			}
+			propertiesFile = file
+		}
+	}
+	if propertiesFile == nil {
+		if packageSpec.Target == "" {
+			return nil, errorutils.CheckErrorf("the jar %s doesn't contain a pom.properties file. Set the target of the package in the spec", packageSpec.Path)
+		}
+		return &resolvedPackage{moduleId: filepath.Base(packageSpec.Path), moduleType: buildinfo.Maven,
+			files: []packageFile{{localPath: packageSpec.Path, target: getTarget(packageSpec, "")}}}, nil
+	}
+	coordinates, err := readPomProperties(propertiesFile)
+	if err != nil {
+		return nil, err
+	}
+	groupId, artifactId, version := coordinates["groupId"], coordinates["artifactId"], coordinates["version"]
+	if groupId == "" || artifactId == "" || version == "" {
+		return nil, errorutils.CheckErrorf("the pom.properties file in the jar %s doesn't contain the groupId, artifactId and version", packageSpec.Path)
+	}
+	artifactDir := path.Join(strings.ReplaceAll(groupId, ".", "/"), artifactId, version)
+	baseName := artifactId + "-" + version
+	resolved = &resolvedPackage{
+		moduleId:   strings.Join([]string{groupId, artifactId, version}, ":"),
+		moduleType: buildinfo.Maven,
+		files:      []packageFile{{localPath: packageSpec.Path, target: getTarget(packageSpec, path.Join(artifactDir, baseName+".jar"))}},
+	}
+	pomPath := path.Join(path.Dir(propertiesFile.Name), "pom.xml")
+	for _, file := range zipReader.File {
+		if file.Name == pomPath {
+			pomFile = file
+		}
+	}
+	if pomFile != nil {
+		localPomPath := filepath.Join(tempDir, baseName+".pom")
+		if err = extractZipFile(pomFile, localPomPath); err != nil {
+			return nil, err
+		}
+		targetDir := path.Dir(resolved.files[0].target)
+		resolved.files = append(resolved.files, packageFile{localPath: localPomPath, target: path.Join(targetDir, baseName+".pom")})
+	}
+	return resolved, nil
+}
+
+func readPomProperties(file *zip.File) (map[string]string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	properties := map[string]string{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, found := strings.Cut(line, "="); found {
+			properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return properties, errorutils.CheckError(scanner.Err())
+}
+
+func extractZipFile(file *zip.File, targetPath string) (err error) {
+	reader, err := file.Open()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	return writeFile(targetPath, reader)
+}
+
+func writeFile(targetPath string, reader io.Reader) (err error) {
+	file, err := os.Create(targetPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	_, err = io.Copy(file, reader)
+	return errorutils.CheckError(err)
+}
+
+// Reads the entries of a tar archive. The handler returns true to stop reading.
+func readTarFile(tarPath string, gzipped bool, handler func(header *tar.Header, reader io.Reader) (bool, error)) (err error) {
+	file, err := os.Open(tarPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	var reader io.Reader = file
+	if gzipped {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return errorutils.CheckErrorf("failed to read %s: %s", tarPath, err.Error())
+		}
+		reader = gzipReader
+	}
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errorutils.CheckErrorf("failed to read %s: %s", tarPath, err.Error())
+		}
+		done, err := handler(header, tarReader)
+		if done || err != nil {
+			return err
+		}
+	}
+}
+
+// The manifest.json of an archive created by 'docker save'.
+type dockerArchiveManifest struct {
+	Config   string   `json:"Config"`
+	RepoTags []string `json:"RepoTags"`
+	Layers   []string `json:"Layers"`
+}
+
+type dockerManifest struct {
+	SchemaVersion int                   `json:"schemaVersion"`
+	MediaType     string                `json:"mediaType"`
+	Config        dockerManifestLayer   `json:"config"`
+	Layers        []dockerManifestLayer `json:"layers"`
+}
+
+type dockerManifestLayer struct {
+	MediaType string `json:"mediaType"`
+	Size      int64  `json:"size"`
+	Digest    string `json:"digest"`
+}
+
+// Docker images are deployed as Artifactory stores them in docker repositories: the config and the layers are deployed to
+// <image>/<tag>/sha256__<digest>, next to a manifest.json, which is created from the archive.
+func resolveDockerImage(packageSpec PackageSpec, tempDir string) (*resolvedPackage, error) {
+	var archiveManifests []dockerArchiveManifest
+	// The archive entries, extracted to the temp dir by their names in the archive.
+	extracted := map[string]string{}
+	err := readTarFile(packageSpec.Path, false, func(header *tar.Header, reader io.Reader) (bool, error) {
+		if header.Typeflag != tar.TypeReg {
+			return false, nil
+		}
+		if header.Name == "manifest.json" {
+			content, err := io.ReadAll(reader)
+			if err != nil {
+				return false, errorutils.CheckError(err)
+			}
+			return false, errorutils.CheckError(json.Unmarshal(content, &archiveManifests))
+		}
+		localPath := filepath.Join(tempDir, fmt.Sprintf("entry-%d", len(extracted)))
+		extracted[header.Name] = localPath
+		return false, writeFile(localPath, reader)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(archiveManifests) != 1 {
+		return nil, errorutils.CheckErrorf("the docker archive %s must contain exactly one image, but it contains %d", packageSpec.Path, len(archiveManifests))
+	}
+	archiveManifest := archiveManifests[0]
+	image := packageSpec.Image
+	if image == "" && len(archiveManifest.RepoTags) > 0 {
+		image = archiveManifest.RepoTags[0]
+	}
+	imageName, tag := parseImage(image)
+	if imageName == "" {
+		return nil, errorutils.CheckErrorf("the docker archive %s doesn't contain a tagged image. Set the image in the spec", packageSpec.Path)
+	}
+	imagePath := path.Join(packageSpec.Repo, imageName, tag)
+	resolved := &resolvedPackage{moduleId: imageName + ":" + tag, moduleType: buildinfo.Docker}
+	manifest := dockerManifest{SchemaVersion: 2, MediaType: dockerManifestMediaType}
+	addBlob := func(name string) (*dockerManifestLayer, error) {
+		localPath, exists := extracted[name]
+		if !exists {
+			return nil, errorutils.CheckErrorf("the docker archive %s doesn't contain %s", packageSpec.Path, name)
+		}
+		blob, err := describeBlob(localPath)
+		if err != nil {
+			return nil, err
+		}
+		resolved.files = append(resolved.files, packageFile{localPath: localPath, target: path.Join(imagePath, strings.Replace(blob.Digest, ":", "__", 1))})
+		return blob, nil
+	}
+	config, err := addBlob(archiveManifest.Config)
+	if err != nil {
+		return nil, err
+	}
+	config.MediaType = dockerConfigMediaType
+	manifest.Config = *config
+	for _, layerName := range archiveManifest.Layers {
+		layer, err := addBlob(layerName)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Layers = append(manifest.Layers, *layer)
+	}
+	manifestContent, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	manifestPath := filepath.Join(tempDir, "manifest.json")
+	if err = os.WriteFile(manifestPath, manifestContent, 0600); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	resolved.files = append(resolved.files, packageFile{localPath: manifestPath, target: path.Join(imagePath, "manifest.json")})
+	return resolved, nil
+}
+
+// Returns the digest and size of the blob, and the media type of a layer, according to whether it's compressed.
+func describeBlob(localPath string) (blob *dockerManifestLayer, err error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	header := make([]byte, 2)
+	n, _ := io.ReadFull(file, header)
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	mediaType := dockerLayerMediaType
+	if n == 2 && bytes.Equal(header, []byte{0x1f, 0x8b}) {
+		mediaType = dockerGzipLayerType
+	}
+	return &dockerManifestLayer{MediaType: mediaType, Size: size, Digest: "sha256:" + hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+// Splits an image reference into its name and tag. The tag defaults to 'latest'.
+// The registry host isn't part of the image path in the repository, and is removed.
+func parseImage(image string) (name, tag string) {
+	if image == "" {
+		return "", ""
+	}
+	if host, rest, found := strings.Cut(image, "/"); found && (strings.ContainsAny(host, ".:") || host == "localhost") {
+		image = rest
+	}
+	index := strings.LastIndex(image, ":")
+	if index <= strings.LastIndex(image, "/") {
+		return image, "latest"
+	}
+	return image[:index], image[index+1:]
+}
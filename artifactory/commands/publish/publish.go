@@ -0,0 +1,182 @@
+package publish
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// PublishCommand publishes a set of packages of different types, described by a publish spec, to their repositories.
+// All the packages are added to the same build-info, each as a module of its package type, for polyglot release pipelines.
+type PublishCommand struct {
+	serverDetails      *config.ServerDetails
+	specPath           string
+	buildConfiguration *build.BuildConfiguration
+	dryRun             bool
+	// If true, the vcs.revision, vcs.branch and vcs.url properties of the packages' git repositories are set on the published files.
+	addVcsProps bool
+	result      *commandsutils.Result
+}
+
+func NewPublishCommand() *PublishCommand {
+	return &PublishCommand{result: new(commandsutils.Result)}
+}
+
+func (pc *PublishCommand) SetServerDetails(serverDetails *config.ServerDetails) *PublishCommand {
+	pc.serverDetails = serverDetails
+	return pc
+}
+
+func (pc *PublishCommand) SetSpecPath(specPath string) *PublishCommand {
+	pc.specPath = specPath
+	return pc
+}
+
+func (pc *PublishCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *PublishCommand {
+	pc.buildConfiguration = buildConfiguration
+	return pc
+}
+
+func (pc *PublishCommand) SetAddVcsProps(addVcsProps bool) *PublishCommand {
+	pc.addVcsProps = addVcsProps
+	return pc
+}
+
+// EnableDryRun implements commands.DryRunCommand.
+func (pc *PublishCommand) EnableDryRun() {
+	pc.dryRun = true
+}
+
+func (pc *PublishCommand) Result() *commandsutils.Result {
+	return pc.result
+}
+
+func (pc *PublishCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pc.serverDetails, nil
+}
+
+func (pc *PublishCommand) CommandName() string {
+	return "rt_publish"
+}
+
+func (pc *PublishCommand) Run() (err error) {
+	publishSpec, err := LoadPublishSpec(pc.specPath)
+	if err != nil {
+		return err
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if removeErr := fileutils.RemoveTempDir(tempDir); err == nil {
+			err = removeErr
+		}
+	}()
+	// All the packages are resolved before deploying any of them, to avoid publishing only part of the release.
+	var packages []*resolvedPackage
+	for i, packageSpec := range publishSpec.Packages {
+		packageTempDir := filepath.Join(tempDir, strconv.Itoa(i))
+		if err = os.Mkdir(packageTempDir, 0700); err != nil {
+			return errorutils.CheckError(err)
+		}
+		resolved, err := resolvePackage(packageSpec, packageTempDir)
+		if err != nil {
+			return err
+		}
+		packages = append(packages, resolved)
+	}
+
+	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, -1, 0, pc.dryRun)
+	if err != nil {
+		return err
+	}
+	toCollect, err := pc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	buildProps := ""
+	if toCollect && !pc.dryRun {
+		if buildProps, err = build.CreateBuildPropsFromConfiguration(pc.buildConfiguration); err != nil {
+			return err
+		}
+	}
+	failedPackages := 0
+	for _, resolved := range packages {
+		if err = pc.publishPackage(servicesManager, resolved, buildProps, toCollect && !pc.dryRun); err != nil {
+			log.Error(fmt.Sprintf("Failed publishing the %s package %s: %s", resolved.spec.Type, resolved.spec.Path, err.Error()))
+			failedPackages++
+		}
+	}
+	if failedPackages > 0 {
+		return errorutils.CheckErrorf("failed publishing %d out of %d packages. Review the logs for more information", failedPackages, len(packages))
+	}
+	log.Info(fmt.Sprintf("Published %d packages.", len(packages)))
+	return nil
+}
+
+// Deploys the files of the package, and adds them to the build-info as a module of the package type.
+func (pc *PublishCommand) publishPackage(servicesManager artifactory.ArtifactoryServicesManager, resolved *resolvedPackage, buildProps string, collectBuildInfo bool) (err error) {
+	log.Info(fmt.Sprintf("Publishing the %s package %s to %s...", resolved.spec.Type, resolved.moduleId, resolved.spec.Repo))
+	targetProps, err := servicesutils.ParseProperties(resolved.spec.Props)
+	if err != nil {
+		return err
+	}
+	var uploadParams []services.UploadParams
+	for _, file := range resolved.files {
+		params := services.NewUploadParams()
+		params.CommonParams = &servicesutils.CommonParams{Pattern: file.localPath, Target: file.target, TargetProps: targetProps}
+		params.BuildProps = buildProps
+		params.AddVcsProps = pc.addVcsProps
+		params.Flat = true
+		uploadParams = append(uploadParams, params)
+	}
+	summary, err := servicesManager.UploadFilesWithSummary(uploadParams...)
+	if err != nil {
+		return err
+	}
+	defer ioutils.Close(summary, &err)
+	pc.result.SetSuccessCount(pc.result.SuccessCount() + summary.TotalSucceeded)
+	pc.result.SetFailCount(pc.result.FailCount() + summary.TotalFailed)
+	if summary.TotalFailed > 0 {
+		return errorutils.CheckErrorf("%d out of %d files failed to upload", summary.TotalFailed, len(resolved.files))
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	artifacts, err := servicesutils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+	if err != nil {
+		return err
+	}
+	return pc.saveModule(resolved, artifacts)
+}
+
+func (pc *PublishCommand) saveModule(resolved *resolvedPackage, artifacts []buildinfo.Artifact) error {
+	buildName, err := pc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := pc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	return build.SavePartialBuildInfo(buildName, buildNumber, pc.buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+		partial.Artifacts = artifacts
+		partial.ModuleId = resolved.moduleId
+		partial.ModuleType = resolved.moduleType
+	})
+}
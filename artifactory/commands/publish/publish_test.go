@@ -0,0 +1,191 @@
+package publish
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Creates a tar archive with the provided entries, optionally compressed with gzip.
+func createTar(t *testing.T, tarPath string, gzipped bool, entries map[string]string) {
+	buffer := new(bytes.Buffer)
+	var writer io.Writer = buffer
+	var gzipWriter *gzip.Writer
+	if gzipped {
+		gzipWriter = gzip.NewWriter(buffer)
+		writer = gzipWriter
+	}
+	tarWriter := tar.NewWriter(writer)
+	for name, content := range entries {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	if gzipWriter != nil {
+		require.NoError(t, gzipWriter.Close())
+	}
+	require.NoError(t, os.WriteFile(tarPath, buffer.Bytes(), 0600))
+}
+
+func createJar(t *testing.T, jarPath string, entries map[string]string) {
+	buffer := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buffer)
+	for name, content := range entries {
+		writer, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, os.WriteFile(jarPath, buffer.Bytes(), 0600))
+}
+
+func createPackages(t *testing.T) (dir string) {
+	dir = t.TempDir()
+	createTar(t, filepath.Join(dir, "app.tgz"), true, map[string]string{"package/package.json": `{"name":"@acme/app","version":"1.0.0"}`})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "my_lib-2.1.0-py3-none-any.whl"), []byte("wheel"), 0600))
+	createJar(t, filepath.Join(dir, "service.jar"), map[string]string{
+		"META-INF/maven/com.acme/service/pom.properties": "#Generated by Maven\ngroupId=com.acme\nartifactId=service\nversion=3.0.0\n",
+		"META-INF/maven/com.acme/service/pom.xml":        "<project/>",
+	})
+	createTar(t, filepath.Join(dir, "image.tar"), false, map[string]string{
+		"manifest.json":     `[{"Config":"config.json","RepoTags":["registry.acme.io/team/web:4.0"],"Layers":["layer1/layer.tar"]}]`,
+		"config.json":       `{"architecture":"amd64"}`,
+		"layer1/layer.tar":  "layer",
+		"layer1/VERSION":    "1.0",
+		"repositories":      "{}",
+		"unrelated/ignored": "",
+	})
+	return
+}
+
+func TestResolvePackages(t *testing.T) {
+	dir := createPackages(t)
+	npmPackage, err := resolvePackage(PackageSpec{Type: Npm, Path: filepath.Join(dir, "app.tgz"), Repo: "npm-local"}, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "acme:app:1.0.0", npmPackage.moduleId)
+	assert.Equal(t, "npm-local/@acme/app/-/app-1.0.0.tgz", npmPackage.files[0].target)
+
+	pypiPackage, err := resolvePackage(PackageSpec{Type: Pypi, Path: filepath.Join(dir, "my_lib-2.1.0-py3-none-any.whl"), Repo: "pypi-local", Module: "custom"}, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "custom", pypiPackage.moduleId)
+	assert.Equal(t, "pypi-local/my_lib/2.1.0/my_lib-2.1.0-py3-none-any.whl", pypiPackage.files[0].target)
+
+	mavenPackage, err := resolvePackage(PackageSpec{Type: Maven, Path: filepath.Join(dir, "service.jar"), Repo: "libs-local"}, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "com.acme:service:3.0.0", mavenPackage.moduleId)
+	require.Len(t, mavenPackage.files, 2)
+	assert.Equal(t, "libs-local/com/acme/service/3.0.0/service-3.0.0.jar", mavenPackage.files[0].target)
+	assert.Equal(t, "libs-local/com/acme/service/3.0.0/service-3.0.0.pom", mavenPackage.files[1].target)
+	pomContent, err := os.ReadFile(mavenPackage.files[1].localPath)
+	require.NoError(t, err)
+	assert.Equal(t, "<project/>", string(pomContent))
+
+	dockerImage, err := resolvePackage(PackageSpec{Type: Docker, Path: filepath.Join(dir, "image.tar"), Repo: "docker-local"}, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "team/web:4.0", dockerImage.moduleId)
+	require.Len(t, dockerImage.files, 3)
+	assert.Equal(t, "docker-local/team/web/4.0/manifest.json", dockerImage.files[2].target)
+	manifestContent, err := os.ReadFile(dockerImage.files[2].localPath)
+	require.NoError(t, err)
+	var manifest dockerManifest
+	require.NoError(t, json.Unmarshal(manifestContent, &manifest))
+	assert.Equal(t, dockerConfigMediaType, manifest.Config.MediaType)
+	require.Len(t, manifest.Layers, 1)
+	assert.Equal(t, dockerLayerMediaType, manifest.Layers[0].MediaType)
+	assert.Equal(t, int64(len("layer")), manifest.Layers[0].Size)
+	assert.Equal(t, "docker-local/team/web/4.0/"+strings.Replace(manifest.Layers[0].Digest, ":", "__", 1), dockerImage.files[1].target)
+	assert.Equal(t, "docker-local/team/web/4.0/"+strings.Replace(manifest.Config.Digest, ":", "__", 1), dockerImage.files[0].target)
+
+	_, err = resolvePackage(PackageSpec{Type: Pypi, Path: filepath.Join(dir, "app.tgz"), Repo: "pypi-local"}, t.TempDir())
+	assert.ErrorContains(t, err, "couldn't parse the name and version of the Python package")
+}
+
+func TestParseImage(t *testing.T) {
+	for image, expected := range map[string][2]string{
+		"web":                       {"web", "latest"},
+		"team/web:1.0":              {"team/web", "1.0"},
+		"localhost:8082/web":        {"web", "latest"},
+		"registry.acme.io/a/web:v2": {"a/web", "v2"},
+	} {
+		name, tag := parseImage(image)
+		assert.Equal(t, expected, [2]string{name, tag}, image)
+	}
+}
+
+func TestLoadPublishSpec(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "publish.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte("packages:\n  - type: rpm\n    path: a.rpm\n    repo: rpm-local\n"), 0600))
+	_, err := LoadPublishSpec(specPath)
+	assert.ErrorContains(t, err, "package 1 in the publish spec")
+	assert.ErrorContains(t, err, "unsupported package type 'rpm'")
+
+	require.NoError(t, os.WriteFile(specPath, []byte("packages:\n  - type: npm\n    path: a.tgz\n"), 0600))
+	_, err = LoadPublishSpec(specPath)
+	assert.ErrorContains(t, err, "both 'path' and 'repo' are mandatory")
+}
+
+func TestPublish(t *testing.T) {
+	dir := createPackages(t)
+	spec := `packages:
+  - type: npm
+    path: ` + filepath.Join(dir, "app.tgz") + `
+    repo: npm-local
+    props: team=web
+  - type: pypi
+    path: ` + filepath.Join(dir, "my_lib-2.1.0-py3-none-any.whl") + `
+    repo: pypi-local
+  - type: maven
+    path: ` + filepath.Join(dir, "service.jar") + `
+    repo: libs-local
+  - type: docker
+    path: ` + filepath.Join(dir, "image.tar") + `
+    repo: docker-local
+    image: web:5.0
+`
+	specPath := filepath.Join(dir, "publish.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(spec), 0600))
+
+	var mutex sync.Mutex
+	deployed := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		deployed[strings.Split(r.URL.Path, ";")[0]] = r.URL.Path
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	publishCmd := NewPublishCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetSpecPath(specPath).SetBuildConfiguration(build.NewBuildConfiguration("", "", "", ""))
+	require.NoError(t, publishCmd.Run())
+	assert.Equal(t, 7, publishCmd.Result().SuccessCount())
+	assert.Equal(t, 0, publishCmd.Result().FailCount())
+	assert.Contains(t, deployed, "/npm-local/@acme/app/-/app-1.0.0.tgz")
+	assert.Contains(t, deployed["/npm-local/@acme/app/-/app-1.0.0.tgz"], ";team=web")
+	assert.Contains(t, deployed, "/pypi-local/my_lib/2.1.0/my_lib-2.1.0-py3-none-any.whl")
+	assert.Contains(t, deployed, "/libs-local/com/acme/service/3.0.0/service-3.0.0.jar")
+	assert.Contains(t, deployed, "/libs-local/com/acme/service/3.0.0/service-3.0.0.pom")
+	assert.Contains(t, deployed, "/docker-local/web/5.0/manifest.json")
+	assert.Len(t, deployed, 7)
+}
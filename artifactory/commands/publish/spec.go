@@ -0,0 +1,95 @@
+package publish
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v3"
+)
+
+type PackageType string
+
+const (
+	Npm     PackageType = "npm"
+	Pypi    PackageType = "pypi"
+	Maven   PackageType = "maven"
+	Docker  PackageType = "docker"
+	Generic PackageType = "generic"
+)
+
+var packageTypes = []PackageType{Npm, Pypi, Maven, Docker, Generic}
+
+// PublishSpec describes a set of packages of different types, published together with one build-info. For example:
+//
+//	packages:
+//	  - type: npm
+//	    path: frontend/my-app-1.0.0.tgz
+//	    repo: npm-local
+//	  - type: pypi
+//	    path: dist/my_lib-1.0.0-py3-none-any.whl
+//	    repo: pypi-local
+//	  - type: maven
+//	    path: backend/target/my-service-1.0.0.jar
+//	    repo: libs-release-local
+//	  - type: docker
+//	    path: my-image.tar
+//	    repo: docker-local
+//	    image: my-image:1.0.0
+type PublishSpec struct {
+	Packages []PackageSpec `yaml:"packages"`
+}
+
+type PackageSpec struct {
+	Type PackageType `yaml:"type"`
+	// The path to the local package file: an npm tarball, a Python wheel or sdist, a jar, an archive created by 'docker save', or any file for the generic type.
+	Path string `yaml:"path"`
+	Repo string `yaml:"repo"`
+	// The target path in the repository. If empty, the path is determined by the layout of the package type.
+	// Docker images are always deployed according to their name and tag.
+	Target string `yaml:"target,omitempty"`
+	// The build-info module ID of the package. If empty, the ID is determined by the package's metadata.
+	Module string `yaml:"module,omitempty"`
+	// Properties to set on the deployed files, in the format of key1=value1;key2=value2.
+	Props string `yaml:"props,omitempty"`
+	// The name and tag of a docker image. If empty, the first tag of the image in the archive is used.
+	Image string `yaml:"image,omitempty"`
+}
+
+// LoadPublishSpec reads and validates the publish spec from the provided YAML file.
+func LoadPublishSpec(specPath string) (*PublishSpec, error) {
+	content, err := fileutils.ReadFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+	publishSpec := &PublishSpec{}
+	if err = yaml.Unmarshal(content, publishSpec); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the publish spec %s: %s", specPath, err.Error())
+	}
+	if len(publishSpec.Packages) == 0 {
+		return nil, errorutils.CheckErrorf("the publish spec %s doesn't contain any packages", specPath)
+	}
+	for i, packageSpec := range publishSpec.Packages {
+		if err = packageSpec.validate(); err != nil {
+			return nil, errorutils.CheckErrorf("package %d in the publish spec %s is invalid: %s", i+1, specPath, err.Error())
+		}
+	}
+	return publishSpec, nil
+}
+
+func (ps *PackageSpec) validate() error {
+	if ps.Path == "" || ps.Repo == "" {
+		return fmt.Errorf("both 'path' and 'repo' are mandatory")
+	}
+	for _, packageType := range packageTypes {
+		if ps.Type == packageType {
+			return nil
+		}
+	}
+	supportedTypes := make([]string, 0, len(packageTypes))
+	for _, packageType := range packageTypes {
+		supportedTypes = append(supportedTypes, string(packageType))
+	}
+	return fmt.Errorf("unsupported package type '%s'. Possible values are: %s", ps.Type, strings.Join(supportedTypes, ", "))
+}
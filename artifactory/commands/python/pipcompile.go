@@ -0,0 +1,226 @@
+package python
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/utils/pythonutils"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	defaultRequirementsInFile  = "requirements.in"
+	defaultRequirementsTxtFile = "requirements.txt"
+)
+
+var (
+	// Matches the links of the distribution files in a PEP 503 simple repository page, with their SHA-256 hashes.
+	simpleIndexLinkRegexp = regexp.MustCompile(`<a [^>]*href="[^"#]*#sha256=([0-9a-fA-F]{64})"[^>]*>([^<]+)</a>`)
+	nameSeparatorsRegexp  = regexp.MustCompile(`[-_.]+`)
+)
+
+// PipCompileCommand resolves the requirements in a requirements.in file to a fully pinned requirements.txt file with hashes.
+// The requirements are resolved by pip through the Artifactory PyPI repository, which is the same resolution path of 'jf pip install'.
+type PipCompileCommand struct {
+	PythonCommand
+	requirementsInFile string
+	outputFile         string
+}
+
+func NewPipCompileCommand() *PipCompileCommand {
+	return &PipCompileCommand{PythonCommand: *NewPythonCommand(pythonutils.Pip), requirementsInFile: defaultRequirementsInFile, outputFile: defaultRequirementsTxtFile}
+}
+
+func (pcc *PipCompileCommand) CommandName() string {
+	return "rt_pip_compile"
+}
+
+func (pcc *PipCompileCommand) SetRequirementsInFile(requirementsInFile string) *PipCompileCommand {
+	pcc.requirementsInFile = requirementsInFile
+	return pcc
+}
+
+func (pcc *PipCompileCommand) SetOutputFile(outputFile string) *PipCompileCommand {
+	pcc.outputFile = outputFile
+	return pcc
+}
+
+func (pcc *PipCompileCommand) Run() (err error) {
+	log.Info(fmt.Sprintf("Resolving the requirements in %s through the '%s' repository...", pcc.requirementsInFile, pcc.repository))
+	tempDirPath, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDirPath))
+	}()
+	reportPath := filepath.Join(tempDirPath, "report.json")
+	// Resolve the requirements without installing them. The report lists all the packages which would be installed.
+	pcc.commandName = "install"
+	pcc.args = append([]string{"--dry-run", "--ignore-installed", "--quiet", "--report", reportPath, "-r", pcc.requirementsInFile}, pcc.args...)
+	if err = pcc.SetPypiRepoUrlWithCredentials(); err != nil {
+		return err
+	}
+	if err = pcc.setTlsEnv(); err != nil {
+		return err
+	}
+	if err = gofrogcmd.RunCmd(pcc); err != nil {
+		return errorutils.CheckErrorf("failed resolving the requirements. Note that pip 22.2 or above is required: %s", err.Error())
+	}
+	packages, err := readPipInstallReport(reportPath)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(pcc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	for _, pkg := range packages {
+		if err = pcc.setHashes(servicesManager, pkg); err != nil {
+			return err
+		}
+	}
+	if err = os.WriteFile(pcc.outputFile, []byte(formatRequirements(packages, pcc.requirementsInFile)), 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info(fmt.Sprintf("Pinned %d packages in %s.", len(packages), pcc.outputFile))
+	return nil
+}
+
+type pipInstallReport struct {
+	Install []struct {
+		DownloadInfo struct {
+			Url         string `json:"url"`
+			ArchiveInfo *struct {
+				Hashes map[string]string `json:"hashes"`
+			} `json:"archive_info"`
+		} `json:"download_info"`
+		IsDirect bool `json:"is_direct"`
+		Metadata struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"metadata"`
+	} `json:"install"`
+}
+
+type pinnedPackage struct {
+	name    string
+	version string
+	// The SHA-256 hashes of the distribution files of the version.
+	hashes []string
+}
+
+func readPipInstallReport(reportPath string) ([]*pinnedPackage, error) {
+	content, err := os.ReadFile(reportPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var report pipInstallReport
+	if err = json.Unmarshal(content, &report); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the pip installation report: %s", err.Error())
+	}
+	var packages []*pinnedPackage
+	for _, installed := range report.Install {
+		if installed.IsDirect {
+			return nil, errorutils.CheckErrorf("the direct reference to %s (%s) can't be pinned with hashes. Use a requirement of a package from the repository instead", installed.Metadata.Name, installed.DownloadInfo.Url)
+		}
+		pkg := &pinnedPackage{name: installed.Metadata.Name, version: installed.Metadata.Version}
+		if installed.DownloadInfo.ArchiveInfo != nil && installed.DownloadInfo.ArchiveInfo.Hashes["sha256"] != "" {
+			pkg.hashes = []string{installed.DownloadInfo.ArchiveInfo.Hashes["sha256"]}
+		}
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return canonicalizeName(packages[i].name) < canonicalizeName(packages[j].name)
+	})
+	return packages, nil
+}
+
+// Sets the hashes of all the distribution files of the package version in the repository, so that the requirements can be installed
+// on other platforms as well. If the simple index of the package can't be read, the hash of the file resolved by pip is kept.
+func (pcc *PipCompileCommand) setHashes(servicesManager artifactory.ArtifactoryServicesManager, pkg *pinnedPackage) error {
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	indexUrl := servicesManager.GetConfig().GetServiceDetails().GetUrl() + "api/pypi/" + pcc.repository + "/simple/" + canonicalizeName(pkg.name) + "/"
+	resp, body, _, err := servicesManager.Client().SendGet(indexUrl, true, &httpDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Warn(fmt.Sprintf("Failed reading the simple index of %s (status %d). Only the hash of the resolved file is added.", pkg.name, resp.StatusCode))
+		return nil
+	}
+	if hashes := getVersionHashes(string(body), pkg.name, pkg.version); len(hashes) > 0 {
+		pkg.hashes = hashes
+	}
+	if len(pkg.hashes) == 0 {
+		return errorutils.CheckErrorf("no SHA-256 hashes were found for %s==%s", pkg.name, pkg.version)
+	}
+	return nil
+}
+
+// Returns the SHA-256 hashes of the distribution files of the version, listed in the simple index page of the package.
+func getVersionHashes(simpleIndexPage, name, version string) []string {
+	var hashes []string
+	for _, match := range simpleIndexLinkRegexp.FindAllStringSubmatch(simpleIndexPage, -1) {
+		fileName := html.UnescapeString(strings.TrimSpace(match[2]))
+		fileNameProject, fileVersion := parseDistributionFileName(fileName)
+		if canonicalizeName(fileNameProject) == canonicalizeName(name) && fileVersion == version {
+			hashes = append(hashes, strings.ToLower(match[1]))
+		}
+	}
+	sort.Strings(hashes)
+	return hashes
+}
+
+// Returns the project name and version of a wheel or source distribution file name.
+func parseDistributionFileName(fileName string) (name, version string) {
+	if strings.HasSuffix(fileName, ".whl") {
+		parts := strings.Split(fileName, "-")
+		if len(parts) < 5 {
+			return "", ""
+		}
+		return parts[0], parts[1]
+	}
+	for _, extension := range []string{".tar.gz", ".zip", ".tar.bz2", ".tgz"} {
+		if baseName, found := strings.CutSuffix(fileName, extension); found {
+			separatorIndex := strings.LastIndex(baseName, "-")
+			if separatorIndex < 0 {
+				return "", ""
+			}
+			return baseName[:separatorIndex], baseName[separatorIndex+1:]
+		}
+	}
+	return "", ""
+}
+
+// Normalizes a project name as defined in PEP 503.
+func canonicalizeName(name string) string {
+	return nameSeparatorsRegexp.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+// Formats the pinned packages in the requirements file format, with the hashes required by pip's hash-checking mode.
+func formatRequirements(packages []*pinnedPackage, requirementsInFile string) string {
+	var builder strings.Builder
+	builder.WriteString("#\n# This file is autogenerated by 'jf pip compile' from " + filepath.Base(requirementsInFile) + "\n#\n")
+	for _, pkg := range packages {
+		builder.WriteString(canonicalizeName(pkg.name) + "==" + pkg.version)
+		for _, hash := range pkg.hashes {
+			builder.WriteString(" \\\n    --hash=sha256:" + hash)
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,91 @@
+package python
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPipInstallReport = `{
+  "version": "1",
+  "install": [
+    {"download_info": {"url": "https://rt/api/pypi/pypi/packages/requests-2.31.0-py3-none-any.whl", "archive_info": {"hashes": {"sha256": "%[1]s"}}}, "is_direct": false, "requested": true, "metadata": {"name": "requests", "version": "2.31.0"}},
+    {"download_info": {"url": "https://rt/api/pypi/pypi/packages/charset_normalizer-3.3.2-py3-none-any.whl", "archive_info": {"hashes": {"sha256": "%[2]s"}}}, "is_direct": false, "requested": false, "metadata": {"name": "charset-normalizer", "version": "3.3.2"}}
+  ]
+}`
+
+func TestPipCompileHashes(t *testing.T) {
+	requestsWheelHash, requestsSdistHash, charsetHash := strings.Repeat("a", 64), strings.Repeat("b", 64), strings.Repeat("c", 64)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/pypi/pypi-remote/simple/requests/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := fmt.Fprintf(w, `<html><body>
+<a href="../../packages/requests-2.30.0-py3-none-any.whl#sha256=%[3]s">requests-2.30.0-py3-none-any.whl</a>
+<a href="../../packages/requests-2.31.0-py3-none-any.whl#sha256=%[1]s" data-requires-python="&gt;=3.7">requests-2.31.0-py3-none-any.whl</a>
+<a href="../../packages/requests-2.31.0.tar.gz#sha256=%[2]s">requests-2.31.0.tar.gz</a>
+</body></html>`, requestsWheelHash, requestsSdistHash, charsetHash)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, os.WriteFile(reportPath, []byte(fmt.Sprintf(testPipInstallReport, requestsWheelHash, charsetHash)), 0644))
+	packages, err := readPipInstallReport(reportPath)
+	require.NoError(t, err)
+	require.Len(t, packages, 2)
+
+	pipCompileCmd := NewPipCompileCommand()
+	pipCompileCmd.SetRepo("pypi-remote").SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"})
+	servicesManager, err := utils.CreateServiceManager(pipCompileCmd.serverDetails, -1, 0, false)
+	require.NoError(t, err)
+	for _, pkg := range packages {
+		assert.NoError(t, pipCompileCmd.setHashes(servicesManager, pkg))
+	}
+	// The hashes of all the files of the version are listed. If the index can't be read, the hash of the resolved file is kept.
+	assert.Equal(t, `#
+# This file is autogenerated by 'jf pip compile' from requirements.in
+#
+charset-normalizer==3.3.2 \
+    --hash=sha256:`+charsetHash+`
+requests==2.31.0 \
+    --hash=sha256:`+requestsWheelHash+` \
+    --hash=sha256:`+requestsSdistHash+`
+`, formatRequirements(packages, "requirements.in"))
+}
+
+func TestReadPipInstallReportDirectReference(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, os.WriteFile(reportPath, []byte(`{"install":[{"download_info":{"url":"file:///src/my-lib"},"is_direct":true,"metadata":{"name":"my-lib","version":"1.0"}}]}`), 0644))
+	_, err := readPipInstallReport(reportPath)
+	assert.ErrorContains(t, err, "the direct reference to my-lib (file:///src/my-lib) can't be pinned with hashes")
+}
+
+func TestParseDistributionFileName(t *testing.T) {
+	testCases := []struct {
+		fileName        string
+		expectedName    string
+		expectedVersion string
+	}{
+		{"charset_normalizer-3.3.2-cp311-cp311-manylinux_2_17_x86_64.whl", "charset_normalizer", "3.3.2"},
+		{"python-dateutil-2.8.2.tar.gz", "python-dateutil", "2.8.2"},
+		{"PyYAML-6.0.1.zip", "PyYAML", "6.0.1"},
+		{"README.md", "", ""},
+	}
+	for _, testCase := range testCases {
+		name, version := parseDistributionFileName(testCase.fileName)
+		assert.Equal(t, testCase.expectedName, name, testCase.fileName)
+		assert.Equal(t, testCase.expectedVersion, version, testCase.fileName)
+	}
+	assert.Equal(t, "charset-normalizer", canonicalizeName("Charset_Normalizer"))
+}
@@ -38,7 +38,7 @@ func (pc *PoetryCommand) Run() (err error) {
 	log.Info(fmt.Sprintf("Running Poetry %s.", pc.commandName))
 	var buildConfiguration *buildUtils.BuildConfiguration
 	pc.args, buildConfiguration, err = buildUtils.ExtractBuildDetailsFromArgs(pc.args)
-	if err != nil {
+	if err != nil || pc.skipPublishInDryRun() {
 		return err
 	}
 	pythonBuildInfo, err := buildUtils.PrepareBuildPrerequisites(buildConfiguration)
@@ -54,6 +54,9 @@ func (pc *PoetryCommand) Run() (err error) {
 	if err != nil {
 		return err
 	}
+	if err = pc.setTlsEnv(); err != nil {
+		return err
+	}
 	if pythonBuildInfo != nil {
 		switch pc.commandName {
 		case "install":
@@ -5,6 +5,7 @@ import (
 	python "github.com/jfrog/jfrog-cli-core/v2/utils/python"
 	"io"
 	"os/exec"
+	"strings"
 
 	"github.com/jfrog/build-info-go/build"
 	"github.com/jfrog/build-info-go/entities"
@@ -13,6 +14,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/python/dependencies"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
@@ -24,6 +26,7 @@ type PythonCommand struct {
 	commandName   string
 	args          []string
 	repository    string
+	dryRun        bool
 }
 
 func NewPythonCommand(pythonTool pythonutils.PythonTool) *PythonCommand {
@@ -34,7 +37,7 @@ func (pc *PythonCommand) Run() (err error) {
 	log.Info("Running", string(pc.pythonTool), pc.commandName)
 	var buildConfiguration *buildUtils.BuildConfiguration
 	pc.args, buildConfiguration, err = buildUtils.ExtractBuildDetailsFromArgs(pc.args)
-	if err != nil {
+	if err != nil || pc.skipPublishInDryRun() {
 		return
 	}
 	pythonBuildInfo, err := buildUtils.PrepareBuildPrerequisites(buildConfiguration)
@@ -50,6 +53,9 @@ func (pc *PythonCommand) Run() (err error) {
 	if err != nil {
 		return
 	}
+	if err = pc.setTlsEnv(); err != nil {
+		return
+	}
 
 	if pythonBuildInfo != nil && pc.commandName == "install" {
 		// Need to collect build info
@@ -76,6 +82,25 @@ func (pc *PythonCommand) Run() (err error) {
 	return
 }
 
+// IsReadOnly implements commands.ReadOnlyCommand. Only the publish command deploys packages.
+func (pc *PythonCommand) IsReadOnly() bool {
+	return pc.commandName != "publish"
+}
+
+// EnableDryRun implements commands.DryRunCommand.
+// In dry run mode, the publish command reports the package it would publish, without running the package manager.
+func (pc *PythonCommand) EnableDryRun() {
+	pc.dryRun = true
+}
+
+func (pc *PythonCommand) skipPublishInDryRun() bool {
+	if !pc.dryRun || pc.IsReadOnly() {
+		return false
+	}
+	commands.LogDryRunAction("Publishing the package to:", pc.repository, "by running:", strings.TrimSpace(string(pc.pythonTool)+" "+pc.commandName+" "+strings.Join(pc.args, " ")))
+	return true
+}
+
 func (pc *PythonCommand) UpdateDepsChecksumInfoFunc(dependenciesMap map[string]entities.Dependency, srcPath string) error {
 	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, -1, 0, false)
 	if err != nil {
@@ -108,6 +133,15 @@ func (pc *PythonCommand) SetPypiRepoUrlWithCredentials() error {
 	return nil
 }
 
+// Propagates the TLS settings of the Artifactory server to the Python package manager.
+func (pc *PythonCommand) setTlsEnv() error {
+	tlsConfig, err := utils.GetPackageManagerTlsConfig(pc.serverDetails)
+	if err != nil {
+		return err
+	}
+	return tlsConfig.SetPythonEnv()
+}
+
 func (pc *PythonCommand) SetServerDetails(serverDetails *config.ServerDetails) *PythonCommand {
 	pc.serverDetails = serverDetails
 	return pc
@@ -0,0 +1,18 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoetryPublishDryRun(t *testing.T) {
+	poetryCmd := NewPoetryCommand().SetCommandName("install")
+	assert.True(t, poetryCmd.IsReadOnly())
+
+	// The publish command isn't run in dry run mode, so no server or package manager is needed.
+	poetryCmd.SetCommandName("publish").SetRepo("pypi-local").SetArgs([]string{"--build-name=app", "--build-number=1"})
+	assert.False(t, poetryCmd.IsReadOnly())
+	poetryCmd.EnableDryRun()
+	assert.NoError(t, poetryCmd.Run())
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 )
@@ -49,68 +50,72 @@ func (rcc *ReplicationCreateCommand) CommandName() string {
 }
 
 func (rcc *ReplicationCreateCommand) Run() (err error) {
-	content, err := fileutils.ReadFile(rcc.templatePath)
-	if errorutils.CheckError(err) != nil {
-		return
+	params, err := readReplicationTemplate(rcc.templatePath, rcc.vars)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(rcc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	return servicesManager.CreateReplication(services.CreateReplicationParams{ReplicationParams: *params})
+}
+
+// Reads the replication template, and returns the replication configuration it describes.
+// All the values in the template are strings, and are converted to their correct type using the writersMap.
+func readReplicationTemplate(templatePath, vars string) (*clientUtils.ReplicationParams, error) {
+	content, err := fileutils.ReadFile(templatePath)
+	if err != nil {
+		return nil, err
 	}
 	// Replace vars string-by-string if needed
-	if len(rcc.vars) > 0 {
-		templateVars := coreutils.SpecVarsStringToMap(rcc.vars)
+	if len(vars) > 0 {
+		templateVars := coreutils.SpecVarsStringToMap(vars)
 		content = coreutils.ReplaceVars(content, templateVars)
 	}
 	// Unmarshal template to a map
 	var replicationConfigMap map[string]interface{}
-	err = json.Unmarshal(content, &replicationConfigMap)
-	if errorutils.CheckError(err) != nil {
-		return
+	if err = json.Unmarshal(content, &replicationConfigMap); err != nil {
+		return nil, errorutils.CheckError(err)
 	}
-	// All the values in the template are strings
 	// Go over the confMap and write the values with the correct type using the writersMap
 	serverId := ""
 	for key, value := range replicationConfigMap {
 		if err = utils.ValidateMapEntry(key, value, writersMap); err != nil {
-			return
+			return nil, err
 		}
 		if key == "serverId" {
 			serverId = fmt.Sprint(value)
 		} else {
-			err := writersMap[key](&replicationConfigMap, key, fmt.Sprint(value))
-			if err != nil {
-				return err
+			if err = writersMap[key](&replicationConfigMap, key, fmt.Sprint(value)); err != nil {
+				return nil, err
 			}
 		}
 	}
-	err = fillMissingDefaultValue(replicationConfigMap)
-	if err != nil {
-		return err
+	if err = fillMissingDefaultValue(replicationConfigMap); err != nil {
+		return nil, err
 	}
 	// Write a JSON with the correct values
 	content, err = json.Marshal(replicationConfigMap)
-	if errorutils.CheckError(err) != nil {
-		return
+	if err != nil {
+		return nil, errorutils.CheckError(err)
 	}
-	var params services.CreateReplicationParams
-	err = json.Unmarshal(content, &params)
-	if errorutils.CheckError(err) != nil {
-		return
+	var params clientUtils.ReplicationParams
+	if err = json.Unmarshal(content, &params); err != nil {
+		return nil, errorutils.CheckError(err)
 	}
-
 	setPathPrefixBackwardCompatibility(&params)
-	servicesManager, err := rtUtils.CreateServiceManager(rcc.serverDetails, -1, 0, false)
-	if err != nil {
-		return err
-	}
 	// In case 'serverId' is not found, pull replication will be assumed.
 	if serverId != "" {
-		if targetRepo, ok := replicationConfigMap["targetRepoKey"]; ok {
-			if err = updateArtifactoryInfo(&params, serverId, fmt.Sprint(targetRepo)); err != nil {
-				return err
-			}
-		} else {
-			return errorutils.CheckErrorf("expected 'targetRepoKey' field in the json template file.")
+		targetRepo, ok := replicationConfigMap["targetRepoKey"]
+		if !ok {
+			return nil, errorutils.CheckErrorf("expected 'targetRepoKey' field in the json template file.")
+		}
+		if err = updateArtifactoryInfo(&params, serverId, fmt.Sprint(targetRepo)); err != nil {
+			return nil, err
 		}
 	}
-	return servicesManager.CreateReplication(params)
+	return &params, nil
 }
 
 func fillMissingDefaultValue(replicationConfigMap map[string]interface{}) error {
@@ -130,7 +135,7 @@ func fillMissingDefaultValue(replicationConfigMap map[string]interface{}) error
 }
 
 // Make the pathPrefix parameter equals to the includePathPrefixPattern to support Artifactory < 7.27.4
-func setPathPrefixBackwardCompatibility(params *services.CreateReplicationParams) {
+func setPathPrefixBackwardCompatibility(params *clientUtils.ReplicationParams) {
 	if params.IncludePathPrefixPattern == "" {
 		params.IncludePathPrefixPattern = params.PathPrefix
 		return
@@ -140,7 +145,7 @@ func setPathPrefixBackwardCompatibility(params *services.CreateReplicationParams
 	}
 }
 
-func updateArtifactoryInfo(param *services.CreateReplicationParams, serverId, targetRepo string) error {
+func updateArtifactoryInfo(param *clientUtils.ReplicationParams, serverId, targetRepo string) error {
 	singleConfig, err := config.GetSpecificConfig(serverId, true, false)
 	if err != nil {
 		return err
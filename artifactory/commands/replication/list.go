@@ -0,0 +1,112 @@
+package replication
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ReplicationListCommand lists the replication configurations of a repository, or of all the repositories.
+type ReplicationListCommand struct {
+	serverDetails *config.ServerDetails
+	repoKey       string
+	outputFormat  format.OutputFormat
+	replications  []ReplicationConfig
+}
+
+type replicationRow struct {
+	RepoKey    string `col-name:"Repository"`
+	Type       string `col-name:"Type"`
+	Url        string `col-name:"Target URL"`
+	CronExp    string `col-name:"Cron Expression"`
+	EventBased string `col-name:"Event-Based"`
+	Enabled    string `col-name:"Enabled"`
+	PathFilter string `col-name:"Path Filter"`
+}
+
+func NewReplicationListCommand() *ReplicationListCommand {
+	return &ReplicationListCommand{outputFormat: format.Table}
+}
+
+func (rlc *ReplicationListCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReplicationListCommand {
+	rlc.serverDetails = serverDetails
+	return rlc
+}
+
+// SetRepoKey sets the repository to list the replications of. By default, the replications of all the repositories are listed.
+func (rlc *ReplicationListCommand) SetRepoKey(repoKey string) *ReplicationListCommand {
+	rlc.repoKey = repoKey
+	return rlc
+}
+
+// SetOutputFormat sets the output format - 'table' or 'json'.
+func (rlc *ReplicationListCommand) SetOutputFormat(outputFormat format.OutputFormat) *ReplicationListCommand {
+	rlc.outputFormat = outputFormat
+	return rlc
+}
+
+func (rlc *ReplicationListCommand) Replications() []ReplicationConfig {
+	return rlc.replications
+}
+
+func (rlc *ReplicationListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rlc.serverDetails, nil
+}
+
+func (rlc *ReplicationListCommand) CommandName() string {
+	return "rt_replication_list"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (rlc *ReplicationListCommand) IsReadOnly() bool {
+	return true
+}
+
+func (rlc *ReplicationListCommand) Run() (err error) {
+	service, err := newReplicationService(rlc.serverDetails)
+	if err != nil {
+		return err
+	}
+	if rlc.replications, err = service.getReplications(rlc.repoKey); err != nil {
+		return err
+	}
+	switch rlc.outputFormat {
+	case format.Json:
+		content, err := json.Marshal(rlc.replications)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	case format.Table:
+		return printReplicationsTable(rlc.replications)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s", rlc.outputFormat, format.Table, format.Json)
+	}
+}
+
+func printReplicationsTable(replications []ReplicationConfig) error {
+	var rows []replicationRow
+	for _, replication := range replications {
+		pathFilter := replication.IncludePathPrefixPattern
+		if pathFilter == "" {
+			pathFilter = replication.PathPrefix
+		}
+		rows = append(rows, replicationRow{
+			RepoKey:    replication.RepoKey,
+			Type:       replication.ReplicationType,
+			Url:        replication.Url,
+			CronExp:    replication.CronExp,
+			EventBased: strconv.FormatBool(replication.EnableEventReplication),
+			Enabled:    strconv.FormatBool(replication.Enabled),
+			PathFilter: pathFilter,
+		})
+	}
+	return coreutils.PrintTable(rows, "Replications", "No replications were found", false)
+}
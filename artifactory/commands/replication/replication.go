@@ -0,0 +1,105 @@
+package replication
+
+import (
+	"encoding/json"
+	"net/http"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	replicationsApi       = "api/replications"
+	replicationStatusApi  = "api/replication/"
+	replicationExecuteApi = "api/replication/execute/"
+)
+
+// ReplicationConfig is a replication configuration of a repository, as returned by Artifactory.
+type ReplicationConfig struct {
+	RepoKey                  string `json:"repoKey"`
+	ReplicationType          string `json:"replicationType,omitempty"`
+	Url                      string `json:"url,omitempty"`
+	CronExp                  string `json:"cronExp,omitempty"`
+	EnableEventReplication   bool   `json:"enableEventReplication"`
+	Enabled                  bool   `json:"enabled"`
+	SyncDeletes              bool   `json:"syncDeletes"`
+	SyncProperties           bool   `json:"syncProperties"`
+	SyncStatistics           bool   `json:"syncStatistics"`
+	PathPrefix               string `json:"pathPrefix,omitempty"`
+	IncludePathPrefixPattern string `json:"includePathPrefixPattern,omitempty"`
+}
+
+// ReplicationStatus is the status of the scheduled replication of a repository, as returned by Artifactory.
+type ReplicationStatus struct {
+	Status        string                    `json:"status"`
+	LastCompleted string                    `json:"lastCompleted,omitempty"`
+	Targets       []ReplicationTargetStatus `json:"targets,omitempty"`
+}
+
+type ReplicationTargetStatus struct {
+	Url           string `json:"url"`
+	RepoKey       string `json:"repoKey,omitempty"`
+	Status        string `json:"status"`
+	LastCompleted string `json:"lastCompleted,omitempty"`
+}
+
+// replicationService sends the replication REST API requests, which aren't supported by the services manager, to Artifactory.
+type replicationService struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+}
+
+func newReplicationService(serverDetails *config.ServerDetails) (*replicationService, error) {
+	servicesManager, err := rtUtils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	return &replicationService{servicesManager: servicesManager}, nil
+}
+
+// Returns the replication configurations of the repository, or of all the repositories if no repository key is provided.
+func (rs *replicationService) getReplications(repoKey string) ([]ReplicationConfig, error) {
+	restApi := replicationsApi
+	if repoKey != "" {
+		restApi += "/" + repoKey
+	}
+	body, err := rs.sendGet(restApi)
+	if err != nil {
+		return nil, err
+	}
+	var replications []ReplicationConfig
+	return replications, errorutils.CheckError(json.Unmarshal(body, &replications))
+}
+
+func (rs *replicationService) getStatus(repoKey string) (*ReplicationStatus, error) {
+	body, err := rs.sendGet(replicationStatusApi + repoKey)
+	if err != nil {
+		return nil, err
+	}
+	status := &ReplicationStatus{}
+	return status, errorutils.CheckError(json.Unmarshal(body, status))
+}
+
+// Triggers an immediate replication of the repository, regardless of its cron schedule.
+func (rs *replicationService) execute(repoKey string) error {
+	serviceDetails := rs.servicesManager.GetConfig().GetServiceDetails()
+	httpDetails := serviceDetails.CreateHttpClientDetails()
+	clientUtils.SetContentType("application/json", &httpDetails.Headers)
+	resp, body, err := rs.servicesManager.Client().SendPost(serviceDetails.GetUrl()+replicationExecuteApi+repoKey, nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusAccepted)
+}
+
+func (rs *replicationService) sendGet(restApi string) ([]byte, error) {
+	serviceDetails := rs.servicesManager.GetConfig().GetServiceDetails()
+	httpDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := rs.servicesManager.Client().SendGet(serviceDetails.GetUrl()+restApi, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	return body, errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
@@ -0,0 +1,196 @@
+package replication
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	replicationStatusInProgress = "inprogress"
+	replicationStatusPending    = "pending"
+	replicationStatusError      = "error"
+	replicationStatusFailure    = "failure"
+
+	defaultReplicationRunTimeout = 60 * time.Minute
+)
+
+var replicationStatusPollingInterval = 10 * time.Second
+
+// ReplicationRunCommand triggers an immediate replication of a repository, regardless of its cron schedule.
+// With 'wait', the replication status is polled until the replication completes or the timeout is reached,
+// and the status of each replication target is reported. The command fails if the replication failed.
+type ReplicationRunCommand struct {
+	serverDetails *config.ServerDetails
+	repoKey       string
+	wait          bool
+	timeout       time.Duration
+	report        *ReplicationRunReport
+}
+
+type ReplicationRunReport struct {
+	RepoKey       string                    `json:"repo_key"`
+	Status        string                    `json:"status"`
+	LastCompleted string                    `json:"last_completed,omitempty"`
+	Duration      string                    `json:"duration"`
+	Targets       []ReplicationTargetStatus `json:"targets"`
+}
+
+type replicationTargetRow struct {
+	Url           string `col-name:"Target URL"`
+	RepoKey       string `col-name:"Target Repository"`
+	Status        string `col-name:"Status"`
+	LastCompleted string `col-name:"Last Completed"`
+}
+
+func NewReplicationRunCommand() *ReplicationRunCommand {
+	return &ReplicationRunCommand{timeout: defaultReplicationRunTimeout}
+}
+
+func (rrc *ReplicationRunCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReplicationRunCommand {
+	rrc.serverDetails = serverDetails
+	return rrc
+}
+
+func (rrc *ReplicationRunCommand) SetRepoKey(repoKey string) *ReplicationRunCommand {
+	rrc.repoKey = repoKey
+	return rrc
+}
+
+// SetWait sets whether to wait for the replication to complete, and report its statistics.
+func (rrc *ReplicationRunCommand) SetWait(wait bool) *ReplicationRunCommand {
+	rrc.wait = wait
+	return rrc
+}
+
+func (rrc *ReplicationRunCommand) SetTimeout(timeout time.Duration) *ReplicationRunCommand {
+	rrc.timeout = timeout
+	return rrc
+}
+
+// Report returns the replication report. Available only when waiting for the replication to complete.
+func (rrc *ReplicationRunCommand) Report() *ReplicationRunReport {
+	return rrc.report
+}
+
+func (rrc *ReplicationRunCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rrc.serverDetails, nil
+}
+
+func (rrc *ReplicationRunCommand) CommandName() string {
+	return "rt_replication_run"
+}
+
+func (rrc *ReplicationRunCommand) Run() (err error) {
+	service, err := newReplicationService(rrc.serverDetails)
+	if err != nil {
+		return err
+	}
+	// The status before triggering the replication is kept, to identify the completion of the triggered replication.
+	var initialStatus *ReplicationStatus
+	if rrc.wait {
+		if initialStatus, err = service.getStatus(rrc.repoKey); err != nil {
+			return err
+		}
+	}
+	startTime := time.Now()
+	if err = service.execute(rrc.repoKey); err != nil {
+		return err
+	}
+	log.Info("Replication of repository", rrc.repoKey, "was triggered.")
+	if !rrc.wait {
+		return nil
+	}
+	status, err := rrc.waitForReplication(service, initialStatus.LastCompleted)
+	if err != nil {
+		return err
+	}
+	rrc.report = &ReplicationRunReport{
+		RepoKey:       rrc.repoKey,
+		Status:        status.Status,
+		LastCompleted: status.LastCompleted,
+		Duration:      time.Since(startTime).Round(time.Second).String(),
+		Targets:       status.Targets,
+	}
+	if rrc.report.Targets == nil {
+		rrc.report.Targets = []ReplicationTargetStatus{}
+	}
+	if err = printRunReport(rrc.report); err != nil {
+		return err
+	}
+	return getReplicationFailuresError(rrc.report)
+}
+
+// Polls the replication status until the triggered replication completes.
+// The replication is considered complete once it isn't running, and either its completion time changed or it was seen running.
+func (rrc *ReplicationRunCommand) waitForReplication(service *replicationService, initialLastCompleted string) (*ReplicationStatus, error) {
+	var status *ReplicationStatus
+	seenRunning := false
+	pollingExecutor := &httputils.PollingExecutor{
+		Timeout:         rrc.timeout,
+		PollingInterval: replicationStatusPollingInterval,
+		MsgPrefix:       fmt.Sprintf("Waiting for the replication of %s...", rrc.repoKey),
+		PollingAction: func() (shouldStop bool, responseBody []byte, err error) {
+			status, err = service.getStatus(rrc.repoKey)
+			if err != nil {
+				return true, nil, err
+			}
+			if isReplicationRunning(status.Status) {
+				seenRunning = true
+				return false, nil, nil
+			}
+			return seenRunning || status.LastCompleted != initialLastCompleted, nil, nil
+		},
+	}
+	_, err := pollingExecutor.Execute()
+	if errors.As(err, &clientUtils.RetryExecutorTimeoutError{}) {
+		return nil, errorutils.CheckErrorf("the replication of %s did not complete within %s. Last status: %s", rrc.repoKey, rrc.timeout, status.Status)
+	}
+	return status, err
+}
+
+func isReplicationRunning(status string) bool {
+	return status == replicationStatusInProgress || status == replicationStatusPending
+}
+
+func isReplicationFailed(status string) bool {
+	return status == replicationStatusError || status == replicationStatusFailure
+}
+
+func printRunReport(report *ReplicationRunReport) error {
+	var rows []replicationTargetRow
+	for _, target := range report.Targets {
+		rows = append(rows, replicationTargetRow{Url: target.Url, RepoKey: target.RepoKey, Status: target.Status, LastCompleted: target.LastCompleted})
+	}
+	if err := coreutils.PrintTable(rows, "Replication Targets of "+report.RepoKey, "The replication has no targets", false); err != nil {
+		return err
+	}
+	log.Output(fmt.Sprintf("Replication status: %s, completed: %s, duration: %s", report.Status, report.LastCompleted, report.Duration))
+	return nil
+}
+
+// Returns an error listing the targets the replication failed on, if any.
+func getReplicationFailuresError(report *ReplicationRunReport) error {
+	var failedTargets []string
+	for _, target := range report.Targets {
+		if isReplicationFailed(target.Status) {
+			failedTargets = append(failedTargets, target.Url)
+		}
+	}
+	if len(failedTargets) == 0 {
+		if isReplicationFailed(report.Status) {
+			return errorutils.CheckErrorf("the replication of %s failed", report.RepoKey)
+		}
+		return nil
+	}
+	return errorutils.CheckErrorf("the replication of %s failed on %d out of %d targets: %s",
+		report.RepoKey, len(failedTargets), len(report.Targets), strings.Join(failedTargets, ", "))
+}
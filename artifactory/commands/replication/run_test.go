@@ -0,0 +1,114 @@
+package replication
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicationRunWait(t *testing.T) {
+	replicationStatusPollingInterval = 10 * time.Millisecond
+	executed := false
+	statusRequests := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/replication/execute/repo-local":
+			executed = true
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/replication/repo-local":
+			status := ReplicationStatus{Status: "ok", LastCompleted: "2024-01-01T00:00:00.000Z"}
+			if executed {
+				statusRequests++
+				// The replication is reported running, and then completed.
+				if statusRequests < 3 {
+					status.Status = replicationStatusInProgress
+				} else {
+					status.LastCompleted = "2024-01-02T00:00:00.000Z"
+					status.Targets = []ReplicationTargetStatus{
+						{Url: "https://target-1/artifactory/repo", Status: "ok", LastCompleted: status.LastCompleted},
+						{Url: "https://target-2/artifactory/repo", Status: replicationStatusError},
+					}
+				}
+			}
+			writeJson(t, w, status)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	runCmd := NewReplicationRunCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}).
+		SetRepoKey("repo-local").SetWait(true).SetTimeout(time.Minute)
+	err := runCmd.Run()
+	assert.ErrorContains(t, err, "failed on 1 out of 2 targets: https://target-2/artifactory/repo")
+	report := runCmd.Report()
+	require.NotNil(t, report)
+	assert.Equal(t, "ok", report.Status)
+	assert.Equal(t, "2024-01-02T00:00:00.000Z", report.LastCompleted)
+	assert.Len(t, report.Targets, 2)
+	assert.Equal(t, 3, statusRequests)
+}
+
+func TestReplicationRunWithoutWait(t *testing.T) {
+	executed := false
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/replication/execute/repo-local", r.URL.Path)
+		executed = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	runCmd := NewReplicationRunCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}).SetRepoKey("repo-local")
+	assert.NoError(t, runCmd.Run())
+	assert.True(t, executed)
+	assert.Nil(t, runCmd.Report())
+}
+
+func TestReplicationList(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/replications", r.URL.Path)
+		writeJson(t, w, []ReplicationConfig{
+			{RepoKey: "repo-local", ReplicationType: "PUSH", Url: "https://target/artifactory/repo", CronExp: "0 0 12 * * ?", Enabled: true, IncludePathPrefixPattern: "org/"},
+			{RepoKey: "repo-remote", ReplicationType: "PULL", EnableEventReplication: true},
+		})
+	}))
+	defer testServer.Close()
+
+	listCmd := NewReplicationListCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}).SetOutputFormat(format.Json)
+	assert.NoError(t, listCmd.Run())
+	replications := listCmd.Replications()
+	require.Len(t, replications, 2)
+	assert.Equal(t, "org/", replications[0].IncludePathPrefixPattern)
+	assert.True(t, replications[1].EnableEventReplication)
+}
+
+func TestReplicationUpdate(t *testing.T) {
+	updated := false
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/replications/repl-RepoKey", r.URL.Path)
+		updated = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	updateCmd := NewReplicationUpdateCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}).
+		SetTemplatePath(filepath.Join(templatesPath, "template-includePathPrefixPattern.json"))
+	assert.NoError(t, updateCmd.Run())
+	assert.True(t, updated)
+}
+
+func writeJson(t *testing.T, w http.ResponseWriter, payload any) {
+	content, err := json.Marshal(payload)
+	require.NoError(t, err)
+	_, err = w.Write(content)
+	assert.NoError(t, err)
+}
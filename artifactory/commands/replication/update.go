@@ -0,0 +1,53 @@
+package replication
+
+import (
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+)
+
+// ReplicationUpdateCommand updates the replication of a repository, using the same template as the replication-create command.
+type ReplicationUpdateCommand struct {
+	serverDetails *config.ServerDetails
+	templatePath  string
+	vars          string
+}
+
+func NewReplicationUpdateCommand() *ReplicationUpdateCommand {
+	return &ReplicationUpdateCommand{}
+}
+
+func (ruc *ReplicationUpdateCommand) SetTemplatePath(path string) *ReplicationUpdateCommand {
+	ruc.templatePath = path
+	return ruc
+}
+
+func (ruc *ReplicationUpdateCommand) SetVars(vars string) *ReplicationUpdateCommand {
+	ruc.vars = vars
+	return ruc
+}
+
+func (ruc *ReplicationUpdateCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReplicationUpdateCommand {
+	ruc.serverDetails = serverDetails
+	return ruc
+}
+
+func (ruc *ReplicationUpdateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ruc.serverDetails, nil
+}
+
+func (ruc *ReplicationUpdateCommand) CommandName() string {
+	return "rt_replication_update"
+}
+
+func (ruc *ReplicationUpdateCommand) Run() (err error) {
+	params, err := readReplicationTemplate(ruc.templatePath, ruc.vars)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(ruc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	return servicesManager.UpdateReplication(services.UpdateReplicationParams{ReplicationParams: *params})
+}
@@ -22,6 +22,12 @@ func (rcc *RepoCreateCommand) SetVars(vars string) *RepoCreateCommand {
 	return rcc
 }
 
+// SetPreview prints the configurations of the repositories described by the template, without creating or updating them.
+func (rcc *RepoCreateCommand) SetPreview(preview bool) *RepoCreateCommand {
+	rcc.preview = preview
+	return rcc
+}
+
 func (rcc *RepoCreateCommand) SetServerDetails(serverDetails *config.ServerDetails) *RepoCreateCommand {
 	rcc.serverDetails = serverDetails
 	return rcc
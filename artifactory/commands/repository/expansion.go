@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// A repository template may describe multiple repositories. Besides a single repository configuration, the template may hold
+// an expansion entry, or a list of repository configurations and expansion entries:
+//
+//	{
+//	  "forEach": {"tech": "${techs}", "rclass": ["local", "remote", "virtual"]},
+//	  "when": {"rclass": ["local", "remote"]},
+//	  "template": {"key": "${tech}-${rclass}", "rclass": "${rclass}", "packageType": "${tech}"},
+//	  "conditionals": [{"when": {"tech": "npm", "rclass": "remote"}, "template": {"url": "https://registry.npmjs.org"}}]
+//	}
+//
+// Each combination of the 'forEach' values creates a repository, with the values replacing the variables of the template.
+// A 'forEach' value may be a list, or a comma separated string - usually a list provided by the template vars.
+// Combinations not matching the 'when' block are skipped, and the templates of the matching 'conditionals' are merged
+// into the repository configuration.
+const (
+	forEachKey      = "forEach"
+	whenKey         = "when"
+	templateKey     = "template"
+	conditionalsKey = "conditionals"
+)
+
+type expansionEntry struct {
+	ForEach      map[string]any         `json:"forEach,omitempty"`
+	When         map[string]any         `json:"when,omitempty"`
+	Template     map[string]interface{} `json:"template"`
+	Conditionals []conditionalBlock     `json:"conditionals,omitempty"`
+}
+
+type conditionalBlock struct {
+	When     map[string]any         `json:"when"`
+	Template map[string]interface{} `json:"template"`
+}
+
+// Returns the configurations of the repositories described by the template content, after the template vars were replaced.
+func expandRepoTemplate(content []byte) ([]map[string]interface{}, error) {
+	var template any
+	if err := json.Unmarshal(content, &template); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var elements []any
+	switch value := template.(type) {
+	case []any:
+		elements = value
+	case map[string]any:
+		elements = []any{value}
+	default:
+		return nil, errorutils.CheckErrorf("template syntax error: the template should be a JSON object or a list of JSON objects")
+	}
+	var repoConfigs []map[string]interface{}
+	for _, element := range elements {
+		elementMap, ok := element.(map[string]any)
+		if !ok {
+			return nil, errorutils.CheckErrorf("template syntax error: the template list should hold JSON objects only")
+		}
+		if _, isExpansion := elementMap[templateKey]; !isExpansion {
+			repoConfigs = append(repoConfigs, elementMap)
+			continue
+		}
+		expanded, err := expandEntry(elementMap)
+		if err != nil {
+			return nil, err
+		}
+		repoConfigs = append(repoConfigs, expanded...)
+	}
+	return repoConfigs, validateUniqueKeys(repoConfigs)
+}
+
+func expandEntry(elementMap map[string]any) ([]map[string]interface{}, error) {
+	for key := range elementMap {
+		if key != forEachKey && key != whenKey && key != templateKey && key != conditionalsKey {
+			return nil, errorutils.CheckErrorf("template syntax error: unknown key in an expansion entry: \"%s\".", key)
+		}
+	}
+	content, err := json.Marshal(elementMap)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var entry expansionEntry
+	if err = json.Unmarshal(content, &entry); err != nil {
+		return nil, errorutils.CheckErrorf("template syntax error: %s", err.Error())
+	}
+	combinations, err := getCombinations(entry.ForEach)
+	if err != nil {
+		return nil, err
+	}
+	var repoConfigs []map[string]interface{}
+	for _, combination := range combinations {
+		matched, err := matchCondition(entry.When, combination)
+		if err != nil || !matched {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		repoConfig, err := replaceTemplateVars(entry.Template, combination)
+		if err != nil {
+			return nil, err
+		}
+		for _, conditional := range entry.Conditionals {
+			if matched, err = matchCondition(conditional.When, combination); err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+			conditionalConfig, err := replaceTemplateVars(conditional.Template, combination)
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range conditionalConfig {
+				repoConfig[key] = value
+			}
+		}
+		repoConfigs = append(repoConfigs, repoConfig)
+	}
+	return repoConfigs, nil
+}
+
+// Returns all the combinations of the 'forEach' values. The variables are iterated in an alphabetical order, to keep the
+// order of the created repositories stable.
+func getCombinations(forEach map[string]any) ([]map[string]string, error) {
+	var varNames []string
+	for varName := range forEach {
+		varNames = append(varNames, varName)
+	}
+	sort.Strings(varNames)
+	combinations := []map[string]string{{}}
+	for _, varName := range varNames {
+		values, err := toStringList(forEach[varName])
+		if err != nil {
+			return nil, errorutils.CheckErrorf("template syntax error: the 'forEach' value of \"%s\" %s", varName, err.Error())
+		}
+		var extended []map[string]string
+		for _, combination := range combinations {
+			for _, value := range values {
+				extendedCombination := map[string]string{varName: value}
+				for k, v := range combination {
+					extendedCombination[k] = v
+				}
+				extended = append(extended, extendedCombination)
+			}
+		}
+		combinations = extended
+	}
+	return combinations, nil
+}
+
+// A condition matches if the value of each of its variables is one of the expected values.
+func matchCondition(condition map[string]any, combination map[string]string) (bool, error) {
+	for varName, expected := range condition {
+		expectedValues, err := toStringList(expected)
+		if err != nil {
+			return false, errorutils.CheckErrorf("template syntax error: the 'when' value of \"%s\" %s", varName, err.Error())
+		}
+		value, exists := combination[varName]
+		if !exists {
+			return false, errorutils.CheckErrorf("template syntax error: the 'when' variable \"%s\" isn't a 'forEach' variable", varName)
+		}
+		matched := false
+		for _, expectedValue := range expectedValues {
+			if value == expectedValue {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func toStringList(value any) ([]string, error) {
+	switch typedValue := value.(type) {
+	case string:
+		var values []string
+		for _, item := range strings.Split(typedValue, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values, nil
+	case []any:
+		var values []string
+		for _, item := range typedValue {
+			stringItem, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("should be a list of strings")
+			}
+			values = append(values, stringItem)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("should be a string or a list of strings")
+	}
+}
+
+func replaceTemplateVars(template map[string]interface{}, vars map[string]string) (map[string]interface{}, error) {
+	content, err := json.Marshal(template)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var repoConfig map[string]interface{}
+	err = json.Unmarshal(coreutils.ReplaceVars(content, vars), &repoConfig)
+	return repoConfig, errorutils.CheckError(err)
+}
+
+func validateUniqueKeys(repoConfigs []map[string]interface{}) error {
+	keys := map[string]bool{}
+	for _, repoConfig := range repoConfigs {
+		key := fmt.Sprint(repoConfig[Key])
+		if keys[key] {
+			return errorutils.CheckErrorf("template syntax error: the repository key \"%s\" appears more than once", key)
+		}
+		keys[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandRepoTemplate(t *testing.T) {
+	repoCmd := &RepoCommand{templatePath: filepath.Join("testdata", "multi-repo-template.json"), vars: "team=web;techs=npm,maven"}
+	repoConfigs, err := repoCmd.readRepoConfigs()
+	require.NoError(t, err)
+	require.Len(t, repoConfigs, 7)
+
+	var keys []string
+	for _, repoConfig := range repoConfigs {
+		keys = append(keys, repoConfig[Key].(string))
+	}
+	assert.Equal(t, []string{"web-npm-local", "web-maven-local", "web-npm-remote", "web-maven-remote", "web-npm-virtual", "web-maven-virtual",
+		"web-generic-local"}, keys)
+	assert.Equal(t, "https://registry.npmjs.org", repoConfigs[2][Url])
+	assert.Equal(t, "https://repo.maven.apache.org/maven2", repoConfigs[3][Url])
+	assert.NotContains(t, repoConfigs[0], Url)
+	assert.Equal(t, "web-npm-local,web-npm-remote", repoConfigs[4][Repositories])
+
+	// The string values are converted to their correct types
+	content, err := convertRepoConfig(repoConfigs[4])
+	require.NoError(t, err)
+	var converted map[string]any
+	require.NoError(t, json.Unmarshal(content, &converted))
+	assert.Equal(t, []any{"web-npm-local", "web-npm-remote"}, converted[Repositories])
+}
+
+func TestExpandRepoTemplateWhen(t *testing.T) {
+	repoConfigs, err := expandRepoTemplate([]byte(`{"forEach": {"tech": ["go", "npm"], "rclass": "local,remote"}, "when": {"rclass": "remote"},
+		"template": {"key": "${tech}-${rclass}", "rclass": "${rclass}", "packageType": "${tech}"}}`))
+	require.NoError(t, err)
+	require.Len(t, repoConfigs, 2)
+	assert.Equal(t, "go-remote", repoConfigs[0][Key])
+	assert.Equal(t, "npm-remote", repoConfigs[1][Key])
+}
+
+func TestExpandRepoTemplateErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		template string
+		errorMsg string
+	}{
+		{"unknown key", `{"forEach": {"tech": "go"}, "template": {"key": "${tech}"}, "unknown": ""}`, "unknown key in an expansion entry"},
+		{"duplicate keys", `{"forEach": {"tech": "go,npm"}, "template": {"key": "repo"}}`, "appears more than once"},
+		{"unknown when variable", `{"forEach": {"tech": "go"}, "when": {"rclass": "local"}, "template": {"key": "${tech}"}}`, "isn't a 'forEach' variable"},
+		{"invalid forEach", `{"forEach": {"tech": 5}, "template": {"key": "${tech}"}}`, "should be a string or a list of strings"},
+		{"invalid template", `"repo"`, "should be a JSON object"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := expandRepoTemplate([]byte(testCase.template))
+			assert.ErrorContains(t, err, testCase.errorMsg)
+		})
+	}
+}
+
+func TestWriteFederationMembers(t *testing.T) {
+	content, err := convertRepoConfig(map[string]interface{}{Key: "repo1", Rclass: Federated, PackageType: "generic",
+		Members: "https://site1.jfrog.io/artifactory/repo1, https://site2.jfrog.io/artifactory/repo1"})
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"members":[{"url":"https://site1.jfrog.io/artifactory/repo1","enabled":true},{"url":"https://site2.jfrog.io/artifactory/repo1","enabled":true}]`)
+}
@@ -10,10 +10,14 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 const (
@@ -26,6 +30,7 @@ type RepoCommand struct {
 	serverDetails *config.ServerDetails
 	templatePath  string
 	vars          string
+	preview       bool
 }
 
 func (rc *RepoCommand) Vars() string {
@@ -36,31 +41,75 @@ func (rc *RepoCommand) TemplatePath() string {
 	return rc.templatePath
 }
 
+// PerformRepoCmd creates or updates the repositories described by the template.
+// In preview mode, the configurations of the repositories are printed without sending them to Artifactory.
 func (rc *RepoCommand) PerformRepoCmd(isUpdate bool) (err error) {
-	repoConfigMap, err := utils.ConvertTemplateToMap(rc)
+	repoConfigMaps, err := rc.readRepoConfigs()
 	if err != nil {
 		return err
 	}
-	// All the values in the template are strings
-	// Go over the confMap and write the values with the correct type using the writersMap
-	for key, value := range repoConfigMap {
-		if err = utils.ValidateMapEntry(key, value, writersMap); err != nil {
-			return
-		}
-		if err = writersMap[key](&repoConfigMap, key, fmt.Sprint(value)); err != nil {
-			return
+	var contents [][]byte
+	for _, repoConfigMap := range repoConfigMaps {
+		content, err := convertRepoConfig(repoConfigMap)
+		if err != nil {
+			return err
 		}
+		contents = append(contents, content)
 	}
-	// Write a JSON with the correct values
-	content, err := json.Marshal(repoConfigMap)
-	if err != nil {
-		return err
+	if rc.preview {
+		return printRepoConfigs(repoConfigMaps)
 	}
 
 	servicesManager, err := rtUtils.CreateServiceManager(rc.serverDetails, -1, 0, false)
 	if err != nil {
 		return err
 	}
+	for i, repoConfigMap := range repoConfigMaps {
+		if err = performRepoRequest(servicesManager, repoConfigMap, contents[i], isUpdate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reads the template, replaces its vars and expands it to the configurations of the repositories it describes.
+func (rc *RepoCommand) readRepoConfigs() ([]map[string]interface{}, error) {
+	content, err := fileutils.ReadFile(rc.templatePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(rc.vars) > 0 {
+		content = coreutils.ReplaceVars(content, coreutils.SpecVarsStringToMap(rc.vars))
+	}
+	return expandRepoTemplate(content)
+}
+
+// All the values in the template are strings.
+// Writes the values of the repository configuration with the correct type using the writersMap, and returns its JSON.
+func convertRepoConfig(repoConfigMap map[string]interface{}) ([]byte, error) {
+	for key, value := range repoConfigMap {
+		if err := utils.ValidateMapEntry(key, value, writersMap); err != nil {
+			return nil, err
+		}
+		if err := writersMap[key](&repoConfigMap, key, fmt.Sprint(value)); err != nil {
+			return nil, err
+		}
+	}
+	content, err := json.Marshal(repoConfigMap)
+	return content, errorutils.CheckError(err)
+}
+
+func printRepoConfigs(repoConfigMaps []map[string]interface{}) error {
+	content, err := json.Marshal(repoConfigMaps)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info(fmt.Sprintf("Preview of %d repositories:", len(repoConfigMaps)))
+	log.Output(clientUtils.IndentJson(content))
+	return nil
+}
+
+func performRepoRequest(servicesManager artifactory.ArtifactoryServicesManager, repoConfigMap map[string]interface{}, content []byte, isUpdate bool) error {
 	// Rclass and packageType are mandatory keys in our templates
 	// Using their values we'll pick the suitable handler from one of the handler maps to create/update a repository
 	var handlerFunc func(servicesManager artifactory.ArtifactoryServicesManager, jsonConfig []byte, isUpdate bool) error
@@ -163,6 +212,21 @@ var writersMap = map[string]ioutils.AnswerWriter{
 	ForceMavenAuthentication:             ioutils.WriteBoolAnswer,
 	ForceNugetAuthentication:             ioutils.WriteBoolAnswer,
 	ExternalDependenciesRemoteRepo:       ioutils.WriteStringAnswer,
+	Members:                              writeFederationMembers,
+}
+
+// The federation members are provided as a comma separated list of the members URLs, in the form of <artifactory-url>/<repo-key>.
+func writeFederationMembers(resultMap *map[string]interface{}, key, value string) error {
+	var members []services.FederatedRepositoryMember
+	for _, memberUrl := range strings.Split(value, ",") {
+		if memberUrl = strings.TrimSpace(memberUrl); memberUrl == "" {
+			continue
+		}
+		enabled := true
+		members = append(members, services.FederatedRepositoryMember{Url: memberUrl, Enabled: &enabled})
+	}
+	(*resultMap)[key] = members
+	return nil
 }
 
 func writeContentSynchronisation(resultMap *map[string]interface{}, key, value string) error {
@@ -122,6 +122,9 @@ const (
 	ForceMavenAuthentication                      = "forceMavenAuthentication"
 	ExternalDependenciesRemoteRepo                = "externalDependenciesRemoteRepo"
 
+	// Unique federated repository configuration JSON keys
+	Members = "members"
+
 	// rclasses
 	Local     = "local"
 	Remote    = "remote"
@@ -298,6 +301,7 @@ var optionalSuggestsMap = map[string]prompt.Suggest{
 	ForceMavenAuthentication:             {Text: ForceMavenAuthentication},
 	ForceNugetAuthentication:             {Text: ForceNugetAuthentication},
 	ExternalDependenciesRemoteRepo:       {Text: ExternalDependenciesRemoteRepo},
+	Members:                              {Text: Members},
 }
 
 var baseLocalRepoConfKeys = []string{
@@ -570,7 +574,7 @@ func pkgTypeCallback(iq *ioutils.InteractiveQuestionnaire, pkgType string) (stri
 	case Virtual:
 		iq.OptionalKeysSuggests = getVirtualRepoConfKeys(pkgType)
 	case Federated:
-		iq.OptionalKeysSuggests = getLocalRepoConfKeys(pkgType)
+		iq.OptionalKeysSuggests = append(getLocalRepoConfKeys(pkgType), ioutils.GetSuggestsFromKeys([]string{Members}, optionalSuggestsMap)...)
 	default:
 		return "", errors.New("unsupported rclass was configured")
 	}
@@ -943,4 +947,5 @@ var questionMap = map[string]ioutils.QuestionInfo{
 	ForceMavenAuthentication:       BoolToStringQuestionInfo,
 	ForceNugetAuthentication:       BoolToStringQuestionInfo,
 	ExternalDependenciesRemoteRepo: ioutils.FreeStringQuestionInfo,
+	Members:                        StringListToStringQuestionInfo,
 }
@@ -22,6 +22,12 @@ func (ruc *RepoUpdateCommand) SetVars(vars string) *RepoUpdateCommand {
 	return ruc
 }
 
+// SetPreview prints the configurations of the repositories described by the template, without creating or updating them.
+func (ruc *RepoUpdateCommand) SetPreview(preview bool) *RepoUpdateCommand {
+	ruc.preview = preview
+	return ruc
+}
+
 func (ruc *RepoUpdateCommand) SetServerDetails(serverDetails *config.ServerDetails) *RepoUpdateCommand {
 	ruc.serverDetails = serverDetails
 	return ruc
@@ -0,0 +1,249 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type SortBy string
+
+const (
+	SortBySize  SortBy = "size"
+	SortByFiles SortBy = "files"
+	SortByItems SortBy = "items"
+	SortByName  SortBy = "name"
+
+	// The storage info API lists the total of all the repositories as a repository with this key.
+	totalRepoKey = "TOTAL"
+)
+
+// StorageSummaryCommand reports the storage usage of each repository, and the deduplication of the binaries in the filestore.
+// The storage info is calculated periodically by Artifactory, so the reported values may be slightly outdated.
+type StorageSummaryCommand struct {
+	serverDetails *config.ServerDetails
+	sortBy        SortBy
+	limit         int
+	outputFormat  format.OutputFormat
+	summary       *StorageSummary
+}
+
+// StorageSummary is the storage usage report.
+// The dedup ratio is the size of all the artifacts divided by the size of the binaries actually stored,
+// which is higher the more identical artifacts share a single binary.
+type StorageSummary struct {
+	Repositories       []RepositoryStorage `json:"repositories"`
+	BinariesCount      int64               `json:"binariesCount"`
+	BinariesSizeBytes  int64               `json:"binariesSizeBytes"`
+	ArtifactsCount     int64               `json:"artifactsCount"`
+	ArtifactsSizeBytes int64               `json:"artifactsSizeBytes"`
+	DedupRatio         float64             `json:"dedupRatio"`
+}
+
+type RepositoryStorage struct {
+	RepoKey        string `json:"repoKey" csv:"repoKey" col-name:"Repository"`
+	RepoType       string `json:"repoType" csv:"repoType" col-name:"Type"`
+	PackageType    string `json:"packageType" csv:"packageType" col-name:"Package Type"`
+	FilesCount     int64  `json:"filesCount" csv:"filesCount" col-name:"Files"`
+	FoldersCount   int64  `json:"foldersCount" csv:"foldersCount" col-name:"Folders"`
+	ItemsCount     int64  `json:"itemsCount" csv:"itemsCount"`
+	UsedSpaceBytes int64  `json:"usedSpaceBytes" csv:"usedSpaceBytes"`
+	UsedSpace      string `json:"-" csv:"-" col-name:"Used Space"`
+	Percentage     string `json:"percentage" csv:"percentage" col-name:"Percentage"`
+}
+
+func NewStorageSummaryCommand() *StorageSummaryCommand {
+	return &StorageSummaryCommand{sortBy: SortBySize, outputFormat: format.Table}
+}
+
+func (ssc *StorageSummaryCommand) SetServerDetails(serverDetails *config.ServerDetails) *StorageSummaryCommand {
+	ssc.serverDetails = serverDetails
+	return ssc
+}
+
+// SetSortBy sets the order of the repositories - 'size', 'files' or 'items' in descending order, or 'name' in ascending order.
+func (ssc *StorageSummaryCommand) SetSortBy(sortBy SortBy) *StorageSummaryCommand {
+	ssc.sortBy = sortBy
+	return ssc
+}
+
+// SetLimit sets the number of repositories to report, after sorting. Zero reports all the repositories.
+func (ssc *StorageSummaryCommand) SetLimit(limit int) *StorageSummaryCommand {
+	ssc.limit = limit
+	return ssc
+}
+
+// SetOutputFormat sets the output format - 'table', 'json' or 'csv'.
+func (ssc *StorageSummaryCommand) SetOutputFormat(outputFormat format.OutputFormat) *StorageSummaryCommand {
+	ssc.outputFormat = outputFormat
+	return ssc
+}
+
+func (ssc *StorageSummaryCommand) Summary() *StorageSummary {
+	return ssc.summary
+}
+
+func (ssc *StorageSummaryCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ssc.serverDetails, nil
+}
+
+func (ssc *StorageSummaryCommand) CommandName() string {
+	return "rt_storage_summary"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (ssc *StorageSummaryCommand) IsReadOnly() bool {
+	return true
+}
+
+func (ssc *StorageSummaryCommand) Run() (err error) {
+	if err = ssc.validate(); err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(ssc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	storageInfo, err := servicesManager.GetStorageInfo()
+	if err != nil {
+		return err
+	}
+	if ssc.summary, err = createStorageSummary(storageInfo); err != nil {
+		return err
+	}
+	sortRepositories(ssc.summary.Repositories, ssc.sortBy)
+	if ssc.limit > 0 && len(ssc.summary.Repositories) > ssc.limit {
+		ssc.summary.Repositories = ssc.summary.Repositories[:ssc.limit]
+	}
+	return ssc.printSummary()
+}
+
+func (ssc *StorageSummaryCommand) validate() error {
+	switch ssc.sortBy {
+	case SortBySize, SortByFiles, SortByItems, SortByName:
+	default:
+		return errorutils.CheckErrorf("unsupported sort order '%s'. Possible values are: %s, %s, %s, %s", ssc.sortBy, SortBySize, SortByFiles, SortByItems, SortByName)
+	}
+	if ssc.limit < 0 {
+		return errorutils.CheckErrorf("the limit must be a positive number")
+	}
+	return nil
+}
+
+func createStorageSummary(storageInfo *servicesUtils.StorageInfo) (summary *StorageSummary, err error) {
+	summary = &StorageSummary{Repositories: []RepositoryStorage{}}
+	binaries := storageInfo.BinariesSummary
+	if summary.BinariesCount, err = parseCount(binaries.BinariesCount); err != nil {
+		return
+	}
+	if summary.ArtifactsCount, err = parseCount(binaries.ArtifactsCount); err != nil {
+		return
+	}
+	if summary.BinariesSizeBytes, err = parseSize(binaries.BinariesSize); err != nil {
+		return
+	}
+	if summary.ArtifactsSizeBytes, err = parseSize(binaries.ArtifactsSize); err != nil {
+		return
+	}
+	if summary.BinariesSizeBytes > 0 {
+		summary.DedupRatio = float64(summary.ArtifactsSizeBytes) / float64(summary.BinariesSizeBytes)
+	}
+	for i, repoSummary := range storageInfo.RepositoriesSummaryList {
+		if repoSummary.RepoKey == totalRepoKey {
+			continue
+		}
+		repoStorage := RepositoryStorage{
+			RepoKey:     repoSummary.RepoKey,
+			RepoType:    repoSummary.RepoType,
+			PackageType: repoSummary.PackageType,
+			Percentage:  repoSummary.Percentage,
+		}
+		if repoStorage.FilesCount, err = parseCount(repoSummary.FilesCount.String()); err != nil {
+			return
+		}
+		if repoStorage.FoldersCount, err = parseCount(repoSummary.FoldersCount.String()); err != nil {
+			return
+		}
+		if repoStorage.ItemsCount, err = parseCount(repoSummary.ItemsCount.String()); err != nil {
+			return
+		}
+		if repoStorage.UsedSpaceBytes, err = rtUtils.GetUsedSpaceInBytes(&storageInfo.RepositoriesSummaryList[i]); err != nil {
+			return
+		}
+		repoStorage.UsedSpace = servicesUtils.ConvertIntToStorageSizeString(repoStorage.UsedSpaceBytes)
+		summary.Repositories = append(summary.Repositories, repoStorage)
+	}
+	return
+}
+
+// The storage info API returns the counts as strings with thousands separators, for example: "1,234".
+func parseCount(count string) (int64, error) {
+	if count == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.ParseInt(strings.ReplaceAll(count, ",", ""), 10, 64)
+	return parsed, errorutils.CheckError(err)
+}
+
+func parseSize(size string) (int64, error) {
+	if size == "" {
+		return 0, nil
+	}
+	return rtUtils.ConvertStorageSizeStringToBytes(size)
+}
+
+func sortRepositories(repositories []RepositoryStorage, sortBy SortBy) {
+	sort.SliceStable(repositories, func(i, j int) bool {
+		switch sortBy {
+		case SortByFiles:
+			return repositories[i].FilesCount > repositories[j].FilesCount
+		case SortByItems:
+			return repositories[i].ItemsCount > repositories[j].ItemsCount
+		case SortByName:
+			return repositories[i].RepoKey < repositories[j].RepoKey
+		default:
+			return repositories[i].UsedSpaceBytes > repositories[j].UsedSpaceBytes
+		}
+	})
+}
+
+func (ssc *StorageSummaryCommand) printSummary() error {
+	switch ssc.outputFormat {
+	case format.Json:
+		content, err := json.Marshal(ssc.summary)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	case format.Csv:
+		content, err := gocsv.MarshalString(ssc.summary.Repositories)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(strings.TrimSuffix(content, "\n"))
+		return nil
+	case format.Table:
+		if err := coreutils.PrintTable(ssc.summary.Repositories, "Repositories Storage", "No repositories were found", false); err != nil {
+			return err
+		}
+		log.Output(fmt.Sprintf("Artifacts: %d (%s), binaries: %d (%s), dedup ratio: %.2f",
+			ssc.summary.ArtifactsCount, servicesUtils.ConvertIntToStorageSizeString(ssc.summary.ArtifactsSizeBytes),
+			ssc.summary.BinariesCount, servicesUtils.ConvertIntToStorageSizeString(ssc.summary.BinariesSizeBytes), ssc.summary.DedupRatio))
+		return nil
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s, %s", ssc.outputFormat, format.Table, format.Json, format.Csv)
+	}
+}
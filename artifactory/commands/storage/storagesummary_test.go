@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageSummary(t *testing.T) {
+	testCases := []struct {
+		name          string
+		sortBy        SortBy
+		limit         int
+		outputFormat  format.OutputFormat
+		expectedRepos []string
+	}{
+		{"size", SortBySize, 0, format.Table, []string{"maven-local", "docker-local", "npm-remote-cache"}},
+		{"files top 2", SortByFiles, 2, format.Json, []string{"docker-local", "maven-local"}},
+		{"name", SortByName, 0, format.Csv, []string{"docker-local", "maven-local", "npm-remote-cache"}},
+	}
+	testServer := createStorageInfoServer(t)
+	defer testServer.Close()
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			summaryCmd := NewStorageSummaryCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}).
+				SetSortBy(testCase.sortBy).SetLimit(testCase.limit).SetOutputFormat(testCase.outputFormat)
+			require.NoError(t, summaryCmd.Run())
+			var actualRepos []string
+			for _, repo := range summaryCmd.Summary().Repositories {
+				actualRepos = append(actualRepos, repo.RepoKey)
+			}
+			assert.Equal(t, testCase.expectedRepos, actualRepos)
+		})
+	}
+}
+
+func TestCreateStorageSummary(t *testing.T) {
+	testServer := createStorageInfoServer(t)
+	defer testServer.Close()
+	summaryCmd := NewStorageSummaryCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"})
+	require.NoError(t, summaryCmd.Run())
+
+	summary := summaryCmd.Summary()
+	assert.Equal(t, int64(1250), summary.BinariesCount)
+	assert.Equal(t, int64(2900), summary.ArtifactsCount)
+	assert.Equal(t, 2*servicesUtils.SizeGiB, summary.BinariesSizeBytes)
+	assert.Equal(t, 5*servicesUtils.SizeGiB, summary.ArtifactsSizeBytes)
+	assert.InDelta(t, 2.5, summary.DedupRatio, 0.001)
+
+	require.Len(t, summary.Repositories, 3)
+	mavenRepo := summary.Repositories[0]
+	assert.Equal(t, "maven-local", mavenRepo.RepoKey)
+	assert.Equal(t, int64(900), mavenRepo.FilesCount)
+	assert.Equal(t, int64(100), mavenRepo.FoldersCount)
+	assert.Equal(t, 3*servicesUtils.SizeGiB, mavenRepo.UsedSpaceBytes)
+}
+
+func TestStorageSummaryValidation(t *testing.T) {
+	assert.ErrorContains(t, NewStorageSummaryCommand().SetSortBy("date").Run(), "unsupported sort order 'date'")
+	assert.ErrorContains(t, NewStorageSummaryCommand().SetLimit(-1).Run(), "the limit must be a positive number")
+}
+
+func createStorageInfoServer(t *testing.T) *httptest.Server {
+	content, err := fileutils.ReadFile(filepath.Join("testdata", "storageinfo.json"))
+	require.NoError(t, err)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/storageinfo", r.URL.Path)
+		_, err := w.Write(content)
+		assert.NoError(t, err)
+	}))
+}
@@ -35,6 +35,8 @@ type TerraformPublishCommandArgs struct {
 	buildConfiguration *build.BuildConfiguration
 	collectBuildInfo   bool
 	buildProps         string
+	// In dry run mode, the modules are reported instead of being deployed, and no build-info is collected.
+	dryRun bool
 }
 
 type TerraformPublishCommand struct {
@@ -87,6 +89,11 @@ func (tpc *TerraformPublishCommand) SetConfigFilePath(configFilePath string) *Te
 	return tpc
 }
 
+// EnableDryRun implements commands.DryRunCommand.
+func (tpc *TerraformPublishCommand) EnableDryRun() {
+	tpc.dryRun = true
+}
+
 func (tpc *TerraformPublishCommand) Result() *commandsUtils.Result {
 	return tpc.result
 }
@@ -131,6 +138,10 @@ func (tpc *TerraformPublishCommand) Init() error {
 
 func (tpc *TerraformPublishCommand) publish() error {
 	log.Debug("Deploying terraform module...")
+	if tpc.dryRun {
+		tpc.collectBuildInfo = false
+		tpc.buildProps = ""
+	}
 	success, failed, err := tpc.terraformPublish()
 	if err != nil {
 		return err
@@ -200,7 +211,7 @@ func (tpc *TerraformPublishCommand) prepareTerraformPublishTasks(producer parall
 			errorsQueue.AddError(err)
 		}
 		// Walk and upload directories which contain '.tf' files.
-		err = tpc.walkDirAndUploadTerraformModules(pwd, producer, errorsQueue, uploadSummary, addTaskWithError)
+		err = tpc.walkDirAndUploadTerraformModules(pwd, producer, errorsQueue, uploadSummary, tpc.addTaskWithError)
 		if err != nil && err != io.EOF {
 			log.Error(err)
 			errorsQueue.AddError(err)
@@ -211,13 +222,13 @@ func (tpc *TerraformPublishCommand) prepareTerraformPublishTasks(producer parall
 // ProduceTaskFunc is provided as an argument to 'walkDirAndUploadTerraformModules' function for testing purposes.
 type ProduceTaskFunc func(producer parallel.Runner, serverDetails *config.ServerDetails, uploadSummary *[][]*servicesUtils.OperationSummary, uploadParams *services.UploadParams, errorsQueue *clientUtils.ErrorsQueue) (int, error)
 
-func addTaskWithError(producer parallel.Runner, serverDetails *config.ServerDetails, uploadSummary *[][]*servicesUtils.OperationSummary, uploadParams *services.UploadParams, errorsQueue *clientUtils.ErrorsQueue) (int, error) {
-	return producer.AddTaskWithError(uploadModuleTask(serverDetails, uploadSummary, uploadParams), errorsQueue.AddError)
+func (tpc *TerraformPublishCommand) addTaskWithError(producer parallel.Runner, serverDetails *config.ServerDetails, uploadSummary *[][]*servicesUtils.OperationSummary, uploadParams *services.UploadParams, errorsQueue *clientUtils.ErrorsQueue) (int, error) {
+	return producer.AddTaskWithError(uploadModuleTask(serverDetails, uploadSummary, uploadParams, tpc.dryRun), errorsQueue.AddError)
 }
 
-func uploadModuleTask(serverDetails *config.ServerDetails, uploadSummary *[][]*servicesUtils.OperationSummary, uploadParams *services.UploadParams) parallel.TaskFunc {
+func uploadModuleTask(serverDetails *config.ServerDetails, uploadSummary *[][]*servicesUtils.OperationSummary, uploadParams *services.UploadParams, dryRun bool) parallel.TaskFunc {
 	return func(threadId int) (err error) {
-		summary, err := createServiceManagerAndUpload(serverDetails, uploadParams, false)
+		summary, err := createServiceManagerAndUpload(serverDetails, uploadParams, dryRun)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,226 @@
+package transferentities
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/general/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	lifecycle "github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	buildsApi               = "api/build"
+	projectsApi             = "api/v1/projects/"
+	releaseBundleNamesApi   = "api/v2/release_bundle/names"
+	releaseBundleRecordsApi = "api/v2/release_bundle/records/"
+
+	customRoleType = "CUSTOM"
+)
+
+// entitiesClient sends the REST requests of the entities which aren't supported by the services managers.
+type entitiesClient struct {
+	artifactoryManager artifactory.ArtifactoryServicesManager
+	accessDetails      auth.ServiceDetails
+	lifecycleDetails   auth.ServiceDetails
+	client             *jfroghttpclient.JfrogHttpClient
+}
+
+func newEntitiesClient(serverDetails *config.ServerDetails, artifactoryManager artifactory.ArtifactoryServicesManager) (*entitiesClient, error) {
+	accessDetails, err := serverDetails.CreateAccessAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	lifecycleDetails, err := serverDetails.CreateLifecycleAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &entitiesClient{
+		artifactoryManager: artifactoryManager,
+		accessDetails:      accessDetails,
+		lifecycleDetails:   lifecycleDetails,
+		client:             artifactoryManager.Client(),
+	}, nil
+}
+
+type buildsResponse struct {
+	Builds []struct {
+		Uri string `json:"uri"`
+	} `json:"builds"`
+}
+
+type buildNumbersResponse struct {
+	BuildsNumbers []struct {
+		Uri string `json:"uri"`
+	} `json:"buildsNumbers"`
+}
+
+// Returns the names of the builds of the project, or of the default builds if the project key is empty.
+func (ec *entitiesClient) getBuildNames(projectKey string) ([]string, error) {
+	var response buildsResponse
+	if _, err := ec.getJson(ec.artifactoryManager.GetConfig().GetServiceDetails(), buildsApi, projectKey, &response); err != nil {
+		return nil, err
+	}
+	var buildNames []string
+	for _, build := range response.Builds {
+		buildName, err := unescapeUri(build.Uri)
+		if err != nil {
+			return nil, err
+		}
+		buildNames = append(buildNames, buildName)
+	}
+	return buildNames, nil
+}
+
+func (ec *entitiesClient) getBuildNumbers(projectKey, buildName string) ([]string, error) {
+	var response buildNumbersResponse
+	if _, err := ec.getJson(ec.artifactoryManager.GetConfig().GetServiceDetails(), buildsApi+"/"+url.PathEscape(buildName), projectKey, &response); err != nil {
+		return nil, err
+	}
+	var buildNumbers []string
+	for _, buildNumber := range response.BuildsNumbers {
+		number, err := unescapeUri(buildNumber.Uri)
+		if err != nil {
+			return nil, err
+		}
+		buildNumbers = append(buildNumbers, number)
+	}
+	return buildNumbers, nil
+}
+
+type releaseBundlesResponse struct {
+	ReleaseBundles []struct {
+		Name    string `json:"release_bundle_name"`
+		Version string `json:"release_bundle_version"`
+	} `json:"release_bundles"`
+}
+
+func (ec *entitiesClient) getReleaseBundleNames(projectKey string) ([]string, error) {
+	var response releaseBundlesResponse
+	if _, err := ec.getJson(ec.lifecycleDetails, releaseBundleNamesApi, projectKey, &response); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, releaseBundle := range response.ReleaseBundles {
+		names = append(names, releaseBundle.Name)
+	}
+	return names, nil
+}
+
+func (ec *entitiesClient) getReleaseBundleVersions(projectKey, name string) ([]string, error) {
+	var response releaseBundlesResponse
+	if _, err := ec.getJson(ec.lifecycleDetails, releaseBundleRecordsApi+url.PathEscape(name), projectKey, &response); err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, releaseBundle := range response.ReleaseBundles {
+		versions = append(versions, releaseBundle.Version)
+	}
+	return versions, nil
+}
+
+// Returns the artifacts of the release bundle version.
+func (ec *entitiesClient) getReleaseBundleSpec(projectKey, name, version string) (*lifecycle.ReleaseBundleSpecResponse, error) {
+	spec := &lifecycle.ReleaseBundleSpecResponse{}
+	found, err := ec.getJson(ec.lifecycleDetails, releaseBundleRecordsApi+url.PathEscape(name)+"/"+url.PathEscape(version), projectKey, spec)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("release bundle '%s/%s' was not found", name, version)
+	}
+	return spec, nil
+}
+
+// Returns the custom roles of the project. The predefined roles are created with the project, and are therefore omitted.
+func (ec *entitiesClient) getProjectCustomRoles(projectKey string) ([]project.ProjectRole, error) {
+	var roles []project.ProjectRole
+	if _, err := ec.getJson(ec.accessDetails, projectsApi+url.PathEscape(projectKey)+"/roles", "", &roles); err != nil {
+		return nil, err
+	}
+	var customRoles []project.ProjectRole
+	for _, role := range roles {
+		if role.Type == customRoleType {
+			customRoles = append(customRoles, role)
+		}
+	}
+	return customRoles, nil
+}
+
+// Returns the users or groups of the project with their roles. membersType is either 'users' or 'groups'.
+func (ec *entitiesClient) getProjectMembers(projectKey, membersType string) ([]project.ProjectMember, error) {
+	var response struct {
+		Members []project.ProjectMember `json:"members"`
+	}
+	if _, err := ec.getJson(ec.accessDetails, projectsApi+url.PathEscape(projectKey)+"/"+membersType, "", &response); err != nil {
+		return nil, err
+	}
+	return response.Members, nil
+}
+
+func (ec *entitiesClient) createProjectRole(projectKey string, role project.ProjectRole) error {
+	return ec.sendJson(http.MethodPost, projectsApi+url.PathEscape(projectKey)+"/roles", role, http.StatusOK, http.StatusCreated)
+}
+
+func (ec *entitiesClient) setProjectMember(projectKey, membersType string, member project.ProjectMember) error {
+	return ec.sendJson(http.MethodPut, projectsApi+url.PathEscape(projectKey)+"/"+membersType+"/"+url.PathEscape(member.Name), member, http.StatusOK, http.StatusCreated)
+}
+
+// Sends a GET request and unmarshals the response into result. The segments of the REST API path must be escaped by the caller,
+// since entity names, such as build names, may contain slashes.
+// Returns false if the entity doesn't exist, as Artifactory returns 404 if no builds or release bundles exist.
+func (ec *entitiesClient) getJson(serviceDetails auth.ServiceDetails, restApi, projectKey string, result any) (bool, error) {
+	parsedUrl, err := url.Parse(serviceDetails.GetUrl() + restApi)
+	if err = errorutils.CheckError(err); err != nil {
+		return false, err
+	}
+	if projectKey != "" {
+		parsedUrl.RawQuery = url.Values{"project": []string{projectKey}}.Encode()
+	}
+	requestUrl := parsedUrl.String()
+	httpDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := ec.client.SendGet(requestUrl, true, &httpDetails)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return false, err
+	}
+	return true, errorutils.CheckError(json.Unmarshal(body, result))
+}
+
+func (ec *entitiesClient) sendJson(method, restApi string, payload any, expectedStatusCodes ...int) error {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	httpDetails := ec.accessDetails.CreateHttpClientDetails()
+	servicesUtils.SetContentType("application/json", &httpDetails.Headers)
+	requestUrl := ec.accessDetails.GetUrl() + restApi
+	var resp *http.Response
+	var body []byte
+	if method == http.MethodPut {
+		resp, body, err = ec.client.SendPut(requestUrl, content, &httpDetails)
+	} else {
+		resp, body, err = ec.client.SendPost(requestUrl, content, &httpDetails)
+	}
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, expectedStatusCodes...)
+}
+
+func unescapeUri(uri string) (string, error) {
+	unescaped, err := url.PathUnescape(strings.TrimPrefix(uri, "/"))
+	return unescaped, errorutils.CheckError(err)
+}
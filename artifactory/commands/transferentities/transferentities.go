@@ -0,0 +1,525 @@
+package transferentities
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	commandsUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/access"
+	accessServices "github.com/jfrog/jfrog-client-go/access/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	lifecycleServices "github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+)
+
+type EntityType string
+
+const (
+	Project       EntityType = "project"
+	BuildInfo     EntityType = "build-info"
+	ReleaseBundle EntityType = "release-bundle"
+
+	planFilePrefix = "transfer-entities-plan"
+)
+
+// The entities are transferred in this order, as each entity may depend on the entities before it.
+// Build-infos and release bundles may belong to a project, and release bundles may be created from build-infos.
+var EntityTypes = []EntityType{Project, BuildInfo, ReleaseBundle}
+
+type PlanAction string
+
+const (
+	Create PlanAction = "create"
+	Skip   PlanAction = "skip"
+)
+
+type EntityStatus string
+
+const (
+	Pending     EntityStatus = "pending"
+	Transferred EntityStatus = "transferred"
+	Skipped     EntityStatus = "skipped"
+	Failed      EntityStatus = "failed"
+)
+
+// PlannedEntity is an entity of the migration plan. The plan is written as a CSV report, with the status of each entity after the transfer.
+type PlannedEntity struct {
+	Type       EntityType   `json:"type,omitempty" csv:"type" col-name:"Type"`
+	ProjectKey string       `json:"project_key,omitempty" csv:"project_key" col-name:"Project"`
+	Name       string       `json:"name,omitempty" csv:"name" col-name:"Name"`
+	Version    string       `json:"version,omitempty" csv:"version" col-name:"Version"`
+	Action     PlanAction   `json:"action,omitempty" csv:"action" col-name:"Action"`
+	Status     EntityStatus `json:"status,omitempty" csv:"status" col-name:"Status"`
+	Reason     string       `json:"reason,omitempty" csv:"reason" col-name:"Reason"`
+}
+
+func (pe *PlannedEntity) String() string {
+	name := pe.Name
+	if pe.Version != "" {
+		name += "/" + pe.Version
+	}
+	if pe.ProjectKey != "" {
+		name += fmt.Sprintf(" (project '%s')", pe.ProjectKey)
+	}
+	return fmt.Sprintf("%s '%s'", pe.Type, name)
+}
+
+// TransferEntitiesCommand migrates projects with their roles and members, published build-infos and release bundles
+// from the source instance to the target instance.
+// The repositories and their files should be transferred before, using the transfer-config and transfer-files commands,
+// as the build-infos and release bundles refer to the artifacts in the target.
+// Entities which already exist in the target are skipped. If an entity fails to transfer, the entities which depend on it are skipped.
+type TransferEntitiesCommand struct {
+	commandsUtils.TransferConfigBase
+	entityTypes             []EntityType
+	includeProjectsPatterns []string
+	excludeProjectsPatterns []string
+	includeBuildsPatterns   []string
+	excludeBuildsPatterns   []string
+	signingKey              string
+	dryRun                  bool
+	sourceClient            *entitiesClient
+	targetClient            *entitiesClient
+	targetLifecycleManager  *lifecycle.LifecycleServicesManager
+	plan                    []PlannedEntity
+}
+
+func NewTransferEntitiesCommand(sourceServer, targetServer *config.ServerDetails) *TransferEntitiesCommand {
+	return &TransferEntitiesCommand{TransferConfigBase: *commandsUtils.NewTransferConfigBase(sourceServer, targetServer), entityTypes: EntityTypes}
+}
+
+func (tec *TransferEntitiesCommand) CommandName() string {
+	return "rt_transfer_entities"
+}
+
+func (tec *TransferEntitiesCommand) SetEntityTypes(entityTypes []EntityType) *TransferEntitiesCommand {
+	tec.entityTypes = entityTypes
+	return tec
+}
+
+func (tec *TransferEntitiesCommand) SetIncludeProjectsPatterns(includeProjectsPatterns []string) *TransferEntitiesCommand {
+	tec.includeProjectsPatterns = includeProjectsPatterns
+	return tec
+}
+
+func (tec *TransferEntitiesCommand) SetExcludeProjectsPatterns(excludeProjectsPatterns []string) *TransferEntitiesCommand {
+	tec.excludeProjectsPatterns = excludeProjectsPatterns
+	return tec
+}
+
+func (tec *TransferEntitiesCommand) SetIncludeBuildsPatterns(includeBuildsPatterns []string) *TransferEntitiesCommand {
+	tec.includeBuildsPatterns = includeBuildsPatterns
+	return tec
+}
+
+func (tec *TransferEntitiesCommand) SetExcludeBuildsPatterns(excludeBuildsPatterns []string) *TransferEntitiesCommand {
+	tec.excludeBuildsPatterns = excludeBuildsPatterns
+	return tec
+}
+
+// SetSigningKey sets the name of the GPG key in the target, used to sign the created release bundles.
+func (tec *TransferEntitiesCommand) SetSigningKey(signingKey string) *TransferEntitiesCommand {
+	tec.signingKey = signingKey
+	return tec
+}
+
+// SetDryRun sets whether to only create the migration plan, without transferring the entities.
+func (tec *TransferEntitiesCommand) SetDryRun(dryRun bool) *TransferEntitiesCommand {
+	tec.dryRun = dryRun
+	return tec
+}
+
+func (tec *TransferEntitiesCommand) Plan() []PlannedEntity {
+	return tec.plan
+}
+
+// Run transfers the entities and returns the path of the migration plan report.
+func (tec *TransferEntitiesCommand) Run() (planPath string, err error) {
+	tec.LogTitle("Preparations")
+	if err = tec.initServiceManagersAndValidateServers(); err != nil {
+		return
+	}
+
+	tec.LogTitle("Creating the migration plan")
+	if err = tec.createPlan(); err != nil {
+		return
+	}
+	if tec.dryRun {
+		planPath, err = tec.writePlan()
+		if err != nil {
+			return
+		}
+		err = coreutils.PrintTable(tec.plan, "Migration Plan", "No entities to transfer were found", false)
+		return
+	}
+
+	tec.transferEntities()
+	if planPath, err = tec.writePlan(); err != nil {
+		return
+	}
+	log.Info(fmt.Sprintf("The migration plan report is available at %s", planPath))
+	return planPath, tec.getFailuresError()
+}
+
+func (tec *TransferEntitiesCommand) initServiceManagersAndValidateServers() (err error) {
+	if err = tec.CreateServiceManagers(false); err != nil {
+		return
+	}
+	if err = tec.ValidateDifferentServers(); err != nil {
+		return
+	}
+	if err = tec.ValidateAccessServerConnection(tec.SourceServerDetails, tec.SourceAccessManager); err != nil {
+		return
+	}
+	if err = tec.ValidateAccessServerConnection(tec.TargetServerDetails, tec.TargetAccessManager); err != nil {
+		return
+	}
+	if tec.sourceClient, err = newEntitiesClient(tec.SourceServerDetails, tec.SourceArtifactoryManager); err != nil {
+		return
+	}
+	if tec.targetClient, err = newEntitiesClient(tec.TargetServerDetails, tec.TargetArtifactoryManager); err != nil {
+		return
+	}
+	if slices.Contains(tec.entityTypes, ReleaseBundle) {
+		tec.targetLifecycleManager, err = utils.CreateLifecycleServiceManager(tec.TargetServerDetails, false)
+	}
+	return
+}
+
+// entitiesInventory holds the entities of an instance, by their keys.
+type entitiesInventory struct {
+	projects       []string
+	builds         map[entityKey][]string
+	releaseBundles map[entityKey][]string
+}
+
+// entityKey is the key of a build or a release bundle in the inventory.
+// The default builds and release bundles have no project key.
+type entityKey struct {
+	projectKey string
+	name       string
+}
+
+func newEntitiesInventory() *entitiesInventory {
+	return &entitiesInventory{builds: map[entityKey][]string{}, releaseBundles: map[entityKey][]string{}}
+}
+
+func (tec *TransferEntitiesCommand) createPlan() error {
+	source, err := tec.getInventory(tec.sourceClient, tec.SourceAccessManager, nil)
+	if err != nil {
+		return err
+	}
+	target, err := tec.getInventory(tec.targetClient, tec.TargetAccessManager, source)
+	if err != nil {
+		return err
+	}
+	tec.plan, err = createMigrationPlan(source, target, tec.getFilters())
+	if err != nil {
+		return err
+	}
+	var toCreate int
+	for _, entity := range tec.plan {
+		if entity.Action == Create {
+			toCreate++
+		}
+	}
+	log.Info(fmt.Sprintf("%d entities will be transferred, %d entities will be skipped.", toCreate, len(tec.plan)-toCreate))
+	return nil
+}
+
+type planFilters struct {
+	entityTypes []EntityType
+	projects    *utils.IncludeExcludeFilter
+	builds      *utils.IncludeExcludeFilter
+}
+
+func (tec *TransferEntitiesCommand) getFilters() planFilters {
+	return planFilters{
+		entityTypes: tec.entityTypes,
+		projects:    &utils.IncludeExcludeFilter{IncludePatterns: tec.includeProjectsPatterns, ExcludePatterns: tec.excludeProjectsPatterns},
+		builds:      &utils.IncludeExcludeFilter{IncludePatterns: tec.includeBuildsPatterns, ExcludePatterns: tec.excludeBuildsPatterns},
+	}
+}
+
+// Collects the entities of the instance. If source is provided, only the builds and release bundles found in the source are collected.
+func (tec *TransferEntitiesCommand) getInventory(client *entitiesClient, accessManager *access.AccessServicesManager, source *entitiesInventory) (*entitiesInventory, error) {
+	inventory := newEntitiesInventory()
+	projects, err := accessManager.GetAllProjects()
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		inventory.projects = append(inventory.projects, project.ProjectKey)
+	}
+	// Builds and release bundles are listed per project. The default ones are listed without a project key.
+	projectKeys := append([]string{""}, inventory.projects...)
+	if source != nil {
+		projectKeys = append([]string{""}, source.projects...)
+	}
+	for _, projectKey := range projectKeys {
+		if slices.Contains(tec.entityTypes, BuildInfo) {
+			if err = collectEntities(projectKey, inventory.builds, source.getNames(BuildInfo, projectKey), client.getBuildNames, client.getBuildNumbers); err != nil {
+				return nil, err
+			}
+		}
+		if slices.Contains(tec.entityTypes, ReleaseBundle) {
+			if err = collectEntities(projectKey, inventory.releaseBundles, source.getNames(ReleaseBundle, projectKey), client.getReleaseBundleNames, client.getReleaseBundleVersions); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return inventory, nil
+}
+
+// Returns the versions of the builds or the release bundles of the inventory, by their keys.
+func (ei *entitiesInventory) getEntities(entityType EntityType) map[entityKey][]string {
+	if entityType == ReleaseBundle {
+		return ei.releaseBundles
+	}
+	return ei.builds
+}
+
+// Returns the names of the builds or release bundles of the project, or nil if the inventory is nil.
+func (ei *entitiesInventory) getNames(entityType EntityType, projectKey string) []string {
+	if ei == nil {
+		return nil
+	}
+	names := []string{}
+	for key := range ei.getEntities(entityType) {
+		if key.projectKey == projectKey {
+			names = append(names, key.name)
+		}
+	}
+	return names
+}
+
+// Collects the versions of each entity of the project. If names is nil, the names of the entities are listed first.
+func collectEntities(projectKey string, entities map[entityKey][]string, names []string,
+	getNames func(projectKey string) ([]string, error), getVersions func(projectKey, name string) ([]string, error)) (err error) {
+	if names == nil {
+		if names, err = getNames(projectKey); err != nil {
+			return
+		}
+	}
+	for _, name := range names {
+		var versions []string
+		if versions, err = getVersions(projectKey, name); err != nil {
+			return
+		}
+		if len(versions) > 0 {
+			entities[entityKey{projectKey: projectKey, name: name}] = versions
+		}
+	}
+	return
+}
+
+// Creates the migration plan from the entities of the source and the target, in the transfer order.
+func createMigrationPlan(source, target *entitiesInventory, filters planFilters) ([]PlannedEntity, error) {
+	plan := []PlannedEntity{}
+	// The projects which will exist in the target after the transfer.
+	targetProjects := slices.Clone(target.projects)
+	for _, projectKey := range source.projects {
+		include, err := filters.projects.ShouldIncludeItem(projectKey)
+		if err != nil {
+			return nil, err
+		}
+		if !include || !slices.Contains(filters.entityTypes, Project) {
+			continue
+		}
+		entity := PlannedEntity{Type: Project, Name: projectKey, ProjectKey: projectKey, Action: Create, Status: Pending}
+		if slices.Contains(target.projects, projectKey) {
+			entity.Action, entity.Status, entity.Reason = Skip, Skipped, "already exists in the target"
+		} else {
+			targetProjects = append(targetProjects, projectKey)
+		}
+		plan = append(plan, entity)
+	}
+
+	for _, entityType := range []EntityType{BuildInfo, ReleaseBundle} {
+		if !slices.Contains(filters.entityTypes, entityType) {
+			continue
+		}
+		sourceEntities, targetEntities := source.getEntities(entityType), target.getEntities(entityType)
+		entityKeys := make([]entityKey, 0, len(sourceEntities))
+		for key := range sourceEntities {
+			entityKeys = append(entityKeys, key)
+		}
+		slices.SortFunc(entityKeys, func(a, b entityKey) int {
+			if a.projectKey != b.projectKey {
+				return strings.Compare(a.projectKey, b.projectKey)
+			}
+			return strings.Compare(a.name, b.name)
+		})
+		for _, key := range entityKeys {
+			projectKey, name := key.projectKey, key.name
+			if projectKey != "" {
+				if include, err := filters.projects.ShouldIncludeItem(projectKey); err != nil || !include {
+					if err != nil {
+						return nil, err
+					}
+					continue
+				}
+			}
+			if entityType == BuildInfo {
+				if include, err := filters.builds.ShouldIncludeItem(name); err != nil || !include {
+					if err != nil {
+						return nil, err
+					}
+					continue
+				}
+			}
+			for _, version := range sourceEntities[key] {
+				entity := PlannedEntity{Type: entityType, ProjectKey: projectKey, Name: name, Version: version, Action: Create, Status: Pending}
+				switch {
+				case slices.Contains(targetEntities[key], version):
+					entity.Action, entity.Status, entity.Reason = Skip, Skipped, "already exists in the target"
+				case projectKey != "" && !slices.Contains(targetProjects, projectKey):
+					entity.Action, entity.Status, entity.Reason = Skip, Skipped, fmt.Sprintf("project '%s' doesn't exist in the target", projectKey)
+				}
+				plan = append(plan, entity)
+			}
+		}
+	}
+	return plan, nil
+}
+
+// Transfers the entities of the plan in order, and updates their status.
+func (tec *TransferEntitiesCommand) transferEntities() {
+	var lastType EntityType
+	for i := range tec.plan {
+		entity := &tec.plan[i]
+		if entity.Type != lastType {
+			lastType = entity.Type
+			tec.LogTitle(fmt.Sprintf("Transferring %ss", entity.Type))
+		}
+		if entity.Status != Pending {
+			continue
+		}
+		log.Info(fmt.Sprintf("Transferring %s...", entity))
+		if err := tec.transferEntity(entity); err != nil {
+			log.Error(fmt.Sprintf("Failed to transfer %s: %s", entity, err.Error()))
+			entity.Status, entity.Reason = Failed, err.Error()
+			if entity.Type == Project {
+				skipProjectEntities(tec.plan[i+1:], entity.ProjectKey)
+			}
+			continue
+		}
+		entity.Status = Transferred
+	}
+}
+
+// Skips the pending entities of a project which failed to transfer.
+func skipProjectEntities(entities []PlannedEntity, projectKey string) {
+	for i := range entities {
+		if entities[i].ProjectKey == projectKey && entities[i].Status == Pending {
+			entities[i].Status, entities[i].Reason = Skipped, fmt.Sprintf("project '%s' failed to transfer", projectKey)
+		}
+	}
+}
+
+func (tec *TransferEntitiesCommand) transferEntity(entity *PlannedEntity) error {
+	switch entity.Type {
+	case Project:
+		return tec.transferProject(entity.ProjectKey)
+	case BuildInfo:
+		return tec.transferBuildInfo(entity.ProjectKey, entity.Name, entity.Version)
+	default:
+		return tec.transferReleaseBundle(entity.ProjectKey, entity.Name, entity.Version)
+	}
+}
+
+// Creates the project in the target, with its custom roles and the roles of its users and groups.
+func (tec *TransferEntitiesCommand) transferProject(projectKey string) error {
+	project, err := tec.SourceAccessManager.GetProject(projectKey)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return errorutils.CheckErrorf("project '%s' was not found in the source", projectKey)
+	}
+	if err = tec.TargetAccessManager.CreateProject(accessServices.ProjectParams{ProjectDetails: *project}); err != nil {
+		return err
+	}
+	roles, err := tec.sourceClient.getProjectCustomRoles(projectKey)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if err = tec.targetClient.createProjectRole(projectKey, role); err != nil {
+			return err
+		}
+	}
+	for _, membersType := range []string{"users", "groups"} {
+		members, err := tec.sourceClient.getProjectMembers(projectKey, membersType)
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			if err = tec.targetClient.setProjectMember(projectKey, membersType, member); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (tec *TransferEntitiesCommand) transferBuildInfo(projectKey, buildName, buildNumber string) error {
+	publishedBuildInfo, found, err := tec.SourceArtifactoryManager.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: buildNumber, ProjectKey: projectKey})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errorutils.CheckErrorf("build-info '%s/%s' was not found in the source", buildName, buildNumber)
+	}
+	_, err = tec.TargetArtifactoryManager.PublishBuildInfo(&publishedBuildInfo.BuildInfo, projectKey)
+	return err
+}
+
+// Creates the release bundle in the target from its artifacts, which should already be transferred to the target.
+func (tec *TransferEntitiesCommand) transferReleaseBundle(projectKey, name, version string) error {
+	spec, err := tec.sourceClient.getReleaseBundleSpec(projectKey, name, version)
+	if err != nil {
+		return err
+	}
+	artifacts := lifecycleServices.CreateFromArtifacts{}
+	for _, artifact := range spec.Artifacts {
+		artifactPath := artifact.Path
+		if artifact.SourceRepositoryKey != "" && !strings.HasPrefix(artifactPath, artifact.SourceRepositoryKey+"/") {
+			artifactPath = path.Join(artifact.SourceRepositoryKey, artifactPath)
+		}
+		artifacts.Artifacts = append(artifacts.Artifacts, lifecycleServices.ArtifactSource{Path: artifactPath, Sha256: artifact.Checksum})
+	}
+	rbDetails := lifecycleServices.ReleaseBundleDetails{ReleaseBundleName: name, ReleaseBundleVersion: version}
+	queryParams := lifecycleServices.CommonOptionalQueryParams{ProjectKey: projectKey}
+	return tec.targetLifecycleManager.CreateReleaseBundleFromArtifacts(rbDetails, queryParams, tec.signingKey, artifacts)
+}
+
+func (tec *TransferEntitiesCommand) writePlan() (string, error) {
+	if len(tec.plan) == 0 {
+		return "", nil
+	}
+	return commandsUtils.CreateCSVFile(planFilePrefix, tec.plan, time.Now())
+}
+
+func (tec *TransferEntitiesCommand) getFailuresError() error {
+	var errs []error
+	for _, entity := range tec.plan {
+		if entity.Status == Failed {
+			errs = append(errs, fmt.Errorf("failed to transfer %s: %s", entity.String(), entity.Reason))
+		}
+	}
+	if len(errs) == 0 {
+		log.Info("Entities transfer completed successfully!")
+		return nil
+	}
+	return errorutils.CheckError(errors.Join(errs...))
+}
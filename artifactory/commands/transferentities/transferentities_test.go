@@ -0,0 +1,124 @@
+package transferentities
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMigrationPlan(t *testing.T) {
+	source := &entitiesInventory{
+		projects: []string{"proj1", "proj2", "proj3"},
+		builds: map[entityKey][]string{
+			{name: "build-a"}:                      {"1", "2"},
+			{projectKey: "proj2", name: "build-b"}: {"7"},
+			{projectKey: "proj3", name: "build-c"}: {"1"},
+			{name: "ignored-build"}:                {"1"},
+		},
+		releaseBundles: map[entityKey][]string{{projectKey: "proj1", name: "rb"}: {"1.0.0"}},
+	}
+	target := &entitiesInventory{
+		projects:       []string{"proj1"},
+		builds:         map[entityKey][]string{{name: "build-a"}: {"1"}},
+		releaseBundles: map[entityKey][]string{},
+	}
+	filters := planFilters{
+		entityTypes: EntityTypes,
+		projects:    &utils.IncludeExcludeFilter{ExcludePatterns: []string{"proj3"}},
+		builds:      &utils.IncludeExcludeFilter{ExcludePatterns: []string{"ignored-*"}},
+	}
+	plan, err := createMigrationPlan(source, target, filters)
+	require.NoError(t, err)
+	expected := []PlannedEntity{
+		{Type: Project, ProjectKey: "proj1", Name: "proj1", Action: Skip, Status: Skipped, Reason: "already exists in the target"},
+		{Type: Project, ProjectKey: "proj2", Name: "proj2", Action: Create, Status: Pending},
+		{Type: BuildInfo, Name: "build-a", Version: "1", Action: Skip, Status: Skipped, Reason: "already exists in the target"},
+		{Type: BuildInfo, Name: "build-a", Version: "2", Action: Create, Status: Pending},
+		{Type: BuildInfo, ProjectKey: "proj2", Name: "build-b", Version: "7", Action: Create, Status: Pending},
+		{Type: ReleaseBundle, ProjectKey: "proj1", Name: "rb", Version: "1.0.0", Action: Create, Status: Pending},
+	}
+	assert.Equal(t, expected, plan)
+}
+
+func TestCreateMigrationPlanMissingProject(t *testing.T) {
+	source := &entitiesInventory{
+		projects:       []string{"proj1"},
+		builds:         map[entityKey][]string{{projectKey: "proj1", name: "build"}: {"1"}},
+		releaseBundles: map[entityKey][]string{},
+	}
+	target := newEntitiesInventory()
+	filters := planFilters{entityTypes: []EntityType{BuildInfo}, projects: &utils.IncludeExcludeFilter{}, builds: &utils.IncludeExcludeFilter{}}
+	plan, err := createMigrationPlan(source, target, filters)
+	require.NoError(t, err)
+	require.Len(t, plan, 1)
+	assert.Equal(t, Skip, plan[0].Action)
+	assert.Equal(t, "project 'proj1' doesn't exist in the target", plan[0].Reason)
+}
+
+func TestSkipProjectEntities(t *testing.T) {
+	entities := []PlannedEntity{
+		{Type: BuildInfo, ProjectKey: "proj1", Name: "build", Version: "1", Status: Pending},
+		{Type: BuildInfo, ProjectKey: "proj2", Name: "build", Version: "1", Status: Pending},
+		{Type: ReleaseBundle, ProjectKey: "proj1", Name: "rb", Version: "1", Status: Skipped, Reason: "already exists in the target"},
+	}
+	skipProjectEntities(entities, "proj1")
+	assert.Equal(t, Skipped, entities[0].Status)
+	assert.Equal(t, "project 'proj1' failed to transfer", entities[0].Reason)
+	assert.Equal(t, Pending, entities[1].Status)
+	assert.Equal(t, "already exists in the target", entities[2].Reason)
+}
+
+func TestCollectBuilds(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "proj1", r.URL.Query().Get("project"))
+		// Build names may contain slashes, which must be escaped in the URL.
+		switch r.URL.EscapedPath() {
+		case "/api/build":
+			writeJson(t, w, map[string]any{"builds": []map[string]string{{"uri": "/team%2Fbuild%20a"}, {"uri": "/build-b"}}})
+		case "/api/build/team%2Fbuild%20a":
+			writeJson(t, w, map[string]any{"buildsNumbers": []map[string]string{{"uri": "/1"}, {"uri": "/2"}}})
+		default:
+			// Artifactory returns 404 for builds without build numbers.
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	serverDetails := &config.ServerDetails{Url: testServer.URL + "/", ArtifactoryUrl: testServer.URL + "/"}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	require.NoError(t, err)
+	client, err := newEntitiesClient(serverDetails, servicesManager)
+	require.NoError(t, err)
+
+	builds := map[entityKey][]string{}
+	require.NoError(t, collectEntities("proj1", builds, nil, client.getBuildNames, client.getBuildNumbers))
+	assert.Equal(t, map[entityKey][]string{{projectKey: "proj1", name: "team/build a"}: {"1", "2"}}, builds)
+}
+
+func TestInventoryGetNames(t *testing.T) {
+	var nilInventory *entitiesInventory
+	assert.Nil(t, nilInventory.getNames(BuildInfo, ""))
+
+	inventory := &entitiesInventory{
+		// Build names may contain slashes.
+		builds:         map[entityKey][]string{{name: "build"}: {"1"}, {projectKey: "proj1", name: "team/other"}: {"2"}},
+		releaseBundles: map[entityKey][]string{{projectKey: "proj1", name: "rb"}: {"1.0.0"}},
+	}
+	assert.Equal(t, []string{"build"}, inventory.getNames(BuildInfo, ""))
+	assert.Equal(t, []string{"team/other"}, inventory.getNames(BuildInfo, "proj1"))
+	assert.Equal(t, []string{"rb"}, inventory.getNames(ReleaseBundle, "proj1"))
+	assert.Empty(t, inventory.getNames(ReleaseBundle, ""))
+}
+
+func writeJson(t *testing.T, w http.ResponseWriter, payload any) {
+	content, err := json.Marshal(payload)
+	require.NoError(t, err)
+	_, err = w.Write(content)
+	require.NoError(t, err)
+}
@@ -47,7 +47,7 @@ var generateDiffAqlQueryTestCases = []struct {
 func TestGenerateDiffAqlQuery(t *testing.T) {
 	for _, testCase := range generateDiffAqlQueryTestCases {
 		t.Run("", func(*testing.T) {
-			results := generateDiffAqlQuery(repo1Key, "1", "2", testCase.paginationOffset, testCase.disabledDistinctiveAql)
+			results := generateDiffAqlQuery(repo1Key, "1", "2", "", testCase.paginationOffset, testCase.disabledDistinctiveAql)
 			assert.Equal(t, testCase.expectedAql, results)
 		})
 	}
@@ -67,7 +67,7 @@ var generateDockerManifestAqlQueryTestCases = []struct {
 func TestGenerateDockerManifestAqlQuery(t *testing.T) {
 	for _, testCase := range generateDockerManifestAqlQueryTestCases {
 		t.Run("", func(*testing.T) {
-			results := generateDockerManifestAqlQuery(repo1Key, "1", "2", testCase.paginationOffset, testCase.disabledDistinctiveAql)
+			results := generateDockerManifestAqlQuery(repo1Key, "1", "2", "", testCase.paginationOffset, testCase.disabledDistinctiveAql)
 			assert.Equal(t, testCase.expectedAql, results)
 		})
 	}
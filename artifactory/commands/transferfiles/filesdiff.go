@@ -124,6 +124,10 @@ func (f *filesDiffPhase) handleTimeFrameFilesDiff(pcWrapper *producerConsumerWra
 			}
 			break
 		}
+		// The results are filtered only after checking for an empty page, as a page may be filtered out entirely.
+		if result, err = f.pathFilter.Filter(result); err != nil {
+			return err
+		}
 		files := convertResultsToFileRepresentation(result)
 		totalSize := 0
 		for _, r := range files {
@@ -214,7 +218,7 @@ func (f *filesDiffPhase) getTimeFrameFilesDiff(fromTimestamp, toTimestamp string
 }
 
 func (f *filesDiffPhase) getNonDockerTimeFrameFilesDiff(fromTimestamp, toTimestamp string, paginationOffset int) (aqlResult *servicesUtils.AqlSearchResult, err error) {
-	query := generateDiffAqlQuery(f.repoKey, fromTimestamp, toTimestamp, paginationOffset, f.disabledDistinctiveAql)
+	query := generateDiffAqlQuery(f.repoKey, fromTimestamp, toTimestamp, f.pathFilter.getCreatedAfterAqlCriteria(), paginationOffset, f.disabledDistinctiveAql)
 	return runAql(f.context, f.srcRtDetails, query)
 }
 
@@ -224,8 +228,9 @@ func (f *filesDiffPhase) getNonDockerTimeFrameFilesDiff(fromTimestamp, toTimesta
 // To avoid this situation, we look for all "manifest.json" and "list.manifest.json" files, and for each "manifest.json", we will run a search AQL in Artifactory
 // to get all artifacts in its path (that includes the "manifest.json" file itself and all its layouts).
 func (f *filesDiffPhase) getDockerTimeFrameFilesDiff(fromTimestamp, toTimestamp string, paginationOffset int) (aqlResult *servicesUtils.AqlSearchResult, err error) {
-	// Get all newly created or modified manifest files ("manifest.json" and "list.manifest.json" files)
-	query := generateDockerManifestAqlQuery(f.repoKey, fromTimestamp, toTimestamp, paginationOffset, f.disabledDistinctiveAql)
+	// Get all newly created or modified manifest files ("manifest.json" and "list.manifest.json" files).
+	// The created-after time applies to the manifests, since the layers of a new image may have been created before it.
+	query := generateDockerManifestAqlQuery(f.repoKey, fromTimestamp, toTimestamp, f.pathFilter.getCreatedAfterAqlCriteria(), paginationOffset, f.disabledDistinctiveAql)
 	manifestFilesResult, err := runAql(f.context, f.srcRtDetails, query)
 	if err != nil {
 		return
@@ -261,8 +266,8 @@ func (f *filesDiffPhase) getDockerTimeFrameFilesDiff(fromTimestamp, toTimestamp
 	return
 }
 
-func generateDiffAqlQuery(repoKey, fromTimestamp, toTimestamp string, paginationOffset int, disabledDistinctiveAql bool) string {
-	query := fmt.Sprintf(`items.find({"$and":[{"modified":{"$gte":"%s"}},{"modified":{"$lt":"%s"}},{"repo":"%s","type":"any"}]})`, fromTimestamp, toTimestamp, repoKey)
+func generateDiffAqlQuery(repoKey, fromTimestamp, toTimestamp, extraCriteria string, paginationOffset int, disabledDistinctiveAql bool) string {
+	query := fmt.Sprintf(`items.find({"$and":[{"modified":{"$gte":"%s"}},{"modified":{"$lt":"%s"}},{"repo":"%s","type":"any"}%s]})`, fromTimestamp, toTimestamp, repoKey, extraCriteria)
 	query += `.include("repo","path","name","type","modified","size")`
 	return query + generateAqlSortingPart(paginationOffset, disabledDistinctiveAql)
 }
@@ -282,9 +287,9 @@ func generateGetDirContentAqlQuery(repoKey string, paths []string) string {
 }
 
 // This function generates an AQL that searches for all files named "manifest.json" and "list.manifest.json" in a specific repository.
-func generateDockerManifestAqlQuery(repoKey, fromTimestamp, toTimestamp string, paginationOffset int, disabledDistinctiveAql bool) string {
+func generateDockerManifestAqlQuery(repoKey, fromTimestamp, toTimestamp, extraCriteria string, paginationOffset int, disabledDistinctiveAql bool) string {
 	query := `items.find({"$and":`
-	query += fmt.Sprintf(`[{"repo":"%s"},{"modified":{"$gte":"%s"}},{"modified":{"$lt":"%s"}},{"$or":[{"name":"manifest.json"},{"name":"list.manifest.json"}]}`, repoKey, fromTimestamp, toTimestamp)
+	query += fmt.Sprintf(`[{"repo":"%s"},{"modified":{"$gte":"%s"}},{"modified":{"$lt":"%s"}},{"$or":[{"name":"manifest.json"},{"name":"list.manifest.json"}]}%s`, repoKey, fromTimestamp, toTimestamp, extraCriteria)
 	query += `]}).include("repo","path","name","type","modified")`
 	return query + generateAqlSortingPart(paginationOffset, disabledDistinctiveAql)
 }
@@ -62,8 +62,11 @@ func (m *fullTransferPhase) phaseDone() error {
 // Marks the phase as completed and deletes snapshots.
 // Should ONLY be called if phase ended SUCCESSFULLY (not interrupted / stopped).
 func (m *fullTransferPhase) handleSuccessfulTransfer() error {
-	if err := m.stateManager.SetRepoFullTransferCompleted(); err != nil {
-		return err
+	// A filtered transfer doesn't transfer the entire repository, so the full transfer will run again in the next transfer.
+	if !m.pathFilter.IsEnabled() {
+		if err := m.stateManager.SetRepoFullTransferCompleted(); err != nil {
+			return err
+		}
 	}
 	// Disable repo transfer snapshot since it is not tracked by the following phases we are not handling a full transfer.
 	m.stateManager.DisableRepoTransferSnapshot()
@@ -205,6 +208,13 @@ func (m *fullTransferPhase) searchAndHandleFolderContents(params folderParams, p
 			if item.Name == "." {
 				continue
 			}
+			var included bool
+			if included, err = m.pathFilter.IsIncluded(item); err != nil {
+				return
+			}
+			if !included {
+				continue
+			}
 			switch item.Type {
 			case "folder":
 				err = m.handleFoundChildFolder(params, pcWrapper,
@@ -274,7 +284,7 @@ func getFolderRelativePath(folderName, relativeLocation string) string {
 }
 
 func (m *fullTransferPhase) getDirectoryContentAql(relativePath string, paginationOffset int) (result []servicesUtils.ResultItem, lastPage bool, err error) {
-	query := generateFolderContentAqlQuery(m.repoKey, relativePath, paginationOffset, m.disabledDistinctiveAql)
+	query := generateFolderContentAqlQuery(m.repoKey, relativePath, m.pathFilter.getCreatedAfterAqlCriteria(), paginationOffset, m.disabledDistinctiveAql)
 	aqlResults, err := runAql(m.context, m.srcRtDetails, query)
 	if err != nil {
 		return []servicesUtils.ResultItem{}, false, err
@@ -285,8 +295,8 @@ func (m *fullTransferPhase) getDirectoryContentAql(relativePath string, paginati
 	return
 }
 
-func generateFolderContentAqlQuery(repoKey, relativePath string, paginationOffset int, disabledDistinctiveAql bool) string {
-	query := fmt.Sprintf(`items.find({"type":"any","$or":[{"$and":[{"repo":"%s","path":{"$match":"%s"},"name":{"$match":"*"}}]}]})`, repoKey, relativePath)
+func generateFolderContentAqlQuery(repoKey, relativePath, extraCriteria string, paginationOffset int, disabledDistinctiveAql bool) string {
+	query := fmt.Sprintf(`items.find({"type":"any","$or":[{"$and":[{"repo":"%s","path":{"$match":"%s"},"name":{"$match":"*"}}%s]}]})`, repoKey, relativePath, extraCriteria)
 	query += `.include("repo","path","name","type","size")`
 	query += fmt.Sprintf(`.sort({"$asc":["name"]}).offset(%d).limit(%d)`, paginationOffset*AqlPaginationLimit, AqlPaginationLimit)
 	query += appendDistinctIfNeeded(disabledDistinctiveAql)
@@ -0,0 +1,120 @@
+package transferfiles
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jfrog/gofrog/stringutils"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// pathFilter restricts the transfer to specific paths inside the transferred repositories.
+// Patterns are matched against the path of the item inside the repository, for example 'org/acme/app-*.jar'.
+// A '*' wildcard matches any sequence of characters including '/', and a trailing '/' matches all the content of a folder.
+// Files created before the createdAfter time are excluded. This filter is applied in the AQL queries, and is therefore not handled here.
+type pathFilter struct {
+	includePatterns []string
+	excludePatterns []string
+	createdAfter    time.Time
+}
+
+func newPathFilter(includePatterns, excludePatterns []string, createdAfter time.Time) (*pathFilter, error) {
+	for _, patterns := range [][]string{includePatterns, excludePatterns} {
+		for _, pattern := range patterns {
+			if strings.HasPrefix(pattern, "/") {
+				return nil, errorutils.CheckErrorf("the path pattern '%s' should be relative to the repository root", pattern)
+			}
+		}
+	}
+	return &pathFilter{includePatterns: includePatterns, excludePatterns: excludePatterns, createdAfter: createdAfter}, nil
+}
+
+func (pf *pathFilter) IsEnabled() bool {
+	return pf != nil && (len(pf.includePatterns) > 0 || len(pf.excludePatterns) > 0 || !pf.createdAfter.IsZero())
+}
+
+// IsIncluded returns whether an item found by AQL should be transferred.
+// Folders are included if they may contain included files, so that their content is explored.
+func (pf *pathFilter) IsIncluded(item servicesUtils.ResultItem) (bool, error) {
+	if pf == nil {
+		return true, nil
+	}
+	pathInRepo := getPathInRepo(&item)
+	if item.Type == "folder" {
+		return pf.shouldExploreFolder(pathInRepo)
+	}
+	return pf.isFileIncluded(pathInRepo)
+}
+
+// Filter returns the AQL results which should be transferred.
+func (pf *pathFilter) Filter(items []servicesUtils.ResultItem) ([]servicesUtils.ResultItem, error) {
+	if pf == nil || len(pf.includePatterns)+len(pf.excludePatterns) == 0 {
+		return items, nil
+	}
+	var filtered []servicesUtils.ResultItem
+	for _, item := range items {
+		included, err := pf.IsIncluded(item)
+		if err != nil {
+			return nil, err
+		}
+		if included {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
+
+func (pf *pathFilter) isFileIncluded(pathInRepo string) (bool, error) {
+	excluded, err := matchAny(pf.excludePatterns, pathInRepo)
+	if err != nil || excluded {
+		return false, err
+	}
+	if len(pf.includePatterns) == 0 {
+		return true, nil
+	}
+	return matchAny(pf.includePatterns, pathInRepo)
+}
+
+// A folder is skipped if it is excluded with all of its content, or if none of the include patterns can match its content.
+func (pf *pathFilter) shouldExploreFolder(folderPath string) (bool, error) {
+	folderPath = strings.TrimSuffix(folderPath, "/") + "/"
+	excluded, err := matchAny(pf.excludePatterns, folderPath)
+	if err != nil || excluded {
+		return false, err
+	}
+	if len(pf.includePatterns) == 0 {
+		return true, nil
+	}
+	for _, pattern := range pf.includePatterns {
+		// The part of the pattern before the first wildcard must be compatible with the folder path.
+		staticPrefix, _, _ := strings.Cut(pattern, "*")
+		if strings.HasPrefix(staticPrefix, folderPath) || strings.HasPrefix(folderPath, staticPrefix) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Returns an AQL criteria excluding files created before the createdAfter time, or an empty string if no such time is set.
+// Folders are kept, so that their content is explored.
+func (pf *pathFilter) getCreatedAfterAqlCriteria() string {
+	if pf == nil || pf.createdAfter.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(`,{"$or":[{"type":"folder"},{"created":{"$gte":"%s"}}]}`, pf.createdAfter.UTC().Format(time.RFC3339))
+}
+
+func matchAny(patterns []string, str string) (bool, error) {
+	for _, pattern := range patterns {
+		matched, err := stringutils.MatchWildcardPattern(pattern, str)
+		if err != nil {
+			return false, errorutils.CheckError(err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
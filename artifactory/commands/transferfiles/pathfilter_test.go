@@ -0,0 +1,87 @@
+package transferfiles
+
+import (
+	"testing"
+	"time"
+
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var pathFilterTestCases = []struct {
+	name            string
+	includePatterns []string
+	excludePatterns []string
+	item            servicesUtils.ResultItem
+	expected        bool
+}{
+	{"no patterns", nil, nil, servicesUtils.ResultItem{Path: "org/acme", Name: "a.jar", Type: "file"}, true},
+	{"included file", []string{"org/acme/*.jar"}, nil, servicesUtils.ResultItem{Path: "org/acme", Name: "a.jar", Type: "file"}, true},
+	{"not included file", []string{"org/acme/*.jar"}, nil, servicesUtils.ResultItem{Path: "org/acme", Name: "a.pom", Type: "file"}, false},
+	{"included folder content", []string{"org/acme/"}, nil, servicesUtils.ResultItem{Path: "org/acme/app/1.0", Name: "a.pom", Type: "file"}, true},
+	{"root file", []string{"*.txt"}, nil, servicesUtils.ResultItem{Path: ".", Name: "readme.txt", Type: "file"}, true},
+	{"excluded file", nil, []string{"*-SNAPSHOT*"}, servicesUtils.ResultItem{Path: "org/acme/1.0-SNAPSHOT", Name: "a.jar", Type: "file"}, false},
+	{"exclude overrides include", []string{"org/"}, []string{"org/legacy/"}, servicesUtils.ResultItem{Path: "org/legacy", Name: "a.jar", Type: "file"}, false},
+	{"parent of included folder", []string{"org/acme/*.jar"}, nil, servicesUtils.ResultItem{Path: ".", Name: "org", Type: "folder"}, true},
+	{"child of included folder", []string{"org/acme/"}, nil, servicesUtils.ResultItem{Path: "org/acme", Name: "app", Type: "folder"}, true},
+	{"unrelated folder", []string{"org/acme/"}, nil, servicesUtils.ResultItem{Path: ".", Name: "com", Type: "folder"}, false},
+	{"sibling folder", []string{"org/acme/"}, nil, servicesUtils.ResultItem{Path: "org", Name: "acme-legacy", Type: "folder"}, false},
+	{"excluded folder", nil, []string{"org/legacy/"}, servicesUtils.ResultItem{Path: "org", Name: "legacy", Type: "folder"}, false},
+	{"folder with wildcard include", []string{"*.jar"}, nil, servicesUtils.ResultItem{Path: "org", Name: "acme", Type: "folder"}, true},
+}
+
+func TestPathFilterIsIncluded(t *testing.T) {
+	for _, testCase := range pathFilterTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			filter, err := newPathFilter(testCase.includePatterns, testCase.excludePatterns, time.Time{})
+			assert.NoError(t, err)
+			included, err := filter.IsIncluded(testCase.item)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, included)
+		})
+	}
+}
+
+func TestPathFilterFilter(t *testing.T) {
+	filter, err := newPathFilter([]string{"org/acme/"}, []string{"*.md5"}, time.Time{})
+	assert.NoError(t, err)
+	filtered, err := filter.Filter([]servicesUtils.ResultItem{
+		{Path: "org/acme", Name: "a.jar", Type: "file"},
+		{Path: "org/acme", Name: "a.jar.md5", Type: "file"},
+		{Path: "com/acme", Name: "b.jar", Type: "file"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []servicesUtils.ResultItem{{Path: "org/acme", Name: "a.jar", Type: "file"}}, filtered)
+
+	// A nil filter keeps all the results.
+	var nilFilter *pathFilter
+	assert.False(t, nilFilter.IsEnabled())
+	filtered, err = nilFilter.Filter([]servicesUtils.ResultItem{{Path: "com/acme", Name: "b.jar", Type: "file"}})
+	assert.NoError(t, err)
+	assert.Len(t, filtered, 1)
+}
+
+func TestPathFilterAbsolutePattern(t *testing.T) {
+	_, err := newPathFilter([]string{"/org/acme/"}, nil, time.Time{})
+	assert.ErrorContains(t, err, "should be relative to the repository root")
+}
+
+func TestGenerateFolderContentAqlQueryCreatedAfter(t *testing.T) {
+	filter, err := newPathFilter(nil, nil, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, filter.IsEnabled())
+	query := generateFolderContentAqlQuery(repo1Key, "a/b", filter.getCreatedAfterAqlCriteria(), 0, false)
+	assert.Equal(t, `items.find({"type":"any","$or":[{"$and":[{"repo":"repo1","path":{"$match":"a/b"},"name":{"$match":"*"}},{"$or":[{"type":"folder"},{"created":{"$gte":"2024-01-02T03:04:05Z"}}]}]}]})`+
+		`.include("repo","path","name","type","size").sort({"$asc":["name"]}).offset(0).limit(10000)`, query)
+
+	// Without the created-after time, the query is unchanged.
+	assert.NotContains(t, generateFolderContentAqlQuery(repo1Key, "a/b", (*pathFilter)(nil).getCreatedAfterAqlCriteria(), 0, false), "created")
+}
+
+func TestGenerateDockerManifestAqlQueryCreatedAfter(t *testing.T) {
+	filter, err := newPathFilter(nil, nil, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	assert.NoError(t, err)
+	query := generateDockerManifestAqlQuery(repo1Key, "1", "2", filter.getCreatedAfterAqlCriteria(), 0, false)
+	assert.Equal(t, `items.find({"$and":[{"repo":"repo1"},{"modified":{"$gte":"1"}},{"modified":{"$lt":"2"}},{"$or":[{"name":"manifest.json"},{"name":"list.manifest.json"}]},`+
+		`{"$or":[{"type":"folder"},{"created":{"$gte":"2024-01-02T03:04:05Z"}}]}]}).include("repo","path","name","type","modified").sort({"$asc":["name","path"]}).offset(0).limit(10000)`, query)
+}
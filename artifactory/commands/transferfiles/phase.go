@@ -32,6 +32,7 @@ type transferPhase interface {
 	setProgressBar(*TransferProgressMng)
 	setStateManager(stateManager *state.TransferStateManager)
 	setLocallyGeneratedFilter(locallyGeneratedFilter *locallyGeneratedFilter)
+	setPathFilter(pathFilter *pathFilter)
 	initProgressBar() error
 	setProxyKey(proxyKey string)
 	setBuildInfo(setBuildInfo bool)
@@ -60,6 +61,7 @@ type phaseBase struct {
 	transferManager           *transferManager
 	stateManager              *state.TransferStateManager
 	locallyGeneratedFilter    *locallyGeneratedFilter
+	pathFilter                *pathFilter
 	stopSignal                chan os.Signal
 	// Optimization in Artifactory version 7.37 and above enables the exclusion of setting DISTINCT in SQL queries
 	disabledDistinctiveAql bool
@@ -137,6 +139,10 @@ func (pb *phaseBase) setLocallyGeneratedFilter(locallyGeneratedFilter *locallyGe
 	pb.locallyGeneratedFilter = locallyGeneratedFilter
 }
 
+func (pb *phaseBase) setPathFilter(pathFilter *pathFilter) {
+	pb.pathFilter = pathFilter
+}
+
 func (pb *phaseBase) setBuildInfo(buildInfoRepo bool) {
 	pb.buildInfoRepo = buildInfoRepo
 }
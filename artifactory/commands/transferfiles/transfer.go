@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/jfrog/gofrog/version"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferfiles/state"
@@ -48,6 +49,12 @@ type TransferFilesCommand struct {
 	progressbar               *TransferProgressMng
 	includeReposPatterns      []string
 	excludeReposPatterns      []string
+	includePathsPatterns      []string
+	excludePathsPatterns      []string
+	createdAfter              time.Time
+	pathFilter                *pathFilter
+	verify                    bool
+	verificationReport        *VerificationReport
 	ignoreState               bool
 	proxyKey                  string
 	status                    bool
@@ -80,6 +87,17 @@ func (tdc *TransferFilesCommand) CommandName() string {
 	return "rt_transfer_files"
 }
 
+// The transfer stops gracefully on interrupt signals, and saves its state so that the next run continues from where it stopped.
+func (tdc *TransferFilesCommand) HandlesInterrupts() bool {
+	return true
+}
+
+// SetContext implements commands.ContextCommand. The transfer stops gracefully when the context is done, for example when the command times out.
+func (tdc *TransferFilesCommand) SetContext(ctx context.Context) {
+	tdc.cancelFunc()
+	tdc.context, tdc.cancelFunc = context.WithCancel(ctx)
+}
+
 func (tdc *TransferFilesCommand) SetFilestore(filestore bool) {
 	tdc.checkExistenceInFilestore = filestore
 }
@@ -92,6 +110,30 @@ func (tdc *TransferFilesCommand) SetExcludeReposPatterns(excludeReposPatterns []
 	tdc.excludeReposPatterns = excludeReposPatterns
 }
 
+// SetIncludePathsPatterns restricts the transfer to the files matching the patterns, relative to the root of each transferred repository.
+func (tdc *TransferFilesCommand) SetIncludePathsPatterns(includePathsPatterns []string) {
+	tdc.includePathsPatterns = includePathsPatterns
+}
+
+func (tdc *TransferFilesCommand) SetExcludePathsPatterns(excludePathsPatterns []string) {
+	tdc.excludePathsPatterns = excludePathsPatterns
+}
+
+// SetCreatedAfter restricts the transfer to the files created after the provided time.
+func (tdc *TransferFilesCommand) SetCreatedAfter(createdAfter time.Time) {
+	tdc.createdAfter = createdAfter
+}
+
+// SetVerify sets whether to compare the files of the source and target repositories after the transfer.
+// Missing or mismatched files are reported, and transferred again in the next run.
+func (tdc *TransferFilesCommand) SetVerify(verify bool) {
+	tdc.verify = verify
+}
+
+func (tdc *TransferFilesCommand) VerificationReport() *VerificationReport {
+	return tdc.verificationReport
+}
+
 func (tdc *TransferFilesCommand) SetIgnoreState(ignoreState bool) {
 	tdc.ignoreState = ignoreState
 }
@@ -119,6 +161,9 @@ func (tdc *TransferFilesCommand) Run() (err error) {
 	if tdc.stop {
 		return tdc.signalStop()
 	}
+	if tdc.pathFilter, err = newPathFilter(tdc.includePathsPatterns, tdc.excludePathsPatterns, tdc.createdAfter); err != nil {
+		return err
+	}
 	if err = tdc.stateManager.TryLockTransferStateManager(); err != nil {
 		return err
 	}
@@ -216,6 +261,18 @@ func (tdc *TransferFilesCommand) Run() (err error) {
 		return tdc.cleanup(err, allSourceLocalRepos)
 	}
 
+	// Verify the transferred repositories
+	if tdc.verify && !tdc.shouldStop() {
+		reportPath, err := tdc.verifyRepos(allSourceLocalRepos, append(slices.Clone(targetLocalRepos), targetBuildInfoRepos...))
+		if err != nil {
+			return tdc.cleanup(err, allSourceLocalRepos)
+		}
+		// A stopped verification doesn't write a report
+		if reportPath != "" {
+			log.Info("The verification report was written to:", reportPath)
+		}
+	}
+
 	// Close progressBar and create CSV errors summary file
 	return tdc.cleanup(err, allSourceLocalRepos)
 }
@@ -518,16 +575,21 @@ func (tdc *TransferFilesCommand) handleStop(srcUpService *srcUserPluginService)
 	signal.Notify(tdc.stopSignal, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		defer close(finishStop)
-		// Wait for the stop signal or close(stopSignal) to happen
-		if <-tdc.stopSignal == nil {
-			// The stopSignal channel is closed
-			return
-		}
-		// Before interrupting the process, do a thread dump
-		if err := doThreadDump(); err != nil {
-			log.Error(err)
+		// Wait for the stop signal, close(stopSignal) or the cancellation of the command context to happen
+		select {
+		case stopSignal := <-tdc.stopSignal:
+			if stopSignal == nil {
+				// The stopSignal channel is closed
+				return
+			}
+			// Before interrupting the process, do a thread dump
+			if err := doThreadDump(); err != nil {
+				log.Error(err)
+			}
+			tdc.cancelFunc()
+		case <-tdc.context.Done():
+			// The command context is cancelled, for example when the command times out
 		}
-		tdc.cancelFunc()
 		if newPhase != nil {
 			newPhase.StopGracefully()
 		}
@@ -563,6 +625,7 @@ func (tdc *TransferFilesCommand) initNewPhase(newPhase transferPhase, srcUpServi
 	newPhase.setBuildInfo(buildInfoRepo)
 	newPhase.setPackageType(repoSummary.PackageType)
 	newPhase.setLocallyGeneratedFilter(tdc.locallyGeneratedFilter)
+	newPhase.setPathFilter(tdc.pathFilter)
 	newPhase.setStopSignal(tdc.stopSignal)
 	newPhase.setMinCheckSumDeploySize(minChecksumDeploySize)
 }
@@ -44,6 +44,18 @@ func TestCancelFunc(t *testing.T) {
 	assert.True(t, transferFilesCommand.shouldStop())
 }
 
+func TestSetContext(t *testing.T) {
+	transferFilesCommand, err := NewTransferFilesCommand(nil, nil)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	transferFilesCommand.SetContext(ctx)
+	assert.False(t, transferFilesCommand.shouldStop())
+
+	// Cancelling the command context should stop the transfer
+	cancel()
+	assert.True(t, transferFilesCommand.shouldStop())
+}
+
 func TestSignalStop(t *testing.T) {
 	cleanUpJfrogHome, err := tests.SetJfrogHome()
 	assert.NoError(t, err)
@@ -0,0 +1,214 @@
+package transferfiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferfiles/api"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	missingInTargetReason   = "missing in the target repository"
+	checksumMismatchReason  = "checksum mismatch between the source and target repositories"
+	verificationReportFile  = "verification-report"
+	verificationErrorsPhase = "verify"
+)
+
+// VerificationReport lists the files of the transferred repositories which are missing in the target, or differ from the source.
+// These files are written to the retryable errors files, so that they are transferred again in the next run.
+type VerificationReport struct {
+	Repositories []RepoVerification `json:"repositories"`
+}
+
+type RepoVerification struct {
+	RepoKey     string              `json:"repoKey"`
+	SourceFiles int                 `json:"sourceFiles"`
+	TargetFiles int                 `json:"targetFiles"`
+	Missing     []VerificationIssue `json:"missing"`
+	Mismatched  []VerificationIssue `json:"mismatched"`
+}
+
+type VerificationIssue struct {
+	Path       string `json:"path"`
+	SourceSha1 string `json:"sourceSha1"`
+	SourceSize int64  `json:"sourceSize"`
+	TargetSha1 string `json:"targetSha1,omitempty"`
+	TargetSize int64  `json:"targetSize,omitempty"`
+}
+
+func (rv *RepoVerification) issuesCount() int {
+	return len(rv.Missing) + len(rv.Mismatched)
+}
+
+// Compares the files of each transferred repository between the source and the target, after the transfer.
+// Locally generated files are excluded, as they are generated by the target Artifactory and may differ from the source.
+// Returns the path of the written verification report, or an empty path if the verification was stopped.
+func (tdc *TransferFilesCommand) verifyRepos(sourceRepos, targetRepos []string) (reportPath string, err error) {
+	log.Info("Verifying the transferred repositories...")
+	tdc.verificationReport = &VerificationReport{Repositories: []RepoVerification{}}
+	for _, repoKey := range sourceRepos {
+		if tdc.shouldStop() {
+			return
+		}
+		if !slices.Contains(targetRepos, repoKey) {
+			continue
+		}
+		var repoVerification *RepoVerification
+		if repoVerification, err = tdc.verifyRepo(repoKey); err != nil {
+			return
+		}
+		if err = requeueVerificationIssues(repoVerification, tdc.stateManager.ChangeTransferFailureCountBy); err != nil {
+			return
+		}
+		tdc.verificationReport.Repositories = append(tdc.verificationReport.Repositories, *repoVerification)
+		if repoVerification.issuesCount() > 0 {
+			log.Warn(fmt.Sprintf("Repository '%s': %d files are missing and %d files differ in the target. They will be transferred again in the next run.",
+				repoKey, len(repoVerification.Missing), len(repoVerification.Mismatched)))
+		} else {
+			log.Info(fmt.Sprintf("Repository '%s' was verified successfully.", repoKey))
+		}
+	}
+	return writeVerificationReport(tdc.verificationReport)
+}
+
+func (tdc *TransferFilesCommand) verifyRepo(repoKey string) (*RepoVerification, error) {
+	sourceFiles, err := tdc.getRepoFiles(tdc.sourceServerDetails, repoKey, tdc.pathFilter.getCreatedAfterAqlCriteria())
+	if err != nil {
+		return nil, err
+	}
+	if sourceFiles, err = tdc.pathFilter.Filter(sourceFiles); err != nil {
+		return nil, err
+	}
+	if sourceFiles, err = tdc.locallyGeneratedFilter.FilterLocallyGenerated(sourceFiles); err != nil {
+		return nil, err
+	}
+	targetFiles, err := tdc.getRepoFiles(tdc.targetServerDetails, repoKey, "")
+	if err != nil {
+		return nil, err
+	}
+	return compareRepoFiles(repoKey, sourceFiles, targetFiles), nil
+}
+
+// Returns all the files in the repository, using paginated AQL queries.
+func (tdc *TransferFilesCommand) getRepoFiles(serverDetails *config.ServerDetails, repoKey, extraCriteria string) ([]servicesUtils.ResultItem, error) {
+	var files []servicesUtils.ResultItem
+	for paginationI := 0; ; paginationI++ {
+		result, err := runAql(tdc.context, serverDetails, generateRepoFilesAqlQuery(repoKey, extraCriteria, paginationI, tdc.disabledDistinctiveAql))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, result.Results...)
+		if len(result.Results) < AqlPaginationLimit {
+			return files, nil
+		}
+	}
+}
+
+func generateRepoFilesAqlQuery(repoKey, extraCriteria string, paginationOffset int, disabledDistinctiveAql bool) string {
+	query := fmt.Sprintf(`items.find({"$and":[{"repo":"%s","type":"file"}%s]})`, repoKey, extraCriteria)
+	query += `.include("repo","path","name","type","size","actual_sha1")`
+	query += fmt.Sprintf(`.sort({"$asc":["path","name"]}).offset(%d).limit(%d)`, paginationOffset*AqlPaginationLimit, AqlPaginationLimit)
+	return query + appendDistinctIfNeeded(disabledDistinctiveAql)
+}
+
+func compareRepoFiles(repoKey string, sourceFiles, targetFiles []servicesUtils.ResultItem) *RepoVerification {
+	repoVerification := &RepoVerification{
+		RepoKey:     repoKey,
+		SourceFiles: len(sourceFiles),
+		TargetFiles: len(targetFiles),
+		Missing:     []VerificationIssue{},
+		Mismatched:  []VerificationIssue{},
+	}
+	targetFilesMap := make(map[string]servicesUtils.ResultItem, len(targetFiles))
+	for _, targetFile := range targetFiles {
+		targetFilesMap[getPathInRepo(&targetFile)] = targetFile
+	}
+	for _, sourceFile := range sourceFiles {
+		pathInRepo := getPathInRepo(&sourceFile)
+		issue := VerificationIssue{Path: pathInRepo, SourceSha1: sourceFile.Actual_Sha1, SourceSize: sourceFile.Size}
+		targetFile, exists := targetFilesMap[pathInRepo]
+		if !exists {
+			repoVerification.Missing = append(repoVerification.Missing, issue)
+			continue
+		}
+		if targetFile.Actual_Sha1 != sourceFile.Actual_Sha1 || targetFile.Size != sourceFile.Size {
+			issue.TargetSha1, issue.TargetSize = targetFile.Actual_Sha1, targetFile.Size
+			repoVerification.Mismatched = append(repoVerification.Mismatched, issue)
+		}
+	}
+	return repoVerification
+}
+
+// Writes the missing and mismatched files to a retryable errors file of the repository, to transfer them again in the next run.
+func requeueVerificationIssues(repoVerification *RepoVerification, changeFailureCount func(uint64, bool) error) error {
+	if repoVerification.issuesCount() == 0 {
+		return nil
+	}
+	filesErrors := FilesErrors{}
+	timestamp := time.Now().Format(time.RFC3339)
+	addIssues := func(issues []VerificationIssue, reason string) {
+		for _, issue := range issues {
+			dir, name := path.Split(issue.Path)
+			if dir == "" {
+				dir = "."
+			}
+			filesErrors.Errors = append(filesErrors.Errors, ExtendedFileUploadStatusResponse{
+				FileUploadStatusResponse: api.FileUploadStatusResponse{
+					FileRepresentation: api.FileRepresentation{Repo: repoVerification.RepoKey, Path: path.Clean(dir), Name: name, Size: issue.SourceSize},
+					SizeBytes:          issue.SourceSize,
+					Status:             api.Fail,
+					Reason:             reason,
+				},
+				Time: timestamp,
+			})
+		}
+	}
+	addIssues(repoVerification.Missing, missingInTargetReason)
+	addIssues(repoVerification.Mismatched, checksumMismatchReason)
+
+	if err := initTransferErrorsDir(repoVerification.RepoKey); err != nil {
+		return err
+	}
+	retryableDir, err := getJfrogTransferRepoRetryableDir(repoVerification.RepoKey)
+	if err != nil {
+		return err
+	}
+	errorsFilePath, err := getUniqueErrorOrDelayFilePath(retryableDir, func() string {
+		return fmt.Sprintf("%s-%s-%s", repoVerification.RepoKey, verificationErrorsPhase, strconv.FormatInt(time.Now().Unix(), 10))
+	})
+	if err != nil {
+		return err
+	}
+	if err = writeJsonFile(errorsFilePath, filesErrors); err != nil {
+		return err
+	}
+	return changeFailureCount(uint64(repoVerification.issuesCount()), true)
+}
+
+func writeVerificationReport(report *VerificationReport) (string, error) {
+	transferDir, err := coreutils.GetJfrogTransferDir()
+	if err != nil {
+		return "", err
+	}
+	reportPath := filepath.Join(transferDir, fmt.Sprintf("%s-%d.json", verificationReportFile, time.Now().Unix()))
+	return reportPath, writeJsonFile(reportPath, report)
+}
+
+func writeJsonFile(filePath string, payload any) error {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(filePath, content, 0600))
+}
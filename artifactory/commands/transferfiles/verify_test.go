@@ -0,0 +1,69 @@
+package transferfiles
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferfiles/api"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareRepoFiles(t *testing.T) {
+	sourceFiles := []servicesUtils.ResultItem{
+		{Repo: testRepoKey, Path: ".", Name: "root.txt", Actual_Sha1: "sha1-root", Size: 1},
+		{Repo: testRepoKey, Path: "a/b", Name: "same.jar", Actual_Sha1: "sha1-same", Size: 2},
+		{Repo: testRepoKey, Path: "a/b", Name: "changed.jar", Actual_Sha1: "sha1-new", Size: 3},
+		{Repo: testRepoKey, Path: "a", Name: "missing.jar", Actual_Sha1: "sha1-missing", Size: 4},
+	}
+	targetFiles := []servicesUtils.ResultItem{
+		{Repo: testRepoKey, Path: ".", Name: "root.txt", Actual_Sha1: "sha1-root", Size: 1},
+		{Repo: testRepoKey, Path: "a/b", Name: "same.jar", Actual_Sha1: "sha1-same", Size: 2},
+		{Repo: testRepoKey, Path: "a/b", Name: "changed.jar", Actual_Sha1: "sha1-old", Size: 3},
+		{Repo: testRepoKey, Path: "a", Name: "target-only.jar", Actual_Sha1: "sha1-target", Size: 5},
+	}
+	repoVerification := compareRepoFiles(testRepoKey, sourceFiles, targetFiles)
+	assert.Equal(t, 4, repoVerification.SourceFiles)
+	assert.Equal(t, 4, repoVerification.TargetFiles)
+	assert.Equal(t, []VerificationIssue{{Path: "a/missing.jar", SourceSha1: "sha1-missing", SourceSize: 4}}, repoVerification.Missing)
+	assert.Equal(t, []VerificationIssue{{Path: "a/b/changed.jar", SourceSha1: "sha1-new", SourceSize: 3, TargetSha1: "sha1-old", TargetSize: 3}}, repoVerification.Mismatched)
+}
+
+func TestRequeueVerificationIssues(t *testing.T) {
+	cleanUpJfrogHome, err := tests.SetJfrogHome()
+	assert.NoError(t, err)
+	defer cleanUpJfrogHome()
+
+	var failuresCount uint64
+	changeFailureCount := func(count uint64, increase bool) error {
+		assert.True(t, increase)
+		failuresCount += count
+		return nil
+	}
+	repoVerification := &RepoVerification{
+		RepoKey:    testRepoKey,
+		Missing:    []VerificationIssue{{Path: "root.txt", SourceSize: 1}},
+		Mismatched: []VerificationIssue{{Path: "a/b/changed.jar", SourceSize: 3}},
+	}
+	assert.NoError(t, requeueVerificationIssues(repoVerification, changeFailureCount))
+	assert.Equal(t, uint64(2), failuresCount)
+
+	// The issues should be retried in the next run, as any other retryable error.
+	errorsFiles, err := getErrorsFiles([]string{testRepoKey}, true)
+	assert.NoError(t, err)
+	require.Len(t, errorsFiles, 1)
+	filesErrors, err := readErrorFile(errorsFiles[0])
+	assert.NoError(t, err)
+	require.Len(t, filesErrors.Errors, 2)
+	assert.Equal(t, api.FileRepresentation{Repo: testRepoKey, Path: ".", Name: "root.txt", Size: 1}, filesErrors.Errors[0].FileRepresentation)
+	assert.Equal(t, missingInTargetReason, filesErrors.Errors[0].Reason)
+	assert.Equal(t, api.FileRepresentation{Repo: testRepoKey, Path: "a/b", Name: "changed.jar", Size: 3}, filesErrors.Errors[1].FileRepresentation)
+	assert.Equal(t, api.Fail, filesErrors.Errors[1].Status)
+
+	// Nothing is written if the repository was verified successfully.
+	assert.NoError(t, requeueVerificationIssues(&RepoVerification{RepoKey: testRepoKey}, changeFailureCount))
+	errorsFiles, err = getErrorsFiles([]string{testRepoKey}, true)
+	assert.NoError(t, err)
+	assert.Len(t, errorsFiles, 1)
+}
@@ -0,0 +1,67 @@
+package usersmanagement
+
+import (
+	"fmt"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// GroupsImportCommand creates multiple groups with their details and members, usually read from a bulk import file.
+type GroupsImportCommand struct {
+	serverDetails   *config.ServerDetails
+	groups          []services.Group
+	replaceIfExists bool
+}
+
+func NewGroupsImportCommand() *GroupsImportCommand {
+	return &GroupsImportCommand{}
+}
+
+func (gic *GroupsImportCommand) SetGroups(groups []services.Group) *GroupsImportCommand {
+	gic.groups = groups
+	return gic
+}
+
+func (gic *GroupsImportCommand) Groups() []services.Group {
+	return gic.groups
+}
+
+func (gic *GroupsImportCommand) SetReplaceIfExists(replaceIfExists bool) *GroupsImportCommand {
+	gic.replaceIfExists = replaceIfExists
+	return gic
+}
+
+func (gic *GroupsImportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return gic.serverDetails, nil
+}
+
+func (gic *GroupsImportCommand) SetServerDetails(serverDetails *config.ServerDetails) *GroupsImportCommand {
+	gic.serverDetails = serverDetails
+	return gic
+}
+
+func (gic *GroupsImportCommand) CommandName() string {
+	return "rt_groups_import"
+}
+
+func (gic *GroupsImportCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(gic.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range gic.groups {
+		log.Info(fmt.Sprintf("Creating group %s...", group.Name))
+		params := new(services.GroupParams)
+		params.GroupDetails = group
+		params.IncludeUsers = len(group.UsersNames) > 0
+		params.ReplaceIfExists = gic.replaceIfExists
+		if err = servicesManager.CreateGroup(*params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package usersmanagement
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+)
+
+type GroupsListCommand struct {
+	serverDetails *config.ServerDetails
+	outputFormat  format.OutputFormat
+}
+
+type groupRow struct {
+	Name string `col-name:"Name" json:"name"`
+}
+
+func NewGroupsListCommand() *GroupsListCommand {
+	return &GroupsListCommand{outputFormat: format.Table}
+}
+
+func (glc *GroupsListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return glc.serverDetails, nil
+}
+
+func (glc *GroupsListCommand) SetServerDetails(serverDetails *config.ServerDetails) *GroupsListCommand {
+	glc.serverDetails = serverDetails
+	return glc
+}
+
+// SetOutputFormat sets the output format - 'table' or 'json'.
+func (glc *GroupsListCommand) SetOutputFormat(outputFormat format.OutputFormat) *GroupsListCommand {
+	glc.outputFormat = outputFormat
+	return glc
+}
+
+func (glc *GroupsListCommand) CommandName() string {
+	return "rt_groups_list"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (glc *GroupsListCommand) IsReadOnly() bool {
+	return true
+}
+
+func (glc *GroupsListCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(glc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	groups, err := servicesManager.GetAllGroups()
+	if err != nil {
+		return err
+	}
+	var rows []groupRow
+	if groups != nil {
+		for _, group := range *groups {
+			rows = append(rows, groupRow{Name: group})
+		}
+	}
+	return printList(rows, glc.outputFormat, "Groups", "No groups were found")
+}
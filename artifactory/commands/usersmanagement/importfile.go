@@ -0,0 +1,103 @@
+package usersmanagement
+
+import (
+	"github.com/gocarina/gocsv"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v3"
+	"path/filepath"
+	"strings"
+)
+
+// The users and groups of a bulk import are read from a CSV or a YAML file, according to the file extension.
+// In CSV files, the lists of groups or users are comma separated, for example: alice,alice@example.com,"readers,deployers"
+type userRecord struct {
+	Name     string `csv:"username" yaml:"username"`
+	Email    string `csv:"email" yaml:"email"`
+	Password string `csv:"password,omitempty" yaml:"password,omitempty"`
+	Admin    *bool  `csv:"admin,omitempty" yaml:"admin,omitempty"`
+	Groups   string `csv:"groups,omitempty" yaml:"-"`
+	// YAML files list the groups as a sequence.
+	GroupsList []string `csv:"-" yaml:"groups,omitempty"`
+}
+
+type groupRecord struct {
+	Name            string   `csv:"name" yaml:"name"`
+	Description     string   `csv:"description,omitempty" yaml:"description,omitempty"`
+	AutoJoin        *bool    `csv:"autoJoin,omitempty" yaml:"autoJoin,omitempty"`
+	AdminPrivileges *bool    `csv:"adminPrivileges,omitempty" yaml:"adminPrivileges,omitempty"`
+	Users           string   `csv:"users,omitempty" yaml:"-"`
+	UsersList       []string `csv:"-" yaml:"users,omitempty"`
+}
+
+// ReadUsersFile reads the users of a bulk import. Each user should have a username and an email.
+func ReadUsersFile(filePath string) ([]services.User, error) {
+	var records []userRecord
+	if err := readRecordsFile(filePath, &records); err != nil {
+		return nil, err
+	}
+	var users []services.User
+	for _, record := range records {
+		if record.Name == "" || record.Email == "" {
+			return nil, errorutils.CheckErrorf("%s: a username and an email are mandatory for each user", filePath)
+		}
+		user := services.User{Name: record.Name, Email: record.Email, Password: record.Password, Admin: record.Admin}
+		if groups := append(splitList(record.Groups), record.GroupsList...); len(groups) > 0 {
+			user.Groups = &groups
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// ReadGroupsFile reads the groups of a bulk import. Each group should have a name.
+func ReadGroupsFile(filePath string) ([]services.Group, error) {
+	var records []groupRecord
+	if err := readRecordsFile(filePath, &records); err != nil {
+		return nil, err
+	}
+	var groups []services.Group
+	for _, record := range records {
+		if record.Name == "" {
+			return nil, errorutils.CheckErrorf("%s: a name is mandatory for each group", filePath)
+		}
+		groups = append(groups, services.Group{
+			Name:            record.Name,
+			Description:     record.Description,
+			AutoJoin:        record.AutoJoin,
+			AdminPrivileges: record.AdminPrivileges,
+			UsersNames:      append(splitList(record.Users), record.UsersList...),
+		})
+	}
+	return groups, nil
+}
+
+func readRecordsFile(filePath string, records any) error {
+	content, err := fileutils.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".csv":
+		err = gocsv.UnmarshalBytes(content, records)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, records)
+	default:
+		return errorutils.CheckErrorf("unsupported file type '%s'. Possible types are: csv, yaml", filePath)
+	}
+	if err != nil {
+		return errorutils.CheckErrorf("failed to parse %s: %s", filePath, err.Error())
+	}
+	return nil
+}
+
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,49 @@
+package usersmanagement
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadUsersFile(t *testing.T) {
+	for _, fileName := range []string{"users.csv", "users.yaml"} {
+		t.Run(fileName, func(t *testing.T) {
+			users, err := ReadUsersFile(filepath.Join("testdata", fileName))
+			require.NoError(t, err)
+			require.Len(t, users, 2)
+			assert.Equal(t, "alice", users[0].Name)
+			assert.Equal(t, "alice@example.com", users[0].Email)
+			assert.True(t, *users[0].Admin)
+			assert.Equal(t, []string{"readers", "deployers"}, *users[0].Groups)
+			assert.Nil(t, users[1].Admin)
+			assert.Nil(t, users[1].Groups)
+		})
+	}
+}
+
+func TestReadGroupsFile(t *testing.T) {
+	groups, err := ReadGroupsFile(filepath.Join("testdata", "groups.csv"))
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "Read only", groups[0].Description)
+	assert.True(t, *groups[0].AutoJoin)
+	assert.Equal(t, []string{"alice", "bob"}, groups[0].UsersNames)
+	assert.Empty(t, groups[1].UsersNames)
+}
+
+func TestReadUsersFileErrors(t *testing.T) {
+	tempDir := t.TempDir()
+	missingEmail := filepath.Join(tempDir, "users.csv")
+	assert.NoError(t, os.WriteFile(missingEmail, []byte("username,email\nalice,\n"), 0600))
+	_, err := ReadUsersFile(missingEmail)
+	assert.ErrorContains(t, err, "a username and an email are mandatory")
+
+	unsupported := filepath.Join(tempDir, "users.txt")
+	assert.NoError(t, os.WriteFile(unsupported, []byte("alice"), 0600))
+	_, err = ReadUsersFile(unsupported)
+	assert.ErrorContains(t, err, "unsupported file type")
+}
@@ -64,7 +64,10 @@ func (ucc *UsersCreateCommand) Run() error {
 
 	for _, user := range ucc.users {
 		log.Info(fmt.Sprintf("Creating user %s...", user.Name))
-		user.Groups = ucc.usersGroups
+		// Groups provided for all the users override the groups of each user, as read from a bulk import file.
+		if ucc.usersGroups != nil {
+			user.Groups = ucc.usersGroups
+		}
 		params := new(services.UserParams)
 		params.UserDetails = user
 		params.ReplaceIfExists = ucc.ReplaceIfExists()
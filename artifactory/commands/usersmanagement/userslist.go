@@ -0,0 +1,88 @@
+package usersmanagement
+
+import (
+	"encoding/json"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type UsersListCommand struct {
+	serverDetails *config.ServerDetails
+	outputFormat  format.OutputFormat
+}
+
+type userRow struct {
+	Name  string `col-name:"Name" json:"name"`
+	Email string `col-name:"Email" json:"email,omitempty"`
+	Realm string `col-name:"Realm" json:"realm,omitempty"`
+}
+
+func NewUsersListCommand() *UsersListCommand {
+	return &UsersListCommand{outputFormat: format.Table}
+}
+
+func (ulc *UsersListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ulc.serverDetails, nil
+}
+
+func (ulc *UsersListCommand) SetServerDetails(serverDetails *config.ServerDetails) *UsersListCommand {
+	ulc.serverDetails = serverDetails
+	return ulc
+}
+
+// SetOutputFormat sets the output format - 'table' or 'json'.
+func (ulc *UsersListCommand) SetOutputFormat(outputFormat format.OutputFormat) *UsersListCommand {
+	ulc.outputFormat = outputFormat
+	return ulc
+}
+
+func (ulc *UsersListCommand) CommandName() string {
+	return "rt_users_list"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (ulc *UsersListCommand) IsReadOnly() bool {
+	return true
+}
+
+func (ulc *UsersListCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(ulc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	users, err := servicesManager.GetAllUsers()
+	if err != nil {
+		return err
+	}
+	var rows []userRow
+	for _, user := range users {
+		rows = append(rows, userRow{Name: user.Name, Email: user.Email, Realm: user.Realm})
+	}
+	return printList(rows, ulc.outputFormat, "Users", "No users were found")
+}
+
+// Prints the listed users or groups in the requested output format.
+func printList[T any](rows []T, outputFormat format.OutputFormat, title, emptyTableMessage string) error {
+	switch outputFormat {
+	case format.Json:
+		if rows == nil {
+			rows = []T{}
+		}
+		content, err := json.Marshal(rows)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	case format.Table:
+		return coreutils.PrintTable(rows, title, emptyTableMessage, false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s", outputFormat, format.Table, format.Json)
+	}
+}
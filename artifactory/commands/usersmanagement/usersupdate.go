@@ -0,0 +1,59 @@
+package usersmanagement
+
+import (
+	"fmt"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// UsersUpdateCommand updates existing users. Only the provided fields of each user are changed.
+type UsersUpdateCommand struct {
+	serverDetails *config.ServerDetails
+	users         []services.User
+}
+
+func NewUsersUpdateCommand() *UsersUpdateCommand {
+	return &UsersUpdateCommand{}
+}
+
+func (uuc *UsersUpdateCommand) SetUsers(users []services.User) *UsersUpdateCommand {
+	uuc.users = users
+	return uuc
+}
+
+func (uuc *UsersUpdateCommand) Users() []services.User {
+	return uuc.users
+}
+
+func (uuc *UsersUpdateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return uuc.serverDetails, nil
+}
+
+func (uuc *UsersUpdateCommand) SetServerDetails(serverDetails *config.ServerDetails) *UsersUpdateCommand {
+	uuc.serverDetails = serverDetails
+	return uuc
+}
+
+func (uuc *UsersUpdateCommand) CommandName() string {
+	return "rt_users_update"
+}
+
+func (uuc *UsersUpdateCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(uuc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range uuc.users {
+		log.Info(fmt.Sprintf("Updating user %s...", user.Name))
+		params := new(services.UserParams)
+		params.UserDetails = user
+		if err = servicesManager.UpdateUser(*params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
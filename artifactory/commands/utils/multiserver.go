@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ServerDeploymentResult is the result of the deployment to one of the servers, when deploying to multiple servers.
+type ServerDeploymentResult struct {
+	ServerId     string `col-name:"Server ID"`
+	Url          string `col-name:"URL"`
+	SuccessCount int    `col-name:"Succeeded"`
+	FailCount    int    `col-name:"Failed"`
+	Error        string `col-name:"Error"`
+}
+
+// ServerDeployFunc deploys to a single server, and returns the number of artifacts which were deployed successfully and which failed.
+type ServerDeployFunc func(serverDetails *config.ServerDetails) (successCount, failCount int, err error)
+
+// GetServersDetails returns the details of the configured servers with the given IDs.
+func GetServersDetails(serverIds []string) ([]*config.ServerDetails, error) {
+	var serversDetails []*config.ServerDetails
+	for _, serverId := range serverIds {
+		serverDetails, err := config.GetSpecificConfig(serverId, false, true)
+		if err != nil {
+			return nil, err
+		}
+		serversDetails = append(serversDetails, serverDetails)
+	}
+	return serversDetails, nil
+}
+
+// DeployToServers deploys to all the servers concurrently, and prints the results of each server.
+// Returns an error if the deployment to any of the servers failed.
+func DeployToServers(serversDetails []*config.ServerDetails, deploy ServerDeployFunc) ([]ServerDeploymentResult, error) {
+	results := make([]ServerDeploymentResult, len(serversDetails))
+	var wg sync.WaitGroup
+	for i, serverDetails := range serversDetails {
+		wg.Add(1)
+		go func(i int, serverDetails *config.ServerDetails) {
+			defer wg.Done()
+			results[i] = ServerDeploymentResult{ServerId: serverDetails.ServerId, Url: serverDetails.ArtifactoryUrl}
+			successCount, failCount, err := deploy(serverDetails)
+			results[i].SuccessCount, results[i].FailCount = successCount, failCount
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}(i, serverDetails)
+	}
+	wg.Wait()
+	var err error
+	for _, result := range results {
+		if result.Error != "" || result.FailCount > 0 {
+			err = errors.Join(err, fmt.Errorf("the deployment to %s finished with errors", getServerName(result)))
+		}
+	}
+	if printErr := coreutils.PrintTable(results, "Deployment results", "", false); printErr != nil {
+		log.Error(printErr)
+	}
+	return results, errorutils.CheckError(err)
+}
+
+func getServerName(result ServerDeploymentResult) string {
+	if result.ServerId != "" {
+		return "'" + result.ServerId + "'"
+	}
+	return result.Url
+}
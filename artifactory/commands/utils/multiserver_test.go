@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeployToServers(t *testing.T) {
+	serversDetails := []*config.ServerDetails{
+		{ServerId: "first", ArtifactoryUrl: "https://first.jfrog.io/artifactory/"},
+		{ServerId: "second", ArtifactoryUrl: "https://second.jfrog.io/artifactory/"},
+		{ArtifactoryUrl: "https://third.jfrog.io/artifactory/"},
+	}
+	results, err := DeployToServers(serversDetails, func(serverDetails *config.ServerDetails) (int, int, error) {
+		switch serverDetails.ServerId {
+		case "first":
+			return 2, 0, nil
+		case "second":
+			return 1, 1, nil
+		default:
+			return 0, 0, errors.New("connection refused")
+		}
+	})
+	assert.EqualError(t, err, "the deployment to 'second' finished with errors\nthe deployment to https://third.jfrog.io/artifactory/ finished with errors")
+	assert.Equal(t, []ServerDeploymentResult{
+		{ServerId: "first", Url: "https://first.jfrog.io/artifactory/", SuccessCount: 2},
+		{ServerId: "second", Url: "https://second.jfrog.io/artifactory/", SuccessCount: 1, FailCount: 1},
+		{Url: "https://third.jfrog.io/artifactory/", Error: "connection refused"},
+	}, results)
+
+	_, err = DeployToServers(serversDetails[:1], func(serverDetails *config.ServerDetails) (int, int, error) {
+		return 1, 0, nil
+	})
+	assert.NoError(t, err)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/auth"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
@@ -23,6 +24,7 @@ func GetArtifactoryNpmRepoDetails(repo string, authArtDetails *auth.ServiceDetai
 	if err != nil {
 		return "", "", err
 	}
+	config.WarnIfApiKeyEmbedded((*authArtDetails).GetPassword(), (*authArtDetails).GetAccessToken(), "npm registry authentication")
 
 	if err = utils.ValidateRepoExists(repo, *authArtDetails); err != nil {
 		return "", "", err
@@ -68,7 +68,12 @@ func UnmarshalDeployableArtifacts(deployableArtifactsFilePath, projectConfigPath
 	for _, module := range *modulesMap {
 		for _, artifact := range module {
 			if lateDeploy || artifact.DeploySucceeded {
-				artifactDetails, err := artifact.CreateFileTransferDetails(url, repo)
+				// The artifacts may be deployed to different repositories, such as the release and snapshot repositories.
+				targetRepo := artifact.TargetRepository
+				if targetRepo == "" {
+					targetRepo = repo
+				}
+				artifactDetails, err := artifact.CreateFileTransferDetails(url, targetRepo)
 				if err != nil {
 					return nil, err
 				}
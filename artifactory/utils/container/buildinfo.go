@@ -231,9 +231,9 @@ func performMultiPlatformImageSearch(imagePathPattern string, serviceManager art
 	return
 }
 
-// Digest of type sha256:30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e to
+// DigestToLayer converts a digest of type sha256:30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e to
 // sha256__30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e
-func digestToLayer(digest string) string {
+func DigestToLayer(digest string) string {
 	return strings.Replace(digest, ":", "__", 1)
 }
 
@@ -365,21 +365,21 @@ func (builder *buildInfoBuilder) createMultiPlatformBuildInfo(fatManifest *FatMa
 func (builder *buildInfoBuilder) createPushBuildProperties(imageManifest *manifest, candidateLayers map[string]*utils.ResultItem) (artifacts []buildinfo.Artifact, dependencies []buildinfo.Dependency, imageLayers []utils.ResultItem, err error) {
 	// Add artifacts.
 	artifacts = append(artifacts, getManifestArtifact(candidateLayers["manifest.json"]))
-	artifacts = append(artifacts, candidateLayers[digestToLayer(builder.imageSha2)].ToArtifact())
+	artifacts = append(artifacts, candidateLayers[DigestToLayer(builder.imageSha2)].ToArtifact())
 
 	// Add layers.
 	imageLayers = append(imageLayers, *candidateLayers["manifest.json"])
-	imageLayers = append(imageLayers, *candidateLayers[digestToLayer(builder.imageSha2)])
+	imageLayers = append(imageLayers, *candidateLayers[DigestToLayer(builder.imageSha2)])
 
 	totalLayers := len(imageManifest.Layers)
-	totalDependencies, err := builder.totalDependencies(candidateLayers[digestToLayer(builder.imageSha2)])
+	totalDependencies, err := builder.totalDependencies(candidateLayers[DigestToLayer(builder.imageSha2)])
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
 	// Add image layers as artifacts and dependencies.
 	for i := 0; i < totalLayers; i++ {
-		layerFileName := digestToLayer(imageManifest.Layers[i].Digest)
+		layerFileName := DigestToLayer(imageManifest.Layers[i].Digest)
 		item, layerExists := candidateLayers[layerFileName]
 		if !layerExists {
 			err := handleForeignLayer(imageManifest.Layers[i].MediaType, layerFileName)
@@ -423,7 +423,7 @@ func getDependenciesFromManifestConfig(candidateLayers map[string]*utils.ResultI
 	}
 
 	dependencies = append(dependencies, getManifestDependency(manifestSearchResults))
-	imageDetails, found := candidateLayers[digestToLayer(imageSha2)]
+	imageDetails, found := candidateLayers[DigestToLayer(imageSha2)]
 	if !found {
 		return nil, errorutils.CheckErrorf("failed to collect build-info. Image '" + imageSha2 + "' was not found in Artifactory")
 	}
@@ -434,7 +434,7 @@ func getDependenciesFromManifestConfig(candidateLayers map[string]*utils.ResultI
 func getDependenciesFromManifestLayer(layers map[string]*utils.ResultItem, imageManifest *manifest) ([]buildinfo.Dependency, error) {
 	var dependencies []buildinfo.Dependency
 	for i := 0; i < len(imageManifest.Layers); i++ {
-		layerFileName := digestToLayer(imageManifest.Layers[i].Digest)
+		layerFileName := DigestToLayer(imageManifest.Layers[i].Digest)
 		item, layerExists := layers[layerFileName]
 		if !layerExists {
 			if err := handleForeignLayer(imageManifest.Layers[i].MediaType, layerFileName); err != nil {
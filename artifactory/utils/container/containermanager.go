@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strings"
 
+	artutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/auth"
@@ -204,6 +205,7 @@ func ContainerManagerLogin(image *Image, config *ContainerManagerLoginConfig, co
 		}
 		password = config.ServerDetails.AccessToken
 	}
+	logDockerTlsHints(config.ServerDetails, imageRegistry, containerManager)
 	// Perform login.
 	cmd := &LoginCmd{DockerRegistry: imageRegistry, Username: username, Password: password, containerManager: containerManager}
 	err = cmd.RunCmd()
@@ -227,6 +229,22 @@ func ContainerManagerLogin(image *Image, config *ContainerManagerLoginConfig, co
 	return nil
 }
 
+// The container manager's daemon isn't configured by JFrog CLI, so the user is instructed how to configure it
+// with the same TLS settings as JFrog CLI.
+func logDockerTlsHints(serverDetails *config.ServerDetails, imageRegistry string, containerManager ContainerManagerType) {
+	if containerManager != DockerClient {
+		return
+	}
+	tlsConfig, err := artutils.GetPackageManagerTlsConfig(serverDetails)
+	if err != nil {
+		log.Debug("Failed reading the TLS settings:", err.Error())
+		return
+	}
+	for _, hint := range tlsConfig.DockerHints(imageRegistry) {
+		log.Info(hint)
+	}
+}
+
 // Version command
 // Docker-client provides an API for interacting with the Docker daemon. This cmd should be used for docker client only.
 type VersionCmd struct{}
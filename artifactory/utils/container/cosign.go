@@ -0,0 +1,248 @@
+package container
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// The cosign signatures format. The signatures of an image are stored in the same repository, as layers of an OCI image
+// tagged 'sha256-<image digest>.sig'. Each layer holds a signed 'simple signing' payload, and its signature in an annotation.
+// See https://github.com/sigstore/cosign/blob/main/specs/SIGNATURE_SPEC.md
+const (
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	SignatureAnnotation    = "dev.cosignproject.cosign/signature"
+	CertificateAnnotation  = "dev.sigstore.cosign/certificate"
+	ChainAnnotation        = "dev.sigstore.cosign/chain"
+	BundleAnnotation       = "dev.sigstore.cosign/bundle"
+
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	ociConfigMediaType   = "application/vnd.oci.image.config.v1+json"
+	cosignSignatureType  = "cosign container image signature"
+	manifestAcceptHeader = "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json, application/vnd.docker.distribution.manifest.list.v2+json, application/vnd.oci.image.index.v1+json"
+)
+
+type OciDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int               `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type OciManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Config        OciDescriptor   `json:"config"`
+	Layers        []OciDescriptor `json:"layers"`
+}
+
+// SimpleSigningPayload is the signed content of a cosign signature.
+type SimpleSigningPayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]string `json:"optional"`
+}
+
+func NewSimpleSigningPayload(dockerReference, manifestDigest string) *SimpleSigningPayload {
+	payload := &SimpleSigningPayload{}
+	payload.Critical.Identity.DockerReference = dockerReference
+	payload.Critical.Image.DockerManifestDigest = manifestDigest
+	payload.Critical.Type = cosignSignatureType
+	return payload
+}
+
+// GetSignatureTag returns the tag of the cosign signatures image of the image with the provided digest.
+func GetSignatureTag(manifestDigest string) string {
+	return strings.Replace(manifestDigest, ":", "-", 1) + ".sig"
+}
+
+func GetDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Returns the config blob of a signatures image, which lists the digests of the signature layers.
+func createSignaturesConfig(layers []OciDescriptor) ([]byte, error) {
+	diffIds := []string{}
+	for _, layer := range layers {
+		diffIds = append(diffIds, layer.Digest)
+	}
+	config := map[string]any{
+		"architecture": "",
+		"os":           "",
+		"config":       map[string]any{},
+		"rootfs":       map[string]any{"type": "layers", "diff_ids": diffIds},
+	}
+	content, err := json.Marshal(config)
+	return content, errorutils.CheckError(err)
+}
+
+// RegistryClient sends Docker Registry HTTP API V2 requests to the container registry of an image in Artifactory.
+type RegistryClient struct {
+	serviceManager artifactory.ArtifactoryServicesManager
+	// The image repository URL, for example: https://my-registry/v2/docker-local/hello-world/
+	repositoryUrl string
+}
+
+func NewRegistryClient(image *Image, serviceManager artifactory.ArtifactoryServicesManager) (*RegistryClient, error) {
+	registry, err := image.GetRegistry()
+	if err != nil {
+		return nil, err
+	}
+	longImageName, err := image.GetImageLongName()
+	if err != nil {
+		return nil, err
+	}
+	scheme := "http://"
+	if strings.HasPrefix(serviceManager.GetConfig().GetServiceDetails().GetUrl(), "https") {
+		scheme = "https://"
+	}
+	return &RegistryClient{serviceManager: serviceManager, repositoryUrl: scheme + path.Join(registry, "v2", longImageName) + "/"}, nil
+}
+
+// GetManifest returns the manifest of the provided tag or digest, and its digest. If the manifest doesn't exist, nil is returned.
+func (rc *RegistryClient) GetManifest(reference string) (content []byte, digest string, err error) {
+	httpDetails := rc.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpDetails.Headers["Accept"] = manifestAcceptHeader
+	resp, body, _, err := rc.serviceManager.Client().SendGet(rc.repositoryUrl+"manifests/"+reference, true, &httpDetails)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, "", err
+	}
+	digest = resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		digest = GetDigest(body)
+	}
+	return body, digest, nil
+}
+
+func (rc *RegistryClient) PutManifest(reference string, content []byte, mediaType string) error {
+	httpDetails := rc.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpDetails.Headers["Content-Type"] = mediaType
+	resp, body, err := rc.serviceManager.Client().SendPut(rc.repositoryUrl+"manifests/"+reference, content, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated)
+}
+
+func (rc *RegistryClient) GetBlob(digest string) ([]byte, error) {
+	httpDetails := rc.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := rc.serviceManager.Client().SendGet(rc.repositoryUrl+"blobs/"+digest, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	if GetDigest(body) != digest {
+		return nil, errorutils.CheckErrorf("the content of blob %s doesn't match its digest", digest)
+	}
+	return body, nil
+}
+
+// UploadBlob uploads the content in a single request after starting an upload session, and returns its digest.
+func (rc *RegistryClient) UploadBlob(content []byte) (string, error) {
+	digest := GetDigest(content)
+	httpDetails := rc.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := rc.serviceManager.Client().SendPost(rc.repositoryUrl+"blobs/uploads/", nil, &httpDetails)
+	if err != nil {
+		return "", err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusAccepted); err != nil {
+		return "", err
+	}
+	uploadUrl, err := rc.resolveUploadUrl(resp.Header.Get("Location"))
+	if err != nil {
+		return "", err
+	}
+	query := uploadUrl.Query()
+	query.Set("digest", digest)
+	uploadUrl.RawQuery = query.Encode()
+
+	httpDetails = rc.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpDetails.Headers["Content-Type"] = "application/octet-stream"
+	resp, body, err = rc.serviceManager.Client().SendPut(uploadUrl.String(), content, &httpDetails)
+	if err != nil {
+		return "", err
+	}
+	return digest, errorutils.CheckResponseStatusWithBody(resp, body, http.StatusCreated)
+}
+
+// The upload location may be relative to the registry URL.
+func (rc *RegistryClient) resolveUploadUrl(location string) (*url.URL, error) {
+	if location == "" {
+		return nil, errorutils.CheckErrorf("the registry didn't return the blob upload location")
+	}
+	base, err := url.Parse(rc.repositoryUrl)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	uploadUrl, err := base.Parse(location)
+	return uploadUrl, errorutils.CheckError(err)
+}
+
+// AddSignatureLayer uploads the signed payload, and adds it as a layer to the signatures image of the provided image digest.
+// Existing signatures are kept.
+func (rc *RegistryClient) AddSignatureLayer(manifestDigest string, payload []byte, annotations map[string]string) error {
+	signatureTag := GetSignatureTag(manifestDigest)
+	signaturesManifest, err := rc.GetSignaturesManifest(manifestDigest)
+	if err != nil {
+		return err
+	}
+	if signaturesManifest == nil {
+		signaturesManifest = &OciManifest{SchemaVersion: 2, MediaType: ociManifestMediaType}
+	}
+	payloadDigest, err := rc.UploadBlob(payload)
+	if err != nil {
+		return err
+	}
+	signaturesManifest.Layers = append(signaturesManifest.Layers,
+		OciDescriptor{MediaType: SimpleSigningMediaType, Digest: payloadDigest, Size: len(payload), Annotations: annotations})
+	config, err := createSignaturesConfig(signaturesManifest.Layers)
+	if err != nil {
+		return err
+	}
+	configDigest, err := rc.UploadBlob(config)
+	if err != nil {
+		return err
+	}
+	signaturesManifest.Config = OciDescriptor{MediaType: ociConfigMediaType, Digest: configDigest, Size: len(config)}
+	content, err := json.Marshal(signaturesManifest)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return rc.PutManifest(signatureTag, content, ociManifestMediaType)
+}
+
+// GetSignaturesManifest returns the manifest of the signatures image of the provided image digest, or nil if the image isn't signed.
+func (rc *RegistryClient) GetSignaturesManifest(manifestDigest string) (*OciManifest, error) {
+	content, _, err := rc.GetManifest(GetSignatureTag(manifestDigest))
+	if err != nil || content == nil {
+		return nil, err
+	}
+	signaturesManifest := &OciManifest{}
+	if err = json.Unmarshal(content, signaturesManifest); err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("failed to parse the signatures manifest: %w", err))
+	}
+	return signaturesManifest, nil
+}
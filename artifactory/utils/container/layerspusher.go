@@ -0,0 +1,552 @@
+package container
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	manifestV2MediaType  = "application/vnd.docker.distribution.manifest.v2+json"
+	imageConfigMediaType = "application/vnd.docker.container.image.v1+json"
+	gzipLayerMediaType   = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+
+	DefaultLayerChunkSize   = 32 * 1024 * 1024
+	defaultLayerPushThreads = 3
+	defaultLayerPushRetries = 3
+
+	layersPushLogPrefix = "[Docker push] "
+	// The timeout of the connection which checks whether the registry serves TLS.
+	registryProbeTimeout = 10 * time.Second
+)
+
+// The interval between the retries of a failed layer upload. A variable, since the tests modify it.
+var layerPushRetriesIntervalMilliSecs = 5000
+
+// To unmarshal the manifest.json file of an image archive, created by the 'save' command.
+type archiveManifest struct {
+	Config   string   `json:"Config"`
+	RepoTags []string `json:"RepoTags"`
+	Layers   []string `json:"Layers"`
+}
+
+// The manifest of the pushed image, in the Docker image manifest V2, schema 2 format.
+type pushedManifest struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	MediaType     string             `json:"mediaType"`
+	Config        pushedDescriptor   `json:"config"`
+	Layers        []pushedDescriptor `json:"layers"`
+}
+
+type pushedDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Size      int64  `json:"size"`
+	Digest    string `json:"digest"`
+}
+
+// A blob of the image, ready to be pushed from a local file.
+type imageBlob struct {
+	localPath string
+	pushedDescriptor
+}
+
+// LayersPusher pushes an image to an Artifactory Docker registry layer by layer, instead of running the push command of the container manager.
+// Each layer is uploaded in chunks, with its own progress indicator, and is retried separately if its upload fails.
+// Up to 'threads' layers are uploaded concurrently. Layers which already exist in the registry aren't uploaded again.
+type LayersPusher struct {
+	image            *Image
+	serviceManager   artifactory.ArtifactoryServicesManager
+	containerManager ContainerManager
+	threads          int
+	retries          int
+	chunkSize        int64
+	progress         ioUtils.ProgressMgr
+}
+
+func NewLayersPusher(image *Image, serviceManager artifactory.ArtifactoryServicesManager, containerManager ContainerManager) *LayersPusher {
+	return &LayersPusher{image: image, serviceManager: serviceManager, containerManager: containerManager,
+		threads: defaultLayerPushThreads, retries: defaultLayerPushRetries, chunkSize: DefaultLayerChunkSize}
+}
+
+func (lp *LayersPusher) SetThreads(threads int) *LayersPusher {
+	if threads > 0 {
+		lp.threads = threads
+	}
+	return lp
+}
+
+func (lp *LayersPusher) SetRetries(retries int) *LayersPusher {
+	if retries >= 0 {
+		lp.retries = retries
+	}
+	return lp
+}
+
+func (lp *LayersPusher) SetChunkSize(chunkSize int64) *LayersPusher {
+	if chunkSize > 0 {
+		lp.chunkSize = chunkSize
+	}
+	return lp
+}
+
+func (lp *LayersPusher) SetProgress(progress ioUtils.ProgressMgr) *LayersPusher {
+	lp.progress = progress
+	return lp
+}
+
+// Push saves the image to an archive using the container manager, and pushes its layers, config and manifest to the registry of the image.
+func (lp *LayersPusher) Push() (err error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	archivePath := filepath.Join(tempDir, "image.tar")
+	log.Info(layersPushLogPrefix + "Saving the image " + lp.image.Name() + " to a local archive...")
+	if err = lp.containerManager.RunNativeCmd([]string{"save", "-o", archivePath, lp.image.Name()}); err != nil {
+		return err
+	}
+	return lp.PushArchive(archivePath, tempDir)
+}
+
+// PushArchive pushes the image from an archive created by the 'save' command of the container manager.
+// The archive is extracted to the working directory, in which the compressed layers are also created.
+func (lp *LayersPusher) PushArchive(archivePath, workingDir string) error {
+	extractedDir := filepath.Join(workingDir, "extracted")
+	manifest, err := readImageArchive(archivePath, extractedDir)
+	if err != nil {
+		return err
+	}
+	repositoryUrl, err := lp.getRepositoryUrl()
+	if err != nil {
+		return err
+	}
+	tag, err := lp.image.GetImageTag()
+	if err != nil {
+		return err
+	}
+	config := &imageBlob{localPath: filepath.Join(extractedDir, filepath.FromSlash(manifest.Config))}
+	config.MediaType = imageConfigMediaType
+	if config.Digest, config.Size, err = getFileDigest(config.localPath); err != nil {
+		return err
+	}
+	layers, err := lp.pushLayers(repositoryUrl, manifest.Layers, extractedDir, workingDir)
+	if err != nil {
+		return err
+	}
+	if err = lp.pushBlobWithRetries(repositoryUrl, config, "config"); err != nil {
+		return err
+	}
+	return lp.pushManifest(repositoryUrl, tag, config, layers)
+}
+
+// Compresses and pushes the layers concurrently. Returns the descriptors of the pushed layers, by their order in the image.
+func (lp *LayersPusher) pushLayers(repositoryUrl string, layerPaths []string, extractedDir, workingDir string) ([]pushedDescriptor, error) {
+	// The same layer may appear more than once in an image, but it is pushed once.
+	blobs := map[string]*imageBlob{}
+	var uniquePaths []string
+	for _, layerPath := range layerPaths {
+		if _, exists := blobs[layerPath]; !exists {
+			blobs[layerPath] = &imageBlob{}
+			uniquePaths = append(uniquePaths, layerPath)
+		}
+	}
+	log.Info(fmt.Sprintf("%sPushing %d layers of %s, up to %d at a time...", layersPushLogPrefix, len(uniquePaths), lp.image.Name(), lp.threads))
+	var errorsLock sync.Mutex
+	var pushErrors []error
+	runner := parallel.NewRunner(lp.threads, uint(len(uniquePaths)), false)
+	go func() {
+		defer runner.Done()
+		for i, layerPath := range uniquePaths {
+			blob, layerPath, compressedPath := blobs[layerPath], layerPath, filepath.Join(workingDir, fmt.Sprintf("layer-%d.tar.gz", i))
+			label := fmt.Sprintf("layer %d/%d", i+1, len(uniquePaths))
+			_, _ = runner.AddTask(func(int) error {
+				err := prepareLayer(filepath.Join(extractedDir, filepath.FromSlash(layerPath)), compressedPath, blob)
+				if err == nil {
+					err = lp.pushBlobWithRetries(repositoryUrl, blob, label)
+				}
+				if err != nil {
+					errorsLock.Lock()
+					pushErrors = append(pushErrors, fmt.Errorf("failed pushing %s (%s): %w", label, layerPath, err))
+					errorsLock.Unlock()
+				}
+				return nil
+			})
+		}
+	}()
+	runner.Run()
+	if len(pushErrors) > 0 {
+		return nil, errors.Join(pushErrors...)
+	}
+	descriptors := make([]pushedDescriptor, 0, len(layerPaths))
+	for _, layerPath := range layerPaths {
+		descriptors = append(descriptors, blobs[layerPath].pushedDescriptor)
+	}
+	return descriptors, nil
+}
+
+// Prepares the layer for the push. Uncompressed layers are compressed with gzip, without a timestamp, so that pushing the same layer again
+// produces the same digest. Layers which are already compressed are pushed as they are.
+func prepareLayer(layerPath, compressedPath string, blob *imageBlob) (err error) {
+	blob.MediaType = gzipLayerMediaType
+	compressed, err := isGzipFile(layerPath)
+	if err != nil {
+		return err
+	}
+	if compressed {
+		blob.localPath = layerPath
+		blob.Digest, blob.Size, err = getFileDigest(layerPath)
+		return err
+	}
+	source, err := os.Open(layerPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(source.Close()))
+	}()
+	target, err := os.Create(compressedPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(target.Close()))
+	}()
+	hash := sha256.New()
+	counter := &countingWriter{}
+	gzipWriter := gzip.NewWriter(io.MultiWriter(target, hash, counter))
+	if _, err = io.Copy(gzipWriter, bufio.NewReader(source)); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	blob.localPath = compressedPath
+	blob.Digest = "sha256:" + hex.EncodeToString(hash.Sum(nil))
+	blob.Size = counter.count
+	return nil
+}
+
+type countingWriter struct {
+	count int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	cw.count += int64(len(p))
+	return len(p), nil
+}
+
+func isGzipFile(filePath string) (bool, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	header := make([]byte, 2)
+	if _, err = io.ReadFull(file, header); err != nil {
+		// Files shorter than the gzip header aren't compressed.
+		return false, nil
+	}
+	return header[0] == 0x1f && header[1] == 0x8b, nil
+}
+
+func getFileDigest(filePath string) (digest string, size int64, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", 0, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	hash := sha256.New()
+	if size, err = io.Copy(hash, file); err != nil {
+		return "", 0, errorutils.CheckError(err)
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// Pushes the blob, and retries the whole blob upload if it fails.
+func (lp *LayersPusher) pushBlobWithRetries(repositoryUrl string, blob *imageBlob, label string) error {
+	retryExecutor := utils.RetryExecutor{
+		MaxRetries:               lp.retries,
+		RetriesIntervalMilliSecs: layerPushRetriesIntervalMilliSecs,
+		ErrorMessage:             fmt.Sprintf("Failed pushing %s (%s)", label, blob.Digest),
+		LogMsgPrefix:             layersPushLogPrefix,
+		ExecutionHandler: func() (shouldRetry bool, err error) {
+			err = lp.pushBlob(repositoryUrl, blob, label)
+			return err != nil, err
+		},
+	}
+	return retryExecutor.Execute()
+}
+
+func (lp *LayersPusher) pushBlob(repositoryUrl string, blob *imageBlob, label string) (err error) {
+	exists, err := lp.isBlobExists(repositoryUrl, blob.Digest)
+	if err != nil || exists {
+		if exists {
+			log.Info(fmt.Sprintf("%s%s (%s) already exists.", layersPushLogPrefix, label, blob.Digest))
+		}
+		return err
+	}
+	var progress ioUtils.Progress
+	if lp.progress != nil {
+		progress = lp.progress.NewProgressReader(blob.Size, "Pushing "+label, blob.Digest)
+		defer lp.progress.RemoveProgress(progress.GetId())
+	}
+	location, err := lp.startBlobUpload(repositoryUrl)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(blob.localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	chunk := make([]byte, lp.chunkSize)
+	var length int
+	for offset := int64(0); offset < blob.Size; {
+		length, err = io.ReadFull(file, chunk)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return errorutils.CheckError(err)
+		}
+		if location, err = lp.uploadChunk(location, chunk[:length], offset); err != nil {
+			return err
+		}
+		offset += int64(length)
+		if progress != nil {
+			progress.SetProgress(offset)
+		} else {
+			log.Debug(fmt.Sprintf("%sPushed %d of %d bytes of %s (%s).", layersPushLogPrefix, offset, blob.Size, label, blob.Digest))
+		}
+	}
+	if err = lp.completeBlobUpload(location, blob.Digest); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("%sPushed %s (%s, %d bytes).", layersPushLogPrefix, label, blob.Digest, blob.Size))
+	return nil
+}
+
+func (lp *LayersPusher) isBlobExists(repositoryUrl, digest string) (bool, error) {
+	httpDetails := lp.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, _, err := lp.serviceManager.Client().SendHead(repositoryUrl+"/blobs/"+digest, &httpDetails)
+	if err != nil {
+		return false, err
+	}
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+// Starts an upload session, and returns its location.
+func (lp *LayersPusher) startBlobUpload(repositoryUrl string) (string, error) {
+	httpDetails := lp.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := lp.serviceManager.Client().SendPost(repositoryUrl+"/blobs/uploads/", nil, &httpDetails)
+	if err != nil {
+		return "", err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusAccepted); err != nil {
+		return "", err
+	}
+	return resolveUploadLocation(repositoryUrl, resp)
+}
+
+// Uploads a chunk of the blob, and returns the location of the next chunk.
+func (lp *LayersPusher) uploadChunk(location string, chunk []byte, offset int64) (string, error) {
+	httpDetails := lp.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpDetails.Headers["Content-Type"] = "application/octet-stream"
+	httpDetails.Headers["Content-Range"] = fmt.Sprintf("%d-%d", offset, offset+int64(len(chunk))-1)
+	resp, body, err := lp.serviceManager.Client().SendPatch(location, chunk, &httpDetails)
+	if err != nil {
+		return "", err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusAccepted, http.StatusNoContent); err != nil {
+		return "", err
+	}
+	if resp.Header.Get("Location") == "" {
+		return location, nil
+	}
+	return resolveUploadLocation(location, resp)
+}
+
+func (lp *LayersPusher) completeBlobUpload(location, digest string) error {
+	uploadUrl, err := url.Parse(location)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	query := uploadUrl.Query()
+	query.Set("digest", digest)
+	uploadUrl.RawQuery = query.Encode()
+	httpDetails := lp.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := lp.serviceManager.Client().SendPut(uploadUrl.String(), nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusCreated)
+}
+
+func (lp *LayersPusher) pushManifest(repositoryUrl, tag string, config *imageBlob, layers []pushedDescriptor) error {
+	content, err := json.Marshal(pushedManifest{SchemaVersion: 2, MediaType: manifestV2MediaType, Config: config.pushedDescriptor, Layers: layers})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	httpDetails := lp.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpDetails.Headers["Content-Type"] = manifestV2MediaType
+	resp, body, err := lp.serviceManager.Client().SendPut(repositoryUrl+"/manifests/"+tag, content, &httpDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusCreated, http.StatusOK); err != nil {
+		return err
+	}
+	log.Info(layersPushLogPrefix + "Pushed the manifest of " + lp.image.Name())
+	return nil
+}
+
+// Returns the URL of the image repository in the registry API, e.g. https://my-registry/v2/docker-local/hello-world.
+func (lp *LayersPusher) getRepositoryUrl() (string, error) {
+	registry, err := lp.image.GetRegistry()
+	if err != nil {
+		return "", err
+	}
+	longImageName, err := lp.image.GetImageLongName()
+	if err != nil {
+		return "", err
+	}
+	return getRegistryScheme(registry) + path.Join(registry, "v2", longImageName), nil
+}
+
+// Returns the scheme of the registry, which may differ from the scheme of the Artifactory URL, e.g. when the registry is exposed
+// by a reverse proxy. Like the Docker client does for insecure registries, HTTP is used only if the registry doesn't serve TLS.
+func getRegistryScheme(registry string) string {
+	address := registry
+	if _, _, err := net.SplitHostPort(registry); err != nil {
+		address = net.JoinHostPort(registry, "443")
+	}
+	// The certificate isn't verified here, since only the support of TLS is probed. It is verified by the push requests.
+	// #nosec G402
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: registryProbeTimeout}, "tcp", address, &tls.Config{InsecureSkipVerify: true})
+	if err == nil {
+		_ = conn.Close()
+		return "https://"
+	}
+	var recordHeaderErr tls.RecordHeaderError
+	if errors.As(err, &recordHeaderErr) {
+		log.Debug(layersPushLogPrefix + "The registry " + registry + " doesn't serve TLS, so HTTP is used.")
+		return "http://"
+	}
+	log.Debug(layersPushLogPrefix + "Couldn't probe the TLS support of the registry " + registry + ": " + err.Error())
+	return "https://"
+}
+
+// Resolves the location returned by the registry, which may be relative, against the URL of the request.
+func resolveUploadLocation(requestUrl string, resp *http.Response) (string, error) {
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", errorutils.CheckErrorf("the registry didn't return the location of the upload")
+	}
+	base, err := url.Parse(requestUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	resolved, err := base.Parse(location)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return resolved.String(), nil
+}
+
+// Extracts the image archive to the target directory, and returns the manifest of its image.
+func readImageArchive(archivePath, targetDir string) (*archiveManifest, error) {
+	if err := extractTar(archivePath, targetDir); err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(filepath.Join(targetDir, "manifest.json"))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed reading the manifest.json file of the image archive: %s", err.Error())
+	}
+	var manifests []archiveManifest
+	if err = json.Unmarshal(content, &manifests); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(manifests) != 1 {
+		return nil, errorutils.CheckErrorf("expected the image archive to contain a single image, but it contains %d", len(manifests))
+	}
+	if manifests[0].Config == "" || len(manifests[0].Layers) == 0 {
+		return nil, errorutils.CheckErrorf("the manifest.json file of the image archive is missing the config or the layers of the image")
+	}
+	return &manifests[0], nil
+}
+
+func extractTar(archivePath, targetDir string) (err error) {
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(archive.Close()))
+	}()
+	reader := tar.NewReader(archive)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		targetPath := filepath.Join(targetDir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(targetPath, filepath.Clean(targetDir)+string(filepath.Separator)) {
+			return errorutils.CheckErrorf("illegal path in the image archive: %s", header.Name)
+		}
+		if err = extractTarFile(reader, targetPath); err != nil {
+			return err
+		}
+	}
+}
+
+func extractTarFile(reader io.Reader, targetPath string) (err error) {
+	if err = os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	file, err := os.Create(targetPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	// #nosec G110 -- The archive was created locally by the container manager.
+	_, err = io.Copy(file, reader)
+	return errorutils.CheckError(err)
+}
@@ -0,0 +1,209 @@
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	artutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A minimal registry, implementing the blob uploads and the manifests push of the Docker registry HTTP API V2.
+type testRegistry struct {
+	lock      sync.Mutex
+	blobs     map[string][]byte
+	uploads   map[string]*bytes.Buffer
+	manifests map[string][]byte
+	// The number of chunk uploads to fail, before accepting them.
+	failedChunks int
+	patches      int
+}
+
+func newTestRegistry() *testRegistry {
+	return &testRegistry{blobs: map[string][]byte{}, uploads: map[string]*bytes.Buffer{}, manifests: map[string][]byte{}}
+}
+
+func (tr *testRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	const repoPath = "/v2/docker-local/hello"
+	switch {
+	case r.Method == http.MethodHead && strings.HasPrefix(r.URL.Path, repoPath+"/blobs/"):
+		if _, exists := tr.blobs[strings.TrimPrefix(r.URL.Path, repoPath+"/blobs/")]; !exists {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodPost && r.URL.Path == repoPath+"/blobs/uploads/":
+		uploadId := fmt.Sprintf("upload-%d", len(tr.uploads))
+		tr.uploads[uploadId] = new(bytes.Buffer)
+		// A relative location, as returned by Artifactory.
+		w.Header().Set("Location", repoPath+"/blobs/uploads/"+uploadId)
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, repoPath+"/blobs/uploads/"):
+		tr.patches++
+		upload := tr.uploads[strings.TrimPrefix(r.URL.Path, repoPath+"/blobs/uploads/")]
+		if tr.failedChunks > 0 {
+			tr.failedChunks--
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var start, end int
+		if _, err := fmt.Sscanf(r.Header.Get("Content-Range"), "%d-%d", &start, &end); err != nil || upload == nil || start != upload.Len() {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		_, _ = io.Copy(upload, r.Body)
+		w.Header().Set("Location", r.URL.Path)
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, repoPath+"/blobs/uploads/"):
+		upload := tr.uploads[strings.TrimPrefix(r.URL.Path, repoPath+"/blobs/uploads/")]
+		if upload == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		digest := r.URL.Query().Get("digest")
+		if checksum := sha256.Sum256(upload.Bytes()); digest != "sha256:"+hex.EncodeToString(checksum[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		tr.blobs[digest] = upload.Bytes()
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, repoPath+"/manifests/"):
+		content, _ := io.ReadAll(r.Body)
+		tr.manifests[strings.TrimPrefix(r.URL.Path, repoPath+"/manifests/")] = content
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// Creates an image archive, like the one created by 'docker save'. The second layer appears twice in the image.
+func createImageArchive(t *testing.T, archivePath string, config []byte, layers ...[]byte) {
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	addFile := func(name string, content []byte) {
+		require.NoError(t, writer.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := writer.Write(content)
+		require.NoError(t, err)
+	}
+	manifest := archiveManifest{Config: "config.json", RepoTags: []string{"hello:1.0"}}
+	for i, layer := range layers {
+		layerPath := fmt.Sprintf("layer%d/layer.tar", i)
+		addFile(layerPath, layer)
+		manifest.Layers = append(manifest.Layers, layerPath)
+	}
+	manifest.Layers = append(manifest.Layers, manifest.Layers[len(manifest.Layers)-1])
+	addFile("config.json", config)
+	manifestContent, err := json.Marshal([]archiveManifest{manifest})
+	require.NoError(t, err)
+	addFile("manifest.json", manifestContent)
+	require.NoError(t, writer.Close())
+	require.NoError(t, os.WriteFile(archivePath, buffer.Bytes(), 0600))
+}
+
+func createLayersPusher(t *testing.T, registry *testRegistry) *LayersPusher {
+	server := httptest.NewServer(registry)
+	t.Cleanup(server.Close)
+	serviceManager, err := artutils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}, 0, 0, false)
+	require.NoError(t, err)
+	image := NewImage(strings.TrimPrefix(server.URL, "http://") + "/docker-local/hello:1.0")
+	return NewLayersPusher(image, serviceManager, nil).SetChunkSize(100).SetThreads(2)
+}
+
+func TestLayersPusherPushArchive(t *testing.T) {
+	layerPushRetriesIntervalMilliSecs = 0
+	registry := newTestRegistry()
+	// The first chunk upload fails, and its layer is pushed again.
+	registry.failedChunks = 1
+	pusher := createLayersPusher(t, registry)
+
+	workingDir := t.TempDir()
+	archivePath := filepath.Join(workingDir, "image.tar")
+	config := []byte(`{"architecture":"amd64","os":"linux"}`)
+	var compressedLayer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressedLayer)
+	_, err := gzipWriter.Write(bytes.Repeat([]byte("compressed layer "), 20))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+	createImageArchive(t, archivePath, config, bytes.Repeat([]byte("first layer "), 100), compressedLayer.Bytes())
+	require.NoError(t, pusher.PushArchive(archivePath, workingDir))
+
+	var manifest pushedManifest
+	require.NoError(t, json.Unmarshal(registry.manifests["1.0"], &manifest))
+	assert.Equal(t, manifestV2MediaType, manifest.MediaType)
+	configChecksum := sha256.Sum256(config)
+	assert.Equal(t, "sha256:"+hex.EncodeToString(configChecksum[:]), manifest.Config.Digest)
+	assert.Equal(t, config, registry.blobs[manifest.Config.Digest])
+	require.Len(t, manifest.Layers, 3)
+	assert.Equal(t, manifest.Layers[1], manifest.Layers[2])
+	for _, layer := range manifest.Layers {
+		assert.Equal(t, gzipLayerMediaType, layer.MediaType)
+		assert.Equal(t, layer.Size, int64(len(registry.blobs[layer.Digest])))
+	}
+	// The uncompressed layer is compressed, and the compressed layer is pushed as is.
+	reader, err := gzip.NewReader(bytes.NewReader(registry.blobs[manifest.Layers[0].Digest]))
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte("first layer "), 100), content)
+	assert.Equal(t, compressedLayer.Bytes(), registry.blobs[manifest.Layers[1].Digest])
+
+	// Pushing the image again doesn't upload the existing layers.
+	patches := registry.patches
+	require.NoError(t, pusher.PushArchive(archivePath, t.TempDir()))
+	assert.Equal(t, patches, registry.patches)
+}
+
+func TestLayersPusherRetriesExhausted(t *testing.T) {
+	layerPushRetriesIntervalMilliSecs = 0
+	registry := newTestRegistry()
+	registry.failedChunks = 100
+	pusher := createLayersPusher(t, registry).SetRetries(2).SetThreads(1)
+
+	workingDir := t.TempDir()
+	archivePath := filepath.Join(workingDir, "image.tar")
+	createImageArchive(t, archivePath, []byte(`{}`), []byte("layer"))
+	err := pusher.PushArchive(archivePath, workingDir)
+	assert.ErrorContains(t, err, "failed pushing layer 1/1")
+	// The layer is uploaded once, and retried twice.
+	assert.Equal(t, 3, registry.patches)
+	assert.Empty(t, registry.manifests)
+}
+
+func TestGetRegistryScheme(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	plainServer := httptest.NewServer(handler)
+	defer plainServer.Close()
+	assert.Equal(t, "http://", getRegistryScheme(strings.TrimPrefix(plainServer.URL, "http://")))
+
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+	assert.Equal(t, "https://", getRegistryScheme(strings.TrimPrefix(tlsServer.URL, "https://")))
+}
+
+func TestReadImageArchiveIllegalPath(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	require.NoError(t, writer.WriteHeader(&tar.Header{Name: "../escape", Mode: 0644, Size: 1, Typeflag: tar.TypeReg}))
+	_, err := writer.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	archivePath := filepath.Join(t.TempDir(), "image.tar")
+	require.NoError(t, os.WriteFile(archivePath, buffer.Bytes(), 0600))
+	_, err = readImageArchive(archivePath, filepath.Join(t.TempDir(), "extracted"))
+	assert.ErrorContains(t, err, "illegal path in the image archive")
+}
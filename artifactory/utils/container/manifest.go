@@ -46,6 +46,7 @@ type ManifestDetails struct {
 type Platform struct {
 	Architecture string `json:"architecture"`
 	Os           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
 }
 
 // Return all the search patterns in which manifest can be found.
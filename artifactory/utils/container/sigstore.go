@@ -0,0 +1,390 @@
+package container
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/signing"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Keyless signing uses a short-lived certificate issued by Fulcio for the OIDC identity of the signer,
+// and records the signature in the Rekor transparency log.
+const (
+	DefaultFulcioUrl = "https://fulcio.sigstore.dev"
+	DefaultRekorUrl  = "https://rekor.sigstore.dev"
+	// The environment variable holding the OIDC identity token used for keyless signing, as in cosign.
+	SigstoreIdTokenEnv = "SIGSTORE_ID_TOKEN"
+)
+
+var (
+	// The OIDC issuer extensions of Fulcio certificates.
+	oidcIssuerOid   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidcIssuerV2Oid = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+type fulcioCertificateRequest struct {
+	Credentials struct {
+		OidcIdentityToken string `json:"oidcIdentityToken"`
+	} `json:"credentials"`
+	PublicKeyRequest struct {
+		PublicKey struct {
+			Algorithm string `json:"algorithm"`
+			Content   string `json:"content"`
+		} `json:"publicKey"`
+		ProofOfPossession string `json:"proofOfPossession"`
+	} `json:"publicKeyRequest"`
+}
+
+type fulcioCertificateResponse struct {
+	SignedCertificateEmbeddedSct *fulcioCertificateChain `json:"signedCertificateEmbeddedSct,omitempty"`
+	SignedCertificateDetachedSct *fulcioCertificateChain `json:"signedCertificateDetachedSct,omitempty"`
+}
+
+type fulcioCertificateChain struct {
+	Chain struct {
+		Certificates []string `json:"certificates"`
+	} `json:"chain"`
+}
+
+type rekorEntry struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogId          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+	Verification   struct {
+		SignedEntryTimestamp string `json:"signedEntryTimestamp"`
+	} `json:"verification"`
+}
+
+// RekorBundle is the proof of the signature inclusion in the transparency log, stored in the bundle annotation of the signature.
+type RekorBundle struct {
+	SignedEntryTimestamp string `json:"SignedEntryTimestamp"`
+	Payload              struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogIndex       int64  `json:"logIndex"`
+		LogId          string `json:"logID"`
+	} `json:"Payload"`
+}
+
+// GetFulcioCertificate requests a signing certificate for the public key of the private key, issued to the identity of the OIDC token.
+// Returns the PEM certificate and the PEM chain of its issuers.
+func GetFulcioCertificate(fulcioUrl, idToken string, privateKey crypto.Signer) (certificate, chain string, err error) {
+	tokenSubject, err := getTokenSubject(idToken)
+	if err != nil {
+		return
+	}
+	// Proves the possession of the private key, by signing the token subject.
+	proof, err := signing.Sign([]byte(tokenSubject), privateKey)
+	if err != nil {
+		return
+	}
+	publicKey, err := signing.MarshalPublicKey(privateKey.Public())
+	if err != nil {
+		return
+	}
+	request := fulcioCertificateRequest{}
+	request.Credentials.OidcIdentityToken = idToken
+	request.PublicKeyRequest.PublicKey.Algorithm = "ECDSA"
+	request.PublicKeyRequest.PublicKey.Content = string(publicKey)
+	request.PublicKeyRequest.ProofOfPossession = base64.StdEncoding.EncodeToString(proof)
+	body, err := sendSigstoreRequest(strings.TrimSuffix(fulcioUrl, "/")+"/api/v2/signingCert", request)
+	if err != nil {
+		return
+	}
+	var response fulcioCertificateResponse
+	if err = errorutils.CheckError(json.Unmarshal(body, &response)); err != nil {
+		return
+	}
+	certificateChain := response.SignedCertificateEmbeddedSct
+	if certificateChain == nil {
+		certificateChain = response.SignedCertificateDetachedSct
+	}
+	if certificateChain == nil || len(certificateChain.Chain.Certificates) == 0 {
+		err = errorutils.CheckErrorf("no signing certificate was returned by Fulcio")
+		return
+	}
+	certificates := certificateChain.Chain.Certificates
+	return certificates[0], strings.Join(certificates[1:], ""), nil
+}
+
+// Returns the identity the certificate will be issued to - the email claim of the token if exists, or the subject claim otherwise.
+func getTokenSubject(idToken string) (string, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return "", errorutils.CheckErrorf("the OIDC identity token is not a valid JWT")
+	}
+	claimsJson, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	var claims struct {
+		Subject string `json:"sub"`
+		Email   string `json:"email"`
+	}
+	if err = json.Unmarshal(claimsJson, &claims); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if claims.Email != "" {
+		return claims.Email, nil
+	}
+	if claims.Subject == "" {
+		return "", errorutils.CheckErrorf("the OIDC identity token has no subject")
+	}
+	return claims.Subject, nil
+}
+
+// UploadToRekor records the signature of the payload in the Rekor transparency log, and returns the inclusion proof.
+func UploadToRekor(rekorUrl string, payload, signature []byte, certificate string) (*RekorBundle, error) {
+	payloadHash := sha256.Sum256(payload)
+	entry := map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]any{
+			"data": map[string]any{"hash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(payloadHash[:])}},
+			"signature": map[string]any{
+				"content":   base64.StdEncoding.EncodeToString(signature),
+				"publicKey": map[string]string{"content": base64.StdEncoding.EncodeToString([]byte(certificate))},
+			},
+		},
+	}
+	body, err := sendSigstoreRequest(strings.TrimSuffix(rekorUrl, "/")+"/api/v1/log/entries", entry)
+	if err != nil {
+		return nil, err
+	}
+	var entries map[string]rekorEntry
+	if err = json.Unmarshal(body, &entries); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, created := range entries {
+		bundle := &RekorBundle{SignedEntryTimestamp: created.Verification.SignedEntryTimestamp}
+		bundle.Payload.Body = created.Body
+		bundle.Payload.IntegratedTime = created.IntegratedTime
+		bundle.Payload.LogIndex = created.LogIndex
+		bundle.Payload.LogId = created.LogId
+		return bundle, nil
+	}
+	return nil, errorutils.CheckErrorf("no transparency log entry was returned by Rekor")
+}
+
+func sendSigstoreRequest(requestUrl string, payload any) ([]byte, error) {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	client, err := httpclient.ClientBuilder().SetRetries(3).Build()
+	if err != nil {
+		return nil, err
+	}
+	httpDetails := httputils.HttpClientDetails{Headers: map[string]string{"Content-Type": "application/json", "Accept": "application/json"}}
+	resp, body, err := client.SendPost(requestUrl, content, httpDetails, "")
+	if err != nil {
+		return nil, err
+	}
+	return body, errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated)
+}
+
+// KeylessIdentity is the expected identity of a keyless signature.
+type KeylessIdentity struct {
+	// The email or URI the signing certificate was issued to.
+	Identity string
+	// The OIDC issuer which authenticated the identity, for example: https://token.actions.githubusercontent.com
+	OidcIssuer string
+	// PEM file of the trusted Fulcio root and intermediate certificates.
+	RootsPath string
+	// PEM file of the public key of the Rekor transparency log, which signs the inclusion proofs of the signatures.
+	RekorPublicKeyPath string
+}
+
+// VerifyBundle verifies the inclusion proof of the signature in the transparency log, and returns the time the signature was recorded in the log.
+// The proof is trusted only if its signed entry timestamp is signed by the Rekor public key, and its log entry records the signature,
+// the signing certificate and the hash of the payload.
+func (ki *KeylessIdentity) VerifyBundle(bundleJson, certificatePem string, payload, signature []byte) (time.Time, error) {
+	if bundleJson == "" {
+		return time.Time{}, errorutils.CheckErrorf("the signature has no transparency log inclusion proof")
+	}
+	if ki.RekorPublicKeyPath == "" {
+		return time.Time{}, errorutils.CheckErrorf("the Rekor public key is required for verifying the transparency log inclusion proof")
+	}
+	var bundle RekorBundle
+	if err := json.Unmarshal([]byte(bundleJson), &bundle); err != nil {
+		return time.Time{}, errorutils.CheckErrorf("failed parsing the transparency log inclusion proof: %s", err.Error())
+	}
+	rekorPublicKey, err := signing.LoadPublicKey(ki.RekorPublicKeyPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err = verifySignedEntryTimestamp(&bundle, rekorPublicKey); err != nil {
+		return time.Time{}, err
+	}
+	if err = verifyRekorEntryBody(bundle.Payload.Body, certificatePem, payload, signature); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(bundle.Payload.IntegratedTime, 0), nil
+}
+
+// Verifies the bundle was created by the transparency log of the public key - its log ID is the hash of the public key,
+// and its signed entry timestamp is the signature of the log on the canonical JSON of the log entry.
+func verifySignedEntryTimestamp(bundle *RekorBundle, rekorPublicKey crypto.PublicKey) error {
+	publicKeyDer, err := x509.MarshalPKIXPublicKey(rekorPublicKey)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	logId := sha256.Sum256(publicKeyDer)
+	if bundle.Payload.LogId != hex.EncodeToString(logId[:]) {
+		return errorutils.CheckErrorf("the transparency log inclusion proof is of a different log: %s", bundle.Payload.LogId)
+	}
+	signedEntryTimestamp, err := base64.StdEncoding.DecodeString(bundle.SignedEntryTimestamp)
+	if err != nil || len(signedEntryTimestamp) == 0 {
+		return errorutils.CheckErrorf("the transparency log inclusion proof has no valid signed entry timestamp")
+	}
+	// The keys of maps are marshaled in sorted order, as in canonical JSON.
+	canonicalEntry, err := marshalCanonical(map[string]any{
+		"body":           bundle.Payload.Body,
+		"integratedTime": bundle.Payload.IntegratedTime,
+		"logID":          bundle.Payload.LogId,
+		"logIndex":       bundle.Payload.LogIndex,
+	})
+	if err != nil {
+		return err
+	}
+	if !signing.Verify(canonicalEntry, signedEntryTimestamp, rekorPublicKey) {
+		return errorutils.CheckErrorf("the signed entry timestamp of the transparency log inclusion proof doesn't match the Rekor public key")
+	}
+	return nil
+}
+
+func marshalCanonical(value any) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// Verifies the log entry records the signature of the payload, made with the key of the signing certificate.
+func verifyRekorEntryBody(body, certificatePem string, payload, signature []byte) error {
+	bodyJson, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return errorutils.CheckErrorf("failed decoding the transparency log entry: %s", err.Error())
+	}
+	var entry struct {
+		Kind string `json:"kind"`
+		Spec struct {
+			Data struct {
+				Hash struct {
+					Algorithm string `json:"algorithm"`
+					Value     string `json:"value"`
+				} `json:"hash"`
+			} `json:"data"`
+			Signature struct {
+				Content   string `json:"content"`
+				PublicKey struct {
+					Content string `json:"content"`
+				} `json:"publicKey"`
+			} `json:"signature"`
+		} `json:"spec"`
+	}
+	if err = json.Unmarshal(bodyJson, &entry); err != nil {
+		return errorutils.CheckErrorf("failed parsing the transparency log entry: %s", err.Error())
+	}
+	payloadHash := sha256.Sum256(payload)
+	switch {
+	case entry.Kind != "hashedrekord":
+		return errorutils.CheckErrorf("unsupported transparency log entry kind '%s'", entry.Kind)
+	case entry.Spec.Data.Hash.Algorithm != "sha256" || entry.Spec.Data.Hash.Value != hex.EncodeToString(payloadHash[:]):
+		return errorutils.CheckErrorf("the transparency log entry is of a different payload")
+	case entry.Spec.Signature.Content != base64.StdEncoding.EncodeToString(signature):
+		return errorutils.CheckErrorf("the transparency log entry is of a different signature")
+	case entry.Spec.Signature.PublicKey.Content != base64.StdEncoding.EncodeToString([]byte(certificatePem)):
+		return errorutils.CheckErrorf("the transparency log entry is of a different signing certificate")
+	}
+	return nil
+}
+
+// VerifyCertificate verifies the signing certificate was issued by a trusted Fulcio root to the expected identity,
+// and returns the public key of the certificate.
+// As Fulcio certificates are short-lived, the certificate is verified at the time the signature was recorded in the transparency log,
+// which should be taken from a verified inclusion proof (see VerifyBundle).
+func (ki *KeylessIdentity) VerifyCertificate(certificatePem, chainPem string, signedAt time.Time) (crypto.PublicKey, error) {
+	if signedAt.IsZero() {
+		return nil, errorutils.CheckErrorf("the signing certificate can't be verified without a verified signing time")
+	}
+	certificate, err := parseCertificate(certificatePem)
+	if err != nil {
+		return nil, err
+	}
+	rootsContent, err := fileutils.ReadFile(ki.RootsPath)
+	if err != nil {
+		return nil, err
+	}
+	roots, intermediates := x509.NewCertPool(), x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(rootsContent) {
+		return nil, errorutils.CheckErrorf("no certificates were found in %s", ki.RootsPath)
+	}
+	intermediates.AppendCertsFromPEM([]byte(chainPem))
+	if _, err = certificate.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, CurrentTime: signedAt,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}}); err != nil {
+		return nil, errorutils.CheckErrorf("the signing certificate isn't trusted: %s", err.Error())
+	}
+	if !ki.matchIdentity(certificate) {
+		return nil, errorutils.CheckErrorf("the signing certificate wasn't issued to '%s'", ki.Identity)
+	}
+	if issuer := getOidcIssuer(certificate); issuer != ki.OidcIssuer {
+		return nil, errorutils.CheckErrorf("the signing certificate was issued by OIDC issuer '%s' rather than '%s'", issuer, ki.OidcIssuer)
+	}
+	return certificate.PublicKey, nil
+}
+
+func (ki *KeylessIdentity) matchIdentity(certificate *x509.Certificate) bool {
+	for _, email := range certificate.EmailAddresses {
+		if email == ki.Identity {
+			return true
+		}
+	}
+	for _, uri := range certificate.URIs {
+		if uri.String() == ki.Identity {
+			return true
+		}
+	}
+	return false
+}
+
+func getOidcIssuer(certificate *x509.Certificate) string {
+	for _, extension := range certificate.Extensions {
+		switch {
+		case extension.Id.Equal(oidcIssuerV2Oid):
+			var issuer string
+			if _, err := asn1.Unmarshal(extension.Value, &issuer); err == nil {
+				return issuer
+			}
+		case extension.Id.Equal(oidcIssuerOid):
+			return string(extension.Value)
+		}
+	}
+	return ""
+}
+
+func parseCertificate(certificatePem string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certificatePem))
+	if block == nil {
+		return nil, errorutils.CheckErrorf("failed to decode the signing certificate")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	return certificate, errorutils.CheckError(err)
+}
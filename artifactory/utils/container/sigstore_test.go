@@ -0,0 +1,146 @@
+package container
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTokenSubject(t *testing.T) {
+	createToken := func(claims string) string {
+		return "header." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+	}
+	subject, err := getTokenSubject(createToken(`{"sub":"repo:org/repo","email":"user@example.com"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "user@example.com", subject)
+	subject, err = getTokenSubject(createToken(`{"sub":"repo:org/repo"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "repo:org/repo", subject)
+	_, err = getTokenSubject(createToken(`{}`))
+	assert.Error(t, err)
+	_, err = getTokenSubject("not-a-jwt")
+	assert.Error(t, err)
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	notBefore := time.Now().Add(-time.Hour)
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	rootTemplate := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "sigstore"}, NotBefore: notBefore,
+		NotAfter: notBefore.Add(24 * time.Hour), IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign}
+	rootDer, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	assert.NoError(t, err)
+	rootsPath := filepath.Join(t.TempDir(), "roots.pem")
+	assert.NoError(t, os.WriteFile(rootsPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rootDer}), 0600))
+
+	// A short-lived certificate, as issued by Fulcio
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	issuer, err := asn1.Marshal("https://token.actions.githubusercontent.com")
+	assert.NoError(t, err)
+	leafTemplate := &x509.Certificate{SerialNumber: big.NewInt(2), NotBefore: notBefore, NotAfter: notBefore.Add(10 * time.Minute),
+		EmailAddresses: []string{"user@example.com"}, KeyUsage: x509.KeyUsageDigitalSignature, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		ExtraExtensions: []pkix.Extension{{Id: oidcIssuerV2Oid, Value: issuer}}}
+	leafDer, err := x509.CreateCertificate(rand.Reader, leafTemplate, rootTemplate, signingKey.Public(), rootKey)
+	assert.NoError(t, err)
+	certificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDer}))
+
+	identity := &KeylessIdentity{Identity: "user@example.com", OidcIssuer: "https://token.actions.githubusercontent.com", RootsPath: rootsPath}
+	publicKey, err := identity.VerifyCertificate(certificate, "", notBefore.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, signingKey.PublicKey.Equal(publicKey))
+	// Without a verified signing time, the certificate can't be verified
+	_, err = identity.VerifyCertificate(certificate, "", time.Time{})
+	assert.ErrorContains(t, err, "verified signing time")
+
+	// Signed after the certificate expired
+	_, err = identity.VerifyCertificate(certificate, "", notBefore.Add(time.Hour))
+	assert.ErrorContains(t, err, "isn't trusted")
+	wrongIdentity := *identity
+	wrongIdentity.Identity = "other@example.com"
+	_, err = wrongIdentity.VerifyCertificate(certificate, "", notBefore.Add(time.Minute))
+	assert.ErrorContains(t, err, "wasn't issued to")
+	wrongIssuer := *identity
+	wrongIssuer.OidcIssuer = "https://accounts.google.com"
+	_, err = wrongIssuer.VerifyCertificate(certificate, "", notBefore.Add(time.Minute))
+	assert.ErrorContains(t, err, "OIDC issuer")
+}
+
+func TestVerifyBundle(t *testing.T) {
+	rekorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rekorKeyPem, err := signing.MarshalPublicKey(rekorKey.Public())
+	require.NoError(t, err)
+	rekorKeyPath := filepath.Join(t.TempDir(), "rekor.pub")
+	require.NoError(t, os.WriteFile(rekorKeyPath, rekorKeyPem, 0600))
+	rekorKeyDer, err := x509.MarshalPKIXPublicKey(rekorKey.Public())
+	require.NoError(t, err)
+	logId := sha256.Sum256(rekorKeyDer)
+
+	certificate := "-----BEGIN CERTIFICATE-----\ncertificate\n-----END CERTIFICATE-----\n"
+	payload, signature := []byte(`{"critical":{}}`), []byte("signature")
+	payloadHash := sha256.Sum256(payload)
+	body := base64.StdEncoding.EncodeToString([]byte(`{"apiVersion":"0.0.1","kind":"hashedrekord","spec":{"data":{"hash":{"algorithm":"sha256","value":"` +
+		hex.EncodeToString(payloadHash[:]) + `"}},"signature":{"content":"` + base64.StdEncoding.EncodeToString(signature) +
+		`","publicKey":{"content":"` + base64.StdEncoding.EncodeToString([]byte(certificate)) + `"}}}}`))
+	createBundle := func(integratedTime int64, signedTime int64) string {
+		bundle := RekorBundle{}
+		bundle.Payload.Body = body
+		bundle.Payload.IntegratedTime = integratedTime
+		bundle.Payload.LogIndex = 7
+		bundle.Payload.LogId = hex.EncodeToString(logId[:])
+		signedEntry, err := marshalCanonical(map[string]any{"body": body, "integratedTime": signedTime, "logID": bundle.Payload.LogId, "logIndex": 7})
+		require.NoError(t, err)
+		signedEntryTimestamp, err := signing.Sign(signedEntry, rekorKey)
+		require.NoError(t, err)
+		bundle.SignedEntryTimestamp = base64.StdEncoding.EncodeToString(signedEntryTimestamp)
+		bundleJson, err := json.Marshal(bundle)
+		require.NoError(t, err)
+		return string(bundleJson)
+	}
+
+	identity := &KeylessIdentity{RekorPublicKeyPath: rekorKeyPath}
+	signedAt, err := identity.VerifyBundle(createBundle(1700000000, 1700000000), certificate, payload, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1700000000, 0), signedAt)
+
+	// The integrated time was changed after the bundle was signed
+	_, err = identity.VerifyBundle(createBundle(1700000000, 1600000000), certificate, payload, signature)
+	assert.ErrorContains(t, err, "signed entry timestamp")
+	// The bundle records a different signature or payload
+	_, err = identity.VerifyBundle(createBundle(1700000000, 1700000000), certificate, payload, []byte("other"))
+	assert.ErrorContains(t, err, "different signature")
+	_, err = identity.VerifyBundle(createBundle(1700000000, 1700000000), certificate, []byte("other"), signature)
+	assert.ErrorContains(t, err, "different payload")
+	// No inclusion proof, or no Rekor public key to verify it with
+	_, err = identity.VerifyBundle("", certificate, payload, signature)
+	assert.ErrorContains(t, err, "no transparency log inclusion proof")
+	_, err = (&KeylessIdentity{}).VerifyBundle(createBundle(1700000000, 1700000000), certificate, payload, signature)
+	assert.ErrorContains(t, err, "Rekor public key")
+	// Signed by a different log
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKeyPem, err := signing.MarshalPublicKey(otherKey.Public())
+	require.NoError(t, err)
+	otherKeyPath := filepath.Join(t.TempDir(), "other.pub")
+	require.NoError(t, os.WriteFile(otherKeyPath, otherKeyPem, 0600))
+	_, err = (&KeylessIdentity{RekorPublicKeyPath: otherKeyPath}).VerifyBundle(createBundle(1700000000, 1700000000), certificate, payload, signature)
+	assert.ErrorContains(t, err, "different log")
+}
@@ -1,13 +1,29 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/artifactory"
-	"github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/jfrog/jfrog-client-go/utils/io"
 )
 
-func CreateDownloadServiceManager(artDetails *config.ServerDetails, threads, httpRetries, httpRetryWaitMilliSecs int, dryRun bool, progressBar io.ProgressMgr) (artifactory.ArtifactoryServicesManager, error) {
-	return CreateServiceManagerWithProgressBar(artDetails, threads, httpRetries, httpRetryWaitMilliSecs, dryRun, progressBar)
+// CreateDownloadServiceManager creates a services manager for downloading files.
+// A positive rateLimit caps the bandwidth of the downloads in bytes per second, overriding the JFROG_CLI_RATE_LIMIT environment variable.
+// If checksumValidator isn't nil, it validates the checksums of the downloaded files while they're transferred.
+func CreateDownloadServiceManager(artDetails *config.ServerDetails, threads, httpRetries, httpRetryWaitMilliSecs int, dryRun bool, progressBar ioutils.ProgressMgr, rateLimit int64,
+	checksumValidator *ChecksumValidator) (artifactory.ArtifactoryServicesManager, error) {
+	return createServiceManagerWithRateLimit(artDetails, threads, httpRetries, httpRetryWaitMilliSecs, dryRun, progressBar, rateLimit, checksumValidator)
 }
 
 type DownloadConfiguration struct {
@@ -17,4 +33,171 @@ type DownloadConfiguration struct {
 	Symlink         bool
 	ValidateSymlink bool
 	SkipChecksum    bool
+	// If true, the SHA-256 checksums of the downloaded files are compared to the checksums reported by Artifactory.
+	ValidateChecksums      bool
+	ChecksumMismatchAction ChecksumMismatchAction
+}
+
+// ChecksumMismatchAction is the behavior when the checksum of a downloaded file doesn't match the checksum reported by Artifactory.
+type ChecksumMismatchAction string
+
+const (
+	// Fail the command, leaving the downloaded file in place.
+	ChecksumMismatchFail ChecksumMismatchAction = "fail"
+	// Download the file again, and fail the command if the checksum still doesn't match.
+	ChecksumMismatchRetry ChecksumMismatchAction = "retry"
+	// Rename the file with the quarantine suffix, so that it isn't used, and fail the command.
+	ChecksumMismatchQuarantine ChecksumMismatchAction = "quarantine"
+
+	// The number of times a file is downloaded again by the retry action.
+	ChecksumMismatchRetries = 2
+	QuarantineSuffix        = ".quarantine"
+)
+
+// ParseChecksumMismatchAction returns the action by its name. An empty name returns the fail action.
+func ParseChecksumMismatchAction(action string) (ChecksumMismatchAction, error) {
+	switch ChecksumMismatchAction(strings.ToLower(action)) {
+	case "", ChecksumMismatchFail:
+		return ChecksumMismatchFail, nil
+	case ChecksumMismatchRetry:
+		return ChecksumMismatchRetry, nil
+	case ChecksumMismatchQuarantine:
+		return ChecksumMismatchQuarantine, nil
+	}
+	return "", errorutils.CheckErrorf("unsupported checksum mismatch action '%s'. Possible values are: %s, %s, %s", action, ChecksumMismatchFail, ChecksumMismatchRetry, ChecksumMismatchQuarantine)
+}
+
+// QuarantineFile renames the file with the quarantine suffix, and returns its new path.
+func QuarantineFile(path string) (string, error) {
+	quarantinePath := path + QuarantineSuffix
+	return quarantinePath, errorutils.CheckError(os.Rename(path, quarantinePath))
+}
+
+// Sha256ValidatingReader calculates the SHA-256 checksum of the content while it's read, to validate it without reading the content again.
+type Sha256ValidatingReader struct {
+	reader   io.Reader
+	hash     hash.Hash
+	expected string
+}
+
+func NewSha256ValidatingReader(reader io.Reader, expectedSha256 string) *Sha256ValidatingReader {
+	hasher := sha256.New()
+	return &Sha256ValidatingReader{reader: io.TeeReader(reader, hasher), hash: hasher, expected: expectedSha256}
+}
+
+func (svr *Sha256ValidatingReader) Read(p []byte) (int, error) {
+	return svr.reader.Read(p)
+}
+
+// Validate returns an error if the checksum of the content read so far doesn't match the expected checksum.
+func (svr *Sha256ValidatingReader) Validate() error {
+	if actual := hex.EncodeToString(svr.hash.Sum(nil)); !strings.EqualFold(actual, svr.expected) {
+		return errorutils.CheckErrorf("SHA-256 checksum mismatch: expected %s, but received %s", svr.expected, actual)
+	}
+	return nil
+}
+
+// ChecksumValidator validates the SHA-256 checksums of the downloaded files while they're transferred, by wrapping the HTTP transport
+// of the services manager. The checksum of the content of each download response is compared to the checksum in its X-Checksum-Sha256
+// header, once the content is read to the end.
+type ChecksumValidator struct {
+	mutex sync.Mutex
+	// The validation results of the downloads which were read to the end, by the paths of their URLs.
+	results map[string]error
+}
+
+func NewChecksumValidator() *ChecksumValidator {
+	return &ChecksumValidator{results: map[string]error{}}
+}
+
+// Result returns the validation result of the last download of the file at the relative path, from the Artifactory URL.
+// validated is false if the download wasn't validated while it was transferred, for example if it was downloaded in chunks,
+// or if Artifactory didn't return its checksum. In this case, the downloaded file should be validated instead.
+func (cv *ChecksumValidator) Result(rtUrl, relativePath string) (validated bool, mismatchErr error) {
+	parsedUrl, err := url.Parse(rtUrl)
+	if err != nil {
+		return false, nil
+	}
+	cv.mutex.Lock()
+	defer cv.mutex.Unlock()
+	mismatchErr, validated = cv.results[strings.TrimSuffix(parsedUrl.Path, "/")+"/"+strings.TrimPrefix(relativePath, "/")]
+	return
+}
+
+func (cv *ChecksumValidator) setResult(urlPath string, validated bool, mismatchErr error) {
+	cv.mutex.Lock()
+	defer cv.mutex.Unlock()
+	if validated {
+		cv.results[urlPath] = mismatchErr
+	} else {
+		delete(cv.results, urlPath)
+	}
+}
+
+func (cv *ChecksumValidator) wrapRoundTripper(roundTripper http.RoundTripper) http.RoundTripper {
+	return &checksumValidatingRoundTripper{validator: cv, roundTripper: roundTripper}
+}
+
+type checksumValidatingRoundTripper struct {
+	validator    *ChecksumValidator
+	roundTripper http.RoundTripper
+}
+
+func (cvrt *checksumValidatingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := cvrt.roundTripper.RoundTrip(req)
+	if err != nil || req.Method != http.MethodGet {
+		return resp, err
+	}
+	urlPath := req.URL.Path
+	// A previous result of the same file is no longer relevant.
+	cvrt.validator.setResult(urlPath, false, nil)
+	// Partial content, such as the chunks of concurrent downloads, can't be validated separately.
+	expectedSha256 := resp.Header.Get("X-Checksum-Sha256")
+	if resp.StatusCode != http.StatusOK || expectedSha256 == "" {
+		return resp, nil
+	}
+	resp.Body = &validatingBody{
+		Sha256ValidatingReader: NewSha256ValidatingReader(resp.Body, expectedSha256),
+		body:                   resp.Body,
+		onEOF: func(mismatchErr error) {
+			cvrt.validator.setResult(urlPath, true, mismatchErr)
+		},
+	}
+	return resp, nil
+}
+
+// The body of a download response, whose checksum is validated once it's read to the end.
+type validatingBody struct {
+	*Sha256ValidatingReader
+	body  io.ReadCloser
+	onEOF func(mismatchErr error)
+	done  bool
+}
+
+func (vb *validatingBody) Read(p []byte) (n int, err error) {
+	n, err = vb.Sha256ValidatingReader.Read(p)
+	if err == io.EOF && !vb.done {
+		vb.done = true
+		vb.onEOF(vb.Validate())
+	}
+	return
+}
+
+func (vb *validatingBody) Close() error {
+	return vb.body.Close()
+}
+
+// GetChecksumMismatchActionFromEnv returns the action set by the JFROG_CLI_VALIDATE_CHECKSUMS environment variable, which enables
+// validating the checksums of the files fetched by the download and warm-cache commands. The binaries fetched by package managers,
+// such as npm and Maven, aren't validated. If the variable isn't set, validate is false.
+func GetChecksumMismatchActionFromEnv() (validate bool, action ChecksumMismatchAction, err error) {
+	value := os.Getenv(coreutils.ValidateChecksums)
+	if value == "" || strings.EqualFold(value, "false") {
+		return false, "", nil
+	}
+	if strings.EqualFold(value, "true") {
+		value = ""
+	}
+	action, err = ParseChecksumMismatchAction(value)
+	return err == nil, action, err
 }
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChecksumMismatchAction(t *testing.T) {
+	action, err := ParseChecksumMismatchAction("")
+	assert.NoError(t, err)
+	assert.Equal(t, ChecksumMismatchFail, action)
+	action, err = ParseChecksumMismatchAction("Quarantine")
+	assert.NoError(t, err)
+	assert.Equal(t, ChecksumMismatchQuarantine, action)
+	_, err = ParseChecksumMismatchAction("ignore")
+	assert.ErrorContains(t, err, "unsupported checksum mismatch action 'ignore'")
+}
+
+func TestSha256ValidatingReader(t *testing.T) {
+	// The SHA-256 checksum of 'content'.
+	const contentSha256 = "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"
+	reader := NewSha256ValidatingReader(strings.NewReader("content"), strings.ToUpper(contentSha256))
+	_, err := io.Copy(io.Discard, reader)
+	assert.NoError(t, err)
+	assert.NoError(t, reader.Validate())
+
+	reader = NewSha256ValidatingReader(strings.NewReader("corrupt"), contentSha256)
+	_, err = io.Copy(io.Discard, reader)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, reader.Validate(), "SHA-256 checksum mismatch")
+}
+
+func TestGetChecksumMismatchActionFromEnv(t *testing.T) {
+	t.Setenv(coreutils.ValidateChecksums, "")
+	validate, _, err := GetChecksumMismatchActionFromEnv()
+	assert.NoError(t, err)
+	assert.False(t, validate)
+
+	t.Setenv(coreutils.ValidateChecksums, "true")
+	validate, action, err := GetChecksumMismatchActionFromEnv()
+	assert.NoError(t, err)
+	assert.True(t, validate)
+	assert.Equal(t, ChecksumMismatchFail, action)
+
+	t.Setenv(coreutils.ValidateChecksums, "retry")
+	validate, action, err = GetChecksumMismatchActionFromEnv()
+	assert.NoError(t, err)
+	assert.True(t, validate)
+	assert.Equal(t, ChecksumMismatchRetry, action)
+}
+
+func TestChecksumValidator(t *testing.T) {
+	contentSha256 := sha256.Sum256([]byte("content"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/artifactory/generic-local/no-checksum.txt" {
+			w.Header().Set("X-Checksum-Sha256", hex.EncodeToString(contentSha256[:]))
+		}
+		if r.URL.Path == "/artifactory/generic-local/corrupted.txt" {
+			_, err := w.Write([]byte("corrupt"))
+			assert.NoError(t, err)
+			return
+		}
+		_, err := w.Write([]byte("content"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	validator := NewChecksumValidator()
+	client := &http.Client{Transport: validator.wrapRoundTripper(http.DefaultTransport)}
+	rtUrl := server.URL + "/artifactory/"
+	for _, path := range []string{"generic-local/valid.txt", "generic-local/corrupted.txt", "generic-local/no-checksum.txt", "generic-local/unread.txt"} {
+		resp, err := client.Get(rtUrl + path)
+		require.NoError(t, err)
+		if path != "generic-local/unread.txt" {
+			_, err = io.Copy(io.Discard, resp.Body)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, resp.Body.Close())
+	}
+
+	validated, mismatchErr := validator.Result(rtUrl, "generic-local/valid.txt")
+	assert.True(t, validated)
+	assert.NoError(t, mismatchErr)
+	validated, mismatchErr = validator.Result(rtUrl, "generic-local/corrupted.txt")
+	assert.True(t, validated)
+	assert.ErrorContains(t, mismatchErr, "SHA-256 checksum mismatch")
+	// Downloads without a checksum, and downloads which weren't read to the end, aren't validated.
+	validated, _ = validator.Result(rtUrl, "generic-local/no-checksum.txt")
+	assert.False(t, validated)
+	validated, _ = validator.Result(rtUrl, "generic-local/unread.txt")
+	assert.False(t, validated)
+}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	// Environment variables tuning the connections of the HTTP clients of all commands.
+	HttpMaxIdleConnsEnv        = "JFROG_CLI_HTTP_MAX_IDLE_CONNS"
+	HttpMaxIdleConnsPerHostEnv = "JFROG_CLI_HTTP_MAX_IDLE_CONNS_PER_HOST"
+	HttpMaxConnsPerHostEnv     = "JFROG_CLI_HTTP_MAX_CONNS_PER_HOST"
+	Http2Env                   = "JFROG_CLI_HTTP2"
+	HttpDialTimeoutEnv         = "JFROG_CLI_HTTP_DIAL_TIMEOUT"
+	HttpRequestTimeoutEnv      = "JFROG_CLI_HTTP_REQUEST_TIMEOUT"
+)
+
+// HttpTuning holds the connection pool, protocol and timeout settings of the HTTP clients.
+// Uploads and downloads with many threads open many connections to the same host. Since only 2 idle connections
+// per host are kept by default, the rest are closed after each request and left in TIME_WAIT, which may exhaust the
+// ephemeral ports. Raising MaxIdleConnsPerHost reuses them instead, and MaxConnsPerHost limits the total connections.
+// Zero values keep the defaults.
+type HttpTuning struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	// Enables or disables HTTP/2. If nil, the default is kept, which is HTTP/1.1 for transports with custom TLS settings.
+	Http2          *bool
+	DialTimeout    time.Duration
+	RequestTimeout time.Duration
+}
+
+// GetHttpTuning returns the HTTP tuning configured by the JFROG_CLI_HTTP_* environment variables,
+// or nil if none of them is configured.
+func GetHttpTuning() (*HttpTuning, error) {
+	tuning := &HttpTuning{}
+	configured := false
+	for env, value := range map[string]*int{
+		HttpMaxIdleConnsEnv:        &tuning.MaxIdleConns,
+		HttpMaxIdleConnsPerHostEnv: &tuning.MaxIdleConnsPerHost,
+		HttpMaxConnsPerHostEnv:     &tuning.MaxConnsPerHost,
+	} {
+		envValue := os.Getenv(env)
+		if envValue == "" {
+			continue
+		}
+		number, err := strconv.Atoi(envValue)
+		if err != nil || number < 0 {
+			return nil, errorutils.CheckErrorf("the value of %s must be a non-negative integer, but is: %s", env, envValue)
+		}
+		*value = number
+		configured = true
+	}
+	if envValue := os.Getenv(Http2Env); envValue != "" {
+		http2, err := strconv.ParseBool(envValue)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("the value of %s must be a boolean, but is: %s", Http2Env, envValue)
+		}
+		tuning.Http2 = &http2
+		configured = true
+	}
+	var err error
+	if tuning.DialTimeout, err = getDurationEnv(HttpDialTimeoutEnv, 0); err != nil {
+		return nil, err
+	}
+	if tuning.RequestTimeout, err = getDurationEnv(HttpRequestTimeoutEnv, 0); err != nil {
+		return nil, err
+	}
+	if !configured && tuning.DialTimeout == 0 && tuning.RequestTimeout == 0 {
+		return nil, nil
+	}
+	return tuning, nil
+}
+
+// Applies the connection pool, protocol and dial timeout settings to the transport.
+func (ht *HttpTuning) applyToTransport(transport *http.Transport) {
+	if ht.MaxIdleConns > 0 {
+		transport.MaxIdleConns = ht.MaxIdleConns
+	}
+	if ht.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = ht.MaxIdleConnsPerHost
+	}
+	if ht.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = ht.MaxConnsPerHost
+	}
+	if ht.DialTimeout > 0 {
+		transport.DialContext = (&net.Dialer{Timeout: ht.DialTimeout, KeepAlive: 20 * time.Second}).DialContext
+	}
+	if ht.Http2 != nil {
+		transport.ForceAttemptHTTP2 = *ht.Http2
+		if !*ht.Http2 {
+			// A non-nil empty map disables HTTP/2.
+			transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientConfig "github.com/jfrog/jfrog-client-go/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHttpTuningNotConfigured(t *testing.T) {
+	tuning, err := GetHttpTuning()
+	assert.NoError(t, err)
+	assert.Nil(t, tuning)
+}
+
+func TestGetHttpTuning(t *testing.T) {
+	setRetryPolicyEnv(t, map[string]string{
+		HttpMaxIdleConnsEnv:        "200",
+		HttpMaxIdleConnsPerHostEnv: "32",
+		HttpMaxConnsPerHostEnv:     "64",
+		Http2Env:                   "true",
+		HttpDialTimeoutEnv:         "5s",
+		HttpRequestTimeoutEnv:      "10m",
+	})
+	tuning, err := GetHttpTuning()
+	require.NoError(t, err)
+	http2 := true
+	assert.Equal(t, &HttpTuning{MaxIdleConns: 200, MaxIdleConnsPerHost: 32, MaxConnsPerHost: 64, Http2: &http2, DialTimeout: 5 * time.Second, RequestTimeout: 10 * time.Minute}, tuning)
+
+	transport, err := createHttpTransport(&config.ServerDetails{})
+	require.NoError(t, err)
+	assert.Equal(t, 200, transport.MaxIdleConns)
+	assert.Equal(t, 32, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 64, transport.MaxConnsPerHost)
+	assert.True(t, transport.ForceAttemptHTTP2)
+
+	httpClient, _, err := createCustomHttpClient(&config.ServerDetails{}, -1, 0, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, httpClient.Timeout)
+	// The command's timeout takes precedence.
+	httpClient, _, err = createCustomHttpClient(&config.ServerDetails{}, -1, time.Minute, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, httpClient.Timeout)
+}
+
+func TestApplyCustomHttpClient(t *testing.T) {
+	// Without configuration, the default HTTP client of the services manager is used.
+	configBuilder := clientConfig.NewConfigBuilder().SetHttpRetries(3)
+	require.NoError(t, ApplyCustomHttpClient(configBuilder, &config.ServerDetails{}, -1, 0, 0))
+	serviceConfig, err := configBuilder.Build()
+	require.NoError(t, err)
+	assert.Nil(t, serviceConfig.GetHttpClient())
+
+	setRetryPolicyEnv(t, map[string]string{HttpRequestTimeoutEnv: "10m", HttpRetryMaxDelayEnv: "5s"})
+	require.NoError(t, ApplyCustomHttpClient(configBuilder, &config.ServerDetails{}, -1, 0, 0))
+	serviceConfig, err = configBuilder.Build()
+	require.NoError(t, err)
+	require.NotNil(t, serviceConfig.GetHttpClient())
+	assert.Equal(t, 10*time.Minute, serviceConfig.GetHttpClient().Timeout)
+	// The retries are handled by the HTTP client.
+	assert.Zero(t, serviceConfig.GetHttpRetries())
+}
+
+func TestHttpTuningDisableHttp2(t *testing.T) {
+	http2 := false
+	transport := &http.Transport{}
+	(&HttpTuning{Http2: &http2}).applyToTransport(transport)
+	assert.False(t, transport.ForceAttemptHTTP2)
+	assert.NotNil(t, transport.TLSNextProto)
+	assert.Empty(t, transport.TLSNextProto)
+}
+
+func TestGetHttpTuningInvalidValues(t *testing.T) {
+	testCases := map[string]string{
+		HttpMaxIdleConnsEnv:        "many",
+		HttpMaxIdleConnsPerHostEnv: "-1",
+		HttpMaxConnsPerHostEnv:     "1.5",
+		Http2Env:                   "maybe",
+		HttpDialTimeoutEnv:         "5",
+		HttpRequestTimeoutEnv:      "-1s",
+	}
+	for env, value := range testCases {
+		t.Run(env, func(t *testing.T) {
+			setRetryPolicyEnv(t, map[string]string{env: value})
+			_, err := GetHttpTuning()
+			assert.ErrorContains(t, err, env)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	// Caps the aggregate bandwidth of all HTTP requests sent by the CLI process, for example: '50MB'.
+	RateLimitEnv = "JFROG_CLI_RATE_LIMIT"
+	// The maximal number of bytes read at once from a throttled stream.
+	throttledChunkSize = 32 * 1024
+)
+
+var (
+	bandwidthLimiters      = map[int64]*bandwidthLimiter{}
+	bandwidthLimitersMutex sync.Mutex
+)
+
+// GetRateLimit returns the bandwidth limit in bytes per second configured by the JFROG_CLI_RATE_LIMIT environment variable, or 0 if not configured.
+func GetRateLimit() (int64, error) {
+	rateLimit := os.Getenv(RateLimitEnv)
+	if rateLimit == "" {
+		return 0, nil
+	}
+	return ParseRateLimit(rateLimit)
+}
+
+// ParseRateLimit parses a bandwidth limit in bytes per second, such as '500KB', '50MB' or '1GB'.
+// A number without a unit is interpreted as bytes per second.
+func ParseRateLimit(rateLimit string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(rateLimit))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"KB", utils.SizeKib}, {"MB", utils.SizeMiB}, {"GB", utils.SizeGiB}, {"B", 1}} {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.size
+			break
+		}
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number <= 0 {
+		return 0, errorutils.CheckErrorf("invalid rate limit '%s'. The rate limit should be a positive size such as '500KB', '50MB' or '1GB'", rateLimit)
+	}
+	return int64(number * float64(multiplier)), nil
+}
+
+// Returns the limiter of the provided rate. The same limiter is shared by all the HTTP clients of the process,
+// to cap the aggregate bandwidth of all the workers.
+func getBandwidthLimiter(bytesPerSecond int64) *bandwidthLimiter {
+	bandwidthLimitersMutex.Lock()
+	defer bandwidthLimitersMutex.Unlock()
+	limiter, exists := bandwidthLimiters[bytesPerSecond]
+	if !exists {
+		limiter = &bandwidthLimiter{bytesPerSecond: bytesPerSecond}
+		bandwidthLimiters[bytesPerSecond] = limiter
+	}
+	return limiter
+}
+
+// bandwidthLimiter schedules the transferred bytes, so that the total rate doesn't exceed bytesPerSecond.
+type bandwidthLimiter struct {
+	bytesPerSecond int64
+	// The time at which the next bytes are allowed to be transferred.
+	next  time.Time
+	mutex sync.Mutex
+}
+
+// Waits until n bytes can be transferred without exceeding the rate limit.
+func (bl *bandwidthLimiter) wait(ctx context.Context, n int) error {
+	bl.mutex.Lock()
+	now := time.Now()
+	if bl.next.Before(now) {
+		bl.next = now
+	}
+	delay := bl.next.Sub(now)
+	bl.next = bl.next.Add(time.Duration(int64(n) * int64(time.Second) / bl.bytesPerSecond))
+	bl.mutex.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type throttledReader struct {
+	reader  io.ReadCloser
+	limiter *bandwidthLimiter
+	ctx     context.Context
+}
+
+func (tr *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > throttledChunkSize {
+		p = p[:throttledChunkSize]
+	}
+	n, err := tr.reader.Read(p)
+	if n > 0 {
+		if waitErr := tr.limiter.wait(tr.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+func (tr *throttledReader) Close() error {
+	return tr.reader.Close()
+}
+
+// throttledRoundTripper limits the bandwidth of the requests' and responses' bodies.
+type throttledRoundTripper struct {
+	limiter *bandwidthLimiter
+	next    http.RoundTripper
+}
+
+func newThrottledRoundTripper(bytesPerSecond int64, next http.RoundTripper) *throttledRoundTripper {
+	return &throttledRoundTripper{limiter: getBandwidthLimiter(bytesPerSecond), next: next}
+}
+
+func (trt *throttledRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody {
+		// Cloning the request to avoid modifying the caller's request, as required by the http.RoundTripper contract.
+		body := req.Body
+		getBody := req.GetBody
+		req = req.Clone(req.Context())
+		req.Body = &throttledReader{reader: body, limiter: trt.limiter, ctx: req.Context()}
+		if getBody != nil {
+			req.GetBody = func() (io.ReadCloser, error) {
+				rc, err := getBody()
+				if err != nil {
+					return nil, err
+				}
+				return &throttledReader{reader: rc, limiter: trt.limiter, ctx: req.Context()}, nil
+			}
+		}
+	}
+	resp, err := trt.next.RoundTrip(req)
+	if err != nil || resp == nil || resp.Body == nil {
+		return resp, err
+	}
+	resp.Body = &throttledReader{reader: resp.Body, limiter: trt.limiter, ctx: req.Context()}
+	return resp, nil
+}
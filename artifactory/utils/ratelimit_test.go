@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	testCases := []struct {
+		rateLimit     string
+		expected      int64
+		expectedError bool
+	}{
+		{rateLimit: "1024", expected: 1024},
+		{rateLimit: "100B", expected: 100},
+		{rateLimit: "500KB", expected: 500 * 1024},
+		{rateLimit: "50mb", expected: 50 * 1024 * 1024},
+		{rateLimit: "1.5GB", expected: 1536 * 1024 * 1024},
+		{rateLimit: "0", expectedError: true},
+		{rateLimit: "-5MB", expectedError: true},
+		{rateLimit: "fast", expectedError: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.rateLimit, func(t *testing.T) {
+			rateLimit, err := ParseRateLimit(testCase.rateLimit)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, rateLimit)
+		})
+	}
+}
+
+func TestThrottledRoundTripper(t *testing.T) {
+	payload := bytes.Repeat([]byte("a"), 2000)
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		_, err = w.Write(body)
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+
+	// 10KB per second - uploading and downloading 4000 bytes should take at least ~300ms, excluding the first chunk.
+	client := &http.Client{Transport: newThrottledRoundTripper(10*1024, http.DefaultTransport)}
+	start := time.Now()
+	resp, err := client.Post(testServer.URL, "text/plain", bytes.NewReader(payload))
+	assert.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, payload, body)
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestCreateCustomHttpClientRateLimit(t *testing.T) {
+	// No rate limit is configured.
+	httpClient, _, err := createCustomHttpClient(&config.ServerDetails{}, -1, 0, 0, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, httpClient)
+
+	// The command's rate limit overrides the environment variable.
+	testsutils.SetEnvAndAssert(t, RateLimitEnv, "1MB")
+	httpClient, _, err = createCustomHttpClient(&config.ServerDetails{}, -1, 0, 500*utils.SizeKib, nil)
+	assert.NoError(t, err)
+	roundTripper, ok := httpClient.Transport.(*throttledRoundTripper)
+	if assert.True(t, ok) {
+		assert.Equal(t, 500*utils.SizeKib, roundTripper.limiter.bytesPerSecond)
+	}
+	httpClient, _, err = createCustomHttpClient(&config.ServerDetails{}, -1, 0, 0, nil)
+	assert.NoError(t, err)
+	roundTripper, ok = httpClient.Transport.(*throttledRoundTripper)
+	if assert.True(t, ok) {
+		assert.Equal(t, utils.SizeMiB, roundTripper.limiter.bytesPerSecond)
+	}
+}
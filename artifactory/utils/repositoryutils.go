@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"encoding/json"
 	"github.com/jfrog/gofrog/datastructures"
 	"golang.org/x/exp/slices"
+	"net/http"
+	"net/url"
 	"path"
 	"strings"
 
@@ -58,6 +61,7 @@ var blacklistedRepositories = []string{
 }
 
 // GetRepositories returns the names of local, remote, virtual or federated repositories filtered by their type.
+// If a default project is set, only the repositories of the project are returned.
 // artDetails - Artifactory server details
 // repoTypes - Repository types to filter. If empty - return all repository types.
 func GetRepositories(artDetails *config.ServerDetails, repoTypes ...RepoType) ([]string, error) {
@@ -65,9 +69,15 @@ func GetRepositories(artDetails *config.ServerDetails, repoTypes ...RepoType) ([
 	if err != nil {
 		return nil, err
 	}
+	projectKey := config.GetProjectKey("", artDetails)
 	repos := []string{}
 	for _, repoType := range repoTypes {
-		filteredRepos, err := GetFilteredRepositoriesByNameAndType(sm, nil, nil, repoType)
+		var filteredRepos []string
+		if projectKey != "" {
+			filteredRepos, err = GetProjectRepositoriesByType(sm, projectKey, repoType)
+		} else {
+			filteredRepos, err = GetFilteredRepositoriesByNameAndType(sm, nil, nil, repoType)
+		}
 		if err != nil {
 			return repos, err
 		}
@@ -77,6 +87,24 @@ func GetRepositories(artDetails *config.ServerDetails, repoTypes ...RepoType) ([
 	return repos, nil
 }
 
+// GetProjectRepositoriesByType returns the names of the repositories of the given type, which are assigned to the project or shared with it.
+func GetProjectRepositoriesByType(servicesManager artifactory.ArtifactoryServicesManager, projectKey string, repoType RepoType) ([]string, error) {
+	params := url.Values{"type": {repoType.String()}, "project": {projectKey}}
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(servicesManager.GetConfig().GetServiceDetails().GetUrl()+"api/repositories?"+params.Encode(), true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var repoDetailsList []services.RepositoryDetails
+	if err = json.Unmarshal(body, &repoDetailsList); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return getFilteredRepositories(&repoDetailsList, nil, nil)
+}
+
 // Since we can't search dependencies in a remote repository, we will turn the search to the repository's cache.
 // Local/Virtual repository name will be returned as is.
 func GetRepoNameForDependenciesSearch(repoName string, serviceManager artifactory.ArtifactoryServicesManager) (string, error) {
@@ -99,6 +127,25 @@ func IsRemoteRepo(repoName string, serviceManager artifactory.ArtifactoryService
 	return repoDetails.GetRepoType() == "remote", nil
 }
 
+// GetDeploymentRepo returns the repository to which the artifacts deployed to the repository are stored.
+// This is the default deployment repository of a virtual repository, and the repository itself otherwise.
+func GetDeploymentRepo(repoName string, serviceManager artifactory.ArtifactoryServicesManager) (string, error) {
+	repoDetails := &struct {
+		services.RepositoryDetails
+		DefaultDeploymentRepo string `json:"defaultDeploymentRepo,omitempty"`
+	}{}
+	if err := serviceManager.GetRepository(repoName, repoDetails); err != nil {
+		return "", errorutils.CheckErrorf("failed to get details for repository '" + repoName + "'. Error:\n" + err.Error())
+	}
+	if repoDetails.GetRepoType() != "virtual" {
+		return repoName, nil
+	}
+	if repoDetails.DefaultDeploymentRepo == "" {
+		return "", errorutils.CheckErrorf("the virtual repository '%s' has no default deployment repository", repoName)
+	}
+	return repoDetails.DefaultDeploymentRepo, nil
+}
+
 // GetFilteredRepositoriesByName returns the names of local, remote, virtual and federated repositories filtered by their names.
 // includePatterns - patterns of repository names (can contain wildcards) to include in the results. A repository's name
 // must match at least one of these patterns in order to be included in the results. If includePatterns' length is zero,
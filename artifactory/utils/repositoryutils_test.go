@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -60,3 +63,17 @@ func TestShouldIncludeRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRepositoriesOfDefaultProject(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/repositories", r.URL.Path)
+		assert.Equal(t, "proj", r.URL.Query().Get("project"))
+		assert.Equal(t, "remote", r.URL.Query().Get("type"))
+		_, err := w.Write([]byte(`[{"key":"proj-npm-remote","type":"REMOTE"},{"key":"shared-npm-remote","type":"REMOTE"}]`))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+	repos, err := GetRepositories(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/", Project: "proj"}, Remote)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"proj-npm-remote", "shared-npm-remote"}, repos)
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// The size of the recorded request body kept in memory. Larger bodies, such as uploaded files, are recorded to a temporary file.
+const maxInMemoryRetryBodySize = 1024 * 1024
+
+// recordedBody records a request body which can't be re-read while it's sent, so that the request can be sent again.
+// The body is recorded in memory, and moved to a temporary file if it's larger than maxInMemoryRetryBodySize.
+type recordedBody struct {
+	body   io.ReadCloser
+	buffer bytes.Buffer
+	file   *os.File
+	size   int64
+	err    error
+	// Closed when the transport is done reading the body.
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newRecordedBody(body io.ReadCloser) *recordedBody {
+	return &recordedBody{body: body, done: make(chan struct{})}
+}
+
+func (rb *recordedBody) Read(p []byte) (n int, err error) {
+	n, err = rb.body.Read(p)
+	if n > 0 {
+		if recordErr := rb.record(p[:n]); recordErr != nil {
+			return n, recordErr
+		}
+	}
+	return
+}
+
+// The transport closes the body once it's sent, or if sending it failed. The original body is closed by cleanup.
+func (rb *recordedBody) Close() error {
+	rb.closeOnce.Do(func() { close(rb.done) })
+	return nil
+}
+
+func (rb *recordedBody) record(content []byte) error {
+	if rb.err != nil {
+		return rb.err
+	}
+	rb.size += int64(len(content))
+	if rb.file == nil && rb.size > maxInMemoryRetryBodySize {
+		if rb.file, rb.err = fileutils.CreateTempFile(); rb.err != nil {
+			return rb.err
+		}
+		if _, rb.err = rb.file.Write(rb.buffer.Bytes()); rb.err != nil {
+			return errorutils.CheckError(rb.err)
+		}
+		rb.buffer = bytes.Buffer{}
+	}
+	if rb.file != nil {
+		_, rb.err = rb.file.Write(content)
+		return errorutils.CheckError(rb.err)
+	}
+	rb.buffer.Write(content)
+	return nil
+}
+
+// Returns a new reader of the whole body, after the transport is done reading it.
+// The part of the body which wasn't sent, for example because the server responded before it was sent, is read and recorded first.
+func (rb *recordedBody) replay(ctx context.Context) (io.ReadCloser, error) {
+	select {
+	case <-rb.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if _, err := io.Copy(io.Discard, rb); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if rb.file == nil {
+		return io.NopCloser(bytes.NewReader(rb.buffer.Bytes())), nil
+	}
+	return io.NopCloser(io.NewSectionReader(rb.file, 0, rb.size)), nil
+}
+
+// Closes the original body and removes the recorded body.
+func (rb *recordedBody) cleanup() error {
+	err := rb.body.Close()
+	if rb.file != nil {
+		err = errors.Join(err, rb.file.Close(), os.Remove(rb.file.Name()))
+	}
+	return errorutils.CheckError(err)
+}
@@ -0,0 +1,327 @@
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/metrics"
+	"github.com/jfrog/jfrog-client-go/auth/cert"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// Environment variables configuring the HTTP retry policy of all commands.
+	HttpRetriesEnv              = "JFROG_CLI_HTTP_RETRIES"
+	HttpRetryWaitTimeEnv        = "JFROG_CLI_HTTP_RETRY_WAIT_TIME"
+	HttpRetryBackoffBaseEnv     = "JFROG_CLI_HTTP_RETRY_BACKOFF_BASE"
+	HttpRetryMaxDelayEnv        = "JFROG_CLI_HTTP_RETRY_MAX_DELAY"
+	HttpRetryableStatusCodesEnv = "JFROG_CLI_HTTP_RETRYABLE_STATUS_CODES"
+
+	DefaultHttpRetries       = 3
+	DefaultHttpRetryWaitTime = time.Second
+	DefaultHttpBackoffBase   = 2.0
+	DefaultHttpRetryMaxDelay = time.Minute
+)
+
+var DefaultRetryableStatusCodes = []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// RetryPolicy determines how failed HTTP requests are retried.
+// The delay before retry number N (starting at 0) is RetryWaitTime * BackoffBase^N, limited by MaxDelay.
+// If the server responds with a 'Retry-After' header, the delay it requests is used instead, limited by MaxDelay as well.
+type RetryPolicy struct {
+	Retries              int
+	RetryWaitTime        time.Duration
+	BackoffBase          float64
+	MaxDelay             time.Duration
+	RetryableStatusCodes []int
+}
+
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		Retries:              DefaultHttpRetries,
+		RetryWaitTime:        DefaultHttpRetryWaitTime,
+		BackoffBase:          DefaultHttpBackoffBase,
+		MaxDelay:             DefaultHttpRetryMaxDelay,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+	}
+}
+
+// GetRetryPolicy returns the retry policy configured for the server, overridden by the JFROG_CLI_HTTP_RETRY_* environment variables,
+// which are also set by the corresponding flags.
+// The command's httpRetries, usually provided by the --retries flag, override only the configured number of retries,
+// and only if they were explicitly set - that is, non-negative and different from the flag's default (DefaultHttpRetries).
+// The command's wait time doesn't override the configured wait time and backoff.
+// Returns nil if no retry policy is configured, in which case the default fixed-interval retries are used.
+func GetRetryPolicy(serverDetails *config.ServerDetails, httpRetries int) (*RetryPolicy, error) {
+	var serverPolicy *config.HttpRetryPolicy
+	if serverDetails != nil {
+		serverPolicy = serverDetails.HttpRetryPolicy
+	}
+	if serverPolicy == nil && !isRetryPolicyConfigured() {
+		return nil, nil
+	}
+	policy := NewRetryPolicy()
+	if serverPolicy != nil {
+		if err := policy.applyServerPolicy(serverDetails.ServerId, serverPolicy); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if value := os.Getenv(HttpRetriesEnv); value != "" {
+		if policy.Retries, err = strconv.Atoi(value); err != nil || policy.Retries < 0 {
+			return nil, errorutils.CheckErrorf("the value of %s must be a non-negative integer, but is: %s", HttpRetriesEnv, value)
+		}
+	}
+	if policy.RetryWaitTime, err = getDurationEnv(HttpRetryWaitTimeEnv, policy.RetryWaitTime); err != nil {
+		return nil, err
+	}
+	if policy.MaxDelay, err = getDurationEnv(HttpRetryMaxDelayEnv, policy.MaxDelay); err != nil {
+		return nil, err
+	}
+	if value := os.Getenv(HttpRetryBackoffBaseEnv); value != "" {
+		if policy.BackoffBase, err = strconv.ParseFloat(value, 64); err != nil || policy.BackoffBase < 1 {
+			return nil, errorutils.CheckErrorf("the value of %s must be a number greater than or equal to 1, but is: %s", HttpRetryBackoffBaseEnv, value)
+		}
+	}
+	if value := os.Getenv(HttpRetryableStatusCodesEnv); value != "" {
+		if policy.RetryableStatusCodes, err = ParseStatusCodes(value); err != nil {
+			return nil, err
+		}
+	}
+	if httpRetries >= 0 && httpRetries != DefaultHttpRetries {
+		policy.Retries = httpRetries
+	}
+	return policy, nil
+}
+
+// Applies the settings of the retry policy in the configuration of the server.
+func (rp *RetryPolicy) applyServerPolicy(serverId string, serverPolicy *config.HttpRetryPolicy) (err error) {
+	invalidSetting := func(setting string, value any) error {
+		return errorutils.CheckErrorf("invalid %s in the HTTP retry policy of the '%s' server configuration: %v", setting, serverId, value)
+	}
+	if serverPolicy.Retries != nil {
+		if *serverPolicy.Retries < 0 {
+			return invalidSetting("retries", *serverPolicy.Retries)
+		}
+		rp.Retries = *serverPolicy.Retries
+	}
+	if serverPolicy.RetryWaitTime != "" {
+		if rp.RetryWaitTime, err = time.ParseDuration(serverPolicy.RetryWaitTime); err != nil || rp.RetryWaitTime < 0 {
+			return invalidSetting("retryWaitTime", serverPolicy.RetryWaitTime)
+		}
+	}
+	if serverPolicy.MaxDelay != "" {
+		if rp.MaxDelay, err = time.ParseDuration(serverPolicy.MaxDelay); err != nil || rp.MaxDelay < 0 {
+			return invalidSetting("maxDelay", serverPolicy.MaxDelay)
+		}
+	}
+	if serverPolicy.BackoffBase != 0 {
+		if serverPolicy.BackoffBase < 1 {
+			return invalidSetting("backoffBase", serverPolicy.BackoffBase)
+		}
+		rp.BackoffBase = serverPolicy.BackoffBase
+	}
+	if len(serverPolicy.RetryableStatusCodes) > 0 {
+		for _, code := range serverPolicy.RetryableStatusCodes {
+			if code < 100 || code > 599 {
+				return invalidSetting("retryableStatusCodes", code)
+			}
+		}
+		rp.RetryableStatusCodes = serverPolicy.RetryableStatusCodes
+	}
+	return nil
+}
+
+func isRetryPolicyConfigured() bool {
+	for _, env := range []string{HttpRetriesEnv, HttpRetryWaitTimeEnv, HttpRetryBackoffBaseEnv, HttpRetryMaxDelayEnv, HttpRetryableStatusCodesEnv} {
+		if os.Getenv(env) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func getDurationEnv(env string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(env)
+	if value == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, errorutils.CheckErrorf("the value of %s must be a non-negative duration such as '500ms' or '2s', but is: %s", env, value)
+	}
+	return duration, nil
+}
+
+// ParseStatusCodes parses a comma-separated list of HTTP status codes, for example: '429,502,503'.
+func ParseStatusCodes(statusCodes string) ([]int, error) {
+	var parsed []int
+	for _, statusCode := range strings.Split(statusCodes, ",") {
+		code, err := strconv.Atoi(strings.TrimSpace(statusCode))
+		if err != nil || code < 100 || code > 599 {
+			return nil, errorutils.CheckErrorf("invalid HTTP status code: '%s'", statusCode)
+		}
+		parsed = append(parsed, code)
+	}
+	return parsed, nil
+}
+
+// GetDelay returns the time to wait before retry number 'attempt' (starting at 0), considering the response's 'Retry-After' header if exists.
+func (rp *RetryPolicy) GetDelay(attempt int, resp *http.Response) time.Duration {
+	delay := time.Duration(float64(rp.RetryWaitTime) * math.Pow(rp.BackoffBase, float64(attempt)))
+	if retryAfter, ok := getRetryAfter(resp); ok {
+		delay = retryAfter
+	}
+	if rp.MaxDelay > 0 && (delay > rp.MaxDelay || delay < 0) {
+		delay = rp.MaxDelay
+	}
+	return delay
+}
+
+func (rp *RetryPolicy) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		// Don't retry requests that were canceled by the caller.
+		return ctx.Err() == nil
+	}
+	return slices.Contains(rp.RetryableStatusCodes, resp.StatusCode)
+}
+
+// Parses the 'Retry-After' header, which holds either a number of seconds or an HTTP date.
+func getRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	retryAfter := resp.Header.Get("Retry-After")
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		return time.Until(date), true
+	}
+	return 0, false
+}
+
+// CreateHttpClient creates an HTTP client for the provided server, which retries failed requests according to the policy.
+func (rp *RetryPolicy) CreateHttpClient(serverDetails *config.ServerDetails) (*http.Client, error) {
+	transport, err := createHttpTransport(serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: rp.wrapRoundTripper(transport)}, nil
+}
+
+func (rp *RetryPolicy) wrapRoundTripper(next http.RoundTripper) http.RoundTripper {
+	return &retryRoundTripper{policy: rp, next: next}
+}
+
+// Creates the HTTP transport of the provided server, using the certificates at the JFrog home directory and the server's client certificate.
+// The HTTP tuning, if configured, is applied to the transport.
+func createHttpTransport(serverDetails *config.ServerDetails) (*http.Transport, error) {
+	certsPath, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return nil, err
+	}
+	transport, err := cert.GetTransportWithLoadedCert(certsPath, serverDetails.InsecureTls, createDefaultHttpTransport())
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed creating HttpClient: " + err.Error())
+	}
+	tuning, err := GetHttpTuning()
+	if err != nil {
+		return nil, err
+	}
+	if tuning != nil {
+		tuning.applyToTransport(transport)
+	}
+	if serverDetails.ClientCertPath != "" {
+		certificate, err := cert.LoadCertificate(serverDetails.ClientCertPath, serverDetails.ClientCertKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return transport, nil
+}
+
+// The transport settings are aligned with the default transport of jfrog-client-go.
+func createDefaultHttpTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   httpclient.DefaultDialTimeout,
+			KeepAlive: 20 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+type retryRoundTripper struct {
+	policy *RetryPolicy
+	next   http.RoundTripper
+}
+
+func (rrt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	getBody := req.GetBody
+	if getBody == nil && req.Body != nil && req.Body != http.NoBody && rrt.policy.Retries > 0 {
+		// Bodies which can't be re-read, such as those of file uploads, are recorded while they're sent, to send them again on retries.
+		recorded := newRecordedBody(req.Body)
+		defer func() {
+			if err := recorded.cleanup(); err != nil {
+				log.Warn("Failed removing the recorded body of the HTTP request to", req.URL.Redacted()+":", err.Error())
+			}
+		}()
+		ctx := req.Context()
+		getBody = func() (io.ReadCloser, error) {
+			return recorded.replay(ctx)
+		}
+		req = req.Clone(ctx)
+		req.Body = recorded
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := rrt.next.RoundTrip(req)
+		if attempt >= rrt.policy.Retries || !rrt.policy.shouldRetry(req.Context(), resp, err) {
+			return resp, err
+		}
+		delay := rrt.policy.GetDelay(attempt, resp)
+		metrics.AddRetry()
+		if err != nil {
+			log.Debug("HTTP request to", req.URL.Redacted(), "failed:", err.Error()+". Retrying in", delay.String()+"...")
+		} else {
+			log.Debug("HTTP request to", req.URL.Redacted(), "returned status", strconv.Itoa(resp.StatusCode)+". Retrying in", delay.String()+"...")
+			// Drain the body to allow reusing the connection.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+		if getBody != nil {
+			body, err := getBody()
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
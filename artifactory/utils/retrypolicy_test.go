@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRetryPolicyNotConfigured(t *testing.T) {
+	policy, err := GetRetryPolicy(nil, 5)
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestGetRetryPolicy(t *testing.T) {
+	setRetryPolicyEnv(t, map[string]string{
+		HttpRetriesEnv:              "4",
+		HttpRetryWaitTimeEnv:        "200ms",
+		HttpRetryBackoffBaseEnv:     "3",
+		HttpRetryMaxDelayEnv:        "1s",
+		HttpRetryableStatusCodesEnv: "429, 503",
+	})
+
+	policy, err := GetRetryPolicy(nil, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, &RetryPolicy{Retries: 4, RetryWaitTime: 200 * time.Millisecond, BackoffBase: 3, MaxDelay: time.Second, RetryableStatusCodes: []int{429, 503}}, policy)
+
+	// The command's explicit retries override only the configured number of retries.
+	policy, err = GetRetryPolicy(nil, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &RetryPolicy{Retries: 1, RetryWaitTime: 200 * time.Millisecond, BackoffBase: 3, MaxDelay: time.Second, RetryableStatusCodes: []int{429, 503}}, policy)
+
+	// The default retries of the command don't override the configured number of retries.
+	policy, err = GetRetryPolicy(nil, DefaultHttpRetries)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, policy.Retries)
+}
+
+func TestGetRetryPolicyFromServerConfig(t *testing.T) {
+	retries := 2
+	serverDetails := &config.ServerDetails{ServerId: "my-server", HttpRetryPolicy: &config.HttpRetryPolicy{
+		Retries:              &retries,
+		RetryWaitTime:        "100ms",
+		BackoffBase:          1.5,
+		RetryableStatusCodes: []int{503},
+	}}
+	policy, err := GetRetryPolicy(serverDetails, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, &RetryPolicy{Retries: 2, RetryWaitTime: 100 * time.Millisecond, BackoffBase: 1.5, MaxDelay: DefaultHttpRetryMaxDelay, RetryableStatusCodes: []int{503}}, policy)
+
+	// The environment variables override the server configuration.
+	setRetryPolicyEnv(t, map[string]string{HttpRetriesEnv: "5"})
+	policy, err = GetRetryPolicy(serverDetails, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, policy.Retries)
+	assert.Equal(t, 100*time.Millisecond, policy.RetryWaitTime)
+
+	serverDetails.HttpRetryPolicy.BackoffBase = 0.5
+	_, err = GetRetryPolicy(serverDetails, -1)
+	assert.EqualError(t, err, "invalid backoffBase in the HTTP retry policy of the 'my-server' server configuration: 0.5")
+}
+
+func TestGetRetryPolicyInvalidValues(t *testing.T) {
+	testCases := map[string]string{
+		HttpRetriesEnv:              "-1",
+		HttpRetryWaitTimeEnv:        "5",
+		HttpRetryBackoffBaseEnv:     "0.5",
+		HttpRetryMaxDelayEnv:        "forever",
+		HttpRetryableStatusCodesEnv: "429,abc",
+	}
+	for env, value := range testCases {
+		t.Run(env, func(t *testing.T) {
+			setRetryPolicyEnv(t, map[string]string{env: value})
+			_, err := GetRetryPolicy(nil, -1)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRetryPolicyGetDelay(t *testing.T) {
+	policy := &RetryPolicy{RetryWaitTime: 100 * time.Millisecond, BackoffBase: 2, MaxDelay: time.Second}
+	assert.Equal(t, 100*time.Millisecond, policy.GetDelay(0, nil))
+	assert.Equal(t, 400*time.Millisecond, policy.GetDelay(2, nil))
+	// Limited by the max delay
+	assert.Equal(t, time.Second, policy.GetDelay(10, nil))
+
+	// The Retry-After header takes precedence over the backoff
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"0"}}}
+	assert.Equal(t, time.Duration(0), policy.GetDelay(2, resp))
+	resp.Header.Set("Retry-After", "120")
+	assert.Equal(t, time.Second, policy.GetDelay(0, resp))
+}
+
+func TestRetryPolicyHttpClient(t *testing.T) {
+	var requests int
+	var bodies []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		bodies = append(bodies, string(body))
+		switch requests {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer testServer.Close()
+
+	policy := &RetryPolicy{Retries: 3, RetryWaitTime: time.Millisecond, BackoffBase: 1, RetryableStatusCodes: DefaultRetryableStatusCodes}
+	client, err := policy.CreateHttpClient(&config.ServerDetails{})
+	assert.NoError(t, err)
+	resp, err := client.Post(testServer.URL, "text/plain", strings.NewReader("payload"))
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, []string{"payload", "payload", "payload"}, bodies)
+
+	// Non-retryable status codes are returned immediately
+	requests = 0
+	policy.RetryableStatusCodes = []int{http.StatusServiceUnavailable}
+	resp, err = client.Get(testServer.URL)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, 1, requests)
+}
+
+func setRetryPolicyEnv(t *testing.T, envVars map[string]string) {
+	for env, value := range envVars {
+		testsutils.SetEnvAndAssert(t, env, value)
+	}
+	t.Cleanup(func() {
+		for env := range envVars {
+			testsutils.UnSetEnvAndAssert(t, env)
+		}
+	})
+}
+
+func TestRetryPolicyHttpClientStreamedBody(t *testing.T) {
+	// Larger than the body recorded in memory, so that it's recorded to a temporary file.
+	payload := strings.Repeat("a", maxInMemoryRetryBodySize+1)
+	var bodies []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer testServer.Close()
+
+	policy := &RetryPolicy{Retries: 2, RetryWaitTime: time.Millisecond, BackoffBase: 1, RetryableStatusCodes: DefaultRetryableStatusCodes}
+	client, err := policy.CreateHttpClient(&config.ServerDetails{})
+	assert.NoError(t, err)
+	// The body of the request can't be re-read, as the bodies of file uploads.
+	req, err := http.NewRequest(http.MethodPut, testServer.URL, io.MultiReader(strings.NewReader(payload)))
+	assert.NoError(t, err)
+	assert.Nil(t, req.GetBody)
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, []string{payload, payload}, bodies)
+}
@@ -147,10 +147,11 @@ func GetUsedSpaceInBytes(repoSummary *utils.RepositorySummary) (int64, error) {
 		return size, errorutils.CheckError(err)
 	}
 
-	return convertStorageSizeStringToBytes(repoSummary.UsedSpace)
+	return ConvertStorageSizeStringToBytes(repoSummary.UsedSpace)
 }
 
-func convertStorageSizeStringToBytes(sizeStr string) (int64, error) {
+// ConvertStorageSizeStringToBytes converts a size string returned by the storage info API, such as "1,004.64 KB", to bytes.
+func ConvertStorageSizeStringToBytes(sizeStr string) (int64, error) {
 	usedSpaceParts := strings.Fields(sizeStr)
 	if len(usedSpaceParts) != 2 {
 		return 0, errorutils.CheckErrorf("could not parse size string '%s'", sizeStr)
@@ -87,7 +87,7 @@ func TestConvertStorageSizeStringToBytes(t *testing.T) {
 }
 
 func assertConvertedStorageSize(t *testing.T, size string, errorExpected bool, expectedSizeBeforeConversion float64) {
-	converted, err := convertStorageSizeStringToBytes(size)
+	converted, err := ConvertStorageSizeStringToBytes(size)
 	if errorExpected {
 		assert.Error(t, err)
 		return
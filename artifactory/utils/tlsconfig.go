@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The bundle of the custom CA certificates, generated in the JFrog security directory for the package managers.
+const caBundleFileName = "ca-bundle.pem"
+
+// Environment variables read by pip, pipenv and poetry.
+const (
+	pipCertEnv          = "PIP_CERT"
+	pipTrustedHostEnv   = "PIP_TRUSTED_HOST"
+	requestsCaBundleEnv = "REQUESTS_CA_BUNDLE"
+)
+
+// Node.js trusts the certificates in this file in addition to its built-in root certificates, unlike the 'cafile' npm config, which replaces them.
+const nodeExtraCaCertsEnv = "NODE_EXTRA_CA_CERTS"
+
+// The locations of the system root certificates bundle on Linux and BSD, as searched by Go's crypto/x509.
+// SSL_CERT_FILE overrides them.
+var systemCaBundlePaths = []string{
+	"/etc/ssl/certs/ca-certificates.crt",
+	"/etc/pki/tls/certs/ca-bundle.crt",
+	"/etc/ssl/ca-bundle.pem",
+	"/etc/pki/tls/cacert.pem",
+	"/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem",
+	"/etc/ssl/cert.pem",
+	"/usr/local/etc/ssl/cert.pem",
+}
+
+// PackageManagerTlsConfig holds the TLS settings of JFrog CLI, to be propagated to the package managers it runs,
+// so that they trust the same servers JFrog CLI trusts.
+type PackageManagerTlsConfig struct {
+	// The path to a PEM bundle of the system root certificates, followed by the custom CA certificates.
+	// Empty if no custom certificates are configured.
+	CaBundlePath string
+	// True if the TLS certificates of the server shouldn't be verified (--insecure-tls).
+	InsecureTls bool
+	// The host of the Artifactory server.
+	Host string
+}
+
+// GetPackageManagerTlsConfig returns the TLS settings of the provided server.
+// The custom CA certificates in the JFrog certificates directory are merged into a single PEM bundle, since the package managers accept a single CA file.
+// Since pip and requests trust only the certificates in the bundle, the system root certificates are added to it as well.
+func GetPackageManagerTlsConfig(serverDetails *config.ServerDetails) (*PackageManagerTlsConfig, error) {
+	certsDir, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return nil, err
+	}
+	securityDir, err := coreutils.GetJfrogSecurityDir()
+	if err != nil {
+		return nil, err
+	}
+	return getPackageManagerTlsConfig(serverDetails, certsDir, filepath.Join(securityDir, caBundleFileName))
+}
+
+func getPackageManagerTlsConfig(serverDetails *config.ServerDetails, certsDir, caBundlePath string) (*PackageManagerTlsConfig, error) {
+	tlsConfig := &PackageManagerTlsConfig{InsecureTls: serverDetails.InsecureTls}
+	if parsedUrl, err := url.Parse(serverDetails.GetArtifactoryUrl()); err == nil {
+		tlsConfig.Host = parsedUrl.Hostname()
+	}
+	bundle, err := createCaBundle(certsDir)
+	if err != nil || len(bundle) == 0 {
+		return tlsConfig, err
+	}
+	bundle = append(readSystemCaBundle(), bundle...)
+	if err = os.MkdirAll(filepath.Dir(caBundlePath), 0700); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if err = os.WriteFile(caBundlePath, bundle, 0600); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	tlsConfig.CaBundlePath = caBundlePath
+	return tlsConfig, nil
+}
+
+// Concatenates the PEM certificates in the certificates directory. Files which don't contain certificates are ignored.
+func createCaBundle(certsDir string) ([]byte, error) {
+	entries, err := os.ReadDir(certsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	var fileNames []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			fileNames = append(fileNames, entry.Name())
+		}
+	}
+	sort.Strings(fileNames)
+	bundle := new(bytes.Buffer)
+	for _, fileName := range fileNames {
+		content, err := os.ReadFile(filepath.Join(certsDir, fileName))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		for block, rest := pem.Decode(content); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			if err = pem.Encode(bundle, block); err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+		}
+	}
+	return bundle.Bytes(), nil
+}
+
+// Returns the system root certificates bundle, or nil if it isn't found, as on macOS and Windows,
+// where the package managers use the system trust store or their own root certificates.
+func readSystemCaBundle() []byte {
+	paths := systemCaBundlePaths
+	if sslCertFile := os.Getenv("SSL_CERT_FILE"); sslCertFile != "" {
+		paths = []string{sslCertFile}
+	}
+	for _, path := range paths {
+		if content, err := os.ReadFile(path); err == nil {
+			if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+				content = append(content, '\n')
+			}
+			return content
+		}
+	}
+	log.Debug("The system root certificates bundle wasn't found, so only the custom CA certificates are added to the package managers' bundle.")
+	return nil
+}
+
+// NpmConfig returns the .npmrc lines configuring npm with the TLS settings.
+// The CA certificates are configured by NpmEnv, so that npm keeps trusting its built-in root certificates.
+func (tc *PackageManagerTlsConfig) NpmConfig() []string {
+	var npmConfig []string
+	if tc.InsecureTls {
+		npmConfig = append(npmConfig, "strict-ssl = false\n")
+	}
+	return npmConfig
+}
+
+// NpmEnv returns the environment variables configuring npm with the TLS settings.
+func (tc *PackageManagerTlsConfig) NpmEnv() map[string]string {
+	env := map[string]string{}
+	if tc.CaBundlePath != "" {
+		env[nodeExtraCaCertsEnv] = tc.CaBundlePath
+	}
+	return env
+}
+
+// SetNpmEnv sets the environment variables returned by NpmEnv, unless they're already set by the user.
+func (tc *PackageManagerTlsConfig) SetNpmEnv() error {
+	return setEnvIfNotSet(tc.NpmEnv())
+}
+
+// PythonEnv returns the environment variables configuring pip, pipenv and poetry with the TLS settings.
+func (tc *PackageManagerTlsConfig) PythonEnv() map[string]string {
+	env := map[string]string{}
+	if tc.CaBundlePath != "" {
+		env[pipCertEnv] = tc.CaBundlePath
+		env[requestsCaBundleEnv] = tc.CaBundlePath
+	}
+	if tc.InsecureTls && tc.Host != "" {
+		env[pipTrustedHostEnv] = tc.Host
+	}
+	return env
+}
+
+// SetPythonEnv sets the environment variables returned by PythonEnv, unless they're already set by the user.
+func (tc *PackageManagerTlsConfig) SetPythonEnv() error {
+	return setEnvIfNotSet(tc.PythonEnv())
+}
+
+func setEnvIfNotSet(env map[string]string) error {
+	for key, value := range env {
+		if _, exists := os.LookupEnv(key); exists {
+			log.Debug(fmt.Sprintf("The %s environment variable is already set, and isn't overridden.", key))
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+// DockerHints returns instructions for configuring the Docker daemon with the TLS settings.
+// Unlike the other package managers, the daemon's configuration requires elevated permissions, and isn't modified by JFrog CLI.
+func (tc *PackageManagerTlsConfig) DockerHints(registry string) []string {
+	var hints []string
+	if tc.CaBundlePath != "" {
+		hints = append(hints, fmt.Sprintf("To make the Docker daemon trust the custom CA certificates, copy %s to %s.",
+			tc.CaBundlePath, filepath.ToSlash(filepath.Join("/etc/docker/certs.d", registry, "ca.crt"))))
+	}
+	if tc.InsecureTls {
+		hints = append(hints, fmt.Sprintf("To skip the TLS verification in the Docker daemon, add '%s' to the 'insecure-registries' list in its daemon.json file.", registry))
+	}
+	return hints
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createCertificatePem(t *testing.T, commonName string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestGetPackageManagerTlsConfig(t *testing.T) {
+	certsDir := t.TempDir()
+	firstCert, secondCert := createCertificatePem(t, "first"), createCertificatePem(t, "second")
+	require.NoError(t, os.WriteFile(filepath.Join(certsDir, "a.pem"), firstCert, 0600))
+	// A private key shouldn't be added to the bundle.
+	require.NoError(t, os.WriteFile(filepath.Join(certsDir, "b.pem"), append(secondCert, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})...), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(certsDir, "readme.txt"), []byte("not a certificate"), 0600))
+	caBundlePath := filepath.Join(t.TempDir(), "security", caBundleFileName)
+	systemCert := createCertificatePem(t, "system")
+	systemBundlePath := filepath.Join(t.TempDir(), "ca-certificates.crt")
+	require.NoError(t, os.WriteFile(systemBundlePath, systemCert, 0600))
+	t.Setenv("SSL_CERT_FILE", systemBundlePath)
+
+	tlsConfig, err := getPackageManagerTlsConfig(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", InsecureTls: true}, certsDir, caBundlePath)
+	require.NoError(t, err)
+	assert.Equal(t, caBundlePath, tlsConfig.CaBundlePath)
+	assert.Equal(t, "acme.jfrog.io", tlsConfig.Host)
+	bundle, err := os.ReadFile(caBundlePath)
+	require.NoError(t, err)
+	// The system root certificates are kept trusted by pip and requests.
+	assert.Equal(t, string(systemCert)+string(firstCert)+string(secondCert), string(bundle))
+
+	assert.Equal(t, []string{"strict-ssl = false\n"}, tlsConfig.NpmConfig())
+	assert.Equal(t, map[string]string{nodeExtraCaCertsEnv: caBundlePath}, tlsConfig.NpmEnv())
+	assert.Equal(t, map[string]string{pipCertEnv: caBundlePath, requestsCaBundleEnv: caBundlePath, pipTrustedHostEnv: "acme.jfrog.io"}, tlsConfig.PythonEnv())
+	assert.Len(t, tlsConfig.DockerHints("acme.jfrog.io"), 2)
+}
+
+func TestGetPackageManagerTlsConfigDefaults(t *testing.T) {
+	caBundlePath := filepath.Join(t.TempDir(), caBundleFileName)
+	tlsConfig, err := getPackageManagerTlsConfig(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}, filepath.Join(t.TempDir(), "missing"), caBundlePath)
+	require.NoError(t, err)
+	assert.Empty(t, tlsConfig.CaBundlePath)
+	assert.NoFileExists(t, caBundlePath)
+	assert.Empty(t, tlsConfig.NpmConfig())
+	assert.Empty(t, tlsConfig.NpmEnv())
+	assert.Empty(t, tlsConfig.PythonEnv())
+	assert.Empty(t, tlsConfig.DockerHints("acme.jfrog.io"))
+}
@@ -2,17 +2,22 @@ package utils
 
 import (
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io"
 )
 
-func CreateUploadServiceManager(serverDetails *config.ServerDetails, threads, httpRetries, httpRetryWaitMilliSecs int, dryRun bool, progressBar io.ProgressMgr) (artifactory.ArtifactoryServicesManager, error) {
-	return CreateServiceManagerWithProgressBar(serverDetails, threads, httpRetries, httpRetryWaitMilliSecs, dryRun, progressBar)
+// CreateUploadServiceManager creates a services manager for uploading files.
+// A positive rateLimit caps the bandwidth of the uploads in bytes per second, overriding the JFROG_CLI_RATE_LIMIT environment variable.
+func CreateUploadServiceManager(serverDetails *config.ServerDetails, threads, httpRetries, httpRetryWaitMilliSecs int, dryRun bool, progressBar io.ProgressMgr, rateLimit int64) (artifactory.ArtifactoryServicesManager, error) {
+	return createServiceManagerWithRateLimit(serverDetails, threads, httpRetries, httpRetryWaitMilliSecs, dryRun, progressBar, rateLimit, nil)
 }
 
 type UploadConfiguration struct {
@@ -23,6 +28,8 @@ type UploadConfiguration struct {
 	SplitCount            int
 	MinSplitSizeMB        int64
 	ChunkSizeMB           int64
+	// If true, the vcs.revision, vcs.branch and vcs.url properties of the git repository containing the files are set on the uploaded artifacts.
+	AddVcsProps bool
 }
 
 func GetMinChecksumDeploySize() (int64, error) {
@@ -37,3 +44,28 @@ func GetMinChecksumDeploySize() (int64, error) {
 	}
 	return minSize * 1000, nil
 }
+
+// CreateVcsProps returns the vcs.revision, vcs.branch and vcs.url properties of the git repository containing the path,
+// in the form of 'vcs.revision=<revision>;vcs.branch=<branch>;vcs.url=<url>'.
+// Returns an empty string if the path isn't in a git repository.
+func CreateVcsProps(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	revision, url, branch, err := clientutils.NewVcsDetails().GetVcsDetails(absPath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	var props []string
+	if revision != "" {
+		props = append(props, "vcs.revision="+revision)
+	}
+	if branch != "" {
+		props = append(props, "vcs.branch="+branch)
+	}
+	if url != "" {
+		props = append(props, "vcs.url="+url)
+	}
+	return strings.Join(props, ";"), nil
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateVcsProps(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repoDir := t.TempDir()
+	runGit := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@acme.io"}, args...)...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+		return strings.TrimSpace(string(output))
+	}
+	runGit("init", "-q", "-b", "main")
+	runGit("remote", "add", "origin", "https://github.com/acme/app.git")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "dist"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "dist", "app.zip"), []byte("app"), 0644))
+	runGit("add", "-A")
+	runGit("commit", "-q", "-m", "initial")
+	revision := runGit("rev-parse", "HEAD")
+
+	props, err := CreateVcsProps(filepath.Join(repoDir, "dist"))
+	assert.NoError(t, err)
+	assert.Equal(t, "vcs.revision="+revision+";vcs.branch=main;vcs.url=https://github.com/acme/app.git", props)
+
+	// A path outside a git repository has no VCS properties.
+	props, err = CreateVcsProps(t.TempDir())
+	assert.NoError(t, err)
+	assert.Empty(t, props)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/metrics"
 	"github.com/jfrog/jfrog-client-go/access"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/auth"
@@ -85,13 +86,13 @@ func GetEncryptedPasswordFromArtifactory(artifactoryAuth auth.ServiceDetails, in
 }
 
 func CreateServiceManager(serverDetails *config.ServerDetails, httpRetries, httpRetryWaitMilliSecs int, isDryRun bool) (artifactory.ArtifactoryServicesManager, error) {
-	return CreateServiceManagerWithContext(context.Background(), serverDetails, isDryRun, 0, httpRetries, httpRetryWaitMilliSecs, 0)
+	return CreateServiceManagerWithContext(coreutils.CommandContext(), serverDetails, isDryRun, 0, httpRetries, httpRetryWaitMilliSecs, 0)
 }
 
 // Create a service manager with threads.
 // If the value sent for httpRetries is negative, the default will be used.
 func CreateServiceManagerWithThreads(serverDetails *config.ServerDetails, isDryRun bool, threads, httpRetries, httpRetryWaitMilliSecs int) (artifactory.ArtifactoryServicesManager, error) {
-	return CreateServiceManagerWithContext(context.Background(), serverDetails, isDryRun, threads, httpRetries, httpRetryWaitMilliSecs, 0)
+	return CreateServiceManagerWithContext(coreutils.CommandContext(), serverDetails, isDryRun, threads, httpRetries, httpRetryWaitMilliSecs, 0)
 }
 
 func CreateServiceManagerWithContext(context context.Context, serverDetails *config.ServerDetails, isDryRun bool, threads, httpRetries, httpRetryWaitMilliSecs int, timeout time.Duration) (artifactory.ArtifactoryServicesManager, error) {
@@ -119,6 +120,9 @@ func CreateServiceManagerWithContext(context context.Context, serverDetails *con
 	if timeout > 0 {
 		configBuilder.SetOverallRequestTimeout(timeout)
 	}
+	if err = ApplyCustomHttpClient(configBuilder, serverDetails, httpRetries, timeout, 0); err != nil {
+		return nil, err
+	}
 	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
@@ -127,6 +131,14 @@ func CreateServiceManagerWithContext(context context.Context, serverDetails *con
 }
 
 func CreateServiceManagerWithProgressBar(serverDetails *config.ServerDetails, threads, httpRetries, httpRetryWaitMilliSecs int, dryRun bool, progressBar ioUtils.ProgressMgr) (artifactory.ArtifactoryServicesManager, error) {
+	return createServiceManagerWithRateLimit(serverDetails, threads, httpRetries, httpRetryWaitMilliSecs, dryRun, progressBar, 0, nil)
+}
+
+// Creates a services manager with a progress bar, which limits the bandwidth of its requests to rateLimit bytes per second.
+// If rateLimit is 0, the rate limit configured by the JFROG_CLI_RATE_LIMIT environment variable is used.
+// If checksumValidator isn't nil, it validates the checksums of the downloaded files while they're transferred.
+func createServiceManagerWithRateLimit(serverDetails *config.ServerDetails, threads, httpRetries, httpRetryWaitMilliSecs int, dryRun bool, progressBar ioUtils.ProgressMgr, rateLimit int64,
+	checksumValidator *ChecksumValidator) (artifactory.ArtifactoryServicesManager, error) {
 	certsPath, err := coreutils.GetJfrogCertsDir()
 	if err != nil {
 		return nil, err
@@ -135,7 +147,7 @@ func CreateServiceManagerWithProgressBar(serverDetails *config.ServerDetails, th
 	if err != nil {
 		return nil, err
 	}
-	servicesConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(artAuth).
 		SetDryRun(dryRun).
 		SetCertificatesPath(certsPath).
@@ -143,14 +155,92 @@ func CreateServiceManagerWithProgressBar(serverDetails *config.ServerDetails, th
 		SetThreads(threads).
 		SetHttpRetries(httpRetries).
 		SetHttpRetryWaitMilliSecs(httpRetryWaitMilliSecs).
-		Build()
-
+		SetContext(coreutils.CommandContext())
+	if err = applyCustomHttpClient(configBuilder, serverDetails, httpRetries, 0, rateLimit, checksumValidator); err != nil {
+		return nil, err
+	}
+	servicesConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
 	return artifactory.NewWithProgress(servicesConfig, progressBar)
 }
 
+// The setters of the services manager configuration builder, which is returned by clientConfig.NewConfigBuilder.
+type httpClientConfigBuilder[T any] interface {
+	SetHttpClient(httpClient *http.Client) T
+	SetHttpRetries(httpRetries int) T
+}
+
+// ApplyCustomHttpClient sets the HTTP client created by createCustomHttpClient in the services manager configuration, if one is needed.
+// It should be applied to the configurations of the services managers of all the JFrog services, so that they all use the configured
+// retry policy, rate limit, HTTP tuning and metrics. A negative httpRetries keeps the retries of the configuration.
+func ApplyCustomHttpClient[T httpClientConfigBuilder[T]](configBuilder T, serverDetails *config.ServerDetails, httpRetries int, timeout time.Duration, rateLimit int64) error {
+	return applyCustomHttpClient(configBuilder, serverDetails, httpRetries, timeout, rateLimit, nil)
+}
+
+func applyCustomHttpClient[T httpClientConfigBuilder[T]](configBuilder T, serverDetails *config.ServerDetails, httpRetries int, timeout time.Duration, rateLimit int64,
+	checksumValidator *ChecksumValidator) error {
+	customHttpClient, retriesHandled, err := createCustomHttpClient(serverDetails, httpRetries, timeout, rateLimit, checksumValidator)
+	if err != nil {
+		return err
+	}
+	if customHttpClient != nil {
+		configBuilder.SetHttpClient(customHttpClient)
+	}
+	if retriesHandled {
+		configBuilder.SetHttpRetries(0)
+	}
+	return nil
+}
+
+// If a retry policy, a rate limit, an HTTP tuning, metrics or a checksum validator are configured, returns an HTTP client which applies them.
+// Otherwise, returns nil.
+// A positive rateLimit, usually provided by the command, overrides the rate limit configured by the JFROG_CLI_RATE_LIMIT environment variable.
+// retriesHandled is true if the client retries failed requests, in which case the retries of the services manager should be disabled.
+func createCustomHttpClient(serverDetails *config.ServerDetails, httpRetries int, timeout time.Duration, rateLimit int64,
+	checksumValidator *ChecksumValidator) (httpClient *http.Client, retriesHandled bool, err error) {
+	retryPolicy, err := GetRetryPolicy(serverDetails, httpRetries)
+	if err != nil {
+		return
+	}
+	if rateLimit <= 0 {
+		if rateLimit, err = GetRateLimit(); err != nil {
+			return
+		}
+	}
+	tuning, err := GetHttpTuning()
+	collectMetrics := metrics.IsEnabled()
+	if err != nil || (retryPolicy == nil && rateLimit == 0 && tuning == nil && !collectMetrics && checksumValidator == nil) {
+		return
+	}
+	// The timeout of the command takes precedence over the global request timeout.
+	if timeout == 0 && tuning != nil {
+		timeout = tuning.RequestTimeout
+	}
+	transport, err := createHttpTransport(serverDetails)
+	if err != nil {
+		return
+	}
+	var roundTripper http.RoundTripper = transport
+	if collectMetrics {
+		roundTripper = metrics.WrapRoundTripper(roundTripper)
+	}
+	if rateLimit > 0 {
+		roundTripper = newThrottledRoundTripper(rateLimit, roundTripper)
+	}
+	if retryPolicy != nil {
+		roundTripper = retryPolicy.wrapRoundTripper(roundTripper)
+		retriesHandled = true
+	}
+	if checksumValidator != nil {
+		// Only the content of the final response of each download is validated.
+		roundTripper = checksumValidator.wrapRoundTripper(roundTripper)
+	}
+	httpClient = &http.Client{Transport: roundTripper, Timeout: timeout}
+	return
+}
+
 func CreateDistributionServiceManager(serviceDetails *config.ServerDetails, isDryRun bool) (*distribution.DistributionServicesManager, error) {
 	certsPath, err := coreutils.GetJfrogCertsDir()
 	if err != nil {
@@ -160,12 +250,16 @@ func CreateDistributionServiceManager(serviceDetails *config.ServerDetails, isDr
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(distAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
 		SetDryRun(isDryRun).
-		Build()
+		SetContext(coreutils.CommandContext())
+	if err = ApplyCustomHttpClient(configBuilder, serviceDetails, -1, 0, 0); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -173,6 +267,15 @@ func CreateDistributionServiceManager(serviceDetails *config.ServerDetails, isDr
 }
 
 func CreateAccessServiceManager(serviceDetails *config.ServerDetails, isDryRun bool) (*access.AccessServicesManager, error) {
+	return createAccessServiceManager(serviceDetails, isDryRun, -1, 0)
+}
+
+// CreateAccessServiceManagerWithTimeout creates an Access services manager whose requests aren't retried, and time out after the timeout.
+func CreateAccessServiceManagerWithTimeout(serviceDetails *config.ServerDetails, timeout time.Duration) (*access.AccessServicesManager, error) {
+	return createAccessServiceManager(serviceDetails, false, 0, timeout)
+}
+
+func createAccessServiceManager(serviceDetails *config.ServerDetails, isDryRun bool, httpRetries int, timeout time.Duration) (*access.AccessServicesManager, error) {
 	certsPath, err := coreutils.GetJfrogCertsDir()
 	if err != nil {
 		return nil, err
@@ -181,12 +284,22 @@ func CreateAccessServiceManager(serviceDetails *config.ServerDetails, isDryRun b
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(accessAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
 		SetDryRun(isDryRun).
-		Build()
+		SetContext(coreutils.CommandContext())
+	if httpRetries >= 0 {
+		configBuilder.SetHttpRetries(httpRetries)
+	}
+	if timeout > 0 {
+		configBuilder.SetOverallRequestTimeout(timeout)
+	}
+	if err = ApplyCustomHttpClient(configBuilder, serviceDetails, httpRetries, timeout, 0); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -202,12 +315,16 @@ func CreateLifecycleServiceManager(serviceDetails *config.ServerDetails, isDryRu
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(lcAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
 		SetDryRun(isDryRun).
-		Build()
+		SetContext(coreutils.CommandContext())
+	if err = ApplyCustomHttpClient(configBuilder, serviceDetails, -1, 0, 0); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -262,6 +379,7 @@ func createServiceManager(serviceDetails auth.ServiceDetails) (artifactory.Artif
 		SetServiceDetails(serviceDetails).
 		SetCertificatesPath(certsPath).
 		SetDryRun(false).
+		SetContext(coreutils.CommandContext()).
 		Build()
 	if err != nil {
 		return nil, err
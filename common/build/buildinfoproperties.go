@@ -44,6 +44,7 @@ const DeployerPrefix = "deployer."
 const Repo = "repo"
 const SnapshotRepo = "snapshotRepo"
 const ReleaseRepo = "releaseRepo"
+const MirrorRepos = "mirrorRepos"
 
 const ServerId = "serverId"
 const Url = "url"
@@ -60,6 +61,8 @@ const ForkCount = "forkCount"
 const IncludePatterns = "includePatterns"
 const ExcludePatterns = "excludePatterns"
 const FilterExcludedArtifactsFromBuild = "filterExcludedArtifactsFromBuild"
+const ProfilesExcludePatterns = "profilesExcludePatterns"
+const DefaultProfilesExcludePatterns = "defaultProfilesExcludePatterns"
 
 // For path and temp files
 const PropertiesTempPath = "jfrog/properties/"
@@ -136,7 +139,7 @@ var gradleConfigMapping = map[string]string{
 	"resolve.repoKey":                                   ResolverPrefix + Repo,
 	"resolve.downSnapshotRepoKey":                       ResolverPrefix + Repo,
 	"publish.repoKey":                                   DeployerPrefix + Repo,
-	"publish.snapshot.repoKey":                          DeployerPrefix + Repo,
+	"publish.snapshot.repoKey":                          DeployerPrefix + SnapshotRepo,
 	"publish.maven":                                     DeployerPrefix + MavenDescriptor,
 	"publish.ivy":                                       DeployerPrefix + IvyDescriptor,
 	"publish.ivy.ivyPattern":                            DeployerPrefix + IvyPattern,
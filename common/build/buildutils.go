@@ -225,6 +225,46 @@ func GetGeneratedBuildsInfo(buildName, buildNumber, projectKey string) ([]*build
 	return generatedBuildsInfo, nil
 }
 
+// UpdateGeneratedBuildsInfo updates the generated build-info files of the build, such as the build-info files of the Maven and Gradle extractors.
+// The update function returns true if it modified the build-info, in which case the file is rewritten.
+func UpdateGeneratedBuildsInfo(buildName, buildNumber, projectKey string, update func(buildInfo *buildInfo.BuildInfo) bool) error {
+	buildDir, err := GetBuildDir(buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	buildFiles, err := fileutils.ListFiles(buildDir, false)
+	if err != nil {
+		return err
+	}
+	for _, buildFile := range buildFiles {
+		dir, err := fileutils.IsDirExists(buildFile, false)
+		if err != nil {
+			return err
+		}
+		if dir {
+			continue
+		}
+		content, err := fileutils.ReadFile(buildFile)
+		if err != nil {
+			return err
+		}
+		generatedBuildInfo := new(buildInfo.BuildInfo)
+		if err = json.Unmarshal(content, &generatedBuildInfo); err != nil {
+			return errorutils.CheckError(err)
+		}
+		if !update(generatedBuildInfo) {
+			continue
+		}
+		if content, err = json.MarshalIndent(generatedBuildInfo, "", "  "); err != nil {
+			return errorutils.CheckError(err)
+		}
+		if err = os.WriteFile(buildFile, content, 0600); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
 func ReadPartialBuildInfoFiles(buildName, buildNumber, projectKey string) (buildInfo.Partials, error) {
 	var partials buildInfo.Partials
 	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber, projectKey)
@@ -311,6 +351,7 @@ func RemoveBuildDir(buildName, buildNumber, projectKey string) error {
 type BuildConfiguration struct {
 	buildName            string
 	buildNumber          string
+	buildUrl             string
 	module               string
 	project              string
 	loadedFromConfigFile bool
@@ -330,6 +371,11 @@ func (bc *BuildConfiguration) SetBuildNumber(buildNumber string) *BuildConfigura
 	return bc
 }
 
+func (bc *BuildConfiguration) SetBuildUrl(buildUrl string) *BuildConfiguration {
+	bc.buildUrl = buildUrl
+	return bc
+}
+
 func (bc *BuildConfiguration) SetProject(project string) *BuildConfiguration {
 	bc.project = project
 	return bc
@@ -348,6 +394,12 @@ func (bc *BuildConfiguration) GetBuildName() (string, error) {
 	if bc.buildName = os.Getenv(coreutils.BuildName); bc.buildName != "" {
 		return bc.buildName, nil
 	}
+	// Resolve from the CI provider's environment.
+	if ciBuildDetails := GetCiBuildDetails(); ciBuildDetails != nil {
+		log.Info("Using the build name and number of the " + ciBuildDetails.Provider + " pipeline: " + ciBuildDetails.BuildName + "/" + ciBuildDetails.BuildNumber)
+		bc.buildName = ciBuildDetails.BuildName
+		return bc.buildName, nil
+	}
 	// Resolve from config file in '.jfrog' folder.
 	var err error
 	if bc.buildName, err = bc.getBuildNameFromConfigFile(); bc.buildName != "" {
@@ -380,6 +432,11 @@ func (bc *BuildConfiguration) GetBuildNumber() (string, error) {
 	if bc.buildNumber = os.Getenv(coreutils.BuildNumber); bc.buildNumber != "" {
 		return bc.buildNumber, nil
 	}
+	// Resolve from the CI provider's environment.
+	if ciBuildDetails := GetCiBuildDetails(); ciBuildDetails != nil {
+		bc.buildNumber = ciBuildDetails.BuildNumber
+		return bc.buildNumber, nil
+	}
 	// If build name was resolve from build.yaml file, use 'LATEST' as build number.
 	buildName, err := bc.GetBuildName()
 	if err != nil {
@@ -391,6 +448,17 @@ func (bc *BuildConfiguration) GetBuildNumber() (string, error) {
 	return bc.buildNumber, nil
 }
 
+// GetBuildUrl returns the URL of the CI build, if it's provided or detected from the CI provider's environment.
+func (bc *BuildConfiguration) GetBuildUrl() string {
+	if bc.buildUrl != "" {
+		return bc.buildUrl
+	}
+	if ciBuildDetails := GetCiBuildDetails(); ciBuildDetails != nil {
+		bc.buildUrl = ciBuildDetails.BuildUrl
+	}
+	return bc.buildUrl
+}
+
 func (bc *BuildConfiguration) GetProject() string {
 	if bc.project != "" {
 		return bc.project
@@ -0,0 +1,102 @@
+package build
+
+import (
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// CiBuildDetails holds the build name, number and URL of the CI pipeline the CLI is running in.
+type CiBuildDetails struct {
+	Provider    string
+	BuildName   string
+	BuildNumber string
+	BuildUrl    string
+}
+
+type ciProvider struct {
+	name string
+	// Returns true if the CLI runs in the CI provider's pipeline.
+	detect         func() bool
+	getBuildName   func() string
+	getBuildNumber func() string
+	getBuildUrl    func() string
+}
+
+var ciProviders = []ciProvider{
+	{
+		name:           "GitHub Actions",
+		detect:         func() bool { return os.Getenv("GITHUB_ACTIONS") == "true" },
+		getBuildName:   func() string { return os.Getenv("GITHUB_WORKFLOW") },
+		getBuildNumber: func() string { return os.Getenv("GITHUB_RUN_NUMBER") },
+		getBuildUrl: func() string {
+			return joinIfAllSet("/", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), "actions/runs", os.Getenv("GITHUB_RUN_ID"))
+		},
+	},
+	{
+		name:           "GitLab CI",
+		detect:         func() bool { return os.Getenv("GITLAB_CI") == "true" },
+		getBuildName:   func() string { return os.Getenv("CI_PROJECT_PATH") },
+		getBuildNumber: func() string { return os.Getenv("CI_PIPELINE_ID") },
+		getBuildUrl:    func() string { return os.Getenv("CI_PIPELINE_URL") },
+	},
+	{
+		name:           "Jenkins",
+		detect:         func() bool { return os.Getenv("JENKINS_URL") != "" },
+		getBuildName:   func() string { return os.Getenv("JOB_NAME") },
+		getBuildNumber: func() string { return os.Getenv("BUILD_NUMBER") },
+		getBuildUrl:    func() string { return os.Getenv("BUILD_URL") },
+	},
+	{
+		name:           "Azure DevOps",
+		detect:         func() bool { return strings.EqualFold(os.Getenv("TF_BUILD"), "true") },
+		getBuildName:   func() string { return os.Getenv("BUILD_DEFINITIONNAME") },
+		getBuildNumber: func() string { return os.Getenv("BUILD_BUILDNUMBER") },
+		getBuildUrl: func() string {
+			buildId := os.Getenv("BUILD_BUILDID")
+			if buildId == "" {
+				return ""
+			}
+			return joinIfAllSet("/", strings.TrimSuffix(os.Getenv("SYSTEM_COLLECTIONURI"), "/"), os.Getenv("SYSTEM_TEAMPROJECT"), "_build/results?buildId="+buildId)
+		},
+	},
+}
+
+// GetCiBuildDetails returns the build details of the CI pipeline the CLI is running in, or nil if it's not running in
+// a supported CI provider, or if the provider doesn't expose both the build name and number.
+// The detection is opt-in, by setting JFROG_CLI_CI_BUILD_DETECTION to true, since it makes commands collect build-info.
+func GetCiBuildDetails() *CiBuildDetails {
+	detectionEnabled, err := clientutils.GetBoolEnvValue(coreutils.CiBuildDetection, false)
+	if err != nil {
+		log.Debug(err.Error())
+		return nil
+	}
+	if !detectionEnabled {
+		return nil
+	}
+	for _, provider := range ciProviders {
+		if !provider.detect() {
+			continue
+		}
+		details := &CiBuildDetails{Provider: provider.name, BuildName: provider.getBuildName(), BuildNumber: provider.getBuildNumber(), BuildUrl: provider.getBuildUrl()}
+		if details.BuildName == "" || details.BuildNumber == "" {
+			log.Debug("Running in " + provider.name + ", but the build name or number couldn't be detected.")
+			return nil
+		}
+		return details
+	}
+	return nil
+}
+
+// Joins the parts with the separator. Returns an empty string if any of the parts is empty.
+func joinIfAllSet(separator string, parts ...string) string {
+	for _, part := range parts {
+		if part == "" {
+			return ""
+		}
+	}
+	return strings.Join(parts, separator)
+}
@@ -0,0 +1,78 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// The environment variables which identify the CI providers.
+var ciProviderEnvs = []string{"GITHUB_ACTIONS", "GITLAB_CI", "JENKINS_URL", "TF_BUILD"}
+
+func setCiEnv(t *testing.T, env map[string]string) {
+	t.Setenv(coreutils.CiBuildDetection, "true")
+	t.Setenv(coreutils.BuildName, "")
+	t.Setenv(coreutils.BuildNumber, "")
+	for _, providerEnv := range ciProviderEnvs {
+		t.Setenv(providerEnv, "")
+	}
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+}
+
+func TestGetCiBuildDetails(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		expected *CiBuildDetails
+	}{
+		{"GitHub Actions", map[string]string{"GITHUB_ACTIONS": "true", "GITHUB_WORKFLOW": "CI", "GITHUB_RUN_NUMBER": "12",
+			"GITHUB_SERVER_URL": "https://github.com", "GITHUB_REPOSITORY": "acme/app", "GITHUB_RUN_ID": "987"},
+			&CiBuildDetails{"GitHub Actions", "CI", "12", "https://github.com/acme/app/actions/runs/987"}},
+		{"GitLab CI", map[string]string{"GITLAB_CI": "true", "CI_PROJECT_PATH": "acme/app", "CI_PIPELINE_ID": "34", "CI_PIPELINE_URL": "https://gitlab.com/acme/app/-/pipelines/34"},
+			&CiBuildDetails{"GitLab CI", "acme/app", "34", "https://gitlab.com/acme/app/-/pipelines/34"}},
+		{"Jenkins", map[string]string{"JENKINS_URL": "https://jenkins.acme.io/", "JOB_NAME": "app/main", "BUILD_NUMBER": "56", "BUILD_URL": "https://jenkins.acme.io/job/app/job/main/56/"},
+			&CiBuildDetails{"Jenkins", "app/main", "56", "https://jenkins.acme.io/job/app/job/main/56/"}},
+		{"Azure DevOps", map[string]string{"TF_BUILD": "True", "BUILD_DEFINITIONNAME": "app", "BUILD_BUILDNUMBER": "20240101.1", "BUILD_BUILDID": "78",
+			"SYSTEM_COLLECTIONURI": "https://dev.azure.com/acme/", "SYSTEM_TEAMPROJECT": "platform"},
+			&CiBuildDetails{"Azure DevOps", "app", "20240101.1", "https://dev.azure.com/acme/platform/_build/results?buildId=78"}},
+		{"missing build number", map[string]string{"JENKINS_URL": "https://jenkins.acme.io/", "JOB_NAME": "app/main", "BUILD_NUMBER": ""}, nil},
+		{"not in CI", map[string]string{}, nil},
+		{"detection disabled", map[string]string{"GITLAB_CI": "true", "CI_PROJECT_PATH": "acme/app", "CI_PIPELINE_ID": "34", coreutils.CiBuildDetection: "false"}, nil},
+		{"detection not enabled", map[string]string{"GITLAB_CI": "true", "CI_PROJECT_PATH": "acme/app", "CI_PIPELINE_ID": "34", coreutils.CiBuildDetection: ""}, nil},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			setCiEnv(t, testCase.env)
+			assert.Equal(t, testCase.expected, GetCiBuildDetails())
+		})
+	}
+}
+
+func TestBuildConfigurationFromCi(t *testing.T) {
+	setCiEnv(t, map[string]string{"GITLAB_CI": "true", "CI_PROJECT_PATH": "acme/app", "CI_PIPELINE_ID": "34", "CI_PIPELINE_URL": "https://gitlab.com/acme/app/-/pipelines/34"})
+
+	buildConfiguration := NewBuildConfiguration("", "", "", "")
+	toCollect, err := buildConfiguration.IsCollectBuildInfo()
+	assert.NoError(t, err)
+	assert.True(t, toCollect)
+	buildName, err := buildConfiguration.GetBuildName()
+	assert.NoError(t, err)
+	assert.Equal(t, "acme/app", buildName)
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, "34", buildNumber)
+	assert.Equal(t, "https://gitlab.com/acme/app/-/pipelines/34", buildConfiguration.GetBuildUrl())
+
+	// The provided build details override the detected ones.
+	buildConfiguration = NewBuildConfiguration("my-build", "1", "", "").SetBuildUrl("https://ci.acme.io/1")
+	buildName, err = buildConfiguration.GetBuildName()
+	assert.NoError(t, err)
+	assert.Equal(t, "my-build", buildName)
+	buildNumber, err = buildConfiguration.GetBuildNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", buildNumber)
+	assert.Equal(t, "https://ci.acme.io/1", buildConfiguration.GetBuildUrl())
+}
@@ -0,0 +1,134 @@
+package build
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The build-info properties recording the tool version declared by the wrapper which ran the build.
+const (
+	MavenWrapperVersionProp  = "buildInfo.maven.wrapper.version"
+	GradleWrapperVersionProp = "buildInfo.gradle.wrapper.version"
+)
+
+type wrapperDetails struct {
+	unixScript     string
+	windowsScript  string
+	propertiesPath string
+	versionProp    string
+	// Extracts the tool version from the distribution URL in the wrapper properties.
+	versionPattern *regexp.Regexp
+}
+
+var wrappers = map[project.ProjectType]wrapperDetails{
+	project.Maven: {
+		unixScript:     "mvnw",
+		windowsScript:  "mvnw.cmd",
+		propertiesPath: filepath.Join(".mvn", "wrapper", "maven-wrapper.properties"),
+		versionProp:    MavenWrapperVersionProp,
+		versionPattern: regexp.MustCompile(`apache-maven-([^/]+)-bin\.(?:zip|tar\.gz)$`),
+	},
+	project.Gradle: {
+		unixScript:     "gradlew",
+		windowsScript:  "gradlew.bat",
+		propertiesPath: filepath.Join("gradle", "wrapper", "gradle-wrapper.properties"),
+		versionProp:    GradleWrapperVersionProp,
+		versionPattern: regexp.MustCompile(`gradle-([^/]+)-(?:bin|all)\.zip$`),
+	},
+}
+
+// IsWrapperExists returns true if the project in the provided directory contains a Maven or Gradle wrapper script for the current OS.
+func IsWrapperExists(projectDir string, projectType project.ProjectType) (bool, error) {
+	wrapper, ok := wrappers[projectType]
+	if !ok {
+		return false, nil
+	}
+	script := wrapper.unixScript
+	if coreutils.IsWindows() {
+		script = wrapper.windowsScript
+	}
+	return fileutils.IsFileExists(filepath.Join(projectDir, script), false)
+}
+
+// ShouldUseWrapper determines whether a Maven or Gradle build should run with the project's wrapper.
+// An explicit choice of the user is respected. Otherwise, the wrapper is used if enabled in the project's configuration,
+// or if the project contains a wrapper, so that the build behaves like the developers' local invocations.
+func ShouldUseWrapper(useWrapper *bool, configuredUseWrapper bool, projectDir string, projectType project.ProjectType) (bool, error) {
+	if useWrapper != nil {
+		return *useWrapper, nil
+	}
+	if configuredUseWrapper {
+		return true, nil
+	}
+	exists, err := IsWrapperExists(projectDir, projectType)
+	if err != nil || !exists {
+		return false, err
+	}
+	log.Info("Found the " + projectType.String() + " wrapper in the project, and using it to run the build.")
+	return true, nil
+}
+
+// GetWrapperVersion returns the version of Maven or Gradle declared by the distribution URL of the project's wrapper.
+// Returns an empty string if the version is unknown.
+func GetWrapperVersion(projectDir string, projectType project.ProjectType) (string, error) {
+	wrapper, ok := wrappers[projectType]
+	if !ok {
+		return "", nil
+	}
+	file, err := os.Open(filepath.Join(projectDir, wrapper.propertiesPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if !found || strings.TrimSpace(key) != "distributionUrl" {
+			continue
+		}
+		// Colons are escaped in properties files.
+		distributionUrl := strings.ReplaceAll(strings.TrimSpace(value), `\:`, ":")
+		if match := wrapper.versionPattern.FindStringSubmatch(distributionUrl); match != nil {
+			return match[1], nil
+		}
+	}
+	return "", errorutils.CheckError(scanner.Err())
+}
+
+// SaveWrapperVersion records the tool version declared by the project's wrapper in the build-info, if it's collected.
+func SaveWrapperVersion(buildConfiguration *BuildConfiguration, projectDir string, projectType project.ProjectType) error {
+	toCollect, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !toCollect {
+		return err
+	}
+	version, err := GetWrapperVersion(projectDir, projectType)
+	if err != nil || version == "" {
+		return err
+	}
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	return SavePartialBuildInfo(buildName, buildNumber, buildConfiguration.GetProject(), func(partial *buildInfo.Partial) {
+		partial.Env = buildInfo.Env{wrappers[projectType].versionProp: version}
+	})
+}
@@ -0,0 +1,69 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createWrapperProject(t *testing.T, script, propertiesPath, properties string) string {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, script), []byte("#!/bin/sh"), 0700))
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, filepath.Dir(propertiesPath)), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, propertiesPath), []byte(properties), 0600))
+	return projectDir
+}
+
+func TestShouldUseWrapper(t *testing.T) {
+	script := "mvnw"
+	if coreutils.IsWindows() {
+		script = "mvnw.cmd"
+	}
+	mavenProject := createWrapperProject(t, script, filepath.Join(".mvn", "wrapper", "maven-wrapper.properties"), "")
+	emptyProject := t.TempDir()
+	useWrapper, doNotUseWrapper := true, false
+
+	testCases := []struct {
+		name       string
+		useWrapper *bool
+		configured bool
+		projectDir string
+		expected   bool
+	}{
+		{"detected", nil, false, mavenProject, true},
+		{"not detected", nil, false, emptyProject, false},
+		{"configured", nil, true, emptyProject, true},
+		{"disabled explicitly", &doNotUseWrapper, true, mavenProject, false},
+		{"enabled explicitly", &useWrapper, false, emptyProject, true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := ShouldUseWrapper(testCase.useWrapper, testCase.configured, testCase.projectDir, project.Maven)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestGetWrapperVersion(t *testing.T) {
+	mavenProject := createWrapperProject(t, "mvnw", filepath.Join(".mvn", "wrapper", "maven-wrapper.properties"),
+		"wrapperVersion=3.3.2\ndistributionUrl=https://repo.maven.apache.org/maven2/org/apache/maven/apache-maven/3.9.6/apache-maven-3.9.6-bin.zip\n")
+	version, err := GetWrapperVersion(mavenProject, project.Maven)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.9.6", version)
+
+	gradleProject := createWrapperProject(t, "gradlew", filepath.Join("gradle", "wrapper", "gradle-wrapper.properties"),
+		"distributionBase=GRADLE_USER_HOME\ndistributionUrl=https\\://services.gradle.org/distributions/gradle-8.5-all.zip\n")
+	version, err = GetWrapperVersion(gradleProject, project.Gradle)
+	assert.NoError(t, err)
+	assert.Equal(t, "8.5", version)
+
+	version, err = GetWrapperVersion(t.TempDir(), project.Gradle)
+	assert.NoError(t, err)
+	assert.Empty(t, version)
+}
@@ -1,9 +1,13 @@
 package commands
 
 import (
+	"context"
+
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	corelog "github.com/jfrog/jfrog-cli-core/v2/utils/log"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/metrics"
 	usageReporter "github.com/jfrog/jfrog-cli-core/v2/utils/usage"
 	"github.com/jfrog/jfrog-client-go/artifactory/usage"
 	"github.com/jfrog/jfrog-client-go/utils/log"
@@ -18,14 +22,42 @@ type Command interface {
 	CommandName() string
 }
 
+// Exec runs the command. If a timeout is set by the JFROG_CLI_COMMAND_TIMEOUT environment variable, the command is cancelled when it expires.
+// The command is also cancelled when the process is interrupted, unless it handles the interrupt signals by itself.
 func Exec(command Command) error {
+	timeout, err := GetCommandTimeout()
+	if err != nil {
+		return err
+	}
+	ctx, stop := withInterruptCancel(context.Background(), command)
+	defer stop()
+	return execWithTimeout(ctx, command, timeout)
+}
+
+// ExecWithContext runs the command, and cancels it when the context is done.
+func ExecWithContext(ctx context.Context, command Command) error {
+	// Adds the command name to the structured log lines.
+	corelog.SetCommandContext(command.CommandName())
+	if err := applyGlobalDryRun(command); err != nil {
+		return err
+	}
+	if err := checkApiKeyCredentials(command); err != nil {
+		return err
+	}
 	channel := make(chan bool)
 	// Triggers the report usage.
 	go reportUsage(command, channel)
+	// Records the local metrics of the command, if enabled.
+	recorder := metrics.Start(command.CommandName())
 	// Invoke the command interface
-	err := command.Run()
+	err := runWithContext(ctx, command)
 	// Waits for the signal from the report usage to be done.
 	<-channel
+	if recorder != nil {
+		if metricsErr := recorder.Finish(err); metricsErr != nil {
+			log.Debug("Failed recording the command's metrics:", metricsErr.Error())
+		}
+	}
 	return err
 }
 
@@ -55,6 +55,8 @@ type ConfigCommand struct {
 	useWebLogin bool
 	// Forcibly make the configured server default.
 	makeDefault bool
+	// The default project key of the server, used by commands which aren't provided with a project key.
+	project string
 	// For unit tests
 	disablePrompts bool
 	cmdType        ConfigAction
@@ -89,6 +91,11 @@ func (cc *ConfigCommand) SetMakeDefault(makeDefault bool) *ConfigCommand {
 	return cc
 }
 
+func (cc *ConfigCommand) SetProject(project string) *ConfigCommand {
+	cc.project = project
+	return cc
+}
+
 func (cc *ConfigCommand) SetInteractive(interactive bool) *ConfigCommand {
 	cc.interactive = interactive
 	return cc
@@ -159,6 +166,9 @@ func (cc *ConfigCommand) config() error {
 	if err != nil {
 		return err
 	}
+	if cc.project != "" {
+		cc.details.Project = cc.project
+	}
 	cc.addTrailingSlashes()
 	cc.lowerUsername()
 	cc.setDefaultIfNeeded(configurations)
@@ -348,6 +358,9 @@ func (cc *ConfigCommand) getConfigurationFromUser() (err error) {
 	if !clientCertChecked {
 		cc.checkClientCertForReverseProxy()
 	}
+	if cc.project == "" && cc.details.Project == "" {
+		ioutils.ScanFromConsole("Default project key (optional)", &cc.details.Project, cc.defaultDetails.Project)
+	}
 	return
 }
 
@@ -638,6 +651,7 @@ func printConfigs(configuration []*config.ServerDetails) {
 		logIfNotEmpty(details.SshPassphrase, "SSH passphrase:\t\t\t", true, isDefault)
 		logIfNotEmpty(details.ClientCertPath, "Client certificate file path:\t", false, isDefault)
 		logIfNotEmpty(details.ClientCertKeyPath, "Client certificate key path:\t", false, isDefault)
+		logIfNotEmpty(details.Project, "Default project:\t\t", false, isDefault)
 		logIfNotEmpty(strconv.FormatBool(details.IsDefault), "Default:\t\t\t", false, isDefault)
 		log.Output()
 	}
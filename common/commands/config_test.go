@@ -59,6 +59,19 @@ func TestDefaultServerId(t *testing.T) {
 	assert.NoError(t, NewConfigCommand(Delete, config.DefaultServerId).Run())
 }
 
+func TestDefaultProject(t *testing.T) {
+	inputDetails := tests.CreateTestServerDetails()
+	inputDetails.User = "admin"
+	inputDetails.Password = "password"
+
+	configCmd := NewConfigCommand(AddOrEdit, testServerId).SetDetails(inputDetails).SetProject("proj1").SetInteractive(false)
+	assert.NoError(t, configCmd.Run())
+	defer deleteServer(t, testServerId)
+	outputConfig, err := GetConfig(testServerId, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "proj1", outputConfig.Project)
+}
+
 func TestArtifactorySshKey(t *testing.T) {
 	inputDetails := tests.CreateTestServerDetails()
 	inputDetails.SshKeyPath = "/tmp/sshKey"
@@ -45,6 +45,7 @@ const (
 	deployIvyDesc       = "deploy-ivy-desc"
 	ivyDescPattern      = "ivy-desc-pattern"
 	ivyArtifactsPattern = "ivy-artifacts-pattern"
+	deploymentMirrors   = "repo-deploy-mirrors"
 
 	// Nuget flags
 	nugetV2 = "nuget-v2"
@@ -59,6 +60,7 @@ const (
 	setServerIdError           = "server ID must be set. Use the --server-id-resolve/deploy flag or configure a default server using 'jfrog c add' and 'jfrog c use' commands. "
 	setRepositoryError         = "repository/ies must be set. "
 	setSnapshotAndReleaseError = "snapshot and release repositories must be set. "
+	setMirrorsRepositoryError  = "a deployment repository must be set to mirror the deployed artifacts. "
 )
 
 type ConfigFile struct {
@@ -295,6 +297,22 @@ func WithDeployerExcludePatterns(excludePatterns string) ConfigOption {
 	}
 }
 
+// WithDeployerProfilesExcludePatterns maps Maven profile IDs to the patterns of the artifacts to exclude from the deployment when the profile is active.
+func WithDeployerProfilesExcludePatterns(profilesExcludePatterns map[string]string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.ProfilesExcludePatterns = profilesExcludePatterns
+		c.Interactive = false
+	}
+}
+
+// WithDeployerDefaultProfilesExcludePatterns applies the exclude patterns of common test and coverage profiles to the active profiles which aren't mapped.
+func WithDeployerDefaultProfilesExcludePatterns(defaultProfilesExcludePatterns bool) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.DefaultProfilesExcludePatterns = defaultProfilesExcludePatterns
+		c.Interactive = false
+	}
+}
+
 func UseWrapper(useWrapper bool) ConfigOption {
 	return func(c *ConfigFile) {
 		c.UseWrapper = useWrapper
@@ -307,9 +325,23 @@ func (configFile *ConfigFile) populateGradleConfigFromFlags(c *cli.Context) {
 	configFile.Deployer.DeployIvyDesc = c.BoolT(deployIvyDesc)
 	configFile.Deployer.IvyPattern = defaultIfNotSet(c, ivyDescPattern, defaultIvyDescPattern)
 	configFile.Deployer.ArtifactsPattern = defaultIfNotSet(c, ivyArtifactsPattern, defaultIvyArtifactsPattern)
+	configFile.Deployer.SnapshotRepo = c.String(deploymentSnapshotsRepo)
+	configFile.Deployer.ReleaseRepo = c.String(deploymentReleasesRepo)
+	if mirrors := c.String(deploymentMirrors); mirrors != "" {
+		configFile.Deployer.MirrorRepos = strings.Split(mirrors, ",")
+	}
 	configFile.UsePlugin = c.Bool(usesPlugin)
 	configFile.UseWrapper = c.Bool(useWrapper)
-	configFile.Interactive = configFile.Interactive && !isAnyFlagSet(c, deployMavenDesc, deployIvyDesc, ivyDescPattern, ivyArtifactsPattern, usesPlugin, useWrapper)
+	configFile.Interactive = configFile.Interactive && !isAnyFlagSet(c, deployMavenDesc, deployIvyDesc, ivyDescPattern, ivyArtifactsPattern, usesPlugin, useWrapper,
+		deploymentSnapshotsRepo, deploymentReleasesRepo, deploymentMirrors)
+}
+
+// WithDeployerMirrorRepos sets repositories to which the deployed artifacts are copied after the deployment.
+func WithDeployerMirrorRepos(repoIds ...string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.MirrorRepos = repoIds
+		c.Interactive = false
+	}
 }
 
 func WithMavenDescDeployment(mavenDesc bool) ConfigOption {
@@ -591,6 +623,9 @@ func validateRepositoryConfig(repository *project.Repository, errorPrefix string
 	if (releaseRepo == "" && snapshotRepo != "") || (releaseRepo != "" && snapshotRepo == "") {
 		return errorutils.CheckErrorf(errorPrefix + setSnapshotAndReleaseError)
 	}
+	if len(repository.MirrorRepos) > 0 && repository.Repo == "" && releaseRepo == "" {
+		return errorutils.CheckErrorf(errorPrefix + setMirrorsRepositoryError)
+	}
 	return nil
 }
 
@@ -337,6 +337,24 @@ func TestGradleConfigFileDefaultPatterns(t *testing.T) {
 	assert.Equal(t, true, config.GetBool("useWrapper"))
 }
 
+func TestGradleConfigFileWithMirrors(t *testing.T) {
+	// Set JFROG_CLI_HOME_DIR environment variable
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	// Create build config
+	context := createContext(t, resolutionServerId+"=relServer", resolutionRepo+"=repo", deploymentServerId+"=depServer",
+		deploymentReleasesRepo+"=release-local", deploymentSnapshotsRepo+"=snapshot-local", deploymentMirrors+"=mirror-a,mirror-b")
+	err := CreateBuildConfig(context, project.Gradle)
+	assert.NoError(t, err)
+
+	// Check configuration
+	config := checkCommonAndGetConfiguration(t, project.Gradle.String(), tempDirPath)
+	assert.Equal(t, "release-local", config.GetString("deployer.releaseRepo"))
+	assert.Equal(t, "snapshot-local", config.GetString("deployer.snapshotRepo"))
+	assert.Equal(t, []string{"mirror-a", "mirror-b"}, config.GetStringSlice("deployer.mirrorRepos"))
+}
+
 func TestValidateConfigResolver(t *testing.T) {
 	// Create and check empty config
 	tempDirPath := createTempEnv(t)
@@ -417,6 +435,14 @@ func TestValidateConfigDeployer(t *testing.T) {
 	configFile.Deployer.ServerId = ""
 	err = configFile.validateConfig()
 	assert.EqualError(t, err, deploymentErrorPrefix+setServerIdError)
+
+	// Check scenarios of mirror repositories
+	configFile.Deployer = project.Repository{MirrorRepos: []string{"mirrorRepo"}}
+	err = configFile.validateConfig()
+	assert.EqualError(t, err, deploymentErrorPrefix+setMirrorsRepositoryError)
+	configFile.Deployer = project.Repository{ServerId: "serverId", Repo: "repo", MirrorRepos: []string{"mirrorRepo"}}
+	err = configFile.validateConfig()
+	assert.NoError(t, err)
 }
 
 // Set JFROG_CLI_HOME_DIR environment variable to be a new temp directory
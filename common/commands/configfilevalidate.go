@@ -0,0 +1,258 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// The SHA-1 of the deployment probe. Deploying by a checksum which doesn't exist in Artifactory fails without creating an artifact,
+// but only after the deploy permission is checked.
+const deployProbeSha1 = "0000000000000000000000000000000000000000"
+
+// The package types of the repositories which can be used by each technology. Technologies which aren't listed can use any repository.
+var projectPackageTypes = map[project.ProjectType][]string{
+	project.Go:        {"go"},
+	project.Pip:       {"pypi"},
+	project.Pipenv:    {"pypi"},
+	project.Poetry:    {"pypi"},
+	project.Npm:       {"npm"},
+	project.Pnpm:      {"npm"},
+	project.Yarn:      {"npm"},
+	project.Nuget:     {"nuget"},
+	project.Dotnet:    {"nuget"},
+	project.Maven:     {"maven"},
+	project.Gradle:    {"gradle", "maven", "ivy"},
+	project.Terraform: {"terraform"},
+}
+
+// ConfigIssue is a problem found in the configuration of a technology, which would fail the build.
+type ConfigIssue struct {
+	Section string `json:"section"`
+	Repo    string `json:"repo,omitempty"`
+	Message string `json:"message"`
+}
+
+func (issue ConfigIssue) String() string {
+	if issue.Repo == "" {
+		return fmt.Sprintf("[%s]: %s", issue.Section, issue.Message)
+	}
+	return fmt.Sprintf("[%s] %s: %s", issue.Section, issue.Repo, issue.Message)
+}
+
+type repositoryConfigDetails struct {
+	Key                   string `json:"key"`
+	Rclass                string `json:"rclass"`
+	PackageType           string `json:"packageType"`
+	DefaultDeploymentRepo string `json:"defaultDeploymentRepo"`
+}
+
+// ConfigFileValidateCommand validates the stored configuration of a technology, as created by 'jf <tech>-config', against Artifactory.
+// It verifies that the resolver and deployer servers are configured, that the repositories exist and match the technology,
+// and that the user has permissions to resolve from and deploy to them.
+type ConfigFileValidateCommand struct {
+	projectType    project.ProjectType
+	configFilePath string
+	issues         []ConfigIssue
+}
+
+func NewConfigFileValidateCommand(projectType project.ProjectType) *ConfigFileValidateCommand {
+	return &ConfigFileValidateCommand{projectType: projectType}
+}
+
+// SetConfigFilePath sets the path of the configuration file to validate.
+// If not set, the configuration file of the project, or the global configuration file, is validated.
+func (cvc *ConfigFileValidateCommand) SetConfigFilePath(configFilePath string) *ConfigFileValidateCommand {
+	cvc.configFilePath = configFilePath
+	return cvc
+}
+
+// Issues returns the issues found in the configuration.
+func (cvc *ConfigFileValidateCommand) Issues() []ConfigIssue {
+	return cvc.issues
+}
+
+func (cvc *ConfigFileValidateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (cvc *ConfigFileValidateCommand) CommandName() string {
+	return "config_validate"
+}
+
+// IsReadOnly implements ReadOnlyCommand.
+func (cvc *ConfigFileValidateCommand) IsReadOnly() bool {
+	return true
+}
+
+func (cvc *ConfigFileValidateCommand) Run() error {
+	configFilePath := cvc.configFilePath
+	if configFilePath == "" {
+		var exists bool
+		var err error
+		if configFilePath, exists, err = project.GetProjectConfFilePath(cvc.projectType); err != nil {
+			return err
+		}
+		if !exists {
+			return errorutils.CheckErrorf("the %s configuration doesn't exist. Run 'jf %s-config' to create it", cvc.projectType, cvc.projectType)
+		}
+	}
+	content, err := os.ReadFile(configFilePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	configFile := &ConfigFile{}
+	if err = yaml.Unmarshal(content, configFile); err != nil {
+		return errorutils.CheckErrorf("failed parsing %s: %s", configFilePath, err.Error())
+	}
+	log.Info(fmt.Sprintf("Validating the %s configuration in %s...", cvc.projectType, configFilePath))
+	cvc.issues = nil
+	cvc.validateRepository(project.ProjectConfigResolverPrefix, &configFile.Resolver, false)
+	cvc.validateRepository(project.ProjectConfigDeployerPrefix, &configFile.Deployer, true)
+	if len(cvc.issues) == 0 {
+		log.Info("The configuration is valid.")
+		return nil
+	}
+	for _, issue := range cvc.issues {
+		log.Error(issue.String())
+	}
+	return errorutils.CheckErrorf("found %d issues in the %s configuration (%s)", len(cvc.issues), cvc.projectType, configFilePath)
+}
+
+func (cvc *ConfigFileValidateCommand) addIssue(section, repo, format string, args ...any) {
+	cvc.issues = append(cvc.issues, ConfigIssue{Section: section, Repo: repo, Message: fmt.Sprintf(format, args...)})
+}
+
+func (cvc *ConfigFileValidateCommand) validateRepository(section string, repository *project.Repository, deploy bool) {
+	var repos []string
+	for _, repo := range append([]string{repository.Repo, repository.ReleaseRepo, repository.SnapshotRepo}, repository.MirrorRepos...) {
+		if repo != "" && !slices.Contains(repos, repo) {
+			repos = append(repos, repo)
+		}
+	}
+	if repository.ServerId == "" {
+		if len(repos) > 0 {
+			cvc.addIssue(section, "", "the server ID is missing. Run 'jf %s-config' and set the %s server", cvc.projectType, section)
+		}
+		return
+	}
+	if len(repos) == 0 {
+		cvc.addIssue(section, "", "no repository is configured for the '%s' server. Run 'jf %s-config' and set the %s repository", repository.ServerId, cvc.projectType, section)
+		return
+	}
+	serverDetails, err := config.GetSpecificConfig(repository.ServerId, false, true)
+	if err != nil {
+		cvc.addIssue(section, "", "the server ID '%s' isn't configured. Run 'jf c add %s' or change the %s server: %s", repository.ServerId, repository.ServerId, section, err.Error())
+		return
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		cvc.addIssue(section, "", "failed connecting to the '%s' server: %s", repository.ServerId, err.Error())
+		return
+	}
+	for _, repo := range repos {
+		cvc.validateRepo(servicesManager, section, repo, deploy)
+	}
+}
+
+func (cvc *ConfigFileValidateCommand) validateRepo(servicesManager artifactory.ArtifactoryServicesManager, section, repo string, deploy bool) {
+	details, err := getRepositoryConfigDetails(servicesManager, repo)
+	if err != nil {
+		cvc.addIssue(section, repo, "failed getting the repository details: %s", err.Error())
+		return
+	}
+	if details == nil {
+		cvc.addIssue(section, repo, "the repository doesn't exist, or the user has no permissions to read it")
+		return
+	}
+	if packageTypes, exists := projectPackageTypes[cvc.projectType]; exists && !slices.Contains(packageTypes, strings.ToLower(details.PackageType)) {
+		cvc.addIssue(section, repo, "the repository is a %s repository, but %s requires a repository of type %s", details.PackageType, cvc.projectType, strings.Join(packageTypes, "/"))
+	}
+	if !deploy {
+		if readable, err := isRepoReadable(servicesManager, repo); err != nil {
+			cvc.addIssue(section, repo, "failed checking the read permission: %s", err.Error())
+		} else if !readable {
+			cvc.addIssue(section, repo, "the user has no permission to resolve from the repository")
+		}
+		return
+	}
+	switch strings.ToLower(details.Rclass) {
+	case "remote":
+		cvc.addIssue(section, repo, "artifacts can't be deployed to a remote repository. Use a local repository, or a virtual repository with a default deployment repository")
+		return
+	case "virtual":
+		if details.DefaultDeploymentRepo == "" {
+			cvc.addIssue(section, repo, "the virtual repository has no default deployment repository, so artifacts can't be deployed to it")
+			return
+		}
+	}
+	if deployable, err := isRepoDeployable(servicesManager, repo); err != nil {
+		cvc.addIssue(section, repo, "failed checking the deploy permission: %s", err.Error())
+	} else if !deployable {
+		cvc.addIssue(section, repo, "the user has no permission to deploy to the repository")
+	}
+}
+
+// Returns the configuration of the repository, or nil if it doesn't exist.
+func getRepositoryConfigDetails(servicesManager artifactory.ArtifactoryServicesManager, repo string) (*repositoryConfigDetails, error) {
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(servicesManager.GetConfig().GetServiceDetails().GetUrl()+"api/repositories/"+repo, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	details := &repositoryConfigDetails{}
+	return details, errorutils.CheckError(json.Unmarshal(body, details))
+}
+
+// Artifactory hides the repositories the user can't read, so the storage info of a repository is returned only if it can be read.
+func isRepoReadable(servicesManager artifactory.ArtifactoryServicesManager, repo string) (bool, error) {
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(servicesManager.GetConfig().GetServiceDetails().GetUrl()+"api/storage/"+repo, true, &httpDetails)
+	if err != nil {
+		return false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return false, nil
+	}
+	return false, errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
+
+// Checks the deploy permission by deploying a probe by a checksum which doesn't exist, so that nothing is deployed.
+// Artifactory rejects the deployment with 401 or 403 if the permission is missing, and with 404 after the permission is checked.
+func isRepoDeployable(servicesManager artifactory.ArtifactoryServicesManager, repo string) (bool, error) {
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpDetails.Headers["X-Checksum-Deploy"] = "true"
+	httpDetails.Headers["X-Checksum-Sha1"] = deployProbeSha1
+	probeUrl := servicesManager.GetConfig().GetServiceDetails().GetUrl() + repo + "/.jfrog/config-validation/probe"
+	resp, body, err := servicesManager.Client().SendPut(probeUrl, nil, &httpDetails)
+	if err != nil {
+		return false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false, nil
+	case http.StatusNotFound, http.StatusCreated, http.StatusOK:
+		return true, nil
+	}
+	return false, errorutils.CheckResponseStatusWithBody(resp, body, http.StatusNotFound)
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFileValidate(t *testing.T) {
+	server := tests.CreateRestsMockServer(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch {
+		case r.URL.Path == "/api/repositories/npm-remote":
+			_, err = w.Write([]byte(`{"key":"npm-remote","rclass":"remote","packageType":"npm"}`))
+		case r.URL.Path == "/api/repositories/npm-virtual":
+			_, err = w.Write([]byte(`{"key":"npm-virtual","rclass":"virtual","packageType":"npm","defaultDeploymentRepo":"npm-local"}`))
+		case r.URL.Path == "/api/repositories/pypi-local":
+			_, err = w.Write([]byte(`{"key":"pypi-local","rclass":"local","packageType":"pypi"}`))
+		case r.URL.Path == "/api/storage/npm-remote":
+			_, err = w.Write([]byte(`{"repo":"npm-remote","path":"/"}`))
+		case r.URL.Path == "/api/storage/pypi-local":
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == http.MethodPut && r.URL.Path == "/npm-virtual/.jfrog/config-validation/probe":
+			assert.Equal(t, "true", r.Header.Get("X-Checksum-Deploy"))
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		assert.NoError(t, err)
+	})
+	defer server.Close()
+	createTempEnv(t)
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{{ServerId: "test", Url: server.URL + "/", ArtifactoryUrl: server.URL + "/", IsDefault: true}}))
+
+	configFilePath := filepath.Join(t.TempDir(), "npm.yaml")
+	writeConfig := func(content string) {
+		require.NoError(t, os.WriteFile(configFilePath, []byte(content), 0644))
+	}
+	validateCmd := NewConfigFileValidateCommand(project.Npm).SetConfigFilePath(configFilePath)
+
+	writeConfig("version: 1\ntype: npm\nresolver:\n  repo: npm-remote\n  serverId: test\n")
+	assert.NoError(t, validateCmd.Run())
+	assert.Empty(t, validateCmd.Issues())
+
+	writeConfig("version: 1\ntype: npm\nresolver:\n  repo: pypi-local\n  serverId: test\ndeployer:\n  repo: npm-virtual\n  serverId: test\n")
+	assert.EqualError(t, validateCmd.Run(), "found 3 issues in the npm configuration ("+configFilePath+")")
+	assert.Equal(t, []ConfigIssue{
+		{Section: "resolver", Repo: "pypi-local", Message: "the repository is a pypi repository, but npm requires a repository of type npm"},
+		{Section: "resolver", Repo: "pypi-local", Message: "the user has no permission to resolve from the repository"},
+		{Section: "deployer", Repo: "npm-virtual", Message: "the user has no permission to deploy to the repository"},
+	}, validateCmd.Issues())
+
+	writeConfig("version: 1\ntype: npm\nresolver:\n  repo: missing\n  serverId: test\ndeployer:\n  repo: npm-remote\n  serverId: other\n")
+	assert.Error(t, validateCmd.Run())
+	issues := validateCmd.Issues()
+	require.Len(t, issues, 2)
+	assert.Equal(t, ConfigIssue{Section: "resolver", Repo: "missing", Message: "the repository doesn't exist, or the user has no permissions to read it"}, issues[0])
+	assert.Contains(t, issues[1].Message, "the server ID 'other' isn't configured")
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"io"
@@ -18,6 +19,8 @@ type CurlCommand struct {
 	executablePath string
 	serverDetails  *config.ServerDetails
 	url            string
+	paginate       bool
+	query          string
 }
 
 func NewCurlCommand() *CurlCommand {
@@ -44,6 +47,18 @@ func (curlCmd *CurlCommand) SetUrl(url string) *CurlCommand {
 	return curlCmd
 }
 
+// SetPaginate sets whether to fetch all the pages of a list API, and output the merged response.
+func (curlCmd *CurlCommand) SetPaginate(paginate bool) *CurlCommand {
+	curlCmd.paginate = paginate
+	return curlCmd
+}
+
+// SetQuery sets a jq-like path, selecting the values to output from the JSON response. For example: '.results[].name'.
+func (curlCmd *CurlCommand) SetQuery(query string) *CurlCommand {
+	curlCmd.query = query
+	return curlCmd
+}
+
 func (curlCmd *CurlCommand) Run() error {
 	// Get curl execution path.
 	execPath, err := exec.LookPath("curl")
@@ -77,7 +92,62 @@ func (curlCmd *CurlCommand) Run() error {
 
 	// Run curl.
 	log.Debug(fmt.Sprintf("Executing curl command: '%s %s'", cmdWithoutCreds, credentialsMessage))
-	return gofrogcmd.RunCmd(curlCmd)
+	if !curlCmd.paginate && curlCmd.query == "" {
+		return gofrogcmd.RunCmd(curlCmd)
+	}
+	return curlCmd.runWithJsonOutput(uriIndex)
+}
+
+// Runs curl for each page of the response if pagination is enabled, and prints the JSON response or the values selected by the query.
+func (curlCmd *CurlCommand) runWithJsonOutput(uriIndex int) error {
+	// The response is parsed, so the progress meter is hidden.
+	curlCmd.arguments = append(curlCmd.arguments, "-sS")
+	fetchPage := func(pageUrl string) ([]byte, error) {
+		curlCmd.arguments[uriIndex] = pageUrl
+		log.Debug("Fetching " + pageUrl)
+		output, err := gofrogcmd.RunCmdOutput(curlCmd)
+		return []byte(output), errorutils.CheckError(err)
+	}
+	var response any
+	var err error
+	if curlCmd.paginate {
+		response, err = paginate(curlCmd.arguments[uriIndex], fetchPage)
+	} else {
+		response, err = parseJsonResponse(curlCmd.arguments[uriIndex], fetchPage)
+	}
+	if err != nil {
+		return err
+	}
+	return printJsonResponse(response, curlCmd.query)
+}
+
+func parseJsonResponse(requestUrl string, fetch func(string) ([]byte, error)) (response any, err error) {
+	body, err := fetch(requestUrl)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(body, &response); err != nil {
+		err = errorutils.CheckErrorf("the response of %s is not a valid JSON: %s", requestUrl, err.Error())
+	}
+	return
+}
+
+func printJsonResponse(response any, query string) error {
+	values := []any{response}
+	if query != "" {
+		var err error
+		if values, err = applyQuery(query, response); err != nil {
+			return err
+		}
+	}
+	for _, value := range values {
+		output, err := formatQueryResult(value)
+		if err != nil {
+			return err
+		}
+		log.Output(output)
+	}
+	return nil
 }
 
 func (curlCmd *CurlCommand) addCommandCredentials() string {
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The pagination styles of the JFrog list APIs, detected by the query params of the requested URL or by the response:
+// - Offset: the URL includes a 'limit' param, and the 'offset' param is increased by the limit on each page.
+// - Page number: the URL includes a 'num_of_rows' param (Xray), and the 'page_num' param is increased on each page.
+// - Cursor: the response includes a 'cursor' field, which is sent as the 'cursor' param of the next page.
+// Paging stops when a page returns fewer items than the limit, or no cursor.
+const (
+	limitParam     = "limit"
+	offsetParam    = "offset"
+	numOfRowsParam = "num_of_rows"
+	pageNumParam   = "page_num"
+	cursorParam    = "cursor"
+
+	// Protects against APIs which ignore the pagination params, and keep returning the same page.
+	maxPages = 10000
+)
+
+// Fetches all the pages of a list API, and returns the merged response.
+// If the response is an array, the items of all the pages are returned.
+// If the response is an object, the first page is returned, with its items array holding the items of all the pages.
+func paginate(pageUrl string, fetchPage func(pageUrl string) ([]byte, error)) (any, error) {
+	parsedUrl, err := url.Parse(pageUrl)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	query := parsedUrl.Query()
+	sizeParam, positionParam, position := "", "", 0
+	switch {
+	case query.Has(limitParam):
+		sizeParam, positionParam = limitParam, offsetParam
+		position, err = getIntParam(query, offsetParam, 0)
+	case query.Has(numOfRowsParam):
+		sizeParam, positionParam = numOfRowsParam, pageNumParam
+		position, err = getIntParam(query, pageNumParam, 1)
+	}
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := getIntParam(query, sizeParam, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstPage any
+	var itemsField string
+	var allItems []any
+	for i := 0; i < maxPages; i++ {
+		body, err := fetchPage(parsedUrl.String())
+		if err != nil {
+			return nil, err
+		}
+		var page any
+		if err = json.Unmarshal(body, &page); err != nil {
+			return nil, errorutils.CheckErrorf("the response of %s is not a valid JSON: %s", parsedUrl.String(), err.Error())
+		}
+		items, field := getPageItems(page)
+		if firstPage == nil {
+			firstPage, itemsField = page, field
+		}
+		allItems = append(allItems, items...)
+
+		cursor, hasCursor := getCursor(page)
+		switch {
+		case positionParam == offsetParam && pageSize > 0 && len(items) == pageSize:
+			position += pageSize
+		case positionParam == pageNumParam && pageSize > 0 && len(items) == pageSize:
+			position++
+		case positionParam == "" && hasCursor && len(items) > 0:
+			query.Set(cursorParam, cursor)
+			parsedUrl.RawQuery = query.Encode()
+			continue
+		default:
+			return mergePages(firstPage, itemsField, allItems), nil
+		}
+		query.Set(positionParam, strconv.Itoa(position))
+		parsedUrl.RawQuery = query.Encode()
+	}
+	return nil, errorutils.CheckErrorf("the number of pages exceeded the limit of %d", maxPages)
+}
+
+func getIntParam(query url.Values, param string, defaultValue int) (int, error) {
+	value := query.Get(param)
+	if value == "" {
+		return defaultValue, nil
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errorutils.CheckErrorf("the '%s' param must be a number, but got '%s'", param, value)
+	}
+	return intValue, nil
+}
+
+// Returns the items of the page, and the name of the object field holding them.
+// If the page is an object, its items are the first array field in alphabetical order.
+func getPageItems(page any) (items []any, field string) {
+	switch typedPage := page.(type) {
+	case []any:
+		return typedPage, ""
+	case map[string]any:
+		for _, key := range getSortedKeys(typedPage) {
+			if array, ok := typedPage[key].([]any); ok {
+				return array, key
+			}
+		}
+	}
+	return nil, ""
+}
+
+func getCursor(page any) (string, bool) {
+	object, ok := page.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	cursor, ok := object[cursorParam].(string)
+	return cursor, ok && cursor != ""
+}
+
+func mergePages(firstPage any, itemsField string, allItems []any) any {
+	object, ok := firstPage.(map[string]any)
+	if !ok {
+		if allItems == nil {
+			return firstPage
+		}
+		return allItems
+	}
+	if itemsField != "" {
+		object[itemsField] = allItems
+	}
+	delete(object, cursorParam)
+	return object
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns a fetch function serving the items in pages, and records the requested URLs.
+func createPagesFetcher(t *testing.T, items []any, createPage func(query url.Values, items []any) any, requestedUrls *[]string) func(string) ([]byte, error) {
+	return func(pageUrl string) ([]byte, error) {
+		*requestedUrls = append(*requestedUrls, pageUrl)
+		parsedUrl, err := url.Parse(pageUrl)
+		require.NoError(t, err)
+		content, err := json.Marshal(createPage(parsedUrl.Query(), items))
+		require.NoError(t, err)
+		return content, nil
+	}
+}
+
+func getPage(items []any, start, size int) []any {
+	if start >= len(items) {
+		return []any{}
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+func TestPaginateOffset(t *testing.T) {
+	items := []any{"a", "b", "c", "d", "e"}
+	var requestedUrls []string
+	fetch := createPagesFetcher(t, items, func(query url.Values, items []any) any {
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		return map[string]any{"items": getPage(items, offset, limit), "total": len(items)}
+	}, &requestedUrls)
+
+	response, err := paginate("https://acme.jfrog.io/access/api/v1/tokens?limit=2", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"items": items, "total": float64(5)}, response)
+	assert.Equal(t, []string{
+		"https://acme.jfrog.io/access/api/v1/tokens?limit=2",
+		"https://acme.jfrog.io/access/api/v1/tokens?limit=2&offset=2",
+		"https://acme.jfrog.io/access/api/v1/tokens?limit=2&offset=4",
+	}, requestedUrls)
+}
+
+func TestPaginatePageNumber(t *testing.T) {
+	items := []any{float64(1), float64(2), float64(3), float64(4)}
+	var requestedUrls []string
+	fetch := createPagesFetcher(t, items, func(query url.Values, items []any) any {
+		pageNum, _ := strconv.Atoi(query.Get("page_num"))
+		numOfRows, _ := strconv.Atoi(query.Get("num_of_rows"))
+		return getPage(items, (pageNum-1)*numOfRows, numOfRows)
+	}, &requestedUrls)
+
+	response, err := paginate("https://acme.jfrog.io/xray/api/v1/watches?num_of_rows=2&page_num=1", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, items, response)
+	// The last page is full, so an empty page is requested to find the end.
+	assert.Len(t, requestedUrls, 3)
+}
+
+func TestPaginateCursor(t *testing.T) {
+	items := []any{"user1", "user2", "user3"}
+	var requestedUrls []string
+	fetch := createPagesFetcher(t, items, func(query url.Values, items []any) any {
+		index, _ := strconv.Atoi(query.Get("cursor"))
+		page := map[string]any{"users": items[index : index+1]}
+		if index+1 < len(items) {
+			page["cursor"] = fmt.Sprint(index + 1)
+		}
+		return page
+	}, &requestedUrls)
+
+	response, err := paginate("https://acme.jfrog.io/access/api/v2/users", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"users": items}, response)
+	assert.Len(t, requestedUrls, 3)
+}
+
+func TestPaginateNotPaginated(t *testing.T) {
+	var requestedUrls []string
+	fetch := createPagesFetcher(t, nil, func(url.Values, []any) any {
+		return map[string]any{"repositories": []any{"repo1"}}
+	}, &requestedUrls)
+
+	response, err := paginate("https://acme.jfrog.io/artifactory/api/repositories", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"repositories": []any{"repo1"}}, response)
+	assert.Len(t, requestedUrls, 1)
+}
+
+func TestApplyQuery(t *testing.T) {
+	var response any
+	require.NoError(t, json.Unmarshal([]byte(`{"results":[{"name":"a.jar","size":1},{"name":"b.jar","size":2}],"range":{"total":2},"a.b":"dots"}`), &response))
+	tests := []struct {
+		query    string
+		expected []any
+	}{
+		{".", []any{response}},
+		{".results[].name", []any{"a.jar", "b.jar"}},
+		{".results[-1].size", []any{float64(2)}},
+		{".results[5]", []any{nil}},
+		{".range.total", []any{float64(2)}},
+		{`.["a.b"]`, []any{"dots"}},
+		{".missing.field", []any{nil}},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			values, err := applyQuery(test.query, response)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestApplyQueryErrors(t *testing.T) {
+	var response any
+	require.NoError(t, json.Unmarshal([]byte(`{"results":[{"name":"a.jar"}]}`), &response))
+	for _, query := range []string{"results", ".results[", ".results[x]", "..results", ".results.name", ".results[0].name[]"} {
+		t.Run(query, func(t *testing.T) {
+			_, err := applyQuery(query, response)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFormatQueryResult(t *testing.T) {
+	output, err := formatQueryResult("raw string")
+	require.NoError(t, err)
+	assert.Equal(t, "raw string", output)
+
+	output, err = formatQueryResult(map[string]any{"key": float64(1)})
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"key\": 1\n}", output)
+}
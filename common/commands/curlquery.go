@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// querySegment is a single step of a query path.
+type querySegment struct {
+	field   string
+	index   int
+	isIndex bool
+	iterate bool
+}
+
+// parseQuery parses a jq-like path, for example: '.results[].name', '.repositories[0]' or '.["key.with.dots"]'.
+// Supported steps are '.field', '["field"]', '[n]' (negative indexes count from the end) and '[]' to iterate over all the elements.
+func parseQuery(query string) ([]querySegment, error) {
+	if !strings.HasPrefix(query, ".") && !strings.HasPrefix(query, "[") {
+		return nil, errorutils.CheckErrorf("invalid query '%s': the query must start with '.'", query)
+	}
+	var segments []querySegment
+	for rest := query; rest != ""; {
+		switch {
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, errorutils.CheckErrorf("invalid query '%s': missing ']'", query)
+			}
+			segment, err := parseBrackets(rest[1:end])
+			if err != nil {
+				return nil, errorutils.CheckErrorf("invalid query '%s': %s", query, err.Error())
+			}
+			segments = append(segments, segment)
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end > 0 {
+				segments = append(segments, querySegment{field: rest[:end]})
+			} else if rest != "" && !strings.HasPrefix(rest, "[") {
+				return nil, errorutils.CheckErrorf("invalid query '%s': empty field name", query)
+			}
+			rest = rest[end:]
+		default:
+			return nil, errorutils.CheckErrorf("invalid query '%s': unexpected '%s'", query, rest)
+		}
+	}
+	return segments, nil
+}
+
+func parseBrackets(content string) (querySegment, error) {
+	if content == "" {
+		return querySegment{iterate: true}, nil
+	}
+	if strings.HasPrefix(content, `"`) {
+		field, err := strconv.Unquote(content)
+		if err != nil {
+			return querySegment{}, fmt.Errorf("invalid field name %s", content)
+		}
+		return querySegment{field: field}, nil
+	}
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return querySegment{}, fmt.Errorf("invalid index '%s'", content)
+	}
+	return querySegment{index: index, isIndex: true}, nil
+}
+
+// applyQuery returns the values selected by the query. Missing fields and out of range indexes select null, as in jq.
+func applyQuery(query string, value any) ([]any, error) {
+	segments, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	values := []any{value}
+	for _, segment := range segments {
+		var next []any
+		for _, current := range values {
+			selected, err := segment.apply(current)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, selected...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func (qs *querySegment) apply(value any) ([]any, error) {
+	if value == nil {
+		if qs.iterate {
+			return nil, errorutils.CheckErrorf("cannot iterate over null")
+		}
+		return []any{nil}, nil
+	}
+	switch typedValue := value.(type) {
+	case map[string]any:
+		switch {
+		case qs.iterate:
+			values := make([]any, 0, len(typedValue))
+			for _, key := range getSortedKeys(typedValue) {
+				values = append(values, typedValue[key])
+			}
+			return values, nil
+		case !qs.isIndex:
+			return []any{typedValue[qs.field]}, nil
+		}
+		return nil, errorutils.CheckErrorf("cannot index an object with number %d", qs.index)
+	case []any:
+		switch {
+		case qs.iterate:
+			return typedValue, nil
+		case qs.isIndex:
+			index := qs.index
+			if index < 0 {
+				index += len(typedValue)
+			}
+			if index < 0 || index >= len(typedValue) {
+				return []any{nil}, nil
+			}
+			return []any{typedValue[index]}, nil
+		}
+		return nil, errorutils.CheckErrorf("cannot index an array with field '%s'", qs.field)
+	default:
+		return nil, errorutils.CheckErrorf("cannot index %T value", value)
+	}
+}
+
+func getSortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Formats a selected value for the output. Strings are printed raw, so that they can be consumed by scripts.
+func formatQueryResult(value any) (string, error) {
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	content, err := json.MarshalIndent(value, "", "  ")
+	return string(content), errorutils.CheckError(err)
+}
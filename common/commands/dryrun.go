@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const DryRunLogPrefix = "[Dry run] "
+
+// DryRunCommand is implemented by the mutating commands which support the dry run mode.
+// In dry run mode, the command reports the actions it would perform, without calling any write API.
+type DryRunCommand interface {
+	Command
+	EnableDryRun()
+}
+
+// ReadOnlyCommand is implemented by the commands which don't change anything, or don't change anything with some of their options.
+// In dry run mode, such commands run as usual if IsReadOnly returns true.
+type ReadOnlyCommand interface {
+	Command
+	IsReadOnly() bool
+}
+
+// Commands which resolve file specs implement this interface, so that the resolved specs are reported in dry run mode.
+type specCommand interface {
+	Spec() *spec.SpecFiles
+}
+
+// IsGlobalDryRun returns true if the dry run mode was enabled for all commands, using the --dry-run global option or the JFROG_CLI_DRY_RUN environment variable.
+func IsGlobalDryRun() (bool, error) {
+	return clientutils.GetBoolEnvValue(coreutils.DryRun, false)
+}
+
+// LogDryRunAction reports an action which would have been performed if the command didn't run in dry run mode.
+func LogDryRunAction(action string, details ...interface{}) {
+	log.Info(append([]interface{}{DryRunLogPrefix + action}, details...)...)
+}
+
+// Applies the global dry run mode to the command.
+// Read-only commands run as usual. Other commands which don't support the dry run mode fail, rather than performing their actions.
+func applyGlobalDryRun(command Command) error {
+	dryRun, err := IsGlobalDryRun()
+	if err != nil || !dryRun {
+		return err
+	}
+	if readOnlyCommand, ok := command.(ReadOnlyCommand); ok && readOnlyCommand.IsReadOnly() {
+		return nil
+	}
+	dryRunCommand, ok := command.(DryRunCommand)
+	if !ok {
+		return errorutils.CheckErrorf("the '%s' command does not support the dry run mode", command.CommandName())
+	}
+	dryRunCommand.EnableDryRun()
+	log.Info(DryRunLogPrefix + "Running in dry run mode. No changes will be made.")
+	if specCmd, ok := command.(specCommand); ok && specCmd.Spec() != nil {
+		resolvedSpec, err := json.Marshal(specCmd.Spec())
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		LogDryRunAction("Resolved file spec:\n" + clientutils.IndentJson(resolvedSpec))
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCommand struct {
+	ran bool
+}
+
+func (tc *testCommand) Run() error {
+	tc.ran = true
+	return nil
+}
+
+func (tc *testCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (tc *testCommand) CommandName() string {
+	return "test_command"
+}
+
+type testDryRunCommand struct {
+	testCommand
+	dryRun bool
+}
+
+func (tdc *testDryRunCommand) EnableDryRun() {
+	tdc.dryRun = true
+}
+
+type testReadOnlyCommand struct {
+	testCommand
+	readOnly bool
+}
+
+func (trc *testReadOnlyCommand) IsReadOnly() bool {
+	return trc.readOnly
+}
+
+func TestApplyGlobalDryRun(t *testing.T) {
+	// Not in dry run mode
+	dryRunCmd := &testDryRunCommand{}
+	assert.NoError(t, applyGlobalDryRun(dryRunCmd))
+	assert.False(t, dryRunCmd.dryRun)
+
+	testsutils.SetEnvAndAssert(t, coreutils.DryRun, "true")
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.DryRun)
+	assert.NoError(t, Exec(dryRunCmd))
+	assert.True(t, dryRunCmd.dryRun)
+	assert.True(t, dryRunCmd.ran)
+
+	// Commands which don't support the dry run mode must not run
+	cmd := &testCommand{}
+	assert.ErrorContains(t, Exec(cmd), "does not support the dry run mode")
+	assert.False(t, cmd.ran)
+
+	// Read-only commands run as usual
+	readOnlyCmd := &testReadOnlyCommand{readOnly: true}
+	assert.NoError(t, Exec(readOnlyCmd))
+	assert.True(t, readOnlyCmd.ran)
+	readOnlyCmd = &testReadOnlyCommand{readOnly: false}
+	assert.ErrorContains(t, Exec(readOnlyCmd), "does not support the dry run mode")
+	assert.False(t, readOnlyCmd.ran)
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// InterruptHandlingCommand is implemented by commands which handle the interrupt signals by themselves, such as commands which stop gracefully and save their state.
+// When HandlesInterrupts returns true, the command isn't cancelled by the interrupt signals.
+type InterruptHandlingCommand interface {
+	Command
+	HandlesInterrupts() bool
+}
+
+// Returns a context which is cancelled when the process receives an interrupt signal (SIGINT or SIGTERM), unless the command handles the signals by itself.
+// After the first signal, the signals are no longer caught, so that another interrupt terminates the process immediately.
+// The returned function stops catching the signals.
+func withInterruptCancel(parent context.Context, command Command) (context.Context, func()) {
+	if interruptHandlingCmd, ok := command.(InterruptHandlingCommand); ok && interruptHandlingCmd.HandlesInterrupts() {
+		return parent, func() {}
+	}
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-signals:
+			signal.Stop(signals)
+			log.Warn(fmt.Sprintf("Received the %s signal. Stopping the command. Interrupt again to exit immediately.", sig))
+			cancel()
+		case <-stopped:
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		close(stopped)
+		cancel()
+	}
+}
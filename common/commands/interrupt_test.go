@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testInterruptHandlingCommand struct {
+	testTimeoutCommand
+}
+
+func (c *testInterruptHandlingCommand) HandlesInterrupts() bool {
+	return true
+}
+
+func TestExecInterrupted(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Sending an interrupt signal to the process isn't supported on Windows")
+	}
+	restored := false
+	command := &testTimeoutCommand{run: func(*testTimeoutCommand) error {
+		// A temporary change which isn't restored by the command, since it's interrupted.
+		coreutils.RegisterInterruptCleanup(func() error {
+			restored = true
+			return nil
+		})
+		process, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			return err
+		}
+		if err = process.Signal(os.Interrupt); err != nil {
+			return err
+		}
+		<-coreutils.CommandContext().Done()
+		return coreutils.CommandContext().Err()
+	}}
+	err := Exec(command)
+	assert.EqualError(t, err, "the 'test_timeout' command was cancelled before it completed")
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.True(t, restored)
+}
+
+func TestWithInterruptCancel(t *testing.T) {
+	parent := context.Background()
+	ctx, stop := withInterruptCancel(parent, &testInterruptHandlingCommand{})
+	stop()
+	// The command handling the interrupts by itself isn't cancelled by them.
+	assert.Equal(t, parent, ctx)
+
+	ctx, stop = withInterruptCancel(parent, &testTimeoutCommand{})
+	require.NotNil(t, ctx.Done())
+	assert.NoError(t, ctx.Err())
+	stop()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/access/services"
+	"github.com/jfrog/jfrog-client-go/auth"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const migratedTokenDescription = "Migrated from an API key by JFrog CLI"
+
+// Set after the API key warning is logged, to log it once per process.
+var apiKeyWarningLogged bool
+
+// MigrateCredentialsCommand replaces the deprecated Artifactory API keys of the configured servers with access tokens.
+// An access token is created for each server by the Access API, authenticated by the API key, and replaces the API key in the configuration.
+type MigrateCredentialsCommand struct {
+	// The ID of the server to migrate. All the servers are migrated if empty.
+	serverId string
+	dryRun   bool
+	result   *MigrateCredentialsResult
+}
+
+// MigrateCredentialsResult holds the IDs of the servers which were migrated, and the errors of the servers which failed migrating, by their IDs.
+type MigrateCredentialsResult struct {
+	Migrated []string
+	Failed   map[string]error
+}
+
+func NewMigrateCredentialsCommand() *MigrateCredentialsCommand {
+	return &MigrateCredentialsCommand{result: &MigrateCredentialsResult{Failed: map[string]error{}}}
+}
+
+func (mcc *MigrateCredentialsCommand) SetServerId(serverId string) *MigrateCredentialsCommand {
+	mcc.serverId = serverId
+	return mcc
+}
+
+func (mcc *MigrateCredentialsCommand) SetDryRun(dryRun bool) *MigrateCredentialsCommand {
+	mcc.dryRun = dryRun
+	return mcc
+}
+
+func (mcc *MigrateCredentialsCommand) EnableDryRun() {
+	mcc.dryRun = true
+}
+
+func (mcc *MigrateCredentialsCommand) Result() *MigrateCredentialsResult {
+	return mcc.result
+}
+
+func (mcc *MigrateCredentialsCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (mcc *MigrateCredentialsCommand) CommandName() string {
+	return "config_migrate_credentials"
+}
+
+func (mcc *MigrateCredentialsCommand) Run() (err error) {
+	log.Debug("Locking config file to run config migrate-credentials command.")
+	unlockFunc, err := lockConfig()
+	// Defer the lockFile.Unlock() function before throwing a possible error to avoid deadlock situations.
+	defer func() {
+		err = errors.Join(err, unlockFunc())
+	}()
+	if err != nil {
+		return
+	}
+	configurations, err := config.GetAllServersConfigs()
+	if err != nil {
+		return
+	}
+	found := false
+	for _, serverDetails := range configurations {
+		if mcc.serverId != "" && serverDetails.ServerId != mcc.serverId {
+			continue
+		}
+		found = true
+		if !serverDetails.UsesApiKey() {
+			log.Debug(fmt.Sprintf("Server ID '%s' doesn't use an API key.", serverDetails.ServerId))
+			continue
+		}
+		if mcc.dryRun {
+			LogDryRunAction(fmt.Sprintf("Would replace the API key of server ID '%s' with an access token.", serverDetails.ServerId))
+			mcc.result.Migrated = append(mcc.result.Migrated, serverDetails.ServerId)
+			continue
+		}
+		if migrateErr := replaceApiKeyWithAccessToken(serverDetails); migrateErr != nil {
+			log.Error(fmt.Sprintf("Failed migrating the API key of server ID '%s': %s", serverDetails.ServerId, migrateErr.Error()))
+			mcc.result.Failed[serverDetails.ServerId] = migrateErr
+			continue
+		}
+		log.Info(fmt.Sprintf("Replaced the API key of server ID '%s' with an access token.", serverDetails.ServerId))
+		mcc.result.Migrated = append(mcc.result.Migrated, serverDetails.ServerId)
+	}
+	if !found {
+		return errorutils.CheckErrorf("Server ID '%s' does not exist.", mcc.serverId)
+	}
+	if len(mcc.result.Migrated) == 0 && len(mcc.result.Failed) == 0 {
+		log.Info("No servers configured with API keys were found.")
+		return
+	}
+	if !mcc.dryRun && len(mcc.result.Migrated) > 0 {
+		if err = config.SaveServersConf(configurations); err != nil {
+			return
+		}
+	}
+	if len(mcc.result.Failed) > 0 {
+		return errorutils.CheckErrorf("failed migrating the API keys of %d servers", len(mcc.result.Failed))
+	}
+	return
+}
+
+// Creates a refreshable access token for the user of the API key, and replaces the API key of the server with it.
+// The server details are changed only if the token is created successfully.
+func replaceApiKeyWithAccessToken(serverDetails *config.ServerDetails) error {
+	migrationDetails := *serverDetails
+	if migrationDetails.Url == "" {
+		// The Access API is served by the platform URL, which is derived from the Artifactory URL of servers configured without it.
+		migrationDetails.Url = strings.TrimSuffix(clientUtils.AddTrailingSlashIfNeeded(serverDetails.ArtifactoryUrl), "artifactory/")
+	}
+	accessManager, err := utils.CreateAccessServiceManager(&migrationDetails, false)
+	if err != nil {
+		return err
+	}
+	params := services.CreateTokenParams{
+		CommonTokenParams: auth.CommonTokenParams{Refreshable: clientUtils.Pointer(true), Audience: "*@*"},
+		Description:       migratedTokenDescription,
+	}
+	token, err := accessManager.CreateAccessToken(params)
+	if err != nil {
+		return err
+	}
+	serverDetails.AccessToken = token.AccessToken
+	serverDetails.RefreshToken = token.RefreshToken
+	serverDetails.Password = ""
+	return nil
+}
+
+// Checks whether the server of the command authenticates with an API key. If the JFROG_CLI_MIGRATE_API_KEYS environment variable is set,
+// the API key is replaced with an access token, in the configuration and in the details used by the command. Otherwise, a warning is logged.
+func checkApiKeyCredentials(command Command) error {
+	serverDetails, err := command.ServerDetails()
+	if err != nil || serverDetails == nil || !serverDetails.UsesApiKey() {
+		return nil
+	}
+	migrate, err := clientUtils.GetBoolEnvValue(coreutils.MigrateApiKeys, false)
+	if err != nil {
+		return err
+	}
+	if !migrate || serverDetails.ServerId == "" {
+		if !apiKeyWarningLogged {
+			apiKeyWarningLogged = true
+			log.Warn("The server is configured with an Artifactory API key. API keys are deprecated and will stop working once they're disabled in Artifactory. " +
+				"Run 'jf config migrate-credentials' to replace them with access tokens, or set " + coreutils.MigrateApiKeys + "=true to replace them automatically.")
+		}
+		return nil
+	}
+	migrateCmd := NewMigrateCredentialsCommand().SetServerId(serverDetails.ServerId)
+	if err = migrateCmd.Run(); err != nil {
+		return err
+	}
+	// Use the access token in the details the command already holds.
+	if len(migrateCmd.Result().Migrated) == 0 {
+		return nil
+	}
+	migratedDetails, err := config.GetSpecificConfig(serverDetails.ServerId, false, false)
+	if err != nil {
+		return err
+	}
+	serverDetails.AccessToken = migratedDetails.AccessToken
+	serverDetails.RefreshToken = migratedDetails.RefreshToken
+	serverDetails.Password = ""
+	return nil
+}
@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// #nosec G101 -- False positive - no hardcoded credentials.
+	// jfrog-ignore - not a real API key
+	testApiKey = "AKCp8" + "fsafsadfkljaodjpioqwu4-32742398ujklwertjp89347583jtklsdfmgklsdjuftp397859jsdklfnsljgflkdsjlgjld"
+	// #nosec G101 -- False positive - no hardcoded credentials.
+	testMigratedToken = "migrated-access-token"
+)
+
+type testServerCommand struct {
+	testCommand
+	serverDetails *config.ServerDetails
+}
+
+func (tsc *testServerCommand) ServerDetails() (*config.ServerDetails, error) {
+	return tsc.serverDetails, nil
+}
+
+// Creates an Access server which creates tokens for requests authenticated by the test API key, and returns the number of created tokens.
+func createTokensServer(t *testing.T) (*httptest.Server, *int32) {
+	var created int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if r.Method != http.MethodPost || r.URL.Path != "/access/api/v1/tokens" || !ok || user != "admin" || password != testApiKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, true, body["refreshable"])
+		assert.Equal(t, migratedTokenDescription, body["description"])
+		atomic.AddInt32(&created, 1)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"access_token":"` + testMigratedToken + `","refresh_token":"migrated-refresh-token","token_type":"Bearer"}`))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+	return server, &created
+}
+
+func saveMigrationTestServers(t *testing.T, url string) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{
+		{ServerId: "platform", Url: url + "/", ArtifactoryUrl: url + "/artifactory/", User: "admin", Password: testApiKey, IsDefault: true},
+		{ServerId: "artifactory-only", ArtifactoryUrl: url + "/artifactory/", User: "admin", Password: testApiKey},
+		{ServerId: "token", Url: url + "/", ArtifactoryUrl: url + "/artifactory/", AccessToken: "existing-token"},
+		{ServerId: "password", Url: url + "/", ArtifactoryUrl: url + "/artifactory/", User: "admin", Password: "password"},
+	}))
+}
+
+func TestIsApiKeyCredentials(t *testing.T) {
+	assert.True(t, config.IsApiKeyCredentials(testApiKey, ""))
+	assert.False(t, config.IsApiKeyCredentials(testApiKey, "token"))
+	assert.False(t, config.IsApiKeyCredentials("password", ""))
+	assert.False(t, config.IsApiKeyCredentials("", ""))
+}
+
+func TestMigrateCredentials(t *testing.T) {
+	tokensServer, created := createTokensServer(t)
+	saveMigrationTestServers(t, tokensServer.URL)
+
+	migrateCmd := NewMigrateCredentialsCommand()
+	assert.NoError(t, migrateCmd.Run())
+	assert.ElementsMatch(t, []string{"platform", "artifactory-only"}, migrateCmd.Result().Migrated)
+	assert.Empty(t, migrateCmd.Result().Failed)
+	assert.Equal(t, int32(2), *created)
+
+	for _, serverId := range []string{"platform", "artifactory-only"} {
+		serverDetails, err := GetConfig(serverId, false)
+		assert.NoError(t, err)
+		assert.Equal(t, testMigratedToken, serverDetails.AccessToken)
+		assert.Equal(t, "migrated-refresh-token", serverDetails.RefreshToken)
+		assert.Empty(t, serverDetails.Password)
+		assert.False(t, serverDetails.UsesApiKey())
+	}
+	serverDetails, err := GetConfig("token", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "existing-token", serverDetails.AccessToken)
+	serverDetails, err = GetConfig("password", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "password", serverDetails.Password)
+
+	// Running the migration again has nothing to migrate.
+	migrateCmd = NewMigrateCredentialsCommand()
+	assert.NoError(t, migrateCmd.Run())
+	assert.Empty(t, migrateCmd.Result().Migrated)
+	assert.Equal(t, int32(2), *created)
+}
+
+func TestMigrateCredentialsSingleServer(t *testing.T) {
+	tokensServer, created := createTokensServer(t)
+	saveMigrationTestServers(t, tokensServer.URL)
+
+	migrateCmd := NewMigrateCredentialsCommand().SetServerId("artifactory-only")
+	assert.NoError(t, migrateCmd.Run())
+	assert.Equal(t, []string{"artifactory-only"}, migrateCmd.Result().Migrated)
+	assert.Equal(t, int32(1), *created)
+	serverDetails, err := GetConfig("platform", false)
+	assert.NoError(t, err)
+	assert.Equal(t, testApiKey, serverDetails.Password)
+
+	assert.ErrorContains(t, NewMigrateCredentialsCommand().SetServerId("missing").Run(), "Server ID 'missing' does not exist.")
+}
+
+func TestMigrateCredentialsDryRun(t *testing.T) {
+	tokensServer, created := createTokensServer(t)
+	saveMigrationTestServers(t, tokensServer.URL)
+
+	migrateCmd := NewMigrateCredentialsCommand().SetDryRun(true)
+	assert.NoError(t, migrateCmd.Run())
+	assert.ElementsMatch(t, []string{"platform", "artifactory-only"}, migrateCmd.Result().Migrated)
+	assert.Equal(t, int32(0), *created)
+	serverDetails, err := GetConfig("platform", false)
+	assert.NoError(t, err)
+	assert.Equal(t, testApiKey, serverDetails.Password)
+	assert.Empty(t, serverDetails.AccessToken)
+}
+
+func TestMigrateCredentialsFailure(t *testing.T) {
+	tokensServer, created := createTokensServer(t)
+	saveMigrationTestServers(t, tokensServer.URL)
+	// A server whose API key is rejected by the Access API.
+	configurations, err := config.GetAllServersConfigs()
+	require.NoError(t, err)
+	configurations = append(configurations, &config.ServerDetails{ServerId: "rejected", Url: tokensServer.URL + "/", User: "other", Password: testApiKey})
+	require.NoError(t, config.SaveServersConf(configurations))
+
+	migrateCmd := NewMigrateCredentialsCommand()
+	assert.ErrorContains(t, migrateCmd.Run(), "failed migrating the API keys of 1 servers")
+	assert.ElementsMatch(t, []string{"platform", "artifactory-only"}, migrateCmd.Result().Migrated)
+	assert.Contains(t, migrateCmd.Result().Failed, "rejected")
+	assert.Equal(t, int32(2), *created)
+
+	// The servers which were migrated are saved, and the rejected server keeps its API key.
+	serverDetails, err := GetConfig("platform", false)
+	assert.NoError(t, err)
+	assert.Equal(t, testMigratedToken, serverDetails.AccessToken)
+	serverDetails, err = GetConfig("rejected", false)
+	assert.NoError(t, err)
+	assert.Equal(t, testApiKey, serverDetails.Password)
+}
+
+func TestCheckApiKeyCredentials(t *testing.T) {
+	tokensServer, created := createTokensServer(t)
+	saveMigrationTestServers(t, tokensServer.URL)
+	serverDetails, err := GetConfig("platform", false)
+	require.NoError(t, err)
+	command := &testServerCommand{serverDetails: serverDetails}
+
+	// Without the environment variable, the server is only reported.
+	assert.NoError(t, checkApiKeyCredentials(command))
+	assert.Equal(t, int32(0), *created)
+	assert.Equal(t, testApiKey, serverDetails.Password)
+
+	testsutils.SetEnvAndAssert(t, coreutils.MigrateApiKeys, "true")
+	assert.NoError(t, checkApiKeyCredentials(command))
+	assert.Equal(t, int32(1), *created)
+	assert.Equal(t, testMigratedToken, serverDetails.AccessToken)
+	assert.Empty(t, serverDetails.Password)
+	savedDetails, err := GetConfig("platform", false)
+	assert.NoError(t, err)
+	assert.Equal(t, testMigratedToken, savedDetails.AccessToken)
+
+	// The other servers aren't migrated.
+	savedDetails, err = GetConfig("artifactory-only", false)
+	assert.NoError(t, err)
+	assert.Equal(t, testApiKey, savedDetails.Password)
+}
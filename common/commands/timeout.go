@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The time a cancelled command is given to stop, after which it's abandoned and the cancellation error is returned.
+// Requests to the JFrog Platform are aborted immediately, but the command may be busy with local work or waiting for an external process.
+var cancellationGracePeriod = 10 * time.Second
+
+// ContextCommand is implemented by commands which stop their own long-running work, such as external processes, when the context is done.
+// The services managers of all commands use the context, so implementing this interface isn't required for cancelling their requests.
+type ContextCommand interface {
+	Command
+	SetContext(ctx context.Context)
+}
+
+// ResultCommand is implemented by the commands which report the numbers of succeeded and failed operations,
+// so that the partial results are reported when they're cancelled.
+type ResultCommand interface {
+	Result() *commandsutils.Result
+}
+
+// CommandCancelledError is returned when a command is cancelled, or times out, before it completes.
+type CommandCancelledError struct {
+	CommandName string
+	Cause       error
+	// The numbers of operations completed before the cancellation, if the command reports them.
+	Succeeded, Failed int
+	HasResults        bool
+}
+
+func (cce *CommandCancelledError) Error() string {
+	reason := "was cancelled"
+	if errors.Is(cce.Cause, context.DeadlineExceeded) {
+		reason = "timed out"
+	}
+	message := fmt.Sprintf("the '%s' command %s before it completed", cce.CommandName, reason)
+	if cce.HasResults {
+		message += fmt.Sprintf(". %d operations succeeded and %d failed before it was stopped", cce.Succeeded, cce.Failed)
+	}
+	return message
+}
+
+func (cce *CommandCancelledError) Unwrap() error {
+	return cce.Cause
+}
+
+// GetCommandTimeout returns the timeout of all commands, set by the JFROG_CLI_COMMAND_TIMEOUT environment variable, or 0 if it isn't set.
+func GetCommandTimeout() (time.Duration, error) {
+	value := os.Getenv(coreutils.CommandTimeout)
+	if value == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return 0, errorutils.CheckErrorf("the value of %s must be a non-negative duration such as '30m' or '1h', but is: %s", coreutils.CommandTimeout, value)
+	}
+	return timeout, nil
+}
+
+// ExecWithTimeout runs the command, and cancels it if it doesn't complete within the timeout. A zero timeout means no timeout.
+func ExecWithTimeout(command Command, timeout time.Duration) error {
+	return execWithTimeout(context.Background(), command, timeout)
+}
+
+func execWithTimeout(parent context.Context, command Command, timeout time.Duration) error {
+	if timeout <= 0 {
+		return ExecWithContext(parent, command)
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+	return ExecWithContext(ctx, command)
+}
+
+// Runs the command with the context as the command context. When the context is done, the command is given the grace period to stop,
+// and the cancellation error is returned with the partial results of the command.
+func runWithContext(ctx context.Context, command Command) error {
+	if ctx.Done() == nil {
+		// The context can't be cancelled.
+		return command.Run()
+	}
+	restore := coreutils.SetCommandContext(ctx)
+	defer restore()
+	if contextCommand, ok := command.(ContextCommand); ok {
+		contextCommand.SetContext(ctx)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- command.Run()
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		log.Warn(fmt.Sprintf("Stopping the '%s' command: %s", command.CommandName(), ctx.Err().Error()))
+		select {
+		case err = <-done:
+		case <-time.After(cancellationGracePeriod):
+			log.Warn("The command didn't stop within", cancellationGracePeriod.String()+". Abandoning it.")
+			err = ctx.Err()
+		}
+	}
+	// A command which completed successfully just before the cancellation isn't failed.
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	cancelledErr := &CommandCancelledError{CommandName: command.CommandName(), Cause: ctx.Err()}
+	if resultCmd, ok := command.(ResultCommand); ok && resultCmd.Result() != nil {
+		cancelledErr.HasResults = true
+		cancelledErr.Succeeded = resultCmd.Result().SuccessCount()
+		cancelledErr.Failed = resultCmd.Result().FailCount()
+	}
+	log.Debug("The command stopped with:", err.Error())
+	// Restores the temporary changes which the command didn't restore before it stopped, such as replaced configuration files.
+	if cleanupErr := coreutils.RunInterruptCleanups(); cleanupErr != nil {
+		return errors.Join(errorutils.CheckError(cancelledErr), cleanupErr)
+	}
+	return errorutils.CheckError(cancelledErr)
+}
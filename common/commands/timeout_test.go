@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	rtutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTimeoutCommand struct {
+	serverDetails *config.ServerDetails
+	result        *utils.Result
+	run           func(c *testTimeoutCommand) error
+}
+
+func (c *testTimeoutCommand) Run() error {
+	return c.run(c)
+}
+
+func (c *testTimeoutCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (c *testTimeoutCommand) CommandName() string {
+	return "test_timeout"
+}
+
+func (c *testTimeoutCommand) Result() *utils.Result {
+	return c.result
+}
+
+func TestExecWithTimeout(t *testing.T) {
+	server := tests.CreateRestsMockServer(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	defer server.Close()
+
+	// The requests of the services managers are aborted when the command times out.
+	command := &testTimeoutCommand{
+		serverDetails: &config.ServerDetails{ArtifactoryUrl: server.URL + "/"},
+		result:        new(utils.Result),
+		run: func(c *testTimeoutCommand) error {
+			c.result.SetSuccessCount(3)
+			servicesManager, err := rtutils.CreateServiceManager(c.serverDetails, 0, 0, false)
+			if err != nil {
+				return err
+			}
+			_, err = servicesManager.GetVersion()
+			return err
+		},
+	}
+	start := time.Now()
+	err := ExecWithTimeout(command, 100*time.Millisecond)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.EqualError(t, err, "the 'test_timeout' command timed out before it completed. 3 operations succeeded and 0 failed before it was stopped")
+	var cancelledErr *CommandCancelledError
+	assert.True(t, errors.As(err, &cancelledErr))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	// The context of the next commands isn't cancelled.
+	assert.NoError(t, coreutils.CommandContext().Err())
+
+	// A command ignoring the cancellation is abandoned after the grace period.
+	defer func(gracePeriod time.Duration) {
+		cancellationGracePeriod = gracePeriod
+	}(cancellationGracePeriod)
+	cancellationGracePeriod = 50 * time.Millisecond
+	command = &testTimeoutCommand{run: func(*testTimeoutCommand) error {
+		time.Sleep(time.Second)
+		return nil
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.EqualError(t, ExecWithContext(ctx, command), "the 'test_timeout' command was cancelled before it completed")
+
+	// A command completing within the timeout succeeds.
+	command = &testTimeoutCommand{run: func(*testTimeoutCommand) error {
+		return nil
+	}}
+	assert.NoError(t, ExecWithTimeout(command, time.Minute))
+}
+
+func TestGetCommandTimeout(t *testing.T) {
+	t.Setenv(coreutils.CommandTimeout, "")
+	timeout, err := GetCommandTimeout()
+	assert.NoError(t, err)
+	assert.Zero(t, timeout)
+
+	t.Setenv(coreutils.CommandTimeout, "90m")
+	timeout, err = GetCommandTimeout()
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, timeout)
+
+	t.Setenv(coreutils.CommandTimeout, "forever")
+	_, err = GetCommandTimeout()
+	assert.ErrorContains(t, err, coreutils.CommandTimeout)
+}
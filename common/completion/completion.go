@@ -0,0 +1,238 @@
+package completion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Source is a type of values, which can be suggested by the shell completion.
+type Source string
+
+const (
+	ServerIds    Source = "server-ids"
+	Repositories Source = "repositories"
+	BuildNames   Source = "build-names"
+	ProjectKeys  Source = "project-keys"
+)
+
+const (
+	serverIdFlag = "--server-id"
+	// The values fetched from the server are cached, to keep the shell completion responsive.
+	cacheTtl = 10 * time.Minute
+	// The shell completion should never hang the shell for long.
+	requestTimeout = 5 * time.Second
+)
+
+type cachedValues struct {
+	Timestamp int64    `json:"timestamp"`
+	Values    []string `json:"values"`
+}
+
+// GetValuesFunc returns a function which returns the values of the provided source.
+// The values are fetched from the server selected by the --server-id option of the completed command, or from the default server.
+func GetValuesFunc(source Source) func() ([]string, error) {
+	return func() ([]string, error) {
+		return GetValues(source, getServerIdFromArgs(os.Args))
+	}
+}
+
+// GetValues returns the values of the provided source.
+// The values fetched from the server are cached in the JFrog home directory, and refreshed when expired.
+func GetValues(source Source, serverId string) ([]string, error) {
+	if source == ServerIds {
+		return getServerIds()
+	}
+	serverDetails, err := config.GetSpecificConfig(serverId, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return getServerValues(source, serverDetails)
+}
+
+func getServerValues(source Source, serverDetails *config.ServerDetails) ([]string, error) {
+	cachePath, err := getCachePath(source, serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	if values, ok := readCache(cachePath); ok {
+		return values, nil
+	}
+	values, err := fetchValues(source, serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(values)
+	if err = writeCache(cachePath, values); err != nil {
+		// Failing to cache the values should not fail the completion.
+		log.Debug("Failed caching the completion values:", err.Error())
+	}
+	return values, nil
+}
+
+func getServerIds() ([]string, error) {
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return nil, err
+	}
+	var serverIds []string
+	for _, server := range servers {
+		serverIds = append(serverIds, server.ServerId)
+	}
+	return serverIds, nil
+}
+
+// Returns the value of the --server-id option of the completed command, or an empty string if not provided.
+func getServerIdFromArgs(args []string) string {
+	for i, arg := range args {
+		if value, found := strings.CutPrefix(arg, serverIdFlag+"="); found {
+			return value
+		}
+		if arg == serverIdFlag && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+func fetchValues(source Source, serverDetails *config.ServerDetails) ([]string, error) {
+	switch source {
+	case Repositories:
+		return fetchRepositories(serverDetails)
+	case BuildNames:
+		return fetchBuildNames(serverDetails)
+	case ProjectKeys:
+		return fetchProjectKeys(serverDetails)
+	default:
+		return nil, errorutils.CheckErrorf("unsupported completion source: %s", source)
+	}
+}
+
+func fetchRepositories(serverDetails *config.ServerDetails) ([]string, error) {
+	servicesManager, err := utils.CreateServiceManagerWithContext(context.Background(), serverDetails, false, 0, 0, 0, requestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	repositories, err := servicesManager.GetAllRepositories()
+	if err != nil {
+		return nil, err
+	}
+	var repoKeys []string
+	for _, repository := range *repositories {
+		repoKeys = append(repoKeys, repository.Key)
+	}
+	return repoKeys, nil
+}
+
+func fetchBuildNames(serverDetails *config.ServerDetails) ([]string, error) {
+	servicesManager, err := utils.CreateServiceManagerWithContext(context.Background(), serverDetails, false, 0, 0, 0, requestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(servicesManager.GetConfig().GetServiceDetails().GetUrl()+"api/build", true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	// Artifactory returns 404 if no builds exist.
+	if resp.StatusCode == http.StatusNotFound {
+		return []string{}, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var buildsResponse struct {
+		Builds []struct {
+			Uri string `json:"uri"`
+		} `json:"builds"`
+	}
+	if err = json.Unmarshal(body, &buildsResponse); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var buildNames []string
+	for _, build := range buildsResponse.Builds {
+		buildName, err := url.PathUnescape(strings.TrimPrefix(build.Uri, "/"))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		buildNames = append(buildNames, buildName)
+	}
+	return buildNames, nil
+}
+
+func fetchProjectKeys(serverDetails *config.ServerDetails) ([]string, error) {
+	accessManager, err := utils.CreateAccessServiceManagerWithTimeout(serverDetails, requestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := accessManager.GetAllProjects()
+	if err != nil {
+		return nil, err
+	}
+	var projectKeys []string
+	for _, project := range projects {
+		projectKeys = append(projectKeys, project.ProjectKey)
+	}
+	return projectKeys, nil
+}
+
+func getCachePath(source Source, serverDetails *config.ServerDetails) (string, error) {
+	cacheDir, err := coreutils.GetJfrogCompletionCacheDir()
+	if err != nil {
+		return "", err
+	}
+	// Servers without an ID, for example when provided by the --url option, are identified by their URL.
+	serverKey := serverDetails.ServerId
+	if serverKey == "" {
+		serverKey = url.PathEscape(serverDetails.Url)
+	}
+	return filepath.Join(cacheDir, serverKey, string(source)+".json"), nil
+}
+
+func readCache(cachePath string) ([]string, bool) {
+	content, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil, false
+	}
+	var cached cachedValues
+	if err = json.Unmarshal(content, &cached); err != nil {
+		return nil, false
+	}
+	if time.Since(time.Unix(cached.Timestamp, 0)) > cacheTtl {
+		return nil, false
+	}
+	return cached.Values, true
+}
+
+func writeCache(cachePath string, values []string) error {
+	content, err := json.Marshal(cachedValues{Timestamp: time.Now().Unix(), Values: values})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(cachePath)); err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.WriteFile(cachePath, content, 0600))
+}
+
+// CleanCompletionCache removes the cached completion values of all servers.
+func CleanCompletionCache() error {
+	cacheDir, err := coreutils.GetJfrogCompletionCacheDir()
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.RemoveAll(cacheDir))
+}
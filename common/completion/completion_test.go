@@ -0,0 +1,63 @@
+package completion
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetServerIdFromArgs(t *testing.T) {
+	assert.Equal(t, "", getServerIdFromArgs([]string{"jf", "rt", "u", "--repo"}))
+	assert.Equal(t, "my-server", getServerIdFromArgs([]string{"jf", "rt", "u", "--server-id", "my-server", "--repo"}))
+	assert.Equal(t, "my-server", getServerIdFromArgs([]string{"jf", "rt", "u", "--server-id=my-server", "--repo"}))
+	assert.Equal(t, "", getServerIdFromArgs([]string{"jf", "rt", "u", "--server-id", "--repo"}))
+}
+
+func TestGetBuildNamesWithCache(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.HomeDir)
+
+	var requests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/api/build", r.URL.Path)
+		_, err := w.Write([]byte(`{"builds":[{"uri":"/my-build"},{"uri":"/another%20build"}]}`))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+	serverDetails := &config.ServerDetails{ServerId: "test-server", ArtifactoryUrl: testServer.URL + "/"}
+
+	buildNames, err := getServerValues(BuildNames, serverDetails)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"another build", "my-build"}, buildNames)
+
+	// The second call should use the cached values
+	buildNames, err = getServerValues(BuildNames, serverDetails)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"another build", "my-build"}, buildNames)
+	assert.Equal(t, 1, requests)
+
+	assert.NoError(t, CleanCompletionCache())
+	_, err = getServerValues(BuildNames, serverDetails)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestFetchProjectKeysNotRetried(t *testing.T) {
+	var requests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer testServer.Close()
+
+	// The completion doesn't wait for the retries of failed requests.
+	_, err := fetchProjectKeys(&config.ServerDetails{Url: testServer.URL + "/", AccessToken: "token"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests)
+}
@@ -15,9 +15,16 @@ const (
 	Json       OutputFormat = "json"
 	SimpleJson OutputFormat = "simple-json"
 	Sarif      OutputFormat = "sarif"
+	Csv        OutputFormat = "csv"
+	Tree       OutputFormat = "tree"
+	// A CycloneDX SBOM of the scanned components, in JSON
+	CycloneDx OutputFormat = "cyclonedx"
+	// Annotations of pull requests and merge requests
+	GithubAnnotations OutputFormat = "github-annotations"
+	GitlabCodeQuality OutputFormat = "gitlab-codequality"
 )
 
-var OutputFormats = []string{string(Table), string(Json), string(SimpleJson), string(Sarif)}
+var OutputFormats = []string{string(Table), string(Json), string(SimpleJson), string(Sarif), string(Csv), string(CycloneDx)}
 
 func GetOutputFormat(formatFlagVal string) (format OutputFormat, err error) {
 	// Default print format is table.
@@ -32,6 +39,10 @@ func GetOutputFormat(formatFlagVal string) (format OutputFormat, err error) {
 			format = SimpleJson
 		case string(Sarif):
 			format = Sarif
+		case string(Csv):
+			format = Csv
+		case string(CycloneDx):
+			format = CycloneDx
 		default:
 			err = errorutils.CheckErrorf("only the following output formats are supported: " + coreutils.ListToText(OutputFormats))
 		}
@@ -218,8 +218,16 @@ func (p *filesProgressBarManager) GetProgress(id int) ioUtils.Progress {
 }
 
 // Initializes progress bar if possible (all conditions in 'shouldInitProgressBar' are met).
+// If the JSON progress format is configured, JSON progress events are written instead, regardless of the terminal.
 // Returns nil, nil, err if failed.
 func InitFilesProgressBarIfPossible(showLogFilePath bool) (ioUtils.ProgressMgr, error) {
+	progressFormat, err := GetProgressFormat()
+	if err != nil {
+		return nil, err
+	}
+	if progressFormat == ProgressFormatJson {
+		return initJsonProgressManager(showLogFilePath)
+	}
 	shouldInit, err := progressbar.ShouldInitProgressBar()
 	if !shouldInit || err != nil {
 		return nil, err
@@ -232,7 +240,7 @@ func InitFilesProgressBarIfPossible(showLogFilePath bool) (ioUtils.ProgressMgr,
 	if showLogFilePath {
 		log.Info("Log path:", logFile.Name())
 	}
-	log.SetLogger(log.NewLogger(corelog.GetCliLogLevel(), logFile))
+	log.SetLogger(corelog.NewCliLogger(corelog.GetCliLogLevel(), logFile, 0))
 
 	newProgressBar := &filesProgressBarManager{}
 	newProgressBar.barsWg = new(sync.WaitGroup)
@@ -0,0 +1,290 @@
+package progressbar
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	corelog "github.com/jfrog/jfrog-cli-core/v2/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The TTY progress bar, displayed only if the standard error is a terminal and the CLI doesn't run on CI.
+	ProgressFormatTty = "tty"
+	// Line-delimited JSON progress events, written to the standard error.
+	ProgressFormatJson = "json"
+
+	// The minimal interval between two progress events of the same file.
+	defaultProgressEventsInterval = time.Second
+)
+
+// Progress event types
+const (
+	progressEventStarted   = "started"
+	progressEventProgress  = "progress"
+	progressEventMerging   = "merging"
+	progressEventCompleted = "completed"
+	progressEventTotals    = "totals"
+	progressEventHeadline  = "headline"
+	progressEventDone      = "done"
+)
+
+// GetProgressFormat returns the progress format configured by the JFROG_CLI_PROGRESS_FORMAT environment variable.
+// The default format is 'tty'.
+func GetProgressFormat() (string, error) {
+	format := strings.ToLower(strings.TrimSpace(os.Getenv(coreutils.ProgressFormat)))
+	switch format {
+	case "":
+		return ProgressFormatTty, nil
+	case ProgressFormatTty, ProgressFormatJson:
+		return format, nil
+	default:
+		return "", errorutils.CheckErrorf("unsupported progress format '%s'. Possible values are: %s, %s", format, ProgressFormatTty, ProgressFormatJson)
+	}
+}
+
+// A single line-delimited JSON progress event.
+// Each event contains only the fields relevant to its type.
+type progressEvent struct {
+	Event            string `json:"event"`
+	Time             string `json:"time"`
+	Id               int    `json:"id,omitempty"`
+	Label            string `json:"label,omitempty"`
+	Path             string `json:"path,omitempty"`
+	Bytes            *int64 `json:"bytes,omitempty"`
+	TotalBytes       *int64 `json:"totalBytes,omitempty"`
+	SpeedBytesPerSec *int64 `json:"speedBytesPerSec,omitempty"`
+	DurationMs       *int64 `json:"durationMs,omitempty"`
+	CompletedTasks   *int64 `json:"completedTasks,omitempty"`
+	TotalTasks       *int64 `json:"totalTasks,omitempty"`
+	Message          string `json:"message,omitempty"`
+}
+
+// jsonProgressManager implements ioUtils.ProgressMgr by writing line-delimited JSON progress events,
+// allowing CI systems and wrappers to render their own progress UIs.
+type jsonProgressManager struct {
+	progresses     map[int]*jsonProgress
+	lastId         int
+	progressesLock sync.Mutex
+	// The events are written by multiple threads, and therefore must be synchronized.
+	encoder     *json.Encoder
+	encoderLock sync.Mutex
+	// The minimal interval between two progress events of the same file.
+	interval       time.Duration
+	completedTasks int64
+	totalTasks     int64
+	// The log file
+	logFile *os.File
+}
+
+func newJsonProgressManager(writer io.Writer) *jsonProgressManager {
+	return &jsonProgressManager{
+		progresses: make(map[int]*jsonProgress),
+		encoder:    json.NewEncoder(writer),
+		interval:   defaultProgressEventsInterval,
+	}
+}
+
+// Initializes the JSON progress events manager. The logs are written to a log file, to keep the standard error parsable.
+func initJsonProgressManager(showLogFilePath bool) (ioUtils.ProgressMgr, error) {
+	logFile, err := corelog.CreateLogFile()
+	if err != nil {
+		return nil, err
+	}
+	if showLogFilePath {
+		log.Info("Log path:", logFile.Name())
+	}
+	log.SetLogger(corelog.NewCliLogger(corelog.GetCliLogLevel(), logFile, 0))
+
+	progressManager := newJsonProgressManager(os.Stderr)
+	progressManager.logFile = logFile
+	return progressManager, nil
+}
+
+func (jpm *jsonProgressManager) InitProgressReaders() {
+	atomic.StoreInt64(&jpm.completedTasks, 0)
+	atomic.StoreInt64(&jpm.totalTasks, 0)
+}
+
+func (jpm *jsonProgressManager) NewProgressReader(total int64, label, path string) ioUtils.Progress {
+	jpm.progressesLock.Lock()
+	jpm.lastId++
+	progress := &jsonProgress{manager: jpm, id: jpm.lastId, label: strings.TrimSpace(label), path: path, total: total, startTime: time.Now()}
+	jpm.progresses[progress.id] = progress
+	jpm.progressesLock.Unlock()
+
+	jpm.writeEvent(progressEvent{Event: progressEventStarted, Id: progress.id, Label: progress.label, Path: path, TotalBytes: &total})
+	return progress
+}
+
+func (jpm *jsonProgressManager) SetMergingState(id int, _ bool) ioUtils.Progress {
+	progress := jpm.getProgress(id)
+	if progress == nil {
+		return nil
+	}
+	progress.merging.Store(true)
+	jpm.writeEvent(progressEvent{Event: progressEventMerging, Id: id, Label: progress.label, Path: progress.path})
+	return progress
+}
+
+func (jpm *jsonProgressManager) GetProgress(id int) ioUtils.Progress {
+	return jpm.getProgress(id)
+}
+
+func (jpm *jsonProgressManager) getProgress(id int) *jsonProgress {
+	jpm.progressesLock.Lock()
+	defer jpm.progressesLock.Unlock()
+	return jpm.progresses[id]
+}
+
+// Called on both successful and unsuccessful operations.
+func (jpm *jsonProgressManager) RemoveProgress(id int) {
+	jpm.progressesLock.Lock()
+	progress, exists := jpm.progresses[id]
+	delete(jpm.progresses, id)
+	jpm.progressesLock.Unlock()
+	if !exists {
+		return
+	}
+	bytes := progress.bytes.Load()
+	duration := time.Since(progress.startTime)
+	durationMs := duration.Milliseconds()
+	speed := calcSpeed(bytes, duration)
+	jpm.writeEvent(progressEvent{Event: progressEventCompleted, Id: id, Label: progress.label, Path: progress.path, Bytes: &bytes, TotalBytes: &progress.total, DurationMs: &durationMs, SpeedBytesPerSec: &speed})
+}
+
+func (jpm *jsonProgressManager) IncrementGeneralProgress() {
+	atomic.AddInt64(&jpm.completedTasks, 1)
+	jpm.writeTotalsEvent()
+}
+
+func (jpm *jsonProgressManager) IncGeneralProgressTotalBy(n int64) {
+	atomic.AddInt64(&jpm.totalTasks, n)
+	jpm.writeTotalsEvent()
+}
+
+func (jpm *jsonProgressManager) writeTotalsEvent() {
+	completed := atomic.LoadInt64(&jpm.completedTasks)
+	total := atomic.LoadInt64(&jpm.totalTasks)
+	jpm.writeEvent(progressEvent{Event: progressEventTotals, CompletedTasks: &completed, TotalTasks: &total})
+}
+
+func (jpm *jsonProgressManager) SetHeadlineMsg(msg string) {
+	jpm.writeEvent(progressEvent{Event: progressEventHeadline, Message: msg})
+}
+
+func (jpm *jsonProgressManager) ClearHeadlineMsg() {}
+
+func (jpm *jsonProgressManager) Quit() (err error) {
+	completed := atomic.LoadInt64(&jpm.completedTasks)
+	total := atomic.LoadInt64(&jpm.totalTasks)
+	jpm.writeEvent(progressEvent{Event: progressEventDone, CompletedTasks: &completed, TotalTasks: &total})
+	// Close the created log file (once)
+	if jpm.logFile != nil {
+		err = corelog.CloseLogFile(jpm.logFile)
+		jpm.logFile = nil
+		// Set back the default logger
+		corelog.SetDefaultLogger()
+	}
+	return
+}
+
+func (jpm *jsonProgressManager) writeEvent(event progressEvent) {
+	event.Time = time.Now().Format(time.RFC3339Nano)
+	jpm.encoderLock.Lock()
+	defer jpm.encoderLock.Unlock()
+	// Failing to report the progress should not fail the command.
+	if err := jpm.encoder.Encode(event); err != nil {
+		log.Debug("Failed writing progress event:", err.Error())
+	}
+}
+
+// Returns the average speed in bytes per second.
+func calcSpeed(bytes int64, duration time.Duration) int64 {
+	if duration <= 0 {
+		return 0
+	}
+	return int64(float64(bytes) / duration.Seconds())
+}
+
+// jsonProgress tracks the transfer of a single file and reports its progress as JSON events.
+type jsonProgress struct {
+	manager   *jsonProgressManager
+	id        int
+	label     string
+	path      string
+	total     int64
+	startTime time.Time
+	bytes     atomic.Int64
+	merging   atomic.Bool
+	// The time of the last progress event, in Unix nanoseconds.
+	lastEvent atomic.Int64
+}
+
+func (jp *jsonProgress) ActionWithProgress(reader io.Reader) io.Reader {
+	if reader == nil {
+		return nil
+	}
+	return &jsonProgressReader{reader: reader, progress: jp}
+}
+
+func (jp *jsonProgress) SetProgress(progress int64) {
+	// While merging, the progress holds the merge percentage rather than the transferred bytes.
+	if jp.merging.Load() {
+		return
+	}
+	jp.bytes.Store(progress)
+	jp.reportProgress()
+}
+
+func (jp *jsonProgress) Abort() {}
+
+func (jp *jsonProgress) GetId() int {
+	return jp.id
+}
+
+func (jp *jsonProgress) incrBy(n int) {
+	jp.bytes.Add(int64(n))
+	jp.reportProgress()
+}
+
+// Writes a progress event, unless the previous event of this file was written less than an interval ago.
+func (jp *jsonProgress) reportProgress() {
+	now := time.Now()
+	last := jp.lastEvent.Load()
+	if now.Sub(time.Unix(0, last)) < jp.manager.interval || !jp.lastEvent.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	bytes := jp.bytes.Load()
+	speed := calcSpeed(bytes, now.Sub(jp.startTime))
+	jp.manager.writeEvent(progressEvent{Event: progressEventProgress, Id: jp.id, Path: jp.path, Bytes: &bytes, TotalBytes: &jp.total, SpeedBytesPerSec: &speed})
+}
+
+// Wraps an io.Reader for bytes reading tracking
+type jsonProgressReader struct {
+	reader   io.Reader
+	progress *jsonProgress
+}
+
+func (jpr *jsonProgressReader) Read(p []byte) (n int, err error) {
+	n, err = jpr.reader.Read(p)
+	if n > 0 {
+		jpr.progress.incrBy(n)
+	}
+	return
+}
+
+func (jpr *jsonProgressReader) Close() error {
+	if closer, ok := jpr.reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
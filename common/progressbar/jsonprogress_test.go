@@ -0,0 +1,101 @@
+package progressbar
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProgressFormat(t *testing.T) {
+	format, err := GetProgressFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, ProgressFormatTty, format)
+
+	testsutils.SetEnvAndAssert(t, coreutils.ProgressFormat, "JSON")
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.ProgressFormat)
+	format, err = GetProgressFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, ProgressFormatJson, format)
+
+	testsutils.SetEnvAndAssert(t, coreutils.ProgressFormat, "xml")
+	_, err = GetProgressFormat()
+	assert.Error(t, err)
+}
+
+func TestJsonProgressEvents(t *testing.T) {
+	output := &bytes.Buffer{}
+	progressManager := newJsonProgressManager(output)
+	// Report every progress update
+	progressManager.interval = 0
+
+	progressManager.InitProgressReaders()
+	progressManager.IncGeneralProgressTotalBy(1)
+	progress := progressManager.NewProgressReader(7, "  Uploading ", "a/b/file.txt")
+	_, err := io.ReadAll(progress.ActionWithProgress(strings.NewReader("content")))
+	assert.NoError(t, err)
+	progressManager.RemoveProgress(progress.GetId())
+	progressManager.IncrementGeneralProgress()
+	assert.NoError(t, progressManager.Quit())
+
+	events := readProgressEvents(t, output)
+	var eventTypes []string
+	for _, event := range events {
+		eventTypes = append(eventTypes, event.Event)
+	}
+	assert.Equal(t, []string{progressEventTotals, progressEventStarted, progressEventProgress, progressEventCompleted, progressEventTotals, progressEventDone}, eventTypes)
+
+	started := events[1]
+	assert.Equal(t, 1, started.Id)
+	assert.Equal(t, "Uploading", started.Label)
+	assert.Equal(t, "a/b/file.txt", started.Path)
+	assert.Equal(t, int64(7), *started.TotalBytes)
+
+	completed := events[3]
+	assert.Equal(t, 1, completed.Id)
+	assert.Equal(t, int64(7), *completed.Bytes)
+	assert.NotNil(t, completed.SpeedBytesPerSec)
+	assert.NotNil(t, completed.DurationMs)
+
+	done := events[5]
+	assert.Equal(t, int64(1), *done.CompletedTasks)
+	assert.Equal(t, int64(1), *done.TotalTasks)
+}
+
+func TestJsonProgressMergingState(t *testing.T) {
+	output := &bytes.Buffer{}
+	progressManager := newJsonProgressManager(output)
+	progressManager.interval = 0
+
+	progress := progressManager.NewProgressReader(100, "Uploading", "file.zip")
+	progress.SetProgress(100)
+	merging := progressManager.SetMergingState(progress.GetId(), false)
+	// The merge percentage shouldn't be reported as transferred bytes
+	merging.SetProgress(50)
+	progressManager.RemoveProgress(merging.GetId())
+
+	events := readProgressEvents(t, output)
+	if assert.Len(t, events, 4) {
+		assert.Equal(t, progressEventMerging, events[2].Event)
+		assert.Equal(t, progressEventCompleted, events[3].Event)
+		assert.Equal(t, int64(100), *events[3].Bytes)
+	}
+}
+
+func readProgressEvents(t *testing.T, output io.Reader) (events []progressEvent) {
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		var event progressEvent
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		assert.NotEmpty(t, event.Time)
+		events = append(events, event)
+	}
+	assert.NoError(t, scanner.Err())
+	return
+}
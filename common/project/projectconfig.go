@@ -88,6 +88,12 @@ type Repository struct {
 	NugetV2          bool   `yaml:"nugetV2,omitempty"`
 	IncludePatterns  string `yaml:"includePatterns,omitempty"`
 	ExcludePatterns  string `yaml:"excludePatterns,omitempty"`
+	// The exclude patterns of the artifacts produced by Maven profiles, by the profile IDs. Applied when the profile is active.
+	ProfilesExcludePatterns map[string]string `yaml:"profilesExcludePatterns,omitempty"`
+	// Whether to apply the exclude patterns of common test and coverage profiles to the profiles which aren't mapped.
+	DefaultProfilesExcludePatterns bool `yaml:"defaultProfilesExcludePatterns,omitempty"`
+	// Additional repositories to which the deployed artifacts are copied after the deployment. Supported by Gradle.
+	MirrorRepos []string `yaml:"mirrorRepos,omitempty"`
 }
 
 type RepositoryConfig struct {
@@ -32,6 +32,7 @@ type builder struct {
 	validateSymlinks        bool
 	symlinks                bool
 	archive                 string
+	deterministic           bool
 	transitive              bool
 	targetPathInArchive     string
 	include                 []string
@@ -141,6 +142,11 @@ func (b *builder) Archive(archive string) *builder {
 	return b
 }
 
+func (b *builder) Deterministic(deterministic bool) *builder {
+	b.deterministic = deterministic
+	return b
+}
+
 func (b *builder) TargetPathInArchive(targetPathInArchive string) *builder {
 	b.targetPathInArchive = targetPathInArchive
 	return b
@@ -213,6 +219,7 @@ func (b *builder) BuildSpec() *SpecFiles {
 				BypassArchiveInspection: strconv.FormatBool(b.bypassArchiveInspection),
 				ArchiveEntries:          b.archiveEntries,
 				Archive:                 b.archive,
+				Deterministic:           strconv.FormatBool(b.deterministic),
 				TargetPathInArchive:     b.targetPathInArchive,
 				Recursive:               strconv.FormatBool(b.recursive),
 				Flat:                    strconv.FormatBool(b.flat),
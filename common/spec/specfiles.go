@@ -68,6 +68,7 @@ type File struct {
 	ArchiveEntries          string
 	ValidateSymlinks        string
 	Archive                 string
+	Deterministic           string
 	Symlinks                string
 	Transitive              string
 	TargetPathInArchive     string
@@ -122,6 +123,10 @@ func (f File) IsSymlinks(defaultValue bool) (bool, error) {
 	return clientutils.StringToBool(f.Symlinks, defaultValue)
 }
 
+func (f File) IsDeterministic(defaultValue bool) (bool, error) {
+	return clientutils.StringToBool(f.Deterministic, defaultValue)
+}
+
 func (f File) IsTransitive(defaultValue bool) (bool, error) {
 	return clientutils.StringToBool(f.Transitive, defaultValue)
 }
@@ -189,7 +194,8 @@ func ValidateSpec(files []File, isTargetMandatory, isSearchBasedSpec bool) error
 		isValidSortOrder := file.SortOrder == "asc" || file.SortOrder == "desc"
 		isExcludeProps := len(file.ExcludeProps) > 0
 		isArchive := len(file.Archive) > 0
-		isValidArchive := file.Archive == "zip"
+		isValidArchive := file.Archive == "zip" || file.Archive == "tar.gz"
+		isDeterministic, _ := file.IsDeterministic(false)
 		isSymlinks, _ := file.IsSymlinks(false)
 		isRegexp := file.Regexp == "true"
 		isAnt := file.Ant == "true"
@@ -266,7 +272,10 @@ func ValidateSpec(files []File, isTargetMandatory, isSearchBasedSpec bool) error
 			return errorutils.CheckErrorf("symlinks cannot be stored in an archive that will be exploded in artifactory.\\nWhen uploading a symlink to Artifactory, the symlink is represented in Artifactory as 0 size filewith properties describing the symlink.\\nThis symlink representation is not yet supported by Artifactory when exploding symlinks from a zip")
 		}
 		if isArchive && !isValidArchive {
-			return errorutils.CheckErrorf("the value of 'archive' (if provided) must be 'zip'")
+			return errorutils.CheckErrorf("the value of 'archive' (if provided) must be 'zip' or 'tar.gz'")
+		}
+		if isDeterministic && !isArchive {
+			return errorutils.CheckErrorf("spec cannot include 'deterministic' if 'archive' is not included")
 		}
 		if isGPGKey && !isBundle {
 			return errorutils.CheckErrorf("spec cannot include 'gpg-key' if 'bundle' is not included")
@@ -2,6 +2,9 @@ package common
 
 import (
 	"fmt"
+	"os"
+	"strings"
+
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/urfave/cli"
 )
@@ -25,3 +28,36 @@ func CreateBashCompletionFunc(extraCommands ...string) cli.BashCompleteFunc {
 		}
 	}
 }
+
+// Creates a bash completion function, which also suggests the values of the provided flags.
+// When the completed argument is the value of one of the provided flags, the values returned by its function are suggested instead of the flag names.
+func CreateBashCompletionFuncWithFlagValues(flagsValues map[string]func() ([]string, error), extraCommands ...string) cli.BashCompleteFunc {
+	flagNamesCompletion := CreateBashCompletionFunc(extraCommands...)
+	return func(ctx *cli.Context) {
+		if getValues, ok := flagsValues[getCompletedFlagName(os.Args)]; ok {
+			// Errors are ignored, since they can't be displayed while completing.
+			values, err := getValues()
+			if err != nil {
+				return
+			}
+			for _, value := range values {
+				fmt.Println(value)
+			}
+			return
+		}
+		flagNamesCompletion(ctx)
+	}
+}
+
+// Returns the name of the flag whose value is being completed, or an empty string if the completed argument isn't a flag value.
+// The shell invokes the completion with the arguments preceding the completed argument, followed by the completion flag.
+func getCompletedFlagName(args []string) string {
+	if len(args) < 2 || args[len(args)-1] != "--"+cli.BashCompletionFlag.GetName() {
+		return ""
+	}
+	previousArg := args[len(args)-2]
+	if !strings.HasPrefix(previousArg, "--") || strings.Contains(previousArg, "=") {
+		return ""
+	}
+	return strings.TrimPrefix(previousArg, "--")
+}
@@ -0,0 +1,258 @@
+package evidence
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/signing"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"net/http"
+	"path"
+	"time"
+)
+
+const (
+	evidenceApi           = "evidence/api/v1/subject/"
+	inTotoStatementType   = "https://in-toto.io/Statement/v1"
+	releaseBundlesV2Repo  = "release-bundles-v2"
+	releaseBundleManifest = "release-bundle.json.evd"
+)
+
+// EvidenceCreateCommand creates a signed evidence, such as a test attestation, an approval or a scan result, and attaches it to
+// an artifact, a build or a release bundle.
+// The evidence is an in-toto statement about the subject, signed with a local private key and wrapped in a DSSE envelope.
+type EvidenceCreateCommand struct {
+	serverDetails        *config.ServerDetails
+	predicatePath        string
+	predicateType        string
+	keyPath              string
+	keyId                string
+	subjectRepoPath      string
+	buildName            string
+	buildNumber          string
+	releaseBundleName    string
+	releaseBundleVersion string
+	project              string
+}
+
+type statement struct {
+	Type          string          `json:"_type"`
+	Subject       []subject       `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+	CreatedAt     string          `json:"createdAt"`
+	CreatedBy     string          `json:"createdBy,omitempty"`
+}
+
+type subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+func NewEvidenceCreateCommand() *EvidenceCreateCommand {
+	return &EvidenceCreateCommand{}
+}
+
+func (ecc *EvidenceCreateCommand) SetServerDetails(serverDetails *config.ServerDetails) *EvidenceCreateCommand {
+	ecc.serverDetails = serverDetails
+	return ecc
+}
+
+// SetPredicatePath sets the path to a JSON file, holding the content of the evidence.
+func (ecc *EvidenceCreateCommand) SetPredicatePath(predicatePath string) *EvidenceCreateCommand {
+	ecc.predicatePath = predicatePath
+	return ecc
+}
+
+// SetPredicateType sets the URI identifying the type of the evidence, for example: 'https://slsa.dev/provenance/v1'.
+func (ecc *EvidenceCreateCommand) SetPredicateType(predicateType string) *EvidenceCreateCommand {
+	ecc.predicateType = predicateType
+	return ecc
+}
+
+// SetKeyPath sets the path to the PEM private key used for signing the evidence.
+func (ecc *EvidenceCreateCommand) SetKeyPath(keyPath string) *EvidenceCreateCommand {
+	ecc.keyPath = keyPath
+	return ecc
+}
+
+// SetKeyId sets the ID of the signing key, used for finding the public key when verifying the evidence.
+func (ecc *EvidenceCreateCommand) SetKeyId(keyId string) *EvidenceCreateCommand {
+	ecc.keyId = keyId
+	return ecc
+}
+
+// SetSubjectRepoPath sets the path of the artifact to attach the evidence to, in the form of 'repo/path/to/file'.
+func (ecc *EvidenceCreateCommand) SetSubjectRepoPath(subjectRepoPath string) *EvidenceCreateCommand {
+	ecc.subjectRepoPath = subjectRepoPath
+	return ecc
+}
+
+func (ecc *EvidenceCreateCommand) SetBuild(buildName, buildNumber string) *EvidenceCreateCommand {
+	ecc.buildName = buildName
+	ecc.buildNumber = buildNumber
+	return ecc
+}
+
+func (ecc *EvidenceCreateCommand) SetReleaseBundle(releaseBundleName, releaseBundleVersion string) *EvidenceCreateCommand {
+	ecc.releaseBundleName = releaseBundleName
+	ecc.releaseBundleVersion = releaseBundleVersion
+	return ecc
+}
+
+// SetProject sets the project of the build or the release bundle.
+func (ecc *EvidenceCreateCommand) SetProject(project string) *EvidenceCreateCommand {
+	ecc.project = project
+	return ecc
+}
+
+func (ecc *EvidenceCreateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ecc.serverDetails, nil
+}
+
+func (ecc *EvidenceCreateCommand) CommandName() string {
+	return "create_evidence"
+}
+
+func (ecc *EvidenceCreateCommand) Run() error {
+	if err := ecc.validate(); err != nil {
+		return err
+	}
+	predicate, err := readPredicate(ecc.predicatePath)
+	if err != nil {
+		return err
+	}
+	privateKey, err := signing.LoadPrivateKey(ecc.keyPath)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(ecc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	subjectPath, subjectSha256, err := ecc.resolveSubject(servicesManager)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(statement{
+		Type:          inTotoStatementType,
+		Subject:       []subject{{Name: subjectPath, Digest: map[string]string{"sha256": subjectSha256}}},
+		PredicateType: ecc.predicateType,
+		Predicate:     predicate,
+		CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+		CreatedBy:     ecc.serverDetails.User,
+	})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	envelope, err := createEnvelope(payload, privateKey, ecc.keyId)
+	if err != nil {
+		return err
+	}
+	if err = ecc.uploadEvidence(servicesManager, subjectPath, envelope); err != nil {
+		return err
+	}
+	log.Info("The evidence was attached to", subjectPath)
+	return nil
+}
+
+func (ecc *EvidenceCreateCommand) validate() error {
+	if ecc.predicatePath == "" || ecc.predicateType == "" || ecc.keyPath == "" {
+		return errorutils.CheckErrorf("a predicate file, a predicate type and a private key are mandatory")
+	}
+	subjects := []bool{ecc.subjectRepoPath != "", ecc.buildName != "", ecc.releaseBundleName != ""}
+	if coreutils.SumTrueValues(subjects) != 1 {
+		return errorutils.CheckErrorf("exactly one evidence subject should be provided - an artifact, a build or a release bundle")
+	}
+	if ecc.buildName != "" && ecc.buildNumber == "" {
+		return errorutils.CheckErrorf("a build number is mandatory when attaching evidence to a build")
+	}
+	if ecc.releaseBundleName != "" && ecc.releaseBundleVersion == "" {
+		return errorutils.CheckErrorf("a release bundle version is mandatory when attaching evidence to a release bundle")
+	}
+	return nil
+}
+
+func readPredicate(predicatePath string) (json.RawMessage, error) {
+	content, err := fileutils.ReadFile(predicatePath)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(content) {
+		return nil, errorutils.CheckErrorf("the predicate file %s is not a valid JSON", predicatePath)
+	}
+	return content, nil
+}
+
+// Returns the path of the file representing the subject in Artifactory, and its SHA-256 checksum.
+// Builds are represented by their build-info JSON file, and release bundles by their manifest file.
+func (ecc *EvidenceCreateCommand) resolveSubject(servicesManager artifactory.ArtifactoryServicesManager) (subjectPath, sha256 string, err error) {
+	switch {
+	case ecc.buildName != "":
+		return ecc.resolveBuildSubject(servicesManager)
+	case ecc.releaseBundleName != "":
+		subjectPath = path.Join(getReleaseBundlesRepo(ecc.project), ecc.releaseBundleName, ecc.releaseBundleVersion, releaseBundleManifest)
+	default:
+		subjectPath = ecc.subjectRepoPath
+	}
+	fileInfo, err := servicesManager.FileInfo(subjectPath)
+	if err != nil {
+		return "", "", err
+	}
+	return subjectPath, fileInfo.Checksums.Sha256, nil
+}
+
+// The build-info JSON file is named after the build number and the build start time. The latest file of the build number is used.
+func (ecc *EvidenceCreateCommand) resolveBuildSubject(servicesManager artifactory.ArtifactoryServicesManager) (subjectPath, sha256 string, err error) {
+	buildInfoRepo := servicesUtils.GetBuildInfoRepositoryByProject(ecc.project)
+	pattern := path.Join(buildInfoRepo, ecc.buildName, ecc.buildNumber+"-*.json")
+	searchSpec := spec.NewBuilder().Pattern(pattern).SortBy([]string{"created"}).SortOrder("desc").Limit(1).BuildSpec()
+	searchResults, callbackFunc, err := utils.SearchFiles(servicesManager, searchSpec)
+	defer func() {
+		err = errors.Join(err, callbackFunc())
+	}()
+	if err != nil {
+		return
+	}
+	for _, reader := range searchResults {
+		resultItem := new(servicesUtils.ResultItem)
+		if reader.NextRecord(resultItem) == nil {
+			return resultItem.GetItemRelativePath(), resultItem.Sha256, nil
+		}
+		if err = reader.GetError(); err != nil {
+			return
+		}
+	}
+	err = errorutils.CheckErrorf("could not find the build-info of build %s/%s in the %s repository", ecc.buildName, ecc.buildNumber, buildInfoRepo)
+	return
+}
+
+func getReleaseBundlesRepo(project string) string {
+	if project == "" || project == "default" {
+		return releaseBundlesV2Repo
+	}
+	return project + "-" + releaseBundlesV2Repo
+}
+
+func (ecc *EvidenceCreateCommand) uploadEvidence(servicesManager artifactory.ArtifactoryServicesManager, subjectPath string, envelope *Envelope) error {
+	content, err := json.Marshal(envelope)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	httpDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	servicesUtils.SetContentType("application/json", &httpDetails.Headers)
+	evidenceUrl := clientUtils.AddTrailingSlashIfNeeded(ecc.serverDetails.Url) + evidenceApi + subjectPath
+	resp, body, err := servicesManager.Client().SendPost(evidenceUrl, content, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated)
+}
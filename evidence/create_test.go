@@ -0,0 +1,104 @@
+package evidence
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/signing"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateEnvelope(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	payload := []byte(`{"_type":"https://in-toto.io/Statement/v1"}`)
+	for _, privateKey := range []crypto.Signer{rsaKey, ecdsaKey, ed25519Key} {
+		envelope, err := createEnvelope(payload, privateKey, "my-key")
+		assert.NoError(t, err)
+		assert.Equal(t, inTotoPayloadType, envelope.PayloadType)
+		assert.Equal(t, base64.StdEncoding.EncodeToString(payload), envelope.Payload)
+		assert.Equal(t, "my-key", envelope.Signatures[0].KeyId)
+		signature, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+		assert.NoError(t, err)
+		assert.True(t, signing.Verify(preAuthEncoding(inTotoPayloadType, payload), signature, privateKey.Public()), "%T", privateKey)
+	}
+}
+
+func TestPreAuthEncoding(t *testing.T) {
+	assert.Equal(t, "DSSEv1 29 http://example.com/HelloWorld 11 hello world", string(preAuthEncoding("http://example.com/HelloWorld", []byte("hello world"))))
+}
+
+func TestCreateArtifactEvidence(t *testing.T) {
+	tempDir := t.TempDir()
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	keyBytes, err := x509.MarshalECPrivateKey(ecdsaKey)
+	assert.NoError(t, err)
+	keyPath := filepath.Join(tempDir, "key.pem")
+	assert.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+	predicatePath := filepath.Join(tempDir, "predicate.json")
+	assert.NoError(t, os.WriteFile(predicatePath, []byte(`{"tests":"passed"}`), 0600))
+
+	var envelope Envelope
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/storage/repo/file.zip":
+			_, err := w.Write([]byte(`{"repo":"repo","path":"/file.zip","checksums":{"sha256":"abc"}}`))
+			assert.NoError(t, err)
+		case "/evidence/api/v1/subject/repo/file.zip":
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(content, &envelope))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			assert.Fail(t, "unexpected request: "+r.URL.Path)
+		}
+	}))
+	defer testServer.Close()
+
+	createCmd := NewEvidenceCreateCommand().
+		SetServerDetails(&config.ServerDetails{Url: testServer.URL + "/", ArtifactoryUrl: testServer.URL + "/artifactory/"}).
+		SetPredicatePath(predicatePath).SetPredicateType("https://jfrog.com/evidence/test-results/v1").
+		SetKeyPath(keyPath).SetSubjectRepoPath("repo/file.zip")
+	assert.NoError(t, createCmd.Run())
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	assert.NoError(t, err)
+	var attachedStatement statement
+	assert.NoError(t, json.Unmarshal(payload, &attachedStatement))
+	assert.Equal(t, []subject{{Name: "repo/file.zip", Digest: map[string]string{"sha256": "abc"}}}, attachedStatement.Subject)
+	assert.JSONEq(t, `{"tests":"passed"}`, string(attachedStatement.Predicate))
+	signature, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+	assert.NoError(t, err)
+	assert.True(t, signing.Verify(preAuthEncoding(envelope.PayloadType, payload), signature, ecdsaKey.Public()))
+}
+
+func TestValidateEvidenceSubject(t *testing.T) {
+	createCmd := NewEvidenceCreateCommand().SetPredicatePath("p.json").SetPredicateType("type").SetKeyPath("key.pem")
+	assert.EqualError(t, createCmd.validate(), "exactly one evidence subject should be provided - an artifact, a build or a release bundle")
+	createCmd.SetSubjectRepoPath("repo/file").SetBuild("build", "1")
+	assert.Error(t, createCmd.validate())
+	createCmd.SetSubjectRepoPath("")
+	assert.NoError(t, createCmd.validate())
+	assert.Equal(t, "release-bundles-v2", getReleaseBundlesRepo(""))
+	assert.Equal(t, "proj-release-bundles-v2", getReleaseBundlesRepo("proj"))
+}
@@ -0,0 +1,41 @@
+package evidence
+
+import (
+	"crypto"
+	"encoding/base64"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/signing"
+)
+
+const inTotoPayloadType = "application/vnd.in-toto+json"
+
+// Envelope is a DSSE (Dead Simple Signing Envelope) holding a signed payload.
+// See https://github.com/secure-systems-lab/dsse/blob/master/envelope.md
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyId string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"`
+}
+
+// Returns the DSSE Pre-Authentication Encoding of the payload, which is the signed content.
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// Signs the payload and returns the DSSE envelope holding it.
+func createEnvelope(payload []byte, privateKey crypto.Signer, keyId string) (*Envelope, error) {
+	signature, err := signing.Sign(preAuthEncoding(inTotoPayloadType, payload), privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		PayloadType: inTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{{KeyId: keyId, Sig: base64.StdEncoding.EncodeToString(signature)}},
+	}, nil
+}
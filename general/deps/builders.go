@@ -0,0 +1,185 @@
+package deps
+
+import (
+	"path/filepath"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/build/utils/dotnet/solution"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils/pythonutils"
+	pluginsCommon "github.com/jfrog/jfrog-cli-core/v2/plugins/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	goutils "github.com/jfrog/jfrog-cli-core/v2/utils/golang"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+type graphBuilder func(workingDir string) (*dependencyGraph, error)
+
+var graphBuilders = map[coreutils.Technology]graphBuilder{
+	coreutils.Npm:    buildNpmGraph,
+	coreutils.Yarn:   buildYarnGraph,
+	coreutils.Maven:  buildMavenGraph,
+	coreutils.Gradle: buildGradleGraph,
+	coreutils.Go:     buildGoGraph,
+	coreutils.Pip:    createPythonGraphBuilder(pythonutils.Pip),
+	coreutils.Pipenv: createPythonGraphBuilder(pythonutils.Pipenv),
+	coreutils.Poetry: createPythonGraphBuilder(pythonutils.Poetry),
+	coreutils.Nuget:  buildNugetGraph,
+	coreutils.Dotnet: buildNugetGraph,
+}
+
+// Returns the dependency graph of the technology. Technologies registered by plugins are built using their integration.
+func buildDependencyGraph(technology coreutils.Technology, workingDir string) (*dependencyGraph, error) {
+	if builder, exists := graphBuilders[technology]; exists {
+		return builder(workingDir)
+	}
+	if integration := components.GetTechnology(technology.String()); integration != nil {
+		return buildPluginTechnologyGraph(integration, workingDir)
+	}
+	return nil, errorutils.CheckErrorf("the dependency tree of %s projects is not supported", technology)
+}
+
+// npm provides the requestedBy chains and scopes of the dependencies, and their checksums are calculated from the npm cache.
+func buildNpmGraph(workingDir string) (*dependencyGraph, error) {
+	npmVersion, executablePath, err := biutils.GetNpmVersionAndExecPath(log.Logger)
+	if err != nil {
+		return nil, err
+	}
+	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(workingDir, npmVersion)
+	if err != nil {
+		return nil, err
+	}
+	moduleId := packageInfo.BuildInfoModuleId()
+	dependencies, err := biutils.CalculateNpmDependenciesList(executablePath, workingDir, moduleId, biutils.NpmTreeDepListParam{Args: []string{}}, true, log.Logger)
+	if err != nil {
+		return nil, err
+	}
+	graph := newDependencyGraph()
+	graph.addBuildInfoModule(buildinfo.Module{Id: moduleId, Dependencies: dependencies})
+	return graph, nil
+}
+
+func buildYarnGraph(workingDir string) (*dependencyGraph, error) {
+	executablePath, err := biutils.GetYarnExecutable()
+	if err != nil {
+		return nil, err
+	}
+	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(workingDir, nil)
+	if err != nil {
+		return nil, err
+	}
+	dependenciesMap, root, err := biutils.GetYarnDependencies(executablePath, workingDir, packageInfo, log.Logger)
+	if err != nil {
+		return nil, err
+	}
+	getId := func(dependency *biutils.YarnDependency) string {
+		return strings.TrimPrefix(dependency.Name(), "@") + ":" + dependency.Details.Version
+	}
+	graph := newDependencyGraph()
+	rootId := packageInfo.BuildInfoModuleId()
+	graph.addRoot(rootId)
+	for _, dependency := range dependenciesMap {
+		parentId := getId(dependency)
+		if dependency == root {
+			parentId = rootId
+		}
+		for _, pointer := range dependency.Details.Dependencies {
+			if child, exists := dependenciesMap[biutils.GetYarnDependencyKeyFromLocator(pointer.Locator)]; exists {
+				graph.addEdge(parentId, getId(child))
+			}
+		}
+	}
+	return graph, nil
+}
+
+// Go modules are resolved using 'go mod graph', in which the root module has no version.
+func buildGoGraph(workingDir string) (*dependencyGraph, error) {
+	moduleName, err := goutils.GetModuleName(workingDir)
+	if err != nil {
+		return nil, err
+	}
+	dependenciesGraph, err := goutils.GetDependenciesGraph(workingDir)
+	if err != nil {
+		return nil, err
+	}
+	graph := newDependencyGraph()
+	graph.addRoot(moduleName)
+	for parent, children := range dependenciesGraph {
+		for _, child := range children {
+			graph.addEdge(parent, child)
+		}
+	}
+	return graph, nil
+}
+
+func createPythonGraphBuilder(tool pythonutils.PythonTool) graphBuilder {
+	return func(workingDir string) (*dependencyGraph, error) {
+		dependenciesGraph, directDependencies, err := pythonutils.GetPythonDependencies(tool, workingDir, "")
+		if err != nil {
+			return nil, err
+		}
+		rootId, err := pythonutils.GetPackageName(tool, workingDir)
+		if err != nil || rootId == "" {
+			// The package name is missing if the project has no setup.py or pyproject.toml.
+			rootId = filepath.Base(workingDir)
+		}
+		graph := newDependencyGraph()
+		graph.addRoot(rootId)
+		for _, dependency := range directDependencies {
+			graph.addEdge(rootId, dependency)
+		}
+		for parent, children := range dependenciesGraph {
+			for _, child := range children {
+				graph.addEdge(parent, child)
+			}
+		}
+		return graph, nil
+	}
+}
+
+// Each project of the solution is a module. The dependencies are read from the project.assets.json or packages.config files,
+// and therefore the project should be restored first.
+func buildNugetGraph(workingDir string) (*dependencyGraph, error) {
+	sol, err := solution.Load(workingDir, "", "", log.Logger)
+	if err != nil {
+		return nil, err
+	}
+	buildInfo, err := sol.BuildInfo("", log.Logger)
+	if err != nil {
+		return nil, err
+	}
+	graph := newDependencyGraph()
+	for _, module := range buildInfo.Modules {
+		graph.addBuildInfoModule(module)
+	}
+	return graph, nil
+}
+
+func buildPluginTechnologyGraph(integration components.TechnologyIntegration, workingDir string) (*dependencyGraph, error) {
+	ctx, err := pluginsCommon.CreateTechnologyContext(integration.GetName(), "", "", "")
+	if err != nil {
+		return nil, err
+	}
+	ctx.WorkingDir = workingDir
+	trees, err := integration.BuildDependencyTrees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	graph := newDependencyGraph()
+	var addNode func(node *xrayUtils.GraphNode)
+	addNode = func(node *xrayUtils.GraphNode) {
+		for _, child := range node.Nodes {
+			graph.addEdge(node.Id, child.Id)
+			addNode(child)
+		}
+	}
+	for _, tree := range trees {
+		graph.addRoot(tree.Id)
+		addNode(tree)
+	}
+	return graph, nil
+}
@@ -0,0 +1,99 @@
+package deps
+
+import (
+	"bufio"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const gradleDependencyIndent = 5
+
+var (
+	// For example: "compileClasspath - Compile classpath for source set 'main'."
+	gradleConfigurationRegExp = regexp.MustCompile(`^(\w+) - `)
+	// For example: "Root project 'my-app'"
+	gradleRootProjectRegExp = regexp.MustCompile(`^Root project '([^']+)'`)
+	// For example: "|    \--- org.acme:lib:1.0 -> 1.1 (*)"
+	gradleDependencyRegExp = regexp.MustCompile(`^([| ]*)[+\\]--- (.+)$`)
+)
+
+// Gradle dependencies are resolved using the 'dependencies' task of the root project.
+// The classpath configurations are used as the scopes of the dependencies.
+func buildGradleGraph(workingDir string) (*dependencyGraph, error) {
+	output, err := runBuildTool(workingDir, "gradle", getWrapperName("gradlew", ".bat"), []string{"dependencies", "-q", "--console=plain"})
+	if err != nil {
+		return nil, err
+	}
+	return parseGradleDependencies(output, filepath.Base(workingDir)), nil
+}
+
+// Parses the output of the 'dependencies' task. Each configuration is printed as a text tree, in which each level is indented by 5 characters.
+func parseGradleDependencies(output, defaultRootId string) *dependencyGraph {
+	graph := newDependencyGraph()
+	rootId := defaultRootId
+	configuration := ""
+	// The current path in the tree of the configuration, from the root project.
+	var path []string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := gradleRootProjectRegExp.FindStringSubmatch(line); match != nil {
+			rootId = match[1]
+			continue
+		}
+		if match := gradleConfigurationRegExp.FindStringSubmatch(line); match != nil {
+			configuration = match[1]
+			path = []string{rootId}
+			continue
+		}
+		match := gradleDependencyRegExp.FindStringSubmatch(line)
+		if match == nil || !strings.HasSuffix(strings.ToLower(configuration), "classpath") {
+			continue
+		}
+		depth := len(match[1])/gradleDependencyIndent + 1
+		id, resolved := getGradleId(match[2])
+		if depth > len(path) {
+			// Malformed indentation.
+			continue
+		}
+		path = path[:depth]
+		if !resolved {
+			// Constraints and unresolved dependencies have no children, but may be followed by siblings.
+			path = append(path, "")
+			continue
+		}
+		if parent := path[depth-1]; parent != "" {
+			graph.addRoot(rootId)
+			graph.addEdge(parent, id)
+			graph.addScopes(id, configuration)
+		}
+		path = append(path, id)
+	}
+	return graph
+}
+
+// Returns the build-info ID of a dependency line, for example 'org.acme:lib:1.1' for 'org.acme:lib:1.0 -> 1.1 (*)'.
+// Returns false for dependency constraints and for dependencies which weren't resolved.
+func getGradleId(dependency string) (string, bool) {
+	if strings.HasSuffix(dependency, " (c)") || strings.HasSuffix(dependency, " (n)") || strings.HasSuffix(dependency, " FAILED") {
+		return "", false
+	}
+	dependency = strings.TrimSuffix(dependency, " (*)")
+	coordinates, resolvedVersion, hasResolvedVersion := strings.Cut(dependency, " -> ")
+	if strings.HasPrefix(coordinates, "project ") {
+		return coordinates, true
+	}
+	if !hasResolvedVersion {
+		return coordinates, true
+	}
+	// The dependency may be substituted by another module or by a project.
+	if strings.HasPrefix(resolvedVersion, "project ") || strings.Contains(resolvedVersion, ":") {
+		return resolvedVersion, true
+	}
+	parts := strings.Split(coordinates, ":")
+	if len(parts) < 2 {
+		return coordinates, true
+	}
+	return parts[0] + ":" + parts[1] + ":" + resolvedVersion, true
+}
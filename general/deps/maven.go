@@ -0,0 +1,117 @@
+package deps
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Maven dependencies are resolved using the maven-dependency-plugin, which writes the graph of each module in the Trivial Graph Format.
+func buildMavenGraph(workingDir string) (graph *dependencyGraph, err error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	outputFile := filepath.Join(tempDir, "dependencies.tgf")
+	args := []string{"dependency:tree", "-B", "-q", "-DoutputType=tgf", "-DappendOutput=true", "-DoutputFile=" + outputFile}
+	if _, err = runBuildTool(workingDir, "mvn", getWrapperName("mvnw", ".cmd"), args); err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parseTgf(string(content)), nil
+}
+
+// Runs the build tool's wrapper if exists in the working directory, or the build tool from the PATH otherwise, and returns its output.
+func runBuildTool(workingDir, executable, wrapper string, args []string) (string, error) {
+	executablePath := executable
+	if wrapperPath := filepath.Join(workingDir, wrapper); fileutils.IsPathExists(wrapperPath, false) {
+		executablePath = wrapperPath
+	}
+	log.Debug("Running '" + executablePath + " " + strings.Join(args, " ") + "' in " + workingDir)
+	cmd := exec.Command(executablePath, args...)
+	cmd.Dir = workingDir
+	output, err := cmd.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return "", errorutils.CheckErrorf("'%s %s' failed: %s\n%s%s", executable, strings.Join(args, " "), err.Error(), output, exitError.Stderr)
+		}
+		return "", errorutils.CheckError(err)
+	}
+	return string(output), nil
+}
+
+func getWrapperName(wrapper, windowsExtension string) string {
+	if coreutils.IsWindows() {
+		return wrapper + windowsExtension
+	}
+	return wrapper
+}
+
+// Parses the Trivial Graph Format output of the maven-dependency-plugin.
+// The output of each module starts with its nodes, followed by a '#' line and its edges, labeled by the scope of the dependency:
+//
+//	1 org.acme:app:jar:1.0.0
+//	2 org.acme:lib:jar:2.0.0:compile
+//	#
+//	1 2 compile
+func parseTgf(content string) *dependencyGraph {
+	graph := newDependencyGraph()
+	var nodes map[string]string
+	readingEdges := true
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "#" {
+			readingEdges = true
+			continue
+		}
+		fields := strings.Fields(line)
+		if readingEdges && len(fields) == 2 {
+			// A node after the edges starts the graph of the next module, which is its first node.
+			readingEdges = false
+			nodes = map[string]string{}
+			nodes[fields[0]] = getMavenId(fields[1], false)
+			graph.addRoot(nodes[fields[0]])
+			continue
+		}
+		if !readingEdges && len(fields) == 2 {
+			nodes[fields[0]] = getMavenId(fields[1], true)
+			continue
+		}
+		if readingEdges && len(fields) == 3 {
+			parent, child := nodes[fields[0]], nodes[fields[1]]
+			graph.addEdge(parent, child)
+			graph.addScopes(child, fields[2])
+		}
+	}
+	return graph
+}
+
+// Converts Maven coordinates in the form of 'groupId:artifactId:type[:classifier]:version[:scope]' to the build-info ID 'groupId:artifactId:version'.
+func getMavenId(coordinates string, hasScope bool) string {
+	parts := strings.Split(coordinates, ":")
+	if hasScope {
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) < 4 {
+		return coordinates
+	}
+	return parts[0] + ":" + parts[1] + ":" + parts[len(parts)-1]
+}
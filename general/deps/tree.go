@@ -0,0 +1,297 @@
+package deps
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+)
+
+// DependencyNode is a dependency in the resolved dependency tree of a project.
+type DependencyNode struct {
+	Id     string   `json:"id"`
+	Scopes []string `json:"scopes,omitempty"`
+	Sha1   string   `json:"sha1,omitempty"`
+	Sha256 string   `json:"sha256,omitempty"`
+	Md5    string   `json:"md5,omitempty"`
+	// The chains of dependencies which lead to this dependency. Each chain starts with the direct parent, and ends with the root module.
+	RequestedBy [][]string `json:"requestedBy,omitempty"`
+	// True if the dependencies of this dependency were already listed earlier in the tree, and are therefore omitted.
+	Omitted      bool              `json:"omitted,omitempty"`
+	Dependencies []*DependencyNode `json:"dependencies,omitempty"`
+}
+
+// DependencyTree is the dependency tree of a single technology in the project.
+type DependencyTree struct {
+	Technology coreutils.Technology `json:"technology"`
+	Modules    []*DependencyNode    `json:"modules"`
+}
+
+// DepsTreeCommand prints the resolved dependency tree of the project, without scanning it.
+// The technologies of the project are detected from the descriptors in the working directory, unless set explicitly.
+type DepsTreeCommand struct {
+	workingDir   string
+	technologies []string
+	outputFormat format.OutputFormat
+	trees        []DependencyTree
+}
+
+func NewDepsTreeCommand() *DepsTreeCommand {
+	return &DepsTreeCommand{outputFormat: format.Tree}
+}
+
+func (dtc *DepsTreeCommand) SetWorkingDir(workingDir string) *DepsTreeCommand {
+	dtc.workingDir = workingDir
+	return dtc
+}
+
+// SetTechnologies sets the technologies to build the trees for. Technologies registered by plugins are supported as well.
+func (dtc *DepsTreeCommand) SetTechnologies(technologies []string) *DepsTreeCommand {
+	dtc.technologies = technologies
+	return dtc
+}
+
+// SetOutputFormat sets the output format - 'tree' or 'json'.
+func (dtc *DepsTreeCommand) SetOutputFormat(outputFormat format.OutputFormat) *DepsTreeCommand {
+	dtc.outputFormat = outputFormat
+	return dtc
+}
+
+func (dtc *DepsTreeCommand) Trees() []DependencyTree {
+	return dtc.trees
+}
+
+func (dtc *DepsTreeCommand) CommandName() string {
+	return "deps_tree"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (dtc *DepsTreeCommand) IsReadOnly() bool {
+	return true
+}
+
+func (dtc *DepsTreeCommand) Run() (err error) {
+	if dtc.outputFormat != format.Tree && dtc.outputFormat != format.Json {
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s", dtc.outputFormat, format.Tree, format.Json)
+	}
+	if dtc.workingDir == "" {
+		if dtc.workingDir, err = os.Getwd(); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	technologies := dtc.technologies
+	if len(technologies) == 0 {
+		if technologies, err = detectTechnologies(dtc.workingDir); err != nil {
+			return err
+		}
+	}
+	for _, technology := range technologies {
+		log.Info(fmt.Sprintf("Building the %s dependency tree...", technology))
+		modules, err := BuildDependencyTrees(coreutils.Technology(technology), dtc.workingDir)
+		if err != nil {
+			return err
+		}
+		dtc.trees = append(dtc.trees, DependencyTree{Technology: coreutils.Technology(technology), Modules: modules})
+	}
+	return dtc.printTrees()
+}
+
+// BuildDependencyTrees resolves the dependencies of the technology in the working directory using its package manager,
+// and returns the dependency tree of each of its root modules.
+func BuildDependencyTrees(technology coreutils.Technology, workingDir string) ([]*DependencyNode, error) {
+	graph, err := buildDependencyGraph(technology, workingDir)
+	if err != nil {
+		return nil, err
+	}
+	return graph.toTrees(), nil
+}
+
+func detectTechnologies(workingDir string) ([]string, error) {
+	detected, err := coreutils.DetectTechnologies(workingDir, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var technologies []string
+	for technology := range detected {
+		if _, supported := graphBuilders[technology]; supported {
+			technologies = append(technologies, technology.String())
+		}
+	}
+	if len(technologies) == 0 {
+		return nil, errorutils.CheckErrorf("no supported technology was detected in %s", workingDir)
+	}
+	sort.Strings(technologies)
+	return technologies, nil
+}
+
+func (dtc *DepsTreeCommand) printTrees() error {
+	if dtc.outputFormat == format.Json {
+		content, err := json.Marshal(dtc.trees)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	}
+	for _, tree := range dtc.trees {
+		log.Output(coreutils.PrintBoldTitle(tree.Technology.ToFormal()))
+		for _, module := range tree.Modules {
+			log.Output(strings.TrimSuffix(formatTree(module), "\n"))
+		}
+	}
+	return nil
+}
+
+// Returns the tree as text, with a line for each dependency. Dependencies with omitted children are marked with '(*)'.
+func formatTree(root *DependencyNode) string {
+	var builder strings.Builder
+	builder.WriteString(formatNode(root) + "\n")
+	var writeChildren func(node *DependencyNode, prefix string)
+	writeChildren = func(node *DependencyNode, prefix string) {
+		for i, child := range node.Dependencies {
+			connector, childPrefix := "├── ", "│   "
+			if i == len(node.Dependencies)-1 {
+				connector, childPrefix = "└── ", "    "
+			}
+			builder.WriteString(prefix + connector + formatNode(child) + "\n")
+			writeChildren(child, prefix+childPrefix)
+		}
+	}
+	writeChildren(root, "")
+	return builder.String()
+}
+
+func formatNode(node *DependencyNode) string {
+	line := node.Id
+	if len(node.Scopes) > 0 {
+		line += " [" + strings.Join(node.Scopes, ", ") + "]"
+	}
+	if node.Sha1 != "" {
+		line += " sha1:" + node.Sha1
+	}
+	if node.Omitted {
+		line += " (*)"
+	}
+	return line
+}
+
+// dependencyGraph is the resolved dependencies graph of a technology, before it is converted to trees.
+type dependencyGraph struct {
+	// The root modules of the project.
+	roots []string
+	// The direct dependencies of each module or dependency, in their resolution order.
+	children map[string][]string
+	// The scopes, checksums and requestedBy chains of the dependencies, if provided by the package manager.
+	details map[string]*buildinfo.Dependency
+}
+
+func newDependencyGraph() *dependencyGraph {
+	return &dependencyGraph{children: map[string][]string{}, details: map[string]*buildinfo.Dependency{}}
+}
+
+func (dg *dependencyGraph) addRoot(root string) {
+	if !slices.Contains(dg.roots, root) {
+		dg.roots = append(dg.roots, root)
+	}
+}
+
+func (dg *dependencyGraph) addEdge(parent, child string) {
+	if !slices.Contains(dg.children[parent], child) {
+		dg.children[parent] = append(dg.children[parent], child)
+	}
+}
+
+// Adds the scopes of a dependency, or creates its details if missing.
+func (dg *dependencyGraph) addScopes(id string, scopes ...string) {
+	details, exists := dg.details[id]
+	if !exists {
+		details = &buildinfo.Dependency{Id: id}
+		dg.details[id] = details
+	}
+	for _, scope := range scopes {
+		if scope != "" && !slices.Contains(details.Scopes, scope) {
+			details.Scopes = append(details.Scopes, scope)
+		}
+	}
+}
+
+// Adds a build-info module to the graph. The parent of each dependency is the first element of its requestedBy chains.
+// Dependencies without requestedBy chains are direct dependencies of the module.
+func (dg *dependencyGraph) addBuildInfoModule(module buildinfo.Module) {
+	dg.addRoot(module.Id)
+	for i := range module.Dependencies {
+		dependency := module.Dependencies[i]
+		dg.details[dependency.Id] = &dependency
+		if len(dependency.RequestedBy) == 0 {
+			dg.addEdge(module.Id, dependency.Id)
+		}
+		for _, chain := range dependency.RequestedBy {
+			if len(chain) > 0 {
+				dg.addEdge(chain[0], dependency.Id)
+			}
+		}
+	}
+}
+
+// Converts the graph to a tree for each root module.
+// Each dependency is expanded once. Its later occurrences are marked as omitted, to keep the size of the trees linear in the graph size.
+// The requestedBy chains are taken from the package manager if provided, and are otherwise collected while traversing the graph.
+func (dg *dependencyGraph) toTrees() []*DependencyNode {
+	expanded := map[string]bool{}
+	collectedChains := map[string][][]string{}
+	var createNode func(id string, path []string) *DependencyNode
+	createNode = func(id string, path []string) *DependencyNode {
+		node := &DependencyNode{Id: id}
+		if details, exists := dg.details[id]; exists {
+			node.Scopes, node.Sha1, node.Sha256, node.Md5 = details.Scopes, details.Sha1, details.Sha256, details.Md5
+			node.RequestedBy = details.RequestedBy
+		}
+		if len(path) > 0 {
+			chain := make([]string, len(path))
+			for i := range path {
+				chain[i] = path[len(path)-1-i]
+			}
+			collectedChains[id] = append(collectedChains[id], chain)
+		}
+		if expanded[id] {
+			node.Omitted = len(dg.children[id]) > 0
+			return node
+		}
+		expanded[id] = true
+		childPath := append(slices.Clone(path), id)
+		for _, child := range dg.children[id] {
+			// Protects against cycles in the graph.
+			if slices.Contains(childPath, child) {
+				continue
+			}
+			node.Dependencies = append(node.Dependencies, createNode(child, childPath))
+		}
+		return node
+	}
+	var trees []*DependencyNode
+	for _, root := range dg.roots {
+		trees = append(trees, createNode(root, nil))
+	}
+	var setChains func(node *DependencyNode)
+	setChains = func(node *DependencyNode) {
+		if node.RequestedBy == nil {
+			node.RequestedBy = collectedChains[node.Id]
+		}
+		for _, child := range node.Dependencies {
+			setChains(child)
+		}
+	}
+	for _, tree := range trees {
+		setChains(tree)
+	}
+	return trees
+}
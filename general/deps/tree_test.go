@@ -0,0 +1,102 @@
+package deps
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readTestData(t *testing.T, fileName string) string {
+	content, err := os.ReadFile(filepath.Join("testdata", fileName))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestParseTgf(t *testing.T) {
+	graph := parseTgf(readTestData(t, "dependencies.tgf"))
+	assert.Equal(t, []string{"org.acme:app:1.0.0", "org.acme:web:1.0.0"}, graph.roots)
+	assert.Equal(t, []string{"org.acme:lib:2.0.0", "junit:junit:4.13.2"}, graph.children["org.acme:app:1.0.0"])
+	assert.Equal(t, []string{"commons-io:commons-io:2.11.0"}, graph.children["org.acme:lib:2.0.0"])
+	assert.Equal(t, []string{"compile", "runtime"}, graph.details["org.acme:lib:2.0.0"].Scopes)
+	assert.Equal(t, []string{"test"}, graph.details["junit:junit:4.13.2"].Scopes)
+}
+
+func TestParseGradleDependencies(t *testing.T) {
+	graph := parseGradleDependencies(readTestData(t, "gradle-dependencies.txt"), "default")
+	assert.Equal(t, []string{"my-app"}, graph.roots)
+	assert.Equal(t, []string{"org.acme:lib:1.1", "project :core", "org.acme:other:2.0", "junit:junit:4.13.2"}, graph.children["my-app"])
+	assert.Equal(t, []string{"commons-io:commons-io:2.11.0"}, graph.children["org.acme:lib:1.1"])
+	assert.Equal(t, []string{"org.acme:lib:1.1"}, graph.children["org.acme:other:2.0"])
+	assert.Equal(t, []string{"org.hamcrest:hamcrest-core:1.3"}, graph.children["junit:junit:4.13.2"])
+	assert.Equal(t, []string{"compileClasspath", "runtimeClasspath"}, graph.details["org.acme:lib:1.1"].Scopes)
+	assert.NotContains(t, graph.details, "com.google.guava:guava:31.1-jre")
+}
+
+func TestGetGradleId(t *testing.T) {
+	tests := []struct {
+		dependency string
+		expectedId string
+		resolved   bool
+	}{
+		{"org.acme:lib:1.0", "org.acme:lib:1.0", true},
+		{"org.acme:lib:1.0 -> 1.1 (*)", "org.acme:lib:1.1", true},
+		{"org.acme:lib -> 1.1", "org.acme:lib:1.1", true},
+		{"org.acme:lib:1.0 -> org.acme:other:2.0", "org.acme:other:2.0", true},
+		{"org.acme:lib:1.0 -> project :lib", "project :lib", true},
+		{"project :core", "project :core", true},
+		{"org.acme:lib:1.0 (c)", "", false},
+		{"org.acme:missing:1.0 FAILED", "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.dependency, func(t *testing.T) {
+			id, resolved := getGradleId(test.dependency)
+			assert.Equal(t, test.expectedId, id)
+			assert.Equal(t, test.resolved, resolved)
+		})
+	}
+}
+
+func TestBuildInfoModuleToTree(t *testing.T) {
+	graph := newDependencyGraph()
+	graph.addBuildInfoModule(buildinfo.Module{Id: "app:1.0.0", Dependencies: []buildinfo.Dependency{
+		{Id: "a:1.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{"app:1.0.0"}}, Checksum: buildinfo.Checksum{Sha1: "sha1-a"}},
+		{Id: "b:2.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{"a:1.0", "app:1.0.0"}, {"c:3.0", "app:1.0.0"}}},
+		{Id: "c:3.0", Scopes: []string{"dev"}, RequestedBy: [][]string{{"app:1.0.0"}}},
+	}})
+	trees := graph.toTrees()
+	require.Len(t, trees, 1)
+	expected := "app:1.0.0\n" +
+		"├── a:1.0 [prod] sha1:sha1-a\n" +
+		"│   └── b:2.0 [prod]\n" +
+		"└── c:3.0 [dev]\n" +
+		"    └── b:2.0 [prod]\n"
+	assert.Equal(t, expected, formatTree(trees[0]))
+	b := trees[0].Dependencies[0].Dependencies[0]
+	assert.Equal(t, [][]string{{"a:1.0", "app:1.0.0"}, {"c:3.0", "app:1.0.0"}}, b.RequestedBy)
+	assert.Equal(t, "sha1-a", trees[0].Dependencies[0].Sha1)
+}
+
+func TestGraphToTreesOmitsRepeatedSubtrees(t *testing.T) {
+	graph := newDependencyGraph()
+	graph.addRoot("root")
+	graph.addEdge("root", "a")
+	graph.addEdge("root", "b")
+	graph.addEdge("a", "c")
+	graph.addEdge("b", "a")
+	// A cycle, which should be ignored.
+	graph.addEdge("c", "a")
+	trees := graph.toTrees()
+	require.Len(t, trees, 1)
+	expected := "root\n" +
+		"├── a\n" +
+		"│   └── c\n" +
+		"└── b\n" +
+		"    └── a (*)\n"
+	assert.Equal(t, expected, formatTree(trees[0]))
+	// The requestedBy chains are collected while traversing the graph.
+	assert.Equal(t, [][]string{{"root"}, {"b", "root"}}, trees[0].Dependencies[0].RequestedBy)
+}
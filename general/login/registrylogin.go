@@ -0,0 +1,208 @@
+package login
+
+import (
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/jfrog/build-info-go/build/utils/dotnet"
+	dotnetcmd "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/dotnet"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// RegistryClient is an external client whose credential store is configured by the registry login command.
+type RegistryClient string
+
+const (
+	Docker RegistryClient = "docker"
+	Helm   RegistryClient = "helm"
+	Nuget  RegistryClient = "nuget"
+)
+
+var registryClients = []RegistryClient{Docker, Helm, Nuget}
+
+// RegistryLoginCommand logs in the external client to an Artifactory repository with the credentials of the configured server,
+// so that the client can be used without JFrog CLI:
+// docker - the credentials are stored in the docker credential store (config.json) by 'docker login'.
+// helm - the credentials are stored in the helm registry configuration by 'helm registry login'.
+// nuget - the repository is added as an authenticated source to the user's NuGet configuration.
+type RegistryLoginCommand struct {
+	serverDetails *config.ServerDetails
+	client        RegistryClient
+	repo          string
+	// The registry domain of the docker and helm repositories. If empty, the host of the JFrog Platform URL is used.
+	// Should be set if Artifactory is accessed through a different domain, such as with the subdomain Docker access method.
+	registry string
+	// The NuGet source name. If empty, the repository name is used.
+	nugetSourceName string
+	nugetToolchain  dotnet.ToolchainType
+	useNugetV2      bool
+}
+
+func NewRegistryLoginCommand() *RegistryLoginCommand {
+	return &RegistryLoginCommand{nugetToolchain: dotnet.DotnetCore}
+}
+
+func (rlc *RegistryLoginCommand) SetServerDetails(serverDetails *config.ServerDetails) *RegistryLoginCommand {
+	rlc.serverDetails = serverDetails
+	return rlc
+}
+
+func (rlc *RegistryLoginCommand) SetClient(client RegistryClient) *RegistryLoginCommand {
+	rlc.client = client
+	return rlc
+}
+
+func (rlc *RegistryLoginCommand) SetRepo(repo string) *RegistryLoginCommand {
+	rlc.repo = repo
+	return rlc
+}
+
+func (rlc *RegistryLoginCommand) SetRegistry(registry string) *RegistryLoginCommand {
+	rlc.registry = registry
+	return rlc
+}
+
+func (rlc *RegistryLoginCommand) SetNugetSourceName(nugetSourceName string) *RegistryLoginCommand {
+	rlc.nugetSourceName = nugetSourceName
+	return rlc
+}
+
+func (rlc *RegistryLoginCommand) SetNugetToolchain(nugetToolchain dotnet.ToolchainType) *RegistryLoginCommand {
+	rlc.nugetToolchain = nugetToolchain
+	return rlc
+}
+
+func (rlc *RegistryLoginCommand) SetUseNugetV2(useNugetV2 bool) *RegistryLoginCommand {
+	rlc.useNugetV2 = useNugetV2
+	return rlc
+}
+
+func (rlc *RegistryLoginCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rlc.serverDetails, nil
+}
+
+func (rlc *RegistryLoginCommand) CommandName() string {
+	return "jf_login_" + string(rlc.client)
+}
+
+func (rlc *RegistryLoginCommand) Run() error {
+	if err := rlc.validate(); err != nil {
+		return err
+	}
+	username, password, err := getRegistryCredentials(rlc.serverDetails)
+	if err != nil {
+		return err
+	}
+	artDetails, err := rlc.serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return err
+	}
+	if err = rtUtils.ValidateRepoExists(rlc.repo, artDetails); err != nil {
+		return err
+	}
+
+	switch rlc.client {
+	case Docker:
+		err = rlc.dockerLogin()
+	case Helm:
+		err = rlc.helmLogin(username, password)
+	case Nuget:
+		err = rlc.nugetLogin()
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("Logged in " + string(rlc.client) + " to the '" + rlc.repo + "' repository.")
+	return nil
+}
+
+func (rlc *RegistryLoginCommand) validate() error {
+	if rlc.serverDetails == nil {
+		return errorutils.CheckErrorf("no server is configured")
+	}
+	if rlc.repo == "" {
+		return errorutils.CheckErrorf("the repository is mandatory for logging in %s", rlc.client)
+	}
+	for _, client := range registryClients {
+		if rlc.client == client {
+			return nil
+		}
+	}
+	return errorutils.CheckErrorf("unsupported client '%s'. The supported clients are: docker, helm and nuget", rlc.client)
+}
+
+func (rlc *RegistryLoginCommand) dockerLogin() error {
+	registry, err := rlc.getRegistry()
+	if err != nil {
+		return err
+	}
+	// The login is performed to the registry of an image in the repository, with a fallback to the registry domain without the repository.
+	image := container.NewImage(registry + "/" + rlc.repo)
+	return container.ContainerManagerLogin(image, &container.ContainerManagerLoginConfig{ServerDetails: rlc.serverDetails}, container.DockerClient)
+}
+
+func (rlc *RegistryLoginCommand) helmLogin(username, password string) error {
+	registry, err := rlc.getRegistry()
+	if err != nil {
+		return err
+	}
+	cmd := createHelmLoginCmd(registry, username)
+	// The password is passed through the standard input, so that it isn't exposed in the process list.
+	cmd.Stdin = strings.NewReader(password)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
+
+func createHelmLoginCmd(registry, username string) *exec.Cmd {
+	return exec.Command("helm", "registry", "login", registry, "--username", username, "--password-stdin")
+}
+
+func (rlc *RegistryLoginCommand) nugetLogin() error {
+	sourceName := rlc.nugetSourceName
+	if sourceName == "" {
+		sourceName = rlc.repo
+	}
+	return dotnetcmd.AddUserNugetSource(rlc.nugetToolchain, rlc.serverDetails, rlc.repo, sourceName, rlc.useNugetV2)
+}
+
+// Returns the registry domain of the docker and helm repositories.
+func (rlc *RegistryLoginCommand) getRegistry() (string, error) {
+	if rlc.registry != "" {
+		return rlc.registry, nil
+	}
+	platformUrl := rlc.serverDetails.Url
+	if platformUrl == "" {
+		platformUrl = rlc.serverDetails.ArtifactoryUrl
+	}
+	parsedUrl, err := url.Parse(platformUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if parsedUrl.Host == "" {
+		return "", errorutils.CheckErrorf("the registry domain couldn't be resolved from the server URL '%s'", platformUrl)
+	}
+	return parsedUrl.Host, nil
+}
+
+// Returns the credentials of the server for the registry clients. If an access token is configured, it's used as the password.
+func getRegistryCredentials(serverDetails *config.ServerDetails) (username, password string, err error) {
+	username, password = serverDetails.User, serverDetails.Password
+	if serverDetails.AccessToken != "" {
+		if username == "" {
+			username = auth.ExtractUsernameFromAccessToken(serverDetails.AccessToken)
+		}
+		password = serverDetails.AccessToken
+	}
+	if username == "" || password == "" {
+		return "", "", errorutils.CheckErrorf("the server '%s' has no credentials configured. Run 'jf login' or 'jf config add' to configure them", serverDetails.ServerId)
+	}
+	return
+}
@@ -0,0 +1,56 @@
+package login
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryLoginValidate(t *testing.T) {
+	serverDetails := &config.ServerDetails{Url: "https://acme.jfrog.io/"}
+	assert.NoError(t, NewRegistryLoginCommand().SetServerDetails(serverDetails).SetClient(Helm).SetRepo("helm-local").validate())
+	assert.ErrorContains(t, NewRegistryLoginCommand().SetServerDetails(serverDetails).SetClient(Docker).validate(), "repository is mandatory")
+	assert.ErrorContains(t, NewRegistryLoginCommand().SetServerDetails(serverDetails).SetClient("pip").SetRepo("pypi").validate(), "unsupported client")
+}
+
+func TestRegistryLoginGetRegistry(t *testing.T) {
+	testCases := []struct {
+		name          string
+		serverDetails *config.ServerDetails
+		registry      string
+		expected      string
+	}{
+		{"platform url", &config.ServerDetails{Url: "https://acme.jfrog.io/", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}, "", "acme.jfrog.io"},
+		{"artifactory url only", &config.ServerDetails{ArtifactoryUrl: "https://artifactory.acme.io:8443/artifactory/"}, "", "artifactory.acme.io:8443"},
+		{"custom registry", &config.ServerDetails{Url: "https://acme.jfrog.io/"}, "docker-local.acme.io", "docker-local.acme.io"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			registry, err := NewRegistryLoginCommand().SetServerDetails(testCase.serverDetails).SetRegistry(testCase.registry).getRegistry()
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, registry)
+		})
+	}
+}
+
+func TestGetRegistryCredentials(t *testing.T) {
+	username, password, err := getRegistryCredentials(&config.ServerDetails{User: "admin", Password: "password"})
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", username)
+	assert.Equal(t, "password", password)
+
+	// The access token is preferred over the password.
+	username, password, err = getRegistryCredentials(&config.ServerDetails{User: "admin", Password: "password", AccessToken: "token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", username)
+	assert.Equal(t, "token", password)
+
+	_, _, err = getRegistryCredentials(&config.ServerDetails{ServerId: "my-server"})
+	assert.ErrorContains(t, err, "'my-server' has no credentials")
+}
+
+func TestCreateHelmLoginCmd(t *testing.T) {
+	cmd := createHelmLoginCmd("acme.jfrog.io", "admin")
+	assert.Equal(t, []string{"helm", "registry", "login", "acme.jfrog.io", "--username", "admin", "--password-stdin"}, cmd.Args)
+}
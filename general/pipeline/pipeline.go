@@ -0,0 +1,316 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	corelog "github.com/jfrog/jfrog-cli-core/v2/utils/log"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// The version of the pipeline file schema.
+	pipelineSchemaVersion = 1
+
+	StepSucceeded StepStatus = "succeeded"
+	StepFailed    StepStatus = "failed"
+	StepSkipped   StepStatus = "skipped"
+)
+
+// Matches the ${name} variable references in the pipeline file.
+var varReferenceRegexp = regexp.MustCompile(`\$\{([^}]+)}`)
+
+// Pipeline is an ordered set of core commands, defined declaratively in a YAML file.
+type Pipeline struct {
+	Version int `yaml:"version"`
+	// The ID of the server the steps run against, unless a step sets its own server. If empty, the default server is used.
+	ServerId string `yaml:"server-id"`
+	// Variables which the steps reference with ${name}. The values may reference environment variables.
+	Vars  map[string]string `yaml:"vars"`
+	Steps []Step            `yaml:"steps"`
+}
+
+// Step is a single command of the pipeline. The options of the command are set in the 'with' section.
+type Step struct {
+	Name     string `yaml:"name"`
+	Command  string `yaml:"command"`
+	ServerId string `yaml:"server-id"`
+	// If true, the following steps run even if this step fails. The pipeline still fails.
+	ContinueOnError bool      `yaml:"continue-on-error"`
+	With            yaml.Node `yaml:"with"`
+	// The variables of the pipeline, for the spec files referenced by the step.
+	vars map[string]string
+}
+
+type StepStatus string
+
+// StepResult is the outcome of a step of the pipeline.
+type StepResult struct {
+	Name     string        `json:"name"`
+	Command  string        `json:"command"`
+	Status   StepStatus    `json:"status"`
+	Duration time.Duration `json:"duration"`
+	// The numbers of succeeded and failed files, for steps which transfer files.
+	Succeeded int    `json:"succeeded,omitempty"`
+	Failed    int    `json:"failed,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type stepRow struct {
+	Name      string `col-name:"Step"`
+	Command   string `col-name:"Command"`
+	Status    string `col-name:"Status"`
+	Duration  string `col-name:"Duration"`
+	Succeeded string `col-name:"Succeeded"`
+	Failed    string `col-name:"Failed"`
+	Error     string `col-name:"Error"`
+}
+
+// PipelineRunCommand runs the steps of a pipeline file in order, and prints a single summary of all the steps.
+// A failed step skips the steps which follow it, unless it is allowed to fail. The command fails if any of the steps failed.
+type PipelineRunCommand struct {
+	pipelineFile  string
+	vars          map[string]string
+	serverDetails *config.ServerDetails
+	outputFormat  format.OutputFormat
+	results       []StepResult
+}
+
+func NewPipelineRunCommand() *PipelineRunCommand {
+	return &PipelineRunCommand{outputFormat: format.Table}
+}
+
+func (prc *PipelineRunCommand) SetPipelineFile(pipelineFile string) *PipelineRunCommand {
+	prc.pipelineFile = pipelineFile
+	return prc
+}
+
+// SetVars sets variables which override the variables of the pipeline file.
+func (prc *PipelineRunCommand) SetVars(vars map[string]string) *PipelineRunCommand {
+	prc.vars = vars
+	return prc
+}
+
+// SetServerDetails sets the server the steps run against, if neither the pipeline nor the step set a server ID.
+func (prc *PipelineRunCommand) SetServerDetails(serverDetails *config.ServerDetails) *PipelineRunCommand {
+	prc.serverDetails = serverDetails
+	return prc
+}
+
+// SetOutputFormat sets the format of the summary - 'table' or 'json'.
+func (prc *PipelineRunCommand) SetOutputFormat(outputFormat format.OutputFormat) *PipelineRunCommand {
+	prc.outputFormat = outputFormat
+	return prc
+}
+
+// Results returns the outcome of each step of the pipeline.
+func (prc *PipelineRunCommand) Results() []StepResult {
+	return prc.results
+}
+
+// EnableDryRun implements commands.DryRunCommand.
+// The dry run mode is applied to each step when it runs, so a step whose command doesn't support it fails.
+func (prc *PipelineRunCommand) EnableDryRun() {}
+
+func (prc *PipelineRunCommand) ServerDetails() (*config.ServerDetails, error) {
+	return prc.serverDetails, nil
+}
+
+func (prc *PipelineRunCommand) CommandName() string {
+	return "pipeline_run"
+}
+
+func (prc *PipelineRunCommand) Run() error {
+	pipeline, err := ReadPipeline(prc.pipelineFile, prc.vars)
+	if err != nil {
+		return err
+	}
+	// All the steps are created before running the first one, so that an invalid step fails the pipeline before it changes anything.
+	stepCommands := make([]commands.Command, len(pipeline.Steps))
+	for i := range pipeline.Steps {
+		if stepCommands[i], err = prc.createStepCommand(pipeline, &pipeline.Steps[i]); err != nil {
+			return err
+		}
+	}
+	prc.results = nil
+	var failedSteps []string
+	stopped := false
+	for i, step := range pipeline.Steps {
+		result := StepResult{Name: step.Name, Command: step.Command, Status: StepSkipped}
+		if stopped || coreutils.CommandContext().Err() != nil {
+			log.Info(fmt.Sprintf("Skipping step %d/%d '%s'.", i+1, len(pipeline.Steps), step.Name))
+			prc.results = append(prc.results, result)
+			continue
+		}
+		log.Info(fmt.Sprintf("Running step %d/%d '%s' (%s)...", i+1, len(pipeline.Steps), step.Name, step.Command))
+		start := time.Now()
+		stepErr := commands.ExecWithContext(coreutils.CommandContext(), stepCommands[i])
+		corelog.SetCommandContext(prc.CommandName())
+		result.Duration = time.Since(start)
+		if resultCmd, ok := stepCommands[i].(commands.ResultCommand); ok && resultCmd.Result() != nil {
+			result.Succeeded = resultCmd.Result().SuccessCount()
+			result.Failed = resultCmd.Result().FailCount()
+		}
+		if stepErr == nil {
+			result.Status = StepSucceeded
+		} else {
+			result.Status = StepFailed
+			result.Error = stepErr.Error()
+			failedSteps = append(failedSteps, step.Name)
+			log.Error(fmt.Sprintf("Step '%s' failed: %s", step.Name, stepErr.Error()))
+			stopped = !step.ContinueOnError
+		}
+		prc.results = append(prc.results, result)
+	}
+	if err = prc.printSummary(); err != nil {
+		return err
+	}
+	if len(failedSteps) > 0 {
+		return errorutils.CheckErrorf("the pipeline failed. Failed steps: %s", strings.Join(failedSteps, ", "))
+	}
+	return nil
+}
+
+func (prc *PipelineRunCommand) createStepCommand(pipeline *Pipeline, step *Step) (commands.Command, error) {
+	builder, ok := stepBuilders[step.Command]
+	if !ok {
+		return nil, errorutils.CheckErrorf("step '%s': unsupported command '%s'. Possible values are: %s", step.Name, step.Command, strings.Join(supportedCommands(), ", "))
+	}
+	resolveServer := func() (*config.ServerDetails, error) {
+		serverId := step.ServerId
+		if serverId == "" {
+			serverId = pipeline.ServerId
+		}
+		if serverId == "" && prc.serverDetails != nil {
+			return prc.serverDetails, nil
+		}
+		return config.GetSpecificConfig(serverId, true, true)
+	}
+	command, err := builder(step, resolveServer)
+	if err != nil {
+		return nil, fmt.Errorf("step '%s': %w", step.Name, err)
+	}
+	return command, nil
+}
+
+func (prc *PipelineRunCommand) printSummary() error {
+	switch prc.outputFormat {
+	case format.Json:
+		content, err := json.Marshal(prc.results)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	case format.Table:
+		var rows []stepRow
+		for _, result := range prc.results {
+			row := stepRow{Name: result.Name, Command: result.Command, Status: string(result.Status), Error: result.Error}
+			if result.Status != StepSkipped {
+				row.Duration = result.Duration.Round(100 * time.Millisecond).String()
+				row.Succeeded = strconv.Itoa(result.Succeeded)
+				row.Failed = strconv.Itoa(result.Failed)
+			}
+			rows = append(rows, row)
+		}
+		return coreutils.PrintTable(rows, "Pipeline Summary", "The pipeline has no steps", false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s", prc.outputFormat, format.Table, format.Json)
+	}
+}
+
+// ReadPipeline reads a pipeline file, and replaces the variable references in the options of its steps.
+// The provided variables override the variables of the file.
+func ReadPipeline(pipelineFile string, vars map[string]string) (*Pipeline, error) {
+	content, err := os.ReadFile(pipelineFile)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	pipeline := new(Pipeline)
+	if err = yaml.Unmarshal(content, pipeline); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the pipeline file %s: %s", pipelineFile, err.Error())
+	}
+	if pipeline.Version != pipelineSchemaVersion {
+		return nil, errorutils.CheckErrorf("unsupported pipeline file version %d. The supported version is %d", pipeline.Version, pipelineSchemaVersion)
+	}
+	if len(pipeline.Steps) == 0 {
+		return nil, errorutils.CheckErrorf("the pipeline file %s has no steps", pipelineFile)
+	}
+	resolvedVars := make(map[string]string, len(pipeline.Vars)+len(vars))
+	for name, value := range pipeline.Vars {
+		// The variables of the file may reference environment variables only.
+		if resolvedVars[name], err = expandVars(value, nil); err != nil {
+			return nil, fmt.Errorf("variable '%s': %w", name, err)
+		}
+	}
+	for name, value := range vars {
+		resolvedVars[name] = value
+	}
+	pipeline.Vars = resolvedVars
+	if pipeline.ServerId, err = expandVars(pipeline.ServerId, resolvedVars); err != nil {
+		return nil, fmt.Errorf("server-id: %w", err)
+	}
+	for i := range pipeline.Steps {
+		step := &pipeline.Steps[i]
+		if step.Command == "" {
+			return nil, errorutils.CheckErrorf("step %d of the pipeline has no command", i+1)
+		}
+		if step.Name == "" {
+			step.Name = step.Command
+		}
+		step.vars = resolvedVars
+		if step.ServerId, err = expandVars(step.ServerId, resolvedVars); err != nil {
+			return nil, fmt.Errorf("step '%s': %w", step.Name, err)
+		}
+		if err = expandNodeVars(&step.With, resolvedVars); err != nil {
+			return nil, fmt.Errorf("step '%s': %w", step.Name, err)
+		}
+	}
+	return pipeline, nil
+}
+
+// Replaces the variable references in all the scalar values of the node.
+func expandNodeVars(node *yaml.Node, vars map[string]string) (err error) {
+	if node.Kind == yaml.ScalarNode {
+		node.Value, err = expandVars(node.Value, vars)
+		return
+	}
+	for _, child := range node.Content {
+		if err = expandNodeVars(child, vars); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Replaces the ${name} references with the values of the variables, or of the environment variables if no such variable is defined.
+func expandVars(value string, vars map[string]string) (string, error) {
+	var undefined []string
+	expanded := varReferenceRegexp.ReplaceAllStringFunc(value, func(reference string) string {
+		name := varReferenceRegexp.FindStringSubmatch(reference)[1]
+		if varValue, ok := vars[name]; ok {
+			return varValue
+		}
+		if envValue, ok := os.LookupEnv(name); ok {
+			return envValue
+		}
+		undefined = append(undefined, name)
+		return reference
+	})
+	if len(undefined) > 0 {
+		return "", errorutils.CheckErrorf("undefined variables: %s", strings.Join(undefined, ", "))
+	}
+	return expanded, nil
+}
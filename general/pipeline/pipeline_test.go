@@ -0,0 +1,169 @@
+package pipeline
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStepCommand struct {
+	result *utils.Result
+	run    func() error
+}
+
+func (c *testStepCommand) Run() error {
+	return c.run()
+}
+
+func (c *testStepCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (c *testStepCommand) CommandName() string {
+	return "test_step"
+}
+
+func (c *testStepCommand) Result() *utils.Result {
+	return c.result
+}
+
+func TestReadPipeline(t *testing.T) {
+	t.Setenv("PIPELINE_TEST_BUILD_NUMBER", "42")
+	pipeline, err := ReadPipeline(filepath.Join("testdata", "pipeline.yaml"), map[string]string{"repo": "libs-local"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-server", pipeline.ServerId)
+	assert.Equal(t, map[string]string{"server": "my-server", "build_name": "my-app", "build_number": "42", "repo": "libs-local"}, pipeline.Vars)
+	require.Len(t, pipeline.Steps, 4)
+	assert.Equal(t, []string{"validate", "upload-artifacts", "build-publish", "promote"},
+		[]string{pipeline.Steps[0].Name, pipeline.Steps[1].Name, pipeline.Steps[2].Name, pipeline.Steps[3].Name})
+	assert.Equal(t, "other-server", pipeline.Steps[1].ServerId)
+	assert.True(t, pipeline.Steps[2].ContinueOnError)
+
+	options := new(uploadOptions)
+	require.NoError(t, decodeOptions(&pipeline.Steps[1], options))
+	assert.Equal(t, "libs-local/my-app/42/", options.Target)
+	assert.Equal(t, "my-app", options.BuildName)
+	assert.Equal(t, "42", options.BuildNumber)
+}
+
+func TestReadPipelineInvalid(t *testing.T) {
+	testCases := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{"undefined variable", "version: 1\nsteps:\n  - command: upload\n    with:\n      target: ${pipeline_test_undefined}/\n", "undefined variables: pipeline_test_undefined"},
+		{"unsupported version", "version: 2\nsteps:\n  - command: upload\n", "unsupported pipeline file version 2"},
+		{"no steps", "version: 1\n", "has no steps"},
+		{"no command", "version: 1\nsteps:\n  - name: nothing\n", "step 1 of the pipeline has no command"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pipelineFile := filepath.Join(t.TempDir(), "pipeline.yaml")
+			require.NoError(t, os.WriteFile(pipelineFile, []byte(testCase.content), 0644))
+			_, err := ReadPipeline(pipelineFile, nil)
+			assert.ErrorContains(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestCreateStepCommands(t *testing.T) {
+	t.Setenv("PIPELINE_TEST_BUILD_NUMBER", "42")
+	pipeline, err := ReadPipeline(filepath.Join("testdata", "pipeline.yaml"), nil)
+	require.NoError(t, err)
+	serverDetails := &config.ServerDetails{ServerId: "other-server", ArtifactoryUrl: "http://localhost:8081/artifactory/"}
+	resolveServer := func() (*config.ServerDetails, error) { return serverDetails, nil }
+
+	command, err := newUploadStep(&pipeline.Steps[1], resolveServer)
+	require.NoError(t, err)
+	uploadCmd, ok := command.(*generic.UploadCommand)
+	require.True(t, ok)
+	assert.Equal(t, "dist/*.tgz", uploadCmd.Spec().Files[0].Pattern)
+	assert.Equal(t, "generic-local/my-app/42/", uploadCmd.Spec().Files[0].Target)
+
+	command, err = newPromoteStep(&pipeline.Steps[3], resolveServer)
+	require.NoError(t, err)
+	promoteCmd, ok := command.(*buildinfo.BuildPromotionCommand)
+	require.True(t, ok)
+	assert.Equal(t, "generic-local-release", promoteCmd.TargetRepo)
+	assert.Equal(t, "released", promoteCmd.Status)
+	assert.True(t, promoteCmd.FailFast)
+
+	// The promotion requires a target repository.
+	_, err = newPromoteStep(&Step{Name: "promote", Command: "promote"}, resolveServer)
+	assert.Error(t, err)
+
+	// The npm install resolves from the server of the npm configuration, so it rejects another server.
+	_, err = newNpmInstallStep(&Step{Name: "install", Command: "npm-install", ServerId: "other-server"}, resolveServer)
+	assert.ErrorContains(t, err, "the server-id option isn't supported by the npm-install step")
+}
+
+func TestRunPipeline(t *testing.T) {
+	testCases := []struct {
+		name             string
+		continueOnError  bool
+		expectedRun      []string
+		expectedStatuses []StepStatus
+	}{
+		{"stop on failure", false, []string{"first", "second"}, []StepStatus{StepSucceeded, StepFailed, StepSkipped}},
+		{"continue on error", true, []string{"first", "second", "third"}, []StepStatus{StepSucceeded, StepFailed, StepSucceeded}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var run []string
+			originalBuilders := stepBuilders
+			defer func() { stepBuilders = originalBuilders }()
+			stepBuilders = map[string]stepBuilder{
+				"test": func(step *Step, _ func() (*config.ServerDetails, error)) (commands.Command, error) {
+					result := new(utils.Result)
+					return &testStepCommand{result: result, run: func() error {
+						run = append(run, step.Name)
+						if step.Name == "second" {
+							result.SetFailCount(1)
+							return errors.New("second failed")
+						}
+						result.SetSuccessCount(2)
+						return nil
+					}}, nil
+				},
+			}
+			content := "version: 1\nsteps:\n  - name: first\n    command: test\n  - name: second\n    command: test\n"
+			if testCase.continueOnError {
+				content += "    continue-on-error: true\n"
+			}
+			content += "  - name: third\n    command: test\n"
+			pipelineFile := filepath.Join(t.TempDir(), "pipeline.yaml")
+			require.NoError(t, os.WriteFile(pipelineFile, []byte(content), 0644))
+
+			pipelineCmd := NewPipelineRunCommand().SetPipelineFile(pipelineFile).SetServerDetails(&config.ServerDetails{})
+			err := pipelineCmd.Run()
+			assert.ErrorContains(t, err, "Failed steps: second")
+			assert.Equal(t, testCase.expectedRun, run)
+			results := pipelineCmd.Results()
+			require.Len(t, results, 3)
+			for i, expectedStatus := range testCase.expectedStatuses {
+				assert.Equal(t, expectedStatus, results[i].Status, results[i].Name)
+			}
+			assert.Equal(t, 2, results[0].Succeeded)
+			assert.Equal(t, 1, results[1].Failed)
+			assert.Equal(t, "second failed", results[1].Error)
+		})
+	}
+}
+
+func TestRunPipelineUnsupportedCommand(t *testing.T) {
+	pipelineFile := filepath.Join(t.TempDir(), "pipeline.yaml")
+	require.NoError(t, os.WriteFile(pipelineFile, []byte("version: 1\nsteps:\n  - command: deploy-everything\n"), 0644))
+	err := NewPipelineRunCommand().SetPipelineFile(pipelineFile).Run()
+	assert.ErrorContains(t, err, "unsupported command 'deploy-everything'")
+}
@@ -0,0 +1,319 @@
+package pipeline
+
+import (
+	"sort"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/npm"
+	rtutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit"
+	biconf "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	defaultUploadThreads = 3
+	// The environment variables excluded from the published build-info by default, as in 'jf rt build-publish'.
+	defaultEnvExclude = "*password*;*psw*;*secret*;*key*;*token*;*auth*"
+)
+
+// stepBuilder creates the command of a step from its options. resolveServer returns the server the step runs against.
+type stepBuilder func(step *Step, resolveServer func() (*config.ServerDetails, error)) (commands.Command, error)
+
+var stepBuilders = map[string]stepBuilder{
+	"config-check":  newConfigCheckStep,
+	"npm-install":   newNpmInstallStep,
+	"audit":         newAuditStep,
+	"upload":        newUploadStep,
+	"build-publish": newBuildPublishStep,
+	"promote":       newPromoteStep,
+}
+
+func supportedCommands() []string {
+	var names []string
+	for name := range stepBuilders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// The build options shared by the steps which collect or publish build-info.
+type buildOptions struct {
+	BuildName   string `yaml:"build-name"`
+	BuildNumber string `yaml:"build-number"`
+	Module      string `yaml:"module"`
+	Project     string `yaml:"project"`
+}
+
+func (options *buildOptions) buildConfiguration() *build.BuildConfiguration {
+	return build.NewBuildConfiguration(options.BuildName, options.BuildNumber, options.Module, options.Project)
+}
+
+func (options *buildOptions) validateBuild() error {
+	if options.BuildName == "" || options.BuildNumber == "" {
+		return errorutils.CheckErrorf("the build-name and build-number options are mandatory")
+	}
+	return nil
+}
+
+// Decodes the 'with' section of the step into the options of its command.
+func decodeOptions(step *Step, options any) error {
+	if step.With.IsZero() {
+		return nil
+	}
+	if err := step.With.Decode(options); err != nil {
+		return errorutils.CheckErrorf("invalid options: %s", err.Error())
+	}
+	return nil
+}
+
+type configCheckOptions struct {
+	Technology string `yaml:"technology"`
+	ConfigPath string `yaml:"config-path"`
+}
+
+func newConfigCheckStep(step *Step, _ func() (*config.ServerDetails, error)) (commands.Command, error) {
+	options := new(configCheckOptions)
+	if err := decodeOptions(step, options); err != nil {
+		return nil, err
+	}
+	projectType, err := parseProjectType(options.Technology)
+	if err != nil {
+		return nil, err
+	}
+	return commands.NewConfigFileValidateCommand(projectType).SetConfigFilePath(options.ConfigPath), nil
+}
+
+type npmInstallOptions struct {
+	buildOptions `yaml:",inline"`
+	Args         []string `yaml:"args"`
+	ConfigPath   string   `yaml:"config-path"`
+}
+
+func newNpmInstallStep(step *Step, _ func() (*config.ServerDetails, error)) (commands.Command, error) {
+	if step.ServerId != "" {
+		// The npm command resolves the dependencies from the server of its configuration.
+		return nil, errorutils.CheckErrorf("the server-id option isn't supported by the npm-install step. The server is set by the npm configuration")
+	}
+	options := new(npmInstallOptions)
+	if err := decodeOptions(step, options); err != nil {
+		return nil, err
+	}
+	configPath := options.ConfigPath
+	if configPath == "" {
+		var exists bool
+		var err error
+		if configPath, exists, err = project.GetProjectConfFilePath(project.Npm); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errorutils.CheckErrorf("the npm configuration doesn't exist. Run 'jf npm-config' to create it")
+		}
+	}
+	// The build options are passed as flags, since the npm command reads them from its arguments.
+	args := append([]string(nil), options.Args...)
+	for _, flag := range [][2]string{{"build-name", options.BuildName}, {"build-number", options.BuildNumber}, {"module", options.Module}, {"project", options.Project}} {
+		if flag[1] != "" {
+			args = append(args, "--"+flag[0]+"="+flag[1])
+		}
+	}
+	npmCmd := npm.NewNpmInstallCommand().SetConfigFilePath(configPath).SetArgs(args)
+	if err := npmCmd.Init(); err != nil {
+		return nil, err
+	}
+	return npmCmd, nil
+}
+
+type auditOptions struct {
+	buildOptions          `yaml:",inline"`
+	WorkingDir            string   `yaml:"working-dir"`
+	Technologies          []string `yaml:"technologies"`
+	Production            bool     `yaml:"production"`
+	LockfileOnly          bool     `yaml:"lockfile-only"`
+	FailOnVulnerabilities bool     `yaml:"fail-on-vulnerabilities"`
+	UploadResults         string   `yaml:"upload-results"`
+}
+
+func newAuditStep(step *Step, resolveServer func() (*config.ServerDetails, error)) (commands.Command, error) {
+	options := new(auditOptions)
+	if err := decodeOptions(step, options); err != nil {
+		return nil, err
+	}
+	var technologies []project.ProjectType
+	for _, technology := range options.Technologies {
+		projectType, err := parseProjectType(technology)
+		if err != nil {
+			return nil, err
+		}
+		technologies = append(technologies, projectType)
+	}
+	serverDetails, err := resolveServer()
+	if err != nil {
+		return nil, err
+	}
+	auditCmd := audit.NewAuditCommand().
+		SetServerDetails(serverDetails).
+		SetWorkingDirectory(options.WorkingDir).
+		SetTechnologies(technologies...).
+		SetProduction(options.Production).
+		SetLockfileOnly(options.LockfileOnly).
+		SetFailOnVulnerabilities(options.FailOnVulnerabilities).
+		SetUploadResults(options.UploadResults)
+	if options.BuildName != "" {
+		auditCmd.SetBuildConfiguration(options.buildConfiguration())
+	}
+	return auditCmd, nil
+}
+
+type uploadOptions struct {
+	buildOptions `yaml:",inline"`
+	Spec         string   `yaml:"spec"`
+	Pattern      string   `yaml:"pattern"`
+	Target       string   `yaml:"target"`
+	Props        string   `yaml:"props"`
+	Exclusions   []string `yaml:"exclusions"`
+	Flat         bool     `yaml:"flat"`
+	Recursive    *bool    `yaml:"recursive"`
+	Threads      int      `yaml:"threads"`
+	DryRun       bool     `yaml:"dry-run"`
+}
+
+func (options *uploadOptions) uploadSpec(vars map[string]string) (*spec.SpecFiles, error) {
+	if options.Spec != "" {
+		if options.Pattern != "" || options.Target != "" {
+			return nil, errorutils.CheckErrorf("the spec option can't be used together with the pattern and target options")
+		}
+		return spec.CreateSpecFromFile(options.Spec, vars)
+	}
+	if options.Pattern == "" || options.Target == "" {
+		return nil, errorutils.CheckErrorf("either the spec option, or the pattern and target options, are mandatory")
+	}
+	recursive := options.Recursive == nil || *options.Recursive
+	return spec.NewBuilder().
+		Pattern(options.Pattern).
+		Target(options.Target).
+		TargetProps(options.Props).
+		Exclusions(options.Exclusions).
+		Flat(options.Flat).
+		Recursive(recursive).
+		BuildSpec(), nil
+}
+
+func newUploadStep(step *Step, resolveServer func() (*config.ServerDetails, error)) (commands.Command, error) {
+	options := new(uploadOptions)
+	if err := decodeOptions(step, options); err != nil {
+		return nil, err
+	}
+	uploadSpec, err := options.uploadSpec(step.vars)
+	if err != nil {
+		return nil, err
+	}
+	if err = spec.ValidateSpec(uploadSpec.Files, true, false); err != nil {
+		return nil, err
+	}
+	serverDetails, err := resolveServer()
+	if err != nil {
+		return nil, err
+	}
+	threads := options.Threads
+	if threads <= 0 {
+		threads = defaultUploadThreads
+	}
+	uploadCmd := generic.NewUploadCommand()
+	uploadCmd.SetUploadConfiguration(&rtutils.UploadConfiguration{Threads: threads})
+	if options.BuildName != "" {
+		uploadCmd.SetBuildConfiguration(options.buildConfiguration())
+	}
+	uploadCmd.SetServerDetails(serverDetails).SetSpec(uploadSpec).SetDryRun(options.DryRun)
+	return uploadCmd, nil
+}
+
+type buildPublishOptions struct {
+	buildOptions `yaml:",inline"`
+	BuildUrl     string `yaml:"build-url"`
+	EnvInclude   string `yaml:"env-include"`
+	EnvExclude   string `yaml:"env-exclude"`
+	DryRun       bool   `yaml:"dry-run"`
+}
+
+func newBuildPublishStep(step *Step, resolveServer func() (*config.ServerDetails, error)) (commands.Command, error) {
+	options := &buildPublishOptions{EnvExclude: defaultEnvExclude}
+	if err := decodeOptions(step, options); err != nil {
+		return nil, err
+	}
+	if err := options.validateBuild(); err != nil {
+		return nil, err
+	}
+	serverDetails, err := resolveServer()
+	if err != nil {
+		return nil, err
+	}
+	return buildinfo.NewBuildPublishCommand().
+		SetServerDetails(serverDetails).
+		SetBuildConfiguration(options.buildConfiguration()).
+		SetConfig(&biconf.Configuration{BuildUrl: options.BuildUrl, DryRun: options.DryRun, EnvInclude: options.EnvInclude, EnvExclude: options.EnvExclude}), nil
+}
+
+type promoteOptions struct {
+	buildOptions        `yaml:",inline"`
+	TargetRepo          string `yaml:"target-repo"`
+	SourceRepo          string `yaml:"source-repo"`
+	Status              string `yaml:"status"`
+	Comment             string `yaml:"comment"`
+	Props               string `yaml:"props"`
+	Copy                bool   `yaml:"copy"`
+	IncludeDependencies bool   `yaml:"include-dependencies"`
+	FailFast            *bool  `yaml:"fail-fast"`
+	DryRun              bool   `yaml:"dry-run"`
+}
+
+func newPromoteStep(step *Step, resolveServer func() (*config.ServerDetails, error)) (commands.Command, error) {
+	options := new(promoteOptions)
+	if err := decodeOptions(step, options); err != nil {
+		return nil, err
+	}
+	if err := options.validateBuild(); err != nil {
+		return nil, err
+	}
+	if options.TargetRepo == "" {
+		return nil, errorutils.CheckErrorf("the target-repo option is mandatory")
+	}
+	serverDetails, err := resolveServer()
+	if err != nil {
+		return nil, err
+	}
+	return buildinfo.NewBuildPromotionCommand().
+		SetServerDetails(serverDetails).
+		SetBuildConfiguration(options.buildConfiguration()).
+		SetDryRun(options.DryRun).
+		SetPromotionParams(services.PromotionParams{
+			BuildName:           options.BuildName,
+			BuildNumber:         options.BuildNumber,
+			ProjectKey:          options.Project,
+			TargetRepo:          options.TargetRepo,
+			SourceRepo:          options.SourceRepo,
+			Status:              options.Status,
+			Comment:             options.Comment,
+			Properties:          options.Props,
+			Copy:                options.Copy,
+			IncludeDependencies: options.IncludeDependencies,
+			FailFast:            options.FailFast == nil || *options.FailFast,
+		}), nil
+}
+
+func parseProjectType(technology string) (project.ProjectType, error) {
+	for i, projectType := range project.ProjectTypes {
+		if projectType == technology {
+			return project.ProjectType(i), nil
+		}
+	}
+	return 0, errorutils.CheckErrorf("unsupported technology '%s'", technology)
+}
@@ -0,0 +1,80 @@
+package project
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// ProjectAssignRepoCommand assigns repositories to a project, provided by its key or by a project template.
+type ProjectAssignRepoCommand struct {
+	ProjectCommand
+	projectKey string
+	repos      []string
+	force      bool
+}
+
+func NewProjectAssignRepoCommand() *ProjectAssignRepoCommand {
+	return &ProjectAssignRepoCommand{}
+}
+
+func (parc *ProjectAssignRepoCommand) SetProjectKey(projectKey string) *ProjectAssignRepoCommand {
+	parc.projectKey = projectKey
+	return parc
+}
+
+// SetRepos sets the repositories to assign. If a template is provided, its repositories are assigned as well.
+func (parc *ProjectAssignRepoCommand) SetRepos(repos []string) *ProjectAssignRepoCommand {
+	parc.repos = repos
+	return parc
+}
+
+func (parc *ProjectAssignRepoCommand) SetTemplatePath(path string) *ProjectAssignRepoCommand {
+	parc.templatePath = path
+	return parc
+}
+
+func (parc *ProjectAssignRepoCommand) SetVars(vars string) *ProjectAssignRepoCommand {
+	parc.vars = vars
+	return parc
+}
+
+// SetForce allows moving repositories from the projects they're currently assigned to.
+func (parc *ProjectAssignRepoCommand) SetForce(force bool) *ProjectAssignRepoCommand {
+	parc.force = force
+	return parc
+}
+
+func (parc *ProjectAssignRepoCommand) SetServerDetails(serverDetails *config.ServerDetails) *ProjectAssignRepoCommand {
+	parc.serverDetails = serverDetails
+	return parc
+}
+
+func (parc *ProjectAssignRepoCommand) ServerDetails() (*config.ServerDetails, error) {
+	return parc.serverDetails, nil
+}
+
+func (parc *ProjectAssignRepoCommand) CommandName() string {
+	return "project_assign_repo"
+}
+
+func (parc *ProjectAssignRepoCommand) Run() error {
+	repos := parc.repos
+	if parc.templatePath != "" {
+		template, err := ReadProjectTemplate(parc.templatePath, parc.vars)
+		if err != nil {
+			return err
+		}
+		if parc.projectKey == "" {
+			parc.projectKey = template.ProjectKey
+		}
+		repos = append(repos, template.Repositories...)
+	}
+	if parc.projectKey == "" || len(repos) == 0 {
+		return errorutils.CheckErrorf("a project key and at least one repository are mandatory")
+	}
+	service, err := newProjectService(parc.serverDetails)
+	if err != nil {
+		return err
+	}
+	return service.assignRepos(parc.projectKey, repos, parc.force)
+}
@@ -0,0 +1,58 @@
+package project
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ProjectCreateCommand creates a project from a project template, with its roles, members and assigned repositories.
+type ProjectCreateCommand struct {
+	ProjectCommand
+}
+
+func NewProjectCreateCommand() *ProjectCreateCommand {
+	return &ProjectCreateCommand{}
+}
+
+func (pcc *ProjectCreateCommand) SetTemplatePath(path string) *ProjectCreateCommand {
+	pcc.templatePath = path
+	return pcc
+}
+
+func (pcc *ProjectCreateCommand) SetVars(vars string) *ProjectCreateCommand {
+	pcc.vars = vars
+	return pcc
+}
+
+func (pcc *ProjectCreateCommand) SetServerDetails(serverDetails *config.ServerDetails) *ProjectCreateCommand {
+	pcc.serverDetails = serverDetails
+	return pcc
+}
+
+func (pcc *ProjectCreateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pcc.serverDetails, nil
+}
+
+func (pcc *ProjectCreateCommand) CommandName() string {
+	return "project_create"
+}
+
+func (pcc *ProjectCreateCommand) Run() error {
+	template, err := ReadProjectTemplate(pcc.templatePath, pcc.vars)
+	if err != nil {
+		return err
+	}
+	service, err := newProjectService(pcc.serverDetails)
+	if err != nil {
+		return err
+	}
+	log.Info("Creating project '" + template.ProjectKey + "'...")
+	if err = service.accessManager.CreateProject(template.toProjectParams()); err != nil {
+		return err
+	}
+	if err = service.applyTemplate(template, false); err != nil {
+		return err
+	}
+	log.Info("Project '" + template.ProjectKey + "' was created successfully.")
+	return nil
+}
@@ -0,0 +1,83 @@
+package project
+
+import (
+	"fmt"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ProjectDeleteCommand deletes a project, provided by its key or by a project template.
+// When a template is provided, its repositories are unassigned from the project before it is deleted.
+type ProjectDeleteCommand struct {
+	ProjectCommand
+	projectKey string
+	quiet      bool
+}
+
+func NewProjectDeleteCommand() *ProjectDeleteCommand {
+	return &ProjectDeleteCommand{}
+}
+
+func (pdc *ProjectDeleteCommand) SetProjectKey(projectKey string) *ProjectDeleteCommand {
+	pdc.projectKey = projectKey
+	return pdc
+}
+
+func (pdc *ProjectDeleteCommand) SetTemplatePath(path string) *ProjectDeleteCommand {
+	pdc.templatePath = path
+	return pdc
+}
+
+func (pdc *ProjectDeleteCommand) SetVars(vars string) *ProjectDeleteCommand {
+	pdc.vars = vars
+	return pdc
+}
+
+func (pdc *ProjectDeleteCommand) SetQuiet(quiet bool) *ProjectDeleteCommand {
+	pdc.quiet = quiet
+	return pdc
+}
+
+func (pdc *ProjectDeleteCommand) SetServerDetails(serverDetails *config.ServerDetails) *ProjectDeleteCommand {
+	pdc.serverDetails = serverDetails
+	return pdc
+}
+
+func (pdc *ProjectDeleteCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pdc.serverDetails, nil
+}
+
+func (pdc *ProjectDeleteCommand) CommandName() string {
+	return "project_delete"
+}
+
+func (pdc *ProjectDeleteCommand) Run() error {
+	if (pdc.projectKey == "") == (pdc.templatePath == "") {
+		return errorutils.CheckErrorf("either a project key or a project template should be provided")
+	}
+	var repos []string
+	if pdc.templatePath != "" {
+		template, err := ReadProjectTemplate(pdc.templatePath, pdc.vars)
+		if err != nil {
+			return err
+		}
+		pdc.projectKey, repos = template.ProjectKey, template.Repositories
+	}
+	if !pdc.quiet && !coreutils.AskYesNo("Are you sure you want to permanently delete the project "+pdc.projectKey+"?", false) {
+		return nil
+	}
+	service, err := newProjectService(pdc.serverDetails)
+	if err != nil {
+		return err
+	}
+	for _, repo := range repos {
+		log.Info(fmt.Sprintf("Unassigning repository '%s' from project '%s'...", repo, pdc.projectKey))
+		if err = service.accessManager.UnassignRepoFromProject(repo); err != nil {
+			return err
+		}
+	}
+	log.Info("Deleting project '" + pdc.projectKey + "'...")
+	return service.accessManager.DeleteProject(pdc.projectKey)
+}
@@ -0,0 +1,237 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/access"
+	"github.com/jfrog/jfrog-client-go/access/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	projectsApi = "api/v1/projects/"
+
+	customRoleType = "CUSTOM"
+)
+
+// ProjectTemplate is the YAML template describing a JFrog Project, its roles, members and assigned repositories.
+// Variables in the form of ${var} are replaced before the template is parsed, as in the repository templates.
+type ProjectTemplate struct {
+	ProjectKey        string                  `yaml:"projectKey"`
+	DisplayName       string                  `yaml:"displayName"`
+	Description       string                  `yaml:"description,omitempty"`
+	AdminPrivileges   *AdminPrivilegesSection `yaml:"adminPrivileges,omitempty"`
+	StorageQuotaBytes float64                 `yaml:"storageQuotaBytes,omitempty"`
+	SoftLimit         *bool                   `yaml:"softLimit,omitempty"`
+	Roles             []ProjectRole           `yaml:"roles,omitempty"`
+	Members           MembersSection          `yaml:"members,omitempty"`
+	Repositories      []string                `yaml:"repositories,omitempty"`
+}
+
+type AdminPrivilegesSection struct {
+	ManageMembers   *bool `yaml:"manageMembers,omitempty"`
+	ManageResources *bool `yaml:"manageResources,omitempty"`
+	IndexResources  *bool `yaml:"indexResources,omitempty"`
+}
+
+// ProjectRole is a custom role of the project, granting actions on the resources of the listed environments.
+type ProjectRole struct {
+	Name         string   `yaml:"name" json:"name"`
+	Description  string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Type         string   `yaml:"type,omitempty" json:"type"`
+	Environments []string `yaml:"environments,omitempty" json:"environments"`
+	Actions      []string `yaml:"actions,omitempty" json:"actions"`
+}
+
+type MembersSection struct {
+	Users  []ProjectMember `yaml:"users,omitempty"`
+	Groups []ProjectMember `yaml:"groups,omitempty"`
+}
+
+type ProjectMember struct {
+	Name  string   `yaml:"name" json:"name"`
+	Roles []string `yaml:"roles" json:"roles"`
+}
+
+// ProjectCommand is the base of the project administration commands driven by a project template.
+type ProjectCommand struct {
+	serverDetails *config.ServerDetails
+	templatePath  string
+	vars          string
+}
+
+func (pc *ProjectCommand) TemplatePath() string {
+	return pc.templatePath
+}
+
+func (pc *ProjectCommand) Vars() string {
+	return pc.vars
+}
+
+// ReadProjectTemplate reads the project template, after replacing its variables. Unknown keys are considered a syntax error.
+func ReadProjectTemplate(templatePath, vars string) (*ProjectTemplate, error) {
+	content, err := fileutils.ReadFile(templatePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(vars) > 0 {
+		content = coreutils.ReplaceVars(content, coreutils.SpecVarsStringToMap(vars))
+	}
+	template := &ProjectTemplate{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(template); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errorutils.CheckErrorf("template syntax error: %s", err.Error())
+	}
+	return template, template.validate()
+}
+
+func (pt *ProjectTemplate) validate() error {
+	if pt.ProjectKey == "" {
+		return errorutils.CheckErrorf("template syntax error: the 'projectKey' key is mandatory")
+	}
+	for _, role := range pt.Roles {
+		if role.Name == "" {
+			return errorutils.CheckErrorf("template syntax error: a role without a name was found")
+		}
+	}
+	for _, member := range append(append([]ProjectMember{}, pt.Members.Users...), pt.Members.Groups...) {
+		if member.Name == "" {
+			return errorutils.CheckErrorf("template syntax error: a member without a name was found")
+		}
+	}
+	return nil
+}
+
+func (pt *ProjectTemplate) toProjectParams() services.ProjectParams {
+	params := services.NewProjectParams()
+	params.ProjectDetails = services.Project{
+		ProjectKey:        pt.ProjectKey,
+		DisplayName:       pt.DisplayName,
+		Description:       pt.Description,
+		SoftLimit:         pt.SoftLimit,
+		StorageQuotaBytes: pt.StorageQuotaBytes,
+	}
+	if pt.AdminPrivileges != nil {
+		params.ProjectDetails.AdminPrivileges = &services.AdminPrivileges{
+			ManageMembers:   pt.AdminPrivileges.ManageMembers,
+			ManageResources: pt.AdminPrivileges.ManageResources,
+			IndexResources:  pt.AdminPrivileges.IndexResources,
+		}
+	}
+	return params
+}
+
+// projectService sends the project administration requests to Access.
+// Roles and user members aren't supported by the Access services manager, so their REST API is called directly.
+type projectService struct {
+	accessManager *access.AccessServicesManager
+	accessDetails auth.ServiceDetails
+}
+
+func newProjectService(serverDetails *config.ServerDetails) (*projectService, error) {
+	accessManager, err := rtUtils.CreateAccessServiceManager(serverDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	accessDetails, err := serverDetails.CreateAccessAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &projectService{accessManager: accessManager, accessDetails: accessDetails}, nil
+}
+
+// Applies the roles, members and repositories of the template to an existing project.
+func (ps *projectService) applyTemplate(template *ProjectTemplate, forceAssign bool) error {
+	for _, role := range template.Roles {
+		if err := ps.createOrUpdateRole(template.ProjectKey, role); err != nil {
+			return err
+		}
+	}
+	for _, user := range template.Members.Users {
+		log.Info(fmt.Sprintf("Setting the roles of user '%s' in project '%s'...", user.Name, template.ProjectKey))
+		if err := ps.sendRequest(http.MethodPut, template.ProjectKey+"/users/"+url.PathEscape(user.Name), user, http.StatusOK, http.StatusCreated); err != nil {
+			return err
+		}
+	}
+	for _, group := range template.Members.Groups {
+		log.Info(fmt.Sprintf("Setting the roles of group '%s' in project '%s'...", group.Name, template.ProjectKey))
+		if err := ps.accessManager.UpdateGroupInProject(template.ProjectKey, group.Name, services.ProjectGroup{Name: group.Name, Roles: group.Roles}); err != nil {
+			return err
+		}
+	}
+	return ps.assignRepos(template.ProjectKey, template.Repositories, forceAssign)
+}
+
+func (ps *projectService) createOrUpdateRole(projectKey string, role ProjectRole) error {
+	if role.Type == "" {
+		role.Type = customRoleType
+	}
+	rolePath := projectKey + "/roles/" + url.PathEscape(role.Name)
+	exists, err := ps.exists(rolePath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.Info(fmt.Sprintf("Updating role '%s' in project '%s'...", role.Name, projectKey))
+		return ps.sendRequest(http.MethodPut, rolePath, role, http.StatusOK)
+	}
+	log.Info(fmt.Sprintf("Creating role '%s' in project '%s'...", role.Name, projectKey))
+	return ps.sendRequest(http.MethodPost, projectKey+"/roles", role, http.StatusOK, http.StatusCreated)
+}
+
+func (ps *projectService) assignRepos(projectKey string, repos []string, force bool) error {
+	for _, repo := range repos {
+		log.Info(fmt.Sprintf("Assigning repository '%s' to project '%s'...", repo, projectKey))
+		if err := ps.accessManager.AssignRepoToProject(repo, projectKey, force); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ps *projectService) exists(restApi string) (bool, error) {
+	httpDetails := ps.accessDetails.CreateHttpClientDetails()
+	resp, body, _, err := ps.accessManager.Client().SendGet(ps.accessDetails.GetUrl()+projectsApi+restApi, true, &httpDetails)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	return true, errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
+
+func (ps *projectService) sendRequest(method, restApi string, payload any, expectedStatusCodes ...int) error {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	httpDetails := ps.accessDetails.CreateHttpClientDetails()
+	clientUtils.SetContentType("application/json", &httpDetails.Headers)
+	requestUrl := ps.accessDetails.GetUrl() + projectsApi + restApi
+	var resp *http.Response
+	var body []byte
+	if method == http.MethodPut {
+		resp, body, err = ps.accessManager.Client().SendPut(requestUrl, content, &httpDetails)
+	} else {
+		resp, body, err = ps.accessManager.Client().SendPost(requestUrl, content, &httpDetails)
+	}
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, expectedStatusCodes...)
+}
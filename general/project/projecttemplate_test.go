@@ -0,0 +1,87 @@
+package project
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+const testTemplatePath = "testdata/project-template.yaml"
+
+func TestReadProjectTemplate(t *testing.T) {
+	template, err := ReadProjectTemplate(testTemplatePath, "key=proj1;name=First")
+	require.NoError(t, err)
+	assert.Equal(t, "proj1", template.ProjectKey)
+	assert.Equal(t, "First Project", template.DisplayName)
+	assert.Equal(t, float64(1073741824), template.StorageQuotaBytes)
+	assert.True(t, *template.AdminPrivileges.ManageMembers)
+	assert.Nil(t, template.AdminPrivileges.IndexResources)
+	assert.Equal(t, []string{"READ_REPOSITORY", "DEPLOY_CACHE_REPOSITORY"}, template.Roles[0].Actions)
+	assert.Equal(t, "proj1-admins", template.Members.Groups[0].Name)
+	assert.Equal(t, []string{"proj1-maven-local"}, template.Repositories)
+
+	params := template.toProjectParams()
+	assert.Equal(t, "proj1", params.ProjectDetails.ProjectKey)
+	assert.True(t, *params.ProjectDetails.AdminPrivileges.ManageResources)
+}
+
+func TestReadProjectTemplateErrors(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTemplate := func(content string) string {
+		templatePath := filepath.Join(tempDir, "template.yaml")
+		assert.NoError(t, os.WriteFile(templatePath, []byte(content), 0600))
+		return templatePath
+	}
+	_, err := ReadProjectTemplate(writeTemplate("projectKey: proj1\nunknownKey: value\n"), "")
+	assert.ErrorContains(t, err, "template syntax error")
+	_, err = ReadProjectTemplate(writeTemplate("displayName: No Key\n"), "")
+	assert.ErrorContains(t, err, "'projectKey' key is mandatory")
+	_, err = ReadProjectTemplate(writeTemplate("projectKey: proj1\nroles:\n  - description: no name\n"), "")
+	assert.ErrorContains(t, err, "a role without a name")
+}
+
+func TestProjectCreate(t *testing.T) {
+	var mutex sync.Mutex
+	requests := map[string]string{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests[r.Method+" "+r.URL.Path] = string(content)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /access/api/v1/projects/proj1", "GET /access/api/v1/projects/proj1/roles/Developer":
+			w.WriteHeader(http.StatusNotFound)
+		case "POST /access/api/v1/projects", "POST /access/api/v1/projects/proj1/roles":
+			w.WriteHeader(http.StatusCreated)
+		case "PUT /access/api/v1/projects/proj1/users/alice", "PUT /access/api/v1/projects/proj1/groups/proj1-admins":
+			w.WriteHeader(http.StatusOK)
+		case "PUT /access/api/v1/projects/_/attach/repositories/proj1-maven-local/proj1":
+			assert.Equal(t, "false", r.URL.Query().Get("force"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "unexpected request: "+r.Method+" "+r.URL.Path)
+		}
+	}))
+	defer testServer.Close()
+
+	createCmd := NewProjectCreateCommand().SetTemplatePath(testTemplatePath).SetVars("key=proj1;name=First").
+		SetServerDetails(&config.ServerDetails{Url: testServer.URL + "/"})
+	require.NoError(t, createCmd.Run())
+	assert.Len(t, requests, 7)
+
+	var role ProjectRole
+	assert.NoError(t, json.Unmarshal([]byte(requests["POST /access/api/v1/projects/proj1/roles"]), &role))
+	assert.Equal(t, customRoleType, role.Type)
+	assert.Equal(t, []string{"DEV"}, role.Environments)
+	assert.JSONEq(t, `{"name":"alice","roles":["Developer"]}`, requests["PUT /access/api/v1/projects/proj1/users/alice"])
+	assert.JSONEq(t, `{"name":"proj1-admins","roles":["Project Admin"]}`, requests["PUT /access/api/v1/projects/proj1/groups/proj1-admins"])
+}
@@ -0,0 +1,75 @@
+package project
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ProjectUpdateCommand updates an existing project from a project template.
+// Roles are created or updated, and the roles of the members are replaced. Existing roles, members and repositories that
+// don't appear in the template are kept.
+type ProjectUpdateCommand struct {
+	ProjectCommand
+	forceAssign bool
+}
+
+func NewProjectUpdateCommand() *ProjectUpdateCommand {
+	return &ProjectUpdateCommand{}
+}
+
+func (puc *ProjectUpdateCommand) SetTemplatePath(path string) *ProjectUpdateCommand {
+	puc.templatePath = path
+	return puc
+}
+
+func (puc *ProjectUpdateCommand) SetVars(vars string) *ProjectUpdateCommand {
+	puc.vars = vars
+	return puc
+}
+
+func (puc *ProjectUpdateCommand) SetServerDetails(serverDetails *config.ServerDetails) *ProjectUpdateCommand {
+	puc.serverDetails = serverDetails
+	return puc
+}
+
+// SetForceAssign allows moving the template repositories from the projects they're currently assigned to.
+func (puc *ProjectUpdateCommand) SetForceAssign(forceAssign bool) *ProjectUpdateCommand {
+	puc.forceAssign = forceAssign
+	return puc
+}
+
+func (puc *ProjectUpdateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return puc.serverDetails, nil
+}
+
+func (puc *ProjectUpdateCommand) CommandName() string {
+	return "project_update"
+}
+
+func (puc *ProjectUpdateCommand) Run() error {
+	template, err := ReadProjectTemplate(puc.templatePath, puc.vars)
+	if err != nil {
+		return err
+	}
+	service, err := newProjectService(puc.serverDetails)
+	if err != nil {
+		return err
+	}
+	project, err := service.accessManager.GetProject(template.ProjectKey)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return errorutils.CheckErrorf("project '%s' does not exist", template.ProjectKey)
+	}
+	log.Info("Updating project '" + template.ProjectKey + "'...")
+	if err = service.accessManager.UpdateProject(template.toProjectParams()); err != nil {
+		return err
+	}
+	if err = service.applyTemplate(template, puc.forceAssign); err != nil {
+		return err
+	}
+	log.Info("Project '" + template.ProjectKey + "' was updated successfully.")
+	return nil
+}
@@ -0,0 +1,160 @@
+package stats
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/metrics"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// StatsShowCommand displays the aggregates of the local command metrics, recorded when JFROG_CLI_METRICS is set to true.
+type StatsShowCommand struct {
+	command      string
+	since        time.Duration
+	outputFormat format.OutputFormat
+	metricsDir   string
+}
+
+type commandRow struct {
+	Command       string `col-name:"Command"`
+	Runs          int    `col-name:"Runs"`
+	Failures      int    `col-name:"Failures"`
+	Average       string `col-name:"Average"`
+	P95           string `col-name:"P95"`
+	Max           string `col-name:"Max"`
+	BytesSent     string `col-name:"Sent"`
+	BytesReceived string `col-name:"Received"`
+	Retries       int64  `col-name:"Retries"`
+}
+
+type apiRow struct {
+	Api      string `col-name:"API"`
+	Requests int64  `col-name:"Requests"`
+	Average  string `col-name:"Average Latency"`
+	Max      string `col-name:"Max Latency"`
+}
+
+type statsOutput struct {
+	Commands []metrics.CommandStats `json:"commands"`
+	Apis     []metrics.ApiStats     `json:"apis"`
+}
+
+func NewStatsShowCommand() *StatsShowCommand {
+	return &StatsShowCommand{outputFormat: format.Table}
+}
+
+// SetCommand shows the metrics of the provided command only.
+func (ssc *StatsShowCommand) SetCommand(command string) *StatsShowCommand {
+	ssc.command = command
+	return ssc
+}
+
+// SetSince shows the metrics of the commands which started in the provided duration before now only.
+func (ssc *StatsShowCommand) SetSince(since time.Duration) *StatsShowCommand {
+	ssc.since = since
+	return ssc
+}
+
+// SetOutputFormat sets the output format - 'table' or 'json'.
+func (ssc *StatsShowCommand) SetOutputFormat(outputFormat format.OutputFormat) *StatsShowCommand {
+	ssc.outputFormat = outputFormat
+	return ssc
+}
+
+func (ssc *StatsShowCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (ssc *StatsShowCommand) CommandName() string {
+	return "stats_show"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (ssc *StatsShowCommand) IsReadOnly() bool {
+	return true
+}
+
+func (ssc *StatsShowCommand) Run() (err error) {
+	metricsDir := ssc.metricsDir
+	if metricsDir == "" {
+		if metricsDir, err = coreutils.GetJfrogMetricsDir(); err != nil {
+			return
+		}
+	}
+	records, err := metrics.ReadRecords(metricsDir)
+	if err != nil {
+		return
+	}
+	if len(records) == 0 && !metrics.IsEnabled() {
+		log.Info("No metrics were recorded. To record the metrics of the commands, set the " + coreutils.Metrics + " environment variable to true.")
+		return
+	}
+	commands, apis := metrics.Aggregate(ssc.filterRecords(records))
+	switch ssc.outputFormat {
+	case format.Json:
+		content, err := json.Marshal(statsOutput{Commands: commands, Apis: apis})
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	case format.Table:
+		return printStatsTables(commands, apis)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s", ssc.outputFormat, format.Table, format.Json)
+	}
+}
+
+func (ssc *StatsShowCommand) filterRecords(records []metrics.CommandRecord) []metrics.CommandRecord {
+	var filtered []metrics.CommandRecord
+	for _, record := range records {
+		if ssc.command != "" && record.Command != ssc.command {
+			continue
+		}
+		if ssc.since > 0 && time.Since(record.StartTime) > ssc.since {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	return filtered
+}
+
+func printStatsTables(commands []metrics.CommandStats, apis []metrics.ApiStats) error {
+	var commandRows []commandRow
+	for _, stats := range commands {
+		commandRows = append(commandRows, commandRow{
+			Command:       stats.Command,
+			Runs:          stats.Runs,
+			Failures:      stats.Failures,
+			Average:       formatDuration(stats.AverageDuration),
+			P95:           formatDuration(stats.P95Duration),
+			Max:           formatDuration(stats.MaxDuration),
+			BytesSent:     servicesutils.ConvertIntToStorageSizeString(stats.BytesSent),
+			BytesReceived: servicesutils.ConvertIntToStorageSizeString(stats.BytesReceived),
+			Retries:       stats.Retries,
+		})
+	}
+	if err := coreutils.PrintTable(commandRows, "Commands", "No commands were recorded", false); err != nil {
+		return err
+	}
+	var apiRows []apiRow
+	for _, stats := range apis {
+		apiRows = append(apiRows, apiRow{Api: stats.Api, Requests: stats.Requests, Average: formatDuration(stats.AverageLatency), Max: formatDuration(stats.MaxLatency)})
+	}
+	return coreutils.PrintTable(apiRows, "API Latencies", "No requests were recorded", false)
+}
+
+func formatDuration(duration time.Duration) string {
+	if duration < time.Second {
+		return strconv.FormatInt(duration.Milliseconds(), 10) + "ms"
+	}
+	return duration.Round(100 * time.Millisecond).String()
+}
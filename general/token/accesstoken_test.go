@@ -0,0 +1,99 @@
+package token
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetScope(t *testing.T) {
+	testCases := []struct {
+		name     string
+		command  *AccessTokenCreateCommand
+		expected string
+	}{
+		{"default", NewAccessTokenCreateCommand(), ""},
+		{"explicit", NewAccessTokenCreateCommand().SetScope("applied-permissions/user").SetGrantAdmin(true), "applied-permissions/user"},
+		{"groups and admin", NewAccessTokenCreateCommand().SetGroups("readers,writers").SetGrantAdmin(true), "applied-permissions/groups:readers,writers applied-permissions/admin"},
+		{"project roles", NewAccessTokenCreateCommand().SetProjectKey("proj").SetProjectRoles([]string{"Developer", "Viewer"}), "applied-permissions/roles:proj:Developer,Viewer"},
+		{"default project roles", NewAccessTokenCreateCommand().SetServerDetails(&config.ServerDetails{Project: "default-proj"}).SetProjectRoles([]string{"Viewer"}), "applied-permissions/roles:default-proj:Viewer"},
+		{"repo permissions", NewAccessTokenCreateCommand().SetRepoPermissions([]string{"libs-local:read,write,deploy", "docker-local:Delete"}), "artifact:libs-local:r,w artifact:docker-local:d"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			scope, err := testCase.command.getScope()
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, scope)
+		})
+	}
+}
+
+func TestGetScopeErrors(t *testing.T) {
+	_, err := NewAccessTokenCreateCommand().SetProjectRoles([]string{"Developer"}).getScope()
+	assert.ErrorContains(t, err, "a project key is mandatory")
+	_, err = NewAccessTokenCreateCommand().SetRepoPermissions([]string{"libs-local"}).getScope()
+	assert.ErrorContains(t, err, "invalid repository permission 'libs-local'")
+	_, err = NewAccessTokenCreateCommand().SetRepoPermissions([]string{"libs-local:read,execute"}).getScope()
+	assert.ErrorContains(t, err, "unsupported action 'execute'")
+}
+
+func TestAccessTokenList(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/access/"+tokensApi, r.URL.Path)
+		_, err := w.Write([]byte(`{"tokens":[{"token_id":"id-1","subject":"jfac@01/users/ci","issued_at":1700000000,"expiry":1700003600,"issuer":"jfac@01","refreshable":true},{"token_id":"id-2","subject":"jfac@01/users/admin","issued_at":1700000000}]}`))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+	listCmd := NewAccessTokenListCommand().SetServerDetails(&config.ServerDetails{Url: testServer.URL, AccessToken: "token"})
+	require.NoError(t, listCmd.Run())
+	tokens := listCmd.Tokens()
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "id-1", tokens[0].TokenId)
+	assert.True(t, tokens[0].Refreshable)
+	assert.Equal(t, "2023-11-14T22:13:20Z", formatTokenTime(tokens[0].IssuedAt))
+	assert.Equal(t, "Never", formatTokenTime(tokens[1].Expiry))
+	assert.NoError(t, listCmd.SetOutputFormat(format.Json).Run())
+}
+
+func TestAccessTokenRevoke(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		if r.URL.Path == "/access/"+tokensApi+"/id-1" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer testServer.Close()
+	serverDetails := &config.ServerDetails{Url: testServer.URL, AccessToken: "token"}
+	assert.NoError(t, NewAccessTokenRevokeCommand().SetServerDetails(serverDetails).SetTokenId("id-1").SetQuiet(true).Run())
+	assert.EqualError(t, NewAccessTokenRevokeCommand().SetServerDetails(serverDetails).SetTokenId("id-2").SetQuiet(true).Run(), "access token 'id-2' does not exist")
+}
+
+func TestAccessTokenExchange(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/access/"+oidcTokenApi, r.URL.Path)
+		var request tokenExchangeRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, tokenExchangeRequest{GrantType: tokenExchangeGrantType, SubjectTokenType: idTokenType, SubjectToken: "oidc-token", ProviderName: "github", ProjectKey: "proj"}, request)
+		_, err := w.Write([]byte(`{"access_token":"short-lived","expires_in":600,"token_type":"Bearer"}`))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+	exchangeCmd := NewAccessTokenExchangeCommand().SetServerDetails(&config.ServerDetails{Url: testServer.URL}).
+		SetOidcToken("oidc-token").SetProviderName("github").SetProjectKey("proj")
+	require.NoError(t, exchangeCmd.Run())
+	response, err := exchangeCmd.Response()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"access_token":"short-lived","expires_in":600,"token_type":"Bearer"}`, string(response))
+
+	assert.ErrorContains(t, NewAccessTokenExchangeCommand().SetOidcToken("oidc-token").Run(), "OIDC provider name are mandatory")
+}
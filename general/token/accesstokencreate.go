@@ -7,14 +7,27 @@ import (
 	"github.com/jfrog/jfrog-client-go/access/services"
 	"github.com/jfrog/jfrog-client-go/auth"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/exp/slices"
 	"strings"
 )
 
 const (
-	AdminScope        = "applied-permissions/admin"
-	GroupsScopePrefix = "applied-permissions/groups:"
+	AdminScope              = "applied-permissions/admin"
+	GroupsScopePrefix       = "applied-permissions/groups:"
+	ProjectRolesScopePrefix = "applied-permissions/roles:"
+	RepoScopePrefix         = "artifact:"
 )
 
+// The actions which can be permitted on a repository, and their abbreviations in the token scope.
+var repoActions = map[string]string{
+	"read":     "r",
+	"write":    "w",
+	"deploy":   "w",
+	"delete":   "d",
+	"annotate": "a",
+	"manage":   "m",
+}
+
 type AccessTokenCreateCommand struct {
 	serverDetails *config.ServerDetails
 	username      string
@@ -23,6 +36,10 @@ type AccessTokenCreateCommand struct {
 	scope      string
 	groups     string
 	grantAdmin bool
+	// The roles of the token in the project, for tokens scoped to a project.
+	projectRoles []string
+	// The permitted actions per repository, for tokens scoped to repositories. Each permission is in the form of <repo>:<action>[,<action>...].
+	repoPermissions []string
 
 	expiry      *uint
 	refreshable bool
@@ -68,6 +85,16 @@ func (atc *AccessTokenCreateCommand) SetGrantAdmin(grantAdmin bool) *AccessToken
 	return atc
 }
 
+func (atc *AccessTokenCreateCommand) SetProjectRoles(projectRoles []string) *AccessTokenCreateCommand {
+	atc.projectRoles = projectRoles
+	return atc
+}
+
+func (atc *AccessTokenCreateCommand) SetRepoPermissions(repoPermissions []string) *AccessTokenCreateCommand {
+	atc.repoPermissions = repoPermissions
+	return atc
+}
+
 func (atc *AccessTokenCreateCommand) SetExpiry(expiry *uint) *AccessTokenCreateCommand {
 	atc.expiry = expiry
 	return atc
@@ -98,6 +125,11 @@ func (atc *AccessTokenCreateCommand) Response() ([]byte, error) {
 	return content, errorutils.CheckError(err)
 }
 
+// AccessToken returns the access token created by the command.
+func (atc *AccessTokenCreateCommand) AccessToken() string {
+	return atc.response.AccessToken
+}
+
 func (atc *AccessTokenCreateCommand) ServerDetails() (*config.ServerDetails, error) {
 	return atc.serverDetails, nil
 }
@@ -112,30 +144,34 @@ func (atc *AccessTokenCreateCommand) Run() error {
 		return err
 	}
 
-	*atc.response, err = servicesManager.CreateAccessToken(atc.getTokenParams())
+	tokenParams, err := atc.getTokenParams()
+	if err != nil {
+		return err
+	}
+	*atc.response, err = servicesManager.CreateAccessToken(tokenParams)
 	return err
 }
 
-func (atc *AccessTokenCreateCommand) getTokenParams() services.CreateTokenParams {
-	tokenParams := services.CreateTokenParams{}
-
+func (atc *AccessTokenCreateCommand) getTokenParams() (tokenParams services.CreateTokenParams, err error) {
 	tokenParams.Username = strings.ToLower(atc.username)
-	tokenParams.ProjectKey = atc.projectKey
-	tokenParams.Scope = atc.getScope()
+	tokenParams.ProjectKey = config.GetProjectKey(atc.projectKey, atc.serverDetails)
+	if tokenParams.Scope, err = atc.getScope(); err != nil {
+		return
+	}
 	tokenParams.ExpiresIn = atc.expiry
 	tokenParams.Refreshable = &atc.refreshable
 	tokenParams.Description = atc.description
 	tokenParams.Audience = atc.audience
 	tokenParams.IncludeReferenceToken = &atc.includeReferenceToken
-	return tokenParams
+	return
 }
 
 // If an explicit scope was provided, apply it.
-// Otherwise, if admin or groups scopes were requested, construct scope from them (space separated).
+// Otherwise, if admin, groups, project roles or repository permissions scopes were requested, construct scope from them (space separated).
 // If no scopes were requested, leave scope empty to provide the default user scope.
-func (atc *AccessTokenCreateCommand) getScope() string {
+func (atc *AccessTokenCreateCommand) getScope() (string, error) {
 	if atc.scope != "" {
-		return atc.scope
+		return atc.scope, nil
 	}
 
 	var scopes []string
@@ -146,5 +182,40 @@ func (atc *AccessTokenCreateCommand) getScope() string {
 	if atc.grantAdmin {
 		scopes = append(scopes, AdminScope)
 	}
-	return strings.Join(scopes, " ")
+
+	if len(atc.projectRoles) > 0 {
+		projectKey := config.GetProjectKey(atc.projectKey, atc.serverDetails)
+		if projectKey == "" {
+			return "", errorutils.CheckErrorf("a project key is mandatory for scoping the token to project roles")
+		}
+		scopes = append(scopes, ProjectRolesScopePrefix+projectKey+":"+strings.Join(atc.projectRoles, ","))
+	}
+
+	for _, repoPermission := range atc.repoPermissions {
+		repoScope, err := getRepoScope(repoPermission)
+		if err != nil {
+			return "", err
+		}
+		scopes = append(scopes, repoScope)
+	}
+	return strings.Join(scopes, " "), nil
+}
+
+// Converts a repository permission, such as 'libs-release-local:read,deploy', to a token scope, such as 'artifact:libs-release-local:r,w'.
+func getRepoScope(repoPermission string) (string, error) {
+	repo, actions, found := strings.Cut(repoPermission, ":")
+	if !found || repo == "" || actions == "" {
+		return "", errorutils.CheckErrorf("invalid repository permission '%s'. The permission should be in the form of <repo>:<action>[,<action>...]", repoPermission)
+	}
+	var abbreviations []string
+	for _, action := range strings.Split(actions, ",") {
+		abbreviation, exists := repoActions[strings.ToLower(strings.TrimSpace(action))]
+		if !exists {
+			return "", errorutils.CheckErrorf("unsupported action '%s' in the repository permission '%s'. Possible values are: read, write, deploy, delete, annotate, manage", action, repoPermission)
+		}
+		if !slices.Contains(abbreviations, abbreviation) {
+			abbreviations = append(abbreviations, abbreviation)
+		}
+	}
+	return RepoScopePrefix + repo + ":" + strings.Join(abbreviations, ","), nil
 }
@@ -0,0 +1,84 @@
+package token
+
+import (
+	"encoding/json"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// AccessTokenExchangeCommand exchanges an OIDC token issued by a CI provider for a short-lived JFrog access token,
+// so that CI pipelines don't need to store long-lived credentials.
+type AccessTokenExchangeCommand struct {
+	serverDetails *config.ServerDetails
+	// The OIDC token issued by the CI provider.
+	oidcToken string
+	// The name of the OIDC integration configured in the JFrog Platform.
+	providerName string
+	projectKey   string
+	expiry       *uint
+
+	response *auth.CreateTokenResponseData
+}
+
+func NewAccessTokenExchangeCommand() *AccessTokenExchangeCommand {
+	return &AccessTokenExchangeCommand{response: new(auth.CreateTokenResponseData)}
+}
+
+func (ate *AccessTokenExchangeCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessTokenExchangeCommand {
+	ate.serverDetails = serverDetails
+	return ate
+}
+
+func (ate *AccessTokenExchangeCommand) SetOidcToken(oidcToken string) *AccessTokenExchangeCommand {
+	ate.oidcToken = oidcToken
+	return ate
+}
+
+func (ate *AccessTokenExchangeCommand) SetProviderName(providerName string) *AccessTokenExchangeCommand {
+	ate.providerName = providerName
+	return ate
+}
+
+func (ate *AccessTokenExchangeCommand) SetProjectKey(projectKey string) *AccessTokenExchangeCommand {
+	ate.projectKey = projectKey
+	return ate
+}
+
+func (ate *AccessTokenExchangeCommand) SetExpiry(expiry *uint) *AccessTokenExchangeCommand {
+	ate.expiry = expiry
+	return ate
+}
+
+func (ate *AccessTokenExchangeCommand) Response() ([]byte, error) {
+	content, err := json.Marshal(*ate.response)
+	return content, errorutils.CheckError(err)
+}
+
+func (ate *AccessTokenExchangeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ate.serverDetails, nil
+}
+
+func (ate *AccessTokenExchangeCommand) CommandName() string {
+	return "jf_access_token_exchange"
+}
+
+func (ate *AccessTokenExchangeCommand) Run() error {
+	if ate.oidcToken == "" || ate.providerName == "" {
+		return errorutils.CheckErrorf("both the OIDC token and the OIDC provider name are mandatory for exchanging a token")
+	}
+	service, err := newTokenService(ate.serverDetails)
+	if err != nil {
+		return err
+	}
+	*ate.response, err = service.exchange(tokenExchangeRequest{
+		GrantType:        tokenExchangeGrantType,
+		SubjectTokenType: idTokenType,
+		SubjectToken:     ate.oidcToken,
+		ProviderName:     ate.providerName,
+		ProjectKey:       config.GetProjectKey(ate.projectKey, ate.serverDetails),
+		ExpiresIn:        ate.expiry,
+	})
+	return err
+}
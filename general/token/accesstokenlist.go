@@ -0,0 +1,97 @@
+package token
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type AccessTokenListCommand struct {
+	serverDetails *config.ServerDetails
+	outputFormat  format.OutputFormat
+	tokens        []TokenInfo
+}
+
+type tokenRow struct {
+	TokenId     string `col-name:"Token ID"`
+	Subject     string `col-name:"Subject"`
+	Description string `col-name:"Description"`
+	IssuedAt    string `col-name:"Issued At"`
+	Expiry      string `col-name:"Expiry"`
+	Refreshable bool   `col-name:"Refreshable"`
+}
+
+func NewAccessTokenListCommand() *AccessTokenListCommand {
+	return &AccessTokenListCommand{outputFormat: format.Table}
+}
+
+func (atl *AccessTokenListCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessTokenListCommand {
+	atl.serverDetails = serverDetails
+	return atl
+}
+
+// SetOutputFormat sets the output format - 'table' or 'json'.
+func (atl *AccessTokenListCommand) SetOutputFormat(outputFormat format.OutputFormat) *AccessTokenListCommand {
+	atl.outputFormat = outputFormat
+	return atl
+}
+
+func (atl *AccessTokenListCommand) Tokens() []TokenInfo {
+	return atl.tokens
+}
+
+func (atl *AccessTokenListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return atl.serverDetails, nil
+}
+
+func (atl *AccessTokenListCommand) CommandName() string {
+	return "jf_access_token_list"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (atl *AccessTokenListCommand) IsReadOnly() bool {
+	return true
+}
+
+func (atl *AccessTokenListCommand) Run() error {
+	service, err := newTokenService(atl.serverDetails)
+	if err != nil {
+		return err
+	}
+	// Only the tokens of the authenticated user are returned, unless the user is an admin.
+	if atl.tokens, err = service.getAll(); err != nil {
+		return err
+	}
+	switch atl.outputFormat {
+	case format.Json:
+		content, err := json.Marshal(atl.tokens)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	case format.Table:
+		var rows []tokenRow
+		for _, token := range atl.tokens {
+			rows = append(rows, tokenRow{TokenId: token.TokenId, Subject: token.Subject, Description: token.Description,
+				IssuedAt: formatTokenTime(token.IssuedAt), Expiry: formatTokenTime(token.Expiry), Refreshable: token.Refreshable})
+		}
+		return coreutils.PrintTable(rows, "Access Tokens", "No access tokens were found", false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s", atl.outputFormat, format.Table, format.Json)
+	}
+}
+
+// Tokens without an expiry never expire.
+func formatTokenTime(epochSeconds int64) string {
+	if epochSeconds == 0 {
+		return "Never"
+	}
+	return time.Unix(epochSeconds, 0).UTC().Format(time.RFC3339)
+}
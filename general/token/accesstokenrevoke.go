@@ -0,0 +1,52 @@
+package token
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type AccessTokenRevokeCommand struct {
+	serverDetails *config.ServerDetails
+	tokenId       string
+	quiet         bool
+}
+
+func NewAccessTokenRevokeCommand() *AccessTokenRevokeCommand {
+	return &AccessTokenRevokeCommand{}
+}
+
+func (atr *AccessTokenRevokeCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessTokenRevokeCommand {
+	atr.serverDetails = serverDetails
+	return atr
+}
+
+func (atr *AccessTokenRevokeCommand) SetTokenId(tokenId string) *AccessTokenRevokeCommand {
+	atr.tokenId = tokenId
+	return atr
+}
+
+func (atr *AccessTokenRevokeCommand) SetQuiet(quiet bool) *AccessTokenRevokeCommand {
+	atr.quiet = quiet
+	return atr
+}
+
+func (atr *AccessTokenRevokeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return atr.serverDetails, nil
+}
+
+func (atr *AccessTokenRevokeCommand) CommandName() string {
+	return "jf_access_token_revoke"
+}
+
+func (atr *AccessTokenRevokeCommand) Run() error {
+	if !atr.quiet && !coreutils.AskYesNo("Are you sure you want to revoke the access token "+atr.tokenId+"?", false) {
+		return nil
+	}
+	service, err := newTokenService(atr.serverDetails)
+	if err != nil {
+		return err
+	}
+	log.Info("Revoking access token '" + atr.tokenId + "'...")
+	return service.revoke(atr.tokenId)
+}
@@ -0,0 +1,113 @@
+package token
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	// #nosec G101 -- False positive - no hardcoded credentials.
+	tokensApi = "api/v1/tokens"
+	// #nosec G101 -- False positive - no hardcoded credentials.
+	oidcTokenApi = "api/v1/oidc/token"
+
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	idTokenType            = "urn:ietf:params:oauth:token-type:id_token"
+)
+
+// TokenInfo is the metadata of an access token, as returned by the Access service. The token itself is never returned.
+type TokenInfo struct {
+	TokenId     string `json:"token_id"`
+	Subject     string `json:"subject"`
+	Expiry      int64  `json:"expiry,omitempty"`
+	IssuedAt    int64  `json:"issued_at"`
+	Issuer      string `json:"issuer"`
+	Description string `json:"description,omitempty"`
+	Refreshable bool   `json:"refreshable"`
+}
+
+type tokensResponse struct {
+	Tokens []TokenInfo `json:"tokens"`
+}
+
+type tokenExchangeRequest struct {
+	GrantType        string `json:"grant_type"`
+	SubjectTokenType string `json:"subject_token_type"`
+	SubjectToken     string `json:"subject_token"`
+	ProviderName     string `json:"provider_name"`
+	ProjectKey       string `json:"project_key,omitempty"`
+	ExpiresIn        *uint  `json:"expires_in,omitempty"`
+}
+
+// tokenService sends the access tokens requests, which aren't supported by the Access services manager, to the Access service.
+type tokenService struct {
+	client         *jfroghttpclient.JfrogHttpClient
+	serviceDetails auth.ServiceDetails
+}
+
+func newTokenService(serverDetails *config.ServerDetails) (*tokenService, error) {
+	accessManager, err := rtUtils.CreateAccessServiceManager(serverDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	serviceDetails, err := serverDetails.CreateAccessAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &tokenService{client: accessManager.Client(), serviceDetails: serviceDetails}, nil
+}
+
+func (ts *tokenService) getAll() ([]TokenInfo, error) {
+	httpDetails := ts.serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := ts.client.SendGet(ts.serviceDetails.GetUrl()+tokensApi, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	response := &tokensResponse{}
+	if err = json.Unmarshal(body, response); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return response.Tokens, nil
+}
+
+func (ts *tokenService) revoke(tokenId string) error {
+	httpDetails := ts.serviceDetails.CreateHttpClientDetails()
+	resp, body, err := ts.client.SendDelete(ts.serviceDetails.GetUrl()+tokensApi+"/"+url.PathEscape(tokenId), nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errorutils.CheckErrorf("access token '%s' does not exist", tokenId)
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
+
+// Exchanges an OIDC token, issued by the CI provider, for a JFrog access token.
+// The exchange doesn't require any other credentials, since the OIDC token is trusted by the configured OIDC integration.
+func (ts *tokenService) exchange(request tokenExchangeRequest) (response auth.CreateTokenResponseData, err error) {
+	content, err := json.Marshal(request)
+	if err != nil {
+		return response, errorutils.CheckError(err)
+	}
+	httpDetails := ts.serviceDetails.CreateHttpClientDetails()
+	servicesUtils.SetContentType("application/json", &httpDetails.Headers)
+	resp, body, err := ts.client.SendPost(ts.serviceDetails.GetUrl()+oidcTokenApi, content, &httpDetails)
+	if err != nil {
+		return response, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return response, err
+	}
+	return response, errorutils.CheckError(json.Unmarshal(body, &response))
+}
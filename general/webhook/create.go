@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// WebhookCreateCommand creates a platform webhook from a spec file.
+type WebhookCreateCommand struct {
+	serverDetails *config.ServerDetails
+	specPath      string
+	vars          string
+}
+
+func NewWebhookCreateCommand() *WebhookCreateCommand {
+	return &WebhookCreateCommand{}
+}
+
+func (wcc *WebhookCreateCommand) SetServerDetails(serverDetails *config.ServerDetails) *WebhookCreateCommand {
+	wcc.serverDetails = serverDetails
+	return wcc
+}
+
+func (wcc *WebhookCreateCommand) SetSpecPath(specPath string) *WebhookCreateCommand {
+	wcc.specPath = specPath
+	return wcc
+}
+
+func (wcc *WebhookCreateCommand) SetVars(vars string) *WebhookCreateCommand {
+	wcc.vars = vars
+	return wcc
+}
+
+func (wcc *WebhookCreateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return wcc.serverDetails, nil
+}
+
+func (wcc *WebhookCreateCommand) CommandName() string {
+	return "webhook_create"
+}
+
+func (wcc *WebhookCreateCommand) Run() error {
+	subscription, err := ReadSubscriptionSpec(wcc.specPath, wcc.vars)
+	if err != nil {
+		return err
+	}
+	service, err := newWebhookService(wcc.serverDetails)
+	if err != nil {
+		return err
+	}
+	log.Info("Creating webhook '" + subscription.Key + "'...")
+	if err = service.create(subscription); err != nil {
+		return err
+	}
+	log.Info("Webhook '" + subscription.Key + "' was created successfully.")
+	return nil
+}
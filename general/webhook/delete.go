@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type WebhookDeleteCommand struct {
+	serverDetails *config.ServerDetails
+	key           string
+	quiet         bool
+}
+
+func NewWebhookDeleteCommand() *WebhookDeleteCommand {
+	return &WebhookDeleteCommand{}
+}
+
+func (wdc *WebhookDeleteCommand) SetServerDetails(serverDetails *config.ServerDetails) *WebhookDeleteCommand {
+	wdc.serverDetails = serverDetails
+	return wdc
+}
+
+func (wdc *WebhookDeleteCommand) SetKey(key string) *WebhookDeleteCommand {
+	wdc.key = key
+	return wdc
+}
+
+func (wdc *WebhookDeleteCommand) SetQuiet(quiet bool) *WebhookDeleteCommand {
+	wdc.quiet = quiet
+	return wdc
+}
+
+func (wdc *WebhookDeleteCommand) ServerDetails() (*config.ServerDetails, error) {
+	return wdc.serverDetails, nil
+}
+
+func (wdc *WebhookDeleteCommand) CommandName() string {
+	return "webhook_delete"
+}
+
+func (wdc *WebhookDeleteCommand) Run() error {
+	if !wdc.quiet && !coreutils.AskYesNo("Are you sure you want to permanently delete the webhook "+wdc.key+"?", false) {
+		return nil
+	}
+	service, err := newWebhookService(wdc.serverDetails)
+	if err != nil {
+		return err
+	}
+	log.Info("Deleting webhook '" + wdc.key + "'...")
+	return service.delete(wdc.key)
+}
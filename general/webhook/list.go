@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"strings"
+)
+
+type WebhookListCommand struct {
+	serverDetails *config.ServerDetails
+	outputFormat  format.OutputFormat
+}
+
+type webhookRow struct {
+	Key        string `col-name:"Key"`
+	Domain     string `col-name:"Domain"`
+	EventTypes string `col-name:"Event Types"`
+	Enabled    bool   `col-name:"Enabled"`
+	Urls       string `col-name:"URLs"`
+}
+
+func NewWebhookListCommand() *WebhookListCommand {
+	return &WebhookListCommand{outputFormat: format.Table}
+}
+
+func (wlc *WebhookListCommand) SetServerDetails(serverDetails *config.ServerDetails) *WebhookListCommand {
+	wlc.serverDetails = serverDetails
+	return wlc
+}
+
+// SetOutputFormat sets the output format - 'table' or 'json'.
+func (wlc *WebhookListCommand) SetOutputFormat(outputFormat format.OutputFormat) *WebhookListCommand {
+	wlc.outputFormat = outputFormat
+	return wlc
+}
+
+func (wlc *WebhookListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return wlc.serverDetails, nil
+}
+
+func (wlc *WebhookListCommand) CommandName() string {
+	return "webhook_list"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (wlc *WebhookListCommand) IsReadOnly() bool {
+	return true
+}
+
+func (wlc *WebhookListCommand) Run() error {
+	service, err := newWebhookService(wlc.serverDetails)
+	if err != nil {
+		return err
+	}
+	subscriptions, err := service.getAll()
+	if err != nil {
+		return err
+	}
+	switch wlc.outputFormat {
+	case format.Json:
+		// The secrets of the handlers are never returned by the event service, so the webhooks can be safely printed.
+		content, err := json.Marshal(subscriptions)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	case format.Table:
+		var rows []webhookRow
+		for _, subscription := range subscriptions {
+			var urls []string
+			for _, handler := range subscription.Handlers {
+				urls = append(urls, handler.Url)
+			}
+			rows = append(rows, webhookRow{Key: subscription.Key, Domain: subscription.EventFilter.Domain,
+				EventTypes: strings.Join(subscription.EventFilter.EventTypes, ", "), Enabled: subscription.Enabled, Urls: strings.Join(urls, ", ")})
+		}
+		return coreutils.PrintTable(rows, "Webhooks", "No webhooks were found", false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s", wlc.outputFormat, format.Table, format.Json)
+	}
+}
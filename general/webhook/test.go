@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"net/http"
+	"time"
+)
+
+const (
+	eventAuthHeader   = "X-JFrog-Event-Auth"
+	sampleEventSource = "jfrog-cli/webhook-test"
+)
+
+// WebhookTestCommand fires a sample event of the webhook to each of its handlers, and verifies the receivers accept it.
+// The sample event is sent from the CLI machine, so the receivers should be reachable from it.
+// As the event service never returns the secrets of the handlers, test the webhook by its spec to verify the receivers
+// authenticate the events.
+type WebhookTestCommand struct {
+	serverDetails *config.ServerDetails
+	key           string
+	specPath      string
+	vars          string
+}
+
+type sampleEvent struct {
+	Domain          string         `json:"domain"`
+	EventType       string         `json:"event_type"`
+	Data            map[string]any `json:"data"`
+	SubscriptionKey string         `json:"subscription_key"`
+	JpdOrigin       string         `json:"jpd_origin"`
+	Source          string         `json:"source"`
+}
+
+func NewWebhookTestCommand() *WebhookTestCommand {
+	return &WebhookTestCommand{}
+}
+
+func (wtc *WebhookTestCommand) SetServerDetails(serverDetails *config.ServerDetails) *WebhookTestCommand {
+	wtc.serverDetails = serverDetails
+	return wtc
+}
+
+// SetKey sets the key of an existing webhook to test.
+func (wtc *WebhookTestCommand) SetKey(key string) *WebhookTestCommand {
+	wtc.key = key
+	return wtc
+}
+
+// SetSpecPath sets the spec of the webhook to test, instead of an existing webhook.
+func (wtc *WebhookTestCommand) SetSpecPath(specPath string) *WebhookTestCommand {
+	wtc.specPath = specPath
+	return wtc
+}
+
+func (wtc *WebhookTestCommand) SetVars(vars string) *WebhookTestCommand {
+	wtc.vars = vars
+	return wtc
+}
+
+func (wtc *WebhookTestCommand) ServerDetails() (*config.ServerDetails, error) {
+	return wtc.serverDetails, nil
+}
+
+func (wtc *WebhookTestCommand) CommandName() string {
+	return "webhook_test"
+}
+
+func (wtc *WebhookTestCommand) Run() error {
+	subscription, err := wtc.getSubscription()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(createSampleEvent(subscription, wtc.serverDetails.Url))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	client, err := httpclient.ClientBuilder().SetRetries(0).Build()
+	if err != nil {
+		return err
+	}
+	var failures int
+	for _, handler := range subscription.Handlers {
+		if err = sendSampleEvent(client, handler, content); err != nil {
+			log.Error(fmt.Sprintf("The receiver %s rejected the sample event: %s", handler.Url, err.Error()))
+			failures++
+			continue
+		}
+		log.Info(fmt.Sprintf("The receiver %s accepted the sample event.", handler.Url))
+	}
+	if failures > 0 {
+		return errorutils.CheckErrorf("%d out of %d receivers of webhook '%s' failed", failures, len(subscription.Handlers), subscription.Key)
+	}
+	return nil
+}
+
+func (wtc *WebhookTestCommand) getSubscription() (*Subscription, error) {
+	if (wtc.key == "") == (wtc.specPath == "") {
+		return nil, errorutils.CheckErrorf("either a webhook key or a webhook spec should be provided")
+	}
+	if wtc.specPath != "" {
+		return ReadSubscriptionSpec(wtc.specPath, wtc.vars)
+	}
+	service, err := newWebhookService(wtc.serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := service.get(wtc.key)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, errorutils.CheckErrorf("webhook '%s' does not exist", wtc.key)
+	}
+	return subscription, nil
+}
+
+// Returns a sample event of the first event type of the webhook.
+func createSampleEvent(subscription *Subscription, platformUrl string) *sampleEvent {
+	event := &sampleEvent{
+		Domain:          subscription.EventFilter.Domain,
+		EventType:       subscription.EventFilter.EventTypes[0],
+		SubscriptionKey: subscription.Key,
+		JpdOrigin:       platformUrl,
+		Source:          sampleEventSource,
+	}
+	switch event.Domain {
+	case ArtifactDomain:
+		event.Data = map[string]any{"repo_key": "sample-repo", "path": "sample-dir/sample-artifact", "name": "sample-artifact", "size": 1024,
+			"sha256": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"}
+	case BuildDomain:
+		event.Data = map[string]any{"build_name": "sample-build", "build_number": "1", "build_started": time.Now().UTC().Format(time.RFC3339)}
+	case DistributionDomain:
+		event.Data = map[string]any{"release_bundle_name": "sample-release-bundle", "release_bundle_version": "1.0.0", "status": event.EventType}
+	}
+	return event
+}
+
+// Sends the sample event with the custom headers of the handler. The handler secret is sent as is, or used for signing the event.
+func sendSampleEvent(client *httpclient.HttpClient, handler Handler, content []byte) error {
+	headers := map[string]string{"Content-Type": "application/json"}
+	for _, header := range handler.CustomHttpHeaders {
+		headers[header.Name] = header.Value
+	}
+	if handler.Secret != "" {
+		if handler.UseSecretForSigning {
+			mac := hmac.New(sha256.New, []byte(handler.Secret))
+			mac.Write(content)
+			headers[eventAuthHeader] = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		} else {
+			headers[eventAuthHeader] = handler.Secret
+		}
+	}
+	resp, body, err := client.SendPost(handler.Url, content, httputils.HttpClientDetails{Headers: headers}, "")
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent)
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// WebhookUpdateCommand replaces an existing platform webhook with the content of a spec file.
+type WebhookUpdateCommand struct {
+	serverDetails *config.ServerDetails
+	specPath      string
+	vars          string
+}
+
+func NewWebhookUpdateCommand() *WebhookUpdateCommand {
+	return &WebhookUpdateCommand{}
+}
+
+func (wuc *WebhookUpdateCommand) SetServerDetails(serverDetails *config.ServerDetails) *WebhookUpdateCommand {
+	wuc.serverDetails = serverDetails
+	return wuc
+}
+
+func (wuc *WebhookUpdateCommand) SetSpecPath(specPath string) *WebhookUpdateCommand {
+	wuc.specPath = specPath
+	return wuc
+}
+
+func (wuc *WebhookUpdateCommand) SetVars(vars string) *WebhookUpdateCommand {
+	wuc.vars = vars
+	return wuc
+}
+
+func (wuc *WebhookUpdateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return wuc.serverDetails, nil
+}
+
+func (wuc *WebhookUpdateCommand) CommandName() string {
+	return "webhook_update"
+}
+
+func (wuc *WebhookUpdateCommand) Run() error {
+	subscription, err := ReadSubscriptionSpec(wuc.specPath, wuc.vars)
+	if err != nil {
+		return err
+	}
+	service, err := newWebhookService(wuc.serverDetails)
+	if err != nil {
+		return err
+	}
+	existing, err := service.get(subscription.Key)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errorutils.CheckErrorf("webhook '%s' does not exist", subscription.Key)
+	}
+	log.Info("Updating webhook '" + subscription.Key + "'...")
+	if err = service.update(subscription); err != nil {
+		return err
+	}
+	log.Info("Webhook '" + subscription.Key + "' was updated successfully.")
+	return nil
+}
@@ -0,0 +1,216 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"net/http"
+	"net/url"
+)
+
+const (
+	subscriptionsApi   = "event/api/v1/subscriptions"
+	webhookHandlerType = "webhook"
+)
+
+// The supported event domains and their event types.
+const (
+	ArtifactDomain     = "artifact"
+	BuildDomain        = "build"
+	DistributionDomain = "distribution"
+)
+
+var domainEventTypes = map[string][]string{
+	ArtifactDomain:     {"deployed", "deleted", "moved", "copied", "cached"},
+	BuildDomain:        {"uploaded", "deleted", "promoted"},
+	DistributionDomain: {"distribute_started", "distribute_completed", "distribute_aborted", "distribute_failed", "delete_started", "delete_completed", "delete_failed"},
+}
+
+// Subscription is a platform webhook, sending the events matching its filter to its handlers.
+type Subscription struct {
+	Key         string      `json:"key"`
+	Description string      `json:"description,omitempty"`
+	Enabled     bool        `json:"enabled"`
+	EventFilter EventFilter `json:"event_filter"`
+	Handlers    []Handler   `json:"handlers"`
+}
+
+type EventFilter struct {
+	Domain     string         `json:"domain"`
+	EventTypes []string       `json:"event_types"`
+	Criteria   map[string]any `json:"criteria,omitempty"`
+}
+
+type Handler struct {
+	HandlerType         string   `json:"handler_type"`
+	Url                 string   `json:"url"`
+	Secret              string   `json:"secret,omitempty"`
+	UseSecretForSigning bool     `json:"use_secret_for_signing,omitempty"`
+	Proxy               string   `json:"proxy,omitempty"`
+	CustomHttpHeaders   []Header `json:"custom_http_headers,omitempty"`
+}
+
+type Header struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ReadSubscriptionSpec reads a webhook spec file, after replacing its vars.
+func ReadSubscriptionSpec(specPath, vars string) (*Subscription, error) {
+	content, err := fileutils.ReadFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(vars) > 0 {
+		content = coreutils.ReplaceVars(content, coreutils.SpecVarsStringToMap(vars))
+	}
+	subscription := &Subscription{Enabled: true}
+	if err = json.Unmarshal(content, subscription); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the webhook spec %s: %s", specPath, err.Error())
+	}
+	return subscription, subscription.validate()
+}
+
+func (s *Subscription) validate() error {
+	if s.Key == "" {
+		return errorutils.CheckErrorf("the webhook key is mandatory")
+	}
+	eventTypes, exists := domainEventTypes[s.EventFilter.Domain]
+	if !exists {
+		return errorutils.CheckErrorf("unsupported event domain '%s'. Possible values are: %s, %s, %s", s.EventFilter.Domain, ArtifactDomain, BuildDomain, DistributionDomain)
+	}
+	if len(s.EventFilter.EventTypes) == 0 {
+		return errorutils.CheckErrorf("at least one event type is mandatory")
+	}
+	for _, eventType := range s.EventFilter.EventTypes {
+		if !contains(eventTypes, eventType) {
+			return errorutils.CheckErrorf("unsupported event type '%s' of the '%s' domain. Possible values are: %v", eventType, s.EventFilter.Domain, eventTypes)
+		}
+	}
+	if len(s.Handlers) == 0 {
+		return errorutils.CheckErrorf("at least one handler is mandatory")
+	}
+	for i := range s.Handlers {
+		if s.Handlers[i].Url == "" {
+			return errorutils.CheckErrorf("the handler URL is mandatory")
+		}
+		if s.Handlers[i].HandlerType == "" {
+			s.Handlers[i].HandlerType = webhookHandlerType
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookService sends the webhook subscriptions requests to the platform event service.
+type webhookService struct {
+	client         *jfroghttpclient.JfrogHttpClient
+	serviceDetails auth.ServiceDetails
+	subscriptions  string
+}
+
+func newWebhookService(serverDetails *config.ServerDetails) (*webhookService, error) {
+	accessManager, err := rtUtils.CreateAccessServiceManager(serverDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	serviceDetails, err := serverDetails.CreateAccessAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &webhookService{client: accessManager.Client(), serviceDetails: serviceDetails,
+		subscriptions: clientUtils.AddTrailingSlashIfNeeded(serverDetails.Url) + subscriptionsApi}, nil
+}
+
+func (ws *webhookService) getAll() ([]Subscription, error) {
+	httpDetails := ws.serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := ws.client.SendGet(ws.subscriptions, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	subscriptions := []Subscription{}
+	return subscriptions, errorutils.CheckError(json.Unmarshal(body, &subscriptions))
+}
+
+// Returns the webhook with the provided key, or nil if it doesn't exist.
+func (ws *webhookService) get(key string) (*Subscription, error) {
+	httpDetails := ws.serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := ws.client.SendGet(ws.subscriptionUrl(key), true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	subscription := &Subscription{}
+	return subscription, errorutils.CheckError(json.Unmarshal(body, subscription))
+}
+
+func (ws *webhookService) create(subscription *Subscription) error {
+	return ws.send(http.MethodPost, ws.subscriptions, subscription)
+}
+
+func (ws *webhookService) update(subscription *Subscription) error {
+	return ws.send(http.MethodPut, ws.subscriptionUrl(subscription.Key), subscription)
+}
+
+func (ws *webhookService) delete(key string) error {
+	httpDetails := ws.serviceDetails.CreateHttpClientDetails()
+	resp, body, err := ws.client.SendDelete(ws.subscriptionUrl(key), nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errorutils.CheckErrorf("webhook '%s' does not exist", key)
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
+
+func (ws *webhookService) send(method, requestUrl string, subscription *Subscription) error {
+	content, err := json.Marshal(subscription)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	httpDetails := ws.serviceDetails.CreateHttpClientDetails()
+	servicesUtils.SetContentType("application/json", &httpDetails.Headers)
+	var resp *http.Response
+	var body []byte
+	if method == http.MethodPut {
+		resp, body, err = ws.client.SendPut(requestUrl, content, &httpDetails)
+	} else {
+		resp, body, err = ws.client.SendPost(requestUrl, content, &httpDetails)
+	}
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return errorutils.CheckErrorf("webhook '%s' already exists", subscription.Key)
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated, http.StatusNoContent)
+}
+
+func (ws *webhookService) subscriptionUrl(key string) string {
+	return fmt.Sprintf("%s/%s", ws.subscriptions, url.PathEscape(key))
+}
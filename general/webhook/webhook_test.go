@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testSpecPath = "testdata/webhook-spec.json"
+
+func TestReadSubscriptionSpec(t *testing.T) {
+	subscription, err := ReadSubscriptionSpec(testSpecPath, "key=deployments;url=https://hooks.example.com/jfrog")
+	require.NoError(t, err)
+	assert.Equal(t, "deployments", subscription.Key)
+	assert.True(t, subscription.Enabled)
+	assert.Equal(t, []string{"deployed"}, subscription.EventFilter.EventTypes)
+	assert.Equal(t, []any{"libs-release-local"}, subscription.EventFilter.Criteria["repoKeys"])
+	assert.Equal(t, webhookHandlerType, subscription.Handlers[0].HandlerType)
+	assert.Equal(t, "https://hooks.example.com/jfrog", subscription.Handlers[0].Url)
+}
+
+func TestReadSubscriptionSpecErrors(t *testing.T) {
+	testCases := []struct {
+		spec     string
+		errorMsg string
+	}{
+		{`{"event_filter": {"domain": "artifact", "event_types": ["deployed"]}, "handlers": [{"url": "https://example.com"}]}`, "key is mandatory"},
+		{`{"key": "a", "event_filter": {"domain": "users", "event_types": ["created"]}, "handlers": [{"url": "https://example.com"}]}`, "unsupported event domain"},
+		{`{"key": "a", "event_filter": {"domain": "build", "event_types": ["deployed"]}, "handlers": [{"url": "https://example.com"}]}`, "unsupported event type"},
+		{`{"key": "a", "event_filter": {"domain": "build", "event_types": ["promoted"]}, "handlers": []}`, "at least one handler"},
+	}
+	specPath := filepath.Join(t.TempDir(), "spec.json")
+	for _, testCase := range testCases {
+		assert.NoError(t, os.WriteFile(specPath, []byte(testCase.spec), 0600))
+		_, err := ReadSubscriptionSpec(specPath, "")
+		assert.ErrorContains(t, err, testCase.errorMsg)
+	}
+}
+
+func TestWebhookCreate(t *testing.T) {
+	var created Subscription
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/"+subscriptionsApi, r.URL.Path)
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(content, &created))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer testServer.Close()
+
+	createCmd := NewWebhookCreateCommand().SetServerDetails(&config.ServerDetails{Url: testServer.URL + "/"}).
+		SetSpecPath(testSpecPath).SetVars("key=deployments;url=https://hooks.example.com/jfrog")
+	require.NoError(t, createCmd.Run())
+	assert.Equal(t, "deployments", created.Key)
+	assert.Equal(t, "s3cr3t", created.Handlers[0].Secret)
+}
+
+func TestWebhookTest(t *testing.T) {
+	var event sampleEvent
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mac := hmac.New(sha256.New, []byte("s3cr3t"))
+		mac.Write(content)
+		if r.Header.Get(eventAuthHeader) != "sha256="+hex.EncodeToString(mac.Sum(nil)) || r.Header.Get("X-Team") != "platform" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.NoError(t, json.Unmarshal(content, &event))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	testCmd := NewWebhookTestCommand().SetServerDetails(&config.ServerDetails{Url: "https://acme.jfrog.io/"}).
+		SetSpecPath(testSpecPath).SetVars("key=deployments;url=" + receiver.URL)
+	require.NoError(t, testCmd.Run())
+	assert.Equal(t, ArtifactDomain, event.Domain)
+	assert.Equal(t, "deployed", event.EventType)
+	assert.Equal(t, "deployments", event.SubscriptionKey)
+	assert.Equal(t, "sample-repo", event.Data["repo_key"])
+
+	// A receiver rejecting the event
+	testCmd.SetVars("key=deployments;url=" + receiver.URL + "/unknown")
+	receiver.Config.Handler = http.NotFoundHandler()
+	assert.ErrorContains(t, testCmd.Run(), "1 out of 1 receivers")
+}
@@ -4,10 +4,12 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/lifecycle"
 	"github.com/jfrog/jfrog-client-go/lifecycle/services"
 	clientUtils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"os"
 )
 
 const minimalLifecycleArtifactoryVersion = "7.63.2"
@@ -37,6 +39,15 @@ func (rbc *releaseBundleCmd) getPrerequisites() (servicesManager *lifecycle.Life
 	return
 }
 
+// Returns the provided signing key name, or the key name set by the JFROG_CLI_SIGNING_KEY environment variable if not provided.
+// If both are empty, the default signing key configured in the platform is used.
+func getSigningKeyName(signingKeyName string) string {
+	if signingKeyName != "" {
+		return signingKeyName
+	}
+	return os.Getenv(coreutils.SigningKey)
+}
+
 func validateArtifactoryVersionSupported(serverDetails *config.ServerDetails) error {
 	rtServiceManager, err := utils.CreateServiceManager(serverDetails, 3, 0, false)
 	if err != nil {
@@ -12,6 +12,8 @@ const (
 	missingCreationSourcesErrMsg  = "unexpected err while validating spec - could not detect any creation sources"
 	multipleCreationSourcesErrMsg = "multiple creation sources were detected in separate spec files. Only a single creation source should be provided. Detected:"
 	singleAqlErrMsg               = "only a single aql query can be provided"
+	asyncManifestErrMsg           = "the release bundle manifest can only be created when the release bundle is created synchronously"
+	stdoutManifestPath            = "-"
 )
 
 type ReleaseBundleCreateCommand struct {
@@ -21,6 +23,7 @@ type ReleaseBundleCreateCommand struct {
 	// Backward compatibility:
 	buildsSpecPath         string
 	releaseBundlesSpecPath string
+	manifestPath           string
 }
 
 func NewReleaseBundleCreateCommand() *ReleaseBundleCreateCommand {
@@ -62,6 +65,12 @@ func (rbc *ReleaseBundleCreateCommand) SetSpec(spec *spec.SpecFiles) *ReleaseBun
 	return rbc
 }
 
+// SetManifestPath sets the file to write the manifest of the created release bundle contents to. Use '-' to print the manifest to the standard output.
+func (rbc *ReleaseBundleCreateCommand) SetManifestPath(manifestPath string) *ReleaseBundleCreateCommand {
+	rbc.manifestPath = manifestPath
+	return rbc
+}
+
 // Deprecated
 func (rbc *ReleaseBundleCreateCommand) SetBuildsSpecPath(buildsSpecPath string) *ReleaseBundleCreateCommand {
 	rbc.buildsSpecPath = buildsSpecPath
@@ -86,6 +95,10 @@ func (rbc *ReleaseBundleCreateCommand) Run() error {
 	if err := validateArtifactoryVersionSupported(rbc.serverDetails); err != nil {
 		return err
 	}
+	if rbc.manifestPath != "" && !rbc.sync {
+		return errorutils.CheckErrorf(asyncManifestErrMsg)
+	}
+	rbc.signingKeyName = getSigningKeyName(rbc.signingKeyName)
 
 	servicesManager, rbDetails, queryParams, err := rbc.getPrerequisites()
 	if err != nil {
@@ -99,16 +112,20 @@ func (rbc *ReleaseBundleCreateCommand) Run() error {
 
 	switch sourceType {
 	case services.Aql:
-		return rbc.createFromAql(servicesManager, rbDetails, queryParams)
+		err = rbc.createFromAql(servicesManager, rbDetails, queryParams)
 	case services.Artifacts:
-		return rbc.createFromArtifacts(servicesManager, rbDetails, queryParams)
+		err = rbc.createFromArtifacts(servicesManager, rbDetails, queryParams)
 	case services.Builds:
-		return rbc.createFromBuilds(servicesManager, rbDetails, queryParams)
+		err = rbc.createFromBuilds(servicesManager, rbDetails, queryParams)
 	case services.ReleaseBundles:
-		return rbc.createFromReleaseBundles(servicesManager, rbDetails, queryParams)
+		err = rbc.createFromReleaseBundles(servicesManager, rbDetails, queryParams)
 	default:
 		return errorutils.CheckErrorf("unknown source for release bundle creation was provided")
 	}
+	if err != nil || rbc.manifestPath == "" {
+		return err
+	}
+	return rbc.writeManifest(servicesManager, rbDetails, sourceType)
 }
 
 func (rbc *ReleaseBundleCreateCommand) identifySourceType() (services.SourceType, error) {
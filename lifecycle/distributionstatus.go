@@ -0,0 +1,293 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	distributionTrackersApi          = "api/v2/distribution/trackers"
+	defaultDistributionStatusTimeout = 60 * time.Minute
+)
+
+var distributionStatusPollingInterval = services.DefaultSyncSleepInterval
+
+// ReleaseBundleDistributionStatusCommand reports the per-site status of a release bundle distribution to the edge nodes.
+// With 'wait', the status is polled and every site status change is logged, until the distribution ends or the timeout is reached.
+// The command fails if the distribution failed on any of the sites.
+type ReleaseBundleDistributionStatusCommand struct {
+	releaseBundleCmd
+	trackerId string
+	wait      bool
+	timeout   time.Duration
+	report    *DistributionStatusReport
+}
+
+type DistributionStatusReport struct {
+	ReleaseBundleName    string                   `json:"release_bundle_name"`
+	ReleaseBundleVersion string                   `json:"release_bundle_version"`
+	TrackerId            string                   `json:"tracker_id"`
+	Status               string                   `json:"status"`
+	Sites                []DistributionSiteReport `json:"sites"`
+}
+
+type DistributionSiteReport struct {
+	Name             string `json:"name"`
+	Type             string `json:"type,omitempty"`
+	Status           string `json:"status"`
+	DistributedFiles string `json:"distributed_files,omitempty"`
+	TotalFiles       string `json:"total_files,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+func NewReleaseBundleDistributionStatusCommand() *ReleaseBundleDistributionStatusCommand {
+	return &ReleaseBundleDistributionStatusCommand{timeout: defaultDistributionStatusTimeout}
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReleaseBundleDistributionStatusCommand {
+	rbs.serverDetails = serverDetails
+	return rbs
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) SetReleaseBundleName(releaseBundleName string) *ReleaseBundleDistributionStatusCommand {
+	rbs.releaseBundleName = releaseBundleName
+	return rbs
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) SetReleaseBundleVersion(releaseBundleVersion string) *ReleaseBundleDistributionStatusCommand {
+	rbs.releaseBundleVersion = releaseBundleVersion
+	return rbs
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) SetReleaseBundleProject(rbProjectKey string) *ReleaseBundleDistributionStatusCommand {
+	rbs.rbProjectKey = rbProjectKey
+	return rbs
+}
+
+// SetTrackerId sets the distribution to report. By default, the latest distribution of the release bundle is reported.
+func (rbs *ReleaseBundleDistributionStatusCommand) SetTrackerId(trackerId string) *ReleaseBundleDistributionStatusCommand {
+	rbs.trackerId = trackerId
+	return rbs
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) SetWait(wait bool) *ReleaseBundleDistributionStatusCommand {
+	rbs.wait = wait
+	return rbs
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) SetTimeout(timeout time.Duration) *ReleaseBundleDistributionStatusCommand {
+	rbs.timeout = timeout
+	return rbs
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) Report() *DistributionStatusReport {
+	return rbs.report
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) CommandName() string {
+	return "rb_distribution_status"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (rbs *ReleaseBundleDistributionStatusCommand) IsReadOnly() bool {
+	return true
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rbs.serverDetails, nil
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) Run() error {
+	servicesManager, err := utils.CreateLifecycleServiceManager(rbs.serverDetails, false)
+	if err != nil {
+		return err
+	}
+	lcDetails, err := rbs.serverDetails.CreateLifecycleAuthConfig()
+	if err != nil {
+		return err
+	}
+	client := servicesManager.Client()
+
+	if rbs.trackerId == "" {
+		if rbs.trackerId, err = rbs.getLatestTrackerId(client, lcDetails); err != nil {
+			return err
+		}
+	}
+
+	var statusResp *distribution.DistributionStatusResponse
+	if rbs.wait {
+		statusResp, err = rbs.waitForDistribution(client, lcDetails)
+	} else {
+		statusResp, err = rbs.getDistributionStatus(client, lcDetails)
+	}
+	if err != nil {
+		return err
+	}
+
+	rbs.report = rbs.createReport(statusResp)
+	content, err := json.Marshal(rbs.report)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(clientUtils.IndentJson(content))
+	return getDistributionFailuresError(rbs.report)
+}
+
+// Returns the tracker ID of the latest distribution of the release bundle.
+func (rbs *ReleaseBundleDistributionStatusCommand) getLatestTrackerId(client *jfroghttpclient.JfrogHttpClient, lcDetails auth.ServiceDetails) (string, error) {
+	body, err := rbs.sendGet(client, lcDetails, path.Join(distributionTrackersApi, rbs.releaseBundleName, rbs.releaseBundleVersion))
+	if err != nil {
+		return "", err
+	}
+	var distributions services.GetDistributionsResponse
+	if err = json.Unmarshal(body, &distributions); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	var latest string
+	var latestId int64 = -1
+	for _, dist := range distributions {
+		if dist.Type != string(distribution.Distribute) {
+			continue
+		}
+		id, err := dist.FriendlyId.Int64()
+		if err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		if id > latestId {
+			latest, latestId = dist.FriendlyId.String(), id
+		}
+	}
+	if latest == "" {
+		return "", errorutils.CheckErrorf("no distributions were found for release bundle %s/%s", rbs.releaseBundleName, rbs.releaseBundleVersion)
+	}
+	return latest, nil
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) getDistributionStatus(client *jfroghttpclient.JfrogHttpClient, lcDetails auth.ServiceDetails) (*distribution.DistributionStatusResponse, error) {
+	body, err := rbs.sendGet(client, lcDetails, path.Join(distributionTrackersApi, rbs.releaseBundleName, rbs.releaseBundleVersion, rbs.trackerId))
+	if err != nil {
+		return nil, err
+	}
+	var statusResp distribution.DistributionStatusResponse
+	if err = json.Unmarshal(body, &statusResp); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &statusResp, nil
+}
+
+// Polls the distribution status until it ends, and logs every site status change.
+func (rbs *ReleaseBundleDistributionStatusCommand) waitForDistribution(client *jfroghttpclient.JfrogHttpClient, lcDetails auth.ServiceDetails) (*distribution.DistributionStatusResponse, error) {
+	sitesStatuses := make(map[string]distribution.DistributionStatus)
+	var statusResp *distribution.DistributionStatusResponse
+	pollingExecutor := &httputils.PollingExecutor{
+		Timeout:         rbs.timeout,
+		PollingInterval: distributionStatusPollingInterval,
+		MsgPrefix:       fmt.Sprintf("Waiting for the distribution of %s/%s...", rbs.releaseBundleName, rbs.releaseBundleVersion),
+		PollingAction: func() (shouldStop bool, responseBody []byte, err error) {
+			statusResp, err = rbs.getDistributionStatus(client, lcDetails)
+			if err != nil {
+				return true, nil, err
+			}
+			logSitesStatusChanges(statusResp.Sites, sitesStatuses)
+			return isDistributionEnded(statusResp.Status), nil, nil
+		},
+	}
+	_, err := pollingExecutor.Execute()
+	if errors.As(err, &clientUtils.RetryExecutorTimeoutError{}) {
+		return nil, errorutils.CheckErrorf("the distribution of %s/%s did not end within %s. Last status: %s",
+			rbs.releaseBundleName, rbs.releaseBundleVersion, rbs.timeout, statusResp.Status)
+	}
+	return statusResp, err
+}
+
+func logSitesStatusChanges(sites []distribution.DistributionSiteStatus, lastStatuses map[string]distribution.DistributionStatus) {
+	for _, site := range sites {
+		if lastStatus, exists := lastStatuses[site.TargetArtifactory.Name]; exists && lastStatus == site.Status {
+			continue
+		}
+		lastStatuses[site.TargetArtifactory.Name] = site.Status
+		log.Info(fmt.Sprintf("Site '%s': %s (%s/%s files)", site.TargetArtifactory.Name, site.Status, site.DistributedFiles, site.TotalFiles))
+	}
+}
+
+func isDistributionEnded(status distribution.DistributionStatus) bool {
+	return status == distribution.Completed || status == distribution.Failed
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) createReport(statusResp *distribution.DistributionStatusResponse) *DistributionStatusReport {
+	report := &DistributionStatusReport{
+		ReleaseBundleName:    rbs.releaseBundleName,
+		ReleaseBundleVersion: rbs.releaseBundleVersion,
+		TrackerId:            rbs.trackerId,
+		Status:               string(statusResp.Status),
+		Sites:                []DistributionSiteReport{},
+	}
+	for _, site := range statusResp.Sites {
+		siteReport := DistributionSiteReport{
+			Name:             site.TargetArtifactory.Name,
+			Type:             site.TargetArtifactory.Type,
+			Status:           string(site.Status),
+			DistributedFiles: site.DistributedFiles.String(),
+			TotalFiles:       site.TotalFiles.String(),
+			Error:            site.Error,
+		}
+		if siteReport.Error == "" && len(site.FileErrors) > 0 {
+			siteReport.Error = strings.Join(site.FileErrors, "; ")
+		}
+		report.Sites = append(report.Sites, siteReport)
+	}
+	sort.Slice(report.Sites, func(i, j int) bool {
+		return report.Sites[i].Name < report.Sites[j].Name
+	})
+	return report
+}
+
+// Returns an error listing the sites the distribution failed on, if any.
+func getDistributionFailuresError(report *DistributionStatusReport) error {
+	var failedSites []string
+	for _, site := range report.Sites {
+		if site.Status == string(distribution.Failed) {
+			failedSites = append(failedSites, site.Name)
+		}
+	}
+	if len(failedSites) == 0 {
+		if report.Status == string(distribution.Failed) {
+			return errorutils.CheckErrorf("the distribution of %s/%s failed", report.ReleaseBundleName, report.ReleaseBundleVersion)
+		}
+		return nil
+	}
+	return errorutils.CheckErrorf("the distribution of %s/%s failed on %d out of %d sites: %s",
+		report.ReleaseBundleName, report.ReleaseBundleVersion, len(failedSites), len(report.Sites), strings.Join(failedSites, ", "))
+}
+
+func (rbs *ReleaseBundleDistributionStatusCommand) sendGet(client *jfroghttpclient.JfrogHttpClient, lcDetails auth.ServiceDetails, restApi string) ([]byte, error) {
+	requestFullUrl, err := clientUtils.BuildUrl(lcDetails.GetUrl(), restApi, distribution.GetProjectQueryParam(rbs.rbProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	httpClientsDetails := lcDetails.CreateHttpClientDetails()
+	resp, body, _, err := client.SendGet(requestFullUrl, true, &httpClientsDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
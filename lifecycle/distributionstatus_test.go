@@ -0,0 +1,56 @@
+package lifecycle
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDistributionStatusWait(t *testing.T) {
+	previousInterval := distributionStatusPollingInterval
+	distributionStatusPollingInterval = time.Millisecond
+	defer func() {
+		distributionStatusPollingInterval = previousInterval
+	}()
+
+	var statusRequests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.URL.Path {
+		case "/api/v2/distribution/trackers/my-rb/1.0.0":
+			response = `[{"distribution_tracker_friendly_id":1,"type":"distribute"},{"distribution_tracker_friendly_id":3,"type":"distribute"},{"distribution_tracker_friendly_id":4,"type":"delete_release_bundle_version"}]`
+		case "/api/v2/distribution/trackers/my-rb/1.0.0/3":
+			statusRequests++
+			if statusRequests == 1 {
+				response = `{"status":"In progress","sites":[{"status":"In progress","target_artifactory":{"name":"edge-1"}},{"status":"In progress","target_artifactory":{"name":"edge-2"}}]}`
+			} else {
+				response = `{"status":"Failed","sites":[{"status":"Completed","target_artifactory":{"name":"edge-1"},"total_files":2,"distributed_files":2},{"status":"Failed","general_error":"disk full","target_artifactory":{"name":"edge-2"}}]}`
+			}
+		default:
+			assert.Fail(t, "unexpected request: "+r.URL.Path)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+
+	statusCmd := NewReleaseBundleDistributionStatusCommand().SetServerDetails(&config.ServerDetails{LifecycleUrl: testServer.URL + "/"}).
+		SetReleaseBundleName("my-rb").SetReleaseBundleVersion("1.0.0").SetWait(true).SetTimeout(time.Second)
+	assert.EqualError(t, statusCmd.Run(), "the distribution of my-rb/1.0.0 failed on 1 out of 2 sites: edge-2")
+	assert.Equal(t, 2, statusRequests)
+	assert.Equal(t, []DistributionSiteReport{
+		{Name: "edge-1", Status: "Completed", DistributedFiles: "2", TotalFiles: "2"},
+		{Name: "edge-2", Status: "Failed", Error: "disk full"},
+	}, statusCmd.Report().Sites)
+}
+
+func TestGetDistributionFailuresError(t *testing.T) {
+	report := &DistributionStatusReport{ReleaseBundleName: "rb", ReleaseBundleVersion: "1", Status: "Completed",
+		Sites: []DistributionSiteReport{{Name: "edge-1", Status: "Completed"}}}
+	assert.NoError(t, getDistributionFailuresError(report))
+	report.Status = "Failed"
+	assert.EqualError(t, getDistributionFailuresError(report), "the distribution of rb/1 failed")
+}
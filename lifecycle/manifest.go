@@ -0,0 +1,90 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+	"time"
+)
+
+// ReleaseBundleManifest describes the contents of a created release bundle, for reviewing it before promotion or distribution.
+type ReleaseBundleManifest struct {
+	Name       string              `json:"name"`
+	Version    string              `json:"version"`
+	Project    string              `json:"project,omitempty"`
+	SourceType services.SourceType `json:"source_type"`
+	SigningKey string              `json:"signing_key,omitempty"`
+	Created    time.Time           `json:"created"`
+	CreatedBy  string              `json:"created_by,omitempty"`
+	Artifacts  []ManifestArtifact  `json:"artifacts"`
+}
+
+type ManifestArtifact struct {
+	Path             string              `json:"path"`
+	Sha256           string              `json:"sha256"`
+	SourceRepository string              `json:"source_repository,omitempty"`
+	PackageType      string              `json:"package_type,omitempty"`
+	Size             int                 `json:"size"`
+	Properties       map[string][]string `json:"properties,omitempty"`
+}
+
+func (rbc *ReleaseBundleCreateCommand) createManifest(servicesManager *lifecycle.LifecycleServicesManager,
+	rbDetails services.ReleaseBundleDetails, sourceType services.SourceType) (*ReleaseBundleManifest, error) {
+	specResp, err := servicesManager.GetReleaseBundleSpecification(rbDetails)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ReleaseBundleManifest{
+		Name:       rbDetails.ReleaseBundleName,
+		Version:    rbDetails.ReleaseBundleVersion,
+		Project:    rbc.rbProjectKey,
+		SourceType: sourceType,
+		SigningKey: rbc.signingKeyName,
+		Created:    specResp.Created,
+		CreatedBy:  specResp.CreatedBy,
+		Artifacts:  []ManifestArtifact{},
+	}
+	for _, artifact := range specResp.Artifacts {
+		manifestArtifact := ManifestArtifact{
+			Path:             artifact.Path,
+			Sha256:           artifact.Checksum,
+			SourceRepository: artifact.SourceRepositoryKey,
+			PackageType:      artifact.PackageType,
+			Size:             artifact.Size,
+		}
+		if len(artifact.Properties) > 0 {
+			manifestArtifact.Properties = make(map[string][]string, len(artifact.Properties))
+			for _, property := range artifact.Properties {
+				manifestArtifact.Properties[property.Key] = property.Values
+			}
+		}
+		manifest.Artifacts = append(manifest.Artifacts, manifestArtifact)
+	}
+	return manifest, nil
+}
+
+// Writes the manifest of the created release bundle to the manifest path, or to the standard output if the path is '-'.
+func (rbc *ReleaseBundleCreateCommand) writeManifest(servicesManager *lifecycle.LifecycleServicesManager,
+	rbDetails services.ReleaseBundleDetails, sourceType services.SourceType) error {
+	manifest, err := rbc.createManifest(servicesManager, rbDetails, sourceType)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if rbc.manifestPath == stdoutManifestPath {
+		log.Output(clientUtils.IndentJson(content))
+		return nil
+	}
+	if err = errorutils.CheckError(os.WriteFile(rbc.manifestPath, []byte(clientUtils.IndentJson(content)), 0644)); err != nil {
+		return err
+	}
+	log.Info("The release bundle manifest was written to", rbc.manifestPath)
+	return nil
+}
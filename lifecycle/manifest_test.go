@@ -0,0 +1,52 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteManifest(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/release_bundle/records/my-rb/1.0.0", r.URL.Path)
+		_, err := w.Write([]byte(`{"created_by":"admin","artifacts":[{"path":"generic-local/a.zip","checksum":"abc","source_repository_key":"generic-local","package_type":"generic","size":3,"properties":[{"key":"k","values":["v"]}]}]}`))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+
+	rbc := NewReleaseBundleCreateCommand().SetReleaseBundleName("my-rb").SetReleaseBundleVersion("1.0.0").SetSigningKeyName("my-key")
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	rbc.SetManifestPath(manifestPath)
+	servicesManager, err := utils.CreateLifecycleServiceManager(&config.ServerDetails{LifecycleUrl: testServer.URL + "/"}, false)
+	assert.NoError(t, err)
+	rbDetails := services.ReleaseBundleDetails{ReleaseBundleName: "my-rb", ReleaseBundleVersion: "1.0.0"}
+	assert.NoError(t, rbc.writeManifest(servicesManager, rbDetails, services.Builds))
+
+	content, err := os.ReadFile(manifestPath)
+	assert.NoError(t, err)
+	var manifest ReleaseBundleManifest
+	assert.NoError(t, json.Unmarshal(content, &manifest))
+	assert.Equal(t, "my-rb", manifest.Name)
+	assert.Equal(t, services.Builds, manifest.SourceType)
+	assert.Equal(t, "my-key", manifest.SigningKey)
+	assert.Equal(t, "admin", manifest.CreatedBy)
+	assert.Equal(t, []ManifestArtifact{{Path: "generic-local/a.zip", Sha256: "abc", SourceRepository: "generic-local",
+		PackageType: "generic", Size: 3, Properties: map[string][]string{"k": {"v"}}}}, manifest.Artifacts)
+}
+
+func TestGetSigningKeyName(t *testing.T) {
+	assert.Equal(t, "", getSigningKeyName(""))
+	testsutils.SetEnvAndAssert(t, coreutils.SigningKey, "env-key")
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.SigningKey)
+	assert.Equal(t, "env-key", getSigningKeyName(""))
+	assert.Equal(t, "my-key", getSigningKeyName("my-key"))
+}
@@ -89,7 +89,7 @@ func (rbp *ReleaseBundlePromoteCommand) Run() error {
 		ExcludedRepositoryKeys: rbp.excludeReposPatterns,
 	}
 
-	promotionResp, err := servicesManager.PromoteReleaseBundle(rbDetails, queryParams, rbp.signingKeyName, promotionParams)
+	promotionResp, err := servicesManager.PromoteReleaseBundle(rbDetails, queryParams, getSigningKeyName(rbp.signingKeyName), promotionParams)
 	if err != nil {
 		return err
 	}
@@ -54,6 +54,11 @@ func (sc *StatusCommand) CommandName() string {
 	return "pl_status"
 }
 
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (sc *StatusCommand) IsReadOnly() bool {
+	return true
+}
+
 func (sc *StatusCommand) SetBranch(br string) *StatusCommand {
 	sc.branch = br
 	return sc
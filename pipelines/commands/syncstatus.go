@@ -37,6 +37,11 @@ func (sc *SyncStatusCommand) CommandName() string {
 	return "pl_sync_status"
 }
 
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (sc *SyncStatusCommand) IsReadOnly() bool {
+	return true
+}
+
 func (sc *SyncStatusCommand) SetBranch(br string) *SyncStatusCommand {
 	sc.branch = br
 	return sc
@@ -20,6 +20,11 @@ func (vc *VersionCommand) CommandName() string {
 	return "pl_version"
 }
 
+// IsReadOnly implements commands.ReadOnlyCommand.
+func (vc *VersionCommand) IsReadOnly() bool {
+	return true
+}
+
 func (vc *VersionCommand) ServerDetails() (*config.ServerDetails, error) {
 	return vc.serverDetails, nil
 }
@@ -1,6 +1,7 @@
 package manager
 
 import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	utilsconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	clientConfig "github.com/jfrog/jfrog-client-go/config"
 	"github.com/jfrog/jfrog-client-go/pipelines"
@@ -14,10 +15,13 @@ func CreateServiceManager(serviceDetails *utilsconfig.ServerDetails) (*pipelines
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(pAuth).
-		SetDryRun(false).
-		Build()
+		SetDryRun(false)
+	if err = utils.ApplyCustomHttpClient(configBuilder, serviceDetails, -1, 0, 0); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
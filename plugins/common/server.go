@@ -7,6 +7,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
 	cliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/completion"
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
@@ -15,7 +16,7 @@ import (
 
 // Get the common 'server-id' flag
 func GetServerIdFlag() components.StringFlag {
-	return components.NewStringFlag("server-id", "Server ID configured using the config command.")
+	return components.NewStringFlag("server-id", "Server ID configured using the config command.", components.WithCompletionValues(completion.GetValuesFunc(completion.ServerIds)))
 }
 
 // Return the Artifactory Details of the provided 'server-id', or the default one.
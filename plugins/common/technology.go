@@ -0,0 +1,215 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	technologyConfigCommandSuffix    = "-config"
+	technologyBuildInfoCommandSuffix = "-build-info"
+	globalFlag                       = "global"
+	buildNameFlag                    = "build-name"
+	buildNumberFlag                  = "build-number"
+	projectFlag                      = "project"
+	moduleFlag                       = "module"
+)
+
+// GetTechnologiesConfigCommands returns the '<name>-config' command of every registered technology integration.
+func GetTechnologiesConfigCommands() (commands []components.Command) {
+	for _, technology := range components.GetRegisteredTechnologies() {
+		commands = append(commands, CreateTechnologyConfigCommand(technology))
+	}
+	return
+}
+
+// GetTechnologiesBuildInfoCommands returns the '<name>-build-info' command of every registered technology integration.
+func GetTechnologiesBuildInfoCommands() (commands []components.Command) {
+	for _, technology := range components.GetRegisteredTechnologies() {
+		commands = append(commands, CreateTechnologyBuildInfoCommand(technology))
+	}
+	return
+}
+
+// CreateTechnologyConfigCommand creates the '<name>-config' command of a technology integration.
+// Every configuration question can be answered using a flag named after the question's key.
+// Questions which weren't answered using flags are asked interactively, unless running on CI.
+func CreateTechnologyConfigCommand(technology components.TechnologyIntegration) components.Command {
+	flags := []components.Flag{components.NewBoolFlag(globalFlag, "Set to true if you'd like the configuration to be global (for all projects). Specific projects can override the global configuration.")}
+	for _, question := range technology.GetConfigQuestions() {
+		flags = append(flags, components.NewStringFlag(question.Key, question.Message))
+	}
+	return components.Command{
+		Name:        technology.GetName() + technologyConfigCommandSuffix,
+		Description: "Generate " + technology.GetName() + " configuration.",
+		Flags:       flags,
+		Action: func(c *components.Context) error {
+			return createTechnologyConfig(c, technology)
+		},
+	}
+}
+
+// CreateTechnologyBuildInfoCommand creates the '<name>-build-info' command of a technology integration.
+// The command collects the build-info module of the project at the working directory, to be published by 'jf rt build-publish'.
+func CreateTechnologyBuildInfoCommand(technology components.TechnologyIntegration) components.Command {
+	return components.Command{
+		Name:        technology.GetName() + technologyBuildInfoCommandSuffix,
+		Description: "Collect the build-info module of the " + technology.GetName() + " project.",
+		Flags: []components.Flag{
+			components.NewStringFlag(buildNameFlag, "Build name.", components.SetMandatory()),
+			components.NewStringFlag(buildNumberFlag, "Build number.", components.SetMandatory()),
+			components.NewStringFlag(projectFlag, "JFrog project key."),
+			components.NewStringFlag(moduleFlag, "Optional module name for the build-info. If not set, the module ID created by the integration is used."),
+		},
+		Action: func(c *components.Context) error {
+			buildConfiguration := build.NewBuildConfiguration(c.GetStringFlagValue(buildNameFlag), c.GetStringFlagValue(buildNumberFlag),
+				c.GetStringFlagValue(moduleFlag), c.GetStringFlagValue(projectFlag))
+			return CollectTechnologyBuildInfo(technology, buildConfiguration)
+		},
+	}
+}
+
+// CollectTechnologyBuildInfo creates the build-info module of the project at the working directory using the technology integration,
+// and saves it as a partial build-info of the build.
+func CollectTechnologyBuildInfo(technology components.TechnologyIntegration, buildConfiguration *build.BuildConfiguration) error {
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	if buildName == "" || buildNumber == "" {
+		return errorutils.CheckErrorf("the --%s and --%s options are mandatory", buildNameFlag, buildNumberFlag)
+	}
+	ctx, err := CreateTechnologyContext(technology.GetName(), buildName, buildNumber, buildConfiguration.GetProject())
+	if err != nil {
+		return err
+	}
+	module, err := technology.CreateBuildInfoModule(ctx)
+	if err != nil {
+		return err
+	}
+	if module == nil {
+		return errorutils.CheckErrorf("the %s integration didn't create a build-info module", technology.GetName())
+	}
+	if buildConfiguration.GetModule() != "" {
+		module.Id = buildConfiguration.GetModule()
+	}
+	if module.Id == "" {
+		return errorutils.CheckErrorf("the build-info module created by the %s integration has no ID. Set it using the --%s option", technology.GetName(), moduleFlag)
+	}
+	log.Info(fmt.Sprintf("Collected the %s build-info module '%s', with %d artifacts and %d dependencies.", technology.GetName(), module.Id, len(module.Artifacts), len(module.Dependencies)))
+	return build.SavePartialBuildInfo(buildName, buildNumber, buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+		partial.ModuleId = module.Id
+		partial.ModuleType = module.Type
+		partial.Artifacts = module.Artifacts
+		partial.Dependencies = module.Dependencies
+	})
+}
+
+func createTechnologyConfig(c *components.Context, technology components.TechnologyIntegration) error {
+	technologyConfig := make(map[string]string)
+	interactive := strings.ToLower(os.Getenv(coreutils.CI)) != "true"
+	for _, question := range technology.GetConfigQuestions() {
+		answer := c.GetStringFlagValue(question.Key)
+		if answer == "" && interactive {
+			if question.DefaultValue != "" {
+				answer = ioutils.AskStringWithDefault(question.Message, "", question.DefaultValue)
+			} else {
+				answer = ioutils.AskString(question.Message, "", question.Optional, false)
+			}
+		}
+		if answer == "" {
+			answer = question.DefaultValue
+		}
+		if answer == "" && !question.Optional {
+			return errorutils.CheckErrorf("the '%s' configuration is mandatory. Set it using the --%s option", question.Key, question.Key)
+		}
+		technologyConfig[question.Key] = answer
+	}
+	return writeTechnologyConfig(technology.GetName(), technologyConfig, c.GetBoolFlagValue(globalFlag))
+}
+
+func writeTechnologyConfig(technologyName string, technologyConfig map[string]string, global bool) error {
+	projectDir, err := utils.GetProjectDir(global)
+	if err != nil {
+		return err
+	}
+	if err = fileutils.CreateDirIfNotExist(projectDir); err != nil {
+		return err
+	}
+	content, err := yaml.Marshal(technologyConfig)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.WriteFile(filepath.Join(projectDir, technologyName+".yaml"), content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info(technologyName + " build config successfully created.")
+	return nil
+}
+
+// GetTechnologyConfig reads the configuration saved by the technology's '<name>-config' command.
+// The project's configuration is used if exists, otherwise the global configuration is used.
+func GetTechnologyConfig(technologyName string) (map[string]string, error) {
+	for _, global := range []bool{false, true} {
+		projectDir, err := utils.GetProjectDir(global)
+		if err != nil {
+			return nil, err
+		}
+		configFilePath := filepath.Join(projectDir, technologyName+".yaml")
+		exists, err := fileutils.IsFileExists(configFilePath, false)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		log.Debug("Using the " + technologyName + " configuration at: " + configFilePath + ", global: " + strconv.FormatBool(global))
+		content, err := fileutils.ReadFile(configFilePath)
+		if err != nil {
+			return nil, err
+		}
+		technologyConfig := make(map[string]string)
+		if err = yaml.Unmarshal(content, &technologyConfig); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		return technologyConfig, nil
+	}
+	return nil, errorutils.CheckErrorf("%s build configuration is missing. Run 'jf %s%s' to configure the project", technologyName, technologyName, technologyConfigCommandSuffix)
+}
+
+// CreateTechnologyContext returns the context passed to the technology integration's hooks, for the project at the working directory.
+func CreateTechnologyContext(technologyName, buildName, buildNumber, project string) (*components.TechnologyContext, error) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	technologyConfig, err := GetTechnologyConfig(technologyName)
+	if err != nil {
+		return nil, err
+	}
+	return &components.TechnologyContext{
+		WorkingDir:  workingDir,
+		Config:      technologyConfig,
+		BuildName:   buildName,
+		BuildNumber: buildNumber,
+		Project:     project,
+	}, nil
+}
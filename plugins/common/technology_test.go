@@ -0,0 +1,67 @@
+package common
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTechnology struct{}
+
+func (tt *testTechnology) GetName() string {
+	return "cargo"
+}
+
+func (tt *testTechnology) GetConfigQuestions() []components.ConfigQuestion {
+	return []components.ConfigQuestion{{Key: "repo-resolve", Message: "Resolution repository"}}
+}
+
+func (tt *testTechnology) CreateBuildInfoModule(ctx *components.TechnologyContext) (*buildinfo.Module, error) {
+	return &buildinfo.Module{
+		Id:           "crate:" + ctx.BuildNumber,
+		Type:         "cargo",
+		Dependencies: []buildinfo.Dependency{{Id: "serde:1.0.0", Checksum: buildinfo.Checksum{Sha1: "abc"}}},
+		Artifacts:    []buildinfo.Artifact{{Name: "crate.crate", Path: ctx.GetConfigValue("repo-resolve") + "/crate.crate"}},
+	}, nil
+}
+
+func (tt *testTechnology) BuildDependencyTrees(*components.TechnologyContext) ([]*xrayUtils.GraphNode, error) {
+	return nil, nil
+}
+
+func TestCollectTechnologyBuildInfo(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	require.NoError(t, writeTechnologyConfig("cargo", map[string]string{"repo-resolve": "cargo-remote"}, true))
+	require.NoError(t, build.RemoveBuildDir("cargo-build", "3", ""))
+	defer func() {
+		assert.NoError(t, build.RemoveBuildDir("cargo-build", "3", ""))
+	}()
+
+	// The build name and number are mandatory.
+	assert.Error(t, CollectTechnologyBuildInfo(&testTechnology{}, build.NewBuildConfiguration("cargo-build", "", "", "")))
+
+	require.NoError(t, CollectTechnologyBuildInfo(&testTechnology{}, build.NewBuildConfiguration("cargo-build", "3", "", "")))
+	partials, err := build.ReadPartialBuildInfoFiles("cargo-build", "3", "")
+	require.NoError(t, err)
+	require.Len(t, partials, 1)
+	assert.Equal(t, "crate:3", partials[0].ModuleId)
+	assert.Equal(t, buildinfo.ModuleType("cargo"), partials[0].ModuleType)
+	require.Len(t, partials[0].Dependencies, 1)
+	assert.Equal(t, "serde:1.0.0", partials[0].Dependencies[0].Id)
+	require.Len(t, partials[0].Artifacts, 1)
+	assert.Equal(t, "cargo-remote/crate.crate", partials[0].Artifacts[0].Path)
+
+	// The module flag overrides the module ID created by the integration.
+	require.NoError(t, CollectTechnologyBuildInfo(&testTechnology{}, build.NewBuildConfiguration("cargo-build", "3", "my-crate", "")))
+	partials, err = build.ReadPartialBuildInfoFiles("cargo-build", "3", "")
+	require.NoError(t, err)
+	require.Len(t, partials, 2)
+	assert.ElementsMatch(t, []string{"crate:3", "my-crate"}, []string{partials[0].ModuleId, partials[1].ModuleId})
+}
@@ -1,16 +1,42 @@
 package common
 
 import (
+	"os"
 	"sort"
 	"strconv"
 	"strings"
 
+	artutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/progressbar"
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"golang.org/x/exp/slices"
 )
 
+const (
+	ProgressFormatFlag = "progress-format"
+	DryRunFlag         = "dry-run"
+	TimeoutFlag        = "timeout"
+
+	HttpRetriesFlag              = "http-retries"
+	HttpRetryWaitTimeFlag        = "http-retry-wait-time"
+	HttpRetryBackoffBaseFlag     = "http-retry-backoff-base"
+	HttpRetryMaxDelayFlag        = "http-retry-max-delay"
+	HttpRetryableStatusCodesFlag = "http-retryable-status-codes"
+)
+
+// The environment variables set by the HTTP retry policy flags.
+var httpRetryFlagsEnv = map[string]string{
+	HttpRetriesFlag:              artutils.HttpRetriesEnv,
+	HttpRetryWaitTimeFlag:        artutils.HttpRetryWaitTimeEnv,
+	HttpRetryBackoffBaseFlag:     artutils.HttpRetryBackoffBaseEnv,
+	HttpRetryMaxDelayFlag:        artutils.HttpRetryMaxDelayEnv,
+	HttpRetryableStatusCodesFlag: artutils.HttpRetryableStatusCodesEnv,
+}
+
 func GetStringsArrFlagValue(c *components.Context, flagName string) (resultArray []string) {
 	if c.IsFlagSet(flagName) {
 		resultArray = append(resultArray, strings.Split(c.GetStringFlagValue(flagName), ";")...)
@@ -58,6 +84,80 @@ func GetThreadsCount(c *components.Context) (threads int, err error) {
 	return cliutils.GetThreadsCount(c.GetStringFlagValue("threads"))
 }
 
+// Returns the --progress-format flag, which determines how the progress of file transfers is displayed.
+func GetProgressFormatFlag() components.StringFlag {
+	return components.NewStringFlag(ProgressFormatFlag,
+		"[Default: "+progressbar.ProgressFormatTty+"] Set to '"+progressbar.ProgressFormatJson+"' to write line-delimited JSON progress events to the standard error, instead of displaying the progress bar.")
+}
+
+// If the --progress-format flag is set, applies it to the progress of the command's file transfers.
+func SetProgressFormatFromFlag(c *components.Context) error {
+	progressFormat := c.GetStringFlagValue(ProgressFormatFlag)
+	if progressFormat == "" {
+		return nil
+	}
+	if err := os.Setenv(coreutils.ProgressFormat, progressFormat); err != nil {
+		return err
+	}
+	_, err := progressbar.GetProgressFormat()
+	return err
+}
+
+// Returns the --dry-run flag, which makes mutating commands report the actions they would perform, without performing them.
+func GetDryRunFlag() components.BoolFlag {
+	return components.NewBoolFlag(DryRunFlag, "[Default: false] Set to true to only report the actions the command would perform, without modifying anything.")
+}
+
+// If the --dry-run flag is set, enables the dry run mode of the executed command.
+func SetDryRunFromFlag(c *components.Context) error {
+	if !c.GetBoolFlagValue(DryRunFlag) {
+		return nil
+	}
+	return os.Setenv(coreutils.DryRun, "true")
+}
+
+// Returns the --timeout flag, which cancels the command if it doesn't complete in time.
+func GetTimeoutFlag() components.StringFlag {
+	return components.NewStringFlag(TimeoutFlag, "[Optional] Maximal duration of the command, such as '30m' or '1h'. The command is cancelled if it doesn't complete in time.")
+}
+
+// If the --timeout flag is set, applies it to the executed command. The flag overrides the JFROG_CLI_COMMAND_TIMEOUT environment variable.
+func SetTimeoutFromFlag(c *components.Context) error {
+	if !c.IsFlagSet(TimeoutFlag) {
+		return nil
+	}
+	if err := os.Setenv(coreutils.CommandTimeout, c.GetStringFlagValue(TimeoutFlag)); err != nil {
+		return err
+	}
+	_, err := commands.GetCommandTimeout()
+	return err
+}
+
+// Returns the flags which configure the retry policy of the HTTP requests of the command.
+func GetHttpRetryFlags() []components.Flag {
+	return []components.Flag{
+		components.NewStringFlag(HttpRetriesFlag, "[Default: "+strconv.Itoa(artutils.DefaultHttpRetries)+"] Number of retries of failed HTTP requests."),
+		components.NewStringFlag(HttpRetryWaitTimeFlag, "[Default: "+artutils.DefaultHttpRetryWaitTime.String()+"] Wait time before the first retry of a failed HTTP request, such as '500ms'."),
+		components.NewStringFlag(HttpRetryBackoffBaseFlag, "[Default: "+strconv.FormatFloat(artutils.DefaultHttpBackoffBase, 'f', -1, 64)+"] The wait time is multiplied by this number after each retry."),
+		components.NewStringFlag(HttpRetryMaxDelayFlag, "[Default: "+artutils.DefaultHttpRetryMaxDelay.String()+"] Maximal wait time between retries of a failed HTTP request, such as '30s'."),
+		components.NewStringFlag(HttpRetryableStatusCodesFlag, "[Default: 429,500,502,503,504] Comma-separated HTTP status codes of the responses which are retried."),
+	}
+}
+
+// Applies the set HTTP retry policy flags to the retry policy of the command. The flags override the environment variables and the server configuration.
+func SetHttpRetryPolicyFromFlags(c *components.Context) error {
+	for flag, env := range httpRetryFlagsEnv {
+		if !c.IsFlagSet(flag) {
+			continue
+		}
+		if err := os.Setenv(env, c.GetStringFlagValue(flag)); err != nil {
+			return err
+		}
+	}
+	_, err := artutils.GetRetryPolicy(nil, -1)
+	return err
+}
+
 func GetPrintCurrentCmdHelp(c *components.Context) func() error {
 	return func() error {
 		return c.PrintCommandHelp(c.CommandName)
@@ -117,6 +117,8 @@ type StringFlag struct {
 	DefaultValue string
 	// Optional. If provided, this field will be used for help usage. --<Name>=<HelpValue> else: --<Name>=<value>
 	HelpValue string
+	// Optional. If provided, the returned values are suggested by the shell completion of the flag's value.
+	CompletionValues func() ([]string, error)
 }
 
 type StringFlagOption func(f *StringFlag)
@@ -161,6 +163,14 @@ func WithHelpValue(helpValue string) StringFlagOption {
 	}
 }
 
+// The values returned by the provided function are suggested by the shell completion of the flag's value.
+// For values fetched from the server, such as repositories or build names, use completion.GetValuesFunc.
+func WithCompletionValues(completionValues func() ([]string, error)) StringFlagOption {
+	return func(f *StringFlag) {
+		f.CompletionValues = completionValues
+	}
+}
+
 func SetHiddenStrFlag() StringFlagOption {
 	return func(f *StringFlag) {
 		f.Hidden = true
@@ -89,7 +89,7 @@ func convertCommand(cmd Command, namespaces ...string) (cli.Command, error) {
 		HelpName:        common.CreateUsage(getCmdUsageString(cmd, namespaces...), cmd.Description, cmdUsages),
 		UsageText:       createArgumentsSummary(cmd),
 		ArgsUsage:       createEnvVarsSummary(cmd),
-		BashComplete:    common.CreateBashCompletionFunc(),
+		BashComplete:    common.CreateBashCompletionFuncWithFlagValues(getFlagsCompletionValues(cmd)),
 		SkipFlagParsing: cmd.SkipFlagParsing,
 		Hidden:          cmd.Hidden,
 		// Passing any other interface than 'cli.ActionFunc' will fail the command.
@@ -318,6 +318,17 @@ func convertFlags(cmd Command) ([]cli.Flag, map[string]StringFlag, error) {
 	return convertedFlags, convertedStringFlags, nil
 }
 
+// Returns the functions providing the shell completion values of the command's flags, mapped by the flags' names.
+func getFlagsCompletionValues(cmd Command) map[string]func() ([]string, error) {
+	flagsCompletionValues := map[string]func() ([]string, error){}
+	for _, flag := range cmd.Flags {
+		if stringFlag, ok := flag.(StringFlag); ok && stringFlag.CompletionValues != nil {
+			flagsCompletionValues[stringFlag.Name] = stringFlag.CompletionValues
+		}
+	}
+	return flagsCompletionValues
+}
+
 func convertByType(flag Flag) (cli.Flag, *StringFlag, error) {
 	switch actualType := flag.(type) {
 	case StringFlag:
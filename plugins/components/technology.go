@@ -0,0 +1,89 @@
+package components
+
+import (
+	"sort"
+	"sync"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+// TechnologyIntegration allows a plugin to add support for a package manager, which isn't natively supported by the JFrog CLI.
+// Registered integrations get a '<name>-config' command, and are used by the build-info and audit flows.
+type TechnologyIntegration interface {
+	// The technology's name, for example 'cargo'. The name must be unique among the registered technologies.
+	GetName() string
+	// The questions asked by the '<name>-config' command.
+	// The answers are saved in the technology's project configuration file, and are passed to the other hooks.
+	GetConfigQuestions() []ConfigQuestion
+	// Creates the build-info module of the project located at the context's working directory.
+	CreateBuildInfoModule(ctx *TechnologyContext) (*buildinfo.Module, error)
+	// Returns the dependency trees of the project located at the context's working directory, to be scanned by 'jf audit'.
+	BuildDependencyTrees(ctx *TechnologyContext) ([]*xrayUtils.GraphNode, error)
+}
+
+type ConfigQuestion struct {
+	// The key under which the answer is saved in the configuration file.
+	Key string
+	// The message shown to the user.
+	Message string
+	// Optional. The value used if the user doesn't provide an answer.
+	DefaultValue string
+	// Is an empty answer allowed? Ignored if a default value is provided.
+	Optional bool
+}
+
+type TechnologyContext struct {
+	// The project's root directory.
+	WorkingDir string
+	// The answers saved by the '<name>-config' command.
+	Config map[string]string
+	// Optional. The build name and number, if the build-info is being collected.
+	BuildName   string
+	BuildNumber string
+	Project     string
+}
+
+func (tc *TechnologyContext) GetConfigValue(key string) string {
+	return tc.Config[key]
+}
+
+var (
+	technologiesMutex      sync.RWMutex
+	registeredTechnologies = map[string]TechnologyIntegration{}
+)
+
+// RegisterTechnology adds a technology integration to the list of technologies supported by the CLI.
+// Should be called before the CLI app is converted and run, usually from the plugin's main or init function.
+func RegisterTechnology(technology TechnologyIntegration) error {
+	if technology == nil || technology.GetName() == "" {
+		return errorutils.CheckErrorf("cannot register a technology integration without a name")
+	}
+	technologiesMutex.Lock()
+	defer technologiesMutex.Unlock()
+	if _, exists := registeredTechnologies[technology.GetName()]; exists {
+		return errorutils.CheckErrorf("the technology '%s' is already registered", technology.GetName())
+	}
+	registeredTechnologies[technology.GetName()] = technology
+	return nil
+}
+
+// GetTechnology returns the registered technology integration with the provided name, or nil if no such technology is registered.
+func GetTechnology(name string) TechnologyIntegration {
+	technologiesMutex.RLock()
+	defer technologiesMutex.RUnlock()
+	return registeredTechnologies[name]
+}
+
+// GetRegisteredTechnologies returns all the registered technology integrations, sorted by name.
+func GetRegisteredTechnologies() []TechnologyIntegration {
+	technologiesMutex.RLock()
+	defer technologiesMutex.RUnlock()
+	technologies := make([]TechnologyIntegration, 0, len(registeredTechnologies))
+	for _, technology := range registeredTechnologies {
+		technologies = append(technologies, technology)
+	}
+	sort.Slice(technologies, func(i, j int) bool { return technologies[i].GetName() < technologies[j].GetName() })
+	return technologies
+}
@@ -0,0 +1,56 @@
+package components
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTechnology struct {
+	name string
+}
+
+func (tt *testTechnology) GetName() string {
+	return tt.name
+}
+
+func (tt *testTechnology) GetConfigQuestions() []ConfigQuestion {
+	return []ConfigQuestion{{Key: "repo-resolve", Message: "Resolution repository"}}
+}
+
+func (tt *testTechnology) CreateBuildInfoModule(ctx *TechnologyContext) (*buildinfo.Module, error) {
+	return &buildinfo.Module{Id: ctx.GetConfigValue("repo-resolve")}, nil
+}
+
+func (tt *testTechnology) BuildDependencyTrees(*TechnologyContext) ([]*xrayUtils.GraphNode, error) {
+	return nil, nil
+}
+
+func TestRegisterTechnology(t *testing.T) {
+	defer func() {
+		registeredTechnologies = map[string]TechnologyIntegration{}
+	}()
+
+	assert.NoError(t, RegisterTechnology(&testTechnology{name: "zig"}))
+	assert.NoError(t, RegisterTechnology(&testTechnology{name: "cargo"}))
+	// Duplicate and nameless technologies are rejected
+	assert.Error(t, RegisterTechnology(&testTechnology{name: "cargo"}))
+	assert.Error(t, RegisterTechnology(&testTechnology{}))
+	assert.Error(t, RegisterTechnology(nil))
+
+	assert.Nil(t, GetTechnology("swift"))
+	cargo := GetTechnology("cargo")
+	if assert.NotNil(t, cargo) {
+		module, err := cargo.CreateBuildInfoModule(&TechnologyContext{Config: map[string]string{"repo-resolve": "cargo-remote"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "cargo-remote", module.Id)
+	}
+
+	technologies := GetRegisteredTechnologies()
+	if assert.Len(t, technologies, 2) {
+		assert.Equal(t, "cargo", technologies[0].GetName())
+		assert.Equal(t, "zig", technologies[1].GetName())
+	}
+}
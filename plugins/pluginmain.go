@@ -4,6 +4,7 @@ import (
 	"os"
 
 	jfrogclicore "github.com/jfrog/jfrog-cli-core/v2"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/common"
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/log"
@@ -62,6 +63,9 @@ func RunCliWithPlugin(jfrogApp components.App) func() error {
 		cli.CommandHelpTemplate = commandHelpTemplate
 		cli.AppHelpTemplate = appHelpTemplate
 
+		// Add the configuration and build-info commands of the technologies registered by the plugin.
+		jfrogApp.Commands = append(jfrogApp.Commands, common.GetTechnologiesConfigCommands()...)
+		jfrogApp.Commands = append(jfrogApp.Commands, common.GetTechnologiesBuildInfoCommands()...)
 		baseApp, err := components.ConvertApp(jfrogApp)
 		if err != nil {
 			coreutils.ExitOnErr(err)
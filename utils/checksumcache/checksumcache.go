@@ -0,0 +1,138 @@
+package checksumcache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ChecksumCache maps local files to their checksums, to avoid re-hashing files which weren't modified since they were last hashed.
+// A cached entry is considered valid as long as the file's size and modification time are unchanged.
+// The cache is stored at '.jfrog/checksums-cache/checksums.json'.
+type ChecksumCache struct {
+	cacheFilePath string
+	entries       map[string]*cacheEntry
+	modified      bool
+	mutex         sync.Mutex
+}
+
+type cacheEntry struct {
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"modTime"`
+	Md5     string `json:"md5,omitempty"`
+	Sha1    string `json:"sha1,omitempty"`
+	Sha256  string `json:"sha256,omitempty"`
+}
+
+// IsChecksumCacheEnabled returns true if the JFROG_CLI_CHECKSUMS_CACHE environment variable is set to true.
+func IsChecksumCacheEnabled() bool {
+	return strings.ToLower(os.Getenv(coreutils.ChecksumsCache)) == "true"
+}
+
+// LoadChecksumCache reads the checksum cache from the JFrog home directory.
+// An empty cache is returned if the cache file doesn't exist or can't be parsed.
+func LoadChecksumCache() (*ChecksumCache, error) {
+	cacheDir, err := coreutils.GetJfrogChecksumsCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	cache := &ChecksumCache{cacheFilePath: filepath.Join(cacheDir, coreutils.JfrogChecksumsCacheFileName), entries: make(map[string]*cacheEntry)}
+	exists, err := fileutils.IsFileExists(cache.cacheFilePath, false)
+	if err != nil || !exists {
+		return cache, err
+	}
+	content, err := fileutils.ReadFile(cache.cacheFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, &cache.entries); err != nil {
+		log.Debug("The checksum cache at '" + cache.cacheFilePath + "' is corrupted and will be recreated: " + err.Error())
+		cache.entries = make(map[string]*cacheEntry)
+	}
+	return cache, nil
+}
+
+// GetFileDetails returns the details of the file at the provided path.
+// The checksums are taken from the cache if the file wasn't modified since it was last hashed, otherwise they are calculated and cached.
+func (cc *ChecksumCache) GetFileDetails(filePath string) (*fileutils.FileDetails, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	fileInfo, err := os.Stat(ioutils.ToLongPath(absPath))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	cc.mutex.Lock()
+	entry, exists := cc.entries[absPath]
+	cc.mutex.Unlock()
+	if exists && entry.Size == fileInfo.Size() && entry.ModTime == fileInfo.ModTime().UnixNano() {
+		details := &fileutils.FileDetails{Size: entry.Size}
+		details.Checksum.Md5, details.Checksum.Sha1, details.Checksum.Sha256 = entry.Md5, entry.Sha1, entry.Sha256
+		return details, nil
+	}
+	details, err := fileutils.GetFileDetails(ioutils.ToLongPath(absPath), true)
+	if err != nil {
+		return nil, err
+	}
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+	cc.entries[absPath] = &cacheEntry{
+		Size:    fileInfo.Size(),
+		ModTime: fileInfo.ModTime().UnixNano(),
+		Md5:     details.Checksum.Md5,
+		Sha1:    details.Checksum.Sha1,
+		Sha256:  details.Checksum.Sha256,
+	}
+	cc.modified = true
+	return details, nil
+}
+
+// Save writes the cache to the file system, if it was modified since it was loaded.
+// Entries of files which no longer exist are removed.
+func (cc *ChecksumCache) Save() error {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+	if !cc.modified {
+		return nil
+	}
+	for path := range cc.entries {
+		if exists, err := fileutils.IsFileExists(ioutils.ToLongPath(path), false); err != nil || !exists {
+			delete(cc.entries, path)
+		}
+	}
+	content, err := json.Marshal(cc.entries)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(cc.cacheFilePath)); err != nil {
+		return err
+	}
+	// Write to a temp file and rename it, to avoid leaving a partially written cache file behind.
+	tempFilePath := cc.cacheFilePath + ".tmp"
+	if err = os.WriteFile(tempFilePath, content, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.Rename(tempFilePath, cc.cacheFilePath); err != nil {
+		return errorutils.CheckError(err)
+	}
+	cc.modified = false
+	return nil
+}
+
+// CleanChecksumCache removes the checksum cache from the JFrog home directory.
+func CleanChecksumCache() error {
+	cacheDir, err := coreutils.GetJfrogChecksumsCacheDir()
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.RemoveAll(cacheDir))
+}
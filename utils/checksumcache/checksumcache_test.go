@@ -0,0 +1,57 @@
+package checksumcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksumCache(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.HomeDir)
+	filePath := filepath.Join(t.TempDir(), "file.bin")
+	assert.NoError(t, os.WriteFile(filePath, []byte("content"), 0600))
+	expected, err := fileutils.GetFileDetails(filePath, true)
+	assert.NoError(t, err)
+
+	// Calculate the checksums and save them to the cache
+	cache, err := LoadChecksumCache()
+	assert.NoError(t, err)
+	details, err := cache.GetFileDetails(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Checksum, details.Checksum)
+	assert.NoError(t, cache.Save())
+
+	// Load the cache and verify the cached checksums are used
+	cache, err = LoadChecksumCache()
+	assert.NoError(t, err)
+	absPath, err := filepath.Abs(filePath)
+	assert.NoError(t, err)
+	if assert.Contains(t, cache.entries, absPath) {
+		cache.entries[absPath].Sha256 = "cached-sha256"
+	}
+	details, err = cache.GetFileDetails(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "cached-sha256", details.Checksum.Sha256)
+
+	// Modify the file and verify the checksums are recalculated
+	assert.NoError(t, os.WriteFile(filePath, []byte("modified content"), 0600))
+	assert.NoError(t, os.Chtimes(filePath, time.Now(), time.Now().Add(time.Minute)))
+	expected, err = fileutils.GetFileDetails(filePath, true)
+	assert.NoError(t, err)
+	details, err = cache.GetFileDetails(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Checksum, details.Checksum)
+
+	// Clean the cache
+	assert.NoError(t, CleanChecksumCache())
+	cacheDir, err := coreutils.GetJfrogChecksumsCacheDir()
+	assert.NoError(t, err)
+	assert.NoDirExists(t, cacheDir)
+}
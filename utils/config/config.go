@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/buger/jsonparser"
 	biutils "github.com/jfrog/build-info-go/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
@@ -12,6 +13,7 @@ import (
 	artifactoryAuth "github.com/jfrog/jfrog-client-go/artifactory/auth"
 	"github.com/jfrog/jfrog-client-go/auth"
 	distributionAuth "github.com/jfrog/jfrog-client-go/distribution/auth"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
 	lifecycleAuth "github.com/jfrog/jfrog-client-go/lifecycle/auth"
 	pipelinesAuth "github.com/jfrog/jfrog-client-go/pipelines/auth"
 	"github.com/jfrog/jfrog-client-go/utils"
@@ -107,6 +109,23 @@ func GetDefaultConfiguredConf(configs []*ServerDetails) (*ServerDetails, error)
 	return nil, errors.New("couldn't find default server")
 }
 
+// GetProjectKey returns the project key of a command. The project key is resolved by the following order:
+// 1. The project key provided to the command.
+// 2. The JFROG_CLI_BUILD_PROJECT environment variable.
+// 3. The default project of the server.
+func GetProjectKey(projectKey string, serverDetails *ServerDetails) string {
+	if projectKey != "" {
+		return projectKey
+	}
+	if projectKey = os.Getenv(coreutils.Project); projectKey != "" {
+		return projectKey
+	}
+	if serverDetails != nil {
+		return serverDetails.Project
+	}
+	return ""
+}
+
 // Returns default artifactory conf. Returns nil if default server doesn't exists.
 func GetDefaultServerConf() (*ServerDetails, error) {
 	configurations, err := GetAllServersConfigs()
@@ -592,6 +611,23 @@ type ServerDetails struct {
 	IsDefault                       bool   `json:"isDefault,omitempty"`
 	InsecureTls                     bool   `json:"-"`
 	WebLogin                        bool   `json:"webLogin,omitempty"`
+	// The default project key of the commands running against this server.
+	Project string `json:"project,omitempty"`
+	// The retry policy of the HTTP requests to this server.
+	HttpRetryPolicy *HttpRetryPolicy `json:"httpRetryPolicy,omitempty"`
+}
+
+// HttpRetryPolicy configures how the failed HTTP requests to a server are retried.
+// The unset settings keep their defaults, and the JFROG_CLI_HTTP_RETRY* environment variables override the settings.
+type HttpRetryPolicy struct {
+	Retries *int `json:"retries,omitempty"`
+	// The wait time before the first retry, such as '500ms'.
+	RetryWaitTime string `json:"retryWaitTime,omitempty"`
+	// The wait time is multiplied by the backoff base after each retry.
+	BackoffBase float64 `json:"backoffBase,omitempty"`
+	// The maximal wait time between retries, such as '30s'.
+	MaxDelay             string `json:"maxDelay,omitempty"`
+	RetryableStatusCodes []int  `json:"retryableStatusCodes,omitempty"`
 }
 
 // Deprecated
@@ -600,6 +636,25 @@ type MissionControlDetails struct {
 	AccessToken string `json:"accessToken,omitempty"`
 }
 
+// UsesApiKey returns true if the server authenticates with a deprecated Artifactory API key, configured as the password.
+func (serverDetails *ServerDetails) UsesApiKey() bool {
+	return IsApiKeyCredentials(serverDetails.Password, serverDetails.AccessToken)
+}
+
+// IsApiKeyCredentials returns true if the credentials are an Artifactory API key used as a password, rather than an access token.
+func IsApiKeyCredentials(password, accessToken string) bool {
+	return accessToken == "" && httpclient.IsApiKey(password)
+}
+
+// WarnIfApiKeyEmbedded warns that a package manager configuration generated with the credentials embeds a deprecated API key,
+// which will stop working once API keys are disabled in Artifactory.
+func WarnIfApiKeyEmbedded(password, accessToken, generatedConfig string) {
+	if IsApiKeyCredentials(password, accessToken) {
+		log.Warn(fmt.Sprintf("The %s embeds an Artifactory API key. API keys are deprecated and will stop working once they're disabled in Artifactory. "+
+			"Run 'jf config migrate-credentials' to replace the API keys of the configured servers with access tokens.", generatedConfig))
+	}
+}
+
 func (serverDetails *ServerDetails) IsEmpty() bool {
 	return len(serverDetails.ServerId) == 0 && serverDetails.Url == ""
 }
@@ -321,6 +321,18 @@ func TestGetJfrogDependenciesPath(t *testing.T) {
 	assert.Equal(t, expectedDependenciesPath, dependenciesPath)
 }
 
+func TestGetProjectKey(t *testing.T) {
+	serverDetails := &ServerDetails{Project: "server-project"}
+	assert.Equal(t, "explicit", GetProjectKey("explicit", serverDetails))
+	assert.Equal(t, "server-project", GetProjectKey("", serverDetails))
+	assert.Empty(t, GetProjectKey("", nil))
+
+	testsutils.SetEnvAndAssert(t, coreutils.Project, "env-project")
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.Project)
+	assert.Equal(t, "explicit", GetProjectKey("explicit", serverDetails))
+	assert.Equal(t, "env-project", GetProjectKey("", serverDetails))
+}
+
 func assertionV4Helper(t *testing.T, convertedConfig *ConfigV4, expectedVersion int, expectedEnc bool) {
 	assert.Equal(t, strconv.Itoa(expectedVersion), convertedConfig.Version)
 	assert.Equal(t, expectedEnc, convertedConfig.Enc)
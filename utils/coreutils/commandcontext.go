@@ -0,0 +1,32 @@
+package coreutils
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	commandContext      = context.Background()
+	commandContextMutex sync.RWMutex
+)
+
+// CommandContext returns the context of the running command, which is cancelled when the command times out or is cancelled.
+// The services managers created by the command use it, so that their requests are aborted on cancellation.
+func CommandContext() context.Context {
+	commandContextMutex.RLock()
+	defer commandContextMutex.RUnlock()
+	return commandContext
+}
+
+// SetCommandContext sets the context of the running command, and returns a function restoring the previous context.
+func SetCommandContext(ctx context.Context) (restore func()) {
+	commandContextMutex.Lock()
+	defer commandContextMutex.Unlock()
+	previous := commandContext
+	commandContext = ctx
+	return func() {
+		commandContextMutex.Lock()
+		defer commandContextMutex.Unlock()
+		commandContext = previous
+	}
+}
@@ -10,12 +10,19 @@ const (
 	TokenRefreshDefaultInterval = 60
 
 	// Home Dir
+	JfrogAuditCacheDirName              = "audit-cache"
 	JfrogBackupDirName                  = "backup"
 	JfrogCertsDirName                   = "certs"
+	JfrogCheckpointsDirName             = "checkpoints"
+	JfrogChecksumsCacheDirName          = "checksums-cache"
+	JfrogChecksumsCacheFileName         = "checksums.json"
+	JfrogCompletionCacheDirName         = "completion-cache"
 	JfrogConfigFile                     = "jfrog-cli.conf"
 	JfrogDependenciesDirName            = "dependencies"
 	JfrogLocksDirName                   = "locks"
 	JfrogLogsDirName                    = "logs"
+	JfrogMetricsDirName                 = "metrics"
+	JfrogMetricsFileName                = "commands.jsonl"
 	JfrogPluginsDirName                 = "plugins"
 	JfrogPluginsFileName                = "plugins.yml"
 	JfrogSecurityConfFile               = "security.yaml"
@@ -42,13 +49,25 @@ const (
 	TempDir            = "JFROG_CLI_TEMP_DIR"
 	LogLevel           = "JFROG_CLI_LOG_LEVEL"
 	LogTimestamp       = "JFROG_CLI_LOG_TIMESTAMP"
+	LogFormat          = "JFROG_CLI_LOG_FORMAT"
 	ReportUsage        = "JFROG_CLI_REPORT_USAGE"
 	DependenciesDir    = "JFROG_CLI_DEPENDENCIES_DIR"
 	TransitiveDownload = "JFROG_CLI_TRANSITIVE_DOWNLOAD_EXPERIMENTAL"
 	FailNoOp           = "JFROG_CLI_FAIL_NO_OP"
 	OutputDirPathEnv   = "JFROG_CLI_COMMAND_SUMMARY_OUTPUT_DIR"
+	ChecksumsCache     = "JFROG_CLI_CHECKSUMS_CACHE"
+	DryRun             = "JFROG_CLI_DRY_RUN"
+	ProgressFormat     = "JFROG_CLI_PROGRESS_FORMAT"
+	SigningKey         = "JFROG_CLI_SIGNING_KEY"
 	CI                 = "CI"
 	ServerID           = "JFROG_CLI_SERVER_ID"
+	Metrics            = "JFROG_CLI_METRICS"
+	CiBuildDetection   = "JFROG_CLI_CI_BUILD_DETECTION"
+	BuildShardsRepo    = "JFROG_CLI_BUILD_SHARDS_REPO"
+	ValidateChecksums  = "JFROG_CLI_VALIDATE_CHECKSUMS"
+	CommandTimeout     = "JFROG_CLI_COMMAND_TIMEOUT"
+	AuditCacheTtl      = "JFROG_CLI_AUDIT_CACHE_TTL"
+	MigrateApiKeys     = "JFROG_CLI_MIGRATE_API_KEYS"
 )
 
 // Although these vars are constant, they are defined inside a vars section and not a constants section because the tests modify these values.
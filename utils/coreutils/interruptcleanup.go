@@ -0,0 +1,52 @@
+package coreutils
+
+import (
+	"errors"
+	"sync"
+)
+
+type interruptCleanup struct {
+	id      int
+	cleanup func() error
+}
+
+var (
+	interruptCleanups      []interruptCleanup
+	nextInterruptCleanupId int
+	interruptCleanupsMutex sync.Mutex
+)
+
+// RegisterInterruptCleanup registers a function which restores a temporary change made by the running command, such as a replaced configuration file.
+// The registered functions are run if the command is interrupted before it restores the change by itself.
+// The returned function unregisters the cleanup, and should be called once the change is restored.
+func RegisterInterruptCleanup(cleanup func() error) (unregister func()) {
+	interruptCleanupsMutex.Lock()
+	defer interruptCleanupsMutex.Unlock()
+	id := nextInterruptCleanupId
+	nextInterruptCleanupId++
+	interruptCleanups = append(interruptCleanups, interruptCleanup{id: id, cleanup: cleanup})
+	return func() {
+		interruptCleanupsMutex.Lock()
+		defer interruptCleanupsMutex.Unlock()
+		for i, registered := range interruptCleanups {
+			if registered.id == id {
+				interruptCleanups = append(interruptCleanups[:i], interruptCleanups[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// RunInterruptCleanups runs the registered cleanups in the reverse order of their registration, and unregisters them.
+func RunInterruptCleanups() error {
+	interruptCleanupsMutex.Lock()
+	cleanups := interruptCleanups
+	interruptCleanups = nil
+	interruptCleanupsMutex.Unlock()
+
+	var err error
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		err = errors.Join(err, cleanups[i].cleanup())
+	}
+	return err
+}
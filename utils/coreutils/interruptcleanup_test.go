@@ -0,0 +1,32 @@
+package coreutils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunInterruptCleanups(t *testing.T) {
+	var calls []string
+	RegisterInterruptCleanup(func() error {
+		calls = append(calls, "first")
+		return nil
+	})
+	unregister := RegisterInterruptCleanup(func() error {
+		calls = append(calls, "unregistered")
+		return nil
+	})
+	RegisterInterruptCleanup(func() error {
+		calls = append(calls, "last")
+		return errors.New("cleanup failed")
+	})
+	unregister()
+
+	// The cleanups run in the reverse order of their registration.
+	assert.EqualError(t, RunInterruptCleanups(), "cleanup failed")
+	assert.Equal(t, []string{"last", "first"}, calls)
+	// The cleanups run only once.
+	assert.NoError(t, RunInterruptCleanups())
+	assert.Len(t, calls, 2)
+}
@@ -361,6 +361,46 @@ func GetJfrogBackupDir() (string, error) {
 	return filepath.Join(homeDir, JfrogBackupDirName), nil
 }
 
+func GetJfrogChecksumsCacheDir() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogChecksumsCacheDirName), nil
+}
+
+func GetJfrogMetricsDir() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogMetricsDirName), nil
+}
+
+func GetJfrogAuditCacheDir() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogAuditCacheDirName), nil
+}
+
+func GetJfrogCheckpointsDir() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogCheckpointsDirName), nil
+}
+
+func GetJfrogCompletionCacheDir() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogCompletionCacheDirName), nil
+}
+
 func GetJfrogPluginsDir() (string, error) {
 	homeDir, err := GetJfrogHomeDir()
 	if err != nil {
@@ -127,6 +127,7 @@ func getArtifactoryApiUrl(repoName string, details auth.ServiceDetails) (string,
 		password = details.GetAccessToken()
 	}
 	if password != "" {
+		config.WarnIfApiKeyEmbedded(password, details.GetAccessToken(), "GOPROXY URL")
 		rtUrl.User = url.UserPassword(username, password)
 	}
 	rtUrl.Path += "api/go/" + repoName
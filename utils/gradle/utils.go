@@ -64,6 +64,7 @@ func createGradleRunConfig(vConfig *viper.Viper, deployableArtifactsFile string,
 	if disableDeploy {
 		setDeployFalse(vConfig)
 	}
+	setDeploymentRepos(vConfig)
 	props, err = build.CreateBuildInfoProps(deployableArtifactsFile, vConfig, project.Gradle)
 	if err != nil {
 		return
@@ -85,3 +86,19 @@ func setDeployFalse(vConfig *viper.Viper) {
 		vConfig.Set(build.DeployerPrefix+build.Repo, "empty_repo")
 	}
 }
+
+// The Gradle extractor deploys the artifacts of snapshot versions to the snapshot repository, and the other artifacts to the release repository.
+// The deployer may be configured with a single repository, or with release and snapshot repositories.
+func setDeploymentRepos(vConfig *viper.Viper) {
+	repo := vConfig.GetString(build.DeployerPrefix + build.Repo)
+	if repo == "" {
+		repo = vConfig.GetString(build.DeployerPrefix + build.ReleaseRepo)
+		if repo == "" {
+			return
+		}
+		vConfig.Set(build.DeployerPrefix+build.Repo, repo)
+	}
+	if vConfig.GetString(build.DeployerPrefix+build.SnapshotRepo) == "" {
+		vConfig.Set(build.DeployerPrefix+build.SnapshotRepo, repo)
+	}
+}
@@ -0,0 +1,35 @@
+package gradleutils
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateGradleRunConfigDeploymentRepos(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		deployer             map[string]any
+		expectedRepo         string
+		expectedSnapshotRepo string
+	}{
+		{"single repository", map[string]any{build.Repo: "gradle-local"}, "gradle-local", "gradle-local"},
+		{"release and snapshot repositories", map[string]any{build.ReleaseRepo: "libs-release-local", build.SnapshotRepo: "libs-snapshot-local"}, "libs-release-local", "libs-snapshot-local"},
+		{"repository and snapshot repository", map[string]any{build.Repo: "gradle-local", build.SnapshotRepo: "libs-snapshot-local"}, "gradle-local", "libs-snapshot-local"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			vConfig := viper.New()
+			vConfig.Set("type", "gradle")
+			for key, value := range testCase.deployer {
+				vConfig.Set(build.DeployerPrefix+key, value)
+			}
+			props, _, _, err := createGradleRunConfig(vConfig, "", 0, false)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedRepo, props["publish.repoKey"])
+			assert.Equal(t, testCase.expectedSnapshotRepo, props["publish.snapshot.repoKey"])
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 )
 
@@ -85,7 +86,7 @@ func WinToUnixPathSeparator(filePath string) string {
 // The returned restore function can be called to restore the file's state - the file in filePath will be replaced by the backup in backupPath.
 // If there is no file at filePath, a backup file won't be created, and the restore function will delete the file at filePath.
 func BackupFile(filePath, backupFileName string) (restore func() error, err error) {
-	fileInfo, err := os.Stat(filePath)
+	fileInfo, err := os.Stat(ToLongPath(filePath))
 	if errorutils.CheckError(err) != nil {
 		if os.IsNotExist(err) {
 			restore = createRestoreFileFunc(filePath, backupFileName)
@@ -103,7 +104,7 @@ func BackupFile(filePath, backupFileName string) (restore func() error, err erro
 }
 
 func cloneFile(origFile, newName string, fileMode os.FileMode) (err error) {
-	from, err := os.Open(origFile)
+	from, err := os.Open(ToLongPath(origFile))
 	if errorutils.CheckError(err) != nil {
 		return
 	}
@@ -111,7 +112,7 @@ func cloneFile(origFile, newName string, fileMode os.FileMode) (err error) {
 		err = errors.Join(err, from.Close())
 	}()
 
-	to, err := os.OpenFile(filepath.Join(filepath.Dir(origFile), newName), os.O_RDWR|os.O_CREATE, fileMode)
+	to, err := os.OpenFile(ToLongPath(filepath.Join(filepath.Dir(origFile), newName)), os.O_RDWR|os.O_CREATE, fileMode)
 	if errorutils.CheckError(err) != nil {
 		return
 	}
@@ -128,32 +129,47 @@ func cloneFile(origFile, newName string, fileMode os.FileMode) (err error) {
 // createRestoreFileFunc creates a function for restoring a file from its backup.
 // The returned function replaces the file in filePath with the backup in backupPath.
 // If there is no file at backupPath (which means there was no file at filePath when BackupFile() was called), then the function deletes the file at filePath.
+// The file is also restored if the command is interrupted before the function is called. The file is restored only once.
 func createRestoreFileFunc(filePath, backupFileName string) func() error {
+	var once sync.Once
+	var restoreErr error
+	restore := func() error {
+		once.Do(func() {
+			restoreErr = restoreFile(filePath, backupFileName)
+		})
+		return restoreErr
+	}
+	unregister := coreutils.RegisterInterruptCleanup(restore)
 	return func() error {
-		backupPath := filepath.Join(filepath.Dir(filePath), backupFileName)
-		if _, err := os.Stat(backupPath); err != nil {
-			if os.IsNotExist(err) {
-				// We verify the existence of the file in the specified filePath before initiating its deletion in order to prevent errors that might occur when attempting to remove a non-existent file
-				var fileExists bool
-				fileExists, err = fileutils.IsFileExists(filePath, false)
-				if err != nil {
-					err = fmt.Errorf("failed to check for the existence of '%s' before deleting the file: %s", filePath, err.Error())
-					return errorutils.CheckError(err)
-				}
-				if fileExists {
-					err = os.Remove(filePath)
-				}
+		unregister()
+		return restore()
+	}
+}
+
+func restoreFile(filePath, backupFileName string) error {
+	backupPath := filepath.Join(filepath.Dir(filePath), backupFileName)
+	if _, err := os.Stat(ToLongPath(backupPath)); err != nil {
+		if os.IsNotExist(err) {
+			// We verify the existence of the file in the specified filePath before initiating its deletion in order to prevent errors that might occur when attempting to remove a non-existent file
+			var fileExists bool
+			fileExists, err = fileutils.IsFileExists(ToLongPath(filePath), false)
+			if err != nil {
+				err = fmt.Errorf("failed to check for the existence of '%s' before deleting the file: %s", filePath, err.Error())
 				return errorutils.CheckError(err)
 			}
-			return errorutils.CheckErrorf(createRestoreErrorPrefix(filePath, backupPath) + err.Error())
-		}
-
-		if err := fileutils.MoveFile(backupPath, filePath); err != nil {
+			if fileExists {
+				err = os.Remove(ToLongPath(filePath))
+			}
 			return errorutils.CheckError(err)
 		}
-		log.Debug("Restored the file", filePath, "successfully")
-		return nil
+		return errorutils.CheckErrorf(createRestoreErrorPrefix(filePath, backupPath) + err.Error())
 	}
+
+	if err := fileutils.MoveFile(ToLongPath(backupPath), ToLongPath(filePath)); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Debug("Restored the file", filePath, "successfully")
+	return nil
 }
 
 func createRestoreErrorPrefix(filePath, backupPath string) string {
@@ -0,0 +1,105 @@
+package ioutils
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The prefix of Windows paths which aren't limited to MAX_PATH (260 characters).
+	longPathPrefix = `\\?\`
+	// The prefix of UNC paths (\\server\share\...) which aren't limited to MAX_PATH.
+	uncLongPathPrefix = `\\?\UNC\`
+)
+
+// FromLongPath returns the path without the long path prefix added by ToLongPath, for displaying it and for matching it against patterns.
+func FromLongPath(path string) string {
+	if strings.HasPrefix(path, uncLongPathPrefix) {
+		return `\\` + strings.TrimPrefix(path, uncLongPathPrefix)
+	}
+	return strings.TrimPrefix(path, longPathPrefix)
+}
+
+// ListFiles lists the paths under the root path, like fspatterns.ListFiles of the client. Go resolves long paths on Windows only
+// if they're absolute, so the root path is walked in the form returned by ToLongPath, and the listed paths are converted back to
+// the form of the root path. The exclude pattern is matched against the converted paths.
+func ListFiles(rootPath string, isRecursive, includeDirs, excludeWithRelativePath, preserveSymlinks bool, excludePathPattern string) ([]string, error) {
+	longRootPath := ToLongPath(rootPath)
+	if longRootPath == rootPath {
+		return fspatterns.ListFiles(rootPath, isRecursive, includeDirs, excludeWithRelativePath, preserveSymlinks, excludePathPattern)
+	}
+	longPaths, err := fspatterns.ListFiles(longRootPath, isRecursive, includeDirs, false, preserveSymlinks, "")
+	if err != nil {
+		return nil, err
+	}
+	var rootFilter string
+	if excludeWithRelativePath {
+		rootFilter = rootPath
+	}
+	var paths []string
+	for _, longPath := range longPaths {
+		path := toRootPathForm(longPath, rootPath, longRootPath)
+		if excludePathPattern != "" {
+			excluded, err := regexp.MatchString(excludePathPattern, strings.TrimPrefix(path, rootFilter))
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			if excluded {
+				log.Debug(fmt.Sprintf("The path '%s' is excluded", path))
+				continue
+			}
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// SearchPatterns returns the submatches of the regexp in the path, like fspatterns.SearchPatterns of the client.
+// The file is inspected by the form of its path returned by ToLongPath, and the regexp is matched against the path as is.
+func SearchPatterns(path string, preserveSymlinks, includeDirs bool, regexp *regexp.Regexp) (matches []string, isDir bool, err error) {
+	longPath := ToLongPath(path)
+	if isDir, err = fileutils.IsDirExists(longPath, false); err != nil {
+		return
+	}
+	isSymlinkFlow := preserveSymlinks && fileutils.IsPathSymlink(longPath)
+	if isDir && !includeDirs && !isSymlinkFlow {
+		return
+	}
+	if isSymlinkFlow {
+		isDir = false
+	}
+	matches = regexp.FindStringSubmatch(path)
+	return
+}
+
+// WalkDir walks the file tree of the root path like filepath.WalkDir, in the form of the root path returned by ToLongPath.
+// The paths passed to the function are in the form of the root path, so file operations on them should use ToLongPath too.
+func WalkDir(rootPath string, walkFn fs.WalkDirFunc) error {
+	longRootPath := ToLongPath(rootPath)
+	return filepath.WalkDir(longRootPath, func(longPath string, entry fs.DirEntry, err error) error {
+		return walkFn(toRootPathForm(longPath, rootPath, longRootPath), entry, err)
+	})
+}
+
+// Converts a path under the long form of the root path to the form of the root path.
+func toRootPathForm(longPath, rootPath, longRootPath string) string {
+	if longRootPath == rootPath {
+		return longPath
+	}
+	relativePath := strings.TrimPrefix(longPath, longRootPath)
+	if relativePath == longPath {
+		return FromLongPath(longPath)
+	}
+	if relativePath == "" {
+		return rootPath
+	}
+	return filepath.Join(rootPath, relativePath)
+}
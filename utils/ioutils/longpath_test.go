@@ -0,0 +1,66 @@
+package ioutils
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromLongPath(t *testing.T) {
+	assert.Equal(t, `C:\project\node_modules\a`, FromLongPath(`\\?\C:\project\node_modules\a`))
+	assert.Equal(t, `\\server\share\project`, FromLongPath(`\\?\UNC\server\share\project`))
+	assert.Equal(t, `C:\project`, FromLongPath(`C:\project`))
+	assert.Equal(t, "/project", FromLongPath("/project"))
+}
+
+func TestLongRelativePath(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	chdirCallback := testsutils.ChangeDirWithCallback(t, wd, t.TempDir())
+	defer chdirCallback()
+
+	// A relative directory deeper than MAX_PATH, like a deep node_modules tree.
+	dir := "project"
+	for len(dir) < 300 {
+		dir = filepath.Join(dir, "node_modules", "package")
+	}
+	require.NoError(t, os.MkdirAll(ToLongPath(dir), 0755))
+	filePath := filepath.Join(dir, "index.js")
+	excludedPath := filepath.Join(dir, "index.test.js")
+	for _, path := range []string{filePath, excludedPath} {
+		require.NoError(t, os.WriteFile(ToLongPath(path), []byte("module.exports = {}"), 0644))
+	}
+
+	// The client's walk is done in the long form of the paths, and the paths are listed in their relative form.
+	paths, err := ListFiles("project", true, false, false, false, `^.*\.test\.js$`)
+	require.NoError(t, err)
+	assert.Contains(t, paths, filePath)
+	assert.NotContains(t, paths, excludedPath)
+	matches, isDir, err := SearchPatterns(filePath, false, false, regexp.MustCompile(`^project.*(index)\.js$`))
+	require.NoError(t, err)
+	assert.False(t, isDir)
+	assert.Equal(t, []string{filePath, "index"}, matches)
+
+	var walked []string
+	require.NoError(t, WalkDir("project", func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			walked = append(walked, path)
+		}
+		return err
+	}))
+	assert.Equal(t, []string{filePath, excludedPath}, walked)
+
+	restore, err := BackupFile(filePath, "index.js.backup")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(ToLongPath(filePath), []byte("changed"), 0644))
+	require.NoError(t, restore())
+	content, err := os.ReadFile(ToLongPath(filePath))
+	require.NoError(t, err)
+	assert.Equal(t, "module.exports = {}", string(content))
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+// +build !windows
+
+package ioutils
+
+// ToLongPath returns the path as is, since the length of paths is limited only on Windows.
+func ToLongPath(path string) string {
+	return path
+}
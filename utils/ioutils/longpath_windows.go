@@ -0,0 +1,23 @@
+package ioutils
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ToLongPath converts the path to an absolute path with the long path prefix, so that file operations on it don't fail when it's
+// longer than MAX_PATH, such as in deep node_modules trees. UNC paths of network shares are converted to the \\?\UNC\ form.
+// Paths which already have a prefix, and paths which can't be resolved, are returned as is.
+func ToLongPath(path string) string {
+	if path == "" || strings.HasPrefix(path, longPathPrefix) || strings.HasPrefix(path, `\\.\`) {
+		return path
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if strings.HasPrefix(absPath, `\\`) {
+		return uncLongPathPrefix + strings.TrimPrefix(absPath, `\\`)
+	}
+	return longPathPrefix + absPath
+}
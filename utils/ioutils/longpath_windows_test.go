@@ -0,0 +1,45 @@
+package ioutils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToLongPath(t *testing.T) {
+	assert.Equal(t, `\\?\C:\project\node_modules`, ToLongPath(`C:\project\node_modules`))
+	assert.Equal(t, `\\?\C:\project\node_modules`, ToLongPath(`C:/project/./lib/../node_modules`))
+	assert.Equal(t, `\\?\UNC\server\share\project`, ToLongPath(`\\server\share\project`))
+	assert.Equal(t, `\\?\C:\project`, ToLongPath(`\\?\C:\project`))
+	assert.Equal(t, `\\.\pipe\name`, ToLongPath(`\\.\pipe\name`))
+	assert.Equal(t, "", ToLongPath(""))
+}
+
+func TestBackupFileLongPath(t *testing.T) {
+	// A directory deeper than MAX_PATH, like a deep node_modules tree.
+	dir := t.TempDir()
+	for len(dir) < 300 {
+		dir = filepath.Join(dir, strings.Repeat("node_modules", 2))
+	}
+	require.NoError(t, os.MkdirAll(ToLongPath(dir), 0755))
+	filePath := filepath.Join(dir, ".npmrc")
+	require.NoError(t, os.WriteFile(ToLongPath(filePath), []byte("original"), 0644))
+
+	restore, err := BackupFile(filePath, ".npmrc.backup")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(ToLongPath(filePath), []byte("changed"), 0644))
+	require.NoError(t, restore())
+	content, err := os.ReadFile(ToLongPath(filePath))
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(content))
+}
+
+func TestToRootPathForm(t *testing.T) {
+	assert.Equal(t, `project\node_modules\a`, toRootPathForm(`\\?\C:\work\project\node_modules\a`, `project`, `\\?\C:\work\project`))
+	assert.Equal(t, `project`, toRootPathForm(`\\?\C:\work\project`, `project`, `\\?\C:\work\project`))
+	assert.Equal(t, `\\server\share\a`, toRootPathForm(`\\?\UNC\server\share\a`, `project`, `\\?\C:\work\project`))
+}
@@ -0,0 +1,139 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/forPelevin/gomoji"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The default human-readable log lines.
+	LogFormatText = "text"
+	// Structured JSON log lines, for ingestion into log management systems.
+	LogFormatJson = "json"
+)
+
+var (
+	// The name of the running command, added to the JSON log lines.
+	commandContext      string
+	commandContextMutex sync.RWMutex
+)
+
+// Returns the log format configured by the JFROG_CLI_LOG_FORMAT environment variable.
+// Unknown formats fall back to the default text format.
+func GetCliLogFormat() string {
+	if strings.ToLower(os.Getenv(coreutils.LogFormat)) == LogFormatJson {
+		return LogFormatJson
+	}
+	return LogFormatText
+}
+
+// SetCommandContext sets the name of the running command, which is added to all the following JSON log lines.
+func SetCommandContext(commandName string) {
+	commandContextMutex.Lock()
+	defer commandContextMutex.Unlock()
+	commandContext = commandName
+}
+
+func getCommandContext() string {
+	commandContextMutex.RLock()
+	defer commandContextMutex.RUnlock()
+	return commandContext
+}
+
+// NewCliLogger creates a logger according to the configured log format.
+// The logs are written to the provided writer, or to Stderr if nil.
+// Log flags to modify the log prefix as described in https://pkg.go.dev/log#pkg-constants, used by the text format only.
+func NewCliLogger(logLevel log.LevelType, writer io.Writer, logFlags int) log.Log {
+	if GetCliLogFormat() == LogFormatJson {
+		return NewJsonLogger(logLevel, writer)
+	}
+	return log.NewLoggerWithFlags(logLevel, writer, logFlags)
+}
+
+// A single JSON log line.
+type jsonLogLine struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+	Command   string `json:"command,omitempty"`
+	Pid       int    `json:"pid"`
+}
+
+// JsonLogger writes each log line as a JSON object.
+// The command's output is written to Stdout as is, to keep it parsable by the caller.
+type JsonLogger struct {
+	logLevel log.LevelType
+	writer   io.Writer
+	output   io.Writer
+	mutex    sync.Mutex
+}
+
+// Creates a new JSON logger with a given log level.
+// All logs are written to Stderr, unless an alternative writer is provided.
+func NewJsonLogger(logLevel log.LevelType, writer io.Writer) *JsonLogger {
+	if writer == nil {
+		writer = os.Stderr
+	}
+	return &JsonLogger{logLevel: logLevel, writer: writer, output: os.Stdout}
+}
+
+func (jl *JsonLogger) GetLogLevel() log.LevelType {
+	return jl.logLevel
+}
+
+func (jl *JsonLogger) Debug(a ...interface{}) {
+	if jl.logLevel >= log.DEBUG {
+		jl.writeLine("debug", a...)
+	}
+}
+
+func (jl *JsonLogger) Info(a ...interface{}) {
+	if jl.logLevel >= log.INFO {
+		jl.writeLine("info", a...)
+	}
+}
+
+func (jl *JsonLogger) Warn(a ...interface{}) {
+	if jl.logLevel >= log.WARN {
+		jl.writeLine("warn", a...)
+	}
+}
+
+func (jl *JsonLogger) Error(a ...interface{}) {
+	if jl.logLevel >= log.ERROR {
+		jl.writeLine("error", a...)
+	}
+}
+
+func (jl *JsonLogger) Output(a ...interface{}) {
+	jl.mutex.Lock()
+	defer jl.mutex.Unlock()
+	_, _ = fmt.Fprintln(jl.output, a...)
+}
+
+func (jl *JsonLogger) writeLine(level string, a ...interface{}) {
+	message := strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	line := jsonLogLine{
+		Timestamp: time.Now().Format(time.RFC3339Nano),
+		Level:     level,
+		Message:   strings.TrimSpace(gomoji.RemoveEmojis(message)),
+		Command:   getCommandContext(),
+		Pid:       os.Getpid(),
+	}
+	content, err := json.Marshal(line)
+	if err != nil {
+		return
+	}
+	jl.mutex.Lock()
+	defer jl.mutex.Unlock()
+	_, _ = jl.writer.Write(append(content, '\n'))
+}
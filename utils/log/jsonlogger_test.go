@@ -0,0 +1,48 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCliLogger(t *testing.T) {
+	_, isJson := NewCliLogger(log.INFO, nil, 0).(*JsonLogger)
+	assert.False(t, isJson)
+
+	testsutils.SetEnvAndAssert(t, coreutils.LogFormat, "JSON")
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.LogFormat)
+	_, isJson = NewCliLogger(log.INFO, nil, 0).(*JsonLogger)
+	assert.True(t, isJson)
+}
+
+func TestJsonLogger(t *testing.T) {
+	SetCommandContext("rt_upload")
+	defer SetCommandContext("")
+	buffer := &bytes.Buffer{}
+	logger := NewJsonLogger(log.INFO, buffer)
+
+	logger.Debug("filtered out")
+	logger.Info("Uploading", "file.zip")
+	logger.Error("Upload failed")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if !assert.Len(t, lines, 2) {
+		return
+	}
+	var line jsonLogLine
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &line))
+	assert.Equal(t, "info", line.Level)
+	assert.Equal(t, "Uploading file.zip", line.Message)
+	assert.Equal(t, "rt_upload", line.Command)
+	assert.NotEmpty(t, line.Timestamp)
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &line))
+	assert.Equal(t, "error", line.Level)
+}
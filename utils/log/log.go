@@ -37,7 +37,7 @@ func getJfrogCliLogTimestamp() int {
 }
 
 func SetDefaultLogger() {
-	log.SetLogger(log.NewLoggerWithFlags(GetCliLogLevel(), nil, getJfrogCliLogTimestamp()))
+	log.SetLogger(NewCliLogger(GetCliLogLevel(), nil, getJfrogCliLogTimestamp()))
 }
 
 const DefaultLogTimeLayout = "2006-01-02.15-04-05"
@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// When the metrics file exceeds this size, it's rotated to a single backup file, to bound the disk usage.
+const maxMetricsFileSize = 10 * 1024 * 1024
+
+// The recorder of the running command. Nil if metrics are disabled.
+var current atomic.Pointer[Recorder]
+
+// ApiLatency aggregates the latencies of the requests sent to an API.
+type ApiLatency struct {
+	Requests    int64 `json:"requests"`
+	TotalMillis int64 `json:"totalMillis"`
+	MaxMillis   int64 `json:"maxMillis"`
+}
+
+// CommandRecord holds the metrics of a single command execution. The records are appended as JSON lines to
+// '.jfrog/metrics/commands.jsonl'.
+type CommandRecord struct {
+	Command        string                 `json:"command"`
+	StartTime      time.Time              `json:"startTime"`
+	DurationMillis int64                  `json:"durationMillis"`
+	Success        bool                   `json:"success"`
+	BytesSent      int64                  `json:"bytesSent"`
+	BytesReceived  int64                  `json:"bytesReceived"`
+	Retries        int64                  `json:"retries"`
+	Apis           map[string]*ApiLatency `json:"apis,omitempty"`
+}
+
+// Recorder collects the metrics of the running command.
+type Recorder struct {
+	record        CommandRecord
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+	retries       atomic.Int64
+	apisMutex     sync.Mutex
+	// The recorder of the outer command, if the command is executed by another command, such as a pipeline step.
+	previous *Recorder
+}
+
+// IsEnabled returns true if the JFROG_CLI_METRICS environment variable is set to true.
+// Metrics are recorded locally only, and never sent to any server.
+func IsEnabled() bool {
+	return strings.ToLower(os.Getenv(coreutils.Metrics)) == "true"
+}
+
+// Start starts recording the metrics of the command, if metrics are enabled. Otherwise, returns nil.
+// The recorder of a running outer command is restored when the command finishes.
+func Start(command string) *Recorder {
+	if !IsEnabled() {
+		return nil
+	}
+	recorder := &Recorder{record: CommandRecord{Command: command, StartTime: time.Now(), Apis: map[string]*ApiLatency{}}}
+	recorder.previous = current.Swap(recorder)
+	return recorder
+}
+
+// Finish stops recording, and appends the command's record to the metrics file.
+func (r *Recorder) Finish(commandErr error) error {
+	current.CompareAndSwap(r, r.previous)
+	r.record.DurationMillis = time.Since(r.record.StartTime).Milliseconds()
+	r.record.Success = commandErr == nil
+	r.record.BytesSent = r.bytesSent.Load()
+	r.record.BytesReceived = r.bytesReceived.Load()
+	r.record.Retries = r.retries.Load()
+	metricsDir, err := coreutils.GetJfrogMetricsDir()
+	if err != nil {
+		return err
+	}
+	return appendRecord(metricsDir, &r.record)
+}
+
+// AddRetry counts a retried HTTP request of the running command.
+func AddRetry() {
+	if recorder := current.Load(); recorder != nil {
+		recorder.retries.Add(1)
+	}
+}
+
+func addBytesSent(bytes int64) {
+	if recorder := current.Load(); recorder != nil {
+		recorder.bytesSent.Add(bytes)
+	}
+}
+
+func addBytesReceived(bytes int64) {
+	if recorder := current.Load(); recorder != nil {
+		recorder.bytesReceived.Add(bytes)
+	}
+}
+
+func recordLatency(api string, latency time.Duration) {
+	recorder := current.Load()
+	if recorder == nil {
+		return
+	}
+	recorder.apisMutex.Lock()
+	defer recorder.apisMutex.Unlock()
+	apiLatency, exists := recorder.record.Apis[api]
+	if !exists {
+		apiLatency = &ApiLatency{}
+		recorder.record.Apis[api] = apiLatency
+	}
+	millis := latency.Milliseconds()
+	apiLatency.Requests++
+	apiLatency.TotalMillis += millis
+	if millis > apiLatency.MaxMillis {
+		apiLatency.MaxMillis = millis
+	}
+}
+
+func appendRecord(metricsDir string, record *CommandRecord) error {
+	if err := os.MkdirAll(metricsDir, 0700); err != nil {
+		return errorutils.CheckError(err)
+	}
+	metricsFilePath := filepath.Join(metricsDir, coreutils.JfrogMetricsFileName)
+	if fileInfo, err := os.Stat(metricsFilePath); err == nil && fileInfo.Size() > maxMetricsFileSize {
+		if err = os.Rename(metricsFilePath, metricsFilePath+".old"); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	content, err := json.Marshal(record)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	// The record is appended in a single write, so that records of concurrent processes aren't interleaved.
+	metricsFile, err := os.OpenFile(metricsFilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = metricsFile.Write(append(content, '\n'))
+	return errorutils.CheckError(errors.Join(err, metricsFile.Close()))
+}
+
+// ReadRecords reads the command records from the metrics directory, including the rotated backup file.
+// Corrupted lines are skipped.
+func ReadRecords(metricsDir string) ([]CommandRecord, error) {
+	var records []CommandRecord
+	metricsFilePath := filepath.Join(metricsDir, coreutils.JfrogMetricsFileName)
+	for _, path := range []string{metricsFilePath + ".old", metricsFilePath} {
+		file, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errorutils.CheckError(err)
+		}
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var record CommandRecord
+			if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				log.Debug("Skipping a corrupted metrics record in", path+":", err.Error())
+				continue
+			}
+			records = append(records, record)
+		}
+		err = scanner.Err()
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	return records, nil
+}
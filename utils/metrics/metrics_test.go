@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartDisabled(t *testing.T) {
+	assert.Nil(t, Start("rt_upload"))
+}
+
+func TestRecordCommand(t *testing.T) {
+	homeDir := t.TempDir()
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, homeDir)
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.HomeDir)
+	testsutils.SetEnvAndAssert(t, coreutils.Metrics, "true")
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.Metrics)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = w.Write([]byte("response"))
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: WrapRoundTripper(http.DefaultTransport)}
+
+	recorder := Start("rt_upload")
+	require.NotNil(t, recorder)
+	for _, path := range []string{"/artifactory/api/storage/repo/a", "/artifactory/api/storage/repo/b", "/artifactory/repo/file"} {
+		resp, err := client.Post(server.URL+path, "text/plain", strings.NewReader("request"))
+		require.NoError(t, err)
+		_, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	AddRetry()
+	require.NoError(t, recorder.Finish(nil))
+	// Requests sent after the command finished aren't recorded.
+	AddRetry()
+
+	records, err := ReadRecords(filepath.Join(homeDir, coreutils.JfrogMetricsDirName))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	record := records[0]
+	assert.Equal(t, "rt_upload", record.Command)
+	assert.True(t, record.Success)
+	assert.Equal(t, int64(3*len("request")), record.BytesSent)
+	assert.Equal(t, int64(3*len("response")), record.BytesReceived)
+	assert.Equal(t, int64(1), record.Retries)
+	require.Len(t, record.Apis, 2)
+	assert.Equal(t, int64(2), record.Apis["POST api/storage"].Requests)
+	assert.Equal(t, int64(1), record.Apis["POST files"].Requests)
+}
+
+func TestRecordNestedCommand(t *testing.T) {
+	homeDir := t.TempDir()
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, homeDir)
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.HomeDir)
+	testsutils.SetEnvAndAssert(t, coreutils.Metrics, "true")
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.Metrics)
+
+	outer := Start("pipeline")
+	inner := Start("rt_upload")
+	AddRetry()
+	require.NoError(t, inner.Finish(nil))
+	// The outer command keeps being recorded after the inner command finished.
+	AddRetry()
+	AddRetry()
+	require.NoError(t, outer.Finish(nil))
+	AddRetry()
+
+	records, err := ReadRecords(filepath.Join(homeDir, coreutils.JfrogMetricsDirName))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "rt_upload", records[0].Command)
+	assert.Equal(t, int64(1), records[0].Retries)
+	assert.Equal(t, "pipeline", records[1].Command)
+	assert.Equal(t, int64(2), records[1].Retries)
+}
+
+func TestReadRecordsSkipsCorruptedLines(t *testing.T) {
+	metricsDir := t.TempDir()
+	content := `{"command":"old"}` + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(metricsDir, coreutils.JfrogMetricsFileName+".old"), []byte(content), 0600))
+	content = `{"command":"first"}` + "\n" + `{"comm` + "\n" + `{"command":"second"}` + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(metricsDir, coreutils.JfrogMetricsFileName), []byte(content), 0600))
+	records, err := ReadRecords(metricsDir)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "old", records[0].Command)
+	assert.Equal(t, "second", records[2].Command)
+}
+
+func TestAggregate(t *testing.T) {
+	records := []CommandRecord{
+		{Command: "rt_download", DurationMillis: 100, Success: true, BytesReceived: 10},
+		{Command: "rt_upload", DurationMillis: 1000, Success: true, BytesSent: 100, Retries: 2,
+			Apis: map[string]*ApiLatency{"PUT files": {Requests: 2, TotalMillis: 400, MaxMillis: 300}}},
+		{Command: "rt_upload", DurationMillis: 3000, Success: false, BytesSent: 50,
+			Apis: map[string]*ApiLatency{"PUT files": {Requests: 2, TotalMillis: 200, MaxMillis: 150}, "GET api/system": {Requests: 1, TotalMillis: 10, MaxMillis: 10}}},
+	}
+	commands, apis := Aggregate(records)
+	require.Len(t, commands, 2)
+	assert.Equal(t, CommandStats{Command: "rt_upload", Runs: 2, Failures: 1, AverageDuration: 2 * time.Second, P95Duration: 3 * time.Second,
+		MaxDuration: 3 * time.Second, BytesSent: 150, Retries: 2}, commands[0])
+	assert.Equal(t, "rt_download", commands[1].Command)
+	assert.Equal(t, []ApiStats{
+		{Api: "PUT files", Requests: 4, AverageLatency: 150 * time.Millisecond, MaxLatency: 300 * time.Millisecond},
+		{Api: "GET api/system", Requests: 1, AverageLatency: 10 * time.Millisecond, MaxLatency: 10 * time.Millisecond},
+	}, apis)
+}
+
+func TestGetApiName(t *testing.T) {
+	assert.Equal(t, "GET api/storage", GetApiName(http.MethodGet, "/artifactory/api/storage/repo/path"))
+	assert.Equal(t, "POST api/search", GetApiName(http.MethodPost, "/api/search/aql"))
+	assert.Equal(t, "PUT files", GetApiName(http.MethodPut, "/artifactory/repo/path/file.zip"))
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WrapRoundTripper returns a round tripper which records the bytes transferred and the latencies of the requests
+// in the metrics of the running command.
+func WrapRoundTripper(next http.RoundTripper) http.RoundTripper {
+	return &metricsRoundTripper{next: next}
+}
+
+type metricsRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (mrt *metricsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if current.Load() == nil {
+		return mrt.next.RoundTrip(req)
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		// A round tripper must not modify the provided request.
+		req = req.Clone(req.Context())
+		req.Body = &countingReadCloser{ReadCloser: req.Body, count: addBytesSent}
+	}
+	start := time.Now()
+	resp, err := mrt.next.RoundTrip(req)
+	// The latency is the time to the response headers, since the time to read the body depends on its size.
+	recordLatency(GetApiName(req.Method, req.URL.Path), time.Since(start))
+	if resp != nil && resp.Body != nil {
+		resp.Body = &countingReadCloser{ReadCloser: resp.Body, count: addBytesReceived}
+	}
+	return resp, err
+}
+
+// GetApiName groups requests by the REST API they're sent to, such as 'GET api/storage'.
+// Requests to other paths are file deployments and downloads, grouped by their method only.
+func GetApiName(method, path string) string {
+	if _, apiPath, found := strings.Cut(path, "/api/"); found {
+		resource, _, _ := strings.Cut(apiPath, "/")
+		return method + " api/" + resource
+	}
+	return method + " files"
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	count func(int64)
+}
+
+func (crc *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := crc.ReadCloser.Read(p)
+	crc.count(int64(n))
+	return n, err
+}
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+)
+
+// CommandStats aggregates the records of a command.
+type CommandStats struct {
+	Command         string        `json:"command"`
+	Runs            int           `json:"runs"`
+	Failures        int           `json:"failures"`
+	AverageDuration time.Duration `json:"averageDuration"`
+	P95Duration     time.Duration `json:"p95Duration"`
+	MaxDuration     time.Duration `json:"maxDuration"`
+	BytesSent       int64         `json:"bytesSent"`
+	BytesReceived   int64         `json:"bytesReceived"`
+	Retries         int64         `json:"retries"`
+}
+
+// ApiStats aggregates the latencies of an API over all the records.
+type ApiStats struct {
+	Api            string        `json:"api"`
+	Requests       int64         `json:"requests"`
+	AverageLatency time.Duration `json:"averageLatency"`
+	MaxLatency     time.Duration `json:"maxLatency"`
+}
+
+// Aggregate aggregates the records by their commands and APIs. The commands are sorted by their total duration,
+// and the APIs by their average latency, in descending order, so that the slowest are displayed first.
+func Aggregate(records []CommandRecord) ([]CommandStats, []ApiStats) {
+	durations := map[string][]time.Duration{}
+	commandStats := map[string]*CommandStats{}
+	apiLatencies := map[string]*ApiLatency{}
+	for _, record := range records {
+		stats, exists := commandStats[record.Command]
+		if !exists {
+			stats = &CommandStats{Command: record.Command}
+			commandStats[record.Command] = stats
+		}
+		stats.Runs++
+		if !record.Success {
+			stats.Failures++
+		}
+		stats.BytesSent += record.BytesSent
+		stats.BytesReceived += record.BytesReceived
+		stats.Retries += record.Retries
+		durations[record.Command] = append(durations[record.Command], time.Duration(record.DurationMillis)*time.Millisecond)
+		for api, latency := range record.Apis {
+			total, exists := apiLatencies[api]
+			if !exists {
+				total = &ApiLatency{}
+				apiLatencies[api] = total
+			}
+			total.Requests += latency.Requests
+			total.TotalMillis += latency.TotalMillis
+			if latency.MaxMillis > total.MaxMillis {
+				total.MaxMillis = latency.MaxMillis
+			}
+		}
+	}
+
+	var commands []CommandStats
+	totalDurations := map[string]time.Duration{}
+	for command, stats := range commandStats {
+		commandDurations := durations[command]
+		sort.Slice(commandDurations, func(i, j int) bool { return commandDurations[i] < commandDurations[j] })
+		var total time.Duration
+		for _, duration := range commandDurations {
+			total += duration
+		}
+		totalDurations[command] = total
+		stats.AverageDuration = total / time.Duration(len(commandDurations))
+		stats.P95Duration = commandDurations[(len(commandDurations)*95+99)/100-1]
+		stats.MaxDuration = commandDurations[len(commandDurations)-1]
+		commands = append(commands, *stats)
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		if totalDurations[commands[i].Command] != totalDurations[commands[j].Command] {
+			return totalDurations[commands[i].Command] > totalDurations[commands[j].Command]
+		}
+		return commands[i].Command < commands[j].Command
+	})
+
+	var apis []ApiStats
+	for api, latency := range apiLatencies {
+		if latency.Requests == 0 {
+			continue
+		}
+		apis = append(apis, ApiStats{
+			Api:            api,
+			Requests:       latency.Requests,
+			AverageLatency: time.Duration(latency.TotalMillis/latency.Requests) * time.Millisecond,
+			MaxLatency:     time.Duration(latency.MaxMillis) * time.Millisecond,
+		})
+	}
+	sort.Slice(apis, func(i, j int) bool {
+		if apis[i].AverageLatency != apis[j].AverageLatency {
+			return apis[i].AverageLatency > apis[j].AverageLatency
+		}
+		return apis[i].Api < apis[j].Api
+	})
+	return commands, apis
+}
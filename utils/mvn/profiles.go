@@ -0,0 +1,297 @@
+package mvnutils
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/stringutils"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+// The patterns of the artifacts produced by common test and coverage profiles, used for profiles which aren't mapped in the config file.
+var defaultProfilesExcludePatterns = map[string]string{
+	"test":     "*-tests.jar,*-test-sources.jar",
+	"tests":    "*-tests.jar,*-test-sources.jar",
+	"coverage": "*-coverage.*,*jacoco*",
+	"jacoco":   "*-coverage.*,*jacoco*",
+}
+
+type pomProfiles struct {
+	Profiles []struct {
+		Id         string `xml:"id"`
+		Activation struct {
+			ActiveByDefault bool `xml:"activeByDefault"`
+		} `xml:"activation"`
+	} `xml:"profiles>profile"`
+}
+
+// GetActiveProfiles returns the Maven profiles activated by the goals and options of the command, such as '-P coverage,!docs'.
+// If no profiles are activated explicitly, the profiles of the POM which are active by default are returned.
+func GetActiveProfiles(goals []string, workingDir string) ([]string, error) {
+	activated, deactivated, pomPath := parseProfilesOptions(goals)
+	if len(activated) == 0 {
+		if pomPath == "" {
+			pomPath = filepath.Join(workingDir, "pom.xml")
+		} else if !filepath.IsAbs(pomPath) {
+			pomPath = filepath.Join(workingDir, pomPath)
+		}
+		var err error
+		if activated, err = getActiveByDefaultProfiles(pomPath); err != nil {
+			return nil, err
+		}
+	}
+	var activeProfiles []string
+	for _, profile := range activated {
+		if !deactivated[profile] {
+			activeProfiles = append(activeProfiles, profile)
+		}
+	}
+	return activeProfiles, nil
+}
+
+// Parses the -P/--activate-profiles and -f/--file options of the Maven command.
+func parseProfilesOptions(goals []string) (activated []string, deactivated map[string]bool, pomPath string) {
+	deactivated = map[string]bool{}
+	addProfiles := func(value string) {
+		for _, profile := range strings.Split(value, ",") {
+			profile = strings.TrimPrefix(strings.TrimSpace(profile), "?")
+			switch {
+			case profile == "":
+			case strings.HasPrefix(profile, "!"), strings.HasPrefix(profile, "-"):
+				deactivated[profile[1:]] = true
+			default:
+				activated = append(activated, strings.TrimPrefix(profile, "+"))
+			}
+		}
+	}
+	for i := 0; i < len(goals); i++ {
+		goal := goals[i]
+		switch {
+		case goal == "-P" || goal == "--activate-profiles" || goal == "-f" || goal == "--file":
+			if i+1 >= len(goals) {
+				continue
+			}
+			i++
+			if goal == "-f" || goal == "--file" {
+				pomPath = goals[i]
+			} else {
+				addProfiles(goals[i])
+			}
+		case strings.HasPrefix(goal, "--activate-profiles="):
+			addProfiles(strings.TrimPrefix(goal, "--activate-profiles="))
+		case strings.HasPrefix(goal, "--file="):
+			pomPath = strings.TrimPrefix(goal, "--file=")
+		case strings.HasPrefix(goal, "-P"):
+			addProfiles(strings.TrimPrefix(goal, "-P"))
+		}
+	}
+	return
+}
+
+func getActiveByDefaultProfiles(pomPath string) ([]string, error) {
+	if info, err := os.Stat(pomPath); err == nil && info.IsDir() {
+		pomPath = filepath.Join(pomPath, "pom.xml")
+	}
+	content, err := os.ReadFile(pomPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	var pom pomProfiles
+	if err = xml.Unmarshal(content, &pom); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the profiles of %s: %s", pomPath, err.Error())
+	}
+	var profiles []string
+	for _, profile := range pom.Profiles {
+		if profile.Activation.ActiveByDefault {
+			profiles = append(profiles, profile.Id)
+		}
+	}
+	return profiles, nil
+}
+
+// Returns the exclude patterns of the active profiles, according to the deployer.profilesExcludePatterns mapping of the config file.
+// If deployer.defaultProfilesExcludePatterns is set, profiles which aren't mapped fall back to the default patterns of common test and coverage profiles.
+func getProfilesExcludePatterns(vConfig *viper.Viper, activeProfiles []string) []string {
+	mapping := vConfig.GetStringMapString(buildUtils.DeployerPrefix + buildUtils.ProfilesExcludePatterns)
+	useDefaults := vConfig.GetBool(buildUtils.DeployerPrefix + buildUtils.DefaultProfilesExcludePatterns)
+	patternsSet := map[string]bool{}
+	for _, profile := range activeProfiles {
+		patterns, exists := mapping[strings.ToLower(profile)]
+		if !exists && useDefaults {
+			patterns = defaultProfilesExcludePatterns[strings.ToLower(profile)]
+		}
+		for _, pattern := range splitPatterns(patterns) {
+			patternsSet[pattern] = true
+		}
+	}
+	patterns := make([]string, 0, len(patternsSet))
+	for pattern := range patternsSet {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+func splitPatterns(patterns string) (split []string) {
+	for _, pattern := range strings.Split(patterns, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			split = append(split, pattern)
+		}
+	}
+	return
+}
+
+// The exclusion of the artifacts of the active Maven profiles from the deployment.
+type profilesExclusion struct {
+	// The exclude patterns of the active profiles. The matching artifacts are recorded in the build-info as dependencies.
+	patterns []string
+	// The exclude patterns configured for the deployer, if the matching artifacts are filtered from the build-info.
+	filteredPatterns []string
+	buildDir         string
+	// The build-info files which existed in the build directory before the Maven run.
+	existingBuildFiles map[string]bool
+}
+
+// Excludes the artifacts produced by the active profiles from the deployment.
+// Returns nil if none of the active profiles has exclude patterns.
+// The extractor is configured to keep the excluded artifacts in the build-info, so that the artifacts of the profiles can be
+// recorded as dependencies after the run, and the artifacts of the configured exclude patterns can be filtered as configured.
+func setProfilesExcludePatterns(vConfig *viper.Viper, goals []string) (*profilesExclusion, error) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	activeProfiles, err := GetActiveProfiles(goals, workingDir)
+	if err != nil {
+		return nil, err
+	}
+	patterns := getProfilesExcludePatterns(vConfig, activeProfiles)
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	log.Info("Excluding the artifacts of the active Maven profiles from the deployment, and recording them as build dependencies:", strings.Join(patterns, ", "))
+	exclusion := &profilesExclusion{patterns: patterns}
+	excludePatternsKey := buildUtils.DeployerPrefix + buildUtils.ExcludePatterns
+	filterKey := buildUtils.DeployerPrefix + buildUtils.FilterExcludedArtifactsFromBuild
+	if configured := vConfig.GetString(excludePatternsKey); configured != "" {
+		if !vConfig.IsSet(filterKey) || vConfig.GetBool(filterKey) {
+			exclusion.filteredPatterns = splitPatterns(configured)
+		}
+		patterns = append([]string{configured}, patterns...)
+	}
+	vConfig.Set(filterKey, "false")
+	vConfig.Set(excludePatternsKey, strings.Join(patterns, ", "))
+	return exclusion, nil
+}
+
+// Lists the build-info files in the build directory before the Maven run, to identify the build-info file generated by the run.
+func (pe *profilesExclusion) setBuildDir(buildDir string) error {
+	files, err := listBuildInfoFiles(buildDir)
+	if err != nil {
+		return err
+	}
+	pe.buildDir = buildDir
+	pe.existingBuildFiles = map[string]bool{}
+	for _, file := range files {
+		pe.existingBuildFiles[file] = true
+	}
+	return nil
+}
+
+// Updates the excluded artifacts in the build-info files generated by the Maven run.
+func (pe *profilesExclusion) updateGeneratedBuildInfo() error {
+	files, err := listBuildInfoFiles(pe.buildDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if pe.existingBuildFiles[file] {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		buildInfo := new(entities.BuildInfo)
+		if err = json.Unmarshal(content, buildInfo); err != nil {
+			return errorutils.CheckErrorf("failed parsing the build-info file %s: %s", file, err.Error())
+		}
+		if !pe.recordExcludedArtifacts(buildInfo) {
+			continue
+		}
+		if content, err = json.Marshal(buildInfo); err != nil {
+			return errorutils.CheckError(err)
+		}
+		if err = os.WriteFile(file, content, 0600); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+func listBuildInfoFiles(buildDir string) ([]string, error) {
+	paths, err := fileutils.ListFiles(buildDir, false)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, path := range paths {
+		isDir, err := fileutils.IsDirExists(path, false)
+		if err != nil {
+			return nil, err
+		}
+		if !isDir {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+// Moves the artifacts of the active profiles to the dependencies of their modules, and removes the artifacts of the filtered patterns.
+// Returns true if the build-info was modified.
+func (pe *profilesExclusion) recordExcludedArtifacts(buildInfo *entities.BuildInfo) (modified bool) {
+	for i := range buildInfo.Modules {
+		module := &buildInfo.Modules[i]
+		var artifacts []entities.Artifact
+		for _, artifact := range module.Artifacts {
+			switch {
+			case matchesExcludePatterns(artifact, pe.filteredPatterns):
+			case matchesExcludePatterns(artifact, pe.patterns):
+				module.Dependencies = append(module.Dependencies, entities.Dependency{Id: artifact.Name, Type: artifact.Type, Checksum: artifact.Checksum})
+			default:
+				artifacts = append(artifacts, artifact)
+				continue
+			}
+			modified = true
+		}
+		module.Artifacts = artifacts
+	}
+	return
+}
+
+// The patterns are matched against the name and the deployment path of the artifact.
+func matchesExcludePatterns(artifact entities.Artifact, patterns []string) bool {
+	for _, pattern := range patterns {
+		for _, value := range []string{artifact.Name, artifact.Path} {
+			if value == "" {
+				continue
+			}
+			if matched, err := stringutils.MatchWildcardPattern(pattern, value); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package mvnutils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pomWithProfiles = `<project>
+  <profiles>
+    <profile>
+      <id>coverage</id>
+      <activation><activeByDefault>true</activeByDefault></activation>
+    </profile>
+    <profile>
+      <id>docs</id>
+    </profile>
+  </profiles>
+</project>`
+
+func TestGetActiveProfiles(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "pom.xml"), []byte(pomWithProfiles), 0644))
+	testCases := []struct {
+		name     string
+		goals    []string
+		expected []string
+	}{
+		{"active by default", []string{"clean", "install"}, []string{"coverage"}},
+		{"deactivated by default profile", []string{"install", "-P", "!coverage"}, nil},
+		{"explicit profiles", []string{"install", "-Ptest,docs,!docs"}, []string{"test"}},
+		{"long option", []string{"install", "--activate-profiles=jacoco,?it"}, []string{"jacoco", "it"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			profiles, err := GetActiveProfiles(testCase.goals, projectDir)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, profiles)
+		})
+	}
+
+	// The POM is read from the path of the -f option.
+	profiles, err := GetActiveProfiles([]string{"install", "-f", "missing/pom.xml"}, projectDir)
+	assert.NoError(t, err)
+	assert.Empty(t, profiles)
+}
+
+func TestSetProfilesExcludePatterns(t *testing.T) {
+	vConfig := viper.New()
+	vConfig.Set(buildUtils.DeployerPrefix+buildUtils.ProfilesExcludePatterns, map[string]string{"it": "*-it.jar"})
+	assert.Equal(t, []string{"*-it.jar"}, getProfilesExcludePatterns(vConfig, []string{"it", "docs"}))
+	// The default patterns of the test and coverage profiles are opt-in.
+	assert.Equal(t, []string{"*-it.jar"}, getProfilesExcludePatterns(vConfig, []string{"IT", "test"}))
+	vConfig.Set(buildUtils.DeployerPrefix+buildUtils.DefaultProfilesExcludePatterns, true)
+	assert.Equal(t, []string{"*-it.jar", "*-test-sources.jar", "*-tests.jar"}, getProfilesExcludePatterns(vConfig, []string{"IT", "test"}))
+
+	// The excluded artifacts are kept in the build-info by the extractor, to be recorded as dependencies.
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() {
+		assert.NoError(t, os.Chdir(wd))
+	}()
+	exclusion, err := setProfilesExcludePatterns(vConfig, []string{"install", "-Pit"})
+	require.NoError(t, err)
+	assert.Equal(t, &profilesExclusion{patterns: []string{"*-it.jar"}}, exclusion)
+	assert.Equal(t, "*-it.jar", vConfig.GetString(buildUtils.DeployerPrefix+buildUtils.ExcludePatterns))
+	assert.Equal(t, "false", vConfig.GetString(buildUtils.DeployerPrefix+buildUtils.FilterExcludedArtifactsFromBuild))
+
+	// The artifacts of the configured patterns are filtered from the build-info after the run, as configured.
+	vConfig = viper.New()
+	vConfig.Set(buildUtils.DeployerPrefix+buildUtils.DefaultProfilesExcludePatterns, true)
+	vConfig.Set(buildUtils.DeployerPrefix+buildUtils.ExcludePatterns, "*.zip")
+	exclusion, err = setProfilesExcludePatterns(vConfig, []string{"install", "-Ptest"})
+	require.NoError(t, err)
+	assert.Equal(t, &profilesExclusion{patterns: []string{"*-test-sources.jar", "*-tests.jar"}, filteredPatterns: []string{"*.zip"}}, exclusion)
+	assert.Equal(t, "*.zip, *-test-sources.jar, *-tests.jar", vConfig.GetString(buildUtils.DeployerPrefix+buildUtils.ExcludePatterns))
+	assert.Equal(t, "false", vConfig.GetString(buildUtils.DeployerPrefix+buildUtils.FilterExcludedArtifactsFromBuild))
+
+	// No exclusion without active profiles with patterns.
+	exclusion, err = setProfilesExcludePatterns(viper.New(), []string{"install", "-Ptest"})
+	assert.NoError(t, err)
+	assert.Nil(t, exclusion)
+}
+
+func TestUpdateGeneratedBuildInfo(t *testing.T) {
+	buildDir := t.TempDir()
+	existingBuildInfo := `{"modules":[{"id":"org.acme:lib:1.0","artifacts":[{"name":"lib-1.0-tests.jar"}]}]}`
+	require.NoError(t, os.WriteFile(filepath.Join(buildDir, "existing"), []byte(existingBuildInfo), 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(buildDir, "partials"), 0700))
+
+	exclusion := &profilesExclusion{patterns: []string{"*-tests.jar"}, filteredPatterns: []string{"*.zip"}}
+	require.NoError(t, exclusion.setBuildDir(buildDir))
+	generated := entities.BuildInfo{Modules: []entities.Module{{Id: "org.acme:app:1.0", Artifacts: []entities.Artifact{
+		{Name: "app-1.0.jar", Type: "jar", Path: "org/acme/app/1.0/app-1.0.jar", Checksum: entities.Checksum{Sha1: "111"}},
+		{Name: "app-1.0-tests.jar", Type: "jar", Path: "org/acme/app/1.0/app-1.0-tests.jar", Checksum: entities.Checksum{Sha1: "222"}},
+		{Name: "app-1.0.zip", Type: "zip", Path: "org/acme/app/1.0/app-1.0.zip"},
+	}}}}
+	content, err := json.Marshal(generated)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(buildDir, "generated"), content, 0600))
+
+	require.NoError(t, exclusion.updateGeneratedBuildInfo())
+	content, err = os.ReadFile(filepath.Join(buildDir, "generated"))
+	require.NoError(t, err)
+	var updated entities.BuildInfo
+	require.NoError(t, json.Unmarshal(content, &updated))
+	require.Len(t, updated.Modules, 1)
+	assert.Equal(t, []entities.Artifact{generated.Modules[0].Artifacts[0]}, updated.Modules[0].Artifacts)
+	assert.Equal(t, []entities.Dependency{{Id: "app-1.0-tests.jar", Type: "jar", Checksum: entities.Checksum{Sha1: "222"}}}, updated.Modules[0].Dependencies)
+
+	// The build-info files which existed before the run aren't modified.
+	content, err = os.ReadFile(filepath.Join(buildDir, "existing"))
+	require.NoError(t, err)
+	assert.Equal(t, existingBuildInfo, string(content))
+}
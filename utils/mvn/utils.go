@@ -90,10 +90,21 @@ func RunMvn(mu *MvnUtils) error {
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
-	props, useWrapper, err := createMvnRunProps(mu.vConfig, mu.buildArtifactsDetailsFile, mu.threads, mu.insecureTls, mu.disableDeploy)
+	props, useWrapper, exclusion, err := createMvnRunProps(mu.vConfig, mu.buildArtifactsDetailsFile, mu.goals, mu.threads, mu.insecureTls, mu.disableDeploy)
 	if err != nil {
 		return err
 	}
+	if exclusion != nil && buildName != "" && buildNumber != "" {
+		buildDir, err := buildUtils.GetBuildDir(buildName, buildNumber, mu.buildConf.GetProject())
+		if err != nil {
+			return err
+		}
+		if err = exclusion.setBuildDir(buildDir); err != nil {
+			return err
+		}
+	} else {
+		exclusion = nil
+	}
 	var mvnOpts []string
 	if v := os.Getenv("MAVEN_OPTS"); v != "" {
 		mvnOpts = strings.Fields(v)
@@ -113,7 +124,13 @@ func RunMvn(mu *MvnUtils) error {
 		useWrapper).
 		SetOutputWriter(mu.outputWriter)
 	mavenModule.SetMavenOpts(mvnOpts...)
-	return coreutils.ConvertExitCodeError(mavenModule.CalcDependencies())
+	if err = mavenModule.CalcDependencies(); err != nil {
+		return coreutils.ConvertExitCodeError(err)
+	}
+	if exclusion != nil {
+		return exclusion.updateGeneratedBuildInfo()
+	}
+	return nil
 }
 
 func getMavenDependencyLocalPath() (string, error) {
@@ -124,7 +141,7 @@ func getMavenDependencyLocalPath() (string, error) {
 	return filepath.Join(dependenciesPath, "maven", build.MavenExtractorDependencyVersion), nil
 }
 
-func createMvnRunProps(vConfig *viper.Viper, buildArtifactsDetailsFile string, threads int, insecureTls, disableDeploy bool) (props map[string]string, useWrapper bool, err error) {
+func createMvnRunProps(vConfig *viper.Viper, buildArtifactsDetailsFile string, goals []string, threads int, insecureTls, disableDeploy bool) (props map[string]string, useWrapper bool, exclusion *profilesExclusion, err error) {
 	useWrapper = vConfig.GetBool("useWrapper")
 	vConfig.Set(buildUtils.InsecureTls, insecureTls)
 	if threads > 0 {
@@ -133,6 +150,10 @@ func createMvnRunProps(vConfig *viper.Viper, buildArtifactsDetailsFile string, t
 
 	if disableDeploy {
 		setDeployFalse(vConfig)
+	} else if vConfig.IsSet("deployer") {
+		if exclusion, err = setProfilesExcludePatterns(vConfig, goals); err != nil {
+			return
+		}
 	}
 
 	if vConfig.IsSet("resolver") {
@@ -140,7 +161,7 @@ func createMvnRunProps(vConfig *viper.Viper, buildArtifactsDetailsFile string, t
 	}
 	buildInfoProps, err := buildUtils.CreateBuildInfoProps(buildArtifactsDetailsFile, vConfig, project.Maven)
 
-	return buildInfoProps, useWrapper, err
+	return buildInfoProps, useWrapper, exclusion, err
 }
 
 func setDeployFalse(vConfig *viper.Viper) {
@@ -0,0 +1,185 @@
+package plugins
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/dependencies"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The repository that holds the public plugins in releases.jfrog.io.
+	PublicPluginsRepo = "jfrog-cli-plugins"
+	// The version directory which always points to the latest released version of a plugin.
+	LatestPluginVersion = "latest"
+)
+
+// PluginsRegistry represents an Artifactory repository from which plugins can be installed.
+// The repository is expected to follow the plugins layout:
+//
+//	<repo>/<plugin-name>/<version>/<os-arch>/<plugin-executable>
+type PluginsRegistry struct {
+	serverDetails *config.ServerDetails
+	repo          string
+}
+
+// NewPluginsRegistry returns the registry of the provided server ID and repository.
+// If no server ID is provided, the public plugins registry at releases.jfrog.io is returned.
+func NewPluginsRegistry(serverId, repo string) (*PluginsRegistry, error) {
+	if serverId == "" {
+		if repo != "" {
+			return nil, errorutils.CheckErrorf("a registry repository was provided without a registry server ID")
+		}
+		return &PluginsRegistry{serverDetails: &config.ServerDetails{ArtifactoryUrl: coreutils.JfrogReleasesUrl}, repo: PublicPluginsRepo}, nil
+	}
+	if repo == "" {
+		return nil, errorutils.CheckErrorf("a registry server ID was provided without a registry repository")
+	}
+	serverDetails, err := config.GetSpecificConfig(serverId, false, true)
+	if err != nil {
+		return nil, err
+	}
+	if serverDetails.ArtifactoryUrl == "" {
+		return nil, errorutils.CheckErrorf("the server ID '%s' has no Artifactory URL configured", serverId)
+	}
+	return &PluginsRegistry{serverDetails: serverDetails, repo: repo}, nil
+}
+
+func (pr *PluginsRegistry) GetServerDetails() *config.ServerDetails {
+	return pr.serverDetails
+}
+
+func (pr *PluginsRegistry) GetRepo() string {
+	return pr.repo
+}
+
+// GetPluginVersions returns the versions of the plugin published to the registry, sorted from newest to oldest.
+// The 'latest' directory is not included in the returned list.
+func (pr *PluginsRegistry) GetPluginVersions(pluginName string) ([]string, error) {
+	client, httpClientDetails, err := dependencies.CreateHttpClient(pr.serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	folderInfoUrl := pr.serverDetails.ArtifactoryUrl + path.Join("api", "storage", pr.repo, pluginName)
+	resp, body, _, err := client.SendGet(folderInfoUrl, true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errorutils.CheckErrorf("the plugin '%s' could not be found in the '%s' repository", pluginName, pr.repo)
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	folderInfo := &utils.FolderInfo{}
+	if err = json.Unmarshal(body, folderInfo); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var versions []string
+	for _, child := range folderInfo.Children {
+		childName := strings.TrimPrefix(child.Uri, "/")
+		if !child.Folder || childName == LatestPluginVersion {
+			continue
+		}
+		versions = append(versions, childName)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return version.NewVersion(versions[j]).Compare(versions[i]) > 0
+	})
+	return versions, nil
+}
+
+// GetPluginDownloadPath returns the path of the plugin's executable in the registry, relative to the Artifactory URL.
+// An empty version is resolved to the 'latest' version.
+func (pr *PluginsRegistry) GetPluginDownloadPath(pluginName, pluginVersion string) (string, error) {
+	if pluginVersion == "" {
+		pluginVersion = LatestPluginVersion
+	}
+	arc, err := coreutils.GetOSAndArc()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(pr.repo, pluginName, pluginVersion, arc, GetLocalPluginExecutableName(pluginName)), nil
+}
+
+// DownloadPlugin downloads the plugin's executable into targetDir.
+// The downloaded file is validated against the SHA1 and SHA256 checksums stored in Artifactory.
+// Returns the path of the downloaded executable.
+func (pr *PluginsRegistry) DownloadPlugin(pluginName, pluginVersion, targetDir string) (executablePath string, err error) {
+	downloadPath, err := pr.GetPluginDownloadPath(pluginName, pluginVersion)
+	if err != nil {
+		return
+	}
+	downloadUrl := pr.serverDetails.ArtifactoryUrl + downloadPath
+	log.Debug("Downloading plugin from", downloadUrl)
+	client, httpClientDetails, err := dependencies.CreateHttpClient(pr.serverDetails)
+	if err != nil {
+		return
+	}
+	remoteFileDetails, resp, err := client.GetRemoteFileDetails(downloadUrl, &httpClientDetails)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			err = errorutils.CheckErrorf("the plugin '%s' could not be found at '%s'", pluginName, downloadUrl)
+		}
+		return
+	}
+	if remoteFileDetails.Checksum.Sha1 == "" && remoteFileDetails.Checksum.Sha256 == "" {
+		return "", errorutils.CheckErrorf("no checksum was returned for '%s', the plugin cannot be validated", downloadUrl)
+	}
+	executableName := GetLocalPluginExecutableName(pluginName)
+	downloadFileDetails := &httpclient.DownloadFileDetails{
+		FileName:      executableName,
+		DownloadPath:  downloadUrl,
+		LocalPath:     targetDir,
+		LocalFileName: executableName,
+		ExpectedSha1:  remoteFileDetails.Checksum.Sha1,
+	}
+	resp, err = client.DownloadFile(downloadFileDetails, "", &httpClientDetails, false, false)
+	if err != nil {
+		return "", errors.Join(err, removeDownloadedPlugin(targetDir, executableName))
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return "", errors.Join(err, removeDownloadedPlugin(targetDir, executableName))
+	}
+	executablePath = filepath.Join(targetDir, executableName)
+	if err = validateSha256(executablePath, remoteFileDetails.Checksum.Sha256); err != nil {
+		return "", errors.Join(err, removeDownloadedPlugin(targetDir, executableName))
+	}
+	return
+}
+
+func validateSha256(filePath, expectedSha256 string) error {
+	if expectedSha256 == "" {
+		return nil
+	}
+	localFileDetails, err := fileutils.GetFileDetails(filePath, true)
+	if err != nil {
+		return err
+	}
+	if localFileDetails.Checksum.Sha256 != expectedSha256 {
+		return errorutils.CheckErrorf("checksum mismatch for '%s', expected SHA256: %s, actual: %s", filePath, expectedSha256, localFileDetails.Checksum.Sha256)
+	}
+	return nil
+}
+
+func removeDownloadedPlugin(targetDir, executableName string) error {
+	err := fileutils.RemovePath(filepath.Join(targetDir, executableName))
+	if err != nil {
+		return fmt.Errorf("failed to remove the downloaded plugin: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package plugins
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testRegistryRepo  = "cli-plugins"
+	pluginFileContent = "plugin-binary"
+)
+
+func TestNewPluginsRegistry(t *testing.T) {
+	registry, err := NewPluginsRegistry("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, coreutils.JfrogReleasesUrl, registry.GetServerDetails().ArtifactoryUrl)
+	assert.Equal(t, PublicPluginsRepo, registry.GetRepo())
+
+	_, err = NewPluginsRegistry("", testRegistryRepo)
+	assert.Error(t, err)
+	_, err = NewPluginsRegistry("my-server", "")
+	assert.Error(t, err)
+}
+
+func TestGetPluginVersions(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/storage/"+testRegistryRepo+"/"+pluginName, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"children":[{"uri":"/1.0.0","folder":true},{"uri":"/latest","folder":true},{"uri":"/1.10.0","folder":true},{"uri":"/1.2.0","folder":true},{"uri":"/readme.md","folder":false}]}`))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+
+	registry := createTestPluginsRegistry(testServer)
+	versions, err := registry.GetPluginVersions(pluginName)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.10.0", "1.2.0", "1.0.0"}, versions)
+}
+
+func TestGetPluginVersionsNotFound(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer testServer.Close()
+
+	_, err := createTestPluginsRegistry(testServer).GetPluginVersions(pluginName)
+	assert.ErrorContains(t, err, "could not be found")
+}
+
+func TestDownloadPlugin(t *testing.T) {
+	testCases := []struct {
+		name          string
+		sha256        string
+		expectedError bool
+	}{
+		{name: "validChecksums", sha256: sha256Hex(pluginFileContent)},
+		{name: "sha256Mismatch", sha256: sha256Hex("other-content"), expectedError: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testServer := createPluginDownloadServer(t, sha1Hex(pluginFileContent), testCase.sha256)
+			defer testServer.Close()
+
+			registry := createTestPluginsRegistry(testServer)
+			targetDir := t.TempDir()
+			executablePath, err := registry.DownloadPlugin(pluginName, "1.0.0", targetDir)
+			expectedPath := filepath.Join(targetDir, GetLocalPluginExecutableName(pluginName))
+			if testCase.expectedError {
+				assert.ErrorContains(t, err, "checksum mismatch")
+				assert.NoFileExists(t, expectedPath)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, expectedPath, executablePath)
+			content, err := os.ReadFile(executablePath)
+			assert.NoError(t, err)
+			assert.Equal(t, pluginFileContent, string(content))
+		})
+	}
+}
+
+func TestDownloadPluginWithoutChecksums(t *testing.T) {
+	testServer := createPluginDownloadServer(t, "", "")
+	defer testServer.Close()
+
+	_, err := createTestPluginsRegistry(testServer).DownloadPlugin(pluginName, "", t.TempDir())
+	assert.ErrorContains(t, err, "cannot be validated")
+}
+
+func createTestPluginsRegistry(testServer *httptest.Server) *PluginsRegistry {
+	return &PluginsRegistry{serverDetails: &config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}, repo: testRegistryRepo}
+}
+
+func createPluginDownloadServer(t *testing.T, expectedSha1, expectedSha256 string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Checksum-Sha1", expectedSha1)
+		w.Header().Set("X-Checksum-Sha256", expectedSha256)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, err := w.Write([]byte(pluginFileContent))
+			assert.NoError(t, err)
+		}
+	}))
+}
+
+func sha1Hex(content string) string {
+	//#nosec G401 -- sha1 is supported by Artifactory.
+	sum := sha1.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -59,7 +59,7 @@ func NewBarsMng() (mng *ProgressBarMng, shouldInit bool, err error) {
 		return
 	}
 	log.Info("Log path:", mng.logFile.Name())
-	log.SetLogger(log.NewLoggerWithFlags(corelog.GetCliLogLevel(), mng.logFile, golangLog.Ldate|golangLog.Ltime|golangLog.Lmsgprefix))
+	log.SetLogger(corelog.NewCliLogger(corelog.GetCliLogLevel(), mng.logFile, golangLog.Ldate|golangLog.Ltime|golangLog.Lmsgprefix))
 
 	mng.barsWg = new(sync.WaitGroup)
 	mng.container = mpb.New(
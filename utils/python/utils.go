@@ -46,6 +46,7 @@ func GetPypiRepoUrlWithCredentials(serverDetails *config.ServerDetails, reposito
 	if isCurationCmd {
 		rtUrl.Path += coreutils.CurationPassThroughApi
 	}
+	config.WarnIfApiKeyEmbedded(password, serverDetails.GetAccessToken(), "pip index URL")
 	rtUrl.Path += "api/pypi/" + repository + "/simple"
 	return rtUrl, username, password, err
 }
@@ -0,0 +1,104 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// Sign signs the content with the private key. RSA and ECDSA keys sign the SHA-256 digest of the content, and Ed25519 keys sign the content itself.
+func Sign(content []byte, privateKey crypto.Signer) ([]byte, error) {
+	var signature []byte
+	var err error
+	switch privateKey.(type) {
+	case ed25519.PrivateKey:
+		signature, err = privateKey.Sign(rand.Reader, content, crypto.Hash(0))
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		digest := sha256.Sum256(content)
+		signature, err = privateKey.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, errorutils.CheckErrorf("unsupported private key type %T", privateKey)
+	}
+	return signature, errorutils.CheckError(err)
+}
+
+// Verify returns true if the signature of the content was created by the private key of the provided public key.
+func Verify(content, signature []byte, publicKey crypto.PublicKey) bool {
+	digest := sha256.Sum256(content)
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, content, signature)
+	default:
+		return false
+	}
+}
+
+// LoadPrivateKey reads an unencrypted RSA, ECDSA or Ed25519 private key from a PEM file.
+func LoadPrivateKey(keyPath string) (crypto.Signer, error) {
+	block, err := readPemBlock(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		return key, errorutils.CheckError(err)
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		return key, errorutils.CheckError(err)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errorutils.CheckErrorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	default:
+		return nil, errorutils.CheckErrorf("unsupported PEM block type '%s' in %s. Encrypted private keys are not supported", block.Type, keyPath)
+	}
+}
+
+// LoadPublicKey reads a public key from a PEM file.
+func LoadPublicKey(keyPath string) (crypto.PublicKey, error) {
+	block, err := readPemBlock(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	return key, errorutils.CheckError(err)
+}
+
+// MarshalPublicKey returns the PEM encoding of the public key.
+func MarshalPublicKey(publicKey crypto.PublicKey) ([]byte, error) {
+	keyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: keyBytes}), nil
+}
+
+func readPemBlock(keyPath string) (*pem.Block, error) {
+	content, err := fileutils.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errorutils.CheckErrorf("failed to decode the PEM key at %s", keyPath)
+	}
+	return block, nil
+}
@@ -0,0 +1,89 @@
+package xray
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// GitlabCodeQualityIssue is an issue in the GitLab Code Quality report format, which GitLab displays in merge requests.
+type GitlabCodeQualityIssue struct {
+	Description string                    `json:"description"`
+	CheckName   string                    `json:"check_name"`
+	Fingerprint string                    `json:"fingerprint"`
+	Severity    string                    `json:"severity"`
+	Location    GitlabCodeQualityLocation `json:"location"`
+}
+
+type GitlabCodeQualityLocation struct {
+	Path  string                 `json:"path"`
+	Lines GitlabCodeQualityLines `json:"lines"`
+}
+
+type GitlabCodeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+// NewGitlabCodeQualityIssue creates an issue located at the line of the file. The fingerprint, which GitLab uses to track the issue
+// between pipelines, is computed from the identifying parts of the issue.
+func NewGitlabCodeQualityIssue(checkName, severity, description, path string, line int, identifyingParts ...string) GitlabCodeQualityIssue {
+	fingerprint := sha256.Sum256([]byte(strings.Join(identifyingParts, "|")))
+	return GitlabCodeQualityIssue{
+		Description: description,
+		CheckName:   checkName,
+		Fingerprint: hex.EncodeToString(fingerprint[:]),
+		Severity:    severity,
+		Location:    GitlabCodeQualityLocation{Path: path, Lines: GitlabCodeQualityLines{Begin: line}},
+	}
+}
+
+// FormatGithubAnnotation returns a GitHub Actions workflow command, which GitHub displays as an annotation on the line of the file.
+// The level is 'notice', 'warning' or 'error'.
+func FormatGithubAnnotation(level, path string, line int, title, message string) string {
+	return fmt.Sprintf("::%s file=%s,line=%d,title=%s::%s", level, escapeGithubProperty(path), line, escapeGithubProperty(title), escapeGithubData(message))
+}
+
+// Escapes the message of a GitHub workflow command.
+func escapeGithubData(data string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(data)
+}
+
+// Escapes a property of a GitHub workflow command.
+func escapeGithubProperty(property string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(property)
+}
+
+// GetRepositoryRelativePath returns the path relative to the root of the Git repository containing it, or to the working directory
+// if it's not in a Git repository. GitHub and GitLab expect the paths of the annotations to be relative to the root of the repository.
+func GetRepositoryRelativePath(path string) (string, error) {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	for dir := filepath.Dir(absolutePath); ; dir = filepath.Dir(dir) {
+		if _, err = os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return toSlashRel(dir, absolutePath)
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return toSlashRel(workingDir, absolutePath)
+}
+
+func toSlashRel(basePath, targetPath string) (string, error) {
+	relativePath, err := filepath.Rel(basePath, targetPath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.ToSlash(relativePath), nil
+}
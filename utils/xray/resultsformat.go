@@ -0,0 +1,234 @@
+package xray
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchemaUri = "https://json.schemastore.org/sarif-2.1.0.json"
+	xrayToolName   = "JFrog Xray"
+	xrayToolUri    = "https://jfrog.com/xray/"
+)
+
+// The package URL types of the Xray component types, for identifying the components in the CycloneDX format.
+var purlTypes = map[string]string{"npm": "npm", "gav": "maven", "pypi": "pypi", "go": "golang", "nuget": "nuget", "gem": "gem", "docker": "docker"}
+
+// Results holds the results of an audit or a scan, to print or upload in any of the supported output formats.
+type Results struct {
+	// The report of the command, marshaled as is in the JSON format.
+	Report any
+	// The vulnerable components in the report, from which the SARIF and CycloneDX reports are generated.
+	Vulnerabilities []Vulnerability
+	// The files in which each vulnerable component was found, by the component. Optional.
+	Locations map[string][]string
+	// The dependency graphs which were scanned, whose components are listed in the CycloneDX report. Optional.
+	Graphs []*xrayUtils.GraphNode
+}
+
+// MarshalResults marshals the results in the output format, and returns the extension of the file they should be stored in.
+// The SARIF format is generated from the vulnerabilities of the results, the CycloneDX format from their graphs and vulnerabilities,
+// and the other formats, which aren't meant for files, fall back to the JSON report.
+func MarshalResults(outputFormat format.OutputFormat, results *Results) (content []byte, extension string, err error) {
+	switch outputFormat {
+	case format.Sarif:
+		content, err = json.MarshalIndent(toSarif(results), "", "  ")
+		return content, ".sarif", errorutils.CheckError(err)
+	case format.CycloneDx:
+		var buffer bytes.Buffer
+		if err = cyclonedx.NewBOMEncoder(&buffer, cyclonedx.BOMFileFormatJSON).SetPretty(true).Encode(toCycloneDx(results)); err != nil {
+			return nil, "", errorutils.CheckError(err)
+		}
+		return buffer.Bytes(), ".cdx.json", nil
+	default:
+		content, err = json.MarshalIndent(results.Report, "", "  ")
+		return content, ".json", errorutils.CheckError(err)
+	}
+}
+
+type sarifReport struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+// Creates a SARIF report with a rule for each issue, and a result for each vulnerable component.
+func toSarif(results *Results) *sarifReport {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: xrayToolName, InformationUri: xrayToolUri, Rules: []sarifRule{}}}, Results: []sarifResult{}}
+	rules := map[string]bool{}
+	for _, vulnerability := range results.Vulnerabilities {
+		if !rules[vulnerability.Issue] {
+			rules[vulnerability.Issue] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{Id: vulnerability.Issue, ShortDescription: sarifMessage{Text: vulnerability.Summary}})
+		}
+		message := "[" + vulnerability.Severity + "] " + vulnerability.Component + ": " + vulnerability.Summary
+		if len(vulnerability.Cves) > 0 {
+			message += " (" + strings.Join(vulnerability.Cves, ", ") + ")"
+		}
+		if len(vulnerability.FixedVersions) > 0 {
+			message += ". Fixed versions: " + strings.Join(vulnerability.FixedVersions, ", ")
+		}
+		result := sarifResult{RuleId: vulnerability.Issue, Level: getSarifLevel(vulnerability.Severity), Message: sarifMessage{Text: message}}
+		for _, location := range results.Locations[vulnerability.Component] {
+			result.Locations = append(result.Locations, sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{Uri: location}}})
+		}
+		run.Results = append(run.Results, result)
+	}
+	return &sarifReport{Version: sarifVersion, Schema: sarifSchemaUri, Runs: []sarifRun{run}}
+}
+
+func getSarifLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// Creates a CycloneDX BOM of the scanned components, with their dependencies and vulnerabilities. The roots of the graphs are the
+// scanned projects, which are listed as applications, unless they are placeholders rather than components, such as the root of a local scan.
+func toCycloneDx(results *Results) *cyclonedx.BOM {
+	bom := cyclonedx.NewBOM()
+	components := []cyclonedx.Component{}
+	added := map[string]bool{}
+	addComponent := func(componentId string, componentType cyclonedx.ComponentType) {
+		if !added[componentId] {
+			added[componentId] = true
+			component := toCycloneDxComponent(componentId)
+			component.Type = componentType
+			components = append(components, component)
+		}
+	}
+	dependsOn := map[string][]string{}
+	linked := map[string]bool{}
+	var addDependencies func(node *xrayUtils.GraphNode)
+	addDependencies = func(node *xrayUtils.GraphNode) {
+		for _, child := range node.Nodes {
+			// Components which appear several times in the graph are expanded once.
+			expanded := added[child.Id]
+			addComponent(child.Id, cyclonedx.ComponentTypeLibrary)
+			if link := node.Id + "|" + child.Id; added[node.Id] && !linked[link] {
+				linked[link] = true
+				dependsOn[node.Id] = append(dependsOn[node.Id], child.Id)
+			}
+			if !expanded {
+				addDependencies(child)
+			}
+		}
+	}
+	for _, graph := range results.Graphs {
+		if strings.Contains(graph.Id, "://") {
+			addComponent(graph.Id, cyclonedx.ComponentTypeApplication)
+		}
+		addDependencies(graph)
+	}
+	vulnerabilities := []cyclonedx.Vulnerability{}
+	for _, vulnerability := range results.Vulnerabilities {
+		addComponent(vulnerability.Component, cyclonedx.ComponentTypeLibrary)
+		cdxVulnerability := cyclonedx.Vulnerability{
+			ID:          vulnerability.Issue,
+			Source:      &cyclonedx.Source{Name: xrayToolName, URL: xrayToolUri},
+			Ratings:     &[]cyclonedx.VulnerabilityRating{{Severity: cyclonedx.Severity(strings.ToLower(vulnerability.Severity))}},
+			Description: vulnerability.Summary,
+			Affects:     &[]cyclonedx.Affects{{Ref: vulnerability.Component}},
+		}
+		if len(vulnerability.FixedVersions) > 0 {
+			cdxVulnerability.Recommendation = "Upgrade to one of the fixed versions: " + strings.Join(vulnerability.FixedVersions, ", ")
+		}
+		if len(vulnerability.Cves) > 0 {
+			var references []cyclonedx.VulnerabilityReference
+			for _, cve := range vulnerability.Cves {
+				references = append(references, cyclonedx.VulnerabilityReference{ID: cve, Source: &cyclonedx.Source{Name: "NVD"}})
+			}
+			cdxVulnerability.References = &references
+		}
+		vulnerabilities = append(vulnerabilities, cdxVulnerability)
+	}
+	bom.Components = &components
+	bom.Vulnerabilities = &vulnerabilities
+	if len(dependsOn) > 0 {
+		var dependencies []cyclonedx.Dependency
+		for _, component := range components {
+			if children, exists := dependsOn[component.BOMRef]; exists {
+				dependencies = append(dependencies, cyclonedx.Dependency{Ref: component.BOMRef, Dependencies: &children})
+			}
+		}
+		bom.Dependencies = &dependencies
+	}
+	return bom
+}
+
+// Converts an Xray component ID, such as npm://lodash:4.17.20, to a CycloneDX component.
+func toCycloneDxComponent(componentId string) cyclonedx.Component {
+	component := cyclonedx.Component{BOMRef: componentId, Type: cyclonedx.ComponentTypeLibrary, Name: componentId}
+	componentType, nameAndVersion, found := strings.Cut(componentId, "://")
+	if !found {
+		return component
+	}
+	component.Name = nameAndVersion
+	if separator := strings.LastIndex(nameAndVersion, ":"); separator > 0 {
+		component.Name, component.Version = nameAndVersion[:separator], nameAndVersion[separator+1:]
+	}
+	if purlType, exists := purlTypes[componentType]; exists && component.Version != "" {
+		name := component.Name
+		if componentType == "gav" {
+			// The group and the artifact are the namespace and the name in the package URL.
+			name = strings.Replace(name, ":", "/", 1)
+		}
+		component.PackageURL = "pkg:" + purlType + "/" + strings.ReplaceAll(name, "@", "%40") + "@" + component.Version
+	}
+	return component
+}
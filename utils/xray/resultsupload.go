@@ -0,0 +1,151 @@
+package xray
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The property set on the uploaded reports, with the name of the command which generated them.
+	ResultsReportTypeProperty = "xray.report.type"
+	// The ID of the build-info module of the uploaded reports, if the build configuration has no module.
+	ResultsModuleId = "xray-results"
+)
+
+// ResultsUploader uploads a report generated by an audit or a scan to Artifactory, so that the security evidence is stored next to the binaries.
+// If a build name and number are configured, the report is stamped with the build properties and added to the build-info as an artifact.
+type ResultsUploader struct {
+	serverDetails      *config.ServerDetails
+	target             string
+	buildConfiguration *build.BuildConfiguration
+	outputFormat       format.OutputFormat
+}
+
+// NewResultsUploader creates an uploader of reports to the target, in the form of <repo>/<path>.
+// If the target ends with a slash, the reports are uploaded into it with their default file names.
+func NewResultsUploader(serverDetails *config.ServerDetails, target string) *ResultsUploader {
+	return &ResultsUploader{serverDetails: serverDetails, target: target, outputFormat: format.Json}
+}
+
+func (ru *ResultsUploader) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *ResultsUploader {
+	ru.buildConfiguration = buildConfiguration
+	return ru
+}
+
+// SetOutputFormat sets the format of the uploaded results, see MarshalResults.
+func (ru *ResultsUploader) SetOutputFormat(outputFormat format.OutputFormat) *ResultsUploader {
+	ru.outputFormat = outputFormat
+	return ru
+}
+
+// UploadResults marshals the results in the output format of the uploader and uploads them, and returns their path in Artifactory.
+// The default file name of the results is made of the report type and the extension of the format, e.g. audit-results.sarif.
+func (ru *ResultsUploader) UploadResults(reportType string, results *Results) (string, error) {
+	content, extension, err := MarshalResults(ru.outputFormat, results)
+	if err != nil {
+		return "", err
+	}
+	return ru.Upload(reportType, reportType+"-results"+extension, content)
+}
+
+// Upload uploads the content of the report, and returns its path in Artifactory.
+func (ru *ResultsUploader) Upload(reportType, defaultFileName string, content []byte) (targetPath string, err error) {
+	if ru.serverDetails == nil || ru.serverDetails.ArtifactoryUrl == "" {
+		return "", errorutils.CheckErrorf("uploading the results requires a server with an Artifactory URL")
+	}
+	targetPath = strings.TrimPrefix(ru.target, "/")
+	if targetPath == "" {
+		return "", errorutils.CheckErrorf("the target of the results must be in the form of <repo>/<path>")
+	}
+	if !strings.Contains(targetPath, "/") {
+		// A repository without a path.
+		targetPath += "/"
+	}
+	if strings.HasSuffix(targetPath, "/") {
+		targetPath += defaultFileName
+	}
+
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	reportPath := filepath.Join(tempDir, defaultFileName)
+	if err = os.WriteFile(reportPath, content, 0600); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+
+	toCollect, err := ru.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return "", err
+	}
+	buildProps := ""
+	if toCollect {
+		if buildProps, err = build.CreateBuildPropsFromConfiguration(ru.buildConfiguration); err != nil {
+			return "", err
+		}
+	}
+	servicesManager, err := utils.CreateServiceManager(ru.serverDetails, -1, 0, false)
+	if err != nil {
+		return "", err
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.CommonParams = &servicesutils.CommonParams{Pattern: reportPath, Target: targetPath, TargetProps: servicesutils.NewProperties()}
+	uploadParams.TargetProps.AddProperty(ResultsReportTypeProperty, reportType)
+	uploadParams.BuildProps = buildProps
+	uploadParams.Flat = true
+	log.Info(fmt.Sprintf("Uploading the %s results to %s...", reportType, targetPath))
+	summary, err := servicesManager.UploadFilesWithSummary(uploadParams)
+	if err != nil {
+		return "", err
+	}
+	defer ioutils.Close(summary, &err)
+	if summary.TotalFailed > 0 || summary.TotalSucceeded == 0 {
+		return "", errorutils.CheckErrorf("failed uploading the %s results to %s", reportType, targetPath)
+	}
+	if !toCollect {
+		return targetPath, nil
+	}
+	artifacts, err := servicesutils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+	if err != nil {
+		return "", err
+	}
+	return targetPath, ru.saveBuildArtifacts(artifacts)
+}
+
+func (ru *ResultsUploader) saveBuildArtifacts(artifacts []buildinfo.Artifact) error {
+	buildName, err := ru.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := ru.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	moduleId := ru.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = ResultsModuleId
+	}
+	return build.SavePartialBuildInfo(buildName, buildNumber, ru.buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+		partial.Artifacts = artifacts
+		partial.ModuleId = moduleId
+		partial.ModuleType = buildinfo.Generic
+	})
+}
@@ -0,0 +1,201 @@
+package xray
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testResults = &Results{
+	Report: map[string]string{"status": "vulnerable"},
+	Vulnerabilities: []Vulnerability{
+		{Severity: "High", Component: "npm://@scope/lib:1.0.0", FixedVersions: []string{"[1.0.1]"}, Issue: "XRAY-1", Cves: []string{"CVE-2024-1"}, Summary: "Prototype pollution"},
+		{Severity: "Low", Component: "gav://org.example:lib:2.0", Issue: "XRAY-2", Summary: "Information exposure"},
+	},
+	Locations: map[string][]string{"npm://@scope/lib:1.0.0": {"web/package.json"}},
+}
+
+// Serves the uploads of files, and returns the path and the content of each uploaded file, by the order of the uploads.
+func createUploadServer(t *testing.T) (serverDetails *config.ServerDetails, uploadPaths, uploadContents *[]string) {
+	uploadPaths, uploadContents = new([]string), new([]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		*uploadPaths = append(*uploadPaths, r.URL.Path)
+		*uploadContents = append(*uploadContents, string(content))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+	return &config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, uploadPaths, uploadContents
+}
+
+func TestResultsUploaderUploadResults(t *testing.T) {
+	serverDetails, uploadPaths, uploadContents := createUploadServer(t)
+	testCases := []struct {
+		outputFormat format.OutputFormat
+		target       string
+		expectedPath string
+	}{
+		{format.Json, "security/audit/", "/security/audit/audit-results.json"},
+		{format.Table, "security", "/security/audit-results.json"},
+		{format.Sarif, "security/audit/", "/security/audit/audit-results.sarif"},
+		{format.CycloneDx, "/security/audit/", "/security/audit/audit-results.cdx.json"},
+		{format.Sarif, "security/audit/report.sarif", "/security/audit/report.sarif"},
+	}
+	for i, testCase := range testCases {
+		t.Run(string(testCase.outputFormat), func(t *testing.T) {
+			targetPath, err := NewResultsUploader(serverDetails, testCase.target).SetOutputFormat(testCase.outputFormat).UploadResults("audit", testResults)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedPath, "/"+targetPath)
+			require.Len(t, *uploadPaths, i+1)
+			path, props, _ := strings.Cut((*uploadPaths)[i], ";")
+			assert.Equal(t, testCase.expectedPath, path)
+			assert.Contains(t, props, ResultsReportTypeProperty+"=audit")
+			expectedContent, _, err := MarshalResults(testCase.outputFormat, testResults)
+			require.NoError(t, err)
+			assert.Equal(t, string(expectedContent), (*uploadContents)[i])
+		})
+	}
+}
+
+func TestResultsUploaderBuildInfo(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	// The partial build-info files are stored in the temp dir of the CLI, which is shared with previous runs.
+	require.NoError(t, build.RemoveBuildDir("results", "3", ""))
+	defer func() {
+		assert.NoError(t, build.RemoveBuildDir("results", "3", ""))
+	}()
+	serverDetails, uploadPaths, _ := createUploadServer(t)
+	buildConfiguration := build.NewBuildConfiguration("results", "3", "security", "")
+	_, err := NewResultsUploader(serverDetails, "security/").SetBuildConfiguration(buildConfiguration).Upload("scan", "scan-results.json", []byte("{}"))
+	require.NoError(t, err)
+	require.Len(t, *uploadPaths, 1)
+	assert.Contains(t, (*uploadPaths)[0], "build.name=results")
+	assert.Contains(t, (*uploadPaths)[0], "build.number=3")
+
+	partials, err := build.ReadPartialBuildInfoFiles("results", "3", "")
+	require.NoError(t, err)
+	require.Len(t, partials, 1)
+	assert.Equal(t, "security", partials[0].ModuleId)
+	require.Len(t, partials[0].Artifacts, 1)
+	assert.Equal(t, "scan-results.json", partials[0].Artifacts[0].Name)
+}
+
+func TestResultsUploaderErrors(t *testing.T) {
+	_, err := NewResultsUploader(&config.ServerDetails{XrayUrl: "http://localhost/xray/"}, "security/").Upload("scan", "scan-results.json", nil)
+	assert.EqualError(t, err, "uploading the results requires a server with an Artifactory URL")
+	_, err = NewResultsUploader(&config.ServerDetails{ArtifactoryUrl: "http://localhost/artifactory/"}, "/").Upload("scan", "scan-results.json", nil)
+	assert.EqualError(t, err, "the target of the results must be in the form of <repo>/<path>")
+}
+
+func TestMarshalResultsSarif(t *testing.T) {
+	content, _, err := MarshalResults(format.Sarif, testResults)
+	require.NoError(t, err)
+	var report sarifReport
+	require.NoError(t, json.Unmarshal(content, &report))
+	assert.Equal(t, sarifVersion, report.Version)
+	require.Len(t, report.Runs, 1)
+	assert.Equal(t, []sarifRule{{Id: "XRAY-1", ShortDescription: sarifMessage{Text: "Prototype pollution"}}, {Id: "XRAY-2", ShortDescription: sarifMessage{Text: "Information exposure"}}},
+		report.Runs[0].Tool.Driver.Rules)
+	require.Len(t, report.Runs[0].Results, 2)
+	assert.Equal(t, sarifResult{
+		RuleId:    "XRAY-1",
+		Level:     "error",
+		Message:   sarifMessage{Text: "[High] npm://@scope/lib:1.0.0: Prototype pollution (CVE-2024-1). Fixed versions: [1.0.1]"},
+		Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{Uri: "web/package.json"}}}},
+	}, report.Runs[0].Results[0])
+	assert.Equal(t, "note", report.Runs[0].Results[1].Level)
+	assert.Empty(t, report.Runs[0].Results[1].Locations)
+}
+
+func TestMarshalResultsCycloneDx(t *testing.T) {
+	content, extension, err := MarshalResults(format.CycloneDx, testResults)
+	require.NoError(t, err)
+	assert.Equal(t, ".cdx.json", extension)
+	var bom struct {
+		BomFormat  string `json:"bomFormat"`
+		Components []struct {
+			BomRef     string `json:"bom-ref"`
+			Name       string `json:"name"`
+			Version    string `json:"version"`
+			PackageUrl string `json:"purl"`
+		} `json:"components"`
+		Vulnerabilities []struct {
+			Id      string `json:"id"`
+			Affects []struct {
+				Ref string `json:"ref"`
+			} `json:"affects"`
+		} `json:"vulnerabilities"`
+	}
+	require.NoError(t, json.Unmarshal(content, &bom))
+	assert.Equal(t, "CycloneDX", bom.BomFormat)
+	require.Len(t, bom.Components, 2)
+	assert.Equal(t, "@scope/lib", bom.Components[0].Name)
+	assert.Equal(t, "1.0.0", bom.Components[0].Version)
+	assert.Equal(t, "pkg:npm/%40scope/lib@1.0.0", bom.Components[0].PackageUrl)
+	assert.Equal(t, "pkg:maven/org.example/lib@2.0", bom.Components[1].PackageUrl)
+	require.Len(t, bom.Vulnerabilities, 2)
+	assert.Equal(t, "XRAY-1", bom.Vulnerabilities[0].Id)
+	require.Len(t, bom.Vulnerabilities[0].Affects, 1)
+	assert.Equal(t, "npm://@scope/lib:1.0.0", bom.Vulnerabilities[0].Affects[0].Ref)
+}
+
+func TestMarshalResultsCycloneDxGraphs(t *testing.T) {
+	project := &xrayUtils.GraphNode{Id: "npm://web:1.0.0"}
+	lib := &xrayUtils.GraphNode{Id: "npm://@scope/lib:1.0.0", Parent: project}
+	lib.Nodes = []*xrayUtils.GraphNode{{Id: "npm://dep:2.0.0", Parent: lib}}
+	project.Nodes = []*xrayUtils.GraphNode{lib, {Id: "npm://dep:2.0.0", Parent: project}}
+	// The root of a local scan is a placeholder rather than a component.
+	localScan := &xrayUtils.GraphNode{Id: "root"}
+	localScan.Nodes = []*xrayUtils.GraphNode{{Id: "gav://org.example:lib:2.0", Parent: localScan}}
+	results := *testResults
+	results.Graphs = []*xrayUtils.GraphNode{project, localScan}
+
+	content, _, err := MarshalResults(format.CycloneDx, &results)
+	require.NoError(t, err)
+	var bom struct {
+		Components []struct {
+			BomRef string `json:"bom-ref"`
+			Type   string `json:"type"`
+		} `json:"components"`
+		Dependencies []struct {
+			Ref       string   `json:"ref"`
+			DependsOn []string `json:"dependsOn"`
+		} `json:"dependencies"`
+		Vulnerabilities []struct {
+			Affects []struct {
+				Ref string `json:"ref"`
+			} `json:"affects"`
+		} `json:"vulnerabilities"`
+	}
+	require.NoError(t, json.Unmarshal(content, &bom))
+	// All the scanned components are listed, including the components which aren't vulnerable.
+	var components []string
+	for _, component := range bom.Components {
+		components = append(components, component.BomRef+" ("+component.Type+")")
+	}
+	assert.Equal(t, []string{"npm://web:1.0.0 (application)", "npm://@scope/lib:1.0.0 (library)", "npm://dep:2.0.0 (library)", "gav://org.example:lib:2.0 (library)"}, components)
+	require.Len(t, bom.Dependencies, 2)
+	assert.Equal(t, "npm://web:1.0.0", bom.Dependencies[0].Ref)
+	assert.Equal(t, []string{"npm://@scope/lib:1.0.0", "npm://dep:2.0.0"}, bom.Dependencies[0].DependsOn)
+	assert.Equal(t, "npm://@scope/lib:1.0.0", bom.Dependencies[1].Ref)
+	assert.Equal(t, []string{"npm://dep:2.0.0"}, bom.Dependencies[1].DependsOn)
+	require.Len(t, bom.Vulnerabilities, 2)
+	assert.Equal(t, "gav://org.example:lib:2.0", bom.Vulnerabilities[1].Affects[0].Ref)
+}
@@ -0,0 +1,59 @@
+package xray
+
+import (
+	"sort"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// Vulnerability holds the details of a vulnerable component found by a scan, which are common to all scan commands.
+type Vulnerability struct {
+	Severity      string   `json:"severity"`
+	Component     string   `json:"component"`
+	FixedVersions []string `json:"fixedVersions"`
+	Issue         string   `json:"issue"`
+	Cves          []string `json:"cves"`
+	Summary       string   `json:"summary"`
+}
+
+// ConvertVulnerabilities returns an entry for each vulnerable component in the scan response, sorted by the component and the issue.
+// The convert function creates the entry from the common details of the vulnerable component and its details in the response.
+func ConvertVulnerabilities[T any](scanResponse *services.ScanResponse, convert func(Vulnerability, services.Component) T) []T {
+	type vulnerableComponent struct {
+		vulnerability Vulnerability
+		component     services.Component
+	}
+	var vulnerableComponents []vulnerableComponent
+	for _, vulnerability := range scanResponse.Vulnerabilities {
+		var cves []string
+		for _, cve := range vulnerability.Cves {
+			if cve.Id != "" {
+				cves = append(cves, cve.Id)
+			}
+		}
+		for componentId, component := range vulnerability.Components {
+			vulnerableComponents = append(vulnerableComponents, vulnerableComponent{
+				vulnerability: Vulnerability{
+					Severity:      vulnerability.Severity,
+					Component:     componentId,
+					FixedVersions: component.FixedVersions,
+					Issue:         vulnerability.IssueId,
+					Cves:          cves,
+					Summary:       vulnerability.Summary,
+				},
+				component: component,
+			})
+		}
+	}
+	sort.SliceStable(vulnerableComponents, func(i, j int) bool {
+		if vulnerableComponents[i].vulnerability.Component != vulnerableComponents[j].vulnerability.Component {
+			return vulnerableComponents[i].vulnerability.Component < vulnerableComponents[j].vulnerability.Component
+		}
+		return vulnerableComponents[i].vulnerability.Issue < vulnerableComponents[j].vulnerability.Issue
+	})
+	converted := make([]T, 0, len(vulnerableComponents))
+	for _, vulnerableComponent := range vulnerableComponents {
+		converted = append(converted, convert(vulnerableComponent.vulnerability, vulnerableComponent.component))
+	}
+	return converted
+}
@@ -1,7 +1,9 @@
 package xray
 
 import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	clientconfig "github.com/jfrog/jfrog-client-go/config"
 	"github.com/jfrog/jfrog-client-go/xray"
 )
@@ -11,9 +13,13 @@ func CreateXrayServiceManager(serviceDetails *config.ServerDetails) (*xray.XrayS
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientconfig.NewConfigBuilder().
+	configBuilder := clientconfig.NewConfigBuilder().
 		SetServiceDetails(xrayDetails).
-		Build()
+		SetContext(coreutils.CommandContext())
+	if err = utils.ApplyCustomHttpClient(configBuilder, serviceDetails, -1, 0, 0); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
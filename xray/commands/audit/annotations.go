@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The GitHub annotation levels and GitLab Code Quality severities of the Xray severities.
+var (
+	githubAnnotationLevels = map[string]string{
+		"critical": "error",
+		"high":     "error",
+		"medium":   "warning",
+		"low":      "notice",
+	}
+	gitlabCodeQualitySeverities = map[string]string{
+		"critical": "blocker",
+		"high":     "critical",
+		"medium":   "major",
+		"low":      "minor",
+	}
+)
+
+// A vulnerability found in a subproject, located at the line of the manifest in which the direct dependency bringing it is declared.
+type manifestFinding struct {
+	vulnerability Vulnerability
+	// The direct dependency through which the vulnerable component is installed. Empty if the vulnerable component is the direct dependency.
+	through string
+	path    string
+	line    int
+}
+
+// Returns the findings of all the subprojects, located in their manifests. The dependencies of npm and Yarn subprojects are declared in
+// package.json, and the dependencies of the other subprojects in their descriptors. The paths are relative to the root of the Git repository
+// containing the project, as expected by GitHub and GitLab.
+func getManifestFindings(report *AuditReport, rootDir string) ([]manifestFinding, error) {
+	var findings []manifestFinding
+	for _, subproject := range report.Subprojects {
+		if len(subproject.Vulnerabilities) == 0 {
+			continue
+		}
+		manifest := subproject.Descriptor
+		if subproject.Technology == project.Npm.String() || subproject.Technology == project.Yarn.String() {
+			manifest = "package.json"
+		}
+		manifestPath := filepath.Join(rootDir, filepath.FromSlash(subproject.Path), manifest)
+		manifestLines, err := readManifestLines(manifestPath)
+		if err != nil {
+			return nil, err
+		}
+		relativePath, err := xrayutils.GetRepositoryRelativePath(manifestPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, vulnerability := range subproject.Vulnerabilities {
+			if len(vulnerability.DirectDependencies) == 0 {
+				// The dependency path is unknown, so the finding is located at the top of the manifest.
+				findings = append(findings, manifestFinding{vulnerability: vulnerability, path: relativePath, line: 1})
+				continue
+			}
+			for _, directDependency := range vulnerability.DirectDependencies {
+				finding := manifestFinding{vulnerability: vulnerability, path: relativePath, line: getDeclarationLine(manifestLines, directDependency)}
+				if directDependency != vulnerability.Component {
+					finding.through = directDependency
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings, nil
+}
+
+// Returns the lines of the manifest, or no lines if it doesn't exist.
+func readManifestLines(manifestPath string) ([]string, error) {
+	file, err := os.Open(manifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, errorutils.CheckError(scanner.Err())
+}
+
+// Returns the first line of the manifest in which the name of the dependency appears, or the first line if it doesn't appear.
+func getDeclarationLine(manifestLines []string, componentId string) int {
+	name := getComponentName(componentId)
+	if name == "" {
+		return 1
+	}
+	namePattern := regexp.MustCompile(`(^|[^\w.-])` + regexp.QuoteMeta(name) + `($|[^\w.-])`)
+	for i, line := range manifestLines {
+		if namePattern.MatchString(line) {
+			return i + 1
+		}
+	}
+	return 1
+}
+
+// Returns the name of the component, as declared in the manifests, from its Xray ID. For example, lodash for npm://lodash:4.17.20,
+// and the artifact ID for Maven components.
+func getComponentName(componentId string) string {
+	_, nameAndVersion, found := strings.Cut(componentId, "://")
+	if !found {
+		nameAndVersion = componentId
+	}
+	if separator := strings.LastIndex(nameAndVersion, ":"); separator > 0 {
+		nameAndVersion = nameAndVersion[:separator]
+	}
+	if separator := strings.LastIndex(nameAndVersion, ":"); separator >= 0 {
+		nameAndVersion = nameAndVersion[separator+1:]
+	}
+	return nameAndVersion
+}
+
+func (finding *manifestFinding) getTitle() string {
+	return fmt.Sprintf("%s (%s): %s", finding.vulnerability.Component, finding.vulnerability.Severity, finding.vulnerability.Summary)
+}
+
+func (finding *manifestFinding) getDescription() string {
+	description := finding.getTitle()
+	if finding.through != "" {
+		description += fmt.Sprintf(". Installed through the %s dependency", finding.through)
+	}
+	if len(finding.vulnerability.FixedVersions) > 0 {
+		description += ". Fixed versions: " + strings.Join(finding.vulnerability.FixedVersions, ", ")
+	}
+	if len(finding.vulnerability.Cves) > 0 {
+		description += ". " + strings.Join(finding.vulnerability.Cves, ", ")
+	}
+	return description
+}
+
+// Returns the findings as GitHub Actions workflow commands, which GitHub displays inline on the lines of the manifests.
+func toGithubAnnotations(findings []manifestFinding) string {
+	annotations := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		level, exists := githubAnnotationLevels[strings.ToLower(finding.vulnerability.Severity)]
+		if !exists {
+			level = "notice"
+		}
+		annotations = append(annotations, xrayutils.FormatGithubAnnotation(level, finding.path, finding.line, finding.getTitle(), finding.getDescription()))
+	}
+	return strings.Join(annotations, "\n")
+}
+
+// Returns the findings as a GitLab Code Quality report.
+func toGitlabCodeQuality(findings []manifestFinding) []xrayutils.GitlabCodeQualityIssue {
+	issues := []xrayutils.GitlabCodeQualityIssue{}
+	for _, finding := range findings {
+		severity, exists := gitlabCodeQualitySeverities[strings.ToLower(finding.vulnerability.Severity)]
+		if !exists {
+			severity = "info"
+		}
+		issues = append(issues, xrayutils.NewGitlabCodeQualityIssue(finding.vulnerability.Issue, severity, finding.getDescription(), finding.path, finding.line,
+			finding.vulnerability.Issue, finding.vulnerability.Component, finding.through, finding.path))
+	}
+	return issues
+}
@@ -0,0 +1,420 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+// AuditReport is the unified report of all the audited subprojects.
+type AuditReport struct {
+	Subprojects []*SubprojectReport `json:"subprojects"`
+}
+
+// SubprojectReport is the result of auditing a subproject.
+type SubprojectReport struct {
+	Path       string `json:"path"`
+	Technology string `json:"technology"`
+	Descriptor string `json:"descriptor"`
+	// The server and repository of the resolver in the project configuration of the subproject, if it has one.
+	ServerId        string          `json:"serverId,omitempty"`
+	ResolverRepo    string          `json:"resolverRepo,omitempty"`
+	Dependencies    int             `json:"dependencies"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+	// True if the results were taken from the cache of a previous audit, rather than scanned by Xray.
+	Cached bool `json:"cached,omitempty"`
+	// The error which failed the audit of the subproject.
+	Error string `json:"error,omitempty"`
+}
+
+// Vulnerability is a vulnerable component found in the dependencies of a subproject.
+type Vulnerability struct {
+	xrayutils.Vulnerability
+	// The direct dependencies of the subproject through which the component is installed.
+	DirectDependencies []string `json:"directDependencies"`
+}
+
+type vulnerabilityTableRow struct {
+	Severity           string `col-name:"Severity"`
+	Component          string `col-name:"Component"`
+	FixedVersions      string `col-name:"Fixed Versions"`
+	Issue              string `col-name:"Issue"`
+	Cves               string `col-name:"CVEs"`
+	DirectDependencies string `col-name:"Direct Dependencies"`
+}
+
+// CountVulnerabilities returns the number of vulnerabilities in all the subprojects.
+func (report *AuditReport) CountVulnerabilities() int {
+	count := 0
+	for _, subproject := range report.Subprojects {
+		count += len(subproject.Vulnerabilities)
+	}
+	return count
+}
+
+// AuditCommand audits all the projects of the supported technologies under a root directory, such as the npm applications, Go services and
+// Python libraries of a monorepo, with Xray. The dependencies of npm and Yarn subprojects are resolved by their package manager, unless
+// the command is lockfile-only, and the dependencies of the other subprojects are read from their descriptors. If the subproject,
+// or one of its parent directories up to the root directory, has a project configuration in its .jfrog directory, the subproject is audited
+// with the server of the configured resolver. Otherwise, the server details of the command are used.
+type AuditCommand struct {
+	serverDetails *config.ServerDetails
+	workingDir    string
+	technologies  []project.ProjectType
+	outputFormat  format.OutputFormat
+	// If true, the development dependencies of npm and Yarn subprojects are omitted.
+	production bool
+	// If true, the dependencies of npm and Yarn subprojects are read from their lockfiles, without running the package manager.
+	lockfileOnly bool
+	// If true, the command fails if vulnerabilities are found.
+	failOnVulnerabilities bool
+	// The TTL of the cached scan results. Zero means the cache is disabled.
+	cacheTtl time.Duration
+	// The target in Artifactory to upload the report to. If empty, the report isn't uploaded.
+	resultsTarget      string
+	buildConfiguration *build.BuildConfiguration
+	report             *AuditReport
+	// The dependency graphs of the audited subprojects, which are listed in the CycloneDX report.
+	graphs []*xrayUtils.GraphNode
+}
+
+func NewAuditCommand() *AuditCommand {
+	return &AuditCommand{outputFormat: format.Table}
+}
+
+func (ac *AuditCommand) SetServerDetails(serverDetails *config.ServerDetails) *AuditCommand {
+	ac.serverDetails = serverDetails
+	return ac
+}
+
+// SetWorkingDirectory sets the root directory under which the subprojects are searched. The default is the current directory.
+func (ac *AuditCommand) SetWorkingDirectory(workingDir string) *AuditCommand {
+	ac.workingDir = workingDir
+	return ac
+}
+
+// SetTechnologies limits the audit to the subprojects of the provided technologies. By default, all the supported technologies are audited.
+func (ac *AuditCommand) SetTechnologies(technologies ...project.ProjectType) *AuditCommand {
+	ac.technologies = technologies
+	return ac
+}
+
+// SetOutputFormat sets the output format of the report - 'table', 'json', 'sarif' or 'cyclonedx', 'github-annotations' for annotations
+// of pull requests in GitHub Actions, or 'gitlab-codequality' for a GitLab Code Quality report.
+func (ac *AuditCommand) SetOutputFormat(outputFormat format.OutputFormat) *AuditCommand {
+	ac.outputFormat = outputFormat
+	return ac
+}
+
+func (ac *AuditCommand) SetProduction(production bool) *AuditCommand {
+	ac.production = production
+	return ac
+}
+
+// SetLockfileOnly makes the command read the dependencies of npm and Yarn subprojects from package-lock.json, npm-shrinkwrap.json or yarn.lock,
+// instead of resolving them with the package manager. This doesn't require the package manager or node_modules to be installed.
+func (ac *AuditCommand) SetLockfileOnly(lockfileOnly bool) *AuditCommand {
+	ac.lockfileOnly = lockfileOnly
+	return ac
+}
+
+func (ac *AuditCommand) SetFailOnVulnerabilities(failOnVulnerabilities bool) *AuditCommand {
+	ac.failOnVulnerabilities = failOnVulnerabilities
+	return ac
+}
+
+// SetCacheTtl enables caching the Xray scan results of the subprojects, so that subprojects whose dependencies didn't change since
+// a previous audit aren't scanned again until the results expire. If not set, the TTL is read from JFROG_CLI_AUDIT_CACHE_TTL.
+func (ac *AuditCommand) SetCacheTtl(cacheTtl time.Duration) *AuditCommand {
+	ac.cacheTtl = cacheTtl
+	return ac
+}
+
+// SetUploadResults sets the target in Artifactory, in the form of <repo>/<path>, to upload the report to, in the output format.
+// If the target ends with a slash, the report is uploaded into it as audit-results with the extension of the format, e.g. audit-results.sarif.
+func (ac *AuditCommand) SetUploadResults(resultsTarget string) *AuditCommand {
+	ac.resultsTarget = resultsTarget
+	return ac
+}
+
+// SetBuildConfiguration sets the build, whose properties are set on the uploaded report, and to whose build-info the report is added.
+func (ac *AuditCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *AuditCommand {
+	ac.buildConfiguration = buildConfiguration
+	return ac
+}
+
+func (ac *AuditCommand) Report() *AuditReport {
+	return ac.report
+}
+
+func (ac *AuditCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ac.serverDetails, nil
+}
+
+func (ac *AuditCommand) CommandName() string {
+	return "xr_audit"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand. The command is read-only unless it uploads its results.
+func (ac *AuditCommand) IsReadOnly() bool {
+	return ac.resultsTarget == ""
+}
+
+func (ac *AuditCommand) Run() (err error) {
+	switch ac.outputFormat {
+	case format.Table, format.Json, format.Sarif, format.CycloneDx, format.GithubAnnotations, format.GitlabCodeQuality:
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s, %s, %s, %s, %s", ac.outputFormat,
+			format.Table, format.Json, format.Sarif, format.CycloneDx, format.GithubAnnotations, format.GitlabCodeQuality)
+	}
+	for _, technology := range ac.technologies {
+		if !isTechnologyIncluded(technology, SupportedTechnologies) {
+			return errorutils.CheckErrorf("auditing %s projects isn't supported", technology)
+		}
+	}
+	cacheTtl := ac.cacheTtl
+	if cacheTtl == 0 {
+		if cacheTtl, err = GetAuditCacheTtl(); err != nil {
+			return err
+		}
+	}
+	rootDir := ac.workingDir
+	if rootDir == "" {
+		if rootDir, err = os.Getwd(); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	subprojects, err := DetectSubprojects(rootDir, ac.technologies...)
+	if err != nil {
+		return err
+	}
+	if len(subprojects) == 0 {
+		return errorutils.CheckErrorf("no projects of the supported technologies were found in %s", rootDir)
+	}
+	log.Info(fmt.Sprintf("Found %d projects to audit in %s.", len(subprojects), rootDir))
+	ac.report, ac.graphs = &AuditReport{}, nil
+	var failedSubprojects []string
+	for _, subproject := range subprojects {
+		subprojectReport := ac.auditSubproject(rootDir, subproject, cacheTtl)
+		if subprojectReport.Error != "" {
+			log.Error(fmt.Sprintf("Failed auditing the %s project in %s: %s", subprojectReport.Technology, subprojectReport.Path, subprojectReport.Error))
+			failedSubprojects = append(failedSubprojects, subprojectReport.Path+" ("+subprojectReport.Technology+")")
+		}
+		ac.report.Subprojects = append(ac.report.Subprojects, subprojectReport)
+	}
+	if err = ac.printReport(rootDir); err != nil {
+		return err
+	}
+	if ac.resultsTarget != "" {
+		uploader := xrayutils.NewResultsUploader(ac.serverDetails, ac.resultsTarget).SetBuildConfiguration(ac.buildConfiguration).SetOutputFormat(ac.outputFormat)
+		if _, err = uploader.UploadResults("audit", ac.getResults()); err != nil {
+			return err
+		}
+	}
+	if len(failedSubprojects) > 0 {
+		err = errorutils.CheckErrorf("failed auditing %d projects:\n%s", len(failedSubprojects), strings.Join(failedSubprojects, "\n"))
+	}
+	if count := ac.report.CountVulnerabilities(); ac.failOnVulnerabilities && count > 0 {
+		err = errors.Join(err, errorutils.CheckErrorf("found %d vulnerable components", count))
+	}
+	return err
+}
+
+// Audits a subproject. A failure is recorded in the report of the subproject, so that the other subprojects are still audited.
+func (ac *AuditCommand) auditSubproject(rootDir string, subproject *Subproject, cacheTtl time.Duration) *SubprojectReport {
+	report := &SubprojectReport{Path: subproject.Path, Technology: subproject.Technology.String(), Descriptor: subproject.Descriptor, Vulnerabilities: []Vulnerability{}}
+	subprojectDir := filepath.Join(rootDir, filepath.FromSlash(subproject.Path))
+	serverDetails, err := ac.getSubprojectServerDetails(rootDir, subprojectDir, subproject, report)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	graph, dependencies, err := buildDependencyGraph(subprojectDir, subproject, ac.production, ac.lockfileOnly)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Dependencies = dependencies
+	ac.graphs = append(ac.graphs, graph)
+	if dependencies == 0 {
+		return report
+	}
+	var cacheKey string
+	if cacheTtl > 0 {
+		cacheKey = getCacheKey(serverDetails, graph)
+		if scanResponse, ok := readCachedScanResults(cacheKey, cacheTtl); ok {
+			log.Info(fmt.Sprintf("The dependencies of the %s project in %s didn't change since a previous audit. Using the cached Xray results.", report.Technology, report.Path))
+			report.Vulnerabilities = getVulnerabilities(scanResponse)
+			report.Cached = true
+			return report
+		}
+	}
+	log.Info(fmt.Sprintf("Scanning %d dependencies of the %s project in %s with Xray...", dependencies, report.Technology, report.Path))
+	scanResponse, err := scanGraph(serverDetails, graph)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Vulnerabilities = getVulnerabilities(scanResponse)
+	if cacheKey != "" {
+		if err = writeCachedScanResults(cacheKey, cacheTtl, scanResponse); err != nil {
+			// Failing to cache the results should not fail the audit.
+			log.Debug("Failed caching the Xray scan results:", err.Error())
+		}
+	}
+	return report
+}
+
+// Returns the server details of the resolver in the project configuration of the subproject, if it has one. Otherwise, the server details of the command.
+func (ac *AuditCommand) getSubprojectServerDetails(rootDir, subprojectDir string, subproject *Subproject, report *SubprojectReport) (*config.ServerDetails, error) {
+	confFilePath, err := getSubprojectConfFilePath(rootDir, subprojectDir, subproject.Technology)
+	if err != nil || confFilePath == "" {
+		return ac.serverDetails, err
+	}
+	log.Debug(fmt.Sprintf("Using the project configuration %s for the %s project in %s", confFilePath, report.Technology, report.Path))
+	repoConfig, err := project.ReadResolutionOnlyConfiguration(confFilePath)
+	if err != nil {
+		return nil, err
+	}
+	serverDetails, err := repoConfig.ServerDetails()
+	if err != nil {
+		return nil, err
+	}
+	report.ServerId = serverDetails.ServerId
+	report.ResolverRepo = repoConfig.TargetRepo()
+	if serverDetails.XrayUrl == "" {
+		return nil, errorutils.CheckErrorf("the server '%s' configured in %s has no Xray URL", serverDetails.ServerId, confFilePath)
+	}
+	return serverDetails, nil
+}
+
+func scanGraph(serverDetails *config.ServerDetails, graph *xrayUtils.GraphNode) (*services.ScanResponse, error) {
+	xrayManager, err := xrayutils.CreateXrayServiceManager(serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	scanId, err := xrayManager.ScanGraph(services.XrayGraphScanParams{
+		DependenciesGraph:      graph,
+		ScanType:               services.Dependency,
+		ProjectKey:             config.GetProjectKey("", serverDetails),
+		IncludeVulnerabilities: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return xrayManager.GetScanGraphResults(scanId, true, false, false)
+}
+
+func getVulnerabilities(scanResponse *services.ScanResponse) []Vulnerability {
+	return xrayutils.ConvertVulnerabilities(scanResponse, func(vulnerability xrayutils.Vulnerability, component services.Component) Vulnerability {
+		return Vulnerability{Vulnerability: vulnerability, DirectDependencies: getDirectDependencies(component)}
+	})
+}
+
+// Returns the sorted direct dependencies in the impact paths of the component. The first node in each impact path is the subproject itself.
+func getDirectDependencies(component services.Component) []string {
+	directDependencies := map[string]bool{}
+	for _, impactPath := range component.ImpactPaths {
+		if len(impactPath) > 1 {
+			directDependencies[impactPath[1].ComponentId] = true
+		}
+	}
+	sorted := make([]string, 0, len(directDependencies))
+	for directDependency := range directDependencies {
+		sorted = append(sorted, directDependency)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func (ac *AuditCommand) printReport(rootDir string) error {
+	switch ac.outputFormat {
+	case format.GithubAnnotations, format.GitlabCodeQuality:
+		findings, err := getManifestFindings(ac.report, rootDir)
+		if err != nil {
+			return err
+		}
+		if ac.outputFormat == format.GithubAnnotations {
+			if annotations := toGithubAnnotations(findings); annotations != "" {
+				log.Output(annotations)
+			}
+			return nil
+		}
+		content, err := json.Marshal(toGitlabCodeQuality(findings))
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	case format.Json, format.Sarif, format.CycloneDx:
+		content, _, err := xrayutils.MarshalResults(ac.outputFormat, ac.getResults())
+		if err != nil {
+			return err
+		}
+		log.Output(string(content))
+		return nil
+	}
+	for _, subproject := range ac.report.Subprojects {
+		title := fmt.Sprintf("%s (%s)", subproject.Path, subproject.Technology)
+		if subproject.ResolverRepo != "" {
+			title += fmt.Sprintf(" - resolved from '%s' on '%s'", subproject.ResolverRepo, subproject.ServerId)
+		}
+		switch {
+		case subproject.Error != "":
+			log.Output(title + ": audit failed")
+		case len(subproject.Vulnerabilities) == 0:
+			log.Output(fmt.Sprintf("%s: no vulnerable components were found in %d dependencies", title, subproject.Dependencies))
+		default:
+			var rows []vulnerabilityTableRow
+			for _, vulnerability := range subproject.Vulnerabilities {
+				rows = append(rows, vulnerabilityTableRow{
+					Severity:           vulnerability.Severity,
+					Component:          vulnerability.Component,
+					FixedVersions:      strings.Join(vulnerability.FixedVersions, ", "),
+					Issue:              vulnerability.Issue,
+					Cves:               strings.Join(vulnerability.Cves, ", "),
+					DirectDependencies: strings.Join(vulnerability.DirectDependencies, "\n"),
+				})
+			}
+			if err := coreutils.PrintTable(rows, title, "", false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the results of the audit. The vulnerable components are located in the descriptors of the subprojects which depend on them.
+func (ac *AuditCommand) getResults() *xrayutils.Results {
+	results := &xrayutils.Results{Report: ac.report, Locations: map[string][]string{}, Graphs: ac.graphs}
+	located := map[string]bool{}
+	for _, subproject := range ac.report.Subprojects {
+		descriptor := path.Join(subproject.Path, subproject.Descriptor)
+		for _, vulnerability := range subproject.Vulnerabilities {
+			results.Vulnerabilities = append(results.Vulnerabilities, vulnerability.Vulnerability)
+			if key := vulnerability.Component + "|" + descriptor; !located[key] {
+				located[key] = true
+				results.Locations[vulnerability.Component] = append(results.Locations[vulnerability.Component], descriptor)
+			}
+		}
+	}
+	return results
+}
@@ -0,0 +1,289 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPackageJson = `{"name":"web","version":"1.0.0","dependencies":{"lodash":"^4.17.0"}}`
+	testPackageLock = `{"name":"web","version":"1.0.0","lockfileVersion":3,"packages":{
+		"":{"name":"web","version":"1.0.0","dependencies":{"lodash":"^4.17.0"}},
+		"node_modules/lodash":{"version":"4.17.20"}}}`
+	testGoMod = `module example.com/api
+
+go 1.20
+
+require (
+	github.com/gin-gonic/gin v1.9.0
+	golang.org/x/text v0.3.7 // indirect
+	example.com/shared v0.0.0
+)
+
+replace example.com/shared => ../shared
+`
+	testRequirements = `# This file is autogenerated
+requests[security]==2.25.0 \
+    --hash=sha256:aaaa
+urllib3==1.26.4 ; python_version >= "3.6"
+flask>=2.0
+`
+	testPipConfig = `version: 1
+type: pip
+resolver:
+  repo: pypi-remote
+  serverId: python-server
+`
+)
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+// Creates a monorepo with an npm application, a Go service and a Python library, which has a project configuration.
+func createMonorepo(t *testing.T) string {
+	rootDir := t.TempDir()
+	writeFile(t, filepath.Join(rootDir, "web", "package.json"), testPackageJson)
+	writeFile(t, filepath.Join(rootDir, "web", "package-lock.json"), testPackageLock)
+	// Installed packages and hidden directories aren't searched.
+	writeFile(t, filepath.Join(rootDir, "web", "node_modules", "lodash", "package.json"), testPackageJson)
+	writeFile(t, filepath.Join(rootDir, "web", "node_modules", "lodash", "package-lock.json"), testPackageLock)
+	writeFile(t, filepath.Join(rootDir, ".git", "go.mod"), testGoMod)
+	writeFile(t, filepath.Join(rootDir, "services", "api", "go.mod"), testGoMod)
+	writeFile(t, filepath.Join(rootDir, "libs", "py", "src", "requirements.txt"), testRequirements)
+	writeFile(t, filepath.Join(rootDir, "libs", ".jfrog", "projects", "pip.yaml"), testPipConfig)
+	return rootDir
+}
+
+func TestDetectSubprojects(t *testing.T) {
+	rootDir := createMonorepo(t)
+	subprojects, err := DetectSubprojects(rootDir)
+	require.NoError(t, err)
+	assert.Equal(t, []*Subproject{
+		{Path: "libs/py/src", Technology: project.Pip, Descriptor: "requirements.txt"},
+		{Path: "services/api", Technology: project.Go, Descriptor: "go.mod"},
+		{Path: "web", Technology: project.Npm, Descriptor: "package-lock.json"},
+	}, subprojects)
+
+	subprojects, err = DetectSubprojects(rootDir, project.Go)
+	require.NoError(t, err)
+	assert.Equal(t, []*Subproject{{Path: "services/api", Technology: project.Go, Descriptor: "go.mod"}}, subprojects)
+
+	// The configuration of the closest parent directory up to the root directory is used.
+	confFilePath, err := getSubprojectConfFilePath(rootDir, filepath.Join(rootDir, "libs", "py", "src"), project.Pip)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(rootDir, "libs", ".jfrog", "projects", "pip.yaml"), confFilePath)
+	confFilePath, err = getSubprojectConfFilePath(rootDir, filepath.Join(rootDir, "services", "api"), project.Go)
+	assert.NoError(t, err)
+	assert.Empty(t, confFilePath)
+}
+
+func TestBuildDependencyGraphs(t *testing.T) {
+	rootDir := createMonorepo(t)
+	graph, count, err := buildGoDependencyGraph(filepath.Join(rootDir, "services", "api", "go.mod"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "go://example.com/api", graph.Id)
+	assert.Equal(t, []string{"go://github.com/gin-gonic/gin:v1.9.0", "go://golang.org/x/text:v0.3.7"}, getChildIds(graph))
+
+	graph, count, err = buildPipDependencyGraph(filepath.Join(rootDir, "libs", "py", "src", "requirements.txt"), filepath.Join(rootDir, "libs", "py"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "pypi://py", graph.Id)
+	assert.Equal(t, []string{"pypi://requests:2.25.0", "pypi://urllib3:1.26.4"}, getChildIds(graph))
+}
+
+func getChildIds(graph *xrayUtils.GraphNode) []string {
+	var ids []string
+	for _, child := range graph.Nodes {
+		ids = append(ids, child.Id)
+	}
+	return ids
+}
+
+// Creates an Xray server which returns a vulnerability of the first dependency in each scanned graph.
+func createXrayServer(t *testing.T, scannedGraphs *[]string) *httptest.Server {
+	var lastGraph *xrayUtils.GraphNode
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/xray/api/v1/scan/graph":
+			lastGraph = &xrayUtils.GraphNode{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(lastGraph))
+			*scannedGraphs = append(*scannedGraphs, lastGraph.Id)
+			_, err := w.Write([]byte(`{"scan_id":"scan-1"}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodGet && r.URL.Path == "/xray/api/v1/scan/graph/scan-1":
+			dependency := lastGraph.Nodes[0].Id
+			_, err := w.Write([]byte(`{"scan_id":"scan-1","vulnerabilities":[{"issue_id":"XRAY-1","summary":"Vulnerable","severity":"High",
+				"cves":[{"cve":"CVE-2023-1"}],"components":{"` + dependency + `":{"fixed_versions":["[9.9.9]"],
+				"impact_paths":[[{"component_id":"` + lastGraph.Id + `"},{"component_id":"` + dependency + `"}]]}}}]}`))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestAudit(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.HomeDir)
+	var defaultServerGraphs, pythonServerGraphs []string
+	defaultServer := createXrayServer(t, &defaultServerGraphs)
+	defer defaultServer.Close()
+	pythonServer := createXrayServer(t, &pythonServerGraphs)
+	defer pythonServer.Close()
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{{ServerId: "python-server", Url: pythonServer.URL + "/", XrayUrl: pythonServer.URL + "/xray/"}}))
+
+	rootDir := createMonorepo(t)
+	auditCmd := NewAuditCommand().SetServerDetails(&config.ServerDetails{XrayUrl: defaultServer.URL + "/xray/"}).
+		SetWorkingDirectory(rootDir).SetLockfileOnly(true).SetOutputFormat(format.Json).SetFailOnVulnerabilities(true)
+	assert.EqualError(t, auditCmd.Run(), "found 3 vulnerable components")
+	// The Python library is audited with the server of its resolver.
+	assert.Equal(t, []string{"go://example.com/api", "npm://web:1.0.0"}, defaultServerGraphs)
+	assert.Equal(t, []string{"pypi://src"}, pythonServerGraphs)
+
+	report := auditCmd.Report()
+	require.Len(t, report.Subprojects, 3)
+	assert.Equal(t, &SubprojectReport{Path: "libs/py/src", Technology: "pip", Descriptor: "requirements.txt", ServerId: "python-server", ResolverRepo: "pypi-remote",
+		Dependencies: 2, Vulnerabilities: []Vulnerability{{Vulnerability: xrayutils.Vulnerability{Severity: "High", Component: "pypi://requests:2.25.0", FixedVersions: []string{"[9.9.9]"},
+			Issue: "XRAY-1", Cves: []string{"CVE-2023-1"}, Summary: "Vulnerable"}, DirectDependencies: []string{"pypi://requests:2.25.0"}}}}, report.Subprojects[0])
+	assert.Equal(t, "npm://lodash:4.17.20", report.Subprojects[2].Vulnerabilities[0].Component)
+	// The scanned graphs of all the subprojects are listed in the CycloneDX report.
+	var graphs []string
+	for _, graph := range auditCmd.getResults().Graphs {
+		graphs = append(graphs, graph.Id)
+	}
+	assert.Equal(t, []string{"pypi://src", "go://example.com/api", "npm://web:1.0.0"}, graphs)
+
+	// A subproject which fails is reported, and the other subprojects are still audited.
+	writeFile(t, filepath.Join(rootDir, "services", "api", "go.mod"), "go 1.20\n")
+	defaultServerGraphs = nil
+	err := auditCmd.SetOutputFormat(format.Table).SetFailOnVulnerabilities(false).Run()
+	assert.ErrorContains(t, err, "failed auditing 1 projects:\nservices/api (go)")
+	assert.Contains(t, auditCmd.Report().Subprojects[1].Error, "no module directive was found")
+	assert.Equal(t, []string{"npm://web:1.0.0"}, defaultServerGraphs)
+
+	assert.ErrorContains(t, auditCmd.SetOutputFormat(format.GitlabCodeQuality).Run(), "failed auditing 1 projects")
+
+	assert.ErrorContains(t, NewAuditCommand().SetWorkingDirectory(t.TempDir()).Run(), "no projects of the supported technologies were found")
+	assert.ErrorContains(t, NewAuditCommand().SetTechnologies(project.Maven).Run(), "auditing maven projects isn't supported")
+	assert.ErrorContains(t, NewAuditCommand().SetOutputFormat("xml").Run(), "unsupported output format 'xml'")
+}
+
+func TestAuditLockfileOnly(t *testing.T) {
+	var scannedGraphs []string
+	server := createXrayServer(t, &scannedGraphs)
+	defer server.Close()
+	rootDir := createMonorepo(t)
+	// Without npm, the npm subproject can be audited only from its lockfile.
+	t.Setenv("PATH", "")
+	auditCmd := NewAuditCommand().SetServerDetails(&config.ServerDetails{XrayUrl: server.URL + "/xray/"}).
+		SetWorkingDirectory(rootDir).SetTechnologies(project.Npm).SetOutputFormat(format.Json)
+	assert.ErrorContains(t, auditCmd.Run(), "failed auditing 1 projects:\nweb (npm)")
+	assert.Empty(t, scannedGraphs)
+
+	assert.NoError(t, auditCmd.SetLockfileOnly(true).Run())
+	assert.Equal(t, []string{"npm://web:1.0.0"}, scannedGraphs)
+}
+
+func TestAuditCache(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.HomeDir)
+	var scannedGraphs []string
+	server := createXrayServer(t, &scannedGraphs)
+	defer server.Close()
+	rootDir := createMonorepo(t)
+	auditCmd := NewAuditCommand().SetServerDetails(&config.ServerDetails{XrayUrl: server.URL + "/xray/"}).
+		SetWorkingDirectory(rootDir).SetTechnologies(project.Go, project.Npm).SetLockfileOnly(true).SetOutputFormat(format.Json)
+
+	// The cache is opt-in.
+	assert.NoError(t, auditCmd.Run())
+	assert.NoError(t, auditCmd.Run())
+	assert.Len(t, scannedGraphs, 4)
+
+	scannedGraphs = nil
+	testsutils.SetEnvAndAssert(t, coreutils.AuditCacheTtl, "1h")
+	defer testsutils.UnSetEnvAndAssert(t, coreutils.AuditCacheTtl)
+	assert.NoError(t, auditCmd.Run())
+	assert.NoError(t, auditCmd.Run())
+	assert.Len(t, scannedGraphs, 2)
+	cachedReport := auditCmd.Report()
+	assert.True(t, cachedReport.Subprojects[0].Cached)
+	assert.Equal(t, "go://github.com/gin-gonic/gin:v1.9.0", cachedReport.Subprojects[0].Vulnerabilities[0].Component)
+	assert.Equal(t, []string{"npm://lodash:4.17.20"}, cachedReport.Subprojects[1].Vulnerabilities[0].DirectDependencies)
+	// The temp files of the cached results are renamed.
+	cacheDir, err := coreutils.GetJfrogAuditCacheDir()
+	require.NoError(t, err)
+	tempFiles, err := filepath.Glob(filepath.Join(cacheDir, "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, tempFiles)
+
+	// Only the subproject whose dependencies changed is scanned again.
+	writeFile(t, filepath.Join(rootDir, "services", "api", "go.mod"), "module example.com/api\n\nrequire golang.org/x/text v0.3.8\n")
+	scannedGraphs = nil
+	assert.NoError(t, auditCmd.Run())
+	assert.Equal(t, []string{"go://example.com/api"}, scannedGraphs)
+	assert.False(t, auditCmd.Report().Subprojects[0].Cached)
+	assert.True(t, auditCmd.Report().Subprojects[1].Cached)
+
+	// Expired results aren't used.
+	scannedGraphs = nil
+	assert.NoError(t, auditCmd.SetCacheTtl(time.Nanosecond).Run())
+	assert.Len(t, scannedGraphs, 2)
+
+	testsutils.SetEnvAndAssert(t, coreutils.AuditCacheTtl, "1 day")
+	assert.ErrorContains(t, NewAuditCommand().Run(), "the value of JFROG_CLI_AUDIT_CACHE_TTL must be a non-negative duration")
+	assert.NoError(t, CleanAuditCache())
+}
+
+func TestAuditAnnotations(t *testing.T) {
+	rootDir := createMonorepo(t)
+	vulnerability := xrayutils.Vulnerability{Severity: "High", Issue: "XRAY-1", Summary: "Vulnerable", Cves: []string{"CVE-2023-1"}, FixedVersions: []string{"[0.7.0]"}}
+	netVulnerability, lodashVulnerability := vulnerability, vulnerability
+	netVulnerability.Component, lodashVulnerability.Component = "go://golang.org/x/net:v0.1.0", "npm://lodash:4.17.20"
+	lodashVulnerability.Severity = "Low"
+	report := &AuditReport{Subprojects: []*SubprojectReport{
+		{Path: "services/api", Technology: project.Go.String(), Descriptor: "go.mod",
+			Vulnerabilities: []Vulnerability{{Vulnerability: netVulnerability, DirectDependencies: []string{"go://github.com/gin-gonic/gin:v1.9.0"}}}},
+		{Path: "web", Technology: project.Npm.String(), Descriptor: "package-lock.json",
+			Vulnerabilities: []Vulnerability{{Vulnerability: lodashVulnerability, DirectDependencies: []string{"npm://lodash:4.17.20"}}}},
+	}}
+	findings, err := getManifestFindings(report, rootDir)
+	require.NoError(t, err)
+	// The dependencies of npm subprojects are declared in package.json, rather than in the lockfile.
+	assert.Equal(t, "::error file=services/api/go.mod,line=6,title=go%3A//golang.org/x/net%3Av0.1.0 (High)%3A Vulnerable::"+
+		"go://golang.org/x/net:v0.1.0 (High): Vulnerable. Installed through the go://github.com/gin-gonic/gin:v1.9.0 dependency. Fixed versions: [0.7.0]. CVE-2023-1\n"+
+		"::notice file=web/package.json,line=1,title=npm%3A//lodash%3A4.17.20 (Low)%3A Vulnerable::npm://lodash:4.17.20 (Low): Vulnerable. Fixed versions: [0.7.0]. CVE-2023-1",
+		toGithubAnnotations(findings))
+
+	issues := toGitlabCodeQuality(findings)
+	require.Len(t, issues, 2)
+	assert.Equal(t, "XRAY-1", issues[0].CheckName)
+	assert.Equal(t, "critical", issues[0].Severity)
+	assert.Equal(t, xrayutils.GitlabCodeQualityLocation{Path: "services/api/go.mod", Lines: xrayutils.GitlabCodeQualityLines{Begin: 6}}, issues[0].Location)
+	assert.Equal(t, "minor", issues[1].Severity)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+}
+
+func TestGetComponentName(t *testing.T) {
+	assert.Equal(t, "lodash", getComponentName("npm://lodash:4.17.20"))
+	assert.Equal(t, "@types/node", getComponentName("npm://@types/node:20.1.0"))
+	assert.Equal(t, "github.com/gin-gonic/gin", getComponentName("go://github.com/gin-gonic/gin:v1.9.0"))
+	assert.Equal(t, "commons-io", getComponentName("gav://commons-io:commons-io:2.11.0"))
+}
@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+type cachedScanResults struct {
+	Timestamp    int64                  `json:"timestamp"`
+	ScanResponse *services.ScanResponse `json:"scanResponse"`
+}
+
+// GetAuditCacheTtl returns the TTL of the cached Xray scan results, from the JFROG_CLI_AUDIT_CACHE_TTL environment variable.
+// Zero means the cache is disabled.
+func GetAuditCacheTtl() (time.Duration, error) {
+	value := os.Getenv(coreutils.AuditCacheTtl)
+	if value == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, errorutils.CheckErrorf("the value of %s must be a non-negative duration such as '30m' or '24h', but is: %s", coreutils.AuditCacheTtl, value)
+	}
+	return ttl, nil
+}
+
+// Returns the key of the scan results of the dependency graph. The graph is built from the resolved dependencies of the subproject,
+// so its hash changes only when they change. The key also depends on the Xray server and project which scan the graph.
+func getCacheKey(serverDetails *config.ServerDetails, graph *xrayUtils.GraphNode) string {
+	hash := sha256.New()
+	hash.Write([]byte(serverDetails.XrayUrl + "\n" + config.GetProjectKey("", serverDetails) + "\n"))
+	var writeNode func(node *xrayUtils.GraphNode, depth int)
+	writeNode = func(node *xrayUtils.GraphNode, depth int) {
+		hash.Write([]byte(strings.Repeat(" ", depth) + node.Id + "\n"))
+		for _, child := range node.Nodes {
+			writeNode(child, depth+1)
+		}
+	}
+	writeNode(graph, 0)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func getCachePath(cacheKey string) (string, error) {
+	cacheDir, err := coreutils.GetJfrogAuditCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, cacheKey+".json"), nil
+}
+
+// Returns the cached scan results of the key, if they exist and haven't expired.
+func readCachedScanResults(cacheKey string, ttl time.Duration) (*services.ScanResponse, bool) {
+	cachePath, err := getCachePath(cacheKey)
+	if err != nil {
+		return nil, false
+	}
+	content, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil, false
+	}
+	var cached cachedScanResults
+	if err = json.Unmarshal(content, &cached); err != nil || cached.ScanResponse == nil {
+		log.Debug("The cached scan results at '" + cachePath + "' are corrupted and will be recreated")
+		return nil, false
+	}
+	if time.Since(time.Unix(cached.Timestamp, 0)) > ttl {
+		return nil, false
+	}
+	return cached.ScanResponse, true
+}
+
+// Caches the scan results of the key, and removes the cached results which expired.
+func writeCachedScanResults(cacheKey string, ttl time.Duration, scanResponse *services.ScanResponse) error {
+	cachePath, err := getCachePath(cacheKey)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(cachedScanResults{Timestamp: time.Now().Unix(), ScanResponse: scanResponse})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(cachePath)); err != nil {
+		return err
+	}
+	// Write to a unique temp file and rename it, so that concurrent audits never read or write a partially written file.
+	tempFile, err := os.CreateTemp(filepath.Dir(cachePath), cacheKey+"-*.tmp")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = tempFile.Write(content)
+	if err = errors.Join(err, tempFile.Close()); err == nil {
+		err = os.Rename(tempFile.Name(), cachePath)
+	}
+	if err != nil {
+		return errors.Join(errorutils.CheckError(err), errorutils.CheckError(os.Remove(tempFile.Name())))
+	}
+	return removeExpiredScanResults(filepath.Dir(cachePath), ttl)
+}
+
+func removeExpiredScanResults(cacheDir string, ttl time.Duration) error {
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() || time.Since(info.ModTime()) <= ttl {
+			continue
+		}
+		if err = os.Remove(filepath.Join(cacheDir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+// CleanAuditCache removes the cached scan results from the JFrog home directory.
+func CleanAuditCache() error {
+	cacheDir, err := coreutils.GetJfrogAuditCacheDir()
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.RemoveAll(cacheDir))
+}
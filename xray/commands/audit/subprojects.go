@@ -0,0 +1,309 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/general/deps"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/npmaudit"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"golang.org/x/exp/slices"
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	goPackageTypeId   = "go://"
+	npmPackageTypeId  = "npm://"
+	pypiPackageTypeId = "pypi://"
+)
+
+// The directories which aren't searched for subprojects, since they contain installed dependencies or tool files.
+var ignoredDirs = map[string]bool{"node_modules": true, "vendor": true, "venv": true, "__pycache__": true, "site-packages": true}
+
+// The descriptors identifying a subproject of each supported technology, by their priority.
+var technologyDescriptors = []struct {
+	technology  project.ProjectType
+	descriptors []string
+}{
+	{project.Npm, []string{"package-lock.json", "npm-shrinkwrap.json"}},
+	{project.Yarn, []string{"yarn.lock"}},
+	{project.Go, []string{"go.mod"}},
+	{project.Pip, []string{"requirements.txt"}},
+}
+
+// SupportedTechnologies are the technologies of the subprojects which are audited.
+var SupportedTechnologies = []project.ProjectType{project.Npm, project.Yarn, project.Go, project.Pip}
+
+// Subproject is a project of one technology found under the root directory of the audit.
+type Subproject struct {
+	// The path of the subproject directory, relative to the root directory. The root directory itself is ".".
+	Path       string
+	Technology project.ProjectType
+	// The file from which the dependencies of the subproject are read.
+	Descriptor string
+}
+
+// DetectSubprojects walks the root directory and returns the subprojects of the supported technologies, sorted by their paths.
+// A directory may contain subprojects of several technologies. npm and Yarn subprojects are detected only next to a package.json file,
+// and a directory with both package-lock.json and yarn.lock is considered an npm subproject.
+func DetectSubprojects(rootDir string, technologies ...project.ProjectType) ([]*Subproject, error) {
+	if len(technologies) == 0 {
+		technologies = SupportedTechnologies
+	}
+	var subprojects []*Subproject
+	err := filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != rootDir && (strings.HasPrefix(entry.Name(), ".") || ignoredDirs[entry.Name()]) {
+			return filepath.SkipDir
+		}
+		relativePath, err := filepath.Rel(rootDir, path)
+		if err != nil {
+			return err
+		}
+		dirSubprojects, err := detectDirSubprojects(path, technologies)
+		for _, subproject := range dirSubprojects {
+			subproject.Path = filepath.ToSlash(relativePath)
+			subprojects = append(subprojects, subproject)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	sort.SliceStable(subprojects, func(i, j int) bool {
+		return subprojects[i].Path < subprojects[j].Path
+	})
+	return subprojects, nil
+}
+
+func detectDirSubprojects(dir string, technologies []project.ProjectType) ([]*Subproject, error) {
+	var subprojects []*Subproject
+	hasNpmSubproject := false
+	for _, technologyDescriptor := range technologyDescriptors {
+		for _, descriptor := range technologyDescriptor.descriptors {
+			exists, err := fileutils.IsFileExists(filepath.Join(dir, descriptor), false)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				continue
+			}
+			isNpmTechnology := technologyDescriptor.technology == project.Npm || technologyDescriptor.technology == project.Yarn
+			if isNpmTechnology {
+				if hasNpmSubproject {
+					break
+				}
+				if exists, err = fileutils.IsFileExists(filepath.Join(dir, "package.json"), false); err != nil || !exists {
+					break
+				}
+				hasNpmSubproject = true
+			}
+			if isTechnologyIncluded(technologyDescriptor.technology, technologies) {
+				subprojects = append(subprojects, &Subproject{Technology: technologyDescriptor.technology, Descriptor: descriptor})
+			}
+			break
+		}
+	}
+	return subprojects, nil
+}
+
+func isTechnologyIncluded(technology project.ProjectType, technologies []project.ProjectType) bool {
+	for _, included := range technologies {
+		if included == technology {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the path of the project configuration file of the subproject technology, from the .jfrog directory of the subproject directory
+// or of its closest parent directory up to the root directory, which has one. An empty path is returned if there's no such file.
+func getSubprojectConfFilePath(rootDir, subprojectDir string, technology project.ProjectType) (string, error) {
+	rootDir = filepath.Clean(rootDir)
+	for dir := filepath.Clean(subprojectDir); ; dir = filepath.Dir(dir) {
+		confFilePath := filepath.Join(dir, ".jfrog", "projects", technology.String()+".yaml")
+		exists, err := fileutils.IsFileExists(confFilePath, false)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return confFilePath, nil
+		}
+		if dir == rootDir || filepath.Dir(dir) == dir {
+			return "", nil
+		}
+	}
+}
+
+// Builds the dependency graph of the subproject, to be scanned by Xray. Returns the graph and the number of dependencies in it.
+// The dependencies of npm and Yarn subprojects are resolved by their package manager, unless lockfileOnly is true,
+// in which case they're read from package-lock.json or yarn.lock, without running the package manager or requiring node_modules.
+func buildDependencyGraph(subprojectDir string, subproject *Subproject, production, lockfileOnly bool) (*xrayUtils.GraphNode, int, error) {
+	switch subproject.Technology {
+	case project.Npm, project.Yarn:
+		if !lockfileOnly {
+			return buildPackageManagerDependencyGraph(subprojectDir, subproject.Technology, production)
+		}
+		lockfile, err := npmaudit.ReadNpmLockfile(subprojectDir)
+		if err != nil {
+			return nil, 0, err
+		}
+		graph := lockfile.BuildDependencyGraph(production)
+		return graph, countGraphNodes(graph), nil
+	case project.Go:
+		return buildGoDependencyGraph(filepath.Join(subprojectDir, subproject.Descriptor))
+	case project.Pip:
+		return buildPipDependencyGraph(filepath.Join(subprojectDir, subproject.Descriptor), subprojectDir)
+	}
+	return nil, 0, errorutils.CheckErrorf("auditing %s projects isn't supported", subproject.Technology)
+}
+
+// Builds the dependency graph of an npm or Yarn subproject from the dependency tree resolved by its package manager.
+// Development dependencies are omitted in production only if the package manager provides the scopes of the dependencies, as npm does.
+func buildPackageManagerDependencyGraph(subprojectDir string, technology project.ProjectType, production bool) (*xrayUtils.GraphNode, int, error) {
+	modules, err := deps.BuildDependencyTrees(coreutils.Technology(technology.String()), subprojectDir)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(modules) == 0 {
+		return nil, 0, errorutils.CheckErrorf("no modules were resolved in %s", subprojectDir)
+	}
+	var toGraphNode func(node *deps.DependencyNode, parent *xrayUtils.GraphNode) *xrayUtils.GraphNode
+	toGraphNode = func(node *deps.DependencyNode, parent *xrayUtils.GraphNode) *xrayUtils.GraphNode {
+		graphNode := &xrayUtils.GraphNode{Id: npmPackageTypeId + node.Id, Parent: parent}
+		for _, child := range node.Dependencies {
+			if production && len(child.Scopes) > 0 && !slices.Contains(child.Scopes, "prod") {
+				continue
+			}
+			graphNode.Nodes = append(graphNode.Nodes, toGraphNode(child, graphNode))
+		}
+		return graphNode
+	}
+	graph := toGraphNode(modules[0], nil)
+	return graph, countGraphNodes(graph), nil
+}
+
+// Counts the unique component IDs in the graph, excluding its root.
+func countGraphNodes(graph *xrayUtils.GraphNode) int {
+	ids := map[string]bool{}
+	var addIds func(node *xrayUtils.GraphNode)
+	addIds = func(node *xrayUtils.GraphNode) {
+		for _, child := range node.Nodes {
+			ids[child.Id] = true
+			addIds(child)
+		}
+	}
+	addIds(graph)
+	return len(ids)
+}
+
+// Builds the dependency graph of a Go module from its go.mod file. Since Go 1.17, go.mod lists all the modules which provide packages
+// to the main module, including the indirect ones, so they are all added as children of the root module.
+func buildGoDependencyGraph(goModPath string) (*xrayUtils.GraphNode, int, error) {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, 0, errorutils.CheckError(err)
+	}
+	goMod, err := modfile.Parse(goModPath, content, nil)
+	if err != nil {
+		return nil, 0, errorutils.CheckErrorf("failed parsing %s: %s", goModPath, err.Error())
+	}
+	if goMod.Module == nil {
+		return nil, 0, errorutils.CheckErrorf("no module directive was found in %s", goModPath)
+	}
+	replacements := map[string]string{}
+	for _, replace := range goMod.Replace {
+		// Replacements by local directories aren't resolved from a repository, so they're omitted.
+		if replace.New.Version == "" {
+			replacements[replace.Old.Path] = ""
+			continue
+		}
+		replacements[replace.Old.Path] = replace.New.Path + ":" + replace.New.Version
+	}
+	root := &xrayUtils.GraphNode{Id: goPackageTypeId + goMod.Module.Mod.Path}
+	for _, require := range goMod.Require {
+		componentId := require.Mod.Path + ":" + require.Mod.Version
+		if replacement, replaced := replacements[require.Mod.Path]; replaced {
+			if replacement == "" {
+				continue
+			}
+			componentId = replacement
+		}
+		root.Nodes = append(root.Nodes, &xrayUtils.GraphNode{Id: goPackageTypeId + componentId, Parent: root})
+	}
+	return root, len(root.Nodes), nil
+}
+
+// Builds the dependency graph of a Python project from its requirements file. Only the requirements pinned to an exact version are added,
+// so the file is expected to be fully pinned, like the output of 'jf pip compile' or 'pip freeze'.
+func buildPipDependencyGraph(requirementsPath, projectDir string) (*xrayUtils.GraphNode, int, error) {
+	content, err := os.ReadFile(requirementsPath)
+	if err != nil {
+		return nil, 0, errorutils.CheckError(err)
+	}
+	root := &xrayUtils.GraphNode{Id: pypiPackageTypeId + filepath.Base(projectDir)}
+	for _, requirement := range readRequirements(content) {
+		name, version, pinned := strings.Cut(requirement, "==")
+		name, version = strings.TrimSpace(name), strings.TrimSpace(version)
+		if !pinned || name == "" || version == "" || strings.ContainsAny(version, "*,") {
+			log.Warn(fmt.Sprintf("The requirement '%s' in %s isn't pinned to an exact version, so it isn't audited.", requirement, requirementsPath))
+			continue
+		}
+		// Extras, such as requests[security], don't change the audited package.
+		if index := strings.Index(name, "["); index > 0 {
+			name = name[:index]
+		}
+		root.Nodes = append(root.Nodes, &xrayUtils.GraphNode{Id: pypiPackageTypeId + strings.ToLower(name) + ":" + version, Parent: root})
+	}
+	return root, len(root.Nodes), nil
+}
+
+// Returns the requirements in the content of a requirements file, without comments, options, hashes and environment markers.
+func readRequirements(content []byte) []string {
+	var requirements []string
+	var line strings.Builder
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		text := scanner.Text()
+		if index := strings.Index(text, " #"); index >= 0 || strings.HasPrefix(strings.TrimSpace(text), "#") {
+			if index < 0 {
+				index = 0
+			}
+			text = text[:index]
+		}
+		continued := strings.HasSuffix(strings.TrimSpace(text), "\\")
+		line.WriteString(strings.TrimSuffix(strings.TrimSpace(text), "\\") + " ")
+		if continued {
+			continue
+		}
+		requirement := strings.TrimSpace(line.String())
+		line.Reset()
+		if index := strings.Index(requirement, " --"); index >= 0 {
+			requirement = strings.TrimSpace(requirement[:index])
+		}
+		if index := strings.Index(requirement, ";"); index >= 0 {
+			requirement = strings.TrimSpace(requirement[:index])
+		}
+		if requirement == "" || strings.HasPrefix(requirement, "-") {
+			continue
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements
+}
@@ -0,0 +1,167 @@
+package npmaudit
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+var (
+	// Matches the opening of a dependencies section in package.json, such as '"devDependencies": {'.
+	dependenciesSectionPattern = regexp.MustCompile(`^\s*"(dependencies|devDependencies|optionalDependencies|peerDependencies)"\s*:\s*\{`)
+	dependencyLinePattern      = regexp.MustCompile(`^\s*"([^"]+)"\s*:`)
+)
+
+// The GitHub annotation levels and GitLab Code Quality severities of the npm audit severities.
+var (
+	githubAnnotationLevels = map[string]string{
+		SeverityInfo:     "notice",
+		SeverityLow:      "notice",
+		SeverityModerate: "warning",
+		SeverityHigh:     "error",
+		SeverityCritical: "error",
+	}
+	gitlabCodeQualitySeverities = map[string]string{
+		SeverityInfo:     "info",
+		SeverityLow:      "minor",
+		SeverityModerate: "major",
+		SeverityHigh:     "critical",
+		SeverityCritical: "blocker",
+	}
+)
+
+// A finding of the audit, located at the line of the manifest in which the dependency bringing it is declared.
+type manifestFinding struct {
+	vulnerability *NpmVulnerability
+	advisory      NpmAdvisory
+	// The direct dependency through which the vulnerable package is installed. Empty if the vulnerable package is the direct dependency.
+	through string
+	path    string
+	line    int
+}
+
+// Maps the findings of the report to the lines of package.json, in which the direct dependencies are declared.
+// Findings of transitive dependencies are located at the direct dependencies through which they're installed.
+// The paths are relative to the root of the Git repository containing the project, as expected by GitHub and GitLab.
+func getManifestFindings(report *NpmAuditReport, lockfile *NpmLockfile, projectDir string) ([]manifestFinding, error) {
+	packageJsonPath := filepath.Join(projectDir, "package.json")
+	dependencyLines, err := getDependencyLines(packageJsonPath)
+	if err != nil {
+		return nil, err
+	}
+	manifestPath, err := xrayutils.GetRepositoryRelativePath(packageJsonPath)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(report.Vulnerabilities))
+	for name := range report.Vulnerabilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var findings []manifestFinding
+	for _, name := range names {
+		vulnerability := report.Vulnerabilities[name]
+		directDependencies := []string{name}
+		if !vulnerability.IsDirect {
+			directDependencies = lockfile.GetDirectDependents(vulnerability.Nodes...)
+		}
+		for _, advisory := range vulnerability.Via {
+			if len(directDependencies) == 0 {
+				// The dependency path is unknown, so the finding is located at the top of the manifest.
+				findings = append(findings, manifestFinding{vulnerability: vulnerability, advisory: advisory, path: manifestPath, line: 1})
+				continue
+			}
+			for _, directDependency := range directDependencies {
+				finding := manifestFinding{vulnerability: vulnerability, advisory: advisory, path: manifestPath, line: dependencyLines[directDependency]}
+				if finding.line == 0 {
+					finding.line = 1
+				}
+				if directDependency != name {
+					finding.through = directDependency
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings, nil
+}
+
+// Returns the lines of package.json in which the dependencies are declared, by the dependencies names.
+// package.json isn't required with package-lock.json v2 and above, in which case the findings are located at the first line.
+func getDependencyLines(packageJsonPath string) (map[string]int, error) {
+	dependencyLines := map[string]int{}
+	file, err := os.Open(packageJsonPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return dependencyLines, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	inSection := false
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		switch {
+		case dependenciesSectionPattern.MatchString(line):
+			inSection = !strings.Contains(line, "}")
+		case inSection && strings.Contains(line, "}"):
+			inSection = false
+		case inSection:
+			if match := dependencyLinePattern.FindStringSubmatch(line); match != nil {
+				if _, exists := dependencyLines[match[1]]; !exists {
+					dependencyLines[match[1]] = lineNumber
+				}
+			}
+		}
+	}
+	return dependencyLines, errorutils.CheckError(scanner.Err())
+}
+
+func (finding *manifestFinding) getTitle() string {
+	return fmt.Sprintf("%s (%s): %s", finding.vulnerability.Name, finding.advisory.Severity, finding.advisory.Title)
+}
+
+func (finding *manifestFinding) getDescription() string {
+	description := finding.getTitle()
+	if finding.through != "" {
+		description += fmt.Sprintf(". Installed through the %s dependency", finding.through)
+	}
+	if finding.vulnerability.FixAvailable {
+		description += fmt.Sprintf(". Fix available by upgrading %s", finding.vulnerability.Name)
+	}
+	if finding.advisory.Url != "" {
+		description += ". " + finding.advisory.Url
+	}
+	return description
+}
+
+// Returns the findings as GitHub Actions workflow commands, which GitHub displays inline on the lines of package.json.
+func toGithubAnnotations(findings []manifestFinding) string {
+	annotations := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		annotations = append(annotations, xrayutils.FormatGithubAnnotation(githubAnnotationLevels[finding.advisory.Severity],
+			finding.path, finding.line, finding.getTitle(), finding.getDescription()))
+	}
+	return strings.Join(annotations, "\n")
+}
+
+// Returns the findings as a GitLab Code Quality report.
+func toGitlabCodeQuality(findings []manifestFinding) []xrayutils.GitlabCodeQualityIssue {
+	issues := []xrayutils.GitlabCodeQualityIssue{}
+	for _, finding := range findings {
+		issues = append(issues, xrayutils.NewGitlabCodeQualityIssue(finding.advisory.Source, gitlabCodeQualitySeverities[finding.advisory.Severity],
+			finding.getDescription(), finding.path, finding.line, finding.advisory.Source, finding.vulnerability.Name, finding.through, finding.path))
+	}
+	return issues
+}
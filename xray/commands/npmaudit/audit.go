@@ -0,0 +1,205 @@
+package npmaudit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+// NpmAuditCommand scans the dependencies of an npm or Yarn project with Xray, and prints the results in the formats of 'npm audit'.
+// The dependencies are read from the project's lockfile only, so neither the package manager nor node_modules are required.
+// It replaces 'npm audit', which doesn't work when the dependencies are resolved from Artifactory.
+type NpmAuditCommand struct {
+	serverDetails *config.ServerDetails
+	workingDir    string
+	outputFormat  format.OutputFormat
+	// The minimal severity of vulnerabilities failing the command, like the 'audit-level' option of npm. If empty, any vulnerability fails the command.
+	auditLevel string
+	// If true, the development dependencies are omitted, like 'npm audit --omit=dev'.
+	production bool
+	// The target in Artifactory to upload the report to. If empty, the report isn't uploaded.
+	resultsTarget      string
+	buildConfiguration *build.BuildConfiguration
+	report             *NpmAuditReport
+}
+
+func NewNpmAuditCommand() *NpmAuditCommand {
+	return &NpmAuditCommand{outputFormat: format.Table}
+}
+
+func (nac *NpmAuditCommand) SetServerDetails(serverDetails *config.ServerDetails) *NpmAuditCommand {
+	nac.serverDetails = serverDetails
+	return nac
+}
+
+func (nac *NpmAuditCommand) SetWorkingDirectory(workingDir string) *NpmAuditCommand {
+	nac.workingDir = workingDir
+	return nac
+}
+
+// SetOutputFormat sets the output format - 'table' for the human-readable format, 'json' for the JSON format of 'npm audit --json',
+// 'sarif' or 'cyclonedx' for a SARIF report or a CycloneDX SBOM of the vulnerable packages, 'github-annotations' for annotations
+// of pull requests in GitHub Actions, or 'gitlab-codequality' for a GitLab Code Quality report.
+func (nac *NpmAuditCommand) SetOutputFormat(outputFormat format.OutputFormat) *NpmAuditCommand {
+	nac.outputFormat = outputFormat
+	return nac
+}
+
+func (nac *NpmAuditCommand) SetAuditLevel(auditLevel string) *NpmAuditCommand {
+	nac.auditLevel = auditLevel
+	return nac
+}
+
+func (nac *NpmAuditCommand) SetProduction(production bool) *NpmAuditCommand {
+	nac.production = production
+	return nac
+}
+
+// SetUploadResults sets the target in Artifactory, in the form of <repo>/<path>, to upload the report to, in the output format.
+// If the target ends with a slash, the report is uploaded into it as npm-audit-results with the extension of the format, e.g. npm-audit-results.sarif.
+func (nac *NpmAuditCommand) SetUploadResults(resultsTarget string) *NpmAuditCommand {
+	nac.resultsTarget = resultsTarget
+	return nac
+}
+
+// SetBuildConfiguration sets the build, whose properties are set on the uploaded report, and to whose build-info the report is added.
+func (nac *NpmAuditCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *NpmAuditCommand {
+	nac.buildConfiguration = buildConfiguration
+	return nac
+}
+
+func (nac *NpmAuditCommand) Report() *NpmAuditReport {
+	return nac.report
+}
+
+func (nac *NpmAuditCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nac.serverDetails, nil
+}
+
+func (nac *NpmAuditCommand) CommandName() string {
+	return "xr_npm_audit"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand. The command is read-only unless it uploads its results.
+func (nac *NpmAuditCommand) IsReadOnly() bool {
+	return nac.resultsTarget == ""
+}
+
+func (nac *NpmAuditCommand) Run() (err error) {
+	if nac.auditLevel != "" && getSeverityLevel(nac.auditLevel) < 0 {
+		return errorutils.CheckErrorf("unsupported audit level '%s'. Possible values are: %s, %s, %s, %s, %s", nac.auditLevel, SeverityInfo, SeverityLow, SeverityModerate, SeverityHigh, SeverityCritical)
+	}
+	switch nac.outputFormat {
+	case format.Table, format.Json, format.Sarif, format.CycloneDx, format.GithubAnnotations, format.GitlabCodeQuality:
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s, %s, %s, %s, %s", nac.outputFormat,
+			format.Table, format.Json, format.Sarif, format.CycloneDx, format.GithubAnnotations, format.GitlabCodeQuality)
+	}
+	workingDir := nac.workingDir
+	if workingDir == "" {
+		if workingDir, err = os.Getwd(); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	lockfile, err := ReadNpmLockfile(workingDir)
+	if err != nil {
+		return err
+	}
+	graph := lockfile.BuildDependencyGraph(nac.production)
+	scanResponse, err := nac.scan(lockfile, graph)
+	if err != nil {
+		return err
+	}
+	nac.report = createReport(lockfile, scanResponse, nac.production)
+	results := nac.getResults(scanResponse, graph)
+	if err = nac.printReport(lockfile, workingDir, results); err != nil {
+		return err
+	}
+	if nac.resultsTarget != "" {
+		// The annotations aren't meant to be stored, so the JSON report is uploaded instead.
+		uploader := xrayutils.NewResultsUploader(nac.serverDetails, nac.resultsTarget).SetBuildConfiguration(nac.buildConfiguration).SetOutputFormat(nac.outputFormat)
+		if _, err = uploader.UploadResults("npm-audit", results); err != nil {
+			return err
+		}
+	}
+	minSeverity := nac.auditLevel
+	if minSeverity == "" {
+		minSeverity = SeverityInfo
+	}
+	if count := nac.report.CountVulnerabilities(minSeverity); count > 0 {
+		return errorutils.CheckErrorf("found %d vulnerable packages with '%s' severity or above", count, minSeverity)
+	}
+	return nil
+}
+
+func (nac *NpmAuditCommand) scan(lockfile *NpmLockfile, graph *xrayUtils.GraphNode) (*services.ScanResponse, error) {
+	xrayManager, err := xrayutils.CreateXrayServiceManager(nac.serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Scanning %d npm packages with Xray...", len(lockfile.Packages)))
+	scanId, err := xrayManager.ScanGraph(services.XrayGraphScanParams{
+		DependenciesGraph:      graph,
+		ScanType:               services.Dependency,
+		ProjectKey:             config.GetProjectKey("", nac.serverDetails),
+		IncludeVulnerabilities: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return xrayManager.GetScanGraphResults(scanId, true, false, false)
+}
+
+func (nac *NpmAuditCommand) printReport(lockfile *NpmLockfile, projectDir string, results *xrayutils.Results) error {
+	var output any = nac.report
+	switch nac.outputFormat {
+	case format.Table:
+		log.Output(nac.report.ToHumanReadable())
+		return nil
+	case format.Sarif, format.CycloneDx:
+		content, _, err := xrayutils.MarshalResults(nac.outputFormat, results)
+		if err != nil {
+			return err
+		}
+		log.Output(string(content))
+		return nil
+	case format.GithubAnnotations, format.GitlabCodeQuality:
+		findings, err := getManifestFindings(nac.report, lockfile, projectDir)
+		if err != nil {
+			return err
+		}
+		if nac.outputFormat == format.GithubAnnotations {
+			if annotations := toGithubAnnotations(findings); annotations != "" {
+				log.Output(annotations)
+			}
+			return nil
+		}
+		output = toGitlabCodeQuality(findings)
+	}
+	content, err := json.Marshal(output)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
+
+// Returns the results of the audit. The vulnerable packages are located in the package.json of the project.
+func (nac *NpmAuditCommand) getResults(scanResponse *services.ScanResponse, graph *xrayUtils.GraphNode) *xrayutils.Results {
+	results := &xrayutils.Results{Report: nac.report, Locations: map[string][]string{}, Graphs: []*xrayUtils.GraphNode{graph}}
+	results.Vulnerabilities = xrayutils.ConvertVulnerabilities(scanResponse, func(vulnerability xrayutils.Vulnerability, _ services.Component) xrayutils.Vulnerability {
+		results.Locations[vulnerability.Component] = []string{"package.json"}
+		return vulnerability
+	})
+	return results
+}
@@ -0,0 +1,208 @@
+package npmaudit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testScanResponse = `{"scan_id":"scan-1","vulnerabilities":[
+	{"issue_id":"XRAY-1","summary":"Prototype pollution in lodash","severity":"High","references":["https://example.com/XRAY-1"],
+	 "cves":[{"cve":"CVE-2021-23337","cvss_v3_score":"7.2","cvss_v3_vector":"CVSS:3.1/AV:N","cwe":["CWE-94"]}],
+	 "components":{"npm://lodash:4.17.20":{"fixed_versions":["[4.17.21]"],"impact_paths":[[{"component_id":"npm://my-app:1.0.0"},{"component_id":"npm://lodash:4.17.20"}]]}}},
+	{"issue_id":"XRAY-2","summary":"ReDoS in debug","severity":"Low",
+	 "components":{"npm://debug:2.6.9":{"impact_paths":[[{"component_id":"npm://my-app:1.0.0"},{"component_id":"npm://express:4.17.1"},{"component_id":"npm://debug:2.6.9"}]]}}}
+]}`
+
+// Returns the IDs of the graph nodes as a nested map.
+func toIdTree(node *xrayUtils.GraphNode) map[string]any {
+	tree := map[string]any{}
+	for _, child := range node.Nodes {
+		tree[child.Id] = toIdTree(child)
+	}
+	return tree
+}
+
+func TestBuildDependencyGraph(t *testing.T) {
+	lockfile, err := ReadNpmLockfile(filepath.Join("testdata", "lockfile-v3"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"express", "lodash", "mocha"}, lockfile.DirectDependencies)
+	assert.Equal(t, []string{"node_modules/debug"}, lockfile.GetLocations("debug", "4.3.4"))
+
+	graph := lockfile.BuildDependencyGraph(false)
+	assert.Equal(t, "npm://my-app:1.0.0", graph.Id)
+	assert.Equal(t, map[string]any{
+		"npm://express:4.17.1": map[string]any{"npm://debug:2.6.9": map[string]any{"npm://ms:2.0.0": map[string]any{}}},
+		"npm://lodash:4.17.20": map[string]any{},
+		"npm://mocha:10.2.0":   map[string]any{"npm://debug:4.3.4": map[string]any{"npm://ms:2.1.2": map[string]any{}}},
+	}, toIdTree(graph))
+
+	assert.Equal(t, map[string]any{
+		"npm://express:4.17.1": map[string]any{"npm://debug:2.6.9": map[string]any{"npm://ms:2.0.0": map[string]any{}}},
+		"npm://lodash:4.17.20": map[string]any{},
+	}, toIdTree(lockfile.BuildDependencyGraph(true)))
+}
+
+func TestReadLockfileVersion1(t *testing.T) {
+	lockfile, err := ReadNpmLockfile(filepath.Join("testdata", "lockfile-v1"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"express", "lodash"}, lockfile.DirectDependencies)
+	assert.Equal(t, map[string]any{
+		"npm://express:4.17.1": map[string]any{"npm://debug:2.6.9": map[string]any{}},
+		"npm://lodash:4.17.20": map[string]any{},
+	}, toIdTree(lockfile.BuildDependencyGraph(false)))
+
+	_, err = ReadNpmLockfile(t.TempDir())
+	assert.ErrorContains(t, err, "no package-lock.json or yarn.lock was found")
+}
+
+func TestReadYarnLockfile(t *testing.T) {
+	for _, projectDir := range []string{"yarn-classic", "yarn-berry"} {
+		t.Run(projectDir, func(t *testing.T) {
+			lockfile, err := ReadNpmLockfile(filepath.Join("testdata", projectDir))
+			require.NoError(t, err)
+			assert.Equal(t, "npm://my-app:1.0.0", getComponentId(lockfile.Name, lockfile.Version))
+			assert.Equal(t, []string{"express", "lodash", "mocha"}, lockfile.DirectDependencies)
+			assert.Equal(t, []string{"debug@4.3.4"}, lockfile.GetLocations("debug", "4.3.4"))
+			assert.Equal(t, map[string]any{
+				"npm://express:4.17.1": map[string]any{"npm://debug:2.6.9": map[string]any{"npm://ms:2.0.0": map[string]any{}}},
+				"npm://lodash:4.17.20": map[string]any{},
+				"npm://mocha:10.2.0":   map[string]any{"npm://debug:4.3.4": map[string]any{"npm://ms:2.1.2": map[string]any{}}},
+			}, toIdTree(lockfile.BuildDependencyGraph(false)))
+
+			// yarn.lock doesn't mark the development dependencies, so they're determined by package.json.
+			assert.Equal(t, map[string]any{
+				"npm://express:4.17.1": map[string]any{"npm://debug:2.6.9": map[string]any{"npm://ms:2.0.0": map[string]any{}}},
+				"npm://lodash:4.17.20": map[string]any{},
+			}, toIdTree(lockfile.BuildDependencyGraph(true)))
+			assert.True(t, lockfile.Packages["ms@2.1.2"].Dev)
+			assert.False(t, lockfile.Packages["ms@2.0.0"].Dev)
+		})
+	}
+}
+
+func createXrayServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/xray/api/v1/scan/graph":
+			var graph xrayUtils.GraphNode
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&graph))
+			assert.Equal(t, "npm://my-app:1.0.0", graph.Id)
+			_, err := w.Write([]byte(`{"scan_id":"scan-1"}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodGet && r.URL.Path == "/xray/api/v1/scan/graph/scan-1":
+			_, err := w.Write([]byte(testScanResponse))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestNpmAudit(t *testing.T) {
+	server := createXrayServer(t)
+	defer server.Close()
+	auditCmd := NewNpmAuditCommand().SetServerDetails(&config.ServerDetails{XrayUrl: server.URL + "/xray/"}).
+		SetWorkingDirectory(filepath.Join("testdata", "lockfile-v3")).SetOutputFormat(format.Json)
+	assert.EqualError(t, auditCmd.Run(), "found 2 vulnerable packages with 'info' severity or above")
+
+	report := auditCmd.Report()
+	assert.Equal(t, 2, report.AuditReportVersion)
+	assert.Equal(t, map[string]int{"info": 0, "low": 1, "moderate": 0, "high": 1, "critical": 0, "total": 2}, report.Metadata.Vulnerabilities)
+	assert.Equal(t, NpmDependenciesCount{Prod: 4, Dev: 3, Total: 7}, report.Metadata.Dependencies)
+	assert.Equal(t, &NpmVulnerability{
+		Name:     "lodash",
+		Severity: SeverityHigh,
+		IsDirect: true,
+		Via: []NpmAdvisory{{Source: "XRAY-1", Name: "lodash", Dependency: "lodash", Title: "Prototype pollution in lodash", Url: "https://example.com/XRAY-1",
+			Severity: SeverityHigh, Cwe: []string{"CWE-94"}, Cvss: NpmCvss{Score: 7.2, VectorString: "CVSS:3.1/AV:N"}, Range: "<4.17.21"}},
+		Effects:      []string{},
+		Range:        "<4.17.21",
+		Nodes:        []string{"node_modules/lodash"},
+		FixAvailable: true,
+	}, report.Vulnerabilities["lodash"])
+	debug := report.Vulnerabilities["debug"]
+	assert.False(t, debug.IsDirect)
+	assert.Equal(t, []string{"express"}, debug.Effects)
+	assert.Equal(t, []string{"node_modules/express/node_modules/debug"}, debug.Nodes)
+	assert.Equal(t, "*", debug.Range)
+
+	// Only the high severity vulnerability fails the command.
+	assert.EqualError(t, auditCmd.SetAuditLevel(SeverityModerate).SetOutputFormat(format.Table).Run(), "found 1 vulnerable packages with 'moderate' severity or above")
+	assert.NoError(t, auditCmd.SetAuditLevel(SeverityCritical).Run())
+	assert.ErrorContains(t, auditCmd.SetAuditLevel("severe").Run(), "unsupported audit level 'severe'")
+}
+
+func TestNpmAuditAnnotations(t *testing.T) {
+	server := createXrayServer(t)
+	defer server.Close()
+	// The paths of the annotations are relative to the root of the Git repository.
+	repoDir := t.TempDir()
+	projectDir := filepath.Join(repoDir, "web")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".git"), 0700))
+	require.NoError(t, os.MkdirAll(projectDir, 0700))
+	lockfileContent, err := os.ReadFile(filepath.Join("testdata", "lockfile-v3", "package-lock.json"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "package-lock.json"), lockfileContent, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "package.json"), []byte(`{
+  "name": "my-app",
+  "version": "1.0.0",
+  "dependencies": {
+    "express": "^4.17.0",
+    "lodash": "^4.17.0"
+  },
+  "devDependencies": {
+    "mocha": "^10.2.0"
+  }
+}`), 0600))
+
+	auditCmd := NewNpmAuditCommand().SetServerDetails(&config.ServerDetails{XrayUrl: server.URL + "/xray/"}).
+		SetWorkingDirectory(projectDir).SetOutputFormat(format.GithubAnnotations)
+	assert.Error(t, auditCmd.Run())
+	lockfile, err := ReadNpmLockfile(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"express"}, lockfile.GetDirectDependents("node_modules/express/node_modules/debug"))
+	assert.Equal(t, []string{"express", "mocha"}, lockfile.GetDirectDependents(lockfile.GetLocations("debug", "2.6.9")[0], "node_modules/debug"))
+
+	findings, err := getManifestFindings(auditCmd.Report(), lockfile, projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, "::notice file=web/package.json,line=5,title=debug (low)%3A ReDoS in debug::debug (low): ReDoS in debug. Installed through the express dependency\n"+
+		"::error file=web/package.json,line=6,title=lodash (high)%3A Prototype pollution in lodash::lodash (high): Prototype pollution in lodash. Fix available by upgrading lodash. https://example.com/XRAY-1",
+		toGithubAnnotations(findings))
+
+	issues := toGitlabCodeQuality(findings)
+	require.Len(t, issues, 2)
+	assert.Equal(t, "XRAY-2", issues[0].CheckName)
+	assert.Equal(t, "minor", issues[0].Severity)
+	assert.Equal(t, xrayutils.GitlabCodeQualityLocation{Path: "web/package.json", Lines: xrayutils.GitlabCodeQualityLines{Begin: 5}}, issues[0].Location)
+	assert.Equal(t, "blocker", gitlabCodeQualitySeverities[SeverityCritical])
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+	assert.Error(t, auditCmd.SetOutputFormat(format.GitlabCodeQuality).Run())
+}
+
+func TestToHumanReadable(t *testing.T) {
+	report := &NpmAuditReport{Vulnerabilities: map[string]*NpmVulnerability{
+		"lodash": {Name: "lodash", Severity: SeverityHigh, Range: "<4.17.21", FixAvailable: true, Nodes: []string{"node_modules/lodash"},
+			Via: []NpmAdvisory{{Title: "Prototype pollution in lodash", Url: "https://example.com/XRAY-1"}}},
+	}, Metadata: NpmAuditMetadata{Vulnerabilities: map[string]int{"high": 1, "total": 1}}}
+	assert.Equal(t, `# npm audit report
+
+lodash  <4.17.21
+Severity: high
+Prototype pollution in lodash - https://example.com/XRAY-1
+fix available by upgrading lodash
+node_modules/lodash
+
+1 vulnerability (1 high)`, report.ToHumanReadable())
+	assert.Equal(t, "found 0 vulnerabilities", (&NpmAuditReport{Metadata: NpmAuditMetadata{Vulnerabilities: map[string]int{"total": 0}}}).ToHumanReadable())
+}
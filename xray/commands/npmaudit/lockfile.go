@@ -0,0 +1,304 @@
+package npmaudit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	npmPackageTypeId = "npm://"
+	nodeModules      = "node_modules/"
+)
+
+// The lockfiles created by npm, by their precedence. If none of them exists, yarn.lock is used.
+var lockfileNames = []string{"npm-shrinkwrap.json", "package-lock.json"}
+
+// NpmPackage is a package installed by npm, as listed in the project's lockfile.
+type NpmPackage struct {
+	Name    string
+	Version string
+	// The location of the package in the node_modules tree, for example: node_modules/a/node_modules/b.
+	Location string
+	Dev      bool
+	Optional bool
+	// The names of the dependencies of the package.
+	Dependencies []string
+}
+
+// NpmLockfile is the tree of the packages installed by npm, as listed in the project's lockfile.
+type NpmLockfile struct {
+	Name    string
+	Version string
+	// The names of the direct dependencies of the project.
+	DirectDependencies []string
+	// The installed packages by their locations.
+	Packages map[string]*NpmPackage
+	// Resolves the dependencies of packages, if the lockfile doesn't describe a node_modules tree.
+	resolveDependency func(location, name string) *NpmPackage
+}
+
+type lockfileContent struct {
+	Name            string                        `json:"name"`
+	Version         string                        `json:"version"`
+	LockfileVersion int                           `json:"lockfileVersion"`
+	Packages        map[string]lockfilePackage    `json:"packages"`
+	Dependencies    map[string]lockfileDependency `json:"dependencies"`
+}
+
+// A package in lockfiles of version 2 and above.
+type lockfilePackage struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dev                  bool              `json:"dev"`
+	Optional             bool              `json:"optional"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+}
+
+// A dependency in lockfiles of version 1.
+type lockfileDependency struct {
+	Version      string                        `json:"version"`
+	Dev          bool                          `json:"dev"`
+	Optional     bool                          `json:"optional"`
+	Requires     map[string]string             `json:"requires"`
+	Dependencies map[string]lockfileDependency `json:"dependencies"`
+}
+
+type packageJson struct {
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// ReadNpmLockfile reads the lockfile of the npm or Yarn project in the provided directory.
+// The dependency tree is built from the lockfile only, without running the package manager or requiring node_modules.
+func ReadNpmLockfile(projectDir string) (*NpmLockfile, error) {
+	var lockfilePath string
+	for _, name := range lockfileNames {
+		exists, err := fileutils.IsFileExists(filepath.Join(projectDir, name), false)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			lockfilePath = filepath.Join(projectDir, name)
+			break
+		}
+	}
+	if lockfilePath == "" {
+		exists, err := fileutils.IsFileExists(filepath.Join(projectDir, yarnLockfileName), false)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errorutils.CheckErrorf("no package-lock.json or yarn.lock was found in %s. Run 'npm install --package-lock-only' or 'yarn install --mode=update-lockfile' to create it", projectDir)
+		}
+		lockfilePath = filepath.Join(projectDir, yarnLockfileName)
+	}
+	return ReadNpmLockfileFromPath(lockfilePath)
+}
+
+// ReadNpmLockfileFromPath reads the provided lockfile - package-lock.json, npm-shrinkwrap.json or yarn.lock.
+// The package.json of the project is expected next to the lockfile.
+func ReadNpmLockfileFromPath(lockfilePath string) (*NpmLockfile, error) {
+	projectDir := filepath.Dir(lockfilePath)
+	if filepath.Base(lockfilePath) == yarnLockfileName {
+		return readYarnLockfile(projectDir)
+	}
+	content, err := os.ReadFile(lockfilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lockfile := &lockfileContent{}
+	if err = json.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", lockfilePath, err.Error())
+	}
+	if lockfile.LockfileVersion >= 2 {
+		return parsePackages(lockfile), nil
+	}
+	return parseDependencies(lockfile, projectDir)
+}
+
+func parsePackages(lockfile *lockfileContent) *NpmLockfile {
+	npmLockfile := &NpmLockfile{Name: lockfile.Name, Version: lockfile.Version, Packages: map[string]*NpmPackage{}}
+	for location, pkg := range lockfile.Packages {
+		if location == "" {
+			npmLockfile.DirectDependencies = getNames(pkg.Dependencies, pkg.DevDependencies, pkg.OptionalDependencies)
+			continue
+		}
+		// Links are local packages, such as workspaces, which aren't installed from the registry.
+		if pkg.Link || !strings.Contains(location, nodeModules) {
+			continue
+		}
+		name := pkg.Name
+		if name == "" {
+			name = location[strings.LastIndex(location, nodeModules)+len(nodeModules):]
+		}
+		npmLockfile.Packages[location] = &NpmPackage{Name: name, Version: pkg.Version, Location: location, Dev: pkg.Dev, Optional: pkg.Optional,
+			Dependencies: getNames(pkg.Dependencies, pkg.OptionalDependencies)}
+	}
+	return npmLockfile
+}
+
+// Lockfiles of version 1 don't list the direct dependencies of the project, so they are read from package.json.
+func parseDependencies(lockfile *lockfileContent, projectDir string) (*NpmLockfile, error) {
+	npmLockfile := &NpmLockfile{Name: lockfile.Name, Version: lockfile.Version, Packages: map[string]*NpmPackage{}}
+	addDependencies(npmLockfile, "", lockfile.Dependencies)
+	content, err := os.ReadFile(filepath.Join(projectDir, "package.json"))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errorutils.CheckError(err)
+		}
+		// Without package.json, all the top level packages are considered direct dependencies.
+		npmLockfile.DirectDependencies = getNames(lockfile.Dependencies)
+		return npmLockfile, nil
+	}
+	projectPackageJson := &packageJson{}
+	if err = json.Unmarshal(content, projectPackageJson); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse package.json: %s", err.Error())
+	}
+	npmLockfile.DirectDependencies = getNames(projectPackageJson.Dependencies, projectPackageJson.DevDependencies, projectPackageJson.OptionalDependencies)
+	return npmLockfile, nil
+}
+
+func addDependencies(npmLockfile *NpmLockfile, parentLocation string, dependencies map[string]lockfileDependency) {
+	for name, dependency := range dependencies {
+		location := nodeModules + name
+		if parentLocation != "" {
+			location = parentLocation + "/" + location
+		}
+		npmLockfile.Packages[location] = &NpmPackage{Name: name, Version: dependency.Version, Location: location, Dev: dependency.Dev, Optional: dependency.Optional,
+			Dependencies: getNames(dependency.Requires)}
+		addDependencies(npmLockfile, location, dependency.Dependencies)
+	}
+}
+
+// Returns the sorted unique keys of the provided maps.
+func getNames[T any](maps ...map[string]T) []string {
+	var names []string
+	for _, m := range maps {
+		for name := range m {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns the package installed for a dependency of the package in the provided location, using the node modules resolution algorithm:
+// the dependency is looked up in the node_modules directory of the package, and then in the node_modules directories of its ancestors.
+func (nl *NpmLockfile) resolve(location, name string) *NpmPackage {
+	if nl.resolveDependency != nil {
+		return nl.resolveDependency(location, name)
+	}
+	for {
+		candidate := nodeModules + name
+		if location != "" {
+			candidate = location + "/" + candidate
+		}
+		if pkg, exists := nl.Packages[candidate]; exists {
+			return pkg
+		}
+		if location == "" {
+			return nil
+		}
+		location = getParentLocation(location)
+	}
+}
+
+// Returns the location of the package containing the node_modules directory of the provided location.
+func getParentLocation(location string) string {
+	index := strings.LastIndex(location, "/"+nodeModules)
+	if index < 0 {
+		return ""
+	}
+	return location[:index]
+}
+
+// IsDirect returns true if the package is a direct dependency of the project.
+func (nl *NpmLockfile) IsDirect(name string) bool {
+	return slices.Contains(nl.DirectDependencies, name)
+}
+
+// GetLocations returns the sorted locations in which the package with the provided name and version is installed.
+func (nl *NpmLockfile) GetLocations(name, version string) []string {
+	var locations []string
+	for location, pkg := range nl.Packages {
+		if pkg.Name == name && pkg.Version == version {
+			locations = append(locations, location)
+		}
+	}
+	sort.Strings(locations)
+	return locations
+}
+
+// GetDirectDependents returns the sorted direct dependencies of the project, through which the packages in the provided locations are installed.
+func (nl *NpmLockfile) GetDirectDependents(locations ...string) []string {
+	var dependents []string
+	for _, directDependency := range nl.DirectDependencies {
+		pkg := nl.resolve("", directDependency)
+		if pkg == nil {
+			continue
+		}
+		visited := map[string]bool{pkg.Location: true}
+		var dependsOn func(pkg *NpmPackage) bool
+		dependsOn = func(pkg *NpmPackage) bool {
+			for _, dependency := range pkg.Dependencies {
+				child := nl.resolve(pkg.Location, dependency)
+				if child == nil || visited[child.Location] {
+					continue
+				}
+				visited[child.Location] = true
+				if slices.Contains(locations, child.Location) || dependsOn(child) {
+					return true
+				}
+			}
+			return false
+		}
+		if dependsOn(pkg) {
+			dependents = append(dependents, directDependency)
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// BuildDependencyGraph returns the dependency graph of the project, to be scanned by Xray.
+// The dependencies of each installed package are added to the graph only once. If production is true, the development dependencies are omitted.
+func (nl *NpmLockfile) BuildDependencyGraph(production bool) *xrayUtils.GraphNode {
+	root := &xrayUtils.GraphNode{Id: getComponentId(nl.Name, nl.Version)}
+	expanded := map[string]bool{}
+	var addChildren func(node *xrayUtils.GraphNode, location string, dependencies []string)
+	addChildren = func(node *xrayUtils.GraphNode, location string, dependencies []string) {
+		for _, name := range dependencies {
+			pkg := nl.resolve(location, name)
+			// Optional dependencies may not be installed on the current platform.
+			if pkg == nil || (production && pkg.Dev) {
+				continue
+			}
+			child := &xrayUtils.GraphNode{Id: getComponentId(pkg.Name, pkg.Version), Parent: node}
+			node.Nodes = append(node.Nodes, child)
+			if !expanded[pkg.Location] {
+				expanded[pkg.Location] = true
+				addChildren(child, pkg.Location, pkg.Dependencies)
+			}
+		}
+	}
+	addChildren(root, "", nl.DirectDependencies)
+	return root
+}
+
+func getComponentId(name, version string) string {
+	return npmPackageTypeId + name + ":" + version
+}
@@ -0,0 +1,283 @@
+package npmaudit
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"golang.org/x/exp/slices"
+)
+
+// The npm audit severities, from the lowest to the highest.
+const (
+	SeverityInfo     = "info"
+	SeverityLow      = "low"
+	SeverityModerate = "moderate"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+var severities = []string{SeverityInfo, SeverityLow, SeverityModerate, SeverityHigh, SeverityCritical}
+
+// NpmAuditReport is compatible with the JSON report of 'npm audit' (audit report version 2).
+type NpmAuditReport struct {
+	AuditReportVersion int                          `json:"auditReportVersion"`
+	Vulnerabilities    map[string]*NpmVulnerability `json:"vulnerabilities"`
+	Metadata           NpmAuditMetadata             `json:"metadata"`
+}
+
+// NpmVulnerability lists the vulnerabilities of a package.
+type NpmVulnerability struct {
+	Name     string        `json:"name"`
+	Severity string        `json:"severity"`
+	IsDirect bool          `json:"isDirect"`
+	Via      []NpmAdvisory `json:"via"`
+	// The packages which depend on the vulnerable package.
+	Effects      []string `json:"effects"`
+	Range        string   `json:"range"`
+	Nodes        []string `json:"nodes"`
+	FixAvailable bool     `json:"fixAvailable"`
+}
+
+type NpmAdvisory struct {
+	// The Xray issue ID.
+	Source     string   `json:"source"`
+	Name       string   `json:"name"`
+	Dependency string   `json:"dependency"`
+	Title      string   `json:"title"`
+	Url        string   `json:"url"`
+	Severity   string   `json:"severity"`
+	Cwe        []string `json:"cwe"`
+	Cvss       NpmCvss  `json:"cvss"`
+	Range      string   `json:"range"`
+}
+
+type NpmCvss struct {
+	Score        float64 `json:"score"`
+	VectorString string  `json:"vectorString,omitempty"`
+}
+
+type NpmAuditMetadata struct {
+	Vulnerabilities map[string]int       `json:"vulnerabilities"`
+	Dependencies    NpmDependenciesCount `json:"dependencies"`
+}
+
+type NpmDependenciesCount struct {
+	Prod         int `json:"prod"`
+	Dev          int `json:"dev"`
+	Optional     int `json:"optional"`
+	Peer         int `json:"peer"`
+	PeerOptional int `json:"peerOptional"`
+	Total        int `json:"total"`
+}
+
+// Converts the results of the Xray scan to an npm audit report.
+func createReport(lockfile *NpmLockfile, scanResponse *services.ScanResponse, production bool) *NpmAuditReport {
+	report := &NpmAuditReport{AuditReportVersion: 2, Vulnerabilities: map[string]*NpmVulnerability{}, Metadata: createMetadata(lockfile, production)}
+	for _, vulnerability := range scanResponse.Vulnerabilities {
+		for componentId, component := range vulnerability.Components {
+			name, version, ok := parseComponentId(componentId)
+			if !ok {
+				continue
+			}
+			npmVulnerability, exists := report.Vulnerabilities[name]
+			if !exists {
+				npmVulnerability = &NpmVulnerability{Name: name, Severity: SeverityInfo, IsDirect: lockfile.IsDirect(name), Effects: []string{}, Nodes: []string{}, Via: []NpmAdvisory{}}
+				report.Vulnerabilities[name] = npmVulnerability
+			}
+			advisory := createAdvisory(vulnerability, name, component)
+			npmVulnerability.Via = append(npmVulnerability.Via, advisory)
+			if getSeverityLevel(advisory.Severity) > getSeverityLevel(npmVulnerability.Severity) {
+				npmVulnerability.Severity = advisory.Severity
+			}
+			npmVulnerability.Range = advisory.Range
+			npmVulnerability.FixAvailable = npmVulnerability.FixAvailable || len(component.FixedVersions) > 0
+			npmVulnerability.Nodes = appendUnique(npmVulnerability.Nodes, lockfile.GetLocations(name, version)...)
+			npmVulnerability.Effects = appendUnique(npmVulnerability.Effects, getEffects(component)...)
+		}
+	}
+	for _, npmVulnerability := range report.Vulnerabilities {
+		report.Metadata.Vulnerabilities[npmVulnerability.Severity]++
+		report.Metadata.Vulnerabilities["total"]++
+	}
+	return report
+}
+
+func createMetadata(lockfile *NpmLockfile, production bool) NpmAuditMetadata {
+	metadata := NpmAuditMetadata{Vulnerabilities: map[string]int{"total": 0}}
+	for _, severity := range severities {
+		metadata.Vulnerabilities[severity] = 0
+	}
+	for _, pkg := range lockfile.Packages {
+		switch {
+		case pkg.Dev:
+			if production {
+				continue
+			}
+			metadata.Dependencies.Dev++
+		case pkg.Optional:
+			metadata.Dependencies.Optional++
+		default:
+			metadata.Dependencies.Prod++
+		}
+		metadata.Dependencies.Total++
+	}
+	return metadata
+}
+
+func createAdvisory(vulnerability services.Vulnerability, name string, component services.Component) NpmAdvisory {
+	advisory := NpmAdvisory{
+		Source:     vulnerability.IssueId,
+		Name:       name,
+		Dependency: name,
+		Title:      vulnerability.Summary,
+		Severity:   toNpmSeverity(vulnerability.Severity),
+		Cwe:        []string{},
+		Range:      getVulnerableRange(component.FixedVersions),
+	}
+	if len(vulnerability.References) > 0 {
+		advisory.Url = vulnerability.References[0]
+	}
+	if len(vulnerability.Cves) > 0 {
+		cve := vulnerability.Cves[0]
+		if cve.Cwe != nil {
+			advisory.Cwe = cve.Cwe
+		}
+		advisory.Cvss.Score, _ = strconv.ParseFloat(cve.CvssV3Score, 64)
+		advisory.Cvss.VectorString = cve.CvssV3Vector
+		if advisory.Title == "" {
+			advisory.Title = cve.Id
+		}
+	}
+	return advisory
+}
+
+// Parses an Xray component ID, such as npm://lodash:4.17.20.
+func parseComponentId(componentId string) (name, version string, ok bool) {
+	if !strings.HasPrefix(componentId, npmPackageTypeId) {
+		return "", "", false
+	}
+	componentId = strings.TrimPrefix(componentId, npmPackageTypeId)
+	index := strings.LastIndex(componentId, ":")
+	if index <= 0 {
+		return "", "", false
+	}
+	return componentId[:index], componentId[index+1:], true
+}
+
+// Xray returns the fixed versions in brackets, such as [4.17.21].
+// If there is a single fixed version, all the versions below it are considered vulnerable.
+func getVulnerableRange(fixedVersions []string) string {
+	if len(fixedVersions) != 1 {
+		return "*"
+	}
+	return "<" + strings.Trim(fixedVersions[0], "[]")
+}
+
+// Returns the names of the packages which depend on the vulnerable package, according to its impact paths.
+func getEffects(component services.Component) []string {
+	var effects []string
+	for _, impactPath := range component.ImpactPaths {
+		// The first node in the impact path is the project itself.
+		if len(impactPath) < 3 {
+			continue
+		}
+		if name, _, ok := parseComponentId(impactPath[len(impactPath)-2].ComponentId); ok {
+			effects = appendUnique(effects, name)
+		}
+	}
+	return effects
+}
+
+func appendUnique(values []string, newValues ...string) []string {
+	for _, value := range newValues {
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+func toNpmSeverity(xraySeverity string) string {
+	switch strings.ToLower(xraySeverity) {
+	case "critical":
+		return SeverityCritical
+	case "high":
+		return SeverityHigh
+	case "medium":
+		return SeverityModerate
+	case "low":
+		return SeverityLow
+	default:
+		return SeverityInfo
+	}
+}
+
+// Returns the level of the severity, or -1 if it's not a valid severity.
+func getSeverityLevel(severity string) int {
+	return slices.Index(severities, severity)
+}
+
+// CountVulnerabilities returns the number of vulnerable packages with the provided severity or above.
+func (report *NpmAuditReport) CountVulnerabilities(minSeverity string) int {
+	count := 0
+	for _, vulnerability := range report.Vulnerabilities {
+		if getSeverityLevel(vulnerability.Severity) >= getSeverityLevel(minSeverity) {
+			count++
+		}
+	}
+	return count
+}
+
+// ToHumanReadable returns the report in the format of the human-readable output of 'npm audit'.
+func (report *NpmAuditReport) ToHumanReadable() string {
+	total := report.Metadata.Vulnerabilities["total"]
+	if total == 0 {
+		return "found 0 vulnerabilities"
+	}
+	names := make([]string, 0, len(report.Vulnerabilities))
+	for name := range report.Vulnerabilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var builder strings.Builder
+	builder.WriteString("# npm audit report\n\n")
+	for _, name := range names {
+		vulnerability := report.Vulnerabilities[name]
+		builder.WriteString(fmt.Sprintf("%s  %s\nSeverity: %s\n", name, vulnerability.Range, vulnerability.Severity))
+		for _, advisory := range vulnerability.Via {
+			builder.WriteString(advisory.Title)
+			if advisory.Url != "" {
+				builder.WriteString(" - " + advisory.Url)
+			}
+			builder.WriteString("\n")
+		}
+		if vulnerability.FixAvailable {
+			builder.WriteString("fix available by upgrading " + name + "\n")
+		} else {
+			builder.WriteString("No fix available\n")
+		}
+		if len(vulnerability.Effects) > 0 {
+			builder.WriteString("Packages depending on " + name + ": " + strings.Join(vulnerability.Effects, ", ") + "\n")
+		}
+		for _, node := range vulnerability.Nodes {
+			builder.WriteString(node + "\n")
+		}
+		builder.WriteString("\n")
+	}
+	var counts []string
+	for _, severity := range severities {
+		if count := report.Metadata.Vulnerabilities[severity]; count > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", count, severity))
+		}
+	}
+	noun := "vulnerabilities"
+	if total == 1 {
+		noun = "vulnerability"
+	}
+	builder.WriteString(fmt.Sprintf("%d %s (%s)", total, noun, strings.Join(counts, ", ")))
+	return builder.String()
+}
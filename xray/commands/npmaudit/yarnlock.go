@@ -0,0 +1,208 @@
+package npmaudit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	yarnLockfileName = "yarn.lock"
+	// The header of lockfiles created by Yarn Classic (v1). Lockfiles of later versions are YAML documents.
+	yarnClassicHeader = "# yarn lockfile v1"
+)
+
+// A package resolved in yarn.lock, with the ranges of its dependencies by their names.
+type yarnPackage struct {
+	version      string
+	dependencies map[string]string
+}
+
+// A package in lockfiles of Yarn Berry (v2 and above).
+type yarnBerryPackage struct {
+	Version              string            `yaml:"version"`
+	Resolution           string            `yaml:"resolution"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+type yarnPackageJson struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	packageJson
+}
+
+// yarn.lock doesn't contain a node_modules tree, but the package resolved for each dependency descriptor (name@range).
+// The packages are located by their name and version, and the dependency tree is built by resolving the descriptors.
+// The project's name, version and direct dependencies are read from package.json.
+func readYarnLockfile(projectDir string) (*NpmLockfile, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, yarnLockfileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var descriptors map[string]*yarnPackage
+	if bytes.Contains(content, []byte(yarnClassicHeader)) {
+		descriptors = parseYarnClassicLockfile(content)
+	} else if descriptors, err = parseYarnBerryLockfile(content); err != nil {
+		return nil, err
+	}
+	projectPackageJson := &yarnPackageJson{}
+	packageJsonContent, err := os.ReadFile(filepath.Join(projectDir, "package.json"))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("package.json is required for reading yarn.lock: %s", err.Error())
+	}
+	if err = json.Unmarshal(packageJsonContent, projectPackageJson); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse package.json: %s", err.Error())
+	}
+
+	npmLockfile := &NpmLockfile{Name: projectPackageJson.Name, Version: projectPackageJson.Version, Packages: map[string]*NpmPackage{}}
+	npmLockfile.DirectDependencies = getNames(projectPackageJson.Dependencies, projectPackageJson.DevDependencies, projectPackageJson.OptionalDependencies)
+	ranges := map[string]map[string]string{"": mergeRanges(projectPackageJson.Dependencies, projectPackageJson.DevDependencies, projectPackageJson.OptionalDependencies)}
+	for descriptor, pkg := range descriptors {
+		location := getYarnLocation(getDescriptorName(descriptor), pkg.version)
+		if _, exists := npmLockfile.Packages[location]; exists {
+			continue
+		}
+		npmLockfile.Packages[location] = &NpmPackage{Name: getDescriptorName(descriptor), Version: pkg.version, Location: location, Dependencies: getNames(pkg.dependencies)}
+		ranges[location] = pkg.dependencies
+	}
+	npmLockfile.resolveDependency = func(location, name string) *NpmPackage {
+		dependencyRange, exists := ranges[location][name]
+		if !exists {
+			return nil
+		}
+		// Yarn Berry adds the protocol to the descriptors of registry packages.
+		for _, descriptor := range []string{name + "@" + dependencyRange, name + "@npm:" + dependencyRange} {
+			if pkg, exists := descriptors[descriptor]; exists {
+				return npmLockfile.Packages[getYarnLocation(name, pkg.version)]
+			}
+		}
+		return nil
+	}
+	markDevPackages(npmLockfile, getNames(projectPackageJson.Dependencies, projectPackageJson.OptionalDependencies))
+	return npmLockfile, nil
+}
+
+// Parses the custom format of Yarn Classic lockfiles. For example:
+//
+//	"debug@2.6.9", debug@^2.6.0:
+//	  version "2.6.9"
+//	  dependencies:
+//	    ms "2.0.0"
+func parseYarnClassicLockfile(content []byte) map[string]*yarnPackage {
+	descriptors := map[string]*yarnPackage{}
+	var current *yarnPackage
+	inDependencies := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indent == 0:
+			current = &yarnPackage{dependencies: map[string]string{}}
+			inDependencies = false
+			for _, descriptor := range strings.Split(strings.TrimSuffix(trimmed, ":"), ",") {
+				descriptors[unquote(strings.TrimSpace(descriptor))] = current
+			}
+		case current == nil:
+			continue
+		case indent == 2:
+			key, value := splitYarnClassicLine(trimmed)
+			inDependencies = key == "dependencies:" || key == "optionalDependencies:"
+			if key == "version" {
+				current.version = value
+			}
+		case inDependencies:
+			name, dependencyRange := splitYarnClassicLine(trimmed)
+			current.dependencies[name] = dependencyRange
+		}
+	}
+	return descriptors
+}
+
+// Splits a line of the form 'key "value"', in which the key may be quoted as well.
+func splitYarnClassicLine(line string) (key, value string) {
+	if strings.HasPrefix(line, `"`) {
+		if end := strings.Index(line[1:], `"`); end >= 0 {
+			return line[1 : end+1], unquote(strings.TrimSpace(line[end+2:]))
+		}
+	}
+	key, value, _ = strings.Cut(line, " ")
+	return key, unquote(strings.TrimSpace(value))
+}
+
+func unquote(value string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+}
+
+func parseYarnBerryLockfile(content []byte) (map[string]*yarnPackage, error) {
+	entries := map[string]yarnBerryPackage{}
+	if err := yaml.Unmarshal(content, &entries); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse yarn.lock: %s", err.Error())
+	}
+	descriptors := map[string]*yarnPackage{}
+	for key, entry := range entries {
+		// Workspaces and other local packages aren't installed from the registry.
+		if key == "__metadata" || strings.Contains(entry.Resolution, "@workspace:") || strings.Contains(entry.Resolution, "@link:") {
+			continue
+		}
+		pkg := &yarnPackage{version: entry.Version, dependencies: mergeRanges(entry.Dependencies, entry.OptionalDependencies)}
+		for _, descriptor := range strings.Split(key, ",") {
+			descriptors[strings.TrimSpace(descriptor)] = pkg
+		}
+	}
+	return descriptors, nil
+}
+
+func mergeRanges(maps ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, m := range maps {
+		for name, dependencyRange := range m {
+			merged[name] = strings.TrimPrefix(dependencyRange, "npm:")
+		}
+	}
+	return merged
+}
+
+// Returns the name of the package in a descriptor, such as @scope/name@^1.0.0.
+func getDescriptorName(descriptor string) string {
+	if index := strings.LastIndex(descriptor, "@"); index > 0 {
+		return descriptor[:index]
+	}
+	return descriptor
+}
+
+func getYarnLocation(name, version string) string {
+	return name + "@" + version
+}
+
+// Marks the packages which aren't required by the production dependencies as development dependencies,
+// since yarn.lock doesn't mark them.
+func markDevPackages(npmLockfile *NpmLockfile, productionDependencies []string) {
+	production := map[string]bool{}
+	var visit func(location string, dependencies []string)
+	visit = func(location string, dependencies []string) {
+		for _, name := range dependencies {
+			pkg := npmLockfile.resolve(location, name)
+			if pkg == nil || production[pkg.Location] {
+				continue
+			}
+			production[pkg.Location] = true
+			visit(pkg.Location, pkg.Dependencies)
+		}
+	}
+	visit("", productionDependencies)
+	for location, pkg := range npmLockfile.Packages {
+		pkg.Dev = !production[location]
+	}
+}
@@ -0,0 +1,288 @@
+package scan
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	gavPackageTypeId  = "gav://"
+	npmPackageTypeId  = "npm://"
+	pypiPackageTypeId = "pypi://"
+	// The separator between the path of an archive and the path of an entry inside it, like in 'app.war!/WEB-INF/lib/lib.jar'.
+	archiveSeparator = "!/"
+	// Nested archives are read into memory. Deeper or larger archives are skipped, to protect against archive bombs.
+	maxArchiveDepth      = 5
+	maxNestedArchiveSize = 512 * 1024 * 1024
+)
+
+// Components maps the component IDs found in the scanned paths to their locations.
+type Components map[string][]string
+
+func (c Components) add(componentId, location string) {
+	c[componentId] = append(c[componentId], location)
+}
+
+// Ids returns the sorted IDs of the components.
+func (c Components) Ids() []string {
+	ids := make([]string, 0, len(c))
+	for id := range c {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// FindComponents walks the given directories and archives, including archives nested in other archives such as fat jars,
+// and returns the components identified by their metadata files:
+// pom.properties of Maven artifacts, package.json of npm packages and METADATA of Python distributions.
+func FindComponents(paths ...string) (Components, error) {
+	components := Components{}
+	for _, rootPath := range paths {
+		info, err := os.Stat(ioutils.ToLongPath(rootPath))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if !info.IsDir() {
+			if err = scanLocalFile(components, rootPath); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		// The paths are walked in their long form, since nested node_modules directories may exceed MAX_PATH on Windows.
+		err = ioutils.WalkDir(rootPath, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			return scanLocalFile(components, filePath)
+		})
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	return components, nil
+}
+
+func scanLocalFile(components Components, filePath string) error {
+	location := filepath.ToSlash(filePath)
+	if !isArchive(filePath) {
+		if !isMetadataFile(location) {
+			return nil
+		}
+		content, err := os.ReadFile(ioutils.ToLongPath(filePath))
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		addComponent(components, location, location, content)
+		return nil
+	}
+	file, err := os.Open(ioutils.ToLongPath(filePath))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	info, err := file.Stat()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return scanArchive(components, location, file, info.Size(), 1)
+}
+
+// Scans the entries of a zip or tar.gz archive. The location is the path of the archive, including the paths of its parent archives.
+func scanArchive(components Components, location string, reader io.ReaderAt, size int64, depth int) error {
+	if isTarArchive(location) {
+		return scanTarArchive(components, location, io.NewSectionReader(reader, 0, size), depth)
+	}
+	zipReader, err := zip.NewReader(reader, size)
+	if err != nil {
+		log.Debug("Skipping '" + location + "', which isn't a valid zip archive: " + err.Error())
+		return nil
+	}
+	for _, zipFile := range zipReader.File {
+		if zipFile.FileInfo().IsDir() {
+			continue
+		}
+		if err = scanArchiveEntry(components, location, zipFile.Name, int64(zipFile.UncompressedSize64), zipFile.Open, depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanTarArchive(components Components, location string, reader io.Reader, depth int) error {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		log.Debug("Skipping '" + location + "', which isn't a valid tar.gz archive: " + err.Error())
+		return nil
+	}
+	defer func() {
+		_ = gzipReader.Close()
+	}()
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.Debug("Stopped reading '" + location + "': " + err.Error())
+			return nil
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		open := func() (io.ReadCloser, error) {
+			return io.NopCloser(tarReader), nil
+		}
+		if err = scanArchiveEntry(components, location, header.Name, header.Size, open, depth); err != nil {
+			return err
+		}
+	}
+}
+
+func scanArchiveEntry(components Components, archiveLocation, name string, size int64, open func() (io.ReadCloser, error), depth int) error {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	location := archiveLocation + archiveSeparator + name
+	nested := isArchive(name)
+	if !nested && !isMetadataFile(name) {
+		return nil
+	}
+	if nested && (depth >= maxArchiveDepth || size > maxNestedArchiveSize) {
+		log.Warn("Skipping the nested archive '" + location + "', which exceeds the maximum depth or size of nested archives.")
+		return nil
+	}
+	entryReader, err := open()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = entryReader.Close()
+	}()
+	content, err := io.ReadAll(io.LimitReader(entryReader, maxNestedArchiveSize))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if nested {
+		return scanArchive(components, location, bytes.NewReader(content), int64(len(content)), depth+1)
+	}
+	addComponent(components, location, name, content)
+	return nil
+}
+
+func isArchive(filePath string) bool {
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".zip", ".jar", ".war", ".ear", ".aar", ".whl", ".nupkg":
+		return true
+	}
+	return isTarArchive(filePath)
+}
+
+func isTarArchive(filePath string) bool {
+	lowerPath := strings.ToLower(filePath)
+	return strings.HasSuffix(lowerPath, ".tgz") || strings.HasSuffix(lowerPath, ".tar.gz")
+}
+
+func isMetadataFile(filePath string) bool {
+	switch path.Base(filePath) {
+	case "pom.properties":
+		return strings.Contains(filePath, "META-INF/maven/")
+	case "package.json":
+		// The package.json files of installed packages and of npm tarballs, but not the manifests of the projects.
+		dir := path.Dir(filePath)
+		if dir == "package" {
+			return true
+		}
+		parent := path.Dir(dir)
+		if strings.HasPrefix(path.Base(parent), "@") {
+			parent = path.Dir(parent)
+		}
+		return path.Base(parent) == "node_modules"
+	case "METADATA", "PKG-INFO":
+		return strings.HasSuffix(path.Dir(filePath), ".dist-info") || strings.HasSuffix(path.Dir(filePath), ".egg-info")
+	}
+	return false
+}
+
+// Adds the component identified by the metadata file. The location is reported as the path of the metadata file's parent archive, if any.
+func addComponent(components Components, location, name string, content []byte) {
+	var componentId string
+	switch path.Base(name) {
+	case "pom.properties":
+		componentId = getMavenComponentId(content)
+	case "package.json":
+		componentId = getNpmComponentId(content)
+	default:
+		componentId = getPypiComponentId(content)
+	}
+	if componentId == "" {
+		log.Debug("Couldn't identify a component by '" + location + "'.")
+		return
+	}
+	if index := strings.LastIndex(location, archiveSeparator); index > 0 {
+		location = location[:index]
+	}
+	components.add(componentId, location)
+}
+
+func getMavenComponentId(content []byte) string {
+	properties := parseKeyValues(content, "=")
+	if properties["groupId"] == "" || properties["artifactId"] == "" || properties["version"] == "" {
+		return ""
+	}
+	return gavPackageTypeId + properties["groupId"] + ":" + properties["artifactId"] + ":" + properties["version"]
+}
+
+func getNpmComponentId(content []byte) string {
+	var packageJson struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if json.Unmarshal(content, &packageJson) != nil || packageJson.Name == "" || packageJson.Version == "" {
+		return ""
+	}
+	return npmPackageTypeId + packageJson.Name + ":" + packageJson.Version
+}
+
+func getPypiComponentId(content []byte) string {
+	// The headers of the core metadata end at the first empty line, which is followed by the description.
+	headers := parseKeyValues(bytes.SplitN(content, []byte("\n\n"), 2)[0], ":")
+	if headers["Name"] == "" || headers["Version"] == "" {
+		return ""
+	}
+	return pypiPackageTypeId + strings.ToLower(headers["Name"]) + ":" + headers["Version"]
+}
+
+// Parses 'key<separator>value' lines, ignoring empty lines and '#' comments. The first value of each key is kept.
+func parseKeyValues(content []byte, separator string) map[string]string {
+	values := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, separator)
+		key = strings.TrimSpace(key)
+		if _, exists := values[key]; found && !exists {
+			values[key] = strings.TrimSpace(value)
+		}
+	}
+	return values
+}
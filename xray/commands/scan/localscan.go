@@ -0,0 +1,220 @@
+package scan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+// LocalScanVulnerability is a vulnerable component found in the scanned paths.
+type LocalScanVulnerability struct {
+	xrayutils.Vulnerability
+	Locations []string `json:"locations"`
+}
+
+type localScanTableRow struct {
+	Severity      string `col-name:"Severity"`
+	Component     string `col-name:"Component"`
+	FixedVersions string `col-name:"Fixed Versions"`
+	Issue         string `col-name:"Issue"`
+	Cves          string `col-name:"CVEs"`
+	Locations     string `col-name:"Locations"`
+}
+
+// LocalScanCommand scans local directories and archives with Xray, without uploading them to Artifactory.
+// The paths are walked locally, including archives nested in other archives such as fat jars, and only the identities of the
+// components found in them are sent to Xray.
+type LocalScanCommand struct {
+	serverDetails *config.ServerDetails
+	paths         []string
+	outputFormat  format.OutputFormat
+	// If true, the command fails if vulnerabilities are found.
+	failOnVulnerabilities bool
+	// The target in Artifactory to upload the vulnerabilities to. If empty, they aren't uploaded.
+	resultsTarget      string
+	buildConfiguration *build.BuildConfiguration
+	vulnerabilities    []LocalScanVulnerability
+	// The graph of the found components, which is scanned by Xray.
+	graph *xrayUtils.GraphNode
+}
+
+func NewLocalScanCommand() *LocalScanCommand {
+	return &LocalScanCommand{outputFormat: format.Table}
+}
+
+func (lsc *LocalScanCommand) SetServerDetails(serverDetails *config.ServerDetails) *LocalScanCommand {
+	lsc.serverDetails = serverDetails
+	return lsc
+}
+
+// SetPaths sets the directories and archives to scan.
+func (lsc *LocalScanCommand) SetPaths(paths ...string) *LocalScanCommand {
+	lsc.paths = paths
+	return lsc
+}
+
+// SetOutputFormat sets the output format of the vulnerabilities - 'table', 'json', 'sarif' or 'cyclonedx'.
+func (lsc *LocalScanCommand) SetOutputFormat(outputFormat format.OutputFormat) *LocalScanCommand {
+	lsc.outputFormat = outputFormat
+	return lsc
+}
+
+func (lsc *LocalScanCommand) SetFailOnVulnerabilities(failOnVulnerabilities bool) *LocalScanCommand {
+	lsc.failOnVulnerabilities = failOnVulnerabilities
+	return lsc
+}
+
+// SetUploadResults sets the target in Artifactory, in the form of <repo>/<path>, to upload the report to, in the output format.
+// If the target ends with a slash, the report is uploaded into it as scan-results with the extension of the format, e.g. scan-results.sarif.
+func (lsc *LocalScanCommand) SetUploadResults(resultsTarget string) *LocalScanCommand {
+	lsc.resultsTarget = resultsTarget
+	return lsc
+}
+
+// SetBuildConfiguration sets the build, whose properties are set on the uploaded report, and to whose build-info the report is added.
+func (lsc *LocalScanCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *LocalScanCommand {
+	lsc.buildConfiguration = buildConfiguration
+	return lsc
+}
+
+// Vulnerabilities returns the vulnerable components found by the scan.
+func (lsc *LocalScanCommand) Vulnerabilities() []LocalScanVulnerability {
+	return lsc.vulnerabilities
+}
+
+func (lsc *LocalScanCommand) ServerDetails() (*config.ServerDetails, error) {
+	return lsc.serverDetails, nil
+}
+
+func (lsc *LocalScanCommand) CommandName() string {
+	return "xr_local_scan"
+}
+
+// IsReadOnly implements commands.ReadOnlyCommand. The command is read-only unless it uploads its results.
+func (lsc *LocalScanCommand) IsReadOnly() bool {
+	return lsc.resultsTarget == ""
+}
+
+func (lsc *LocalScanCommand) Run() error {
+	switch lsc.outputFormat {
+	case format.Table, format.Json, format.Sarif, format.CycloneDx:
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s, %s, %s, %s", lsc.outputFormat, format.Table, format.Json, format.Sarif, format.CycloneDx)
+	}
+	if len(lsc.paths) == 0 {
+		return errorutils.CheckErrorf("no paths to scan were provided")
+	}
+	components, err := FindComponents(lsc.paths...)
+	if err != nil {
+		return err
+	}
+	lsc.vulnerabilities, lsc.graph = nil, nil
+	if len(components) == 0 {
+		log.Info("No components were found in the scanned paths.")
+	} else {
+		lsc.graph = buildComponentsGraph(components)
+		scanResponse, err := lsc.scan(len(components))
+		if err != nil {
+			return err
+		}
+		lsc.vulnerabilities = getLocalScanVulnerabilities(scanResponse, components)
+	}
+	if err = lsc.printVulnerabilities(); err != nil {
+		return err
+	}
+	if lsc.resultsTarget != "" {
+		uploader := xrayutils.NewResultsUploader(lsc.serverDetails, lsc.resultsTarget).SetBuildConfiguration(lsc.buildConfiguration).SetOutputFormat(lsc.outputFormat)
+		if _, err = uploader.UploadResults("scan", lsc.getResults()); err != nil {
+			return err
+		}
+	}
+	if lsc.failOnVulnerabilities && len(lsc.vulnerabilities) > 0 {
+		return errorutils.CheckErrorf("found %d vulnerable components", len(lsc.vulnerabilities))
+	}
+	return nil
+}
+
+func (lsc *LocalScanCommand) scan(componentsCount int) (*services.ScanResponse, error) {
+	xrayManager, err := xrayutils.CreateXrayServiceManager(lsc.serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Scanning %d components with Xray...", componentsCount))
+	scanId, err := xrayManager.ScanGraph(services.XrayGraphScanParams{
+		DependenciesGraph:      lsc.graph,
+		ScanType:               services.Dependency,
+		ProjectKey:             config.GetProjectKey("", lsc.serverDetails),
+		IncludeVulnerabilities: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return xrayManager.GetScanGraphResults(scanId, true, false, false)
+}
+
+// The components are scanned as direct dependencies of a root node, since their location, rather than their dependants, is reported.
+func buildComponentsGraph(components Components) *xrayUtils.GraphNode {
+	root := &xrayUtils.GraphNode{Id: "root"}
+	for _, componentId := range components.Ids() {
+		root.Nodes = append(root.Nodes, &xrayUtils.GraphNode{Id: componentId, Parent: root})
+	}
+	return root
+}
+
+func getLocalScanVulnerabilities(scanResponse *services.ScanResponse, components Components) []LocalScanVulnerability {
+	return xrayutils.ConvertVulnerabilities(scanResponse, func(vulnerability xrayutils.Vulnerability, _ services.Component) LocalScanVulnerability {
+		return LocalScanVulnerability{Vulnerability: vulnerability, Locations: components[vulnerability.Component]}
+	})
+}
+
+func (lsc *LocalScanCommand) printVulnerabilities() error {
+	if lsc.outputFormat != format.Table {
+		content, _, err := xrayutils.MarshalResults(lsc.outputFormat, lsc.getResults())
+		if err != nil {
+			return err
+		}
+		log.Output(string(content))
+		return nil
+	}
+	if len(lsc.vulnerabilities) == 0 {
+		log.Info("No vulnerable components were found.")
+		return nil
+	}
+	var rows []localScanTableRow
+	for _, vulnerability := range lsc.vulnerabilities {
+		rows = append(rows, localScanTableRow{
+			Severity:      vulnerability.Severity,
+			Component:     vulnerability.Component,
+			FixedVersions: strings.Join(vulnerability.FixedVersions, ", "),
+			Issue:         vulnerability.Issue,
+			Cves:          strings.Join(vulnerability.Cves, ", "),
+			Locations:     strings.Join(vulnerability.Locations, "\n"),
+		})
+	}
+	return coreutils.PrintTable(rows, "Vulnerable Components", "", false)
+}
+
+// Returns the results of the scan, whose report is the list of the vulnerable components.
+func (lsc *LocalScanCommand) getResults() *xrayutils.Results {
+	results := &xrayutils.Results{Report: lsc.vulnerabilities, Locations: map[string][]string{}}
+	if lsc.vulnerabilities == nil {
+		results.Report = []LocalScanVulnerability{}
+	}
+	if lsc.graph != nil {
+		results.Graphs = []*xrayUtils.GraphNode{lsc.graph}
+	}
+	for _, vulnerability := range lsc.vulnerabilities {
+		results.Vulnerabilities = append(results.Vulnerabilities, vulnerability.Vulnerability)
+		results.Locations[vulnerability.Component] = vulnerability.Locations
+	}
+	return results
+}
@@ -0,0 +1,188 @@
+package scan
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createZip(t *testing.T, files map[string][]byte) []byte {
+	buffer := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buffer)
+	for name, content := range files {
+		writer, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = writer.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+	return buffer.Bytes()
+}
+
+func createTgz(t *testing.T, files map[string][]byte) []byte {
+	buffer := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tarWriter.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return buffer.Bytes()
+}
+
+func pomProperties(groupId, artifactId, version string) []byte {
+	return []byte("#Generated by Maven\ngroupId=" + groupId + "\nartifactId=" + artifactId + "\nversion=" + version + "\n")
+}
+
+// Creates a directory with a fat jar nested in a zip, a wheel and an npm tarball.
+func createScanDir(t *testing.T) string {
+	scanDir := t.TempDir()
+	libJar := createZip(t, map[string][]byte{"META-INF/maven/commons-io/commons-io/pom.properties": pomProperties("commons-io", "commons-io", "2.6")})
+	fatJar := createZip(t, map[string][]byte{
+		"META-INF/maven/com.acme/app/pom.properties": pomProperties("com.acme", "app", "1.0.0"),
+		"BOOT-INF/lib/commons-io-2.6.jar":            libJar,
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(scanDir, "dist.zip"), createZip(t, map[string][]byte{"dist/app.jar": fatJar, "README.md": []byte("readme")}), 0644))
+	wheel := createZip(t, map[string][]byte{"PyYAML-5.3.dist-info/METADATA": []byte("Metadata-Version: 2.1\nName: PyYAML\nVersion: 5.3\n\nVersion: 0.0\n")})
+	require.NoError(t, os.MkdirAll(filepath.Join(scanDir, "python"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(scanDir, "python", "PyYAML-5.3-py3-none-any.whl"), wheel, 0644))
+	tarball := createTgz(t, map[string][]byte{"package/package.json": []byte(`{"name":"lodash","version":"4.17.20"}`)})
+	require.NoError(t, os.WriteFile(filepath.Join(scanDir, "lodash-4.17.20.tgz"), tarball, 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(scanDir, "node_modules", "@types", "node"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(scanDir, "node_modules", "@types", "node", "package.json"), []byte(`{"name":"@types/node","version":"20.1.0"}`), 0644))
+	// The manifest of the project isn't an installed package.
+	require.NoError(t, os.WriteFile(filepath.Join(scanDir, "package.json"), []byte(`{"name":"my-app","version":"1.0.0"}`), 0644))
+	return scanDir
+}
+
+func TestFindComponents(t *testing.T) {
+	scanDir := createScanDir(t)
+	components, err := FindComponents(scanDir)
+	require.NoError(t, err)
+	zipPath := filepath.ToSlash(filepath.Join(scanDir, "dist.zip"))
+	assert.Equal(t, Components{
+		"gav://com.acme:app:1.0.0":        {zipPath + "!/dist/app.jar"},
+		"gav://commons-io:commons-io:2.6": {zipPath + "!/dist/app.jar!/BOOT-INF/lib/commons-io-2.6.jar"},
+		"pypi://pyyaml:5.3":               {filepath.ToSlash(filepath.Join(scanDir, "python", "PyYAML-5.3-py3-none-any.whl"))},
+		"npm://lodash:4.17.20":            {filepath.ToSlash(filepath.Join(scanDir, "lodash-4.17.20.tgz"))},
+		"npm://@types/node:20.1.0":        {filepath.ToSlash(filepath.Join(scanDir, "node_modules", "@types", "node", "package.json"))},
+	}, components)
+
+	// An archive can be scanned directly.
+	components, err = FindComponents(filepath.Join(scanDir, "dist.zip"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gav://com.acme:app:1.0.0", "gav://commons-io:commons-io:2.6"}, components.Ids())
+}
+
+// Serves the graph scan of the scan directory. Returns false if the request isn't a graph scan request.
+func serveGraphScan(t *testing.T, w http.ResponseWriter, r *http.Request) bool {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/xray/api/v1/scan/graph":
+		var graph xrayUtils.GraphNode
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&graph))
+		assert.Len(t, graph.Nodes, 5)
+		_, err := w.Write([]byte(`{"scan_id":"scan-1"}`))
+		assert.NoError(t, err)
+	case r.Method == http.MethodGet && r.URL.Path == "/xray/api/v1/scan/graph/scan-1":
+		_, err := w.Write([]byte(`{"scan_id":"scan-1","vulnerabilities":[
+			{"issue_id":"XRAY-1","summary":"Uncontrolled resource consumption","severity":"Medium","cves":[{"cve":"CVE-2021-29425"}],
+			 "components":{"gav://commons-io:commons-io:2.6":{"fixed_versions":["[2.7]"]}}}]}`))
+		assert.NoError(t, err)
+	default:
+		return false
+	}
+	return true
+}
+
+func TestLocalScan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !serveGraphScan(t, w, r) {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	scanDir := createScanDir(t)
+	scanCmd := NewLocalScanCommand().SetServerDetails(&config.ServerDetails{XrayUrl: server.URL + "/xray/"}).SetPaths(scanDir)
+	assert.NoError(t, scanCmd.Run())
+	assert.Equal(t, []LocalScanVulnerability{{
+		Vulnerability: xrayutils.Vulnerability{
+			Severity:      "Medium",
+			Component:     "gav://commons-io:commons-io:2.6",
+			FixedVersions: []string{"[2.7]"},
+			Issue:         "XRAY-1",
+			Cves:          []string{"CVE-2021-29425"},
+			Summary:       "Uncontrolled resource consumption",
+		},
+		Locations: []string{filepath.ToSlash(filepath.Join(scanDir, "dist.zip")) + "!/dist/app.jar!/BOOT-INF/lib/commons-io-2.6.jar"},
+	}}, scanCmd.Vulnerabilities())
+
+	assert.EqualError(t, scanCmd.SetFailOnVulnerabilities(true).SetOutputFormat(format.Json).Run(), "found 1 vulnerable components")
+	assert.ErrorContains(t, scanCmd.SetOutputFormat(format.Csv).Run(), "unsupported output format 'csv'")
+}
+
+func TestLocalScanUploadResults(t *testing.T) {
+	testsutils.SetEnvAndAssert(t, coreutils.HomeDir, t.TempDir())
+	// The partial build-info files are stored in the temp dir of the CLI, which is shared with previous runs.
+	require.NoError(t, build.RemoveBuildDir("app", "7", ""))
+	defer func() {
+		assert.NoError(t, build.RemoveBuildDir("app", "7", ""))
+	}()
+	var uploadPath string
+	var uploaded []LocalScanVulnerability
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveGraphScan(t, w, r) {
+			return
+		}
+		if r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/artifactory/") {
+			uploadPath = r.URL.Path
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&uploaded))
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	scanCmd := NewLocalScanCommand().SetPaths(createScanDir(t)).SetUploadResults("security/app/").
+		SetBuildConfiguration(build.NewBuildConfiguration("app", "7", "", "")).
+		SetServerDetails(&config.ServerDetails{XrayUrl: server.URL + "/xray/", ArtifactoryUrl: server.URL + "/artifactory/"})
+	assert.NoError(t, scanCmd.Run())
+	assert.Equal(t, scanCmd.Vulnerabilities(), uploaded)
+	// The report is stamped with the build properties and the report type.
+	path, props, _ := strings.Cut(uploadPath, ";")
+	assert.Equal(t, "/artifactory/security/app/scan-results.json", path)
+	assert.Contains(t, props, "build.name=app")
+	assert.Contains(t, props, "build.number=7")
+	assert.Contains(t, props, xrayutils.ResultsReportTypeProperty+"=scan")
+
+	// The report is added to the build-info.
+	partials, err := build.ReadPartialBuildInfoFiles("app", "7", "")
+	require.NoError(t, err)
+	require.Len(t, partials, 1)
+	assert.Equal(t, xrayutils.ResultsModuleId, partials[0].ModuleId)
+	require.Len(t, partials[0].Artifacts, 1)
+	assert.Equal(t, "scan-results.json", partials[0].Artifacts[0].Name)
+	assert.Equal(t, "app/scan-results.json", partials[0].Artifacts[0].Path)
+}